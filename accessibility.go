@@ -0,0 +1,126 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Accessibility describes a chart for screen readers. It is written to svg output as the `aria-label`
+// of a `role="img"` root element, and as its `<title>` and `<desc>`, and to pdf output as the document title
+// and subject; other renderers ignore it.
+// Fields left empty are generated from the chart title and a summary of its values.
+type Accessibility struct {
+	// Label is the aria-label; it defaults to the title.
+	Label string
+	// Title is the svg title; it defaults to the chart title.
+	Title string
+	// Description is the svg description; it defaults to a summary of the series or values.
+	Description string
+	// Hide omits the accessibility attributes and elements.
+	Hide bool
+}
+
+// withDefaults returns the accessibility with the empty fields filled in.
+func (a Accessibility) withDefaults(title string, description func() string) Accessibility {
+	if len(a.Title) == 0 {
+		a.Title = title
+	}
+	if len(a.Title) == 0 {
+		a.Title = "chart"
+	}
+	if len(a.Label) == 0 {
+		a.Label = a.Title
+	}
+	if len(a.Description) == 0 {
+		a.Description = description()
+	}
+	return a
+}
+
+// accessibleRenderer is a renderer that can describe its output for screen readers.
+type accessibleRenderer interface {
+	SetAccessibility(a Accessibility)
+}
+
+// setAccessibility describes the output of the renderer, if the renderer supports it.
+func setAccessibility(r Renderer, a Accessibility) {
+	if a.Hide {
+		return
+	}
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if ar, isAccessible := r.(accessibleRenderer); isAccessible {
+		ar.SetAccessibility(a)
+	}
+}
+
+// describeSeries summarizes the series of a chart, i.e. "Chart with 2 series: cpu, 100 values from 0.10 to 0.90; ...".
+func (c Chart) describeSeries() string {
+	var summaries []string
+	for index, s := range c.Series {
+		if !(s.GetStyle().IsZero() || s.GetStyle().Show) || !isLegendSeries(s) {
+			continue
+		}
+		name := s.GetName()
+		if len(name) == 0 {
+			name = fmt.Sprintf("series %d", index+1)
+		}
+		vp, isValuesProvider := s.(ValuesProvider)
+		if !isValuesProvider {
+			summaries = append(summaries, name)
+			continue
+		}
+		var count int
+		min, max := math.Inf(1), math.Inf(-1)
+		for i := 0; i < vp.Len(); i++ {
+			_, vy := vp.GetValues(i)
+			if math.IsNaN(vy) || math.IsInf(vy, 0) {
+				continue
+			}
+			count++
+			min, max = math.Min(min, vy), math.Max(max, vy)
+		}
+		if count == 0 {
+			summaries = append(summaries, fmt.Sprintf("%s, no values", name))
+			continue
+		}
+		summaries = append(summaries, fmt.Sprintf("%s, %d values from %s to %s", name, count, FloatValueFormatter(min), FloatValueFormatter(max)))
+	}
+	return fmt.Sprintf("Chart with %d series: %s.", len(summaries), strings.Join(summaries, "; "))
+}
+
+// describeValues summarizes labeled values, i.e. "Bar chart of 2 values: a 1.00, b 2.00.".
+func describeValues(kind string, values []Value) string {
+	return fmt.Sprintf("%s of %d values: %s.", kind, len(values), listValues(values))
+}
+
+// listValues lists labeled values, i.e. "a 1.00, b 2.00".
+func listValues(values []Value) string {
+	summaries := make([]string, len(values))
+	for index, v := range values {
+		summaries[index] = strings.TrimSpace(v.Label + " " + FloatValueFormatter(v.Value))
+	}
+	return strings.Join(summaries, ", ")
+}
+
+// writeAccessibility writes the svg root element of `contents` with the accessibility attributes and elements.
+func writeAccessibility(buffer *bytes.Buffer, contents []byte, a Accessibility) {
+	end := bytes.IndexByte(contents, '>')
+	if end < 0 {
+		buffer.Write(contents)
+		return
+	}
+	buffer.Write(contents[:end])
+	buffer.WriteString(` role="img" aria-label="`)
+	xml.EscapeText(buffer, []byte(a.Label))
+	buffer.WriteString(`"><title>`)
+	xml.EscapeText(buffer, []byte(a.Title))
+	buffer.WriteString(`</title><desc>`)
+	xml.EscapeText(buffer, []byte(a.Description))
+	buffer.WriteString(`</desc>`)
+	buffer.Write(contents[end+1:])
+}
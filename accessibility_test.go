@@ -0,0 +1,63 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestChartAccessibleSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Title: "cpu & memory",
+		Series: []Series{
+			ContinuousSeries{Name: "cpu", XValues: []float64{1, 2, 3}, YValues: []float64{0.5, math.NaN(), 0.25}},
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+			AnnotationSeries{Annotations: []Value2{{XValue: 1, YValue: 1, Label: "note"}}},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	svg := buffer.String()
+	assert.True(strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	assert.True(strings.Contains(svg, `role="img" aria-label="cpu &amp; memory"><title>cpu &amp; memory</title>`), svg[:200])
+	assert.True(strings.Contains(svg, `<desc>Chart with 2 series: cpu, 2 values from 0.25 to 0.50; series 2, 3 values from 1.00 to 3.00.</desc>`))
+
+	c.Accessibility = Accessibility{Label: "label", Description: "description"}
+	buffer.Reset()
+	assert.Nil(c.Render(SVG, buffer))
+	svg = buffer.String()
+	assert.True(strings.Contains(svg, `aria-label="label"><title>cpu &amp; memory</title><desc>description</desc>`))
+
+	c.Accessibility = Accessibility{Hide: true}
+	buffer.Reset()
+	assert.Nil(c.Render(SVG, buffer))
+	assert.False(strings.Contains(buffer.String(), "aria-label"))
+}
+
+func TestBarAndPieChartAccessibleSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	values := []Value{{Label: "a", Value: 1}, {Label: "b", Value: 2}}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(BarChart{Bars: values}.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), `aria-label="chart"><title>chart</title><desc>Bar chart of 2 values: a 1.00, b 2.00.</desc>`))
+
+	buffer.Reset()
+	assert.Nil(PieChart{Title: "share", Values: values}.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), `<desc>Pie chart of 2 values: a 1.00, b 2.00.</desc>`))
+
+	buffer.Reset()
+	assert.Nil(StackedBarChart{Bars: []StackedBar{{Name: "x", Values: values}}}.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), `<desc>Stacked bar chart of 1 bars: x 3.00 (a 1.00, b 2.00).</desc>`))
+
+	// raster output is unaffected.
+	buffer.Reset()
+	assert.Nil(BarChart{Bars: values}.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
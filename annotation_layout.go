@@ -0,0 +1,122 @@
+package chart
+
+import (
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// annotationLayoutDirections are the places a label is tried at around its value, as the anchor of the label
+// and the direction it is moved in: right, above, below, left and then the diagonals.
+var annotationLayoutDirections = []struct {
+	anchor AnnotationAnchor
+	dx, dy int
+}{
+	{AnnotationAnchorLeft, 1, 0},
+	{AnnotationAnchorBottom, 0, -1},
+	{AnnotationAnchorTop, 0, 1},
+	{AnnotationAnchorRight, -1, 0},
+	{AnnotationAnchorBottomLeft, 1, -1},
+	{AnnotationAnchorTopLeft, 1, 1},
+	{AnnotationAnchorBottomRight, -1, -1},
+	{AnnotationAnchorTopRight, -1, 1},
+}
+
+// annotationLayoutSteps is the number of distances from its value a label is tried at, in steps of the label height.
+const annotationLayoutSteps = 4
+
+// layoutAnnotations places the labels of the annotations so they don't overlap each other and stay within the canvas.
+//
+// Annotations with an anchor or offset keep their place. The rest are placed greedily in order: at their callout
+// if it is free, or else at the first free place around their value, moved into the canvas and with an arrow
+// back to the value. A label with no free place goes where it overlaps the other labels the least.
+func (as AnnotationSeries) layoutAnnotations(r Renderer, canvasBox Box, xrange, yrange Range, seriesStyle Style) []Value2 {
+	defer r.ResetStyle()
+
+	laidOut := make([]Value2, len(as.Annotations))
+	copy(laidOut, as.Annotations)
+
+	placed := make([]Box, 0, len(laidOut))
+	for _, a := range laidOut {
+		if !isCallout(a) {
+			lx := canvasBox.Left + xrange.Translate(a.XValue)
+			ly := canvasBox.Bottom - yrange.Translate(a.YValue)
+			placed = append(placed, annotationLabelBox(r, a.Style.InheritFrom(seriesStyle), lx+a.Offset.X, ly+a.Offset.Y, a.Anchor, a.Label))
+		}
+	}
+
+	for index, a := range laidOut {
+		if !isCallout(a) {
+			continue
+		}
+		style := a.Style.InheritFrom(seriesStyle)
+		lx := canvasBox.Left + xrange.Translate(a.XValue)
+		ly := canvasBox.Bottom - yrange.Translate(a.YValue)
+
+		best, bestBox := a, Draw.MeasureAnnotation(r, canvasBox, style, lx, ly, a.Label)
+		bestOverlap := math.MaxInt32
+		if isWithin(bestBox, canvasBox) {
+			bestOverlap = overlapArea(bestBox, placed)
+		}
+
+		step := annotationLabelBox(r, style, lx, ly, AnnotationAnchorCenter, a.Label).Height()
+		for distance := 1; distance <= annotationLayoutSteps && bestOverlap > 0; distance++ {
+			for _, d := range annotationLayoutDirections {
+				candidate := a
+				candidate.Anchor = d.anchor
+				candidate.Offset = Point{X: d.dx * distance * step, Y: d.dy * distance * step}
+				candidate.Arrow = true
+
+				box := annotationLabelBox(r, style, lx+candidate.Offset.X, ly+candidate.Offset.Y, d.anchor, a.Label)
+				dx, dy := shiftWithin(box, canvasBox)
+				candidate.Offset.X, candidate.Offset.Y = candidate.Offset.X+dx, candidate.Offset.Y+dy
+				box = box.Shift(dx, dy)
+
+				if overlap := overlapArea(box, placed); overlap < bestOverlap {
+					best, bestBox, bestOverlap = candidate, box, overlap
+					if overlap == 0 {
+						break
+					}
+				}
+			}
+		}
+		laidOut[index] = best
+		placed = append(placed, bestBox)
+	}
+	return laidOut
+}
+
+// isWithin returns if a box is entirely within bounds.
+func isWithin(b, bounds Box) bool {
+	return b.Left >= bounds.Left && b.Right <= bounds.Right && b.Top >= bounds.Top && b.Bottom <= bounds.Bottom
+}
+
+// shiftWithin returns how far to shift a box to move it within bounds; a box larger than the bounds
+// is aligned to their top left.
+func shiftWithin(b, bounds Box) (dx, dy int) {
+	if b.Right > bounds.Right {
+		dx = bounds.Right - b.Right
+	}
+	if b.Left+dx < bounds.Left {
+		dx = bounds.Left - b.Left
+	}
+	if b.Bottom > bounds.Bottom {
+		dy = bounds.Bottom - b.Bottom
+	}
+	if b.Top+dy < bounds.Top {
+		dy = bounds.Top - b.Top
+	}
+	return
+}
+
+// overlapArea returns the area a box shares with a set of boxes.
+func overlapArea(b Box, others []Box) (area int) {
+	for _, o := range others {
+		width := util.Math.MinInt(b.Right, o.Right) - util.Math.MaxInt(b.Left, o.Left)
+		height := util.Math.MinInt(b.Bottom, o.Bottom) - util.Math.MaxInt(b.Top, o.Top)
+		if width > 0 && height > 0 {
+			area += width * height
+		}
+	}
+	return
+}
@@ -7,12 +7,51 @@ import (
 	util "github.com/wcharczuk/go-chart/util"
 )
 
+// AnnotationAnchor is the side or corner of an annotation label that is placed at its value.
+type AnnotationAnchor int
+
+const (
+	// AnnotationAnchorUnset draws the label as a callout pointing left at the value, or anchors it
+	// by its left side if the annotation has an offset.
+	AnnotationAnchorUnset AnnotationAnchor = 0
+	// AnnotationAnchorLeft places the middle of the left side of the label at the value.
+	AnnotationAnchorLeft AnnotationAnchor = 1
+	// AnnotationAnchorRight places the middle of the right side of the label at the value.
+	AnnotationAnchorRight AnnotationAnchor = 2
+	// AnnotationAnchorTop places the middle of the top of the label at the value.
+	AnnotationAnchorTop AnnotationAnchor = 3
+	// AnnotationAnchorBottom places the middle of the bottom of the label at the value.
+	AnnotationAnchorBottom AnnotationAnchor = 4
+	// AnnotationAnchorTopLeft places the top left corner of the label at the value.
+	AnnotationAnchorTopLeft AnnotationAnchor = 5
+	// AnnotationAnchorTopRight places the top right corner of the label at the value.
+	AnnotationAnchorTopRight AnnotationAnchor = 6
+	// AnnotationAnchorBottomLeft places the bottom left corner of the label at the value.
+	AnnotationAnchorBottomLeft AnnotationAnchor = 7
+	// AnnotationAnchorBottomRight places the bottom right corner of the label at the value.
+	AnnotationAnchorBottomRight AnnotationAnchor = 8
+	// AnnotationAnchorCenter places the center of the label at the value.
+	AnnotationAnchorCenter AnnotationAnchor = 9
+)
+
 // AnnotationSeries is a series of labels on the chart.
+//
+// By default a label is a callout pointing at its value; an annotation with an `Anchor` or `Offset` is drawn
+// as a box whose anchor is placed at the value moved by the offset, with an arrow back to the value if it has `Arrow` set.
+// Callouts that would overlap other labels or leave the canvas are moved to a free place nearby (see `NoLayout`).
 type AnnotationSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	Annotations []Value2
+
+	// NoLayout draws the callouts where their values are, even if they overlap each other or leave the canvas.
+	NoLayout bool
+
+	// Clip clips the labels to the canvas; they are drawn over the edges of the canvas by default,
+	// even if the chart clips its series (see `Chart.ClipSeries`).
+	Clip bool
 }
 
 // GetName returns the name of the time series.
@@ -30,6 +69,11 @@ func (as AnnotationSeries) GetYAxis() YAxisType {
 	return as.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (as AnnotationSeries) GetXAxis() XAxisType {
+	return as.XAxis
+}
+
 func (as AnnotationSeries) annotationStyleDefaults(defaults Style) Style {
 	return Style{
 		FontColor:   DefaultTextColor,
@@ -56,7 +100,12 @@ func (as AnnotationSeries) Measure(r Renderer, canvasBox Box, xrange, yrange Ran
 			style := a.Style.InheritFrom(seriesStyle)
 			lx := canvasBox.Left + xrange.Translate(a.XValue)
 			ly := canvasBox.Bottom - yrange.Translate(a.YValue)
-			ab := Draw.MeasureAnnotation(r, canvasBox, style, lx, ly, a.Label)
+			var ab Box
+			if isCallout(a) {
+				ab = Draw.MeasureAnnotation(r, canvasBox, style, lx, ly, a.Label)
+			} else {
+				ab = Draw.MeasureAnchoredAnnotation(r, style, lx, ly, a)
+			}
 			box.Top = util.Math.MinInt(box.Top, ab.Top)
 			box.Left = util.Math.MinInt(box.Left, ab.Left)
 			box.Right = util.Math.MaxInt(box.Right, ab.Right)
@@ -70,15 +119,38 @@ func (as AnnotationSeries) Measure(r Renderer, canvasBox Box, xrange, yrange Ran
 func (as AnnotationSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
 	if as.Style.IsZero() || as.Style.Show {
 		seriesStyle := as.Style.InheritFrom(as.annotationStyleDefaults(defaults))
-		for _, a := range as.Annotations {
+		annotations := as.Annotations
+		if !as.NoLayout {
+			annotations = as.layoutAnnotations(r, canvasBox, xrange, yrange, seriesStyle)
+		}
+		for _, a := range annotations {
 			style := a.Style.InheritFrom(seriesStyle)
 			lx := canvasBox.Left + xrange.Translate(a.XValue)
 			ly := canvasBox.Bottom - yrange.Translate(a.YValue)
-			Draw.Annotation(r, canvasBox, style, lx, ly, a.Label)
+			if isCallout(a) {
+				Draw.Annotation(r, canvasBox, style, lx, ly, a.Label)
+			} else {
+				Draw.AnchoredAnnotation(r, style, lx, ly, a)
+			}
 		}
 	}
 }
 
+// isCallout returns if an annotation is drawn as a callout pointing at its value.
+func isCallout(a Value2) bool {
+	return a.Anchor == AnnotationAnchorUnset && a.Offset == Point{}
+}
+
+// IsClipped implements `ClipProvider`; labels are clipped to the canvas only if the series has `Clip` set.
+func (as AnnotationSeries) IsClipped() bool {
+	return as.Clip
+}
+
+// IsLegendExcluded implements `LegendExcluder`; annotations are never listed in legends.
+func (as AnnotationSeries) IsLegendExcluded() bool {
+	return true
+}
+
 // Validate validates the series.
 func (as AnnotationSeries) Validate() error {
 	if len(as.Annotations) == 0 {
@@ -1,6 +1,7 @@
 package chart
 
 import (
+	"bytes"
 	"image/color"
 	"testing"
 
@@ -117,3 +118,114 @@ func TestAnnotationSeriesRender(t *testing.T) {
 	assert.Equal(0, converted.G)
 	assert.Equal(0, converted.B)
 }
+
+func TestAnnotationLabelBoxAnchors(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(200, 200)
+	assert.Nil(err)
+	style := Style{Font: f, FontSize: 10}
+
+	left := annotationLabelBox(r, style, 100, 100, AnnotationAnchorLeft, "label")
+	assert.Equal(100, left.Left)
+	assert.True(left.Top < 100 && left.Bottom > 100)
+
+	topRight := annotationLabelBox(r, style, 100, 100, AnnotationAnchorTopRight, "label")
+	assert.Equal(100, topRight.Right)
+	assert.Equal(100, topRight.Top)
+
+	bottom := annotationLabelBox(r, style, 100, 100, AnnotationAnchorBottom, "label")
+	assert.Equal(100, bottom.Bottom)
+	assert.True(bottom.Left < 100 && bottom.Right > 100)
+}
+
+func TestAnnotationSeriesMeasureOffsetArrow(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(200, 200)
+	assert.Nil(err)
+
+	xrange := &ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	yrange := &ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	cb := Box{Top: 0, Left: 0, Right: 100, Bottom: 100}
+	as := AnnotationSeries{
+		Annotations: []Value2{
+			{XValue: 5, YValue: 5, Label: "peak", Anchor: AnnotationAnchorBottom, Offset: Point{Y: -30}, Arrow: true},
+		},
+	}
+	box := as.Measure(r, cb, xrange, yrange, Style{Font: f, FontSize: 10})
+	// the label is above the point, and the arrow reaches down to it.
+	assert.True(box.Top < 20, box.String())
+	assert.True(box.Bottom >= 50, box.String())
+
+	as.Render(r, cb, xrange, yrange, Style{Font: f, FontSize: 10, StrokeColor: ColorBlack, StrokeWidth: 1})
+	assert.True(isCallout(Value2{}))
+	assert.False(isCallout(Value2{Offset: Point{X: 1}}))
+}
+
+func TestAnnotationSeriesLayout(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(400, 400)
+	assert.Nil(err)
+
+	xrange := &ContinuousRange{Min: 0, Max: 10, Domain: 400}
+	yrange := &ContinuousRange{Min: 0, Max: 10, Domain: 400}
+	cb := Box{Top: 0, Left: 0, Right: 400, Bottom: 400}
+	as := AnnotationSeries{
+		Annotations: []Value2{
+			{XValue: 5, YValue: 5, Label: "first"},
+			{XValue: 5.1, YValue: 5, Label: "second"},
+			{XValue: 5.2, YValue: 5.1, Label: "third"},
+			{XValue: 10, YValue: 10, Label: "corner"},
+		},
+	}
+	style := as.annotationStyleDefaults(Style{Font: f})
+	laidOut := as.layoutAnnotations(r, cb, xrange, yrange, style)
+	assert.Len(laidOut, 4)
+	// the first callout stays where it is; the rest move with arrows.
+	assert.True(isCallout(laidOut[0]))
+	assert.True(laidOut[1].Arrow)
+
+	var boxes []Box
+	for _, a := range laidOut {
+		lx, ly := xrange.Translate(a.XValue), 400-yrange.Translate(a.YValue)
+		var box Box
+		if isCallout(a) {
+			box = Draw.MeasureAnnotation(r, cb, style, lx, ly, a.Label)
+		} else {
+			box = annotationLabelBox(r, style, lx+a.Offset.X, ly+a.Offset.Y, a.Anchor, a.Label)
+		}
+		assert.True(isWithin(box, cb), box.String())
+		assert.Zero(overlapArea(box, boxes), box.String())
+		boxes = append(boxes, box)
+	}
+}
+
+func TestAnnotationSeriesNoLayout(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, overlapArea(Box{Top: 0, Left: 0, Right: 10, Bottom: 10}, []Box{{Top: 10, Left: 0, Right: 10, Bottom: 20}}))
+	assert.Equal(25, overlapArea(Box{Top: 0, Left: 0, Right: 10, Bottom: 10}, []Box{{Top: 5, Left: 5, Right: 20, Bottom: 20}}))
+
+	dx, dy := shiftWithin(Box{Top: -5, Left: 90, Right: 110, Bottom: 5}, Box{Right: 100, Bottom: 100})
+	assert.Equal(-10, dx)
+	assert.Equal(5, dy)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{0, 1}, YValues: []float64{0, 1}},
+			AnnotationSeries{NoLayout: true, Annotations: []Value2{{XValue: 0.5, YValue: 0.5, Label: "a"}, {XValue: 0.5, YValue: 0.5, Label: "b"}}},
+		},
+		Font: f,
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
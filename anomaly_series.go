@@ -0,0 +1,247 @@
+package chart
+
+import (
+	"math"
+	"sort"
+)
+
+// AnomalyDetector returns which values of a series are anomalous, by their indices.
+type AnomalyDetector func(vs ValuesProvider) []bool
+
+// ZScoreAnomalies flags values more than a number of standard deviations from the mean of the trailing window
+// of a period of values before them; the period defaults to `DefaultRollingPeriod` and the threshold to
+// `DefaultAnomalyZScore`. Values before the first full window, and values after windows without spread, aren't flagged.
+func ZScoreAnomalies(period int, threshold float64) AnomalyDetector {
+	period = getRollingPeriod(period)
+	if threshold <= 0 {
+		threshold = DefaultAnomalyZScore
+	}
+	return func(vs ValuesProvider) []bool {
+		flags := make([]bool, vs.Len())
+		window := make([]float64, 0, period)
+		for index := range flags {
+			_, y := vs.GetValues(index)
+			if math.IsNaN(y) || math.IsInf(y, 0) {
+				continue
+			}
+			if len(window) == period {
+				var mean, variance float64
+				for _, value := range window {
+					mean += value
+				}
+				mean /= float64(period)
+				for _, value := range window {
+					variance += (value - mean) * (value - mean)
+				}
+				deviation := math.Sqrt(variance / float64(period))
+				flags[index] = deviation > 0 && math.Abs(y-mean) > threshold*deviation
+				window = append(window[:0], window[1:]...)
+			}
+			window = append(window, y)
+		}
+		return flags
+	}
+}
+
+// IQRAnomalies flags values more than a number of interquartile ranges below the first quartile or above the third
+// quartile of all the values, i.e. the outliers of a box plot; the number defaults to `DefaultBoxPlotWhiskerRange`.
+func IQRAnomalies(ranges float64) AnomalyDetector {
+	if ranges <= 0 {
+		ranges = DefaultBoxPlotWhiskerRange
+	}
+	return func(vs ValuesProvider) []bool {
+		flags := make([]bool, vs.Len())
+		var sorted []float64
+		for index := range flags {
+			if _, y := vs.GetValues(index); !math.IsNaN(y) && !math.IsInf(y, 0) {
+				sorted = append(sorted, y)
+			}
+		}
+		if len(sorted) == 0 {
+			return flags
+		}
+		sort.Float64s(sorted)
+		q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+		reach := ranges * (q3 - q1)
+		for index := range flags {
+			_, y := vs.GetValues(index)
+			flags[index] = y < q1-reach || y > q3+reach
+		}
+		return flags
+	}
+}
+
+// AnomalyFunc flags the values a callback returns true for.
+func AnomalyFunc(isAnomaly func(index int, x, y float64) bool) AnomalyDetector {
+	return func(vs ValuesProvider) []bool {
+		flags := make([]bool, vs.Len())
+		for index := range flags {
+			x, y := vs.GetValues(index)
+			flags[index] = isAnomaly(index, x, y)
+		}
+		return flags
+	}
+}
+
+// AnomalySeries draws the line of an inner series with the values a detector flags as anomalous marked,
+// and, if its band style is shown, the runs of anomalous values shaded across the canvas behind it.
+type AnomalySeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	InnerSeries ValuesProvider
+	// Detector flags the anomalous values; it defaults to `ZScoreAnomalies` with the default period and threshold.
+	Detector AnomalyDetector
+
+	// AnomalyStyle is the style of the markers, whose size is the dot width; they are red by default.
+	AnomalyStyle  Style
+	AnomalyMarker Marker
+	// BandStyle is the style of the bands behind runs of anomalous values, which reach half way to the values
+	// on either side; they are drawn if it is shown, filled with a translucent marker color by default.
+	BandStyle Style
+}
+
+// GetName returns the name of the series.
+func (as AnomalySeries) GetName() string {
+	return as.Name
+}
+
+// GetStyle returns the line style.
+func (as AnomalySeries) GetStyle() Style {
+	return as.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (as AnomalySeries) GetYAxis() YAxisType {
+	return as.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (as AnomalySeries) GetXAxis() XAxisType {
+	return as.XAxis
+}
+
+// GetDetector returns the detector or a default.
+func (as AnomalySeries) GetDetector() AnomalyDetector {
+	if as.Detector == nil {
+		return ZScoreAnomalies(0, 0)
+	}
+	return as.Detector
+}
+
+// GetAnomalyMarker returns the anomaly marker or a circle.
+func (as AnomalySeries) GetAnomalyMarker() Marker {
+	if as.AnomalyMarker != nil {
+		return as.AnomalyMarker
+	}
+	return MarkerCircle
+}
+
+// GetAnomalies returns the indices of the anomalous values.
+func (as AnomalySeries) GetAnomalies() (indices []int) {
+	for index, isAnomaly := range as.GetDetector()(as.InnerSeries) {
+		if isAnomaly {
+			indices = append(indices, index)
+		}
+	}
+	return
+}
+
+// Len returns the number of values in the series.
+func (as AnomalySeries) Len() int {
+	return as.InnerSeries.Len()
+}
+
+// GetValues gets the x,y values at a given index.
+func (as AnomalySeries) GetValues(index int) (float64, float64) {
+	return as.InnerSeries.GetValues(index)
+}
+
+// GetLastValues gets the last x,y values.
+func (as AnomalySeries) GetLastValues() (float64, float64) {
+	return as.InnerSeries.GetValues(as.InnerSeries.Len() - 1)
+}
+
+// GetValueFormatters returns the value formatters of the inner series, if it has them.
+func (as AnomalySeries) GetValueFormatters() (x, y ValueFormatter) {
+	if vfp, isValueFormatterProvider := as.InnerSeries.(ValueFormatterProvider); isValueFormatterProvider {
+		return vfp.GetValueFormatters()
+	}
+	return FloatValueFormatter, FloatValueFormatter
+}
+
+// Render renders the series.
+func (as AnomalySeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := as.Style.InheritFrom(defaults)
+	anomalyStyle := as.AnomalyStyle.InheritFrom(Style{
+		StrokeColor: ColorRed,
+		StrokeWidth: style.GetStrokeWidth(DefaultSeriesLineWidth),
+		FillColor:   ColorRed,
+		DotWidth:    DefaultAnomalyMarkerSize,
+	})
+	flags := as.GetDetector()(as.InnerSeries)
+
+	if as.BandStyle.Show {
+		bandStyle := as.BandStyle.InheritFrom(Style{FillColor: anomalyStyle.FillColor.WithAlpha(48)})
+		for _, band := range as.bands(flags) {
+			left, right := canvasBox.Left+xrange.Translate(band[0]), canvasBox.Left+xrange.Translate(band[1])
+			if left > right {
+				left, right = right, left
+			}
+			Draw.Box(r, Box{Top: canvasBox.Top, Left: left, Right: right, Bottom: canvasBox.Bottom}, bandStyle)
+		}
+	}
+
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, as)
+
+	marker := as.GetAnomalyMarker()
+	anomalyStyle.GetFillAndStrokeOptions().WriteToRenderer(r)
+	for index, isAnomaly := range flags {
+		vx, vy := as.InnerSeries.GetValues(index)
+		if !isAnomaly || !isFinitePoint(vx, vy) {
+			continue
+		}
+		setPointTooltip(r, vx, vy)
+		marker(r, canvasBox.Left+xrange.Translate(vx), canvasBox.Bottom-yrange.Translate(vy), anomalyStyle.GetDotWidth())
+	}
+	r.ResetStyle()
+}
+
+// bands returns the x bounds of the runs of anomalous values, half way to the values on either side of them,
+// or at the values themselves at the ends of the series.
+func (as AnomalySeries) bands(flags []bool) (bands [][2]float64) {
+	xAt := func(index int) float64 {
+		x, _ := as.InnerSeries.GetValues(index)
+		return x
+	}
+	for start := 0; start < len(flags); {
+		if !flags[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < len(flags) && flags[end] {
+			end++
+		}
+		low, high := xAt(start), xAt(end-1)
+		if start > 0 {
+			low = (low + xAt(start-1)) / 2
+		}
+		if end < len(flags) {
+			high = (high + xAt(end)) / 2
+		}
+		bands = append(bands, [2]float64{low, high})
+		start = end
+	}
+	return
+}
+
+// Validate validates the series.
+func (as AnomalySeries) Validate() error {
+	if as.InnerSeries == nil {
+		return newValidationError(ErrInvalidSeries, "anomaly series requires InnerSeries to be set")
+	}
+	return nil
+}
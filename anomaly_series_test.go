@@ -0,0 +1,78 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func anomalyTestSeries() ContinuousSeries {
+	cs := ContinuousSeries{}
+	for index := 0; index < 40; index++ {
+		cs.XValues = append(cs.XValues, float64(index))
+		cs.YValues = append(cs.YValues, 10+float64(index%3))
+	}
+	cs.YValues[25], cs.YValues[26] = 40, 41
+	cs.YValues[30] = math.NaN()
+	return cs
+}
+
+func TestZScoreAnomalies(t *testing.T) {
+	assert := assert.New(t)
+
+	as := AnomalySeries{InnerSeries: anomalyTestSeries(), Detector: ZScoreAnomalies(10, 3)}
+	assert.Equal([]int{25, 26}, as.GetAnomalies())
+
+	// a flat series has no spread, and no anomalies.
+	flat := ContinuousSeries{XValues: []float64{0, 1, 2, 3}, YValues: []float64{1, 1, 1, 1}}
+	assert.Empty(AnomalySeries{InnerSeries: flat, Detector: ZScoreAnomalies(2, 0)}.GetAnomalies())
+}
+
+func TestIQRAnomalies(t *testing.T) {
+	assert := assert.New(t)
+
+	as := AnomalySeries{InnerSeries: anomalyTestSeries(), Detector: IQRAnomalies(0)}
+	assert.Equal([]int{25, 26}, as.GetAnomalies())
+}
+
+func TestAnomalyFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	as := AnomalySeries{InnerSeries: anomalyTestSeries(), Detector: AnomalyFunc(func(index int, x, y float64) bool {
+		return y > 11.5
+	})}
+	assert.Len(as.GetAnomalies(), 14)
+}
+
+func TestAnomalySeriesBands(t *testing.T) {
+	assert := assert.New(t)
+
+	as := AnomalySeries{InnerSeries: anomalyTestSeries()}
+	bands := as.bands([]bool{true, false, false, true, true, false})
+	assert.Equal([][2]float64{{0, 0.5}, {2.5, 4.5}}, bands)
+}
+
+func TestAnomalySeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{AnomalySeries{
+			InnerSeries:  anomalyTestSeries(),
+			Detector:     IQRAnomalies(0),
+			AnomalyStyle: Style{FillColor: ColorOrange, StrokeColor: ColorOrange},
+			BandStyle:    Style{Show: true},
+		}},
+	}
+	assert.Nil(c.Validate())
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	svg := buffer.String()
+	assert.Equal(2, strings.Count(svg, `r="5" style="stroke-width:1;stroke:rgba(217,101,0,1.0)`), svg)
+	// the band behind both spikes is filled with a translucent marker color.
+	assert.Equal(1, strings.Count(svg, "fill:rgba(217,101,0,0.2)"), svg)
+
+	assert.NotNil(AnomalySeries{}.Validate())
+}
@@ -12,6 +12,22 @@ const (
 	TickPositionUnderTick TickPosition = 2
 )
 
+// TickLabelLayout is how an x axis lays out tick labels that would overlap.
+type TickLabelLayout int
+
+const (
+	// TickLabelLayoutDefault draws every tick label under its tick, even if labels overlap.
+	TickLabelLayoutDefault TickLabelLayout = 0
+	// TickLabelLayoutThin keeps every Nth tick, for the smallest N at which the labels don't overlap.
+	TickLabelLayoutThin TickLabelLayout = 1
+	// TickLabelLayoutStagger draws every other tick label in a second row below the first if the labels
+	// would overlap in one row; it is an alternative to rotating labels that are a little too wide.
+	TickLabelLayoutStagger TickLabelLayout = 2
+	// TickLabelLayoutEllipsize shortens labels wider than the space between their tick and the ticks next to it,
+	// ending them in an ellipsis, i.e. for long category names.
+	TickLabelLayoutEllipsize TickLabelLayout = 3
+)
+
 // YAxisType is a type of y-axis; it can either be primary or secondary.
 type YAxisType int
 
@@ -22,6 +38,29 @@ const (
 	YAxisSecondary YAxisType = 1
 )
 
+// XAxisType is a type of x-axis; it can either be primary (along the bottom) or secondary (along the top).
+type XAxisType int
+
+const (
+	// XAxisPrimary is the primary axis.
+	XAxisPrimary XAxisType = 0
+	// XAxisSecondary is the secondary axis.
+	XAxisSecondary XAxisType = 1
+)
+
+// XAxisProvider is a series that can be mapped to the secondary x-axis.
+type XAxisProvider interface {
+	GetXAxis() XAxisType
+}
+
+// getSeriesXAxis returns which x-axis a series draws on; series that are not an `XAxisProvider` draw on the primary.
+func getSeriesXAxis(s Series) XAxisType {
+	if xap, isXAxisProvider := s.(XAxisProvider); isXAxisProvider {
+		return xap.GetXAxis()
+	}
+	return XAxisPrimary
+}
+
 // Axis is a chart feature detailing what values happen where.
 type Axis interface {
 	GetName() string
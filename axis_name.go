@@ -0,0 +1,90 @@
+package chart
+
+import (
+	"math"
+	"strings"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// AxisNamePlacement is where the name of an axis is drawn along it.
+type AxisNamePlacement int
+
+const (
+	// AxisNamePlacementMiddle centers the name along the axis; it is the default.
+	AxisNamePlacementMiddle AxisNamePlacement = iota
+	// AxisNamePlacementEnd draws the name at the end of the axis, i.e. flush with the right of an x axis
+	// or the top of a y axis.
+	AxisNamePlacementEnd
+)
+
+// UnitPlacement is where the symbol of the unit of an axis is drawn.
+type UnitPlacement int
+
+const (
+	// UnitPlacementTicks labels every tick with the unit, i.e. `256 MiB`, `512 MiB`; it is the default.
+	UnitPlacementTicks UnitPlacement = iota
+	// UnitPlacementAxisEnd labels only the tick at the end of the axis with the unit, and the rest with
+	// the number alone, i.e. `256`, `512`, `768 MiB`.
+	UnitPlacementAxisEnd
+)
+
+// axisName is the name of an axis as it is laid out: its lines, unrotated, and the size of the block of them;
+// vertical lines are columns, as high as they are wide.
+type axisName struct {
+	Lines      []string
+	LineHeight int
+	Height     int
+}
+
+// measureAxisName lays the name of an axis out in lines; the name is broken at newlines, and wrapped to
+// the length of the axis if the style wraps text. Vertical names are broken at newlines only.
+func measureAxisName(r Renderer, name string, length int, style Style) (an axisName) {
+	if style.TextWritingMode == TextWritingModeVertical {
+		an.Lines = strings.Split(name, "\n")
+		for _, line := range an.Lines {
+			an.LineHeight = util.Math.MaxInt(an.LineHeight, Draw.MeasureText(r, line, style).Width())
+		}
+		an.Height = len(an.Lines)*an.LineHeight + (len(an.Lines)-1)*style.GetTextLineSpacing(DefaultLineSpacing)
+		return
+	}
+	unrotated := style
+	unrotated.TextRotationDegrees = 0
+	for _, line := range strings.Split(name, "\n") {
+		an.Lines = append(an.Lines, Text.WrapFit(r, line, length, unrotated)...)
+	}
+	for _, line := range an.Lines {
+		an.LineHeight = util.Math.MaxInt(an.LineHeight, Draw.MeasureText(r, line, unrotated).Height())
+	}
+	an.Height = len(an.Lines)*an.LineHeight + (len(an.Lines)-1)*style.GetTextLineSpacing(DefaultLineSpacing)
+	return
+}
+
+// draw draws the lines of the name centered on cx,cy across the lines, and aligned along them;
+// lines are stacked down the page of the rotated text. Vertical lines are stacked right to left, and aligned
+// on cy by their tops for left alignment, and their bottoms for right alignment.
+func (an axisName) draw(r Renderer, cx, cy int, align TextHorizontalAlign, style Style) {
+	if style.TextWritingMode == TextWritingModeVertical {
+		vertical := TextVerticalAlignMiddle
+		switch align {
+		case TextHorizontalAlignLeft:
+			vertical = TextVerticalAlignTop
+		case TextHorizontalAlignRight:
+			vertical = TextVerticalAlignBottom
+		}
+		step := float64(an.LineHeight + style.GetTextLineSpacing(DefaultLineSpacing))
+		for index, line := range an.Lines {
+			offset := (float64(len(an.Lines)-1)/2 - float64(index)) * step
+			Draw.TextAnchored(r, line, cx+int(math.Round(offset)), cy, withTextAnchor(style, TextHorizontalAlignCenter, vertical))
+		}
+		return
+	}
+	theta := util.Math.DegreesToRadians(style.TextRotationDegrees)
+	step := float64(an.LineHeight + style.GetTextLineSpacing(DefaultLineSpacing))
+	for index, line := range an.Lines {
+		offset := (float64(index) - float64(len(an.Lines)-1)/2) * step
+		x := cx + int(math.Round(-offset*math.Sin(theta)))
+		y := cy + int(math.Round(offset*math.Cos(theta)))
+		Draw.TextAnchored(r, line, x, y, withTextAnchor(style, align, TextVerticalAlignMiddle))
+	}
+}
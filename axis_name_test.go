@@ -0,0 +1,61 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestMeasureAxisName(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(200, 200)
+	assert.Nil(err)
+	style := Style{Font: f, FontSize: DefaultFontSize, TextRotationDegrees: 90}
+
+	name := measureAxisName(r, "Latency", 200, style)
+	assert.Equal([]string{"Latency"}, name.Lines)
+	assert.Equal(name.LineHeight, name.Height)
+
+	name = measureAxisName(r, "Latency\n(ms)", 200, style)
+	assert.Equal([]string{"Latency", "(ms)"}, name.Lines)
+	assert.Equal(2*name.LineHeight+DefaultLineSpacing, name.Height)
+
+	style.TextWrap = TextWrapWord
+	name = measureAxisName(r, "Latency of the requests to the service", 100, style)
+	assert.True(len(name.Lines) > 1)
+}
+
+func TestChartAxisNamePlacement(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Name: "Time\n(seconds)", NameStyle: StyleShow(), NamePlacement: AxisNamePlacementEnd, Style: StyleShow()},
+		YAxis: YAxis{Name: "Value", NameStyle: StyleShow(), NamePlacement: AxisNamePlacementEnd, Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), ">Time</text>"))
+	assert.True(strings.Contains(buf.String(), ">(seconds)</text>"))
+
+	// names with more lines take more room from the canvas.
+	single, multiple := c, c
+	single.YAxis.Name = "Value"
+	multiple.YAxis.Name = "Value\nof the series"
+	r, err := PNG(c.GetWidth(), c.GetHeight())
+	assert.Nil(err)
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	single.defaultFont, multiple.defaultFont = f, f
+	canvas := Box{Top: 20, Left: 20, Right: 1004, Bottom: 380}
+	yr := &ContinuousRange{Min: 0, Max: 3, Domain: canvas.Height()}
+	ticks := single.YAxis.GetTicks(r, yr, single.styleDefaultsAxes(), FloatValueFormatter)
+	assert.True(multiple.YAxis.Measure(r, canvas, yr, multiple.styleDefaultsAxes(), ticks).Right > single.YAxis.Measure(r, canvas, yr, single.styleDefaultsAxes(), ticks).Right)
+}
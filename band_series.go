@@ -0,0 +1,77 @@
+package chart
+
+import "math"
+
+// BandSeries fills the area between an upper and a lower line, i.e. a confidence interval or a min/max envelope.
+// The lines are any values providers, like a `ContinuousSeries`, and need not share x values; they are stroked
+// in the band's stroke color, and the area between them is filled with its fill color.
+type BandSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	Upper ValuesProvider
+	Lower ValuesProvider
+}
+
+// GetName returns the name of the series.
+func (bs BandSeries) GetName() string {
+	return bs.Name
+}
+
+// GetStyle returns the band style.
+func (bs BandSeries) GetStyle() Style {
+	return bs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (bs BandSeries) GetYAxis() YAxisType {
+	return bs.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (bs BandSeries) GetXAxis() XAxisType {
+	return bs.XAxis
+}
+
+// GetBounds implements `BoundsProvider`; the bounds span both lines.
+func (bs BandSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minX, maxX, minY, maxY = math.MaxFloat64, -math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64
+	for _, vp := range []ValuesProvider{bs.Upper, bs.Lower} {
+		if vp == nil {
+			continue
+		}
+		for index := 0; index < vp.Len(); index++ {
+			vx, vy := vp.GetValues(index)
+			if !isFinitePoint(vx, vy) {
+				continue
+			}
+			minX, maxX = math.Min(minX, vx), math.Max(maxX, vx)
+			minY, maxY = math.Min(minY, vy), math.Max(maxY, vy)
+		}
+	}
+	return
+}
+
+// Render renders the series.
+func (bs BandSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := bs.Style.InheritFrom(bs.styleDefaultsBand(defaults))
+	Draw.Band(r, canvasBox, xrange, yrange, style, bs.Upper, bs.Lower)
+}
+
+// styleDefaultsBand fills the band with a translucent version of the series color.
+func (bs BandSeries) styleDefaultsBand(defaults Style) Style {
+	return defaults.InheritFrom(Style{FillColor: defaults.StrokeColor.WithAlpha(64)})
+}
+
+// Validate validates the series.
+func (bs BandSeries) Validate() error {
+	if bs.Upper == nil || bs.Lower == nil {
+		return newValidationError(ErrEmptySeries, "band series requires upper and lower to be set")
+	}
+	if bs.Upper.Len() == 0 || bs.Lower.Len() == 0 {
+		return newValidationError(ErrEmptySeries, "band series has %d upper and %d lower values", bs.Upper.Len(), bs.Lower.Len())
+	}
+	return nil
+}
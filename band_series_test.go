@@ -0,0 +1,55 @@
+package chart
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestBandSeriesBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	bs := BandSeries{
+		Upper: ContinuousSeries{XValues: []float64{0, 1, 2}, YValues: []float64{3, 4, 5}},
+		Lower: ContinuousSeries{XValues: []float64{0.5, 1, 3}, YValues: []float64{1, 2, -1}},
+	}
+	minX, maxX, minY, maxY := bs.GetBounds()
+	assert.Equal(0.0, minX)
+	assert.Equal(3.0, maxX)
+	assert.Equal(-1.0, minY)
+	assert.Equal(5.0, maxY)
+
+	assert.Nil(bs.Validate())
+	assert.NotNil(BandSeries{Upper: bs.Upper}.Validate())
+}
+
+func TestBandSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  200,
+		Height: 200,
+		NoText: true,
+		Series: []Series{
+			BandSeries{
+				Style: Style{Show: true, FillColor: drawing.ColorBlue, StrokeWidth: Disabled},
+				Upper: ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{8, 8}},
+				Lower: ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{2, 2}},
+			},
+			ContinuousSeries{Style: Style{Show: true, StrokeWidth: Disabled, DotWidth: 1}, XValues: []float64{0, 10}, YValues: []float64{0, 10}},
+		},
+	}
+	collector := &rgbaCollector{}
+	assert.Nil(c.Render(PNG, collector))
+
+	box := c.Box()
+	cx, cy := box.Center()
+	// the middle of the canvas is between the lines, and the bottom is below the band.
+	assert.Equal(color.RGBA{B: 255, A: 255}, color.RGBAModel.Convert(collector.i.At(cx, cy)))
+	assert.NotEqual(color.RGBA{B: 255, A: 255}, color.RGBAModel.Convert(collector.i.At(cx, box.Bottom-5)))
+
+	assert.Nil(c.Render(SVG, bytes.NewBuffer(nil)))
+}
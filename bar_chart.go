@@ -1,8 +1,6 @@
 package chart
 
 import (
-	"errors"
-	"fmt"
 	"io"
 	"math"
 
@@ -15,24 +13,94 @@ type BarChart struct {
 	Title      string
 	TitleStyle Style
 
+	// Subtitle is drawn under the title; once it is set, the title and subtitle are laid out above the bars
+	// rather than drawn over it. Footer and Source are laid out below the bars, left and right aligned.
+	Subtitle      string
+	SubtitleStyle Style
+	Footer        string
+	FooterStyle   Style
+	Source        string
+	SourceStyle   Style
+
 	ColorPalette ColorPalette
 
 	Width  int
 	Height int
 	DPI    float64
 
+	// ScaleFactor renders raster output at a multiple of the width and height, with fonts, strokes and
+	// positions scaled to match, so a 2x chart for Retina displays looks like the 1x chart; it defaults to 1.
+	ScaleFactor float64
+
 	BarWidth int
 
 	Background Style
 	Canvas     Style
 
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
 	XAxis Style
 	YAxis YAxis
 
+	// Horizontal draws the bars left to right from the top, with their labels on the left in full;
+	// `XAxis` is then the style of the labels on the left, and `YAxis` the value axis along the bottom.
+	Horizontal bool
+
+	// ValueStyle is the style of the value labels, drawn past the end of each bar if shown.
+	ValueStyle Style
+	// DataLabels draws the value labels too, with a formatter and offset of their own, leaving out labels
+	// that would overlap; its style inherits from `ValueStyle`.
+	DataLabels DataLabels
+
+	// Series draws a bar for each series in each category, side by side, or stacked if `Stacked` is set;
+	// the categories are the `Bars`, whose labels name them and whose values are not drawn.
+	// Value labels and errors are not drawn for series.
+	Series  []BarSeries
+	Stacked bool
+	// Normalized scales each stack of stacked series to 100%, so the bars show the share of each series
+	// in its category; the value axis is formatted as percentages unless it has a formatter of its own.
+	Normalized bool
+	// BarGroupSpacing is the spacing between the bars of a category when the series are side by side;
+	// `BarWidth` is then the width of each bar in the group.
+	BarGroupSpacing int
+
 	BarSpacing int
 
+	// BarWeights makes the width of each bar proportional to its weight, i.e. a second value of the bar;
+	// the bars are sized to fill the canvas together with their spacing. Bars without a weight are not drawn.
+	BarWeights []float64
+	// BarSpacings sets the spacing around each bar, by index, instead of `BarSpacing`.
+	BarSpacings []int
+
+	// BarErrors is the error of each bar, by index; it is drawn as a whisker from the value minus to the value plus the error.
+	BarErrors []float64
+	// ErrorStyle is the style of the whiskers; `ErrorCapWidth` is the width of their caps, by default half the bar width.
+	ErrorStyle    Style
+	ErrorCapWidth int
+
+	// CornerRadius rounds the corners of the bars that `Corners` picks, by default the corners at their ends;
+	// stacked bars are rounded at the ends of the stack rather than each segment.
+	CornerRadius int
+	Corners      BarCorners
+
 	Font        *truetype.Font
 	defaultFont *truetype.Font
+	// contentBox is the chart box less the space taken by the title blocks; it is set while rendering.
+	contentBox Box
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
 
 	Bars     []Value
 	Elements []Renderable
@@ -46,6 +114,14 @@ func (bc BarChart) GetDPI() float64 {
 	return bc.DPI
 }
 
+// GetScaleFactor returns the device scale factor, or a default.
+func (bc BarChart) GetScaleFactor() float64 {
+	if bc.ScaleFactor == 0 {
+		return 1
+	}
+	return bc.ScaleFactor
+}
+
 // GetFont returns the text font.
 func (bc BarChart) GetFont() *truetype.Font {
 	if bc.Font == nil {
@@ -87,61 +163,98 @@ func (bc BarChart) GetBarWidth() int {
 }
 
 // Render renders the chart with the given renderer to the given io.Writer.
-func (bc BarChart) Render(rp RendererProvider, w io.Writer) error {
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (bc BarChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
 	if len(bc.Bars) == 0 {
-		return errors.New("please provide at least one bar")
+		return newValidationError(ErrEmptySeries, "please provide at least one bar")
 	}
 
 	r, err := rp(bc.GetWidth(), bc.GetHeight())
 	if err != nil {
 		return err
 	}
+	if err := applyScaleFactor(r, bc.GetScaleFactor()); err != nil {
+		return err
+	}
 
-	if bc.Font == nil {
-		defaultFont, err := GetDefaultFont()
+	if bc.NoText {
+		r = textFreeRenderer{r}
+	} else if bc.Font == nil {
+		defaultFont, err := loadDefaultFont()
 		if err != nil {
 			return err
 		}
 		bc.defaultFont = defaultFont
 	}
 	r.SetDPI(bc.GetDPI())
+	bc.contentBox = bc.getTitleBlocks().adjust(r, bc.box())
 
 	bc.drawBackground(r)
+	bc.BackgroundImage.draw(r, Box{Right: bc.GetWidth(), Bottom: bc.GetHeight()})
+	bc.Watermark.draw(r, Box{Right: bc.GetWidth(), Bottom: bc.GetHeight()}, bc.GetFont(), bc.GetColorPalette(), false)
 
-	var canvasBox Box
-	var yt []Tick
-	var yr Range
-	var yf ValueFormatter
-
-	canvasBox = bc.getDefaultCanvasBox()
-	yr = bc.getRanges()
+	yr := bc.getRanges()
 	if yr.GetMax()-yr.GetMin() == 0 {
-		return fmt.Errorf("invalid data range; cannot be zero")
+		return newValidationError(ErrInvalidRange, "invalid data range; cannot be zero")
 	}
-	yr = bc.setRangeDomains(canvasBox, yr)
-	yf = bc.getValueFormatters()
+	yf := bc.getValueFormatters()
 
-	if bc.hasAxes() {
-		yt = bc.getAxesTicks(r, yr, yf)
-		canvasBox = bc.getAdjustedCanvasBox(r, canvasBox, yr, yt)
+	var cl ChartLayout
+	if bc.Horizontal {
+		cl = bc.getHorizontalLayout(r, yr, yf)
+		callRenderHook(bc.BeforeRender, r, cl)
+		bc.drawHorizontal(r, cl)
+	} else {
+		var yt []Tick
+		canvasBox := bc.getDefaultCanvasBox()
+		if bc.showValueLabels() {
+			canvasBox.Top += bc.getValueLabelSize(r, yf).Height() + bc.getDataLabels().GetOffset()
+			canvasBox.Bottom -= bc.getNegativeValueLabelSpace(r, yf, false)
+		}
 		yr = bc.setRangeDomains(canvasBox, yr)
-	}
 
-	bc.drawBars(r, canvasBox, yr)
-	bc.drawXAxis(r, canvasBox)
-	bc.drawYAxis(r, canvasBox, yr, yt)
+		if bc.hasAxes() {
+			yt = bc.getAxesTicks(r, yr, yf)
+			canvasBox = bc.getAdjustedCanvasBox(r, canvasBox, yr, yt)
+			yr = bc.setRangeDomains(canvasBox, yr)
+		}
+
+		cl = ChartLayout{Canvas: canvasBox, YRange: yr, YTicks: yt}
+		callRenderHook(bc.BeforeRender, r, cl)
+		bc.drawBars(r, canvasBox, yr)
+		bc.drawBaseline(r, canvasBox, yr)
+		bc.drawErrors(r, canvasBox, yr)
+		// the labels of negative bars are drawn between the bars and the x axis.
+		axisBox := canvasBox.Clone()
+		axisBox.Bottom += bc.getNegativeValueLabelSpace(r, yf, false)
+		bc.drawXAxis(r, axisBox)
+		bc.drawYAxis(r, canvasBox, yr, yt)
+		if bc.showValueLabels() {
+			bc.drawValueLabels(r, canvasBox, yr)
+		}
+	}
 
 	bc.drawTitle(r)
 	for _, a := range bc.Elements {
-		a(r, canvasBox, bc.styleDefaultsElements())
+		a(r, cl.Canvas, bc.styleDefaultsElements())
 	}
+	bc.Watermark.draw(r, Box{Right: bc.GetWidth(), Bottom: bc.GetHeight()}, bc.GetFont(), bc.GetColorPalette(), true)
+	callRenderHook(bc.AfterRender, r, cl)
 
+	setAccessibility(r, bc.Accessibility.withDefaults(bc.Title, func() string {
+		if bc.hasSeries() {
+			return bc.describeSeries()
+		}
+		return describeValues("Bar chart", bc.Bars)
+	}))
 	return r.Save(w)
 }
 
 func (bc BarChart) getRanges() Range {
 	var yrange Range
-	if bc.YAxis.Range != nil && !bc.YAxis.Range.IsZero() {
+	if bc.YAxis.Range != nil {
 		yrange = bc.YAxis.Range
 	} else {
 		yrange = &ContinuousRange{}
@@ -163,13 +276,23 @@ func (bc BarChart) getRanges() Range {
 	}
 
 	min, max := math.MaxFloat64, -math.MaxFloat64
-	for _, b := range bc.Bars {
-		min = math.Min(b.Value, min)
-		max = math.Max(b.Value, max)
+	if bc.hasSeries() {
+		min, max = bc.getSeriesBounds()
+	} else {
+		for index, b := range bc.Bars {
+			barError := bc.getBarError(index)
+			min = math.Min(b.Value-barError, min)
+			max = math.Max(b.Value+barError, max)
+		}
+	}
+	// negative bars extend down from zero, so zero has to be in the range.
+	if min < 0 {
+		max = math.Max(max, 0)
 	}
 
 	yrange.SetMin(min)
 	yrange.SetMax(max)
+	fitRange(yrange)
 
 	return yrange
 }
@@ -182,30 +305,131 @@ func (bc BarChart) drawBackground(r Renderer) {
 }
 
 func (bc BarChart) drawBars(r Renderer, canvasBox Box, yr Range) {
-	xoffset := canvasBox.Left
-
-	width, spacing, _ := bc.calculateScaledTotalWidth(canvasBox)
-	bs2 := spacing >> 1
-
-	var barBox Box
-	var bxl, bxr, by int
+	var top, bottom int
+	yf := bc.getValueFormatters()
+	for _, segment := range bc.getBarSegments(bc.getBarSlots(canvasBox)) {
+		top = canvasBox.Bottom - yr.Translate(clampToRange(yr, segment.To))
+		bottom = canvasBox.Bottom - yr.Translate(clampToRange(yr, segment.From))
+
+		setTooltip(r, bc.getTooltip(segment, yf))
+		Draw.RoundedBox(r, Box{
+			Top:    util.Math.MinInt(top, bottom),
+			Left:   segment.Left,
+			Right:  segment.Right,
+			Bottom: util.Math.MaxInt(top, bottom),
+		}, bc.getCornerRadii(segment, false, bottom, top), segment.Style)
+	}
+}
+
+// getBaseline returns the canvas y coordinate the bars extend from; that is zero,
+// or the bottom or top of the range if zero is not in the range.
+func (bc BarChart) getBaseline(canvasBox Box, yr Range) int {
+	return canvasBox.Bottom - yr.Translate(clampToRange(yr, 0))
+}
+
+// clampToRange returns the value limited to the bounds of the range.
+func clampToRange(ra Range, value float64) float64 {
+	min, max := ra.GetMin(), ra.GetMax()
+	if min > max {
+		min, max = max, min
+	}
+	return math.Min(math.Max(value, min), max)
+}
+
+// drawBaseline draws a line at zero if there are bars on both sides of it.
+func (bc BarChart) drawBaseline(r Renderer, canvasBox Box, yr Range) {
+	baseline := bc.getBaseline(canvasBox, yr)
+	if baseline <= canvasBox.Top || baseline >= canvasBox.Bottom {
+		return
+	}
+	bc.XAxis.InheritFrom(bc.styleDefaultsAxes()).GetStrokeOptions().WriteToRenderer(r)
+	r.MoveTo(canvasBox.Left, baseline)
+	r.LineTo(canvasBox.Right, baseline)
+	r.Stroke()
+}
+
+// drawValueLabels draws the value of each bar past its end; above bars with positive values
+// and below bars with negative values.
+func (bc BarChart) drawValueLabels(r Renderer, canvasBox Box, yr Range) {
+	slots := bc.getBarSlots(canvasBox)
+	dl := bc.getDataLabels()
+	style := dl.Style.InheritFrom(bc.styleDefaultsValue())
+	vf := dl.GetFormatter(bc.getValueFormatters())
+	offset := dl.GetOffset()
+	labels := dataLabelPlacer{noThinning: dl.NoThinning}
 	for index, bar := range bc.Bars {
-		bxl = xoffset + bs2
-		bxr = bxl + width
+		if slots[index].BarRight <= slots[index].BarLeft {
+			continue
+		}
+		label := vf(bar.Value)
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+
+		tx := ((slots[index].BarLeft + slots[index].BarRight) >> 1) - (tb.Width() >> 1)
+		by := canvasBox.Bottom - yr.Translate(bc.getValueLabelAnchor(index))
+		ty := by - offset
+		if bar.Value < 0 {
+			ty = by + offset + tb.Height()
+		}
+		if labels.place(Box{Top: ty - tb.Height(), Left: tx, Right: tx + tb.Width(), Bottom: ty}) {
+			r.Text(label, tx, ty)
+		}
+	}
+}
 
-		by = canvasBox.Bottom - yr.Translate(bar.Value)
+// getValueLabelAnchor returns the value the label of a bar is drawn past; the end of the bar, or of its whisker.
+func (bc BarChart) getValueLabelAnchor(index int) float64 {
+	if value := bc.Bars[index].Value; value < 0 {
+		return value - bc.getBarError(index)
+	}
+	return bc.Bars[index].Value + bc.getBarError(index)
+}
 
-		barBox = Box{
-			Top:    by,
-			Left:   bxl,
-			Right:  bxr,
-			Bottom: canvasBox.Bottom,
+// getNegativeValueLabelSpace returns the space to leave past the canvas for the value labels of negative bars,
+// along the value axis; that is zero if the labels are not shown or no bar is negative.
+func (bc BarChart) getNegativeValueLabelSpace(r Renderer, vf ValueFormatter, isHorizontal bool) int {
+	if !bc.showValueLabels() {
+		return 0
+	}
+	for _, bar := range bc.Bars {
+		if bar.Value < 0 {
+			if isHorizontal {
+				return bc.getValueLabelSize(r, vf).Width() + bc.getDataLabels().GetOffset()
+			}
+			return bc.getValueLabelSize(r, vf).Height() + bc.getDataLabels().GetOffset()
 		}
+	}
+	return 0
+}
 
-		Draw.Box(r, barBox, bar.Style.InheritFrom(bc.styleDefaultsBar(index)))
+// showValueLabels returns if the value labels are drawn; they are for bars without series.
+func (bc BarChart) showValueLabels() bool {
+	return bc.getDataLabels().Show && !bc.hasSeries()
+}
+
+// getDataLabels returns the value labels of the bars; showing `ValueStyle` draws every label, as it did
+// before the bar chart had data labels.
+func (bc BarChart) getDataLabels() DataLabels {
+	if bc.DataLabels.Show {
+		dl := bc.DataLabels
+		dl.Style = dl.Style.InheritFrom(bc.ValueStyle)
+		return dl
+	}
+	return DataLabels{Show: bc.ValueStyle.Show, Style: bc.ValueStyle, NoThinning: true}
+}
 
-		xoffset += width + spacing
+// getValueLabelSize returns the size of the largest value label.
+func (bc BarChart) getValueLabelSize(r Renderer, vf ValueFormatter) Box {
+	dl := bc.getDataLabels()
+	vf = dl.GetFormatter(vf)
+	dl.Style.InheritFrom(bc.styleDefaultsValue()).GetTextOptions().WriteToRenderer(r)
+	var width, height int
+	for _, bar := range bc.Bars {
+		tb := r.MeasureText(vf(bar.Value))
+		width = util.Math.MaxInt(width, tb.Width())
+		height = util.Math.MaxInt(height, tb.Height())
 	}
+	return Box{Right: width, Bottom: height}
 }
 
 func (bc BarChart) drawXAxis(r Renderer, canvasBox Box) {
@@ -213,7 +437,7 @@ func (bc BarChart) drawXAxis(r Renderer, canvasBox Box) {
 		axisStyle := bc.XAxis.InheritFrom(bc.styleDefaultsAxes())
 		axisStyle.WriteToRenderer(r)
 
-		width, spacing, _ := bc.calculateScaledTotalWidth(canvasBox)
+		slots := bc.getBarSlots(canvasBox)
 
 		r.MoveTo(canvasBox.Left, canvasBox.Bottom)
 		r.LineTo(canvasBox.Right, canvasBox.Bottom)
@@ -223,12 +447,11 @@ func (bc BarChart) drawXAxis(r Renderer, canvasBox Box) {
 		r.LineTo(canvasBox.Left, canvasBox.Bottom+DefaultVerticalTickHeight)
 		r.Stroke()
 
-		cursor := canvasBox.Left
 		for index, bar := range bc.Bars {
 			barLabelBox := Box{
 				Top:    canvasBox.Bottom + DefaultXAxisMargin,
-				Left:   cursor,
-				Right:  cursor + width + spacing,
+				Left:   slots[index].Left,
+				Right:  slots[index].Right,
 				Bottom: bc.GetHeight(),
 			}
 
@@ -242,7 +465,6 @@ func (bc BarChart) drawXAxis(r Renderer, canvasBox Box) {
 				r.LineTo(barLabelBox.Right, canvasBox.Bottom+DefaultVerticalTickHeight)
 				r.Stroke()
 			}
-			cursor += width + spacing
 		}
 	}
 }
@@ -279,6 +501,12 @@ func (bc BarChart) drawYAxis(r Renderer, canvasBox Box, yr Range, ticks []Tick)
 }
 
 func (bc BarChart) drawTitle(r Renderer) {
+	if blocks := bc.getTitleBlocks(); blocks.hasHeader() || len(blocks.footer()) > 0 {
+		blocks.draw(r, bc.box())
+		if blocks.hasHeader() {
+			return
+		}
+	}
 	if len(bc.Title) > 0 && bc.TitleStyle.Show {
 		Draw.TextWithin(r, bc.Title, bc.box(), bc.styleDefaultsTitle())
 	}
@@ -294,13 +522,38 @@ func (bc BarChart) setRangeDomains(canvasBox Box, yr Range) Range {
 }
 
 func (bc BarChart) getDefaultCanvasBox() Box {
-	return bc.box()
+	return bc.getContentBox()
+}
+
+// getContentBox returns the box the bars and axes are laid out in, the chart box less the space taken
+// by the title blocks.
+func (bc BarChart) getContentBox() Box {
+	if bc.contentBox.IsZero() {
+		return bc.box()
+	}
+	return bc.contentBox
+}
+
+// getTitleBlocks returns the title, subtitle, footer and source of the chart with their styles.
+func (bc BarChart) getTitleBlocks() titleBlocks {
+	subtitle, footer, source := styleDefaultsTitleBlocks(Style{Font: bc.GetFont(), FontColor: bc.GetColorPalette().TextColor()})
+	title := bc.styleDefaultsTitle()
+	title.Show = bc.TitleStyle.Show
+	return titleBlocks{
+		Title:    titleBlock{Text: bc.Title, Style: title},
+		Subtitle: titleBlock{Text: bc.Subtitle, Style: bc.SubtitleStyle.InheritFrom(subtitle)},
+		Footer:   titleBlock{Text: bc.Footer, Style: bc.FooterStyle.InheritFrom(footer)},
+		Source:   titleBlock{Text: bc.Source, Style: bc.SourceStyle.InheritFrom(source)},
+	}
 }
 
 func (bc BarChart) getValueFormatters() ValueFormatter {
 	if bc.YAxis.ValueFormatter != nil {
 		return bc.YAxis.ValueFormatter
 	}
+	if bc.isNormalized() {
+		return PercentValueFormatter
+	}
 	return FloatValueFormatter
 }
 
@@ -312,9 +565,9 @@ func (bc BarChart) getAxesTicks(r Renderer, yr Range, yf ValueFormatter) (yticks
 }
 
 func (bc BarChart) calculateEffectiveBarSpacing(canvasBox Box) int {
-	totalWithBaseSpacing := bc.calculateTotalBarWidth(bc.GetBarWidth(), bc.GetBarSpacing())
+	totalWithBaseSpacing := bc.calculateTotalBarWidth(bc.getGroupWidth(), bc.GetBarSpacing())
 	if totalWithBaseSpacing > canvasBox.Width() {
-		lessBarWidths := canvasBox.Width() - (len(bc.Bars) * bc.GetBarWidth())
+		lessBarWidths := canvasBox.Width() - (len(bc.Bars) * bc.getGroupWidth())
 		if lessBarWidths > 0 {
 			return int(math.Ceil(float64(lessBarWidths) / float64(len(bc.Bars))))
 		}
@@ -324,7 +577,7 @@ func (bc BarChart) calculateEffectiveBarSpacing(canvasBox Box) int {
 }
 
 func (bc BarChart) calculateEffectiveBarWidth(canvasBox Box, spacing int) int {
-	totalWithBaseWidth := bc.calculateTotalBarWidth(bc.GetBarWidth(), spacing)
+	totalWithBaseWidth := bc.calculateTotalBarWidth(bc.getGroupWidth(), spacing)
 	if totalWithBaseWidth > canvasBox.Width() {
 		totalLessBarSpacings := canvasBox.Width() - (len(bc.Bars) * spacing)
 		if totalLessBarSpacings > 0 {
@@ -332,7 +585,7 @@ func (bc BarChart) calculateEffectiveBarWidth(canvasBox Box, spacing int) int {
 		}
 		return 0
 	}
-	return bc.GetBarWidth()
+	return bc.getGroupWidth()
 }
 
 func (bc BarChart) calculateTotalBarWidth(barWidth, spacing int) int {
@@ -349,7 +602,7 @@ func (bc BarChart) calculateScaledTotalWidth(canvasBox Box) (width, spacing, tot
 func (bc BarChart) getAdjustedCanvasBox(r Renderer, canvasBox Box, yrange Range, yticks []Tick) Box {
 	axesOuterBox := canvasBox.Clone()
 
-	_, _, totalWidth := bc.calculateScaledTotalWidth(canvasBox)
+	slots := bc.getBarSlots(canvasBox)
 
 	if bc.XAxis.Show {
 		xaxisHeight := DefaultVerticalTickHeight
@@ -357,13 +610,12 @@ func (bc BarChart) getAdjustedCanvasBox(r Renderer, canvasBox Box, yrange Range,
 		axisStyle := bc.XAxis.InheritFrom(bc.styleDefaultsAxes())
 		axisStyle.WriteToRenderer(r)
 
-		cursor := canvasBox.Left
-		for _, bar := range bc.Bars {
+		for index, bar := range bc.Bars {
 			if len(bar.Label) > 0 {
 				barLabelBox := Box{
 					Top:    canvasBox.Bottom + DefaultXAxisMargin,
-					Left:   cursor,
-					Right:  cursor + bc.GetBarWidth() + bc.GetBarSpacing(),
+					Left:   slots[index].Left,
+					Right:  slots[index].Right,
 					Bottom: bc.GetHeight(),
 				}
 				lines := Text.WrapFit(r, bar.Label, barLabelBox.Width(), axisStyle)
@@ -376,7 +628,7 @@ func (bc BarChart) getAdjustedCanvasBox(r Renderer, canvasBox Box, yrange Range,
 		xbox := Box{
 			Top:    canvasBox.Top,
 			Left:   canvasBox.Left,
-			Right:  canvasBox.Left + totalWidth,
+			Right:  slots[len(slots)-1].Right,
 			Bottom: bc.GetHeight() - xaxisHeight,
 		}
 
@@ -388,7 +640,7 @@ func (bc BarChart) getAdjustedCanvasBox(r Renderer, canvasBox Box, yrange Range,
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
 
-	return canvasBox.OuterConstrain(bc.box(), axesOuterBox)
+	return canvasBox.OuterConstrain(bc.getContentBox(), axesOuterBox)
 }
 
 // box returns the chart bounds as a box.
@@ -416,11 +668,25 @@ func (bc BarChart) styleDefaultsBackground() Style {
 	}
 }
 
+// styleDefaultsBar returns the default style of a bar, or of a series of bars if the chart has series.
 func (bc BarChart) styleDefaultsBar(index int) Style {
+	count := len(bc.Bars)
+	if bc.hasSeries() {
+		count = len(bc.Series)
+	}
+	color := getSeriesColor(bc.GetColorPalette(), index, count)
 	return Style{
-		StrokeColor: bc.GetColorPalette().GetSeriesColor(index),
+		StrokeColor: color,
 		StrokeWidth: 3.0,
-		FillColor:   bc.GetColorPalette().GetSeriesColor(index),
+		FillColor:   color,
+	}
+}
+
+func (bc BarChart) styleDefaultsValue() Style {
+	return Style{
+		Font:      bc.GetFont(),
+		FontSize:  DefaultAxisFontSize,
+		FontColor: bc.GetColorPalette().TextColor(),
 	}
 }
 
@@ -0,0 +1,56 @@
+package chart
+
+import "math"
+
+// getBarError returns the error of a bar, or zero if it has none; bars of charts with series have none.
+func (bc BarChart) getBarError(index int) float64 {
+	if index < len(bc.BarErrors) && !bc.hasSeries() {
+		if barError := bc.BarErrors[index]; barError > 0 && !math.IsInf(barError, 0) {
+			return barError
+		}
+	}
+	return 0
+}
+
+// getErrorCapWidth returns the width of the whisker caps of a bar `barWidth` wide.
+func (bc BarChart) getErrorCapWidth(barWidth int) int {
+	if bc.ErrorCapWidth > 0 {
+		return bc.ErrorCapWidth
+	}
+	return barWidth >> 1
+}
+
+// drawErrors draws the whiskers of the bars with an error.
+func (bc BarChart) drawErrors(r Renderer, canvasBox Box, yr Range) {
+	if len(bc.BarErrors) == 0 {
+		return
+	}
+	slots := bc.getBarSlots(canvasBox)
+	bc.ErrorStyle.InheritFrom(bc.styleDefaultsErrors()).GetStrokeOptions().WriteToRenderer(r)
+
+	for index, bar := range bc.Bars {
+		barError := bc.getBarError(index)
+		if barError == 0 || slots[index].BarRight <= slots[index].BarLeft {
+			continue
+		}
+		x := (slots[index].BarLeft + slots[index].BarRight) >> 1
+		top := canvasBox.Bottom - yr.Translate(bar.Value+barError)
+		bottom := canvasBox.Bottom - yr.Translate(bar.Value-barError)
+		cap2 := bc.getErrorCapWidth(slots[index].BarRight-slots[index].BarLeft) >> 1
+
+		r.MoveTo(x, top)
+		r.LineTo(x, bottom)
+		r.MoveTo(x-cap2, top)
+		r.LineTo(x+cap2, top)
+		r.MoveTo(x-cap2, bottom)
+		r.LineTo(x+cap2, bottom)
+		r.Stroke()
+	}
+}
+
+func (bc BarChart) styleDefaultsErrors() Style {
+	return Style{
+		StrokeColor: bc.GetColorPalette().TextColor(),
+		StrokeWidth: 1.5,
+	}
+}
@@ -0,0 +1,62 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestBarChartGetRangesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Bars:      []Value{{Value: 2}, {Value: 10}},
+		BarErrors: []float64{1, 3},
+	}
+	yr := bc.getRanges()
+	assert.Equal(1, yr.GetMin())
+	assert.Equal(13, yr.GetMax())
+}
+
+func TestBarChartGetBarError(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Bars:      []Value{{Value: 2}, {Value: 10}, {Value: 5}},
+		BarErrors: []float64{1, -3},
+	}
+	assert.Equal(1.0, bc.getBarError(0))
+	assert.Zero(bc.getBarError(1))
+	assert.Zero(bc.getBarError(2))
+
+	assert.Equal(5, bc.getErrorCapWidth(10))
+	bc.ErrorCapWidth = 8
+	assert.Equal(8, bc.getErrorCapWidth(10))
+}
+
+func TestBarChartValidateErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Bars:      []Value{{Value: 2}, {Value: 10}},
+		BarErrors: []float64{math.NaN(), -1},
+	}
+	err := bc.Validate()
+	assert.NotNil(err)
+	assert.Len(err.(ValidationErrors), 2)
+}
+
+func TestBarChartRenderErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		YAxis:     YAxis{Style: StyleShow()},
+		Bars:      []Value{{Value: 2, Label: "A"}, {Value: -4, Label: "B"}, {Value: 7, Label: "C"}},
+		BarErrors: []float64{0.5, 1, 2},
+	}
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(bc.Render(SVG, buf))
+	assert.NotZero(buf.Len())
+}
@@ -0,0 +1,193 @@
+package chart
+
+import (
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// getHorizontalLayout lays out a horizontal bar chart; the bars extend right from the category labels on the left,
+// and the value axis is along the bottom. The value range is the x range of the layout.
+func (bc BarChart) getHorizontalLayout(r Renderer, yr Range, yf ValueFormatter) ChartLayout {
+	canvasBox := bc.getContentBox()
+	if bc.XAxis.Show {
+		canvasBox.Left += bc.getCategoryLabelWidth(r) + DefaultYAxisMargin
+	}
+	if bc.showValueLabels() {
+		canvasBox.Right -= bc.getValueLabelSize(r, yf).Width() + bc.getDataLabels().GetOffset()
+		canvasBox.Left += bc.getNegativeValueLabelSpace(r, yf, true)
+	}
+	yr.SetDomain(canvasBox.Width())
+
+	var ticks []Tick
+	if bc.YAxis.Style.Show {
+		valueAxis := bc.getValueAxis()
+		ticks = valueAxis.GetTicks(r, yr, bc.styleDefaultsAxes(), yf)
+		axesOuterBox := canvasBox.Clone().Grow(valueAxis.Measure(r, canvasBox, yr, bc.styleDefaultsAxes(), ticks))
+		canvasBox = canvasBox.OuterConstrain(bc.getContentBox(), axesOuterBox)
+		yr.SetDomain(canvasBox.Width())
+	}
+	return ChartLayout{Canvas: canvasBox, XRange: yr, XTicks: ticks}
+}
+
+// getValueAxis returns the value axis of a horizontal bar chart; it is the `YAxis` drawn along the bottom.
+func (bc BarChart) getValueAxis() XAxis {
+	return XAxis{
+		Name:           bc.YAxis.Name,
+		NameStyle:      bc.YAxis.NameStyle,
+		Style:          bc.YAxis.Style,
+		ValueFormatter: bc.YAxis.ValueFormatter,
+		TickFormatter:  bc.YAxis.TickFormatter,
+		Range:          bc.YAxis.Range,
+		Unit:           bc.YAxis.Unit,
+		TickStyle:      bc.YAxis.TickStyle,
+		Ticks:          bc.YAxis.Ticks,
+		GridLines:      bc.YAxis.GridLines,
+		GridMajorStyle: bc.YAxis.GridMajorStyle,
+		GridMinorStyle: bc.YAxis.GridMinorStyle,
+	}
+}
+
+// getCategoryLabelWidth returns the width of the widest bar label; labels are drawn on one line in full.
+func (bc BarChart) getCategoryLabelWidth(r Renderer) (width int) {
+	bc.XAxis.InheritFrom(bc.styleDefaultsAxes()).GetTextOptions().WriteToRenderer(r)
+	for _, bar := range bc.Bars {
+		if len(bar.Label) > 0 {
+			width = util.Math.MaxInt(width, r.MeasureText(bar.Label).Width())
+		}
+	}
+	return
+}
+
+// getHorizontalBarSlots lays out the bars top to bottom from the top of the canvas; the left and right of the
+// slots are their top and bottom.
+func (bc BarChart) getHorizontalBarSlots(canvasBox Box) []barSlot {
+	return bc.getBarSlots(Box{Left: canvasBox.Top, Right: canvasBox.Bottom})
+}
+
+// getHorizontalBaseline returns the canvas x coordinate the bars extend from; that is zero,
+// or the left or right of the range if zero is not in the range.
+func (bc BarChart) getHorizontalBaseline(canvasBox Box, yr Range) int {
+	return canvasBox.Left + yr.Translate(clampToRange(yr, 0))
+}
+
+func (bc BarChart) drawHorizontal(r Renderer, cl ChartLayout) {
+	canvasBox, yr := cl.Canvas, cl.XRange
+	slots := bc.getHorizontalBarSlots(canvasBox)
+	baseline := bc.getHorizontalBaseline(canvasBox, yr)
+
+	var left, right int
+	yf := bc.getValueFormatters()
+	for _, segment := range bc.getBarSegments(slots) {
+		left = canvasBox.Left + yr.Translate(clampToRange(yr, segment.From))
+		right = canvasBox.Left + yr.Translate(clampToRange(yr, segment.To))
+		setTooltip(r, bc.getTooltip(segment, yf))
+		Draw.RoundedBox(r, Box{
+			Top:    segment.Left,
+			Left:   util.Math.MinInt(left, right),
+			Right:  util.Math.MaxInt(left, right),
+			Bottom: segment.Right,
+		}, bc.getCornerRadii(segment, true, left, right), segment.Style)
+	}
+
+	if baseline > canvasBox.Left && baseline < canvasBox.Right {
+		bc.XAxis.InheritFrom(bc.styleDefaultsAxes()).GetStrokeOptions().WriteToRenderer(r)
+		r.MoveTo(baseline, canvasBox.Top)
+		r.LineTo(baseline, canvasBox.Bottom)
+		r.Stroke()
+	}
+
+	bc.drawHorizontalErrors(r, canvasBox, yr, slots)
+	// the labels of negative bars are drawn between the bars and the category axis.
+	axisBox := canvasBox.Clone()
+	axisBox.Left -= bc.getNegativeValueLabelSpace(r, bc.getValueFormatters(), true)
+	bc.drawCategoryAxis(r, axisBox, slots)
+	if bc.YAxis.Style.Show {
+		bc.getValueAxis().Render(r, canvasBox, yr, bc.styleDefaultsAxes(), cl.XTicks)
+	}
+	if bc.showValueLabels() {
+		bc.drawHorizontalValueLabels(r, canvasBox, yr, slots)
+	}
+}
+
+// drawHorizontalErrors draws the whiskers of the bars with an error.
+func (bc BarChart) drawHorizontalErrors(r Renderer, canvasBox Box, yr Range, slots []barSlot) {
+	if len(bc.BarErrors) == 0 {
+		return
+	}
+	bc.ErrorStyle.InheritFrom(bc.styleDefaultsErrors()).GetStrokeOptions().WriteToRenderer(r)
+
+	for index, bar := range bc.Bars {
+		barError := bc.getBarError(index)
+		if barError == 0 || slots[index].BarRight <= slots[index].BarLeft {
+			continue
+		}
+		y := (slots[index].BarLeft + slots[index].BarRight) >> 1
+		left := canvasBox.Left + yr.Translate(bar.Value-barError)
+		right := canvasBox.Left + yr.Translate(bar.Value+barError)
+		cap2 := bc.getErrorCapWidth(slots[index].BarRight-slots[index].BarLeft) >> 1
+
+		r.MoveTo(left, y)
+		r.LineTo(right, y)
+		r.MoveTo(left, y-cap2)
+		r.LineTo(left, y+cap2)
+		r.MoveTo(right, y-cap2)
+		r.LineTo(right, y+cap2)
+		r.Stroke()
+	}
+}
+
+// drawCategoryAxis draws the axis line on the left of the canvas, with the bar labels right aligned
+// and centered on their bars.
+func (bc BarChart) drawCategoryAxis(r Renderer, canvasBox Box, slots []barSlot) {
+	if !bc.XAxis.Show {
+		return
+	}
+	axisStyle := bc.XAxis.InheritFrom(bc.styleDefaultsAxes())
+	axisStyle.GetStrokeOptions().WriteToRenderer(r)
+	r.MoveTo(canvasBox.Left, canvasBox.Top)
+	r.LineTo(canvasBox.Left, canvasBox.Bottom)
+	r.Stroke()
+
+	for index, bar := range bc.Bars {
+		if index < len(bc.Bars)-1 {
+			axisStyle.GetStrokeOptions().WriteToRenderer(r)
+			r.MoveTo(canvasBox.Left, slots[index].Right)
+			r.LineTo(canvasBox.Left-DefaultHorizontalTickWidth, slots[index].Right)
+			r.Stroke()
+		}
+		if len(bar.Label) == 0 {
+			continue
+		}
+		axisStyle.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(bar.Label)
+		ty := ((slots[index].Left + slots[index].Right) >> 1) + (tb.Height() >> 1)
+		r.Text(bar.Label, canvasBox.Left-DefaultYAxisMargin-tb.Width(), ty)
+	}
+}
+
+// drawHorizontalValueLabels draws the value of each bar past its end; right of bars with positive values
+// and left of bars with negative values.
+func (bc BarChart) drawHorizontalValueLabels(r Renderer, canvasBox Box, yr Range, slots []barSlot) {
+	dl := bc.getDataLabels()
+	style := dl.Style.InheritFrom(bc.styleDefaultsValue())
+	vf := dl.GetFormatter(bc.getValueFormatters())
+	offset := dl.GetOffset()
+	labels := dataLabelPlacer{noThinning: dl.NoThinning}
+	for index, bar := range bc.Bars {
+		if slots[index].BarRight <= slots[index].BarLeft {
+			continue
+		}
+		label := vf(bar.Value)
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+
+		bx := canvasBox.Left + yr.Translate(bc.getValueLabelAnchor(index))
+		ty := ((slots[index].BarLeft + slots[index].BarRight) >> 1) + (tb.Height() >> 1)
+		tx := bx + offset
+		if bar.Value < 0 {
+			tx = bx - offset - tb.Width()
+		}
+		if labels.place(Box{Top: ty - tb.Height(), Left: tx, Right: tx + tb.Width(), Bottom: ty}) {
+			r.Text(label, tx, ty)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package chart
+
+import (
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// barSlot is the horizontal extent of a bar and the spacing around it.
+type barSlot struct {
+	// Left and Right bound the bar and its spacing; the bar label is centered within them.
+	Left, Right int
+	// BarLeft and BarRight bound the bar.
+	BarLeft, BarRight int
+}
+
+// getBarSlots lays out the bars left to right from the left of the canvas.
+// Bars are `BarWidth` wide and `BarSpacing` apart unless the chart sets `BarWeights` or `BarSpacings`;
+// widths are shrunk to fit the canvas if needed.
+func (bc BarChart) getBarSlots(canvasBox Box) []barSlot {
+	width, spacing, _ := bc.calculateScaledTotalWidth(canvasBox)
+
+	spacings := make([]int, len(bc.Bars))
+	var totalSpacing int
+	for index := range bc.Bars {
+		spacings[index] = spacing
+		if index < len(bc.BarSpacings) && bc.BarSpacings[index] >= 0 {
+			spacings[index] = bc.BarSpacings[index]
+		}
+		totalSpacing += spacings[index]
+	}
+
+	widths := make([]int, len(bc.Bars))
+	available := canvasBox.Width() - totalSpacing
+	if available < 0 {
+		available = 0
+	}
+	if len(bc.BarWeights) > 0 {
+		weights := bc.getBarWeights()
+		var totalWeight float64
+		for _, weight := range weights {
+			totalWeight += weight
+		}
+		if totalWeight > 0 {
+			for index, weight := range weights {
+				widths[index] = int(math.Floor(float64(available) * weight / totalWeight))
+			}
+		}
+	} else {
+		if len(bc.BarSpacings) > 0 {
+			// explicit spacings are kept as they are; only the bars shrink to fit.
+			width = util.Math.MinInt(bc.getGroupWidth(), available/len(bc.Bars))
+		}
+		for index := range widths {
+			widths[index] = width
+		}
+	}
+
+	slots := make([]barSlot, len(bc.Bars))
+	cursor := canvasBox.Left
+	for index := range bc.Bars {
+		barLeft := cursor + (spacings[index] >> 1)
+		slots[index] = barSlot{
+			Left:     cursor,
+			Right:    cursor + widths[index] + spacings[index],
+			BarLeft:  barLeft,
+			BarRight: barLeft + widths[index],
+		}
+		cursor = slots[index].Right
+	}
+	return slots
+}
+
+// getBarWeights returns the weight of each bar; bars without a weight, or with a negative or non finite weight, weigh zero.
+func (bc BarChart) getBarWeights() []float64 {
+	weights := make([]float64, len(bc.Bars))
+	for index := range weights {
+		if index < len(bc.BarWeights) {
+			if weight := bc.BarWeights[index]; weight > 0 && !math.IsInf(weight, 0) {
+				weights[index] = weight
+			}
+		}
+	}
+	return weights
+}
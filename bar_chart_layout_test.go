@@ -0,0 +1,74 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestBarChartGetBarSlotsDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		BarWidth:   10,
+		BarSpacing: 4,
+		Bars:       []Value{{Value: 1}, {Value: 2}, {Value: 3}},
+	}
+	slots := bc.getBarSlots(Box{Left: 100, Right: 1000, Bottom: 100})
+	assert.Len(slots, 3)
+	assert.Equal(barSlot{Left: 100, Right: 114, BarLeft: 102, BarRight: 112}, slots[0])
+	assert.Equal(barSlot{Left: 114, Right: 128, BarLeft: 116, BarRight: 126}, slots[1])
+	assert.Equal(barSlot{Left: 128, Right: 142, BarLeft: 130, BarRight: 140}, slots[2])
+}
+
+func TestBarChartGetBarSlotsWeights(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		BarSpacing: 10,
+		BarWeights: []float64{1, 3},
+		Bars:       []Value{{Value: 1}, {Value: 2}, {Value: 3}},
+	}
+	slots := bc.getBarSlots(Box{Left: 0, Right: 430, Bottom: 100})
+	assert.Len(slots, 3)
+	assert.Equal(100, slots[0].BarRight-slots[0].BarLeft)
+	assert.Equal(300, slots[1].BarRight-slots[1].BarLeft)
+	assert.Equal(0, slots[2].BarRight-slots[2].BarLeft)
+	assert.Equal(430, slots[2].Right)
+}
+
+func TestBarChartGetBarSlotsSpacings(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		BarWidth:    10,
+		BarSpacings: []int{2, 20},
+		BarSpacing:  6,
+		Bars:        []Value{{Value: 1}, {Value: 2}, {Value: 3}},
+	}
+	slots := bc.getBarSlots(Box{Left: 0, Right: 1000, Bottom: 100})
+	assert.Equal(barSlot{Left: 0, Right: 12, BarLeft: 1, BarRight: 11}, slots[0])
+	assert.Equal(barSlot{Left: 12, Right: 42, BarLeft: 22, BarRight: 32}, slots[1])
+	assert.Equal(barSlot{Left: 42, Right: 58, BarLeft: 45, BarRight: 55}, slots[2])
+
+	// bars shrink to fit the canvas, the spacings don't.
+	slots = bc.getBarSlots(Box{Left: 0, Right: 43, Bottom: 100})
+	assert.Equal(5, slots[0].BarRight-slots[0].BarLeft)
+	assert.Equal(42, slots[2].Right)
+}
+
+func TestBarChartRenderWeights(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		XAxis:      StyleShow(),
+		YAxis:      YAxis{Style: StyleShow()},
+		BarSpacing: 4,
+		BarWeights: []float64{5, 1, 2},
+		Bars:       []Value{{Value: 1, Label: "A"}, {Value: 2, Label: "B"}, {Value: 3, Label: "C"}},
+	}
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(bc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+}
@@ -0,0 +1,288 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// BarSeries is a series of a grouped or stacked bar chart; it has a value for each category, by index.
+type BarSeries struct {
+	Name   string
+	Style  Style
+	Values []float64
+}
+
+// GetValue returns the value of the series for a category, or zero if it has none.
+func (bs BarSeries) GetValue(index int) float64 {
+	if index < len(bs.Values) {
+		if value := bs.Values[index]; !math.IsNaN(value) && !math.IsInf(value, 0) {
+			return value
+		}
+	}
+	return 0
+}
+
+// barSegment is a bar as drawn; its extent across the slot of its category and the values it extends between.
+// RoundBase and RoundEnd are if its corners at `From` and at `To` can be rounded.
+type barSegment struct {
+	Left, Right int
+	From, To    float64
+	Style       Style
+
+	RoundBase, RoundEnd bool
+
+	// Series and Label are the names of the series and the bar of the segment, and Value its value, for tooltips.
+	Series, Label string
+	Value         float64
+}
+
+// getTooltip returns the tooltip of a segment, with its size formatted as on the value axis.
+func (bc BarChart) getTooltip(segment barSegment, yf ValueFormatter) Tooltip {
+	return Tooltip{
+		Title: tooltipTitle(segment.Series, segment.Label, yf(segment.To-segment.From)),
+		X:     segment.Label,
+		Y:     formatTooltipValue(segment.Value),
+	}
+}
+
+// getCornerRadii returns the radii of the corners of a segment drawn from one canvas coordinate to another,
+// along x for horizontal bars and along y otherwise.
+func (bc BarChart) getCornerRadii(segment barSegment, horizontal bool, from, to int) [4]int {
+	reversed := to > from
+	if horizontal {
+		reversed = to < from
+	}
+	return barCornerRadii(bc.CornerRadius, horizontal, reversed, segment.RoundBase, segment.RoundEnd)
+}
+
+// GetBarGroupSpacing returns the spacing between the bars of a group or a default.
+func (bc BarChart) GetBarGroupSpacing() int {
+	if bc.BarGroupSpacing == 0 {
+		return DefaultBarGroupSpacing
+	}
+	return bc.BarGroupSpacing
+}
+
+// hasSeries returns if the chart draws series of bars for its categories rather than a bar each.
+func (bc BarChart) hasSeries() bool {
+	return len(bc.Series) > 0
+}
+
+// getGroupWidth returns the width of the bars of a category together; that is the bar width, or for grouped
+// series, a bar width for each series with the group spacing between them.
+func (bc BarChart) getGroupWidth() int {
+	if !bc.hasSeries() || bc.Stacked {
+		return bc.GetBarWidth()
+	}
+	return len(bc.Series)*bc.GetBarWidth() + (len(bc.Series)-1)*bc.GetBarGroupSpacing()
+}
+
+// getBarSegments returns the bars to draw in the given slots; one for each category,
+// or one for each series in each category, side by side or stacked.
+func (bc BarChart) getBarSegments(slots []barSlot) []barSegment {
+	var segments []barSegment
+	for index, bar := range bc.Bars {
+		slot := slots[index]
+		if slot.BarRight <= slot.BarLeft {
+			continue
+		}
+		if !bc.hasSeries() {
+			segments = append(segments, barSegment{
+				Left:      slot.BarLeft,
+				Right:     slot.BarRight,
+				To:        bar.Value,
+				Style:     bar.Style.InheritFrom(bc.styleDefaultsBar(index)),
+				RoundBase: bc.Corners == BarCornersAll,
+				RoundEnd:  true,
+				Label:     bar.Label,
+				Value:     bar.Value,
+			})
+			continue
+		}
+		if bc.Stacked {
+			segments = append(segments, bc.getStackedSegments(slot, index)...)
+			continue
+		}
+		segments = append(segments, bc.getGroupedSegments(slot, index)...)
+	}
+	return segments
+}
+
+// getGroupedSegments splits the bar of a slot evenly between the series, keeping the group spacing between them
+// if there is room for it.
+func (bc BarChart) getGroupedSegments(slot barSlot, index int) []barSegment {
+	count := len(bc.Series)
+	width := slot.BarRight - slot.BarLeft
+	spacing := bc.GetBarGroupSpacing()
+	if width-(count-1)*spacing < count {
+		spacing = 0
+	}
+	barWidth := (width - (count-1)*spacing) / count
+	if barWidth == 0 {
+		return nil
+	}
+	// the pixels left over from the split are spread on both sides of the group.
+	left := slot.BarLeft + ((width - count*barWidth - (count-1)*spacing) >> 1)
+
+	segments := make([]barSegment, count)
+	for seriesIndex, series := range bc.Series {
+		segments[seriesIndex] = barSegment{
+			Left:      left,
+			Right:     left + barWidth,
+			To:        series.GetValue(index),
+			Style:     series.Style.InheritFrom(bc.styleDefaultsBar(seriesIndex)),
+			RoundBase: bc.Corners == BarCornersAll,
+			RoundEnd:  true,
+			Series:    series.Name,
+			Label:     bc.Bars[index].Label,
+			Value:     series.GetValue(index),
+		}
+		left += barWidth + spacing
+	}
+	return segments
+}
+
+// isNormalized returns if the stacks of the series are scaled to 100%.
+func (bc BarChart) isNormalized() bool {
+	return bc.hasSeries() && bc.Stacked && bc.Normalized
+}
+
+// getStackValue returns the value of a series for a category as it is stacked; its share of the total of
+// the category, by magnitude, if the stacks are normalized.
+func (bc BarChart) getStackValue(series BarSeries, index int) float64 {
+	value := series.GetValue(index)
+	if !bc.isNormalized() || value == 0 {
+		return value
+	}
+	var total float64
+	for _, s := range bc.Series {
+		total += math.Abs(s.GetValue(index))
+	}
+	return value / total
+}
+
+// getStackedSegments stacks the values of the series in a slot; positive values up from zero,
+// and negative values down from it.
+func (bc BarChart) getStackedSegments(slot barSlot, index int) []barSegment {
+	var segments []barSegment
+	var positive, negative float64
+	for seriesIndex, series := range bc.Series {
+		value := bc.getStackValue(series, index)
+		if value == 0 {
+			continue
+		}
+		segment := barSegment{
+			Left:   slot.BarLeft,
+			Right:  slot.BarRight,
+			Style:  series.Style.InheritFrom(bc.styleDefaultsBar(seriesIndex)),
+			Series: series.Name,
+			Label:  bc.Bars[index].Label,
+			Value:  series.GetValue(index),
+		}
+		if value > 0 {
+			segment.From, segment.To = positive, positive+value
+			positive += value
+		} else {
+			segment.From, segment.To = negative, negative+value
+			negative += value
+		}
+		segments = append(segments, segment)
+	}
+	bc.roundStack(segments)
+	return segments
+}
+
+// roundStack marks the segments at the ends of a stack to be rounded, and the segment at its base too
+// if the stack is all on one side of zero and all corners are rounded.
+func (bc BarChart) roundStack(segments []barSegment) {
+	firstPositive, lastPositive, firstNegative, lastNegative := -1, -1, -1, -1
+	for index, segment := range segments {
+		if segment.To > segment.From {
+			if firstPositive < 0 {
+				firstPositive = index
+			}
+			lastPositive = index
+		} else {
+			if firstNegative < 0 {
+				firstNegative = index
+			}
+			lastNegative = index
+		}
+	}
+	if lastPositive >= 0 {
+		segments[lastPositive].RoundEnd = true
+	}
+	if lastNegative >= 0 {
+		segments[lastNegative].RoundEnd = true
+	}
+	if bc.Corners == BarCornersAll && (firstPositive < 0) != (firstNegative < 0) {
+		segments[util.Math.MaxInt(firstPositive, firstNegative)].RoundBase = true
+	}
+}
+
+// getSeriesBounds returns the smallest and largest values the bars of the series extend to.
+func (bc BarChart) getSeriesBounds() (min, max float64) {
+	min, max = math.MaxFloat64, -math.MaxFloat64
+	for index := range bc.Bars {
+		var positive, negative float64
+		for _, series := range bc.Series {
+			value := bc.getStackValue(series, index)
+			if bc.Stacked {
+				if value > 0 {
+					positive += value
+				} else {
+					negative += value
+				}
+				continue
+			}
+			min, max = math.Min(min, value), math.Max(max, value)
+		}
+		if bc.Stacked {
+			min, max = math.Min(min, negative), math.Max(max, positive)
+		}
+	}
+	if bc.isNormalized() {
+		// shares that add up to 100% can miss it by a rounding error, which would add a tick.
+		min, max = math.Round(min*1e9)/1e9, math.Round(max*1e9)/1e9
+	}
+	return
+}
+
+// getLegendEntries returns the names and styles of the series, in series order.
+func (bc BarChart) getLegendEntries() (labels []string, styles []Style) {
+	for index, series := range bc.Series {
+		if len(series.Name) > 0 {
+			labels = append(labels, series.Name)
+			styles = append(styles, series.Style.InheritFrom(bc.styleDefaultsBar(index)))
+		}
+	}
+	return
+}
+
+// BarChartLegend returns a legend renderable for a bar chart with series, which names the series colors.
+func BarChartLegend(bc *BarChart, userDefaults ...Style) Renderable {
+	return func(r Renderer, cb Box, chartDefaults Style) {
+		labels, swatches := bc.getLegendEntries()
+		drawSwatchLegend(r, cb, chartDefaults, userDefaults, labels, swatches)
+	}
+}
+
+// describeSeries summarizes the series, i.e. "Grouped bar chart of 2 series in 2 categories: a (x 1.00, y 2.00); ...".
+func (bc BarChart) describeSeries() string {
+	kind := "Grouped"
+	if bc.Stacked {
+		kind = "Stacked"
+	}
+	summaries := make([]string, len(bc.Series))
+	for seriesIndex, series := range bc.Series {
+		values := make([]Value, len(bc.Bars))
+		for index, bar := range bc.Bars {
+			values[index] = Value{Label: bar.Label, Value: series.GetValue(index)}
+		}
+		summaries[seriesIndex] = fmt.Sprintf("%s (%s)", series.Name, listValues(values))
+	}
+	return fmt.Sprintf("%s bar chart of %d series in %d categories: %s.", kind, len(bc.Series), len(bc.Bars), strings.Join(summaries, "; "))
+}
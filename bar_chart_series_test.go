@@ -0,0 +1,165 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func barChartSeriesTest() BarChart {
+	return BarChart{
+		XAxis: StyleShow(),
+		YAxis: YAxis{
+			Style: StyleShow(),
+		},
+		Bars: []Value{{Label: "Q1"}, {Label: "Q2"}, {Label: "Q3"}},
+		Series: []BarSeries{
+			{Name: "North", Values: []float64{1, 3, 2}},
+			{Name: "South", Values: []float64{2, -1, 4}},
+		},
+	}
+}
+
+func TestBarChartSeriesGetRanges(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := barChartSeriesTest()
+	yr := bc.getRanges()
+	assert.Equal(-1, yr.GetMin())
+	assert.Equal(4, yr.GetMax())
+
+	bc = barChartSeriesTest()
+	bc.Stacked = true
+	yr = bc.getRanges()
+	assert.Equal(-1, yr.GetMin())
+	assert.Equal(6, yr.GetMax())
+}
+
+func TestBarChartGroupedSegments(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := barChartSeriesTest()
+	bc.BarWidth = 20
+	assert.Equal(42, bc.getGroupWidth())
+
+	segments := bc.getGroupedSegments(barSlot{BarLeft: 100, BarRight: 143}, 1)
+	assert.Len(segments, 2)
+	assert.Equal(100, segments[0].Left)
+	assert.Equal(120, segments[0].Right)
+	assert.Equal(122, segments[1].Left)
+	assert.Equal(142, segments[1].Right)
+	assert.Equal(3.0, segments[0].To)
+	assert.Equal(-1.0, segments[1].To)
+
+	// without room for the spacing, the bars touch.
+	segments = bc.getGroupedSegments(barSlot{BarLeft: 0, BarRight: 3}, 0)
+	assert.Len(segments, 2)
+	assert.Equal(1, segments[1].Left)
+
+	assert.Empty(bc.getGroupedSegments(barSlot{BarLeft: 0, BarRight: 1}, 0))
+}
+
+func TestBarChartStackedSegments(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := barChartSeriesTest()
+	bc.Series = append(bc.Series, BarSeries{Values: []float64{5, -2, math.NaN()}})
+	bc.Stacked = true
+	assert.Equal(bc.GetBarWidth(), bc.getGroupWidth())
+
+	segments := bc.getStackedSegments(barSlot{BarLeft: 10, BarRight: 20}, 1)
+	assert.Len(segments, 3)
+	assert.Equal(0.0, segments[0].From)
+	assert.Equal(3.0, segments[0].To)
+	assert.Equal(0.0, segments[1].From)
+	assert.Equal(-1.0, segments[1].To)
+	assert.Equal(-1.0, segments[2].From)
+	assert.Equal(-3.0, segments[2].To)
+
+	// zero and non finite values are not drawn.
+	assert.Len(bc.getStackedSegments(barSlot{BarLeft: 10, BarRight: 20}, 2), 2)
+}
+
+func TestBarChartNormalized(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := barChartSeriesTest()
+	bc.Stacked = true
+	bc.Normalized = true
+
+	// Q1 is a third north and two thirds south; Q2 is three quarters north up and a quarter south down.
+	segments := bc.getStackedSegments(barSlot{BarLeft: 10, BarRight: 20}, 0)
+	assert.InDelta(1.0/3.0, segments[0].To, 1e-9)
+	assert.InDelta(1.0, segments[1].To, 1e-9)
+	segments = bc.getStackedSegments(barSlot{BarLeft: 10, BarRight: 20}, 1)
+	assert.Equal(0.75, segments[0].To)
+	assert.Equal(-0.25, segments[1].To)
+
+	yr := bc.getRanges()
+	assert.Equal(-0.25, yr.GetMin())
+	assert.Equal(1.0, yr.GetMax())
+	assert.Equal("50.00%", bc.getValueFormatters()(0.5))
+
+	bc.YAxis.ValueFormatter = FloatValueFormatter
+	assert.Equal("0.50", bc.getValueFormatters()(0.5))
+
+	// grouped series are not normalized.
+	bc = barChartSeriesTest()
+	bc.Normalized = true
+	assert.Equal(4.0, bc.getRanges().GetMax())
+	assert.Equal("0.50", bc.getValueFormatters()(0.5))
+
+	bc.Stacked = true
+	buf := bytes.NewBuffer([]byte{})
+	assert.Nil(bc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "100.00%"))
+}
+
+func TestBarChartSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, stacked := range []bool{false, true} {
+		for _, horizontal := range []bool{false, true} {
+			bc := barChartSeriesTest()
+			bc.Stacked = stacked
+			bc.Horizontal = horizontal
+			bc.Elements = []Renderable{BarChartLegend(&bc)}
+
+			buf := bytes.NewBuffer([]byte{})
+			assert.Nil(bc.Render(SVG, buf))
+			assert.True(strings.Contains(buf.String(), "North"))
+			assert.True(strings.Contains(buf.String(), "South"))
+		}
+	}
+
+	bc := barChartSeriesTest()
+	buf := bytes.NewBuffer([]byte{})
+	assert.Nil(bc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+}
+
+func TestBarChartSeriesAccessibility(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := barChartSeriesTest()
+	bc.Stacked = true
+	assert.Equal("Stacked bar chart of 2 series in 3 categories: North (Q1 1.00, Q2 3.00, Q3 2.00); South (Q1 2.00, Q2 -1.00, Q3 4.00).", bc.describeSeries())
+}
+
+func TestBarChartSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := barChartSeriesTest()
+	assert.Nil(bc.Validate())
+
+	bc.Series[0].Values = []float64{1, 2, 3, 4}
+	assert.True(errors.Is(bc.Validate(), ErrLengthMismatch))
+
+	bc = barChartSeriesTest()
+	bc.Series[1].Values[0] = math.Inf(1)
+	assert.True(errors.Is(bc.Validate(), ErrInvalidRange))
+}
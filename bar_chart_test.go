@@ -2,7 +2,9 @@ package chart
 
 import (
 	"bytes"
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	assert "github.com/blendlabs/go-assert"
@@ -318,3 +320,152 @@ func TestBarChatGetTitleFontSize(t *testing.T) {
 	size = BarChart{Width: 128, Height: 128}.getTitleFontSize()
 	assert.Equal(10, size)
 }
+
+func TestBarChartGetRangesNegative(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Bars: []Value{
+			{Value: -5.0},
+			{Value: 10.0},
+		},
+	}
+	yr := bc.getRanges()
+	assert.Equal(-5, yr.GetMin())
+	assert.Equal(10, yr.GetMax())
+
+	bc.Bars = []Value{{Value: -5.0}, {Value: -1.0}}
+	yr = bc.getRanges()
+	assert.Equal(-5, yr.GetMin())
+	assert.Equal(0, yr.GetMax())
+}
+
+func TestBarChartGetBaseline(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{}
+	canvasBox := Box{Top: 0, Left: 0, Right: 100, Bottom: 100}
+
+	yr := &ContinuousRange{Min: -10, Max: 10, Domain: 100}
+	assert.Equal(50, bc.getBaseline(canvasBox, yr))
+
+	yr = &ContinuousRange{Min: 1, Max: 10, Domain: 100}
+	assert.Equal(100, bc.getBaseline(canvasBox, yr))
+
+	yr = &ContinuousRange{Min: -10, Max: -1, Domain: 100}
+	assert.Equal(0, bc.getBaseline(canvasBox, yr))
+}
+
+func TestBarChartRenderNegative(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		YAxis: YAxis{Style: StyleShow()},
+		XAxis: StyleShow(),
+		Bars: []Value{
+			{Value: 4, Label: "Up"},
+			{Value: -3, Label: "Down"},
+			{Value: -1, Label: "Down"},
+		},
+	}
+	assert.Nil(bc.Validate())
+
+	buf := bytes.NewBuffer([]byte{})
+	assert.Nil(bc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	bc.Bars = []Value{{Value: -4}}
+	assert.Nil(bc.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestBarChartRenderHorizontal(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Width:      1024,
+		Horizontal: true,
+		XAxis:      StyleShow(),
+		YAxis: YAxis{
+			Style: StyleShow(),
+		},
+		ValueStyle: StyleShow(),
+		Bars: []Value{
+			{Value: 1.0, Label: "A category name that is much longer than a bar is wide"},
+			{Value: -2.0, Label: "Two"},
+			{Value: 3.0, Label: "Three"},
+		},
+	}
+
+	var cl ChartLayout
+	bc.BeforeRender = func(r Renderer, layout ChartLayout) {
+		cl = layout
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	err := bc.Render(SVG, buf)
+	assert.Nil(err)
+	assert.True(strings.Contains(buf.String(), "A category name that is much longer than a bar is wide"))
+	assert.True(strings.Contains(buf.String(), "3.00"))
+	assert.True(strings.Contains(buf.String(), "-2.00"))
+
+	assert.Nil(cl.YRange)
+	assert.NotNil(cl.XRange)
+	assert.NotEmpty(cl.XTicks)
+	assert.Equal(cl.Canvas.Width(), cl.XRange.GetDomain())
+
+	buf = bytes.NewBuffer([]byte{})
+	assert.Nil(bc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+}
+
+func TestBarChartGetHorizontalBarSlots(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		BarWidth:   10,
+		BarSpacing: 10,
+		Bars:       []Value{{Value: 1}, {Value: 2}},
+	}
+
+	slots := bc.getHorizontalBarSlots(Box{Top: 100, Left: 10, Right: 500, Bottom: 140})
+	assert.Len(slots, 2)
+	assert.Equal(100, slots[0].Left)
+	assert.Equal(140, slots[1].Right)
+	assert.True(slots[0].BarLeft < slots[0].BarRight)
+	assert.True(slots[0].BarRight <= slots[1].BarLeft)
+}
+
+func TestBarChartGetHorizontalBaseline(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{}
+	canvasBox := Box{Top: 0, Left: 10, Right: 110, Bottom: 100}
+
+	yr := &ContinuousRange{Min: -50, Max: 50, Domain: 100}
+	assert.Equal(60, bc.getHorizontalBaseline(canvasBox, yr))
+
+	yr = &ContinuousRange{Min: 10, Max: 110, Domain: 100}
+	assert.Equal(10, bc.getHorizontalBaseline(canvasBox, yr))
+}
+
+func TestBarChartRenderValueLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		ValueStyle: StyleShow(),
+		YAxis: YAxis{
+			ValueFormatter: func(v interface{}) string {
+				return fmt.Sprintf("value %v", v)
+			},
+		},
+		Bars: []Value{
+			{Value: 1.0, Label: "One"},
+			{Value: -2.0, Label: "Two"},
+		},
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	assert.Nil(bc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "value 1"))
+	assert.True(strings.Contains(buf.String(), "value -2"))
+}
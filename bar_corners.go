@@ -0,0 +1,42 @@
+package chart
+
+// BarCorners is which corners of a bar a corner radius rounds.
+type BarCorners int
+
+const (
+	// BarCornersEnd rounds the corners at the end of a bar, away from its baseline: the top corners of a bar
+	// with a positive value, and the bottom corners of a bar with a negative value. It is the default.
+	BarCornersEnd BarCorners = iota
+	// BarCornersAll rounds all four corners of a bar.
+	BarCornersAll
+)
+
+// barCornerRadii returns the radii of the corners of a bar, as for `Draw.RoundedBox`, rounding the end of the bar
+// if `roundEnd` and its base, at the baseline, if `roundBase`. Horizontal bars extend right from their base,
+// and vertical bars up, unless they are `reversed`.
+func barCornerRadii(radius int, horizontal, reversed, roundBase, roundEnd bool) (radii [4]int) {
+	if radius <= 0 {
+		return
+	}
+	// the sides of the box in the order of the corners: the top, right, bottom and left sides.
+	const top, right, bottom, left = 0, 1, 2, 3
+	endSide, baseSide := top, bottom
+	if horizontal {
+		endSide, baseSide = right, left
+	}
+	if reversed {
+		endSide, baseSide = baseSide, endSide
+	}
+	roundSide := func(side int) {
+		// corner i is between side i - 1 and side i: top left, top right, bottom right, bottom left.
+		radii[side] = radius
+		radii[(side+1)%4] = radius
+	}
+	if roundEnd {
+		roundSide(endSide)
+	}
+	if roundBase {
+		roundSide(baseSide)
+	}
+	return
+}
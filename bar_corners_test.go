@@ -0,0 +1,115 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestBarCornerRadii(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([4]int{}, barCornerRadii(0, false, false, true, true))
+	assert.Equal([4]int{4, 4, 0, 0}, barCornerRadii(4, false, false, false, true))
+	assert.Equal([4]int{0, 0, 4, 4}, barCornerRadii(4, false, true, false, true))
+	assert.Equal([4]int{0, 4, 4, 0}, barCornerRadii(4, true, false, false, true))
+	assert.Equal([4]int{4, 0, 0, 4}, barCornerRadii(4, true, true, false, true))
+	assert.Equal([4]int{4, 4, 4, 4}, barCornerRadii(4, false, false, true, true))
+}
+
+func TestDrawRoundedBox(t *testing.T) {
+	assert := assert.New(t)
+
+	draw := func(box Box, radii [4]int) string {
+		r, err := SVG(100, 100)
+		assert.Nil(err)
+		Draw.RoundedBox(r, box, radii, Style{FillColor: ColorBlue})
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(r.Save(buffer))
+		return buffer.String()
+	}
+
+	// the top corners are curves of the radius, and the bottom corners square.
+	rounded := draw(Box{Top: 10, Left: 10, Right: 50, Bottom: 90}, [4]int{8, 8, 0, 0})
+	assert.Equal(2, strings.Count(rounded, "\nC"))
+	assert.True(strings.Contains(rounded, "M 18 10\nL 42 10\nC46,10 50,14 50,18"), rounded)
+
+	// the radii of a side are scaled down to fit it.
+	narrow := draw(Box{Top: 10, Left: 10, Right: 20, Bottom: 90}, [4]int{8, 8, 0, 0})
+	assert.True(strings.Contains(narrow, "M 15 10\nL 15 10"), narrow)
+
+	assert.Zero(strings.Count(draw(Box{Top: 10, Left: 10, Right: 50, Bottom: 90}, [4]int{}), "\nC"))
+}
+
+func TestBarChartCornerRadius(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Width:        400,
+		Height:       300,
+		CornerRadius: 6,
+		Bars:         []Value{{Label: "a", Value: 1}, {Label: "b", Value: 2}},
+		Series: []BarSeries{
+			{Values: []float64{1, -1}},
+			{Values: []float64{2, 3}},
+			{Values: []float64{3, 0}},
+		},
+		Stacked: true,
+	}
+	segments := bc.getBarSegments(bc.getBarSlots(NewBox(0, 0, 400, 300)))
+	var ends, bases int
+	for _, segment := range segments {
+		if segment.RoundEnd {
+			ends++
+		}
+		if segment.RoundBase {
+			bases++
+		}
+	}
+	// the top of each stack, and the bottom of the stack below zero.
+	assert.Equal(3, ends)
+	assert.Zero(bases)
+
+	bc.Corners = BarCornersAll
+	segments = bc.getBarSegments(bc.getBarSlots(NewBox(0, 0, 400, 300)))
+	// only the stack that is all above zero is rounded at its base.
+	assert.True(segments[0].RoundBase)
+	assert.False(segments[3].RoundBase)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(bc.Render(SVG, buffer))
+	assert.True(strings.Count(buffer.String(), "\nC") > 0)
+}
+
+func TestStackedBarChartCornerRadius(t *testing.T) {
+	assert := assert.New(t)
+
+	first, last := stackEnds([]Value{{Value: 0}, {Value: 1}, {Value: 2}, {Value: 0}})
+	assert.Equal(1, first)
+	assert.Equal(2, last)
+
+	sbc := StackedBarChart{CornerRadius: 5}
+	assert.Equal([4]int{5, 5, 0, 0}, sbc.getCornerRadii(true, true))
+	sbc.Corners = BarCornersAll
+	assert.Equal([4]int{0, 0, 5, 5}, sbc.getCornerRadii(false, true))
+}
+
+func TestHistogramSeriesCornerRadius(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			HistogramSeries{
+				Style:        Style{Show: true, FillColor: ColorBlue},
+				CornerRadius: 4,
+				InnerSeries:  ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, -2, 3}},
+			},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	// two rounded corners a bar.
+	assert.Equal(6, strings.Count(buffer.String(), "\nC"))
+}
@@ -12,6 +12,7 @@ type BollingerBandsSeries struct {
 	Name  string
 	Style Style
 	YAxis YAxisType
+	XAxis XAxisType
 
 	Period      int
 	K           float64
@@ -35,6 +36,11 @@ func (bbs BollingerBandsSeries) GetYAxis() YAxisType {
 	return bbs.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (bbs BollingerBandsSeries) GetXAxis() XAxisType {
+	return bbs.XAxis
+}
+
 // GetPeriod returns the window size.
 func (bbs BollingerBandsSeries) GetPeriod() int {
 	if bbs.Period == 0 {
@@ -114,13 +120,18 @@ func (bbs *BollingerBandsSeries) GetBoundedLastValues() (x, y1, y2 float64) {
 
 // Render renders the series.
 func (bbs *BollingerBandsSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
-	s := bbs.Style.InheritFrom(defaults.InheritFrom(Style{
+	s := bbs.Style.InheritFrom(defaults.InheritFrom(styleDefaultsBands()))
+
+	Draw.BoundedSeries(r, canvasBox, xrange, yrange, s, bbs, bbs.GetPeriod())
+}
+
+// styleDefaultsBands returns the style bands are drawn with unless the chart or series style sets it.
+func styleDefaultsBands() Style {
+	return Style{
 		StrokeWidth: 1.0,
 		StrokeColor: DefaultAxisColor.WithAlpha(64),
 		FillColor:   DefaultAxisColor.WithAlpha(32),
-	}))
-
-	Draw.BoundedSeries(r, canvasBox, xrange, yrange, s, bbs, bbs.GetPeriod())
+	}
 }
 
 // Validate validates the series.
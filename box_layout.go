@@ -0,0 +1,209 @@
+package chart
+
+import (
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// LayoutSizeKind is how a `LayoutSize` is measured.
+type LayoutSizeKind int
+
+const (
+	// LayoutSizeIntrinsic is the size the element measures itself at; it is the default.
+	LayoutSizeIntrinsic LayoutSizeKind = iota
+	// LayoutSizePixels is a fixed number of pixels.
+	LayoutSizePixels
+	// LayoutSizePercent is a percentage of the length of the bounds being laid out.
+	LayoutSizePercent
+	// LayoutSizeWeight is a share of the length left over by the other elements along the same axis, relative to
+	// the weights of the other weighted elements, i.e. rows of 3 and 1 take three quarters and a quarter.
+	LayoutSizeWeight
+)
+
+// LayoutSize is a size constraint of an element along the axis it is docked on; the zero value is intrinsic.
+type LayoutSize struct {
+	Kind  LayoutSizeKind
+	Value float64
+}
+
+// Pixels returns a fixed size in pixels.
+func Pixels(pixels int) LayoutSize {
+	return LayoutSize{Kind: LayoutSizePixels, Value: float64(pixels)}
+}
+
+// Percent returns a size that is a percentage of the length of the bounds, from 0 to 100.
+func Percent(percent float64) LayoutSize {
+	return LayoutSize{Kind: LayoutSizePercent, Value: percent}
+}
+
+// Weight returns a size that is a weighted share of the length left over by the other elements.
+func Weight(weight float64) LayoutSize {
+	return LayoutSize{Kind: LayoutSizeWeight, Value: weight}
+}
+
+// IsZero returns if the size is intrinsic.
+func (ls LayoutSize) IsZero() bool {
+	return ls.Kind == LayoutSizeIntrinsic
+}
+
+// resolve returns the size within a length, given the intrinsic size of the element; weights resolve to zero.
+func (ls LayoutSize) resolve(length, intrinsic int) float64 {
+	switch ls.Kind {
+	case LayoutSizePixels:
+		return math.Max(ls.Value, 0)
+	case LayoutSizePercent:
+		return math.Max(ls.Value, 0) * float64(length) / 100
+	case LayoutSizeWeight:
+		return 0
+	}
+	return float64(intrinsic)
+}
+
+// LayoutAnchor is the edge of a box an element is docked against.
+type LayoutAnchor int
+
+const (
+	// LayoutAnchorTop docks the element against the top edge, across the whole width; it is the default.
+	LayoutAnchorTop LayoutAnchor = iota
+	// LayoutAnchorBottom docks the element against the bottom edge, across the whole width.
+	LayoutAnchorBottom
+	// LayoutAnchorLeft docks the element against the left edge, down the whole height.
+	LayoutAnchorLeft
+	// LayoutAnchorRight docks the element against the right edge, down the whole height.
+	LayoutAnchorRight
+)
+
+// isHorizontal returns if elements with the anchor are sized along the width.
+func (la LayoutAnchor) isHorizontal() bool {
+	return la == LayoutAnchorLeft || la == LayoutAnchorRight
+}
+
+// BoxLayoutItem is an element of a `BoxLayout`: its anchor, its size along the axis of the anchor, and the gap
+// between it and what is docked after it.
+type BoxLayoutItem struct {
+	Anchor LayoutAnchor
+	Size   LayoutSize
+	// Intrinsic is the size the element measures itself at, for intrinsic sizes.
+	Intrinsic int
+	// Gap is the space between the element and the rest of the box.
+	Gap int
+}
+
+// BoxLayout lays out elements around a box, i.e. the title, legend and footer of a chart around its plot, or the
+// rows of a panel grid. Each element in turn is docked against an edge of what is left of the box by the elements
+// before it, and takes its size off that edge; what is left after the last element is the remaining box.
+// Sizes are fixed, a percentage of the bounds, intrinsic to the element or a weighted share of what the others
+// leave; elements that don't fit are shrunk to what is left.
+type BoxLayout struct {
+	Items []BoxLayoutItem
+}
+
+// Solve returns the box of each element within bounds, and the box that remains.
+func (bl BoxLayout) Solve(bounds Box) (boxes []Box, remaining Box) {
+	lengths := [2]int{bounds.Height(), bounds.Width()}
+	var used, weights [2]float64
+	sizes := make([]float64, len(bl.Items))
+	for index, item := range bl.Items {
+		axis := 0
+		if item.Anchor.isHorizontal() {
+			axis = 1
+		}
+		if item.Size.Kind == LayoutSizeWeight {
+			weights[axis] += math.Max(item.Size.Value, 0)
+		} else {
+			sizes[index] = item.Size.resolve(lengths[axis], item.Intrinsic)
+			used[axis] += sizes[index]
+		}
+		used[axis] += float64(item.Gap)
+	}
+	for index, item := range bl.Items {
+		axis := 0
+		if item.Anchor.isHorizontal() {
+			axis = 1
+		}
+		if item.Size.Kind == LayoutSizeWeight && weights[axis] > 0 {
+			sizes[index] = math.Max(float64(lengths[axis])-used[axis], 0) * math.Max(item.Size.Value, 0) / weights[axis]
+		}
+	}
+
+	// the edges are kept unrounded, so weighted shares round the same wherever they fall.
+	top, left, bottom, right := float64(bounds.Top), float64(bounds.Left), float64(bounds.Bottom), float64(bounds.Right)
+	round := func(top, left, right, bottom float64) Box {
+		return Box{Top: int(math.Round(top)), Left: int(math.Round(left)), Right: int(math.Round(right)), Bottom: int(math.Round(bottom)), IsSet: bounds.IsSet}
+	}
+	boxes = make([]Box, len(bl.Items))
+	for index, item := range bl.Items {
+		gap := float64(item.Gap)
+		switch item.Anchor {
+		case LayoutAnchorBottom:
+			edge := math.Max(bottom-sizes[index], top)
+			boxes[index] = round(edge, left, right, bottom)
+			bottom = math.Max(edge-gap, top)
+		case LayoutAnchorLeft:
+			edge := math.Min(left+sizes[index], right)
+			boxes[index] = round(top, left, edge, bottom)
+			left = math.Min(edge+gap, right)
+		case LayoutAnchorRight:
+			edge := math.Max(right-sizes[index], left)
+			boxes[index] = round(top, edge, right, bottom)
+			right = math.Max(edge-gap, left)
+		default:
+			edge := math.Min(top+sizes[index], bottom)
+			boxes[index] = round(top, left, right, edge)
+			top = math.Min(edge+gap, bottom)
+		}
+	}
+	return boxes, round(top, left, right, bottom)
+}
+
+// AnchoredLayout is a layout engine that sizes the canvas by constraints and docks it against an edge of the space
+// the default layout would give it, i.e. a canvas 60% of the chart wide against the left, leaving room on the right
+// for elements. Percentages are of the chart bounds, and intrinsic sizes fill the space.
+type AnchoredLayout struct {
+	Width  LayoutSize
+	Height LayoutSize
+	// Anchor is the edge the canvas is docked against; the canvas is centered along that edge.
+	Anchor LayoutAnchor
+}
+
+// CanvasBox implements LayoutEngine.
+func (al AnchoredLayout) CanvasBox(bounds Box) Box {
+	return al.constrain(bounds, bounds)
+}
+
+// FitCanvasBox implements LayoutEngine.
+func (al AnchoredLayout) FitCanvasBox(bounds, canvas, outer Box) Box {
+	return al.constrain(bounds, canvas.OuterConstrain(bounds, outer))
+}
+
+// constrain returns the canvas sized within bounds and docked within the space it has.
+func (al AnchoredLayout) constrain(bounds, space Box) Box {
+	if space.Width() <= 0 || space.Height() <= 0 {
+		return space
+	}
+	width := util.Math.MinInt(int(math.Round(al.Width.resolve(bounds.Width(), space.Width()))), space.Width())
+	height := util.Math.MinInt(int(math.Round(al.Height.resolve(bounds.Height(), space.Height()))), space.Height())
+	if al.Width.Kind == LayoutSizeWeight {
+		width = space.Width()
+	}
+	if al.Height.Kind == LayoutSizeWeight {
+		height = space.Height()
+	}
+
+	// the canvas is docked on the anchor's axis, and centered across it.
+	size, across := height, width
+	if al.Anchor.isHorizontal() {
+		size, across = width, height
+	}
+	boxes, _ := BoxLayout{Items: []BoxLayoutItem{{Anchor: al.Anchor, Size: Pixels(size)}}}.Solve(space)
+	canvas := boxes[0]
+	if al.Anchor.isHorizontal() {
+		canvas.Top += (canvas.Height() - across) >> 1
+		canvas.Bottom = canvas.Top + across
+	} else {
+		canvas.Left += (canvas.Width() - across) >> 1
+		canvas.Right = canvas.Left + across
+	}
+	return canvas
+}
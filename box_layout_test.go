@@ -0,0 +1,75 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestBoxLayoutSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	bounds := Box{Right: 400, Bottom: 300}
+	boxes, remaining := BoxLayout{Items: []BoxLayoutItem{
+		{Anchor: LayoutAnchorTop, Intrinsic: 20, Gap: 5},
+		{Anchor: LayoutAnchorBottom, Size: Pixels(30)},
+		{Anchor: LayoutAnchorRight, Size: Percent(25), Gap: 10},
+		{Anchor: LayoutAnchorLeft, Size: Weight(1)},
+	}}.Solve(bounds)
+	assert.Len(boxes, 4)
+	assert.Equal(Box{Top: 0, Left: 0, Right: 400, Bottom: 20}, boxes[0])
+	assert.Equal(Box{Top: 270, Left: 0, Right: 400, Bottom: 300}, boxes[1])
+	assert.Equal(Box{Top: 25, Left: 300, Right: 400, Bottom: 270}, boxes[2])
+	// the weighted item takes what the others leave along its axis.
+	assert.Equal(Box{Top: 25, Left: 0, Right: 290, Bottom: 270}, boxes[3])
+	assert.Equal(290, remaining.Left)
+	assert.Equal(290, remaining.Right)
+}
+
+func TestBoxLayoutSolveWeights(t *testing.T) {
+	assert := assert.New(t)
+
+	boxes, remaining := BoxLayout{Items: []BoxLayoutItem{
+		{Size: Weight(3), Gap: 10},
+		{Size: Weight(1)},
+	}}.Solve(Box{Right: 100, Bottom: 210, IsSet: true})
+	assert.Equal(Box{Top: 0, Left: 0, Right: 100, Bottom: 150, IsSet: true}, boxes[0])
+	assert.Equal(Box{Top: 160, Left: 0, Right: 100, Bottom: 210, IsSet: true}, boxes[1])
+	assert.Equal(210, remaining.Top)
+}
+
+func TestBoxLayoutSolveOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	// items that don't fit are shrunk to what is left, and never overlap.
+	boxes, remaining := BoxLayout{Items: []BoxLayoutItem{
+		{Anchor: LayoutAnchorLeft, Size: Pixels(80), Gap: 10},
+		{Anchor: LayoutAnchorRight, Size: Percent(50)},
+	}}.Solve(Box{Right: 100, Bottom: 100})
+	assert.Equal(Box{Top: 0, Left: 0, Right: 80, Bottom: 100}, boxes[0])
+	assert.Equal(Box{Top: 0, Left: 90, Right: 100, Bottom: 100}, boxes[1])
+	assert.Zero(remaining.Width())
+}
+
+func TestAnchoredLayoutCanvasBox(t *testing.T) {
+	assert := assert.New(t)
+
+	bounds := Box{Right: 400, Bottom: 200}
+	assert.Equal(Box{Top: 0, Left: 0, Right: 240, Bottom: 200}, AnchoredLayout{Width: Percent(60), Anchor: LayoutAnchorLeft}.CanvasBox(bounds))
+	assert.Equal(Box{Top: 100, Left: 100, Right: 300, Bottom: 200}, AnchoredLayout{Width: Pixels(200), Height: Percent(50), Anchor: LayoutAnchorBottom}.CanvasBox(bounds))
+	assert.Equal(bounds, AnchoredLayout{}.CanvasBox(bounds))
+}
+
+func TestChartAnchoredLayout(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(400, 200)
+	assert.Nil(err)
+
+	c := layoutEngineTestChart(AnchoredLayout{Width: Percent(50), Anchor: LayoutAnchorLeft})
+	cl, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+	// percentages are of the chart less its padding.
+	assert.Equal(180, cl.Canvas.Width())
+	assert.True(cl.Canvas.Left < 100, cl.Canvas.String())
+}
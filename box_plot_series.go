@@ -0,0 +1,240 @@
+package chart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// BoxPlot is the summary of the values of one category of a box plot: the box spans the first to the third
+// quartile and is split at the median, and the whiskers reach from the box to the min and max.
+// Outliers are values past the whiskers; they are drawn as markers.
+type BoxPlot struct {
+	Label string
+
+	Min    float64
+	Q1     float64
+	Median float64
+	Q3     float64
+	Max    float64
+
+	Outliers []float64
+}
+
+// NewBoxPlot summarizes samples as a box plot. The quartiles are interpolated between the closest samples,
+// the whiskers reach the most extreme samples within `DefaultBoxPlotWhiskerRange` interquartile ranges of the box,
+// and the samples past them are outliers. Samples that are not finite are left out.
+func NewBoxPlot(label string, samples ...float64) BoxPlot {
+	var sorted []float64
+	for _, sample := range samples {
+		if !math.IsNaN(sample) && !math.IsInf(sample, 0) {
+			sorted = append(sorted, sample)
+		}
+	}
+	bp := BoxPlot{Label: label}
+	if len(sorted) == 0 {
+		return bp
+	}
+	sort.Float64s(sorted)
+
+	bp.Q1, bp.Median, bp.Q3 = quantile(sorted, 0.25), quantile(sorted, 0.5), quantile(sorted, 0.75)
+	reach := DefaultBoxPlotWhiskerRange * (bp.Q3 - bp.Q1)
+	bp.Min, bp.Max = bp.Q1, bp.Q3
+	for _, sample := range sorted {
+		if sample < bp.Q1-reach || sample > bp.Q3+reach {
+			bp.Outliers = append(bp.Outliers, sample)
+			continue
+		}
+		bp.Min, bp.Max = math.Min(bp.Min, sample), math.Max(bp.Max, sample)
+	}
+	return bp
+}
+
+// quantile returns the quantile of sorted values, interpolated linearly between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (position-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// BoxPlotSeries draws a box plot for each of its boxes; box i is drawn at x value i, so the series is meant
+// for a `CategoryRange`, which charts use for their x axis by default if they have a box plot series.
+// Its values are the medians.
+type BoxPlotSeries struct {
+	Name  string
+	Style Style
+
+	// WhiskerStyle is the style of the whiskers; it inherits the stroke of the style.
+	WhiskerStyle Style
+	// OutlierStyle is the style of the outlier markers, whose size is the dot width; it inherits the stroke
+	// of the style, which is also the marker fill by default.
+	OutlierStyle  Style
+	OutlierMarker Marker
+
+	// BoxWidth is the width of the boxes as a share of the width of a category; it defaults to `DefaultBoxPlotWidth`.
+	BoxWidth float64
+
+	YAxis YAxisType
+
+	YValueFormatter ValueFormatter
+
+	Boxes []BoxPlot
+}
+
+// GetName returns the name of the series.
+func (bps BoxPlotSeries) GetName() string {
+	return bps.Name
+}
+
+// GetStyle returns the series style.
+func (bps BoxPlotSeries) GetStyle() Style {
+	return bps.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (bps BoxPlotSeries) GetYAxis() YAxisType {
+	return bps.YAxis
+}
+
+// GetBoxWidth returns the box width or a default.
+func (bps BoxPlotSeries) GetBoxWidth() float64 {
+	if bps.BoxWidth <= 0 || bps.BoxWidth > 1 {
+		return DefaultBoxPlotWidth
+	}
+	return bps.BoxWidth
+}
+
+// GetOutlierMarker returns the outlier marker or a circle.
+func (bps BoxPlotSeries) GetOutlierMarker() Marker {
+	if bps.OutlierMarker != nil {
+		return bps.OutlierMarker
+	}
+	return MarkerCircle
+}
+
+// GetCategories returns the labels of the boxes.
+func (bps BoxPlotSeries) GetCategories() []string {
+	categories := make([]string, len(bps.Boxes))
+	for index, box := range bps.Boxes {
+		categories[index] = box.Label
+	}
+	return categories
+}
+
+// Len returns the number of boxes.
+func (bps BoxPlotSeries) Len() int {
+	return len(bps.Boxes)
+}
+
+// GetValues gets the x value and median of a box.
+func (bps BoxPlotSeries) GetValues(index int) (float64, float64) {
+	return float64(index), bps.Boxes[index].Median
+}
+
+// GetLastValues gets the x value and median of the last box.
+func (bps BoxPlotSeries) GetLastValues() (float64, float64) {
+	return bps.GetValues(len(bps.Boxes) - 1)
+}
+
+// GetBounds implements BoundsProvider; the y bounds span the whiskers and outliers, and the x bounds are padded
+// by half a category so the first and last boxes aren't cut in half.
+func (bps BoxPlotSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minY, maxY = math.MaxFloat64, -math.MaxFloat64
+	for _, box := range bps.Boxes {
+		for _, value := range append([]float64{box.Min, box.Max}, box.Outliers...) {
+			if !math.IsNaN(value) && !math.IsInf(value, 0) {
+				minY, maxY = math.Min(minY, value), math.Max(maxY, value)
+			}
+		}
+	}
+	return -0.5, float64(len(bps.Boxes)) - 0.5, minY, maxY
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (bps BoxPlotSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x, y = FloatValueFormatter, FloatValueFormatter
+	if bps.YValueFormatter != nil {
+		y = bps.YValueFormatter
+	}
+	return
+}
+
+// Render renders the series.
+func (bps BoxPlotSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := bps.Style.InheritFrom(bps.styleDefaultsBox(defaults))
+	whiskerStyle := bps.WhiskerStyle.InheritFrom(Style{StrokeColor: style.StrokeColor, StrokeWidth: style.StrokeWidth, StrokeDashArray: style.StrokeDashArray})
+	outlierStyle := bps.OutlierStyle.InheritFrom(Style{StrokeColor: style.StrokeColor, StrokeWidth: style.StrokeWidth, FillColor: style.StrokeColor})
+	marker := bps.GetOutlierMarker()
+
+	halfWidth := util.Math.MaxInt(1, int(math.Abs(float64(xrange.Translate(1)-xrange.Translate(0)))*bps.GetBoxWidth())>>1)
+	capHalfWidth := util.Math.MaxInt(1, halfWidth>>1)
+	translateY := func(value float64) int {
+		return canvasBox.Bottom - yrange.Translate(value)
+	}
+
+	for index, box := range bps.Boxes {
+		if !isFinitePoint(box.Min, box.Max) || !isFinitePoint(box.Q1, box.Q3) || !isFinitePoint(box.Median, box.Median) {
+			continue
+		}
+		x := canvasBox.Left + xrange.Translate(float64(index))
+		minY, q1Y, medianY, q3Y, maxY := translateY(box.Min), translateY(box.Q1), translateY(box.Median), translateY(box.Q3), translateY(box.Max)
+
+		whiskerStyle.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(x, maxY)
+		r.LineTo(x, q3Y)
+		r.MoveTo(x, q1Y)
+		r.LineTo(x, minY)
+		r.MoveTo(x-capHalfWidth, maxY)
+		r.LineTo(x+capHalfWidth, maxY)
+		r.MoveTo(x-capHalfWidth, minY)
+		r.LineTo(x+capHalfWidth, minY)
+		r.Stroke()
+
+		Draw.Box(r, Box{
+			Top:    util.Math.MinInt(q1Y, q3Y),
+			Left:   x - halfWidth,
+			Right:  x + halfWidth,
+			Bottom: util.Math.MaxInt(q1Y, q3Y),
+		}, style)
+
+		style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(x-halfWidth, medianY)
+		r.LineTo(x+halfWidth, medianY)
+		r.Stroke()
+
+		outlierStyle.GetFillAndStrokeOptions().WriteToRenderer(r)
+		for _, outlier := range box.Outliers {
+			if !math.IsNaN(outlier) && !math.IsInf(outlier, 0) {
+				marker(r, x, translateY(outlier), outlierStyle.GetDotWidth(DefaultScatterMarkerSize))
+			}
+		}
+	}
+}
+
+// styleDefaultsBox returns the default box style; the boxes are filled with a light shade of the series color.
+func (bps BoxPlotSeries) styleDefaultsBox(defaults Style) Style {
+	color := defaults.GetStrokeColor(drawing.ColorBlack)
+	return Style{
+		StrokeColor: color,
+		StrokeWidth: 1.5,
+		FillColor:   color.WithAlpha(64),
+	}
+}
+
+// Validate validates the series.
+func (bps BoxPlotSeries) Validate() error {
+	if len(bps.Boxes) == 0 {
+		return newValidationError(ErrEmptySeries, "box plot series must have boxes set")
+	}
+	for index, box := range bps.Boxes {
+		if box.Min > box.Q1 || box.Q1 > box.Median || box.Median > box.Q3 || box.Q3 > box.Max {
+			return newValidationError(ErrInvalidSeries, "box plot series has a box at index %d whose values are not in order (min <= q1 <= median <= q3 <= max)", index)
+		}
+	}
+	return nil
+}
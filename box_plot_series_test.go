@@ -0,0 +1,82 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestNewBoxPlot(t *testing.T) {
+	assert := assert.New(t)
+
+	bp := NewBoxPlot("test", 7, 1, 3, 2, 5, 4, 6, 20, math.NaN())
+	assert.Equal("test", bp.Label)
+	assert.Equal(2.75, bp.Q1)
+	assert.Equal(4.5, bp.Median)
+	assert.Equal(6.25, bp.Q3)
+	assert.Equal(1.0, bp.Min)
+	assert.Equal(7.0, bp.Max)
+	assert.Equal([]float64{20}, bp.Outliers)
+
+	single := NewBoxPlot("single", 3)
+	assert.Equal(3.0, single.Min)
+	assert.Equal(3.0, single.Median)
+	assert.Equal(3.0, single.Max)
+	assert.Empty(single.Outliers)
+
+	assert.Equal(BoxPlot{Label: "empty"}, NewBoxPlot("empty"))
+}
+
+func TestBoxPlotSeriesGetBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	bps := BoxPlotSeries{Boxes: []BoxPlot{
+		{Min: 1, Q1: 2, Median: 3, Q3: 4, Max: 5, Outliers: []float64{-2}},
+		{Min: 2, Q1: 3, Median: 4, Q3: 5, Max: 9},
+	}}
+	minX, maxX, minY, maxY := bps.GetBounds()
+	assert.Equal(-0.5, minX)
+	assert.Equal(1.5, maxX)
+	assert.Equal(-2.0, minY)
+	assert.Equal(9.0, maxY)
+
+	x, y := bps.GetLastValues()
+	assert.Equal(1.0, x)
+	assert.Equal(4.0, y)
+}
+
+func TestBoxPlotSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(BoxPlotSeries{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(BoxPlotSeries{Boxes: []BoxPlot{{Min: 1, Q1: 3, Median: 2, Q3: 4, Max: 5}}}.Validate(), ErrInvalidSeries))
+	assert.Nil(BoxPlotSeries{Boxes: []BoxPlot{NewBoxPlot("a", 1, 2, 3)}}.Validate())
+}
+
+func TestBoxPlotSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		YAxis: YAxis{Style: StyleShow()},
+		Series: []Series{
+			BoxPlotSeries{Boxes: []BoxPlot{
+				NewBoxPlot("a", 1, 2, 3, 4, 5, 20),
+				NewBoxPlot("b", 2, 3, 4, 5, 6),
+			}},
+		},
+	}
+
+	xr, _, _ := c.getRanges()
+	cr, isCategoryRange := xr.(*CategoryRange)
+	assert.True(isCategoryRange)
+	assert.Equal([]string{"a", "b"}, cr.Categories)
+	assert.Equal(-0.5, cr.GetMin())
+	assert.Equal(1.5, cr.GetMax())
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+}
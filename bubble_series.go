@@ -0,0 +1,258 @@
+package chart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// BubbleScale is how the sizes of a bubble series map to the radii of its bubbles.
+type BubbleScale int
+
+const (
+	// BubbleScaleSqrt maps the square root of the sizes to the radii, so the areas of the bubbles
+	// grow linearly with their sizes; it is the default.
+	BubbleScaleSqrt BubbleScale = 0
+	// BubbleScaleLinear maps the sizes to the radii.
+	BubbleScaleLinear BubbleScale = 1
+	// BubbleScaleLog maps the logarithm of the sizes to the radii; bubbles without a positive size aren't drawn.
+	BubbleScaleLog BubbleScale = 2
+)
+
+// BubbleSeries draws a bubble at each of its values, whose radius shows the `ZValue` of the value. The smallest
+// size maps to `MinRadius` and the largest to `MaxRadius` through the scale. Bubbles are filled with a translucent
+// shade of the series color, with the largest drawn first, and values with a label have it drawn in their bubble,
+// or above it if it doesn't fit. The style of a value overrides the series style.
+type BubbleSeries struct {
+	Name  string
+	Style Style
+
+	// LabelStyle is the style of the bubble labels.
+	LabelStyle Style
+
+	YAxis YAxisType
+	XAxis XAxisType
+
+	XValueFormatter ValueFormatter
+	YValueFormatter ValueFormatter
+
+	Scale BubbleScale
+	// MinRadius and MaxRadius are the radii of the smallest and largest bubbles, in pixels; they default
+	// to `DefaultBubbleMinRadius` and `DefaultBubbleMaxRadius`.
+	MinRadius float64
+	MaxRadius float64
+
+	Values []Value3
+}
+
+// GetName returns the name of the series.
+func (bs BubbleSeries) GetName() string {
+	return bs.Name
+}
+
+// GetStyle returns the series style.
+func (bs BubbleSeries) GetStyle() Style {
+	return bs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (bs BubbleSeries) GetYAxis() YAxisType {
+	return bs.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (bs BubbleSeries) GetXAxis() XAxisType {
+	return bs.XAxis
+}
+
+// GetMinRadius returns the radius of the smallest bubbles or a default.
+func (bs BubbleSeries) GetMinRadius() float64 {
+	if bs.MinRadius <= 0 {
+		return DefaultBubbleMinRadius
+	}
+	return bs.MinRadius
+}
+
+// GetMaxRadius returns the radius of the largest bubbles or a default; it is never less than the min radius.
+func (bs BubbleSeries) GetMaxRadius() float64 {
+	if bs.MaxRadius <= 0 {
+		return math.Max(DefaultBubbleMaxRadius, bs.GetMinRadius())
+	}
+	return math.Max(bs.MaxRadius, bs.GetMinRadius())
+}
+
+// Len returns the number of bubbles in the series.
+func (bs BubbleSeries) Len() int {
+	return len(bs.Values)
+}
+
+// GetValues gets the x,y values at a given index.
+func (bs BubbleSeries) GetValues(index int) (float64, float64) {
+	return bs.Values[index].XValue, bs.Values[index].YValue
+}
+
+// GetLastValues gets the last x,y values.
+func (bs BubbleSeries) GetLastValues() (float64, float64) {
+	return bs.GetValues(len(bs.Values) - 1)
+}
+
+// GetBounds implements BoundsProvider; the bounds of the values are padded by a tenth of their span on each side,
+// so the bubbles at the edges aren't cut off.
+func (bs BubbleSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minX, maxX, minY, maxY = math.MaxFloat64, -math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64
+	for _, value := range bs.Values {
+		if isFinitePoint(value.XValue, value.YValue) {
+			minX, maxX = math.Min(minX, value.XValue), math.Max(maxX, value.XValue)
+			minY, maxY = math.Min(minY, value.YValue), math.Max(maxY, value.YValue)
+		}
+	}
+	if minX > maxX {
+		return 0, 0, 0, 0
+	}
+	padX, padY := bubblePadding(minX, maxX), bubblePadding(minY, maxY)
+	return minX - padX, maxX + padX, minY - padY, maxY + padY
+}
+
+// bubblePadding returns the padding of the bounds of bubbles, a tenth of their span or of the value itself.
+func bubblePadding(min, max float64) float64 {
+	if max > min {
+		return (max - min) / 10
+	}
+	if min != 0 {
+		return math.Abs(min) / 10
+	}
+	return 1
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (bs BubbleSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x, y = FloatValueFormatter, FloatValueFormatter
+	if bs.XValueFormatter != nil {
+		x = bs.XValueFormatter
+	}
+	if bs.YValueFormatter != nil {
+		y = bs.YValueFormatter
+	}
+	return
+}
+
+// GetRadii returns the radius of the bubble of each value; it is zero for values whose size
+// can't be scaled, i.e. sizes that aren't finite or, for the log scale, positive.
+func (bs BubbleSeries) GetRadii() []float64 {
+	radii := make([]float64, len(bs.Values))
+	scaled := make([]float64, len(bs.Values))
+	ok := make([]bool, len(bs.Values))
+	min, max := math.MaxFloat64, -math.MaxFloat64
+	for index, value := range bs.Values {
+		scaled[index], ok[index] = bs.scale(value.ZValue)
+		if ok[index] {
+			min, max = math.Min(min, scaled[index]), math.Max(max, scaled[index])
+		}
+	}
+
+	minRadius, maxRadius := bs.GetMinRadius(), bs.GetMaxRadius()
+	for index := range bs.Values {
+		if !ok[index] {
+			continue
+		}
+		if max == min {
+			radii[index] = maxRadius
+			continue
+		}
+		radii[index] = minRadius + (scaled[index]-min)/(max-min)*(maxRadius-minRadius)
+	}
+	return radii
+}
+
+// scale returns a size on the scale of the series, and if it can be scaled.
+func (bs BubbleSeries) scale(size float64) (float64, bool) {
+	if math.IsNaN(size) || math.IsInf(size, 0) {
+		return 0, false
+	}
+	switch bs.Scale {
+	case BubbleScaleLinear:
+		return size, true
+	case BubbleScaleLog:
+		if size <= 0 {
+			return 0, false
+		}
+		return math.Log(size), true
+	default:
+		return math.Sqrt(math.Max(size, 0)), true
+	}
+}
+
+// Render renders the series.
+func (bs BubbleSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := bs.Style.InheritFrom(bs.styleDefaultsBubbles(defaults))
+	labelStyle := bs.LabelStyle.InheritFrom(Style{Font: defaults.Font, FontSize: defaults.GetFontSize(DefaultFontSize)})
+	radii := bs.GetRadii()
+
+	order := make([]int, len(bs.Values))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return radii[order[i]] > radii[order[j]]
+	})
+
+	for _, index := range order {
+		value := bs.Values[index]
+		if radii[index] <= 0 || !isFinitePoint(value.XValue, value.YValue) {
+			continue
+		}
+		x, y := canvasBox.Left+xrange.Translate(value.XValue), canvasBox.Bottom-yrange.Translate(value.YValue)
+		valueStyle := value.Style.InheritFrom(style)
+
+		Draw.Circle(r, x, y, radii[index], valueStyle)
+
+		if len(value.Label) > 0 {
+			bs.drawLabel(r, value.Label, x, y, radii[index], valueStyle, labelStyle)
+		}
+	}
+}
+
+// drawLabel draws the label of a bubble centered in it, or centered above it if it doesn't fit.
+func (bs BubbleSeries) drawLabel(r Renderer, label string, x, y int, radius float64, bubbleStyle, labelStyle Style) {
+	textBox := Draw.MeasureText(r, label, labelStyle)
+	tx := x - (textBox.Width() >> 1)
+	if float64(textBox.Width()) <= 2*radius && float64(textBox.Height()) <= 2*radius {
+		style := labelStyle.InheritFrom(Style{FontColor: contrastingTextColor(bs.blendedFillColor(bubbleStyle))})
+		Draw.Text(r, label, tx, y+(textBox.Height()>>1), style)
+		return
+	}
+	style := labelStyle.InheritFrom(Style{FontColor: DefaultTextColor})
+	Draw.Text(r, label, tx, y-int(math.Ceil(radius))-4, style)
+}
+
+// blendedFillColor returns the fill color of a bubble as it appears over a white background.
+func (bs BubbleSeries) blendedFillColor(style Style) drawing.Color {
+	fill := style.GetFillColor()
+	return blendColors(drawing.ColorWhite, fill.WithAlpha(255), float64(fill.A)/255)
+}
+
+// styleDefaultsBubbles returns the default bubble style; the bubbles are filled with a translucent shade
+// of the series color.
+func (bs BubbleSeries) styleDefaultsBubbles(defaults Style) Style {
+	color := defaults.GetStrokeColor(drawing.ColorBlack)
+	return Style{
+		StrokeColor: color,
+		StrokeWidth: 1,
+		FillColor:   color.WithAlpha(128),
+	}
+}
+
+// Validate validates the series.
+func (bs BubbleSeries) Validate() error {
+	if len(bs.Values) == 0 {
+		return newValidationError(ErrEmptySeries, "bubble series must have values set")
+	}
+	if bs.Scale < BubbleScaleSqrt || bs.Scale > BubbleScaleLog {
+		return newValidationError(ErrInvalidSeries, "bubble series has an unknown scale %d", bs.Scale)
+	}
+	if bs.MinRadius > 0 && bs.MaxRadius > 0 && bs.MinRadius > bs.MaxRadius {
+		return newValidationError(ErrInvalidSeries, "bubble series min radius %v is greater than its max radius %v", bs.MinRadius, bs.MaxRadius)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestBubbleSeriesGetRadii(t *testing.T) {
+	assert := assert.New(t)
+
+	bs := BubbleSeries{
+		MinRadius: 2,
+		MaxRadius: 10,
+		Values: []Value3{
+			{XValue: 1, YValue: 1, ZValue: 1},
+			{XValue: 2, YValue: 2, ZValue: 25},
+			{XValue: 3, YValue: 3, ZValue: 100},
+			{XValue: 4, YValue: 4, ZValue: math.NaN()},
+		},
+	}
+
+	radii := bs.GetRadii()
+	assert.InDelta(2.0, radii[0], 0.000001)
+	assert.InDelta(2.0+8.0*4.0/9.0, radii[1], 0.000001)
+	assert.InDelta(10.0, radii[2], 0.000001)
+	assert.Zero(radii[3])
+
+	bs.Scale = BubbleScaleLinear
+	radii = bs.GetRadii()
+	assert.InDelta(2.0+8.0*24.0/99.0, radii[1], 0.000001)
+
+	bs.Scale = BubbleScaleLog
+	bs.Values[0].ZValue = 0
+	radii = bs.GetRadii()
+	assert.Zero(radii[0])
+	assert.InDelta(2.0, radii[1], 0.000001)
+	assert.InDelta(10.0, radii[2], 0.000001)
+}
+
+func TestBubbleSeriesGetRadiiSameSize(t *testing.T) {
+	assert := assert.New(t)
+
+	bs := BubbleSeries{
+		Values: []Value3{{XValue: 1, YValue: 1, ZValue: 5}, {XValue: 2, YValue: 2, ZValue: 5}},
+	}
+	radii := bs.GetRadii()
+	assert.Equal(DefaultBubbleMaxRadius, radii[0])
+	assert.Equal(DefaultBubbleMaxRadius, radii[1])
+}
+
+func TestBubbleSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(BubbleSeries{}.Validate())
+	values := []Value3{{XValue: 1, YValue: 1, ZValue: 1}}
+	assert.Nil(BubbleSeries{Values: values}.Validate())
+	assert.NotNil(BubbleSeries{Values: values, Scale: BubbleScale(7)}.Validate())
+	assert.NotNil(BubbleSeries{Values: values, MinRadius: 10, MaxRadius: 5}.Validate())
+}
+
+func TestBubbleSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			BubbleSeries{
+				Values: []Value3{
+					{XValue: 1, YValue: 1, ZValue: 10, Label: "Small"},
+					{XValue: 2, YValue: 3, ZValue: 1000, Label: "Big"},
+					{XValue: 3, YValue: 2, ZValue: 300},
+				},
+			},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	assert.True(bytes.Contains(buffer.Bytes(), []byte("Big")))
+	assert.True(bytes.Contains(buffer.Bytes(), []byte("Small")))
+}
+
+func TestBubbleSeriesGetBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	bs := BubbleSeries{
+		Values: []Value3{{XValue: 0, YValue: 5, ZValue: 1}, {XValue: 10, YValue: 5, ZValue: 2}},
+	}
+	minX, maxX, minY, maxY := bs.GetBounds()
+	assert.Equal(-1.0, minX)
+	assert.Equal(11.0, maxX)
+	assert.Equal(4.5, minY)
+	assert.Equal(5.5, maxY)
+}
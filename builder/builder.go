@@ -0,0 +1,274 @@
+// Package builder provides a fluent api for building charts, i.e.
+//
+//	err := builder.NewLine().
+//		Title("requests").
+//		XTime().
+//		AddTimeSeries("p99", times, latencies).
+//		Legend().
+//		Render(w)
+//
+// Builders construct the same `chart.Chart` and `chart.BarChart` structs as the struct literal api;
+// the built chart can be further customized before rendering. Misconfigurations, i.e. mismatched values
+// or an x axis of times with series that aren't time series, are collected as the builder is used
+// and returned by `Build` or `Render` together with the chart's own validation errors.
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	chart "github.com/wcharczuk/go-chart"
+)
+
+// ErrMixedXValues is the kind of error for a chart with both time series and other series on a time x axis.
+var ErrMixedXValues = errors.New("mixed x values")
+
+// LineBuilder builds a line chart; see `NewLine`.
+type LineBuilder struct {
+	chart  chart.Chart
+	legend bool
+	xTime  bool
+	errs   chart.ValidationErrors
+}
+
+// NewLine returns a new line chart builder.
+func NewLine() *LineBuilder {
+	return &LineBuilder{}
+}
+
+// Title sets the chart title.
+func (lb *LineBuilder) Title(title string) *LineBuilder {
+	lb.chart.Title = title
+	lb.chart.TitleStyle = chart.StyleShow()
+	return lb
+}
+
+// Size sets the chart size in pixels.
+func (lb *LineBuilder) Size(width, height int) *LineBuilder {
+	if width <= 0 || height <= 0 {
+		lb.fail(chart.ErrInvalidCanvas, -1, "", "size must be positive; got %dx%d", width, height)
+	}
+	lb.chart.Width, lb.chart.Height = width, height
+	return lb
+}
+
+// XTime makes the x axis a time axis with calendar aware ticks; every series must be a time series.
+func (lb *LineBuilder) XTime() *LineBuilder {
+	lb.xTime = true
+	lb.chart.XAxis.Range = &chart.TimeRange{}
+	lb.chart.XAxis.ValueFormatter = chart.TimeValueFormatter
+	return lb
+}
+
+// XAxis shows the x axis with the given name; an empty name shows the axis without a name.
+func (lb *LineBuilder) XAxis(name string) *LineBuilder {
+	lb.chart.XAxis.Style = chart.StyleShow()
+	if len(name) > 0 {
+		lb.chart.XAxis.Name = name
+		lb.chart.XAxis.NameStyle = chart.StyleShow()
+	}
+	return lb
+}
+
+// YAxis shows the y axis with the given name; an empty name shows the axis without a name.
+func (lb *LineBuilder) YAxis(name string) *LineBuilder {
+	lb.chart.YAxis.Style = chart.StyleShow()
+	if len(name) > 0 {
+		lb.chart.YAxis.Name = name
+		lb.chart.YAxis.NameStyle = chart.StyleShow()
+	}
+	return lb
+}
+
+// YAxisSecondary shows the secondary y axis with the given name.
+func (lb *LineBuilder) YAxisSecondary(name string) *LineBuilder {
+	lb.chart.YAxisSecondary.Style = chart.StyleShow()
+	if len(name) > 0 {
+		lb.chart.YAxisSecondary.Name = name
+		lb.chart.YAxisSecondary.NameStyle = chart.StyleShow()
+	}
+	return lb
+}
+
+// YRange fixes the y axis range.
+func (lb *LineBuilder) YRange(min, max float64) *LineBuilder {
+	if !(min < max) {
+		lb.fail(chart.ErrInvalidRange, -1, "", "y range min must be less than max; got %v and %v", min, max)
+	}
+	lb.chart.YAxis.Range = &chart.ContinuousRange{Min: min, Max: max}
+	return lb
+}
+
+// Legend adds a legend for the series.
+func (lb *LineBuilder) Legend() *LineBuilder {
+	lb.legend = true
+	return lb
+}
+
+// AddSeries adds a series.
+func (lb *LineBuilder) AddSeries(s chart.Series) *LineBuilder {
+	if s == nil {
+		lb.fail(chart.ErrInvalidSeries, len(lb.chart.Series), "", "series is nil")
+		return lb
+	}
+	lb.chart.Series = append(lb.chart.Series, s)
+	return lb
+}
+
+// AddLine adds a continuous series.
+func (lb *LineBuilder) AddLine(name string, xvalues, yvalues []float64) *LineBuilder {
+	lb.checkLengths(name, len(xvalues), len(yvalues))
+	return lb.AddSeries(chart.ContinuousSeries{Name: name, XValues: xvalues, YValues: yvalues})
+}
+
+// AddTimeSeries adds a time series.
+func (lb *LineBuilder) AddTimeSeries(name string, xvalues []time.Time, yvalues []float64) *LineBuilder {
+	lb.checkLengths(name, len(xvalues), len(yvalues))
+	return lb.AddSeries(chart.TimeSeries{Name: name, XValues: xvalues, YValues: yvalues})
+}
+
+// Build returns the chart, or the errors found building and validating it.
+func (lb *LineBuilder) Build() (*chart.Chart, error) {
+	errs := append(chart.ValidationErrors{}, lb.errs...)
+	if lb.xTime {
+		for index, s := range lb.chart.Series {
+			if _, isTimeSeries := s.(chart.TimeSeries); !isTimeSeries {
+				errs = append(errs, &chart.ValidationError{Kind: ErrMixedXValues, Message: "series on a time x axis must be time series", SeriesIndex: index, SeriesName: s.GetName()})
+			}
+		}
+	}
+
+	c := lb.chart
+	if lb.legend {
+		c.Elements = append([]chart.Renderable{chart.Legend(&c)}, c.Elements...)
+	}
+	if len(errs) == 0 {
+		if err := c.Validate(); err != nil {
+			var verrs chart.ValidationErrors
+			if errors.As(err, &verrs) {
+				errs = append(errs, verrs...)
+			} else {
+				return nil, err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &c, nil
+}
+
+// Render builds the chart and renders it as png.
+func (lb *LineBuilder) Render(w io.Writer) error {
+	return lb.RenderWith(chart.PNG, w)
+}
+
+// RenderWith builds the chart and renders it with the given renderer provider, i.e. `chart.SVG`.
+func (lb *LineBuilder) RenderWith(rp chart.RendererProvider, w io.Writer) error {
+	c, err := lb.Build()
+	if err != nil {
+		return err
+	}
+	return c.Render(rp, w)
+}
+
+func (lb *LineBuilder) checkLengths(name string, xlen, ylen int) {
+	if xlen != ylen {
+		lb.fail(chart.ErrLengthMismatch, len(lb.chart.Series), name, "x and y values must be the same length; got %d and %d", xlen, ylen)
+	}
+}
+
+func (lb *LineBuilder) fail(kind error, seriesIndex int, seriesName string, format string, args ...interface{}) {
+	lb.errs = append(lb.errs, newError(kind, seriesIndex, seriesName, format, args...))
+}
+
+// BarBuilder builds a bar chart; see `NewBar`.
+type BarBuilder struct {
+	chart chart.BarChart
+	errs  chart.ValidationErrors
+}
+
+// NewBar returns a new bar chart builder.
+func NewBar() *BarBuilder {
+	return &BarBuilder{}
+}
+
+// Title sets the chart title.
+func (bb *BarBuilder) Title(title string) *BarBuilder {
+	bb.chart.Title = title
+	bb.chart.TitleStyle = chart.StyleShow()
+	return bb
+}
+
+// Size sets the chart size in pixels.
+func (bb *BarBuilder) Size(width, height int) *BarBuilder {
+	if width <= 0 || height <= 0 {
+		bb.errs = append(bb.errs, newError(chart.ErrInvalidCanvas, -1, "", "size must be positive; got %dx%d", width, height))
+	}
+	bb.chart.Width, bb.chart.Height = width, height
+	return bb
+}
+
+// BarWidth sets the width of each bar.
+func (bb *BarBuilder) BarWidth(width int) *BarBuilder {
+	bb.chart.BarWidth = width
+	return bb
+}
+
+// Axes shows the x and y axes.
+func (bb *BarBuilder) Axes() *BarBuilder {
+	bb.chart.XAxis = chart.StyleShow()
+	bb.chart.YAxis.Style = chart.StyleShow()
+	return bb
+}
+
+// AddBar adds a bar.
+func (bb *BarBuilder) AddBar(label string, value float64) *BarBuilder {
+	bb.chart.Bars = append(bb.chart.Bars, chart.Value{Label: label, Value: value})
+	return bb
+}
+
+// Build returns the chart, or the errors found building and validating it.
+func (bb *BarBuilder) Build() (*chart.BarChart, error) {
+	errs := append(chart.ValidationErrors{}, bb.errs...)
+	c := bb.chart
+	if len(errs) == 0 {
+		if err := c.Validate(); err != nil {
+			var verrs chart.ValidationErrors
+			if errors.As(err, &verrs) {
+				errs = append(errs, verrs...)
+			} else {
+				return nil, err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &c, nil
+}
+
+// Render builds the chart and renders it as png.
+func (bb *BarBuilder) Render(w io.Writer) error {
+	return bb.RenderWith(chart.PNG, w)
+}
+
+// RenderWith builds the chart and renders it with the given renderer provider, i.e. `chart.SVG`.
+func (bb *BarBuilder) RenderWith(rp chart.RendererProvider, w io.Writer) error {
+	c, err := bb.Build()
+	if err != nil {
+		return err
+	}
+	return c.Render(rp, w)
+}
+
+func newError(kind error, seriesIndex int, seriesName string, format string, args ...interface{}) *chart.ValidationError {
+	return &chart.ValidationError{
+		Kind:        kind,
+		Message:     fmt.Sprintf(format, args...),
+		SeriesIndex: seriesIndex,
+		SeriesName:  seriesName,
+	}
+}
@@ -0,0 +1,86 @@
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	chart "github.com/wcharczuk/go-chart"
+)
+
+func TestLineBuilder(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewLine().
+		Title("test").
+		Size(640, 480).
+		XAxis("x").
+		YAxis("").
+		AddLine("a", []float64{1, 2, 3}, []float64{1, 4, 9}).
+		Legend().
+		Build()
+	assert.Nil(err)
+	assert.Equal("test", c.Title)
+	assert.Equal(640, c.Width)
+	assert.Equal("x", c.XAxis.Name)
+	assert.Len(c.Series, 1)
+	assert.Len(c.Elements, 1)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(chart.PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func TestLineBuilderRenderTime(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2)}
+
+	buffer := bytes.NewBuffer(nil)
+	err := NewLine().XTime().AddTimeSeries("a", times, []float64{1, 2, 3}).RenderWith(chart.SVG, buffer)
+	assert.Nil(err)
+	assert.NotZero(buffer.Len())
+}
+
+func TestLineBuilderMisconfiguration(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	_, err := NewLine().
+		Size(0, 10).
+		YRange(5, 5).
+		XTime().
+		AddTimeSeries("a", []time.Time{start, start.Add(time.Hour)}, []float64{1}).
+		AddLine("b", []float64{1, 2}, []float64{1, 2}).
+		AddSeries(nil).
+		Build()
+	assert.NotNil(err)
+	assert.True(errors.Is(err, chart.ErrInvalidCanvas))
+	assert.True(errors.Is(err, chart.ErrInvalidRange))
+	assert.True(errors.Is(err, chart.ErrLengthMismatch))
+	assert.True(errors.Is(err, chart.ErrInvalidSeries))
+	assert.True(errors.Is(err, ErrMixedXValues))
+
+	var verrs chart.ValidationErrors
+	assert.True(errors.As(err, &verrs))
+	assert.Len(verrs, 5)
+
+	// the chart's own validation applies as well.
+	_, err = NewLine().Build()
+	assert.True(errors.Is(err, chart.ErrEmptySeries))
+}
+
+func TestBarBuilder(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	err := NewBar().Title("bars").Axes().BarWidth(40).AddBar("a", 1).AddBar("b", 2).Render(buffer)
+	assert.Nil(err)
+	assert.NotZero(buffer.Len())
+
+	_, err = NewBar().AddBar("a", 1).Size(-1, 10).Build()
+	assert.True(errors.Is(err, chart.ErrInvalidCanvas))
+}
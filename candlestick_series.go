@@ -0,0 +1,191 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// CandlestickSeries draws open, high, low and close values as candles: a wick from the low to the high
+// and a body from the open to the close, colored by whether the close is at or above the open (up) or below it (down).
+// Its values are the close values, so it can be the inner series of i.e. a `SMASeries` or `BollingerBandsSeries`.
+type CandlestickSeries struct {
+	Name  string
+	Style Style
+
+	// UpStyle and DownStyle are the styles of up and down candles; they default to `DefaultCandleUpColor`
+	// and `DefaultCandleDownColor` fills and strokes.
+	UpStyle   Style
+	DownStyle Style
+
+	// BodyWidth is the width of the candle bodies as a share of the smallest gap between candles;
+	// it defaults to `DefaultCandleBodyWidth`.
+	BodyWidth float64
+
+	YAxis YAxisType
+	XAxis XAxisType
+
+	XValueFormatter ValueFormatter
+	YValueFormatter ValueFormatter
+
+	XValues     []float64
+	OpenValues  []float64
+	HighValues  []float64
+	LowValues   []float64
+	CloseValues []float64
+}
+
+// GetName returns the name of the series.
+func (cs CandlestickSeries) GetName() string {
+	return cs.Name
+}
+
+// GetStyle returns the series style.
+func (cs CandlestickSeries) GetStyle() Style {
+	return cs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (cs CandlestickSeries) GetYAxis() YAxisType {
+	return cs.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (cs CandlestickSeries) GetXAxis() XAxisType {
+	return cs.XAxis
+}
+
+// GetBodyWidth returns the body width or a default.
+func (cs CandlestickSeries) GetBodyWidth() float64 {
+	if cs.BodyWidth <= 0 || cs.BodyWidth > 1 {
+		return DefaultCandleBodyWidth
+	}
+	return cs.BodyWidth
+}
+
+// Len returns the number of candles.
+func (cs CandlestickSeries) Len() int {
+	return len(cs.XValues)
+}
+
+// GetValues gets the x and close values at a given index.
+func (cs CandlestickSeries) GetValues(index int) (float64, float64) {
+	return cs.XValues[index], cs.CloseValues[index]
+}
+
+// GetLastValues gets the last x and close values.
+func (cs CandlestickSeries) GetLastValues() (float64, float64) {
+	return cs.GetValues(len(cs.XValues) - 1)
+}
+
+// GetCandle returns the open, high, low and close values at a given index.
+func (cs CandlestickSeries) GetCandle(index int) (open, high, low, close float64) {
+	return cs.OpenValues[index], cs.HighValues[index], cs.LowValues[index], cs.CloseValues[index]
+}
+
+// GetBounds implements BoundsProvider; the y bounds are the lowest low and highest high, and the x bounds
+// are padded by half the smallest gap between candles so the first and last candles aren't cut in half.
+func (cs CandlestickSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minX, maxX = math.MaxFloat64, -math.MaxFloat64
+	minY, maxY = math.MaxFloat64, -math.MaxFloat64
+	gap := math.MaxFloat64
+	for index, x := range cs.XValues {
+		_, high, low, _ := cs.GetCandle(index)
+		if !isFinitePoint(x, high) || !isFinitePoint(x, low) {
+			continue
+		}
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, low), math.Max(maxY, high)
+		if index > 0 {
+			if delta := math.Abs(x - cs.XValues[index-1]); delta > 0 {
+				gap = math.Min(gap, delta)
+			}
+		}
+	}
+	if gap < math.MaxFloat64 {
+		minX, maxX = minX-gap/2, maxX+gap/2
+	}
+	return
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (cs CandlestickSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x, y = FloatValueFormatter, FloatValueFormatter
+	if cs.XValueFormatter != nil {
+		x = cs.XValueFormatter
+	}
+	if cs.YValueFormatter != nil {
+		y = cs.YValueFormatter
+	}
+	return
+}
+
+// Render renders the series.
+// Candles are colored by the up and down styles rather than by the chart's series color.
+func (cs CandlestickSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	up := cs.UpStyle.InheritFrom(cs.Style.InheritFrom(cs.styleDefaultsCandle(DefaultCandleUpColor)))
+	down := cs.DownStyle.InheritFrom(cs.Style.InheritFrom(cs.styleDefaultsCandle(DefaultCandleDownColor)))
+
+	halfWidth := cs.getHalfBodyWidth(xrange)
+	for index, vx := range cs.XValues {
+		open, high, low, close := cs.GetCandle(index)
+		if !isFinitePoint(vx, open) || !isFinitePoint(high, low) || !isFinitePoint(close, close) {
+			continue
+		}
+		candleStyle := up
+		if close < open {
+			candleStyle = down
+		}
+
+		x := canvasBox.Left + xrange.Translate(vx)
+		candleStyle.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(x, canvasBox.Bottom-yrange.Translate(high))
+		r.LineTo(x, canvasBox.Bottom-yrange.Translate(low))
+		r.Stroke()
+
+		top, bottom := canvasBox.Bottom-yrange.Translate(math.Max(open, close)), canvasBox.Bottom-yrange.Translate(math.Min(open, close))
+		if bottom-top < 1 {
+			bottom = top + 1
+		}
+		Draw.Box(r, Box{Top: top, Left: x - halfWidth, Right: x + halfWidth, Bottom: bottom}, candleStyle)
+	}
+}
+
+// getHalfBodyWidth returns half the pixel width of the candle bodies, from the smallest gap between candles.
+func (cs CandlestickSeries) getHalfBodyWidth(xrange Range) int {
+	gap := xrange.GetDomain()
+	for index := 1; index < len(cs.XValues); index++ {
+		if delta := util.Math.AbsInt(xrange.Translate(cs.XValues[index]) - xrange.Translate(cs.XValues[index-1])); delta > 0 {
+			gap = util.Math.MinInt(gap, delta)
+		}
+	}
+	return util.Math.MaxInt(1, int(float64(gap)*cs.GetBodyWidth())>>1)
+}
+
+func (cs CandlestickSeries) styleDefaultsCandle(color drawing.Color) Style {
+	return Style{
+		StrokeColor: color,
+		StrokeWidth: 1,
+		FillColor:   color,
+	}
+}
+
+// Validate validates the series.
+func (cs CandlestickSeries) Validate() error {
+	if len(cs.XValues) == 0 {
+		return newValidationError(ErrEmptySeries, "candlestick series must have xvalues set")
+	}
+	for _, values := range [][]float64{cs.OpenValues, cs.HighValues, cs.LowValues, cs.CloseValues} {
+		if len(values) != len(cs.XValues) {
+			return newValidationError(ErrLengthMismatch, "candlestick series has %d xvalues but %d open, %d high, %d low and %d close values",
+				len(cs.XValues), len(cs.OpenValues), len(cs.HighValues), len(cs.LowValues), len(cs.CloseValues))
+		}
+	}
+	for index := range cs.XValues {
+		if open, high, low, close := cs.GetCandle(index); low > high || open > high || open < low || close > high || close < low {
+			return newValidationError(ErrInvalidSeries, "candlestick series has a candle at index %d whose open and close are not between its low and high", index)
+		}
+	}
+	return nil
+}
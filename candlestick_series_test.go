@@ -0,0 +1,86 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func candlestickTestSeries() CandlestickSeries {
+	return CandlestickSeries{
+		Name:        "prices",
+		XValues:     []float64{1, 2, 3, 4, 5},
+		OpenValues:  []float64{10, 12, 11, 13, 12},
+		HighValues:  []float64{13, 14, 13, 15, 13},
+		LowValues:   []float64{9, 10, 10, 12, 8},
+		CloseValues: []float64{12, 11, 13, 12, 9},
+	}
+}
+
+func TestCandlestickSeriesValues(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := candlestickTestSeries()
+	assert.Equal(5, cs.Len())
+	x, y := cs.GetValues(1)
+	assert.Equal(2.0, x)
+	assert.Equal(11.0, y)
+
+	minX, maxX, minY, maxY := cs.GetBounds()
+	assert.Equal(0.5, minX)
+	assert.Equal(5.5, maxX)
+	assert.Equal(8.0, minY)
+	assert.Equal(15.0, maxY)
+	assert.Equal(DefaultCandleBodyWidth, cs.GetBodyWidth())
+}
+
+func TestCandlestickSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := candlestickTestSeries()
+	assert.Nil(cs.Validate())
+
+	cs.LowValues = cs.LowValues[:4]
+	assert.True(errors.Is(cs.Validate(), ErrLengthMismatch))
+
+	cs = candlestickTestSeries()
+	cs.HighValues[2] = 12
+	assert.True(errors.Is(cs.Validate(), ErrInvalidSeries))
+}
+
+func TestCandlestickSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	prices := candlestickTestSeries()
+	c := Chart{
+		Width:  320,
+		Height: 240,
+		Series: []Series{
+			prices,
+			SMASeries{InnerSeries: prices, Period: 2},
+			&BollingerBandsSeries{InnerSeries: prices, Period: 2},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	svg := buffer.String()
+	// up candles are green and down candles red.
+	assert.True(strings.Contains(svg, "fill:rgba(0,217,101,1.0)"))
+	assert.True(strings.Contains(svg, "fill:rgba(217,0,116,1.0)"))
+}
+
+func TestCandlestickSeriesBodyWidth(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := candlestickTestSeries()
+	xrange := &ContinuousRange{Min: 1, Max: 5, Domain: 400}
+	// candles are 100px apart.
+	assert.Equal(30, cs.getHalfBodyWidth(xrange))
+
+	cs.BodyWidth = 1
+	assert.Equal(50, cs.getHalfBodyWidth(xrange))
+}
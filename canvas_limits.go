@@ -0,0 +1,40 @@
+package chart
+
+// CanvasLimits bounds the size of the raster canvases renderers will allocate,
+// so an unreasonable width or height fails with an error instead of exhausting memory.
+// A zero field means no limit.
+type CanvasLimits struct {
+	MaxWidth  int
+	MaxHeight int
+	// MaxPixels is the largest width * height; each pixel takes 4 bytes.
+	MaxPixels int
+}
+
+// DefaultCanvasLimits are the limits checked by the raster renderers and the chart `Validate` methods.
+// Change them before rendering, i.e. when a service starts; they are not safe to change concurrently with renders.
+var DefaultCanvasLimits = CanvasLimits{
+	MaxWidth:  DefaultMaxCanvasWidth,
+	MaxHeight: DefaultMaxCanvasHeight,
+	MaxPixels: DefaultMaxCanvasPixels,
+}
+
+// Check returns an error with kind `ErrCanvasTooLarge` if a canvas of the given size exceeds the limits.
+func (cl CanvasLimits) Check(width, height int) error {
+	if err := cl.check(width, height); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cl CanvasLimits) check(width, height int) *ValidationError {
+	if cl.MaxWidth > 0 && width > cl.MaxWidth {
+		return newValidationError(ErrCanvasTooLarge, "canvas width %d exceeds the limit of %d", width, cl.MaxWidth)
+	}
+	if cl.MaxHeight > 0 && height > cl.MaxHeight {
+		return newValidationError(ErrCanvasTooLarge, "canvas height %d exceeds the limit of %d", height, cl.MaxHeight)
+	}
+	if cl.MaxPixels > 0 && int64(width)*int64(height) > int64(cl.MaxPixels) {
+		return newValidationError(ErrCanvasTooLarge, "canvas size %dx%d exceeds the limit of %d pixels", width, height, cl.MaxPixels)
+	}
+	return nil
+}
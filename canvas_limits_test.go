@@ -0,0 +1,58 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestCanvasLimitsCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	limits := CanvasLimits{MaxWidth: 100, MaxHeight: 50, MaxPixels: 2000}
+	assert.Nil(limits.Check(100, 20))
+	assert.True(errors.Is(limits.Check(101, 1), ErrCanvasTooLarge))
+	assert.True(errors.Is(limits.Check(1, 51), ErrCanvasTooLarge))
+	assert.True(errors.Is(limits.Check(100, 50), ErrCanvasTooLarge))
+	assert.Nil(CanvasLimits{}.Check(1<<20, 1<<20))
+	assert.NotNil(DefaultCanvasLimits.Check(50000, 50000))
+}
+
+func TestChartRenderCanvasTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  50000,
+		Height: 50000,
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+	err := c.Render(PNG, bytes.NewBuffer(nil))
+	assert.True(errors.Is(err, ErrCanvasTooLarge), err)
+	assert.True(errors.Is(c.Validate(), ErrCanvasTooLarge))
+
+	// svg output doesn't allocate a raster canvas.
+	assert.Nil(c.Render(SVG, bytes.NewBuffer(nil)))
+
+	c.Width, c.Height = 10000, 5000
+	assert.Nil(DefaultCanvasLimits.Check(c.Width, c.Height))
+	c.ScaleFactor = 2
+	err = c.Render(PNG, bytes.NewBuffer(nil))
+	assert.True(errors.Is(err, ErrCanvasTooLarge), err)
+	assert.True(errors.Is(c.Validate(), ErrCanvasTooLarge))
+}
+
+func TestBarChartValidateCanvasTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Width:  50000,
+		Height: 50000,
+		Bars:   []Value{{Value: 1, Label: "one"}, {Value: 2, Label: "two"}},
+	}
+	assert.True(errors.Is(bc.Validate(), ErrCanvasTooLarge))
+	assert.True(errors.Is(bc.Render(PNG, bytes.NewBuffer(nil)), ErrCanvasTooLarge))
+}
@@ -0,0 +1,98 @@
+package chart
+
+import "fmt"
+
+// CategoryScale is how a `CategoryRange` spaces its categories across the domain.
+type CategoryScale int
+
+const (
+	// CategoryScaleUnset places the categories by the bounds of the range, which are set from the series.
+	CategoryScaleUnset CategoryScale = 0
+	// CategoryScaleBand divides the domain into a band for each category, with the category in the middle of its band.
+	CategoryScaleBand CategoryScale = 1
+	// CategoryScalePoint spaces the categories evenly, with the first and last category at the ends of the domain.
+	CategoryScalePoint CategoryScale = 2
+)
+
+// CategoryRange is a range of named categories, i.e. the boxes of a `BoxPlotSeries`. Category i is at value i,
+// and the ticks are the names of the categories in the range.
+//
+// Charts use a `CategoryRange` with the labels of their first box plot series for the x axis by default;
+// its bounds are set from the series like any other range. Charts of `CategorySeries` use one with the
+// categories of all of the series and a band scale, whose bounds are set by the categories.
+type CategoryRange struct {
+	ContinuousRange
+
+	Categories []string
+	Scale      CategoryScale
+}
+
+// String returns a simple string for the range.
+func (cr CategoryRange) String() string {
+	return fmt.Sprintf("CategoryRange [%.2f,%.2f] %d categories => %d", cr.GetMin(), cr.GetMax(), len(cr.Categories), cr.Domain)
+}
+
+// GetMin returns the min value of the range; with a scale it is set by the categories.
+func (cr CategoryRange) GetMin() float64 {
+	min, _ := cr.getBounds()
+	return min
+}
+
+// GetMax returns the max value of the range; with a scale it is set by the categories.
+func (cr CategoryRange) GetMax() float64 {
+	_, max := cr.getBounds()
+	return max
+}
+
+// GetDelta returns the difference between the min and max value.
+func (cr CategoryRange) GetDelta() float64 {
+	min, max := cr.getBounds()
+	return max - min
+}
+
+// Translate maps a value into the range space.
+func (cr CategoryRange) Translate(value float64) int {
+	scaled := cr.ContinuousRange
+	scaled.Min, scaled.Max = cr.getBounds()
+	return scaled.Translate(value)
+}
+
+// getBounds returns the bounds of the range for its scale.
+func (cr CategoryRange) getBounds() (min, max float64) {
+	count := float64(len(cr.Categories))
+	switch {
+	case cr.Scale == CategoryScaleUnset || count == 0:
+		return cr.Min, cr.Max
+	case cr.Scale == CategoryScalePoint && count > 1:
+		return 0, count - 1
+	default:
+		return -0.5, count - 0.5
+	}
+}
+
+// GetPosition returns the value a category is at, and false if the range doesn't have the category.
+func (cr CategoryRange) GetPosition(category string) (float64, bool) {
+	for index, c := range cr.Categories {
+		if c == category {
+			return float64(index), true
+		}
+	}
+	return 0, false
+}
+
+// fit leaves the bounds as they are; they are the bands of the categories, not values to pad or round.
+func (cr *CategoryRange) fit() {}
+
+// GetTicks returns a tick labeled with the category name for every category in the range.
+// It implements `TicksProvider`.
+func (cr *CategoryRange) GetTicks(r Renderer, defaults Style, vf ValueFormatter) []Tick {
+	var ticks []Tick
+	for index, category := range cr.Categories {
+		value := float64(index)
+		if value < cr.GetMin() || value > cr.GetMax() {
+			continue
+		}
+		ticks = append(ticks, Tick{Value: value, Label: category})
+	}
+	return ticks
+}
@@ -0,0 +1,53 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestCategoryRangeGetTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	cr := &CategoryRange{
+		ContinuousRange: ContinuousRange{Min: 0.5, Max: 3.5, Domain: 100},
+		Categories:      []string{"a", "b", "c", "d", "e"},
+	}
+	ticks := cr.GetTicks(nil, Style{}, FloatValueFormatter)
+	assert.Len(ticks, 3)
+	assert.Equal(Tick{Value: 1, Label: "b"}, ticks[0])
+	assert.Equal(Tick{Value: 3, Label: "d"}, ticks[2])
+}
+
+func TestCategoryRangeScales(t *testing.T) {
+	assert := assert.New(t)
+
+	band := &CategoryRange{
+		ContinuousRange: ContinuousRange{Domain: 300},
+		Categories:      []string{"a", "b", "c"},
+		Scale:           CategoryScaleBand,
+	}
+	// bounds set by the chart are ignored.
+	band.SetMin(1)
+	band.SetMax(2)
+	assert.Equal(-0.5, band.GetMin())
+	assert.Equal(2.5, band.GetMax())
+	assert.Equal(50, band.Translate(0))
+	assert.Equal(150, band.Translate(1))
+	assert.Equal(250, band.Translate(2))
+	assert.Len(band.GetTicks(nil, Style{}, nil), 3)
+
+	point := &CategoryRange{
+		ContinuousRange: ContinuousRange{Domain: 300},
+		Categories:      []string{"a", "b", "c"},
+		Scale:           CategoryScalePoint,
+	}
+	assert.Equal(0, point.Translate(0))
+	assert.Equal(300, point.Translate(2))
+
+	position, hasCategory := point.GetPosition("c")
+	assert.True(hasCategory)
+	assert.Equal(2.0, position)
+	_, hasCategory = point.GetPosition("z")
+	assert.False(hasCategory)
+}
@@ -0,0 +1,130 @@
+package chart
+
+import "math"
+
+// CategorySeries is a line of values at named categories, i.e. days of the week or regions; the chart's x axis
+// is a `CategoryRange` with the categories of all of its category series, in the order they first appear.
+// Like a `ContinuousSeries`, it is drawn as dots for a scatter plot if its style has a dot width and no stroke.
+type CategorySeries struct {
+	Name  string
+	Style Style
+
+	YAxis YAxisType
+
+	YValueFormatter ValueFormatter
+
+	XValues []string
+	YValues []float64
+}
+
+// GetName returns the name of the series.
+func (cs CategorySeries) GetName() string {
+	return cs.Name
+}
+
+// GetStyle returns the line style.
+func (cs CategorySeries) GetStyle() Style {
+	return cs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (cs CategorySeries) GetYAxis() YAxisType {
+	return cs.YAxis
+}
+
+// GetCategories returns the categories of the series, in the order they first appear.
+func (cs CategorySeries) GetCategories() []string {
+	var categories []string
+	seen := map[string]bool{}
+	for _, category := range cs.XValues {
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// Len returns the number of elements in the series.
+func (cs CategorySeries) Len() int {
+	return len(cs.XValues)
+}
+
+// GetValues gets the x,y values at a given index; the x value is the index of the category among the
+// categories of the series (see `GetCategories`).
+func (cs CategorySeries) GetValues(index int) (float64, float64) {
+	var position float64
+	seen := map[string]bool{}
+	for _, category := range cs.XValues {
+		if category == cs.XValues[index] {
+			break
+		}
+		if !seen[category] {
+			seen[category] = true
+			position++
+		}
+	}
+	return position, cs.YValues[index]
+}
+
+// GetLastValues gets the last x,y values.
+func (cs CategorySeries) GetLastValues() (float64, float64) {
+	return cs.GetValues(len(cs.XValues) - 1)
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (cs CategorySeries) GetValueFormatters() (x, y ValueFormatter) {
+	x = FloatValueFormatter
+	if cs.YValueFormatter != nil {
+		y = cs.YValueFormatter
+	} else {
+		y = FloatValueFormatter
+	}
+	return
+}
+
+// GetBounds implements `BoundsProvider`; the x bounds span the categories of the series.
+func (cs CategorySeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minY, maxY = math.MaxFloat64, -math.MaxFloat64
+	for _, value := range cs.YValues {
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			minY, maxY = math.Min(minY, value), math.Max(maxY, value)
+		}
+	}
+	return 0, float64(len(cs.GetCategories()) - 1), minY, maxY
+}
+
+// Render renders the series, with each value at its category in the x range.
+func (cs CategorySeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := cs.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, cs.inRange(xrange))
+}
+
+// inRange returns the series with the x values at the categories of a category range;
+// values of categories the range doesn't have are skipped.
+func (cs CategorySeries) inRange(xrange Range) ContinuousSeries {
+	cr, isCategoryRange := xrange.(*CategoryRange)
+	if !isCategoryRange {
+		cr = &CategoryRange{Categories: cs.GetCategories()}
+	}
+	xvalues := make([]float64, len(cs.XValues))
+	for index, category := range cs.XValues {
+		if position, hasCategory := cr.GetPosition(category); hasCategory {
+			xvalues[index] = position
+		} else {
+			xvalues[index] = math.NaN()
+		}
+	}
+	return ContinuousSeries{XValues: xvalues, YValues: cs.YValues}
+}
+
+// Validate validates the series.
+func (cs CategorySeries) Validate() error {
+	if len(cs.XValues) == 0 {
+		return newValidationError(ErrEmptySeries, "category series must have xvalues set")
+	}
+	if len(cs.XValues) != len(cs.YValues) {
+		return newValidationError(ErrLengthMismatch, "category series has %d xvalues but %d yvalues", len(cs.XValues), len(cs.YValues))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestCategorySeriesValues(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := CategorySeries{
+		XValues: []string{"Mon", "Tue", "Mon", "Wed"},
+		YValues: []float64{1, 2, 3, 4},
+	}
+	assert.Equal([]string{"Mon", "Tue", "Wed"}, cs.GetCategories())
+
+	x, y := cs.GetValues(2)
+	assert.Equal(0.0, x)
+	assert.Equal(3.0, y)
+	x, _ = cs.GetLastValues()
+	assert.Equal(2.0, x)
+
+	minX, maxX, minY, maxY := cs.GetBounds()
+	assert.Equal(0.0, minX)
+	assert.Equal(2.0, maxX)
+	assert.Equal(1.0, minY)
+	assert.Equal(4.0, maxY)
+
+	// values are placed at their category in the range, and skipped if it doesn't have it.
+	placed := cs.inRange(&CategoryRange{Categories: []string{"Wed", "Mon"}})
+	assert.Equal(1.0, placed.XValues[0])
+	assert.True(math.IsNaN(placed.XValues[1]))
+	assert.Equal(0.0, placed.XValues[3])
+
+	assert.NotNil(CategorySeries{XValues: []string{"a"}}.Validate())
+}
+
+func TestChartCategorySeries(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		Series: []Series{
+			CategorySeries{XValues: []string{"Mon", "Tue", "Wed"}, YValues: []float64{1, 2, 3}},
+			CategorySeries{
+				Style:   Style{Show: true, StrokeWidth: Disabled, DotWidth: 3},
+				XValues: []string{"Wed", "Thu"},
+				YValues: []float64{2, 5},
+			},
+		},
+	}
+	xr, _, _ := c.getRanges()
+	cr, isCategoryRange := xr.(*CategoryRange)
+	assert.True(isCategoryRange)
+	assert.Equal([]string{"Mon", "Tue", "Wed", "Thu"}, cr.Categories)
+	assert.Equal(CategoryScaleBand, cr.Scale)
+	assert.Equal(-0.5, cr.GetMin())
+	assert.Equal(3.5, cr.GetMax())
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
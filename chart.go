@@ -3,6 +3,7 @@ package chart
 import (
 	"errors"
 	"fmt"
+	"image"
 	"io"
 	"math"
 
@@ -15,7 +16,19 @@ type Chart struct {
 	Title      string
 	TitleStyle Style
 
+	// Subtitle is drawn under the title; once it is set, the title and subtitle are laid out above the canvas
+	// rather than drawn over it. Footer and Source are laid out below the canvas, left and right aligned.
+	Subtitle      string
+	SubtitleStyle Style
+	Footer        string
+	FooterStyle   Style
+	Source        string
+	SourceStyle   Style
+
 	ColorPalette ColorPalette
+	// Theme is the color palette of the chart if `ColorPalette` is not set; grid lines are drawn
+	// in its grid color unless their styles set one.
+	Theme Theme
 
 	Width  int
 	Height int
@@ -24,15 +37,85 @@ type Chart struct {
 	Background Style
 	Canvas     Style
 
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
 	XAxis          XAxis
 	YAxis          YAxis
 	YAxisSecondary YAxis
 
+	// XAxisSecondary is drawn along the top of the canvas with its own range, ticks and formatter, i.e. to label
+	// the same values on a second time scale. Series draw on it if their `XAxis` is `XAxisSecondary`; if none do,
+	// its range spans the primary x range. Like the other axes, it only draws grid lines if its `GridMajorStyle`
+	// or `GridMinorStyle` is shown, so they can be left off to not double up with the primary's.
+	XAxisSecondary XAxis
+
 	Font        *truetype.Font
 	defaultFont *truetype.Font
 
 	Series   []Series
 	Elements []Renderable
+
+	// Legend is a legend the chart draws itself if its style is shown; legends placed outside the canvas
+	// are laid out around, so they never overlap the plot.
+	Legend LegendOptions
+	// plotBox is the chart box less the space taken by the legend; it is set while laying out.
+	plotBox Box
+	// contentBox is the chart box less the space taken by the title blocks; it is set while laying out and painting.
+	contentBox Box
+
+	// ClipSeries clips the series to the canvas, so series with values outside fixed ranges don't draw over the axes.
+	// Series that are a `ClipProvider`, i.e. annotations that overhang the canvas on purpose, decide for themselves.
+	ClipSeries bool
+
+	// GridPlacement is whether the grid lines and the zero line are drawn below or above the series.
+	GridPlacement GridPlacement
+
+	// SeriesParallelism is the number of series drawn at once into raster output, no more than GOMAXPROCS;
+	// `SeriesParallelismAuto` draws GOMAXPROCS series at once. The output is the same as drawing them in turn.
+	SeriesParallelism int
+
+	// RenderCache keeps the layers of the chart that don't depend on the series values between raster renders,
+	// so renders of new data only draw the series; it is optional.
+	RenderCache *RenderCache
+
+	// LayoutEngine positions the canvas; it defaults to `DefaultLayoutEngine`.
+	LayoutEngine LayoutEngine
+
+	ScaleFactor float64
+
+	// UnitAspectRatio locks the length of a y unit to this many times the length of an x unit, i.e. 1 for maps
+	// and geometry where a unit is as long along both axes; the x or y range is widened around its center to keep it.
+	// Zero leaves the ranges as they are.
+	UnitAspectRatio float64
+
+	// Log receives warnings about data quality problems found while rendering; it is optional.
+	Log Logger
+
+	// SpanHook is called around each phase of a render, i.e. to start and end tracing spans; it is optional.
+	SpanHook SpanHook
+	// OnRenderTimings is called with the phase timings at the end of each render; it is optional.
+	OnRenderTimings func(RenderTimings)
+	timer           *renderTimer
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// NoText renders the chart without any text, and without loading a font, i.e. for sparklines and thumbnails.
+	NoText bool
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	// Metadata is text written into the output, as png iTXt chunks or a svg `<metadata>` element.
+	Metadata map[string]string
+	// EmbedSpec adds the chart spec and a hash of the series values to the metadata,
+	// under `MetadataKeySpec` and `MetadataKeyDataHash`, so the output can be audited or reproduced.
+	EmbedSpec bool
 }
 
 // GetDPI returns the dpi for the chart.
@@ -54,6 +137,39 @@ func (c Chart) GetFont() *truetype.Font {
 	return c.Font
 }
 
+// GetSeriesParallelism returns the number of goroutines used to render series, or a default.
+// Values greater than one, or `SeriesParallelismAuto`, render series concurrently (raster output only).
+func (c Chart) GetSeriesParallelism(defaults ...int) int {
+	if c.SeriesParallelism == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return 1
+	}
+	return c.SeriesParallelism
+}
+
+// GetScaleFactor returns the device scale factor, or a default.
+// Raster output is rendered at `ScaleFactor` times the logical width and height,
+// with fonts, strokes and positions scaled to match, i.e. 2 for Retina displays.
+func (c Chart) GetScaleFactor(defaults ...float64) float64 {
+	if c.ScaleFactor == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return 1
+	}
+	return c.ScaleFactor
+}
+
+// GetLayoutEngine returns the layout engine or the default.
+func (c Chart) GetLayoutEngine() LayoutEngine {
+	if c.LayoutEngine == nil {
+		return DefaultLayoutEngine
+	}
+	return c.LayoutEngine
+}
+
 // GetWidth returns the chart width or the default value.
 func (c Chart) GetWidth() int {
 	if c.Width == 0 {
@@ -71,75 +187,247 @@ func (c Chart) GetHeight() int {
 }
 
 // Render renders the chart with the given renderer to the given io.Writer.
-func (c Chart) Render(rp RendererProvider, w io.Writer) error {
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (c Chart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if c.timer = c.newRenderTimer(); c.timer != nil {
+		endRender := c.timer.start(RenderPhaseRender)
+		defer func() {
+			endRender()
+			if c.OnRenderTimings != nil {
+				c.OnRenderTimings(c.timer.timings)
+			}
+		}()
+	}
+
 	if len(c.Series) == 0 {
-		return errors.New("please provide at least one series")
+		return newValidationError(ErrEmptySeries, "please provide at least one series")
 	}
 	if visibleSeriesErr := c.checkHasVisibleSeries(); visibleSeriesErr != nil {
 		return visibleSeriesErr
 	}
-
-	c.YAxisSecondary.AxisType = YAxisSecondary
-
-	r, err := rp(c.GetWidth(), c.GetHeight())
-	if err != nil {
-		return err
+	if lengthErr := c.checkSeriesLengths(); lengthErr != nil {
+		return lengthErr
 	}
 
-	if c.Font == nil {
-		defaultFont, err := GetDefaultFont()
+	if c.Font == nil && !c.NoText {
+		defaultFont, err := loadDefaultFont()
 		if err != nil {
 			return err
 		}
 		c.defaultFont = defaultFont
 	}
+
+	r, err := c.NewRenderer(rp)
+	if err != nil {
+		return err
+	}
+
+	cl, err := c.Layout(r, c.Measure())
+	if err != nil {
+		c.withDefaults().drawBackground(r)
+		r.Save(w)
+		return err
+	}
+	return c.Paint(r, cl, w)
+}
+
+// RenderToImage renders the chart with the raster renderer and returns the image, without encoding it (see `RenderImage`).
+func (c Chart) RenderToImage() (image.Image, error) {
+	return RenderImage(c)
+}
+
+// ChartLayout is the result of measuring and laying out a chart: the final ranges, canvas box and ticks.
+// It can be adjusted between the phases of a render (see `Chart.Measure`).
+type ChartLayout struct {
+	// Canvas is the box the series are plotted in.
+	Canvas Box
+
+	XRange          Range
+	XRangeSecondary Range
+	YRange          Range
+	YRangeSecondary Range
+
+	XTicks          []Tick
+	XTicksSecondary []Tick
+	YTicks          []Tick
+	YTicksSecondary []Tick
+}
+
+// NewRenderer returns a renderer from the provider sized and configured (dpi, scale factor) for the chart.
+func (c Chart) NewRenderer(rp RendererProvider) (Renderer, error) {
+	r, err := rp(c.GetWidth(), c.GetHeight())
+	if err != nil {
+		return nil, err
+	}
 	r.SetDPI(c.GetDPI(DefaultDPI))
+	if err := applyScaleFactor(r, c.GetScaleFactor()); err != nil {
+		return nil, err
+	}
+	if c.NoText {
+		return textFreeRenderer{r}, nil
+	}
+	return r, nil
+}
 
-	c.drawBackground(r)
+// withDefaults returns the chart with the defaults the render phases rely on filled in.
+func (c Chart) withDefaults() Chart {
+	c.XAxisSecondary.AxisType = XAxisSecondary
+	c.YAxisSecondary.AxisType = YAxisSecondary
+	if c.Font == nil && c.defaultFont == nil && !c.NoText {
+		c.defaultFont, _ = GetDefaultFont()
+	}
+	return c
+}
 
-	var xt, yt, yta []Tick
+// Measure is the first phase of a render: it reads the series values and axis settings and returns a layout
+// with the ranges set. `Render` is equivalent to:
+//
+//	r, err := graph.NewRenderer(chart.PNG)
+//	cl, err := graph.Layout(r, graph.Measure())
+//	err = graph.Paint(r, cl, w)
+//
+// so the ranges can be adjusted before the layout, and the canvas box and ticks before painting.
+func (c Chart) Measure() ChartLayout {
+	c = c.withDefaults()
+	defer c.timer.start(RenderPhaseMeasure)()
 	xr, yr, yra := c.getRanges()
+	return ChartLayout{XRange: xr, XRangeSecondary: c.getSecondaryXRange(xr), YRange: yr, YRangeSecondary: yra}
+}
+
+// Layout is the second phase of a render: it generates the ticks for the measured ranges and fits the canvas
+// box around the axes and annotations. The renderer is only used to measure text.
+func (c Chart) Layout(r Renderer, measured ChartLayout) (cl ChartLayout, err error) {
+	c = c.withDefaults()
+	defer c.timer.start(RenderPhaseLayout)()
+
+	var xt, xta, yt, yta []Tick
+	xr, xra, yr, yra := measured.XRange, measured.XRangeSecondary, measured.YRange, measured.YRangeSecondary
+	if xra == nil {
+		xra = c.getSecondaryXRange(xr)
+	}
+	c.contentBox = c.getTitleBlocks().adjust(r, c.Box())
+	c.plotBox = c.getLegendAdjustedBox(r)
 	canvasBox := c.getDefaultCanvasBox()
 	xf, yf, yfa := c.getValueFormatters()
+	xfa := c.getSecondaryXValueFormatter(xf)
 
-	xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
+	xr, xra, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra)
 
 	err = c.checkRanges(xr, yr, yra)
 	if err != nil {
-		r.Save(w)
-		return err
+		return
+	}
+	err = c.checkSecondaryXRange(xra)
+	if err != nil {
+		return
 	}
 
 	if c.hasAxes() {
-		xt, yt, yta = c.getAxesTicks(r, xr, yr, yra, xf, yf, yfa)
-		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, yr, yra, xt, yt, yta)
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
+		xt, xta, yt, yta = c.getAxesTicks(r, xr, xra, yr, yra, xf, xfa, yf, yfa)
+		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, xra, yr, yra, xt, xta, yt, yta)
+		xr, xra, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra)
 
 		// do a second pass in case things haven't settled yet.
-		xt, yt, yta = c.getAxesTicks(r, xr, yr, yra, xf, yf, yfa)
-		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, yr, yra, xt, yt, yta)
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
+		xt, xta, yt, yta = c.getAxesTicks(r, xr, xra, yr, yra, xf, xfa, yf, yfa)
+		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, xra, yr, yra, xt, xta, yt, yta)
+		xr, xra, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra)
+	}
+
+	if c.hasMeasurableSeries() {
+		canvasBox = c.getAnnotationAdjustedCanvasBox(r, canvasBox, xr, xra, yr, yra, xf, yf, yfa)
+		xr, xra, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra)
+		xt, xta, yt, yta = c.getAxesTicks(r, xr, xra, yr, yra, xf, xfa, yf, yfa)
 	}
 
-	if c.hasAnnotationSeries() {
-		canvasBox = c.getAnnotationAdjustedCanvasBox(r, canvasBox, xr, yr, yra, xf, yf, yfa)
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
-		xt, yt, yta = c.getAxesTicks(r, xr, yr, yra, xf, yf, yfa)
+	if c.lockUnitAspectRatio(xr, yr) && c.hasAxes() {
+		xt, xta, yt, yta = c.getAxesTicks(r, xr, xra, yr, yra, xf, xfa, yf, yfa)
 	}
 
-	c.drawCanvas(r, canvasBox)
-	c.drawAxes(r, canvasBox, xr, yr, yra, xt, yt, yta)
-	for index, series := range c.Series {
-		c.drawSeries(r, canvasBox, xr, yr, yra, series, index)
+	cl = ChartLayout{
+		Canvas:          canvasBox,
+		XRange:          xr,
+		XRangeSecondary: xra,
+		YRange:          yr,
+		YRangeSecondary: yra,
+		XTicks:          xt,
+		XTicksSecondary: xta,
+		YTicks:          yt,
+		YTicksSecondary: yta,
 	}
+	return
+}
+
+// paintUnderSeries draws the layers under the series: the background, the canvas and the axes.
+func (c Chart) paintUnderSeries(r Renderer, cl ChartLayout) {
+	startGroup(r, "background", "background")
+	c.drawBackground(r)
+	c.BackgroundImage.draw(r, Box{Right: c.GetWidth(), Bottom: c.GetHeight()})
+	endGroup(r)
+
+	startGroup(r, "canvas", "canvas")
+	c.drawCanvas(r, cl.Canvas)
+	endGroup(r)
+	c.Watermark.draw(r, Box{Right: c.GetWidth(), Bottom: c.GetHeight()}, c.GetFont(), c.GetColorPalette(), false)
+	callRenderHook(c.BeforeRender, r, cl)
+	c.drawAxes(r, cl)
+}
+
+// paintSeries draws the series.
+func (c Chart) paintSeries(r Renderer, cl ChartLayout) {
+	endSeries := c.timer.start(RenderPhaseSeries)
+	c.drawAllSeries(r, cl)
+	endSeries()
+}
+
+// paintOverSeries draws the layers over the series that only depend on the settings and layout of the chart:
+// the grid lines if they are placed above the series, the title and the legend.
+func (c Chart) paintOverSeries(r Renderer, cl ChartLayout) {
+	c.drawGridAboveSeries(r, cl)
 
+	startGroup(r, "title", "title")
 	c.drawTitle(r)
+	endGroup(r)
+	startGroup(r, "legend", "legend")
+	c.drawLegend(r, cl.Canvas)
+	endGroup(r)
+}
+
+// Paint is the last phase of a render: it draws the chart with a layout and saves the renderer to the writer.
+// If the canvas box was changed after `Layout`, the range domains are updated to match it.
+func (c Chart) Paint(r Renderer, cl ChartLayout, w io.Writer) error {
+	c = c.withDefaults()
+	if cl.XRangeSecondary == nil {
+		cl.XRangeSecondary = c.getSecondaryXRange(cl.XRange)
+	}
+	cl.XRange, cl.XRangeSecondary, cl.YRange, cl.YRangeSecondary = c.setRangeDomains(cl.Canvas, cl.XRange, cl.XRangeSecondary, cl.YRange, cl.YRangeSecondary)
+	c.contentBox = c.getTitleBlocks().adjust(r, c.Box())
+	c.logWarnings(r, cl)
+
+	if rr, isCached := c.getRenderCacheRaster(r); isCached {
+		c.paintCached(r, rr, cl)
+	} else {
+		c.paintUnderSeries(r, cl)
+		c.paintSeries(r, cl)
+		c.paintOverSeries(r, cl)
+	}
 
-	for _, a := range c.Elements {
-		a(r, canvasBox, c.styleDefaultsElements())
+	for index, a := range c.Elements {
+		startGroup(r, fmt.Sprintf("element-%d", index), "element", fmt.Sprintf("element-%d", index))
+		a(r, cl.Canvas, c.styleDefaultsElements())
+		endGroup(r)
 	}
+	c.Watermark.draw(r, Box{Right: c.GetWidth(), Bottom: c.GetHeight()}, c.GetFont(), c.GetColorPalette(), true)
+	callRenderHook(c.AfterRender, r, cl)
 
-	return r.Save(w)
+	setAccessibility(r, c.Accessibility.withDefaults(c.Title, c.describeSeries))
+	setMetadata(r, c.getImageMetadata())
+
+	endEncode := c.timer.start(RenderPhaseEncode)
+	err := r.Save(w)
+	endEncode()
+	return err
 }
 
 func (c Chart) checkHasVisibleSeries() error {
@@ -150,7 +438,18 @@ func (c Chart) checkHasVisibleSeries() error {
 		hasVisibleSeries = hasVisibleSeries || (style.IsZero() || style.Show)
 	}
 	if !hasVisibleSeries {
-		return fmt.Errorf("must have (1) visible series; make sure if you set a style, you set .Show = true")
+		return newValidationError(ErrNoVisibleSeries, "must have (1) visible series; make sure if you set a style, you set .Show = true")
+	}
+	return nil
+}
+
+// checkSeriesLengths returns an error for the first series with mismatched x and y values,
+// which would otherwise index out of range while rendering.
+func (c Chart) checkSeriesLengths() error {
+	for index, s := range c.Series {
+		if err := s.Validate(); errors.Is(err, ErrLengthMismatch) {
+			return seriesValidationError(s, index, err)
+		}
 	}
 	return nil
 }
@@ -170,6 +469,8 @@ func (c Chart) getRanges() (xrange, yrange, yrangeAlt Range) {
 	var minx, maxx float64 = math.MaxFloat64, -math.MaxFloat64
 	var miny, maxy float64 = math.MaxFloat64, -math.MaxFloat64
 	var minya, maxya float64 = math.MaxFloat64, -math.MaxFloat64
+	// the smallest positive values are the minimums of logarithmic ranges.
+	var minpx, minpy, minpya float64 = math.MaxFloat64, math.MaxFloat64, math.MaxFloat64
 
 	seriesMappedToSecondaryAxis := false
 
@@ -178,62 +479,106 @@ func (c Chart) getRanges() (xrange, yrange, yrangeAlt Range) {
 	for _, s := range c.Series {
 		if s.GetStyle().IsZero() || s.GetStyle().Show {
 			seriesAxis := s.GetYAxis()
-			if bvp, isBoundedValuesProvider := s.(BoundedValuesProvider); isBoundedValuesProvider {
+			// the x values of series on the secondary x-axis are in its range instead.
+			onPrimaryX := getSeriesXAxis(s) == XAxisPrimary
+			if bminx, bmaxx, bminy, bmaxy, hasBounds := getSeriesBounds(s); hasBounds {
+				if onPrimaryX {
+					minx = math.Min(minx, bminx)
+					maxx = math.Max(maxx, bmaxx)
+					minpx = minPositive(minpx, bminx)
+				}
+
+				if seriesAxis == YAxisPrimary {
+					miny = math.Min(miny, bminy)
+					maxy = math.Max(maxy, bmaxy)
+					minpy = minPositive(minpy, bminy)
+				} else if seriesAxis == YAxisSecondary {
+					minya = math.Min(minya, bminy)
+					maxya = math.Max(maxya, bmaxy)
+					minpya = minPositive(minpya, bminy)
+					seriesMappedToSecondaryAxis = true
+				}
+			} else if bvp, isBoundedValuesProvider := s.(BoundedValuesProvider); isBoundedValuesProvider {
 				seriesLength := bvp.Len()
 				for index := 0; index < seriesLength; index++ {
 					vx, vy1, vy2 := bvp.GetBoundedValues(index)
 
-					minx = math.Min(minx, vx)
-					maxx = math.Max(maxx, vx)
+					if onPrimaryX {
+						minx = math.Min(minx, vx)
+						maxx = math.Max(maxx, vx)
+						minpx = minPositive(minpx, vx)
+					}
 
 					if seriesAxis == YAxisPrimary {
 						miny = math.Min(miny, vy1)
 						miny = math.Min(miny, vy2)
 						maxy = math.Max(maxy, vy1)
 						maxy = math.Max(maxy, vy2)
+						minpy = minPositive(minPositive(minpy, vy1), vy2)
 					} else if seriesAxis == YAxisSecondary {
 						minya = math.Min(minya, vy1)
 						minya = math.Min(minya, vy2)
 						maxya = math.Max(maxya, vy1)
 						maxya = math.Max(maxya, vy2)
+						minpya = minPositive(minPositive(minpya, vy1), vy2)
 						seriesMappedToSecondaryAxis = true
 					}
 				}
 			} else if vp, isValuesProvider := s.(ValuesProvider); isValuesProvider {
+				var xvalues, yvalues [valuesBatchSize]float64
 				seriesLength := vp.Len()
-				for index := 0; index < seriesLength; index++ {
-					vx, vy := vp.GetValues(index)
-
-					minx = math.Min(minx, vx)
-					maxx = math.Max(maxx, vx)
-
-					if seriesAxis == YAxisPrimary {
-						miny = math.Min(miny, vy)
-						maxy = math.Max(maxy, vy)
-					} else if seriesAxis == YAxisSecondary {
-						minya = math.Min(minya, vy)
-						maxya = math.Max(maxya, vy)
-						seriesMappedToSecondaryAxis = true
+				for start := 0; start < seriesLength; {
+					count := GetValuesInto(vp, start, xvalues[:], yvalues[:])
+					for index := 0; index < count; index++ {
+						vx, vy := xvalues[index], yvalues[index]
+						if !isFinitePoint(vx, vy) {
+							continue
+						}
+
+						if onPrimaryX {
+							minx = math.Min(minx, vx)
+							maxx = math.Max(maxx, vx)
+							minpx = minPositive(minpx, vx)
+						}
+
+						if seriesAxis == YAxisPrimary {
+							miny = math.Min(miny, vy)
+							maxy = math.Max(maxy, vy)
+							minpy = minPositive(minpy, vy)
+						} else if seriesAxis == YAxisSecondary {
+							minya = math.Min(minya, vy)
+							maxya = math.Max(maxya, vy)
+							minpya = minPositive(minpya, vy)
+							seriesMappedToSecondaryAxis = true
+						}
 					}
+					if count == 0 {
+						break
+					}
+					start += count
 				}
 			}
 		}
 	}
 
-	if c.XAxis.Range == nil {
-		xrange = &ContinuousRange{}
+	if c.XAxis.Range == nil && c.hasTimeSeries() {
+		xrange = &TimeRange{ContinuousRange: ContinuousRange{Descending: c.XAxis.Descending}, Location: c.XAxis.TimeZone}
+	} else if categories, scale, hasCategories := c.getCategories(); c.XAxis.Range == nil && hasCategories {
+		xrange = &CategoryRange{ContinuousRange: ContinuousRange{Descending: c.XAxis.Descending}, Categories: categories, Scale: scale}
+	} else if c.XAxis.Range == nil {
+		xrange = &ContinuousRange{Descending: c.XAxis.Descending}
 	} else {
 		xrange = c.XAxis.Range
 	}
 
 	if c.YAxis.Range == nil {
-		yrange = &ContinuousRange{}
+		yrange = &ContinuousRange{Descending: c.YAxis.Descending}
 	} else {
 		yrange = c.YAxis.Range
 	}
 
 	if c.YAxisSecondary.Range == nil {
-		yrangeAlt = &ContinuousRange{}
+		yrangeAlt = &ContinuousRange{Descending: c.YAxisSecondary.Descending}
 	} else {
 		yrangeAlt = c.YAxisSecondary.Range
 	}
@@ -247,8 +592,9 @@ func (c Chart) getRanges() (xrange, yrange, yrangeAlt Range) {
 		xrange.SetMin(tickMin)
 		xrange.SetMax(tickMax)
 	} else if xrange.IsZero() {
-		xrange.SetMin(minx)
+		xrange.SetMin(rangeMin(xrange, minx, minpx))
 		xrange.SetMax(maxx)
+		fitRange(xrange)
 	}
 
 	if len(c.YAxis.Ticks) > 0 {
@@ -260,17 +606,12 @@ func (c Chart) getRanges() (xrange, yrange, yrangeAlt Range) {
 		yrange.SetMin(tickMin)
 		yrange.SetMax(tickMax)
 	} else if yrange.IsZero() {
-		yrange.SetMin(miny)
+		yrange.SetMin(rangeMin(yrange, miny, minpy))
 		yrange.SetMax(maxy)
 
-		// only round if we're showing the axis
-		if c.YAxis.Style.Show {
-			delta := yrange.GetDelta()
-			roundTo := util.Math.GetRoundToForDelta(delta)
-			rmin, rmax := util.Math.RoundDown(yrange.GetMin(), roundTo), util.Math.RoundUp(yrange.GetMax(), roundTo)
-
-			yrange.SetMin(rmin)
-			yrange.SetMax(rmax)
+		// only round if we're showing the axis, and the range doesn't round to nice numbers itself.
+		if !fitRange(yrange) && c.YAxis.Style.Show {
+			roundRange(yrange)
 		}
 	}
 
@@ -283,97 +624,234 @@ func (c Chart) getRanges() (xrange, yrange, yrangeAlt Range) {
 		yrangeAlt.SetMin(tickMin)
 		yrangeAlt.SetMax(tickMax)
 	} else if seriesMappedToSecondaryAxis && yrangeAlt.IsZero() {
-		yrangeAlt.SetMin(minya)
+		yrangeAlt.SetMin(rangeMin(yrangeAlt, minya, minpya))
 		yrangeAlt.SetMax(maxya)
 
-		if c.YAxisSecondary.Style.Show {
-			delta := yrangeAlt.GetDelta()
-			roundTo := util.Math.GetRoundToForDelta(delta)
-			rmin, rmax := util.Math.RoundDown(yrangeAlt.GetMin(), roundTo), util.Math.RoundUp(yrangeAlt.GetMax(), roundTo)
-			yrangeAlt.SetMin(rmin)
-			yrangeAlt.SetMax(rmax)
+		if !fitRange(yrangeAlt) && c.YAxisSecondary.Style.Show {
+			roundRange(yrangeAlt)
 		}
 	}
 
 	return
 }
 
-func (c Chart) checkRanges(xr, yr, yra Range) error {
-	xDelta := xr.GetDelta()
-	if math.IsInf(xDelta, 0) {
-		return errors.New("infinite x-range delta")
+// getSecondaryXRange returns the range of the secondary x-axis; it is set by the axis' range or ticks, or spans
+// the x values of the series on the axis, or the primary x range if there are none.
+func (c Chart) getSecondaryXRange(xr Range) (xrangeAlt Range) {
+	var minx, maxx float64 = math.MaxFloat64, -math.MaxFloat64
+	var minpx float64 = math.MaxFloat64
+
+	seriesMappedToSecondaryAxis, hasTimeSeries := false, false
+	for _, s := range c.Series {
+		if !(s.GetStyle().IsZero() || s.GetStyle().Show) || getSeriesXAxis(s) != XAxisSecondary {
+			continue
+		}
+		seriesMappedToSecondaryAxis = true
+		if _, isTimeSeries := s.(TimeSeries); isTimeSeries {
+			hasTimeSeries = true
+		}
+		if bminx, bmaxx, _, _, hasBounds := getSeriesBounds(s); hasBounds {
+			minx = math.Min(minx, bminx)
+			maxx = math.Max(maxx, bmaxx)
+			minpx = minPositive(minpx, bminx)
+		} else if vp, isValuesProvider := s.(ValuesProvider); isValuesProvider {
+			for index := 0; index < vp.Len(); index++ {
+				vx, vy := vp.GetValues(index)
+				if !isFinitePoint(vx, vy) {
+					continue
+				}
+				minx = math.Min(minx, vx)
+				maxx = math.Max(maxx, vx)
+				minpx = minPositive(minpx, vx)
+			}
+		}
 	}
-	if math.IsNaN(xDelta) {
-		return errors.New("nan x-range delta")
+	if !seriesMappedToSecondaryAxis {
+		minx, maxx, minpx = xr.GetMin(), xr.GetMax(), xr.GetMin()
 	}
-	if xDelta == 0 {
-		return errors.New("zero x-range delta; there needs to be at least (2) values")
+
+	// an axis mirroring the primary axis runs in the same direction.
+	descending := ContinuousRange{Descending: c.XAxisSecondary.Descending || (!seriesMappedToSecondaryAxis && xr.IsDescending())}
+	if c.XAxisSecondary.Range != nil {
+		xrangeAlt = c.XAxisSecondary.Range
+	} else if tr, isTimeRange := xr.(*TimeRange); hasTimeSeries || (!seriesMappedToSecondaryAxis && isTimeRange) {
+		// the secondary axis is in the time zone of the primary axis unless it has its own.
+		xrangeAlt = &TimeRange{ContinuousRange: descending, Location: c.XAxisSecondary.TimeZone}
+		if c.XAxisSecondary.TimeZone == nil && isTimeRange {
+			xrangeAlt = &TimeRange{ContinuousRange: descending, Location: tr.Location}
+		}
+	} else {
+		xrangeAlt = &descending
 	}
 
-	yDelta := yr.GetDelta()
-	if math.IsInf(yDelta, 0) {
-		return errors.New("infinite y-range delta")
+	if len(c.XAxisSecondary.Ticks) > 0 {
+		tickMin, tickMax := math.MaxFloat64, -math.MaxFloat64
+		for _, t := range c.XAxisSecondary.Ticks {
+			tickMin = math.Min(tickMin, t.Value)
+			tickMax = math.Max(tickMax, t.Value)
+		}
+		xrangeAlt.SetMin(tickMin)
+		xrangeAlt.SetMax(tickMax)
+	} else if xrangeAlt.IsZero() {
+		xrangeAlt.SetMin(rangeMin(xrangeAlt, minx, minpx))
+		xrangeAlt.SetMax(maxx)
+		fitRange(xrangeAlt)
 	}
-	if math.IsNaN(yDelta) {
-		return errors.New("nan y-range delta")
+	return
+}
+
+// minPositive returns the smaller of a minimum and a value, if the value is positive.
+func minPositive(min, value float64) float64 {
+	if value > 0 && value < min {
+		return value
+	}
+	return min
+}
+
+// rangeMin returns the minimum to set on a range; logarithmic ranges take the smallest positive value.
+func rangeMin(ra Range, min, minPositive float64) float64 {
+	if _, isLogarithmic := ra.(*LogarithmicRange); isLogarithmic {
+		return minPositive
 	}
-	if yDelta == 0 {
-		return errors.New("zero y-range delta")
+	return min
+}
+
+// roundRange rounds the bounds of a range out to round numbers, or powers of 10 for logarithmic ranges.
+func roundRange(ra Range) {
+	if lr, isLogarithmic := ra.(*LogarithmicRange); isLogarithmic {
+		lr.roundToPowers()
+		return
 	}
+	delta := ra.GetDelta()
+	roundTo := util.Math.GetRoundToForDelta(delta)
+	rmin, rmax := util.Math.RoundDown(ra.GetMin(), roundTo), util.Math.RoundUp(ra.GetMax(), roundTo)
+	ra.SetMin(rmin)
+	ra.SetMax(rmax)
+}
 
-	if c.hasSecondarySeries() {
-		yraDelta := yra.GetDelta()
-		if math.IsInf(yraDelta, 0) {
-			return errors.New("infinite secondary y-range delta")
+func (c Chart) checkRanges(xr, yr, yra Range) error {
+	if err := validateLogarithmicRange("x-range", xr); err != nil {
+		return err
+	}
+	if err := validateLogarithmicRange("y-range", yr); err != nil {
+		return err
+	}
+	if err := validateRangeDelta("x-range", xr.GetDelta()); err != nil {
+		if xr.GetDelta() == 0 {
+			err.Message = err.Message + "; there needs to be at least (2) values"
 		}
-		if math.IsNaN(yraDelta) {
-			return errors.New("nan secondary y-range delta")
+		return err
+	}
+	if err := validateRangeDelta("y-range", yr.GetDelta()); err != nil {
+		return err
+	}
+	if c.hasSecondarySeries() {
+		if err := validateLogarithmicRange("secondary y-range", yra); err != nil {
+			return err
 		}
-		if yraDelta == 0 {
-			return errors.New("zero secondary y-range delta")
+		if err := validateRangeDelta("secondary y-range", yra.GetDelta()); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
+// checkSecondaryXRange returns an error if there are series on the secondary x-axis and its range is not valid;
+// otherwise it spans the primary x range, which is already checked.
+func (c Chart) checkSecondaryXRange(xra Range) error {
+	if !c.hasSecondaryXSeries() {
+		return nil
+	}
+	if err := validateLogarithmicRange("secondary x-range", xra); err != nil {
+		return err
+	}
+	if err := validateRangeDelta("secondary x-range", xra.GetDelta()); err != nil {
+		return err
+	}
 	return nil
 }
 
+// getContentBox returns the box the legend and plot are laid out in, the chart box less the space taken
+// by the title blocks.
+func (c Chart) getContentBox() Box {
+	if c.contentBox.IsZero() {
+		return c.Box()
+	}
+	return c.contentBox
+}
+
+// getPlotBox returns the box the canvas and axes are laid out in, the chart box less the space taken by the legend.
+func (c Chart) getPlotBox() Box {
+	if c.plotBox.IsZero() {
+		return c.getContentBox()
+	}
+	return c.plotBox
+}
+
 func (c Chart) getDefaultCanvasBox() Box {
-	return c.Box()
+	return c.GetLayoutEngine().CanvasBox(c.getPlotBox())
 }
 
 func (c Chart) getValueFormatters() (x, y, ya ValueFormatter) {
 	for _, s := range c.Series {
 		if vfp, isVfp := s.(ValueFormatterProvider); isVfp {
 			sx, sy := vfp.GetValueFormatters()
-			if s.GetYAxis() == YAxisPrimary {
+			if getSeriesXAxis(s) == XAxisPrimary {
 				x = sx
+			}
+			if s.GetYAxis() == YAxisPrimary {
 				y = sy
 			} else if s.GetYAxis() == YAxisSecondary {
-				x = sx
 				ya = sy
 			}
 		}
 	}
 	if c.XAxis.ValueFormatter != nil {
 		x = c.XAxis.GetValueFormatter()
+	} else if c.XAxis.Unit != nil {
+		x = c.XAxis.Unit.Format
 	}
 	if c.YAxis.ValueFormatter != nil {
 		y = c.YAxis.GetValueFormatter()
+	} else if c.YAxis.Unit != nil {
+		y = c.YAxis.Unit.Format
 	}
 	if c.YAxisSecondary.ValueFormatter != nil {
 		ya = c.YAxisSecondary.GetValueFormatter()
+	} else if c.YAxisSecondary.Unit != nil {
+		ya = c.YAxisSecondary.Unit.Format
 	}
 	return
 }
 
+// getSecondaryXValueFormatter returns the value formatter of the secondary x-axis; by default it is the formatter
+// of the series on the axis, or the primary x-axis' formatter if there are none.
+func (c Chart) getSecondaryXValueFormatter(xf ValueFormatter) ValueFormatter {
+	if c.XAxisSecondary.ValueFormatter != nil {
+		return c.XAxisSecondary.GetValueFormatter()
+	}
+	if c.XAxisSecondary.Unit != nil {
+		return c.XAxisSecondary.Unit.Format
+	}
+	for _, s := range c.Series {
+		if vfp, isVfp := s.(ValueFormatterProvider); isVfp && getSeriesXAxis(s) == XAxisSecondary {
+			xf, _ = vfp.GetValueFormatters()
+		}
+	}
+	return xf
+}
+
 func (c Chart) hasAxes() bool {
-	return c.XAxis.Style.Show || c.YAxis.Style.Show || c.YAxisSecondary.Style.Show
+	return c.XAxis.Style.Show || c.XAxisSecondary.Style.Show || c.YAxis.Style.Show || c.YAxisSecondary.Style.Show
 }
 
-func (c Chart) getAxesTicks(r Renderer, xr, yr, yar Range, xf, yf, yfa ValueFormatter) (xticks, yticks, yticksAlt []Tick) {
+func (c Chart) getAxesTicks(r Renderer, xr, xra, yr, yar Range, xf, xfa, yf, yfa ValueFormatter) (xticks, xticksAlt, yticks, yticksAlt []Tick) {
 	if c.XAxis.Style.Show {
 		xticks = c.XAxis.GetTicks(r, xr, c.styleDefaultsAxes(), xf)
 	}
+	if c.XAxisSecondary.Style.Show {
+		xticksAlt = c.XAxisSecondary.GetTicks(r, xra, c.styleDefaultsAxes(), xfa)
+	}
 	if c.YAxis.Style.Show {
 		yticks = c.YAxis.GetTicks(r, yr, c.styleDefaultsAxes(), yf)
 	}
@@ -383,12 +861,16 @@ func (c Chart) getAxesTicks(r Renderer, xr, yr, yar Range, xf, yf, yfa ValueForm
 	return
 }
 
-func (c Chart) getAxesAdjustedCanvasBox(r Renderer, canvasBox Box, xr, yr, yra Range, xticks, yticks, yticksAlt []Tick) Box {
+func (c Chart) getAxesAdjustedCanvasBox(r Renderer, canvasBox Box, xr, xra, yr, yra Range, xticks, xticksAlt, yticks, yticksAlt []Tick) Box {
 	axesOuterBox := canvasBox.Clone()
 	if c.XAxis.Style.Show {
 		axesBounds := c.XAxis.Measure(r, canvasBox, xr, c.styleDefaultsAxes(), xticks)
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
+	if c.XAxisSecondary.Style.Show {
+		axesBounds := c.XAxisSecondary.Measure(r, canvasBox, xra, c.styleDefaultsAxes(), xticksAlt)
+		axesOuterBox = axesOuterBox.Grow(axesBounds)
+	}
 	if c.YAxis.Style.Show {
 		axesBounds := c.YAxis.Measure(r, canvasBox, yr, c.styleDefaultsAxes(), yticks)
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
@@ -397,21 +879,90 @@ func (c Chart) getAxesAdjustedCanvasBox(r Renderer, canvasBox Box, xr, yr, yra R
 		axesBounds := c.YAxisSecondary.Measure(r, canvasBox, yra, c.styleDefaultsAxes(), yticksAlt)
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
+	if c.hasYAxisMirror() {
+		axesBounds := c.YAxis.getMirror().Measure(r, canvasBox, yr, c.styleDefaultsAxes(), yticks)
+		axesOuterBox = axesOuterBox.Grow(axesBounds)
+	}
+
+	return c.GetLayoutEngine().FitCanvasBox(c.getPlotBox(), canvasBox, axesOuterBox)
+}
+
+// lockUnitAspectRatio widens the x or y range around its center so a y unit is `UnitAspectRatio` times
+// as long as an x unit, and returns if it changed a range.
+func (c Chart) lockUnitAspectRatio(xr, yr Range) bool {
+	ratio := c.UnitAspectRatio
+	xd, yd := xr.GetDelta(), yr.GetDelta()
+	if ratio <= 0 || xd <= 0 || yd <= 0 || xr.GetDomain() <= 0 || yr.GetDomain() <= 0 {
+		return false
+	}
+	// the length of a unit along each axis in pixels.
+	xScale, yScale := float64(xr.GetDomain())/xd, float64(yr.GetDomain())/yd
+	if yScale > ratio*xScale {
+		widenRange(yr, float64(yr.GetDomain())/(ratio*xScale))
+		return true
+	} else if yScale < ratio*xScale {
+		widenRange(xr, float64(xr.GetDomain())*ratio/yScale)
+		return true
+	}
+	return false
+}
 
-	return canvasBox.OuterConstrain(c.Box(), axesOuterBox)
+// widenRange sets the delta of a range, keeping its center.
+func widenRange(r Range, delta float64) {
+	center := (r.GetMin() + r.GetMax()) / 2
+	r.SetMin(center - delta/2)
+	r.SetMax(center + delta/2)
 }
 
-func (c Chart) setRangeDomains(canvasBox Box, xr, yr, yra Range) (Range, Range, Range) {
+func (c Chart) setRangeDomains(canvasBox Box, xr, xra, yr, yra Range) (Range, Range, Range, Range) {
 	xr.SetDomain(canvasBox.Width())
+	xra.SetDomain(canvasBox.Width())
 	yr.SetDomain(canvasBox.Height())
 	yra.SetDomain(canvasBox.Height())
-	return xr, yr, yra
+	return xr, xra, yr, yra
+}
+
+func (c Chart) hasTimeSeries() bool {
+	for _, s := range c.Series {
+		if _, isTimeSeries := s.(TimeSeries); isTimeSeries {
+			return true
+		}
+	}
+	return false
+}
+
+// getCategories returns the categories of the first box plot or violin series, if there is one, or else the categories
+// of the category series, in the order they first appear, with a band scale.
+func (c Chart) getCategories() ([]string, CategoryScale, bool) {
+	for _, s := range c.Series {
+		switch typed := s.(type) {
+		case BoxPlotSeries:
+			return typed.GetCategories(), CategoryScaleUnset, true
+		case ViolinSeries:
+			return typed.GetCategories(), CategoryScaleUnset, true
+		}
+	}
+	var categories []string
+	var hasCategorySeries bool
+	seen := map[string]bool{}
+	for _, s := range c.Series {
+		if cs, isCategorySeries := s.(CategorySeries); isCategorySeries {
+			hasCategorySeries = true
+			for _, category := range cs.GetCategories() {
+				if !seen[category] {
+					seen[category] = true
+					categories = append(categories, category)
+				}
+			}
+		}
+	}
+	return categories, CategoryScaleBand, hasCategorySeries
 }
 
-func (c Chart) hasAnnotationSeries() bool {
+func (c Chart) hasMeasurableSeries() bool {
 	for _, s := range c.Series {
-		if as, isAnnotationSeries := s.(AnnotationSeries); isAnnotationSeries {
-			if as.Style.IsZero() || as.Style.Show {
+		if s.GetStyle().IsZero() || s.GetStyle().Show {
+			if isMeasurableSeries(s) {
 				return true
 			}
 		}
@@ -428,17 +979,38 @@ func (c Chart) hasSecondarySeries() bool {
 	return false
 }
 
-func (c Chart) getAnnotationAdjustedCanvasBox(r Renderer, canvasBox Box, xr, yr, yra Range, xf, yf, yfa ValueFormatter) Box {
+func (c Chart) hasSecondaryXSeries() bool {
+	for _, s := range c.Series {
+		if getSeriesXAxis(s) == XAxisSecondary {
+			return true
+		}
+	}
+	return false
+}
+
+// getSeriesRanges returns the x and y ranges of the axes a series draws on.
+func (c Chart) getSeriesRanges(s Series, cl ChartLayout) (xr, yr Range) {
+	xr, yr = cl.XRange, cl.YRange
+	if getSeriesXAxis(s) == XAxisSecondary {
+		xr = cl.XRangeSecondary
+	}
+	if s.GetYAxis() == YAxisSecondary {
+		yr = cl.YRangeSecondary
+	}
+	return
+}
+
+func (c Chart) getAnnotationAdjustedCanvasBox(r Renderer, canvasBox Box, xr, xra, yr, yra Range, xf, yf, yfa ValueFormatter) Box {
+	ranges := ChartLayout{XRange: xr, XRangeSecondary: xra, YRange: yr, YRangeSecondary: yra}
 	annotationSeriesBox := canvasBox.Clone()
 	for seriesIndex, s := range c.Series {
-		if as, isAnnotationSeries := s.(AnnotationSeries); isAnnotationSeries {
-			if as.Style.IsZero() || as.Style.Show {
+		if s.GetStyle().IsZero() || s.GetStyle().Show {
+			if isMeasurableSeries(s) {
 				style := c.styleDefaultsSeries(seriesIndex)
 				var annotationBounds Box
-				if as.YAxis == YAxisPrimary {
-					annotationBounds = as.Measure(r, canvasBox, xr, yr, style)
-				} else if as.YAxis == YAxisSecondary {
-					annotationBounds = as.Measure(r, canvasBox, xr, yra, style)
+				if s.GetYAxis() == YAxisPrimary || s.GetYAxis() == YAxisSecondary {
+					sxr, syr := c.getSeriesRanges(s, ranges)
+					annotationBounds = measureSeries(s, r, canvasBox, sxr, syr, style)
 				}
 
 				annotationSeriesBox = annotationSeriesBox.Grow(annotationBounds)
@@ -446,7 +1018,7 @@ func (c Chart) getAnnotationAdjustedCanvasBox(r Renderer, canvasBox Box, xr, yr,
 		}
 	}
 
-	return canvasBox.OuterConstrain(c.Box(), annotationSeriesBox)
+	return c.GetLayoutEngine().FitCanvasBox(c.getPlotBox(), canvasBox, annotationSeriesBox)
 }
 
 func (c Chart) getBackgroundStyle() Style {
@@ -468,29 +1040,143 @@ func (c Chart) drawCanvas(r Renderer, canvasBox Box) {
 	Draw.Box(r, canvasBox, c.getCanvasStyle())
 }
 
-func (c Chart) drawAxes(r Renderer, canvasBox Box, xrange, yrange, yrangeAlt Range, xticks, yticks, yticksAlt []Tick) {
+func (c Chart) drawAxes(r Renderer, cl ChartLayout) {
+	xa, xas, ya, yas := c.getGridStyledAxes()
+	if xa.GridBandStyle.Show || xas.GridBandStyle.Show || ya.GridBandStyle.Show || yas.GridBandStyle.Show {
+		// the bands of every axis are drawn first, so none of them covers the grid lines of another.
+		startGroup(r, "grid-bands", "grid-bands")
+		if c.XAxis.Style.Show {
+			xa.renderGridBands(r, cl.Canvas, cl.XRange, cl.XTicks)
+		}
+		if c.XAxisSecondary.Style.Show {
+			xas.renderGridBands(r, cl.Canvas, cl.XRangeSecondary, cl.XTicksSecondary)
+		}
+		if c.YAxis.Style.Show {
+			ya.renderGridBands(r, cl.Canvas, cl.YRange, cl.YTicks)
+		}
+		if c.YAxisSecondary.Style.Show {
+			yas.renderGridBands(r, cl.Canvas, cl.YRangeSecondary, cl.YTicksSecondary)
+		}
+		endGroup(r)
+		xa.GridBandStyle.Show, xas.GridBandStyle.Show, ya.GridBandStyle.Show, yas.GridBandStyle.Show = false, false, false, false
+	}
+	if c.GridPlacement == GridPlacementAboveSeries {
+		xa.GridMajorStyle.Show, xa.GridMinorStyle.Show = false, false
+		xas.GridMajorStyle.Show, xas.GridMinorStyle.Show = false, false
+		ya.GridMajorStyle.Show, ya.GridMinorStyle.Show, ya.Zero.Style.Show = false, false, false
+		yas.GridMajorStyle.Show, yas.GridMinorStyle.Show, yas.Zero.Style.Show = false, false, false
+	}
+
+	if c.XAxis.Style.Show {
+		startGroup(r, "x-axis", "axis", "x-axis")
+		xa.Render(r, cl.Canvas, cl.XRange, c.styleDefaultsAxes(), cl.XTicks)
+		endGroup(r)
+	}
+	if c.XAxisSecondary.Style.Show {
+		startGroup(r, "x-axis-secondary", "axis", "x-axis", "secondary")
+		xas.Render(r, cl.Canvas, cl.XRangeSecondary, c.styleDefaultsAxes(), cl.XTicksSecondary)
+		endGroup(r)
+	}
+	if c.YAxis.Style.Show {
+		startGroup(r, "y-axis", "axis", "y-axis")
+		ya.Render(r, cl.Canvas, cl.YRange, c.styleDefaultsAxes(), cl.YTicks)
+		endGroup(r)
+	}
+	if c.YAxisSecondary.Style.Show {
+		startGroup(r, "y-axis-secondary", "axis", "y-axis", "secondary")
+		yas.Render(r, cl.Canvas, cl.YRangeSecondary, c.styleDefaultsAxes(), cl.YTicksSecondary)
+		endGroup(r)
+	}
+	if c.hasYAxisMirror() {
+		startGroup(r, "y-axis-mirror", "axis", "y-axis", "mirror")
+		c.YAxis.getMirror().Render(r, cl.Canvas, cl.YRange, c.styleDefaultsAxes(), cl.YTicks)
+		endGroup(r)
+	}
+}
+
+// drawGridAboveSeries draws the grid lines and the zero line over the series, if the grid is placed above them.
+func (c Chart) drawGridAboveSeries(r Renderer, cl ChartLayout) {
+	if c.GridPlacement != GridPlacementAboveSeries {
+		return
+	}
+	xa, xas, ya, yas := c.getGridStyledAxes()
 	if c.XAxis.Style.Show {
-		c.XAxis.Render(r, canvasBox, xrange, c.styleDefaultsAxes(), xticks)
+		xa.renderGridLines(r, cl.Canvas, cl.XRange, cl.XTicks)
+	}
+	if c.XAxisSecondary.Style.Show {
+		xas.renderGridLines(r, cl.Canvas, cl.XRangeSecondary, cl.XTicksSecondary)
 	}
 	if c.YAxis.Style.Show {
-		c.YAxis.Render(r, canvasBox, yrange, c.styleDefaultsAxes(), yticks)
+		ya.renderGridLines(r, cl.Canvas, cl.YRange, cl.YTicks)
 	}
 	if c.YAxisSecondary.Style.Show {
-		c.YAxisSecondary.Render(r, canvasBox, yrangeAlt, c.styleDefaultsAxes(), yticksAlt)
+		yas.renderGridLines(r, cl.Canvas, cl.YRangeSecondary, cl.YTicksSecondary)
+	}
+}
+
+// getGridStyledAxes returns the axes with the grid, band and zero line styles defaulted from the color palette.
+func (c Chart) getGridStyledAxes() (xa, xas XAxis, ya, yas YAxis) {
+	gridDefaults := c.getGridStyleDefaults()
+	bandDefaults := Style{}
+	if !gridDefaults.StrokeColor.IsZero() {
+		bandDefaults.FillColor = gridDefaults.StrokeColor.WithAlpha(96)
+	}
+	zeroDefaults := Style{StrokeColor: c.GetColorPalette().AxisStrokeColor()}
+	withXDefaults := func(xa XAxis) XAxis {
+		xa.GridMajorStyle, xa.GridMinorStyle = withGridDefaults(xa.GridMajorStyle, gridDefaults), withGridDefaults(xa.GridMinorStyle, minorGridDefaults(gridDefaults))
+		xa.GridBandStyle = withGridDefaults(xa.GridBandStyle, bandDefaults)
+		return xa
 	}
+	withYDefaults := func(ya YAxis) YAxis {
+		ya.GridMajorStyle, ya.GridMinorStyle = withGridDefaults(ya.GridMajorStyle, gridDefaults), withGridDefaults(ya.GridMinorStyle, minorGridDefaults(gridDefaults))
+		ya.GridBandStyle = withGridDefaults(ya.GridBandStyle, bandDefaults)
+		ya.Zero.Style = withGridDefaults(ya.Zero.Style, zeroDefaults)
+		return ya
+	}
+	return withXDefaults(c.XAxis), withXDefaults(c.XAxisSecondary), withYDefaults(c.YAxis), withYDefaults(c.YAxisSecondary)
+}
+
+// hasYAxisMirror returns if the primary y axis is mirrored on the other side of the canvas.
+func (c Chart) hasYAxisMirror() bool {
+	return c.YAxis.Mirror && c.YAxis.Style.Show && !c.YAxisSecondary.Style.Show
+}
+
+// withGridDefaults returns a grid line style with the defaults for what it does not set; it is shown if it was.
+func withGridDefaults(gridStyle, defaults Style) Style {
+	final := gridStyle.InheritFrom(defaults)
+	final.Show = gridStyle.Show
+	return final
 }
 
-func (c Chart) drawSeries(r Renderer, canvasBox Box, xrange, yrange, yrangeAlt Range, s Series, seriesIndex int) {
+func (c Chart) drawSeries(r Renderer, cl ChartLayout, s Series, seriesIndex int) {
 	if s.GetStyle().IsZero() || s.GetStyle().Show {
-		if s.GetYAxis() == YAxisPrimary {
-			s.Render(r, canvasBox, xrange, yrange, c.styleDefaultsSeries(seriesIndex))
-		} else if s.GetYAxis() == YAxisSecondary {
-			s.Render(r, canvasBox, xrange, yrangeAlt, c.styleDefaultsSeries(seriesIndex))
+		if s.GetYAxis() == YAxisPrimary || s.GetYAxis() == YAxisSecondary {
+			xr, yr := c.getSeriesRanges(s, cl)
+			startGroup(r, seriesGroupID(s, seriesIndex), "series", fmt.Sprintf("series-%d", seriesIndex))
+			clipped := isSeriesClipped(s, c.ClipSeries)
+			if clipped {
+				setClip(r, cl.Canvas)
+			}
+			if showsTooltips(r) {
+				setTooltipSeries(r, getTooltipSeries(s))
+			}
+			s.Render(r, cl.Canvas, xr, yr, c.styleDefaultsSeries(seriesIndex))
+			setTooltipSeries(r, tooltipSeries{})
+			if clipped {
+				clearClip(r)
+			}
+			endGroup(r)
 		}
 	}
 }
 
 func (c Chart) drawTitle(r Renderer) {
+	if blocks := c.getTitleBlocks(); blocks.hasHeader() || len(blocks.footer()) > 0 {
+		blocks.draw(r, c.Box())
+		if blocks.hasHeader() {
+			return
+		}
+	}
 	if len(c.Title) > 0 && c.TitleStyle.Show {
 		r.SetFont(c.TitleStyle.GetFont(c.GetFont()))
 		r.SetFontColor(c.TitleStyle.GetFontColor(c.GetColorPalette().TextColor()))
@@ -499,13 +1185,36 @@ func (c Chart) drawTitle(r Renderer) {
 
 		textBox := r.MeasureText(c.Title)
 
-		textWidth := textBox.Width()
-		textHeight := textBox.Height()
+		titleX := c.GetWidth() >> 1
+		titleY := c.TitleStyle.Padding.GetTop(DefaultTitleTop) + textBox.Height()
 
-		titleX := (c.GetWidth() >> 1) - (textWidth >> 1)
-		titleY := c.TitleStyle.Padding.GetTop(DefaultTitleTop) + textHeight
+		Draw.TextAnchored(r, c.Title, titleX, titleY, Style{
+			Font:                c.TitleStyle.GetFont(c.GetFont()),
+			FontColor:           c.TitleStyle.GetFontColor(c.GetColorPalette().TextColor()),
+			FontSize:            titleFontSize,
+			TextHorizontalAlign: TextHorizontalAlignCenter,
+		})
+	}
+}
 
-		r.Text(c.Title, titleX, titleY)
+// getTitleBlocks returns the title, subtitle, footer and source of the chart with their styles.
+func (c Chart) getTitleBlocks() titleBlocks {
+	text := Style{Font: c.GetFont(), FontColor: c.GetColorPalette().TextColor()}
+	subtitle, footer, source := styleDefaultsTitleBlocks(text)
+	title := c.TitleStyle.InheritFrom(Style{
+		Font:                text.Font,
+		FontColor:           text.FontColor,
+		FontSize:            DefaultTitleFontSize,
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+	title.Show = c.TitleStyle.Show
+	return titleBlocks{
+		Title:    titleBlock{Text: c.Title, Style: title},
+		Subtitle: titleBlock{Text: c.Subtitle, Style: c.SubtitleStyle.InheritFrom(subtitle)},
+		Footer:   titleBlock{Text: c.Footer, Style: c.FooterStyle.InheritFrom(footer)},
+		Source:   titleBlock{Text: c.Source, Style: c.SourceStyle.InheritFrom(source)},
 	}
 }
 
@@ -527,8 +1236,8 @@ func (c Chart) styleDefaultsCanvas() Style {
 
 func (c Chart) styleDefaultsSeries(seriesIndex int) Style {
 	return Style{
-		DotColor:    c.GetColorPalette().GetSeriesColor(seriesIndex),
-		StrokeColor: c.GetColorPalette().GetSeriesColor(seriesIndex),
+		DotColor:    getSeriesColor(c.GetColorPalette(), seriesIndex, len(c.Series)),
+		StrokeColor: getSeriesColor(c.GetColorPalette(), seriesIndex, len(c.Series)),
 		StrokeWidth: DefaultSeriesLineWidth,
 		Font:        c.GetFont(),
 		FontSize:    DefaultFontSize,
@@ -556,9 +1265,20 @@ func (c Chart) GetColorPalette() ColorPalette {
 	if c.ColorPalette != nil {
 		return c.ColorPalette
 	}
+	if !c.Theme.IsZero() {
+		return c.Theme
+	}
 	return DefaultColorPalette
 }
 
+// getGridStyleDefaults returns the default style of grid lines; it has the grid color of the palette, if it has one.
+func (c Chart) getGridStyleDefaults() Style {
+	if gcp, isGridColorProvider := c.GetColorPalette().(gridColorProvider); isGridColorProvider {
+		return Style{StrokeColor: gcp.GridColor()}
+	}
+	return Style{}
+}
+
 // Box returns the chart bounds as a box.
 func (c Chart) Box() Box {
 	dpr := c.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
@@ -0,0 +1,109 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color/palette"
+	imagedraw "image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// ChartAnimator renders a sequence of charts as the frames of an animated gif, i.e. to replay how a metric evolved.
+//
+// The frames are either the `Frames`, or, if there are none, `FrameCount` charts returned by `Frame`, which
+// lets long animations build each chart as it is rendered rather than holding all of them. Every frame must
+// be the same size. Gif frames have at most 256 colors; the frames are mapped to the Plan 9 palette.
+type ChartAnimator struct {
+	Frames []Chart
+
+	FrameCount int
+	Frame      func(index int) Chart
+
+	// Delay is how long each frame is shown for, rounded down to hundredths of a second; it defaults to
+	// `DefaultAnimationFrameDelay`.
+	Delay time.Duration
+	// Delays are the delays of individual frames; frames past the end use `Delay`.
+	Delays []time.Duration
+
+	// LoopCount is how many times the animation repeats; 0 loops forever and -1 shows it once.
+	LoopCount int
+
+	// Dither diffuses the error of mapping the frames to the palette, which smooths gradients at the cost of noise.
+	Dither bool
+}
+
+// GetFrameCount returns the number of frames.
+func (ca ChartAnimator) GetFrameCount() int {
+	if len(ca.Frames) > 0 {
+		return len(ca.Frames)
+	}
+	if ca.Frame == nil {
+		return 0
+	}
+	return ca.FrameCount
+}
+
+// GetFrame returns the chart of a frame.
+func (ca ChartAnimator) GetFrame(index int) Chart {
+	if len(ca.Frames) > 0 {
+		return ca.Frames[index]
+	}
+	return ca.Frame(index)
+}
+
+// GetDelay returns how long a frame is shown for.
+func (ca ChartAnimator) GetDelay(index int) time.Duration {
+	if index < len(ca.Delays) && ca.Delays[index] > 0 {
+		return ca.Delays[index]
+	}
+	if ca.Delay > 0 {
+		return ca.Delay
+	}
+	return DefaultAnimationFrameDelay
+}
+
+// Render renders the frames and writes them to the writer as an animated gif.
+// Frames that fail to render stop the animation with an error naming the frame.
+func (ca ChartAnimator) Render(w io.Writer) error {
+	frameCount := ca.GetFrameCount()
+	if frameCount == 0 {
+		return errors.New("please provide at least one frame")
+	}
+
+	drawer := imagedraw.Drawer(imagedraw.Src)
+	if ca.Dither {
+		drawer = imagedraw.FloydSteinberg
+	}
+
+	animation := &gif.GIF{LoopCount: ca.LoopCount}
+	var bounds image.Rectangle
+	for index := 0; index < frameCount; index++ {
+		frame, err := ca.renderFrame(ca.GetFrame(index))
+		if err != nil {
+			return fmt.Errorf("frame %d: %w", index, err)
+		}
+		if index == 0 {
+			bounds = frame.Bounds()
+		} else if frame.Bounds() != bounds {
+			return fmt.Errorf("frame %d: size %v does not match the first frame's %v", index, frame.Bounds().Size(), bounds.Size())
+		}
+
+		paletted := image.NewPaletted(bounds, palette.Plan9)
+		drawer.Draw(paletted, bounds, frame, bounds.Min)
+		animation.Image = append(animation.Image, paletted)
+		animation.Delay = append(animation.Delay, int(ca.GetDelay(index)/(10*time.Millisecond)))
+	}
+	return gif.EncodeAll(w, animation)
+}
+
+// renderFrame renders a chart to a raster.
+func (ca ChartAnimator) renderFrame(c Chart) (image.Image, error) {
+	iw := &ImageWriter{}
+	if err := c.Render(PNG, iw); err != nil {
+		return nil, err
+	}
+	return iw.Image()
+}
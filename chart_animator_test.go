@@ -0,0 +1,88 @@
+package chart
+
+import (
+	"bytes"
+	"image/gif"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func animatorTestChart(values ...float64) Chart {
+	return Chart{
+		Width:  200,
+		Height: 100,
+		Series: []Series{
+			ContinuousSeries{
+				XValues: []float64{1, 2, 3},
+				YValues: values,
+			},
+		},
+	}
+}
+
+func TestChartAnimatorRender(t *testing.T) {
+	assert := assert.New(t)
+
+	ca := ChartAnimator{
+		Frames: []Chart{
+			animatorTestChart(1, 2, 3),
+			animatorTestChart(2, 3, 1),
+			animatorTestChart(3, 1, 2),
+		},
+		Delays:    []time.Duration{time.Second},
+		LoopCount: 2,
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(ca.Render(buffer))
+
+	animation, err := gif.DecodeAll(buffer)
+	assert.Nil(err)
+	assert.Len(animation.Image, 3)
+	assert.Equal([]int{100, 10, 10}, animation.Delay)
+	assert.Equal(2, animation.LoopCount)
+	assert.Equal(200, animation.Config.Width)
+	assert.Equal(100, animation.Config.Height)
+}
+
+func TestChartAnimatorRenderFrameFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	ca := ChartAnimator{
+		FrameCount: 4,
+		Frame: func(index int) Chart {
+			return animatorTestChart(0, float64(index), 1)
+		},
+		Delay:  50 * time.Millisecond,
+		Dither: true,
+	}
+	assert.Equal(4, ca.GetFrameCount())
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(ca.Render(buffer))
+
+	animation, err := gif.DecodeAll(buffer)
+	assert.Nil(err)
+	assert.Len(animation.Image, 4)
+	assert.Equal(5, animation.Delay[3])
+}
+
+func TestChartAnimatorRenderErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(ChartAnimator{}.Render(bytes.NewBuffer(nil)))
+	assert.NotNil(ChartAnimator{FrameCount: 2}.Render(bytes.NewBuffer(nil)))
+
+	resized := animatorTestChart(1, 2, 3)
+	resized.Width = 300
+	err := ChartAnimator{Frames: []Chart{animatorTestChart(1, 2, 3), resized}}.Render(bytes.NewBuffer(nil))
+	assert.NotNil(err)
+	assert.True(strings.HasPrefix(err.Error(), "frame 1:"), err.Error())
+
+	err = ChartAnimator{Frames: []Chart{animatorTestChart(1, 2, 3), {}}}.Render(bytes.NewBuffer(nil))
+	assert.NotNil(err)
+	assert.True(strings.HasPrefix(err.Error(), "frame 1:"), err.Error())
+}
@@ -2,6 +2,7 @@ package chart
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"image/png"
 	"math"
@@ -189,6 +190,109 @@ func TestChartGetRangesUseUserRanges(t *testing.T) {
 	assert.True(yar.IsZero(), yar.String())
 }
 
+func TestChartGetSecondaryXRange(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{
+				XValues: []float64{1.0, 2.0, 3.0},
+				YValues: []float64{1.0, 2.0, 3.0},
+			},
+		},
+	}
+	xr, _, _ := c.getRanges()
+	xra := c.getSecondaryXRange(xr)
+	assert.Equal(1.0, xra.GetMin())
+	assert.Equal(3.0, xra.GetMax())
+
+	c.Series = append(c.Series, ContinuousSeries{
+		XAxis:   XAxisSecondary,
+		XValues: []float64{10.0, 20.0, 30.0},
+		YValues: []float64{1.0, 2.0, 3.0},
+	})
+	xr, _, _ = c.getRanges()
+	assert.Equal(1.0, xr.GetMin())
+	assert.Equal(3.0, xr.GetMax())
+	xra = c.getSecondaryXRange(xr)
+	assert.Equal(10.0, xra.GetMin())
+	assert.Equal(30.0, xra.GetMax())
+
+	c.XAxisSecondary.Ticks = []Tick{{Value: 0, Label: "0"}, {Value: 50, Label: "50"}}
+	xra = c.getSecondaryXRange(xr)
+	assert.Equal(0.0, xra.GetMin())
+	assert.Equal(50.0, xra.GetMax())
+}
+
+func TestChartRenderSecondaryXAxis(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		XAxisSecondary: XAxis{
+			Style:          StyleShow(),
+			ValueFormatter: func(v interface{}) string { return fmt.Sprintf("top %.0f", v) },
+		},
+		YAxis: YAxis{Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{
+				XValues: []float64{1.0, 2.0, 3.0},
+				YValues: []float64{1.0, 2.0, 3.0},
+			},
+			ContinuousSeries{
+				XAxis:   XAxisSecondary,
+				XValues: []float64{100.0, 200.0},
+				YValues: []float64{1.0, 3.0},
+			},
+		},
+	}
+
+	r, err := PNG(c.GetWidth(), c.GetHeight())
+	assert.Nil(err)
+	cl, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+	assert.NotEmpty(cl.XTicksSecondary)
+	assert.Equal("top 100", cl.XTicksSecondary[0].Label)
+	assert.Equal(cl.Canvas.Width(), cl.XRangeSecondary.GetDomain())
+	// the canvas is moved down to make room for the axis along the top.
+	assert.True(cl.Canvas.Top > c.getDefaultCanvasBox().Top)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+}
+
+func TestChartSecondaryXAxisDerivedSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	// last week's hours overlaid on this week's; the moving average of last week is on its axis too.
+	lastWeek := ContinuousSeries{XAxis: XAxisSecondary, XValues: []float64{100, 101, 102, 103}, YValues: []float64{4, 5, 6, 7}}
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{200, 201, 202, 203}, YValues: []float64{1, 2, 3, 4}},
+			lastWeek,
+			&SMASeries{XAxis: XAxisSecondary, Period: 2, InnerSeries: lastWeek},
+			AnnotationSeries{XAxis: XAxisSecondary, Annotations: []Value2{{XValue: 103, YValue: 7, Label: "peak"}}},
+		},
+	}
+	xr, _, _ := c.getRanges()
+	assert.Equal(200.0, xr.GetMin())
+	assert.Equal(203.0, xr.GetMax())
+	xra := c.getSecondaryXRange(xr)
+	assert.Equal(100.0, xra.GetMin())
+	assert.Equal(103.0, xra.GetMax())
+
+	sxr, _ := c.getSeriesRanges(c.Series[2], ChartLayout{XRange: xr, XRangeSecondary: xra})
+	assert.Equal(xra, sxr)
+
+	snapshot, err := c.Snapshot()
+	assert.Nil(err)
+	restored, err := RestoreSnapshot(snapshot)
+	assert.Nil(err)
+	for index := range c.Series {
+		assert.Equal(XAxisSecondary == getSeriesXAxis(c.Series[index]), XAxisSecondary == getSeriesXAxis(restored.Series[index]), index)
+	}
+}
+
 func TestChartGetBackgroundStyle(t *testing.T) {
 	assert := assert.New(t)
 
@@ -326,7 +430,7 @@ func TestChartGetAxesTicks(t *testing.T) {
 	}
 	xr, yr, yar := c.getRanges()
 
-	xt, yt, yat := c.getAxesTicks(r, xr, yr, yar, FloatValueFormatter, FloatValueFormatter, FloatValueFormatter)
+	xt, _, yt, yat := c.getAxesTicks(r, xr, xr, yr, yar, FloatValueFormatter, FloatValueFormatter, FloatValueFormatter, FloatValueFormatter)
 	assert.NotEmpty(xt)
 	assert.NotEmpty(yt)
 	assert.NotEmpty(yat)
@@ -574,3 +678,106 @@ func TestChartE2ELineWithFill(t *testing.T) {
 	assert.Equal(defaultSeriesColor, at(i, 0, 49))
 	assert.Equal(defaultSeriesColor, at(i, 49, 0))
 }
+
+func TestChartRenderPhases(t *testing.T) {
+	assert := assert.New(t)
+
+	makeChart := func() Chart {
+		return Chart{
+			Width:  300,
+			Height: 200,
+			XAxis:  XAxis{Style: StyleShow()},
+			YAxis:  YAxis{Style: StyleShow()},
+			Series: []Series{
+				ContinuousSeries{XValues: []float64{1, 2, 3, 4}, YValues: []float64{1, 3, 2, 4}},
+			},
+		}
+	}
+
+	rendered := bytes.NewBuffer(nil)
+	assert.Nil(makeChart().Render(DeterministicSVG, rendered))
+
+	c := makeChart()
+	r, err := c.NewRenderer(DeterministicSVG)
+	assert.Nil(err)
+	measured := c.Measure()
+	assert.Equal(1.0, measured.XRange.GetMin())
+	assert.Equal(4.0, measured.XRange.GetMax())
+	cl, err := c.Layout(r, measured)
+	assert.Nil(err)
+	assert.NotEmpty(cl.XTicks)
+	assert.NotEmpty(cl.YTicks)
+	painted := bytes.NewBuffer(nil)
+	assert.Nil(c.Paint(r, cl, painted))
+	assert.Equal(rendered.String(), painted.String())
+
+	// ranges can be changed between measuring and the layout, and the canvas between the layout and painting.
+	c = makeChart()
+	r, err = c.NewRenderer(DeterministicSVG)
+	assert.Nil(err)
+	measured = c.Measure()
+	measured.YRange.SetMax(10)
+	cl, err = c.Layout(r, measured)
+	assert.Nil(err)
+	assert.Equal(10.0, cl.YTicks[len(cl.YTicks)-1].Value)
+	cl.Canvas.Top += 20
+	adjusted := bytes.NewBuffer(nil)
+	assert.Nil(c.Paint(r, cl, adjusted))
+	assert.Equal(cl.Canvas.Height(), cl.YRange.GetDomain())
+	assert.NotEqual(rendered.String(), adjusted.String())
+}
+
+func TestChartAxisDescending(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Descending: true},
+		YAxis: YAxis{Descending: true},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	xr, yr, yra := c.getRanges()
+	assert.True(xr.IsDescending())
+	assert.True(yr.IsDescending())
+	assert.False(yra.IsDescending())
+	// the secondary x axis mirrors the primary one.
+	assert.True(c.getSecondaryXRange(xr).IsDescending())
+
+	// ranges set on the axes keep their own direction.
+	c.YAxis.Range = &ContinuousRange{}
+	_, yr, _ = c.getRanges()
+	assert.False(yr.IsDescending())
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestChartUnitAspectRatio(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:           600,
+		Height:          300,
+		UnitAspectRatio: 1,
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{0, 10}},
+		},
+	}
+	r, err := PNG(c.GetWidth(), c.GetHeight())
+	assert.Nil(err)
+	cl, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+
+	xScale := float64(cl.XRange.GetDomain()) / cl.XRange.GetDelta()
+	yScale := float64(cl.YRange.GetDomain()) / cl.YRange.GetDelta()
+	assert.InDelta(xScale, yScale, 0.001)
+	// the wider x range is centered on the data.
+	assert.InDelta(5, (cl.XRange.GetMin()+cl.XRange.GetMax())/2, 0.001)
+	assert.Equal(0.0, cl.YRange.GetMin())
+
+	c.UnitAspectRatio = 0.5
+	cl, err = c.Layout(r, c.Measure())
+	assert.Nil(err)
+	yScale = float64(cl.YRange.GetDomain()) / cl.YRange.GetDelta()
+	xScale = float64(cl.XRange.GetDomain()) / cl.XRange.GetDelta()
+	assert.InDelta(0.5*xScale, yScale, 0.001)
+}
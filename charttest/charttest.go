@@ -0,0 +1,370 @@
+// Package charttest provides helpers for golden file testing of charts.
+//
+// Charts are rendered in memory with the normalized renderers, which draw all text in the embedded font and write
+// no metadata, and compared against golden files; png output is compared per pixel with a per channel or a perceptual
+// tolerance, svg output byte for byte. When a comparison fails the actual output (and for png a visual diff) is written
+// next to the golden file. Test data generated with `RandomValues` is the same on every run. The normalized renderers
+// measure text themselves rather than through `chart.DefaultTextMeasureCache`, so the output of a chart doesn't
+// depend on what the tests before it rendered.
+//
+// The raster output of the same chart can differ by a few antialiased pixels between platforms, i.e. where floating
+// point operations are fused; a perceptual `Threshold` and a few `MaxDiffPixels` allow for that:
+//
+//	charttest.AssertPNG(t, "testdata/latency.png", graph, charttest.Options{Threshold: 0.1, MaxDiffPixels: 20})
+//
+// Set `CHARTTEST_UPDATE=1` in the environment to (re)write golden files instead of comparing against them.
+package charttest
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	chart "github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/seq"
+)
+
+// UpdateEnvVar is the environment variable that, when set, makes the assertions write golden files.
+const UpdateEnvVar = "CHARTTEST_UPDATE"
+
+// Seed is the seed of the values generated by `RandomValues`.
+const Seed int64 = 1
+
+// maxYIQDelta is the largest weighted squared YIQ difference of two colors.
+const maxYIQDelta = 35215.0
+
+// Renderable is a chart that can be rendered, i.e. `chart.Chart`, `chart.BarChart`, `chart.PieChart` etc.
+type Renderable interface {
+	Render(rp chart.RendererProvider, w io.Writer) error
+}
+
+// Options are the options for golden file comparisons.
+type Options struct {
+	// Tolerance is the largest per channel difference for two pixels to be considered equal.
+	Tolerance uint8
+	// Threshold is the largest perceptual difference for two pixels to be considered equal, from 0 to 1
+	// (see `ComparePerceptual`); if set, it is used instead of the tolerance.
+	Threshold float64
+	// MaxDiffPixels is the number of differing pixels tolerated before a comparison fails.
+	MaxDiffPixels int
+	// ArtifactDir is where failure artifacts are written; it defaults to the golden file's directory.
+	ArtifactDir string
+	// Update writes the golden file instead of comparing against it.
+	Update bool
+}
+
+// ShouldUpdate returns if golden files should be written instead of compared.
+func (o Options) ShouldUpdate() bool {
+	return o.Update || len(os.Getenv(UpdateEnvVar)) > 0
+}
+
+// GetArtifactDir returns the artifact directory for a golden file.
+func (o Options) GetArtifactDir(golden string) string {
+	if len(o.ArtifactDir) > 0 {
+		return o.ArtifactDir
+	}
+	return filepath.Dir(golden)
+}
+
+// RandomValues returns random values from 0 to max, the same on every run.
+func RandomValues(count int, max float64) []float64 {
+	return seq.New(seq.NewRandomWithSeed(Seed).WithLen(count).WithMax(max)).Array()
+}
+
+// RenderPNG renders a chart to an in memory image with the normalized png renderer.
+func RenderPNG(c Renderable) (*image.RGBA, error) {
+	collector := &chart.ImageWriter{}
+	if err := c.Render(chart.NormalizedPNG, collector); err != nil {
+		return nil, err
+	}
+	img, err := collector.Image()
+	if err != nil {
+		return nil, err
+	}
+	return toRGBA(img), nil
+}
+
+// RenderSVG renders a chart to svg bytes with the normalized svg renderer.
+func RenderSVG(c Renderable) ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	if err := c.Render(chart.NormalizedSVG, buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Diff is the result of comparing two images.
+type Diff struct {
+	// Pixels is the number of pixels that differ by more than the tolerance.
+	Pixels int
+	// MaxDelta is the largest per channel difference found.
+	MaxDelta uint8
+	// MaxDistance is the largest perceptual difference found, from 0 to 1.
+	MaxDistance float64
+	// SizeMismatch is set if the images have different bounds; nothing else is compared.
+	SizeMismatch bool
+	// Image highlights the differing pixels in red over a faded copy of the expected image.
+	Image *image.RGBA
+}
+
+// CompareImages compares two images pixel by pixel with a per channel tolerance.
+func CompareImages(expected, actual image.Image, tolerance uint8) Diff {
+	return compareImages(expected, actual, func(ec, ac color.RGBA) bool {
+		return maxDelta(ec, ac) > tolerance
+	})
+}
+
+// ComparePerceptual compares two images pixel by pixel with a perceptual threshold from 0 to 1: the difference
+// of two pixels is their distance in the YIQ color space, which weighs brightness over hue the way the eye does,
+// as a share of the largest distance of two colors. Pixels are blended over white first, so differences in
+// transparent pixels don't count. A threshold of 0.1 tolerates antialiasing differences but not changed colors.
+func ComparePerceptual(expected, actual image.Image, threshold float64) Diff {
+	return compareImages(expected, actual, func(ec, ac color.RGBA) bool {
+		return perceptualDistance(ec, ac) > threshold
+	})
+}
+
+// compareImages compares two images pixel by pixel, counting the pixels that differ.
+func compareImages(expected, actual image.Image, differ func(ec, ac color.RGBA) bool) Diff {
+	if expected.Bounds().Size() != actual.Bounds().Size() {
+		return Diff{SizeMismatch: true}
+	}
+
+	e, a := toRGBA(expected), toRGBA(actual)
+	bounds := e.Bounds()
+	diff := Diff{Image: image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))}
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			ec := e.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			ac := a.RGBAAt(a.Bounds().Min.X+x, a.Bounds().Min.Y+y)
+			if delta := maxDelta(ec, ac); delta > diff.MaxDelta {
+				diff.MaxDelta = delta
+			}
+			if distance := perceptualDistance(ec, ac); distance > diff.MaxDistance {
+				diff.MaxDistance = distance
+			}
+			if differ(ec, ac) {
+				diff.Pixels++
+				diff.Image.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+			gray := uint8((uint16(ec.R) + uint16(ec.G) + uint16(ec.B)) / 3)
+			diff.Image.SetRGBA(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 64})
+		}
+	}
+	return diff
+}
+
+// AssertPNG renders the chart as png and compares it against the golden png file at `golden`.
+func AssertPNG(t testing.TB, golden string, c Renderable, opts ...Options) {
+	t.Helper()
+	o := getOptions(opts)
+
+	actual, err := RenderPNG(c)
+	if err != nil {
+		t.Fatalf("charttest: render failed: %v", err)
+		return
+	}
+
+	if o.ShouldUpdate() {
+		if err := writePNG(golden, actual); err != nil {
+			t.Fatalf("charttest: cannot update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := readPNG(golden)
+	if err != nil {
+		t.Fatalf("charttest: cannot read golden file (set %s=1 to create it): %v", UpdateEnvVar, err)
+		return
+	}
+
+	diff := o.compare(expected, actual)
+	if !diff.SizeMismatch && diff.Pixels <= o.MaxDiffPixels {
+		return
+	}
+
+	artifacts := writePNGArtifacts(o.GetArtifactDir(golden), golden, actual, diff)
+	if diff.SizeMismatch {
+		t.Errorf("charttest: %s: size mismatch, expected %v actual %v; artifacts: %s", golden, expected.Bounds().Size(), actual.Bounds().Size(), artifacts)
+		return
+	}
+	if o.Threshold > 0 {
+		t.Errorf("charttest: %s: %d pixels differ (max distance %.3f, threshold %.3f); artifacts: %s", golden, diff.Pixels, diff.MaxDistance, o.Threshold, artifacts)
+		return
+	}
+	t.Errorf("charttest: %s: %d pixels differ (max channel delta %d, tolerance %d); artifacts: %s", golden, diff.Pixels, diff.MaxDelta, o.Tolerance, artifacts)
+}
+
+// AssertSVG renders the chart as svg and compares it byte for byte against the golden svg file at `golden`.
+func AssertSVG(t testing.TB, golden string, c Renderable, opts ...Options) {
+	t.Helper()
+	o := getOptions(opts)
+
+	actual, err := RenderSVG(c)
+	if err != nil {
+		t.Fatalf("charttest: render failed: %v", err)
+		return
+	}
+
+	if o.ShouldUpdate() {
+		if err := writeFile(golden, actual); err != nil {
+			t.Fatalf("charttest: cannot update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("charttest: cannot read golden file (set %s=1 to create it): %v", UpdateEnvVar, err)
+		return
+	}
+	if bytes.Equal(expected, actual) {
+		return
+	}
+
+	artifact := artifactPath(o.GetArtifactDir(golden), golden, "actual")
+	if err := writeFile(artifact, actual); err != nil {
+		artifact = err.Error()
+	}
+	t.Errorf("charttest: %s: svg differs at byte %d; actual: %s", golden, firstDifference(expected, actual), artifact)
+}
+
+// compare compares two images with the threshold if set, or the tolerance.
+func (o Options) compare(expected, actual image.Image) Diff {
+	if o.Threshold > 0 {
+		return ComparePerceptual(expected, actual, o.Threshold)
+	}
+	return CompareImages(expected, actual, o.Tolerance)
+}
+
+func getOptions(opts []Options) Options {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return Options{}
+}
+
+func writePNGArtifacts(dir, golden string, actual *image.RGBA, diff Diff) string {
+	var written []string
+	actualPath := artifactPath(dir, golden, "actual")
+	if err := writePNG(actualPath, actual); err != nil {
+		return err.Error()
+	}
+	written = append(written, actualPath)
+	if diff.Image != nil {
+		diffPath := artifactPath(dir, golden, "diff")
+		if err := writePNG(diffPath, diff.Image); err != nil {
+			return err.Error()
+		}
+		written = append(written, diffPath)
+	}
+	return strings.Join(written, ", ")
+}
+
+// artifactPath returns i.e. `dir/name.actual.png` for a golden file `name.png`.
+func artifactPath(dir, golden, kind string) string {
+	base := filepath.Base(golden)
+	ext := filepath.Ext(base)
+	return filepath.Join(dir, fmt.Sprintf("%s.%s%s", strings.TrimSuffix(base, ext), kind, ext))
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	buffer := bytes.NewBuffer(nil)
+	encoder := png.Encoder{CompressionLevel: chart.DeterministicPNGCompression}
+	if err := encoder.Encode(buffer, img); err != nil {
+		return err
+	}
+	return writeFile(path, buffer.Bytes())
+}
+
+func writeFile(path string, contents []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, contents, 0644)
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if typed, isTyped := img.(*image.RGBA); isTyped {
+		return typed
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			rgba.Set(x, y, img.At(x, y))
+		}
+	}
+	return rgba
+}
+
+func maxDelta(a, b color.RGBA) uint8 {
+	delta := absDelta(a.R, b.R)
+	if d := absDelta(a.G, b.G); d > delta {
+		delta = d
+	}
+	if d := absDelta(a.B, b.B); d > delta {
+		delta = d
+	}
+	if d := absDelta(a.A, b.A); d > delta {
+		delta = d
+	}
+	return delta
+}
+
+// perceptualDistance returns the distance of two colors blended over white in the YIQ color space,
+// as a share of the largest distance of two colors.
+func perceptualDistance(a, b color.RGBA) float64 {
+	ay, ai, aq := toYIQ(a)
+	by, bi, bq := toYIQ(b)
+	dy, di, dq := ay-by, ai-bi, aq-bq
+	delta := 0.5053*dy*dy + 0.299*di*di + 0.1957*dq*dq
+	return math.Min(math.Sqrt(delta/maxYIQDelta), 1)
+}
+
+// toYIQ returns the YIQ components of a premultiplied color blended over white.
+func toYIQ(c color.RGBA) (y, i, q float64) {
+	white := 255 - float64(c.A)
+	r, g, b := float64(c.R)+white, float64(c.G)+white, float64(c.B)+white
+	y = 0.29889531*r + 0.58662247*g + 0.11448223*b
+	i = 0.59597799*r - 0.27417610*g - 0.32180189*b
+	q = 0.21147017*r - 0.52261711*g + 0.31114694*b
+	return
+}
+
+func absDelta(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func firstDifference(a, b []byte) int {
+	for index := 0; index < len(a) && index < len(b); index++ {
+		if a[index] != b[index] {
+			return index
+		}
+	}
+	if len(a) < len(b) {
+		return len(a)
+	}
+	return len(b)
+}
@@ -0,0 +1,187 @@
+package charttest
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	chart "github.com/wcharczuk/go-chart"
+)
+
+// recordingT records failures instead of failing the enclosing test.
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (rt *recordingT) Helper() {}
+
+func (rt *recordingT) Errorf(format string, args ...interface{}) {
+	rt.failures = append(rt.failures, fmt.Sprintf(format, args...))
+}
+
+func (rt *recordingT) Fatalf(format string, args ...interface{}) {
+	rt.failures = append(rt.failures, fmt.Sprintf(format, args...))
+}
+
+func testChart(last float64) chart.Chart {
+	return chart.Chart{
+		Width:  200,
+		Height: 100,
+		Series: []chart.Series{
+			chart.ContinuousSeries{
+				XValues: []float64{1, 2, 3, 4},
+				YValues: []float64{1, 3, 2, last},
+			},
+		},
+	}
+}
+
+func TestCompareImages(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	actual := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	actual.SetRGBA(1, 1, color.RGBA{R: 3, A: 0})
+	actual.SetRGBA(0, 1, color.RGBA{G: 200, A: 255})
+
+	diff := CompareImages(expected, actual, 4)
+	assert.False(diff.SizeMismatch)
+	assert.Equal(1, diff.Pixels)
+	assert.Equal(255, diff.MaxDelta)
+	assert.Equal(color.RGBA{R: 255, A: 255}, diff.Image.RGBAAt(0, 1))
+
+	assert.True(CompareImages(expected, image.NewRGBA(image.Rect(0, 0, 3, 2)), 0).SizeMismatch)
+}
+
+func TestAssertPNG(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "charttest")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "line.png")
+
+	rt := &recordingT{TB: t}
+	AssertPNG(rt, golden, testChart(4), Options{Update: true})
+	assert.Empty(rt.failures)
+
+	AssertPNG(rt, golden, testChart(4))
+	assert.Empty(rt.failures)
+
+	AssertPNG(rt, golden, testChart(1))
+	assert.Len(rt.failures, 1)
+	_, err = os.Stat(filepath.Join(dir, "line.actual.png"))
+	assert.Nil(err)
+	_, err = os.Stat(filepath.Join(dir, "line.diff.png"))
+	assert.Nil(err)
+
+	rt = &recordingT{TB: t}
+	AssertPNG(rt, golden, testChart(1), Options{MaxDiffPixels: 200 * 100})
+	assert.Empty(rt.failures)
+}
+
+func TestAssertSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "charttest")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "line.svg")
+
+	rt := &recordingT{TB: t}
+	AssertSVG(rt, golden, testChart(4))
+	assert.Len(rt.failures, 1)
+
+	rt = &recordingT{TB: t}
+	AssertSVG(rt, golden, testChart(4), Options{Update: true})
+	AssertSVG(rt, golden, testChart(4))
+	assert.Empty(rt.failures)
+
+	artifacts := filepath.Join(dir, "artifacts")
+	AssertSVG(rt, golden, testChart(2), Options{ArtifactDir: artifacts})
+	assert.Len(rt.failures, 1)
+	_, err = os.Stat(filepath.Join(artifacts, "line.actual.svg"))
+	assert.Nil(err)
+}
+
+func TestRenderSVGAfterPNG(t *testing.T) {
+	assert := assert.New(t)
+
+	font, err := chart.GetEmbeddedFont()
+	assert.Nil(err)
+	c := testChart(4)
+	c.Font = font
+	c.XAxis = chart.XAxis{Style: chart.StyleShow()}
+	c.YAxis = chart.YAxis{Style: chart.StyleShow()}
+
+	// png renders measure the same text in the same font, and leave their measurements in the shared cache.
+	chart.DefaultTextMeasureCache.Reset()
+	_, err = RenderPNG(c)
+	assert.Nil(err)
+	assert.Nil(c.Render(chart.PNG, io.Discard))
+	actual, err := RenderSVG(c)
+	assert.Nil(err)
+
+	chart.DefaultTextMeasureCache.Reset()
+	expected, err := RenderSVG(c)
+	assert.Nil(err)
+	assert.Equal(string(expected), string(actual))
+}
+
+func TestComparePerceptual(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	actual := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	expected.SetRGBA(0, 0, color.RGBA{R: 100, G: 100, B: 100, A: 255})
+	actual.SetRGBA(0, 0, color.RGBA{R: 110, G: 100, B: 100, A: 255})
+	expected.SetRGBA(1, 0, color.RGBA{R: 255, A: 255})
+	actual.SetRGBA(1, 0, color.RGBA{B: 255, A: 255})
+	// fully transparent pixels are white whatever their color.
+	actual.SetRGBA(2, 0, color.RGBA{})
+
+	diff := ComparePerceptual(expected, actual, 0.1)
+	assert.Equal(1, diff.Pixels)
+	assert.Equal(color.RGBA{R: 255, A: 255}, diff.Image.RGBAAt(1, 0))
+	assert.True(diff.MaxDistance > 0.1 && diff.MaxDistance <= 1)
+	assert.Equal(2, CompareImages(expected, actual, 4).Pixels)
+
+	assert.True(perceptualDistance(color.RGBA{A: 255}, color.RGBA{R: 255, G: 255, B: 255, A: 255}) > 0.9)
+	assert.Zero(perceptualDistance(color.RGBA{}, color.RGBA{R: 255, G: 255, B: 255, A: 255}))
+}
+
+func TestAssertPNGThreshold(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "charttest")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "line.png")
+
+	rt := &recordingT{TB: t}
+	AssertPNG(rt, golden, testChart(4), Options{Update: true})
+	AssertPNG(rt, golden, testChart(4), Options{Threshold: 0.1})
+	assert.Empty(rt.failures)
+
+	AssertPNG(rt, golden, testChart(1), Options{Threshold: 0.1})
+	assert.Len(rt.failures, 1)
+	assert.True(strings.Contains(rt.failures[0], "threshold 0.100"), rt.failures[0])
+}
+
+func TestRandomValues(t *testing.T) {
+	assert := assert.New(t)
+
+	values := RandomValues(32, 100)
+	assert.Len(values, 32)
+	assert.Equal(values, RandomValues(32, 100))
+	for _, value := range values {
+		assert.True(value >= 0 && value <= 100)
+	}
+}
@@ -0,0 +1,48 @@
+package chart
+
+// ClipProvider is a series that decides itself if it is clipped to the canvas, rather than following `Chart.ClipSeries`.
+type ClipProvider interface {
+	IsClipped() bool
+}
+
+// isSeriesClipped returns if a series is clipped to the canvas.
+func isSeriesClipped(s Series, clipSeries bool) bool {
+	if cp, isClipProvider := s.(ClipProvider); isClipProvider {
+		return cp.IsClipped()
+	}
+	return clipSeries
+}
+
+// clipRenderer is a renderer that can clip what it draws to a box.
+type clipRenderer interface {
+	// SetClip clips what is drawn afterwards to a box, until the clip is cleared.
+	SetClip(box Box)
+	// ClearClip clears the clip.
+	ClearClip()
+}
+
+// setClip clips what is drawn afterwards to a box, if the renderer supports it.
+func setClip(r Renderer, box Box) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if or, isOffset := r.(offsetRenderer); isOffset {
+		r, box = or.Renderer, box.Shift(or.dx, or.dy)
+	}
+	if cr, isClipRenderer := r.(clipRenderer); isClipRenderer {
+		cr.SetClip(box)
+	}
+}
+
+// clearClip clears the clip, if the renderer supports it.
+func clearClip(r Renderer) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if or, isOffset := r.(offsetRenderer); isOffset {
+		r = or.Renderer
+	}
+	if cr, isClipRenderer := r.(clipRenderer); isClipRenderer {
+		cr.ClearClip()
+	}
+}
@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func clipTestChart() Chart {
+	return Chart{
+		Width:  200,
+		Height: 200,
+		YAxis:  YAxis{Range: &ContinuousRange{Min: 1, Max: 2}},
+		Series: []Series{
+			ContinuousSeries{Style: Style{Show: true, StrokeWidth: 4, StrokeColor: ColorRed}, XValues: []float64{0, 1}, YValues: []float64{0, 3}},
+			ContinuousSeries{XValues: []float64{0, 1}, YValues: []float64{1, 2}},
+			AnnotationSeries{Annotations: []Value2{{XValue: 1, YValue: 2, Label: "end"}}},
+		},
+	}
+}
+
+func TestChartClipSeriesRaster(t *testing.T) {
+	assert := assert.New(t)
+
+	c := clipTestChart()
+	c.Series = c.Series[:2]
+	unclipped, err := RenderImage(c)
+	assert.Nil(err)
+
+	c.ClipSeries = true
+	clipped, err := RenderImage(c)
+	assert.Nil(err)
+
+	r, err := c.NewRenderer(PNG)
+	assert.Nil(err)
+	cl, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+
+	// the line runs off the canvas, unless it is clipped.
+	countRed := func(img image.Image) (count int) {
+		bounds := img.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				if x >= cl.Canvas.Left && x < cl.Canvas.Right && y >= cl.Canvas.Top && y < cl.Canvas.Bottom {
+					continue
+				}
+				if r, g, _, _ := img.At(x, y).RGBA(); r > 0xc000 && g < 0x4000 {
+					count++
+				}
+			}
+		}
+		return
+	}
+	assert.NotZero(countRed(unclipped))
+	assert.Zero(countRed(clipped))
+
+	c.SeriesParallelism = 2
+	parallel, err := RenderImage(c)
+	assert.Nil(err)
+	assert.Equal(clipped, parallel)
+}
+
+func TestChartClipSeriesSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	c := clipTestChart()
+	c.ClipSeries = true
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buf))
+	svg := buf.String()
+	assert.Equal(1, strings.Count(svg, "<clipPath"))
+	assert.Equal(2, strings.Count(svg, `<g clip-path="url(#clip-1)">`))
+	assert.Equal(strings.Count(svg, "<g"), strings.Count(svg, "</g>"))
+
+	// annotations overhang the canvas unless they ask to be clipped.
+	c.Series[2] = AnnotationSeries{Clip: true, Annotations: []Value2{{XValue: 1, YValue: 2, Label: "end"}}}
+	buf.Reset()
+	assert.Nil(c.Render(SVG, buf))
+	assert.Equal(3, strings.Count(buf.String(), `<g clip-path="url(#clip-1)">`))
+
+	buf.Reset()
+	assert.Nil(clipTestChart().Render(SVG, buf))
+	assert.False(strings.Contains(buf.String(), "clip-path"))
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	chart "github.com/wcharczuk/go-chart"
+)
+
+// renderer is a chart that can be rendered.
+type renderer interface {
+	Render(rp chart.RendererProvider, w io.Writer) error
+}
+
+// timeLayouts are the formats accepted for time x values.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func buildChart(o options, tables []*table) (renderer, error) {
+	palette, err := getPalette(o.theme)
+	if err != nil {
+		return nil, err
+	}
+	var titleStyle chart.Style
+	if len(o.title) > 0 {
+		titleStyle = chart.StyleShow()
+	}
+
+	chartType := strings.ToLower(o.chartType)
+	if chartType == "auto" {
+		chartType = detectChartType(tables)
+	}
+
+	switch chartType {
+	case "line", "time":
+		c := &chart.Chart{
+			Title:        o.title,
+			TitleStyle:   titleStyle,
+			ColorPalette: palette,
+			Width:        o.width,
+			Height:       o.height,
+			XAxis:        chart.XAxis{Style: chart.StyleShow()},
+			YAxis:        chart.YAxis{Style: chart.StyleShow()},
+		}
+		if chartType == "time" {
+			c.XAxis.Range = &chart.TimeRange{}
+		}
+		for _, t := range tables {
+			series, err := lineSeries(chartType, t)
+			if err != nil {
+				return nil, err
+			}
+			c.Series = append(c.Series, series...)
+		}
+		if len(c.Series) > 1 {
+			c.Elements = []chart.Renderable{chart.Legend(c)}
+		}
+		return c, nil
+	case "bar":
+		values, err := barValues(tables)
+		if err != nil {
+			return nil, err
+		}
+		return chart.BarChart{
+			Title:        o.title,
+			TitleStyle:   titleStyle,
+			ColorPalette: palette,
+			Width:        o.width,
+			Height:       o.height,
+			XAxis:        chart.StyleShow(),
+			YAxis:        chart.YAxis{Style: chart.StyleShow()},
+			Bars:         values,
+		}, nil
+	case "pie":
+		values, err := barValues(tables)
+		if err != nil {
+			return nil, err
+		}
+		return chart.PieChart{
+			Title:        o.title,
+			TitleStyle:   titleStyle,
+			ColorPalette: palette,
+			Width:        o.width,
+			Height:       o.height,
+			Values:       values,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown chart type %q", o.chartType)
+	}
+}
+
+func getPalette(theme string) (chart.ColorPalette, error) {
+	switch strings.ToLower(theme) {
+	case "", "default":
+		return chart.DefaultColorPalette, nil
+	case "alternate":
+		return chart.AlternateColorPalette, nil
+	case "dark":
+		return chart.DarkColorPalette, nil
+	default:
+		return nil, fmt.Errorf("unknown theme %q", theme)
+	}
+}
+
+// detectChartType returns line for numeric x values, time for time x values and bar for anything else.
+func detectChartType(tables []*table) string {
+	numbers, times := true, true
+	for _, t := range tables {
+		for _, x := range t.xs {
+			if _, err := parseNumber(x); err != nil {
+				numbers = false
+			}
+			if _, err := parseTime(x); err != nil {
+				times = false
+			}
+		}
+	}
+	if numbers {
+		return "line"
+	}
+	if times {
+		return "time"
+	}
+	return "bar"
+}
+
+func lineSeries(chartType string, t *table) ([]chart.Series, error) {
+	var series []chart.Series
+	if chartType == "time" {
+		xvalues := make([]time.Time, len(t.xs))
+		for index, x := range t.xs {
+			parsed, err := parseTime(x)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %v", index+1, err)
+			}
+			xvalues[index] = parsed
+		}
+		for column, name := range t.names {
+			series = append(series, chart.TimeSeries{Name: name, XValues: xvalues, YValues: t.values[column]})
+		}
+		return series, nil
+	}
+
+	xvalues := make([]float64, len(t.xs))
+	for index, x := range t.xs {
+		parsed, err := parseNumber(x)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", index+1, err)
+		}
+		xvalues[index] = parsed
+	}
+	for column, name := range t.names {
+		series = append(series, chart.ContinuousSeries{Name: name, XValues: xvalues, YValues: t.values[column]})
+	}
+	return series, nil
+}
+
+// barValues returns the labeled values of bar and pie charts, which take a single column of values.
+func barValues(tables []*table) ([]chart.Value, error) {
+	var values []chart.Value
+	for _, t := range tables {
+		if len(t.values) != 1 {
+			return nil, fmt.Errorf("bar and pie charts take one column of values; got %d", len(t.values))
+		}
+		for index, x := range t.xs {
+			values = append(values, chart.Value{Label: x, Value: t.values[0][index]})
+		}
+	}
+	return values, nil
+}
+
+func parseNumber(value string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(value), 64)
+}
+
+func parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a time", value)
+}
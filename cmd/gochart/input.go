@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// table is the data read from an input: a column of x values (numbers, times or labels)
+// and one or more named columns of y values.
+type table struct {
+	xs     []string
+	names  []string
+	values [][]float64
+}
+
+// readCSV reads a table from csv; the first column is x, every other column is a series.
+// The first row is a header of series names if any of its y cells isn't a number.
+func readCSV(r io.Reader) (*table, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("csv is empty")
+	}
+	columns := len(records[0])
+	if columns < 2 {
+		return nil, fmt.Errorf("csv must have at least two columns; got %d", columns)
+	}
+
+	t := &table{names: make([]string, columns-1), values: make([][]float64, columns-1)}
+	if isHeader(records[0]) {
+		copy(t.names, records[0][1:])
+		records = records[1:]
+	}
+	for index, record := range records {
+		t.xs = append(t.xs, record[0])
+		for column, cell := range record[1:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+			if err != nil {
+				return nil, fmt.Errorf("csv row %d column %d: %v", index+1, column+2, err)
+			}
+			t.values[column] = append(t.values[column], value)
+		}
+	}
+	return t, nil
+}
+
+func isHeader(record []string) bool {
+	for _, cell := range record[1:] {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonInput is the json input format, i.e.
+//
+//	{"x": ["2017-03-01", "2017-03-02"], "series": [{"name": "cpu", "values": [0.5, 0.75]}]}
+type jsonInput struct {
+	X      []interface{} `json:"x"`
+	Series []struct {
+		Name   string    `json:"name"`
+		Values []float64 `json:"values"`
+	} `json:"series"`
+}
+
+// readJSON reads a table from json.
+func readJSON(r io.Reader) (*table, error) {
+	var input jsonInput
+	if err := json.NewDecoder(r).Decode(&input); err != nil {
+		return nil, err
+	}
+	if len(input.Series) == 0 {
+		return nil, fmt.Errorf("json must have at least one series")
+	}
+
+	t := &table{}
+	for _, x := range input.X {
+		switch typed := x.(type) {
+		case string:
+			t.xs = append(t.xs, typed)
+		case float64:
+			t.xs = append(t.xs, strconv.FormatFloat(typed, 'g', -1, 64))
+		default:
+			return nil, fmt.Errorf("json x values must be numbers or strings; got %v", x)
+		}
+	}
+	for index, s := range input.Series {
+		if len(s.Values) != len(t.xs) {
+			return nil, fmt.Errorf("json series %d has %d values for %d x values", index, len(s.Values), len(t.xs))
+		}
+		t.names = append(t.names, s.Name)
+		t.values = append(t.values, s.Values)
+	}
+	return t, nil
+}
@@ -0,0 +1,148 @@
+// Command gochart renders charts from csv or json data, i.e.
+//
+//	gochart -title "load" -o load.png < load.csv
+//	curl -s $URL | gochart -input json -type bar -format svg > report.svg
+//
+// Csv input has x values in the first column and a series per other column, with an optional header row of
+// series names. Json input is `{"x": [...], "series": [{"name": "...", "values": [...]}]}`.
+// X values can be numbers, times (i.e. RFC3339 or 2006-01-02) or labels; see `-type`.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	chart "github.com/wcharczuk/go-chart"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "gochart: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// options are the command line options.
+type options struct {
+	chartType string
+	width     int
+	height    int
+	title     string
+	theme     string
+	format    string
+	input     string
+	output    string
+	files     []string
+}
+
+func parseOptions(args []string) (options, error) {
+	var o options
+	flags := flag.NewFlagSet("gochart", flag.ContinueOnError)
+	flags.StringVar(&o.chartType, "type", "auto", "chart type: auto, line, time, bar or pie; auto picks time, line or bar from the x values")
+	flags.IntVar(&o.width, "width", chart.DefaultChartWidth, "chart width in pixels")
+	flags.IntVar(&o.height, "height", chart.DefaultChartHeight, "chart height in pixels")
+	flags.StringVar(&o.title, "title", "", "chart title")
+	flags.StringVar(&o.theme, "theme", "default", "color theme: default, alternate or dark")
+	flags.StringVar(&o.format, "format", "", "output format: png or svg; defaults to the output file extension, or png")
+	flags.StringVar(&o.input, "input", "", "input format: csv or json; defaults to the input file extension, or csv")
+	flags.StringVar(&o.output, "o", "", "output file; defaults to stdout")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: gochart [flags] [file ...]\n\nreads stdin if no files are given.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return o, err
+	}
+	o.files = flags.Args()
+
+	if len(o.format) == 0 {
+		o.format = "png"
+		if strings.EqualFold(filepath.Ext(o.output), ".svg") {
+			o.format = "svg"
+		}
+	}
+	o.format = strings.ToLower(o.format)
+	if o.format != "png" && o.format != "svg" {
+		return o, fmt.Errorf("unknown format %q", o.format)
+	}
+	return o, nil
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	o, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
+
+	tables, err := readTables(o, stdin)
+	if err != nil {
+		return err
+	}
+
+	c, err := buildChart(o, tables)
+	if err != nil {
+		return err
+	}
+
+	rp := chart.PNG
+	if o.format == "svg" {
+		rp = chart.SVG
+	}
+
+	if len(o.output) == 0 {
+		return c.Render(rp, stdout)
+	}
+	f, err := os.Create(o.output)
+	if err != nil {
+		return err
+	}
+	if err := c.Render(rp, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readTables(o options, stdin io.Reader) ([]*table, error) {
+	if len(o.files) == 0 {
+		t, err := readTable(o.input, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("stdin: %v", err)
+		}
+		return []*table{t}, nil
+	}
+
+	var tables []*table
+	for _, path := range o.files {
+		input := o.input
+		if len(input) == 0 {
+			input = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		t, err := readTable(input, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+func readTable(input string, r io.Reader) (*table, error) {
+	switch strings.ToLower(input) {
+	case "json":
+		return readJSON(r)
+	case "", "csv", "txt":
+		return readCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown input format %q", input)
+	}
+}
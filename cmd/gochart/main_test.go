@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestReadCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	tb, err := readCSV(strings.NewReader("x,cpu,mem\n1,0.5,10\n2, 0.75,20\n"))
+	assert.Nil(err)
+	assert.Equal([]string{"1", "2"}, tb.xs)
+	assert.Equal([]string{"cpu", "mem"}, tb.names)
+	assert.Equal([][]float64{{0.5, 0.75}, {10, 20}}, tb.values)
+
+	tb, err = readCSV(strings.NewReader("a,1\nb,2\n"))
+	assert.Nil(err)
+	assert.Equal([]string{""}, tb.names)
+	assert.Equal([]string{"a", "b"}, tb.xs)
+
+	_, err = readCSV(strings.NewReader("1\n2\n"))
+	assert.NotNil(err)
+	_, err = readCSV(strings.NewReader("x,y\n1,nope\n"))
+	assert.NotNil(err)
+}
+
+func TestReadJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tb, err := readJSON(strings.NewReader(`{"x": ["2017-03-01", 2], "series": [{"name": "cpu", "values": [1, 2]}]}`))
+	assert.Nil(err)
+	assert.Equal([]string{"2017-03-01", "2"}, tb.xs)
+	assert.Equal([]string{"cpu"}, tb.names)
+
+	_, err = readJSON(strings.NewReader(`{"x": [1, 2], "series": [{"values": [1]}]}`))
+	assert.NotNil(err)
+	_, err = readJSON(strings.NewReader(`{"x": [1]}`))
+	assert.NotNil(err)
+}
+
+func TestDetectChartType(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("line", detectChartType([]*table{{xs: []string{"1", "2.5"}}}))
+	assert.Equal("time", detectChartType([]*table{{xs: []string{"2017-03-01", "2017-03-02T10:00:00Z"}}}))
+	assert.Equal("bar", detectChartType([]*table{{xs: []string{"a", "2"}}}))
+}
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+
+	stdout := bytes.NewBuffer(nil)
+	assert.Nil(run([]string{"-title", "load"}, strings.NewReader("x,a,b\n1,1,2\n2,3,1\n3,2,2\n"), stdout))
+	assert.True(bytes.HasPrefix(stdout.Bytes(), []byte("\x89PNG")))
+
+	stdout.Reset()
+	assert.Nil(run([]string{"-format", "svg", "-theme", "dark"}, strings.NewReader("2017-03-01,1\n2017-03-02,3\n2017-03-03,2\n"), stdout))
+	assert.True(strings.Contains(stdout.String(), "<svg"))
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.json")
+	assert.Nil(os.WriteFile(input, []byte(`{"x": ["a", "b"], "series": [{"values": [1, 2]}]}`), 0644))
+	output := filepath.Join(dir, "output.svg")
+	for _, chartType := range []string{"bar", "pie"} {
+		assert.Nil(run([]string{"-type", chartType, "-o", output, input}, nil, nil))
+		contents, err := os.ReadFile(output)
+		assert.Nil(err)
+		assert.True(strings.Contains(string(contents), "<svg"))
+	}
+
+	assert.NotNil(run([]string{"-type", "line", input}, nil, stdout))
+	assert.NotNil(run([]string{"-theme", "neon"}, strings.NewReader("1,1\n2,2\n"), stdout))
+	assert.NotNil(run([]string{"-format", "gif"}, strings.NewReader("1,1\n2,2\n"), stdout))
+	assert.NotNil(run([]string{"-type", "bar"}, strings.NewReader("a,1,2\nb,2,3\n"), stdout))
+}
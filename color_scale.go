@@ -0,0 +1,44 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// ColorScale maps a value within a range to a color; `Viridis` and `Jet` are color scales.
+type ColorScale func(v, vmin, vmax float64) drawing.Color
+
+// GradientColorScale returns a color scale that blends linearly between colors spread evenly over the range,
+// i.e. `GradientColorScale(ColorWhite, ColorRed)` for a single hue.
+func GradientColorScale(colors ...drawing.Color) ColorScale {
+	return func(v, vmin, vmax float64) drawing.Color {
+		if len(colors) == 0 {
+			return drawing.ColorTransparent
+		}
+		if len(colors) == 1 || vmax == vmin {
+			return colors[0]
+		}
+		normalized := math.Min(math.Max((v-vmin)/(vmax-vmin), 0), 1)
+		position := normalized * float64(len(colors)-1)
+		index := int(math.Min(position, float64(len(colors)-2)))
+		return blendColors(colors[index], colors[index+1], position-float64(index))
+	}
+}
+
+// blendColors returns the color a share `t` of the way from `a` to `b`.
+func blendColors(a, b drawing.Color, t float64) drawing.Color {
+	blend := func(from, to uint8) uint8 {
+		return uint8(math.Round(float64(from) + (float64(to)-float64(from))*t))
+	}
+	return drawing.Color{R: blend(a.R, b.R), G: blend(a.G, b.G), B: blend(a.B, b.B), A: blend(a.A, b.A)}
+}
+
+// contrastingTextColor returns a text color that reads on a background color; dark text on light colors
+// and white text on dark colors.
+func contrastingTextColor(background drawing.Color) drawing.Color {
+	if lightness, _, _ := colorToLab(background); lightness > 60 {
+		return DefaultTextColor
+	}
+	return ColorWhite
+}
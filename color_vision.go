@@ -0,0 +1,188 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+var (
+	// OkabeItoColors are the Okabe-Ito categorical colors, which stay distinguishable with
+	// deuteranopia, protanopia and tritanopia.
+	OkabeItoColors = []drawing.Color{
+		drawing.ColorFromHex("0072B2"),
+		drawing.ColorFromHex("E69F00"),
+		drawing.ColorFromHex("009E73"),
+		drawing.ColorFromHex("CC79A7"),
+		drawing.ColorFromHex("56B4E9"),
+		drawing.ColorFromHex("D55E00"),
+		drawing.ColorFromHex("F0E442"),
+		drawing.ColorFromHex("000000"),
+	}
+
+	// TolBrightColors are Paul Tol's bright categorical colors, which stay distinguishable with
+	// deuteranopia and protanopia.
+	TolBrightColors = []drawing.Color{
+		drawing.ColorFromHex("4477AA"),
+		drawing.ColorFromHex("EE6677"),
+		drawing.ColorFromHex("228833"),
+		drawing.ColorFromHex("CCBB44"),
+		drawing.ColorFromHex("66CCEE"),
+		drawing.ColorFromHex("AA3377"),
+		drawing.ColorFromHex("BBBBBB"),
+	}
+)
+
+// OkabeItoColorPalette is the default palette with `OkabeItoColors` series colors.
+var OkabeItoColorPalette ColorPalette = seriesColorPalette{colors: OkabeItoColors}
+
+// TolBrightColorPalette is the default palette with `TolBrightColors` series colors.
+var TolBrightColorPalette ColorPalette = seriesColorPalette{colors: TolBrightColors}
+
+// seriesColorPalette is the default palette with other series colors.
+type seriesColorPalette struct {
+	defaultColorPalette
+	colors []drawing.Color
+}
+
+func (sp seriesColorPalette) GetSeriesColor(index int) drawing.Color {
+	return sp.colors[index%len(sp.colors)]
+}
+
+// ColorVisionDeficiency is a kind of color blindness.
+type ColorVisionDeficiency int
+
+const (
+	// Protanopia is red blindness.
+	Protanopia ColorVisionDeficiency = iota
+	// Deuteranopia is green blindness.
+	Deuteranopia
+	// Tritanopia is blue blindness.
+	Tritanopia
+)
+
+// String returns the name of the deficiency.
+func (cvd ColorVisionDeficiency) String() string {
+	switch cvd {
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	}
+	return fmt.Sprintf("ColorVisionDeficiency(%d)", int(cvd))
+}
+
+// cvdMatrices are the full severity simulation matrices of Machado, Oliveira and Fernandes (2009), in linear rgb.
+var cvdMatrices = map[ColorVisionDeficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// SimulateColorVision returns a color as it appears with a color vision deficiency.
+func SimulateColorVision(c drawing.Color, cvd ColorVisionDeficiency) drawing.Color {
+	matrix, hasMatrix := cvdMatrices[cvd]
+	if !hasMatrix {
+		return c
+	}
+	linear := [3]float64{srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)}
+	var simulated [3]uint8
+	for row := range matrix {
+		simulated[row] = linearToSRGB(matrix[row][0]*linear[0] + matrix[row][1]*linear[1] + matrix[row][2]*linear[2])
+	}
+	return drawing.Color{R: simulated[0], G: simulated[1], B: simulated[2], A: c.A}
+}
+
+// DefaultColorVisionMinimumDistance is the CIE76 color difference below which `CheckColorVision` reports
+// two colors as indistinguishable.
+const DefaultColorVisionMinimumDistance = 12.0
+
+// ColorVisionWarning is a pair of adjacent series colors that are hard to tell apart with a color vision deficiency.
+type ColorVisionWarning struct {
+	Deficiency ColorVisionDeficiency
+	// Index is the series index of the first color; the second is the next series.
+	Index    int
+	Colors   [2]drawing.Color
+	Distance float64
+}
+
+// String returns a description of the warning.
+func (cvw ColorVisionWarning) String() string {
+	return fmt.Sprintf("series %d and %d colors (%v, %v) are hard to tell apart with %v; difference %.1f", cvw.Index, cvw.Index+1, cvw.Colors[0], cvw.Colors[1], cvw.Deficiency, cvw.Distance)
+}
+
+// CheckColorVision simulates protanopia, deuteranopia and tritanopia on the first `count` series colors of a palette,
+// and returns a warning for each pair of adjacent colors closer than `minimumDistance` (CIE76) in a simulation.
+// A `minimumDistance` of zero uses `DefaultColorVisionMinimumDistance`.
+func CheckColorVision(palette ColorPalette, count int, minimumDistance float64) []ColorVisionWarning {
+	if minimumDistance == 0 {
+		minimumDistance = DefaultColorVisionMinimumDistance
+	}
+	var warnings []ColorVisionWarning
+	for _, cvd := range []ColorVisionDeficiency{Protanopia, Deuteranopia, Tritanopia} {
+		for index := 0; index < count-1; index++ {
+			a, b := palette.GetSeriesColor(index), palette.GetSeriesColor(index+1)
+			distance := colorDistance(SimulateColorVision(a, cvd), SimulateColorVision(b, cvd))
+			if distance < minimumDistance {
+				warnings = append(warnings, ColorVisionWarning{Deficiency: cvd, Index: index, Colors: [2]drawing.Color{a, b}, Distance: distance})
+			}
+		}
+	}
+	return warnings
+}
+
+// colorDistance returns the CIE76 difference of two colors.
+func colorDistance(a, b drawing.Color) float64 {
+	al, aa, ab := colorToLab(a)
+	bl, ba, bb := colorToLab(b)
+	return math.Sqrt((al-bl)*(al-bl) + (aa-ba)*(aa-ba) + (ab-bb)*(ab-bb))
+}
+
+// colorToLab converts a color to CIE L*a*b* with a D65 white point.
+func colorToLab(c drawing.Color) (l, a, b float64) {
+	r, g, bl := srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)
+	x := (0.4124*r + 0.3576*g + 0.1805*bl) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*bl
+	z := (0.0193*r + 0.1192*g + 0.9505*bl) / 1.08883
+	f := func(t float64) float64 {
+		if t > 0.008856 {
+			return math.Cbrt(t)
+		}
+		return 7.787*t + 16.0/116.0
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+func srgbToLinear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) uint8 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(v * 255))
+}
@@ -0,0 +1,45 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestSimulateColorVision(t *testing.T) {
+	assert := assert.New(t)
+
+	// grays are unaffected.
+	gray := drawing.Color{R: 128, G: 128, B: 128, A: 255}
+	for _, cvd := range []ColorVisionDeficiency{Protanopia, Deuteranopia, Tritanopia} {
+		simulated := SimulateColorVision(gray, cvd)
+		assert.True(colorDistance(gray, simulated) < 1, cvd.String())
+	}
+
+	// red and green collapse with red-green deficiencies.
+	red, green := drawing.ColorFromHex("CC4400"), drawing.ColorFromHex("778800")
+	assert.True(colorDistance(red, green) > 30)
+	assert.True(colorDistance(SimulateColorVision(red, Deuteranopia), SimulateColorVision(green, Deuteranopia)) < 12)
+
+	assert.Equal(red, SimulateColorVision(red, ColorVisionDeficiency(99)))
+	assert.Equal("ColorVisionDeficiency(99)", ColorVisionDeficiency(99).String())
+}
+
+func TestCheckColorVision(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(CheckColorVision(OkabeItoColorPalette, len(OkabeItoColors), 0))
+	assert.Empty(CheckColorVision(TolBrightColorPalette, len(TolBrightColors), 0))
+
+	warnings := CheckColorVision(AlternateColorPalette, 5, 0)
+	assert.Len(warnings, 1)
+	assert.Equal(Tritanopia, warnings[0].Deficiency)
+	assert.Equal(0, warnings[0].Index)
+	assert.NotEmpty(warnings[0].String())
+
+	palette := seriesColorPalette{colors: []drawing.Color{drawing.ColorFromHex("CC4400"), drawing.ColorFromHex("778800")}}
+	warnings = CheckColorVision(palette, 2, 0)
+	assert.NotEmpty(warnings)
+	assert.Empty(CheckColorVision(palette, 2, 0.001))
+}
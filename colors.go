@@ -21,6 +21,8 @@ var (
 	ColorBlack = drawing.Color{R: 51, G: 51, B: 51, A: 255}
 	// ColorLightGray is the basic theme light gray color.
 	ColorLightGray = drawing.Color{R: 239, G: 239, B: 239, A: 255}
+	// ColorDarkGray is the basic theme dark gray color.
+	ColorDarkGray = drawing.Color{R: 34, G: 34, B: 34, A: 255}
 
 	// ColorAlternateBlue is a alternate theme color.
 	ColorAlternateBlue = drawing.Color{R: 106, G: 195, B: 203, A: 255}
@@ -66,6 +68,10 @@ var (
 	DefaultAnnotationFillColor = ColorWhite
 	// DefaultGridLineColor is the default grid line color.
 	DefaultGridLineColor = ColorLightGray
+	// DefaultCandleUpColor is the default color of candles that close at or above their open.
+	DefaultCandleUpColor = ColorGreen
+	// DefaultCandleDownColor is the default color of candles that close below their open.
+	DefaultCandleDownColor = ColorRed
 )
 
 var (
@@ -182,3 +188,36 @@ func (ap alternateColorPalette) TextColor() drawing.Color {
 func (ap alternateColorPalette) GetSeriesColor(index int) drawing.Color {
 	return GetAlternateColor(index)
 }
+
+// DarkColorPalette is a palette with light text and axes on a dark background.
+var DarkColorPalette darkColorPalette
+
+type darkColorPalette struct{}
+
+func (dp darkColorPalette) BackgroundColor() drawing.Color {
+	return ColorDarkGray
+}
+
+func (dp darkColorPalette) BackgroundStrokeColor() drawing.Color {
+	return ColorDarkGray
+}
+
+func (dp darkColorPalette) CanvasColor() drawing.Color {
+	return ColorDarkGray
+}
+
+func (dp darkColorPalette) CanvasStrokeColor() drawing.Color {
+	return ColorDarkGray
+}
+
+func (dp darkColorPalette) AxisStrokeColor() drawing.Color {
+	return ColorAlternateLightGray
+}
+
+func (dp darkColorPalette) TextColor() drawing.Color {
+	return ColorLightGray
+}
+
+func (dp darkColorPalette) GetSeriesColor(index int) drawing.Color {
+	return GetAlternateColor(index)
+}
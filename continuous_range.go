@@ -11,6 +11,13 @@ type ContinuousRange struct {
 	Max        float64
 	Domain     int
 	Descending bool
+
+	// Padding is headroom added to the bounds a chart computes from its values, so the series don't touch
+	// the edges of the plot. Bounds that are set aren't padded.
+	Padding RangePadding
+	// NiceNumbers rounds the bounds a chart computes from its values, after padding, out to multiples of a step
+	// of 1, 2 or 5 times a power of 10, i.e. 0 to 100 rather than 3.7 to 97.2.
+	NiceNumbers bool
 }
 
 // IsDescending returns if the range is descending.
@@ -60,15 +67,38 @@ func (r *ContinuousRange) SetDomain(domain int) {
 	r.Domain = domain
 }
 
+// fit pads the bounds and rounds them to nice numbers as the range asks for.
+func (r *ContinuousRange) fit() {
+	r.Min, r.Max = r.Padding.pad(r.Min, r.Max)
+	if r.NiceNumbers {
+		r.Min, r.Max = niceBounds(r.Min, r.Max)
+	}
+}
+
+// getNiceNumbers returns if the range rounds its bounds to nice numbers.
+func (r ContinuousRange) getNiceNumbers() bool {
+	return r.NiceNumbers
+}
+
 // String returns a simple string for the ContinuousRange.
 func (r ContinuousRange) String() string {
 	return fmt.Sprintf("ContinuousRange [%.2f,%.2f] => %d", r.Min, r.Max, r.Domain)
 }
 
 // Translate maps a given value into the ContinuousRange space.
+// A zero, infinite or NaN delta maps every value to the start of the range.
 func (r ContinuousRange) Translate(value float64) int {
+	delta := r.GetDelta()
+	if delta == 0 || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		if r.IsDescending() {
+			return r.Domain
+		}
+		return 0
+	}
+
+	// offsetting from min before dividing keeps the precision of ranges far from zero, i.e. [1e15, 1.000001e15].
 	normalized := value - r.Min
-	ratio := normalized / r.GetDelta()
+	ratio := normalized / delta
 
 	if r.IsDescending() {
 		return r.Domain - int(math.Ceil(ratio*float64(r.Domain)))
@@ -21,3 +21,22 @@ func TestRangeTranslate(t *testing.T) {
 	assert.Equal(1000, r.Translate(8.0))
 	assert.Equal(572, r.Translate(5.0))
 }
+
+func TestRangeTranslateExtremeMagnitudes(t *testing.T) {
+	assert := assert.New(t)
+
+	tiny := ContinuousRange{Min: 1e-9, Max: 2e-9, Domain: 1000}
+	assert.Equal(0, tiny.Translate(1e-9))
+	assert.Equal(500, tiny.Translate(1.5e-9))
+	assert.Equal(1000, tiny.Translate(2e-9))
+
+	huge := ContinuousRange{Min: 1e15, Max: 1.000001e15, Domain: 1000}
+	assert.Equal(0, huge.Translate(1e15))
+	assert.Equal(500, huge.Translate(1.0000005e15))
+	assert.Equal(1000, huge.Translate(1.000001e15))
+
+	zero := ContinuousRange{Min: 1e15, Max: 1e15, Domain: 1000}
+	assert.Equal(0, zero.Translate(1e15))
+	zero.Descending = true
+	assert.Equal(1000, zero.Translate(1e15))
+}
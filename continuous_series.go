@@ -1,19 +1,29 @@
 package chart
 
-import "fmt"
-
 // ContinuousSeries represents a line on a chart.
 type ContinuousSeries struct {
 	Name  string
 	Style Style
 
 	YAxis YAxisType
+	XAxis XAxisType
 
 	XValueFormatter ValueFormatter
 	YValueFormatter ValueFormatter
 
 	XValues []float64
 	YValues []float64
+
+	// StyleProvider styles individual points, i.e. to color the values above a threshold; the style it returns
+	// inherits from the series style and is used for the dot at the point and the segment ending at it.
+	StyleProvider func(index int, x, y float64) Style
+
+	// DataLabels draws the y value of each point above it, if shown.
+	DataLabels DataLabels
+
+	// GapPolicy is how the line is drawn across missing values: y values that are NaN, or equal to one of `GapValues`.
+	GapPolicy GapPolicy
+	GapValues []float64
 }
 
 // GetName returns the name of the time series.
@@ -32,13 +42,44 @@ func (cs ContinuousSeries) Len() int {
 }
 
 // GetValues gets the x,y values at a given index.
+// Missing y values are NaN, or zero for `GapPolicyZero`.
 func (cs ContinuousSeries) GetValues(index int) (float64, float64) {
-	return cs.XValues[index], cs.YValues[index]
+	return cs.XValues[index], cs.getYValue(index)
+}
+
+// GetValuesInto copies the x,y values starting at a given index into the given slices.
+func (cs ContinuousSeries) GetValuesInto(start int, xvalues, yvalues []float64) int {
+	count := valuesIntoCount(len(cs.XValues), start, xvalues, yvalues)
+	if count == 0 {
+		return 0
+	}
+	copy(xvalues[:count], cs.XValues[start:])
+	copy(yvalues[:count], cs.YValues[start:])
+	if cs.hasGaps() {
+		for index := range yvalues[:count] {
+			yvalues[index] = gapValue(yvalues[index], cs.GapPolicy, cs.GapValues)
+		}
+	}
+	return count
 }
 
 // GetLastValues gets the last x,y values.
 func (cs ContinuousSeries) GetLastValues() (float64, float64) {
-	return cs.XValues[len(cs.XValues)-1], cs.YValues[len(cs.YValues)-1]
+	return cs.XValues[len(cs.XValues)-1], cs.getYValue(len(cs.YValues) - 1)
+}
+
+// getYValue returns the y value at an index, with missing values as the gap policy draws them.
+func (cs ContinuousSeries) getYValue(index int) float64 {
+	if !cs.hasGaps() {
+		return cs.YValues[index]
+	}
+	return gapValue(cs.YValues[index], cs.GapPolicy, cs.GapValues)
+}
+
+// hasGaps returns if any y values are read as other than they are; NaN values are already NaN unless
+// they are drawn as zero.
+func (cs ContinuousSeries) hasGaps() bool {
+	return cs.GapPolicy == GapPolicyZero || len(cs.GapValues) > 0
 }
 
 // GetValueFormatters returns value formatter defaults for the series.
@@ -61,20 +102,48 @@ func (cs ContinuousSeries) GetYAxis() YAxisType {
 	return cs.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (cs ContinuousSeries) GetXAxis() XAxisType {
+	return cs.XAxis
+}
+
 // Render renders the series.
 func (cs ContinuousSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
 	style := cs.Style.InheritFrom(defaults)
-	Draw.LineSeries(r, canvasBox, xrange, yrange, style, cs)
+	if cs.GapPolicy == GapPolicyBreak {
+		for _, run := range gapRuns(cs) {
+			cs.drawLine(r, canvasBox, xrange, yrange, style, valuesRun{ValuesProvider: cs, start: run[0], end: run[1]}, run[0])
+		}
+	} else {
+		cs.drawLine(r, canvasBox, xrange, yrange, style, cs, 0)
+	}
+	_, yf := cs.GetValueFormatters()
+	cs.DataLabels.drawSeries(r, canvasBox, xrange, yrange, cs.DataLabels.Style.InheritFrom(styleDefaultsDataLabels(style)), cs, yf)
+}
+
+// drawLine draws the line through values of the series, which start at an offset within it.
+func (cs ContinuousSeries) drawLine(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider, offset int) {
+	if cs.StyleProvider == nil {
+		Draw.LineSeries(r, canvasBox, xrange, yrange, style, vs)
+		return
+	}
+	Draw.StyledLineSeries(r, canvasBox, xrange, yrange, style, vs, func(index int, x, y float64) Style {
+		return cs.StyleProvider(offset+index, x, y)
+	})
 }
 
 // Validate validates the series.
 func (cs ContinuousSeries) Validate() error {
 	if len(cs.XValues) == 0 {
-		return fmt.Errorf("continuous series must have xvalues set")
+		return newValidationError(ErrEmptySeries, "continuous series must have xvalues set")
 	}
 
 	if len(cs.YValues) == 0 {
-		return fmt.Errorf("continuous series must have yvalues set")
+		return newValidationError(ErrEmptySeries, "continuous series must have yvalues set")
+	}
+
+	if len(cs.XValues) != len(cs.YValues) {
+		return newValidationError(ErrLengthMismatch, "continuous series has %d xvalues but %d yvalues", len(cs.XValues), len(cs.YValues))
 	}
 	return nil
 }
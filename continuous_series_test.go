@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	assert "github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
 	"github.com/wcharczuk/go-chart/seq"
 )
 
@@ -71,3 +72,42 @@ func TestContinuousSeriesValidate(t *testing.T) {
 	}
 	assert.NotNil(cs.Validate())
 }
+
+func TestContinuousSeriesStyleProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  200,
+		Height: 200,
+		Series: []Series{
+			ContinuousSeries{
+				Style:   Style{Show: true, StrokeColor: drawing.ColorBlue, StrokeWidth: 3},
+				XValues: []float64{0, 1, 2, 3},
+				YValues: []float64{0, 3, 0, 3},
+				StyleProvider: func(index int, x, y float64) Style {
+					if y > 2 {
+						return Style{StrokeColor: drawing.ColorRed, DotColor: drawing.ColorRed, DotWidth: 4}
+					}
+					return Style{}
+				},
+			},
+		},
+	}
+	img, err := c.RenderToImage()
+	assert.Nil(err)
+
+	var red, blue int
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if r > 0xf000 && g < 0x1000 && b < 0x1000 {
+				red++
+			} else if b > 0xf000 && r < 0x1000 && g < 0x1000 {
+				blue++
+			}
+		}
+	}
+	assert.NotZero(red)
+	assert.NotZero(blue)
+}
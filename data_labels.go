@@ -0,0 +1,96 @@
+package chart
+
+// DataLabels draws the value of each point of a series, or of each bar of a bar chart, next to it.
+type DataLabels struct {
+	Show  bool
+	Style Style
+
+	// Formatter formats the values; it defaults to the y value formatter of the series or chart.
+	Formatter ValueFormatter
+	// Offset is the distance from a point, or the end of a bar, to its label; it defaults to `DefaultDataLabelOffset`.
+	Offset int
+	// NoThinning draws every label; otherwise a label that would overlap a label already drawn is left out,
+	// so dense series are labeled at the points there is room for.
+	NoThinning bool
+}
+
+// GetOffset returns the distance from a point to its label.
+func (dl DataLabels) GetOffset() int {
+	if dl.Offset == 0 {
+		return DefaultDataLabelOffset
+	}
+	return dl.Offset
+}
+
+// GetFormatter returns the formatter of the labels, or a default.
+func (dl DataLabels) GetFormatter(defaults ValueFormatter) ValueFormatter {
+	if dl.Formatter != nil {
+		return dl.Formatter
+	}
+	if defaults != nil {
+		return defaults
+	}
+	return FloatValueFormatter
+}
+
+// drawSeries draws the labels of the points of a series above them, or below them where there isn't room
+// above within the canvas.
+func (dl DataLabels) drawSeries(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider, vf ValueFormatter) {
+	if !dl.Show {
+		return
+	}
+	vf = dl.GetFormatter(vf)
+	offset := dl.GetOffset()
+	labels := dataLabelPlacer{noThinning: dl.NoThinning}
+	for index := 0; index < vs.Len(); index++ {
+		vx, vy := vs.GetValues(index)
+		if !isFinitePoint(vx, vy) {
+			continue
+		}
+		x := canvasBox.Left + xrange.Translate(vx)
+		y := canvasBox.Bottom - yrange.Translate(vy)
+
+		label := vf(vy)
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+		box := Box{Left: x - (tb.Width() >> 1), Right: x - (tb.Width() >> 1) + tb.Width(), Bottom: y - offset}
+		box.Top = box.Bottom - tb.Height()
+		if box.Top < canvasBox.Top {
+			box = box.Shift(0, tb.Height()+(offset<<1))
+		}
+		if labels.place(box) {
+			Draw.TextAnchored(r, label, x, box.Bottom, withTextAnchor(style, TextHorizontalAlignCenter, TextVerticalAlignBaseline))
+		}
+	}
+}
+
+// dataLabelGap is the least space between the sides of labels that are drawn.
+const dataLabelGap = 2
+
+// dataLabelPlacer keeps track of the labels drawn so far, to leave out labels that would overlap them.
+type dataLabelPlacer struct {
+	noThinning bool
+	placed     []Box
+}
+
+// place returns if a label is drawn in a box; it is unless it overlaps a label already drawn, or runs into
+// one side by side, and labels are thinned.
+func (p *dataLabelPlacer) place(box Box) bool {
+	if p.noThinning {
+		return true
+	}
+	if overlapArea(Box{Top: box.Top, Left: box.Left - dataLabelGap, Right: box.Right + dataLabelGap, Bottom: box.Bottom}, p.placed) > 0 {
+		return false
+	}
+	p.placed = append(p.placed, box)
+	return true
+}
+
+// styleDefaultsDataLabels returns the default style of the data labels of a series; they are the color of the series.
+func styleDefaultsDataLabels(seriesStyle Style) Style {
+	return Style{
+		Font:      seriesStyle.Font,
+		FontSize:  DefaultAxisFontSize,
+		FontColor: seriesStyle.GetStrokeColor(),
+	}
+}
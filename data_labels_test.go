@@ -0,0 +1,89 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestDataLabelsDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	dl := DataLabels{}
+	assert.Equal(DefaultDataLabelOffset, dl.GetOffset())
+	assert.Equal("1.00", dl.GetFormatter(nil)(1.0))
+
+	dl = DataLabels{Offset: 2, Formatter: func(v interface{}) string { return fmt.Sprintf("v%v", v) }}
+	assert.Equal(2, dl.GetOffset())
+	assert.Equal("v1", dl.GetFormatter(FloatValueFormatter)(1.0))
+}
+
+func TestDataLabelPlacer(t *testing.T) {
+	assert := assert.New(t)
+
+	labels := dataLabelPlacer{}
+	assert.True(labels.place(Box{Top: 0, Left: 0, Right: 10, Bottom: 10}))
+	assert.False(labels.place(Box{Top: 5, Left: 5, Right: 15, Bottom: 15}))
+	assert.False(labels.place(Box{Top: 0, Left: 10, Right: 20, Bottom: 10}))
+	assert.True(labels.place(Box{Top: 0, Left: 12, Right: 20, Bottom: 10}))
+
+	labels = dataLabelPlacer{noThinning: true}
+	assert.True(labels.place(Box{Top: 0, Left: 0, Right: 10, Bottom: 10}))
+	assert.True(labels.place(Box{Top: 0, Left: 0, Right: 10, Bottom: 10}))
+}
+
+func TestContinuousSeriesDataLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	render := func(dl DataLabels, count int) string {
+		xvalues, yvalues := make([]float64, count), make([]float64, count)
+		for index := range xvalues {
+			xvalues[index], yvalues[index] = float64(index), float64(index%7)
+		}
+		c := Chart{
+			Width: 400,
+			Series: []Series{
+				ContinuousSeries{XValues: xvalues, YValues: yvalues, DataLabels: dl},
+			},
+		}
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(SVG, buffer))
+		return buffer.String()
+	}
+
+	assert.False(strings.Contains(render(DataLabels{}, 5), ">v3<"))
+
+	formatter := func(v interface{}) string { return fmt.Sprintf("v%v", v) }
+	svg := render(DataLabels{Show: true, Formatter: formatter}, 5)
+	for _, label := range []string{">v0<", ">v1<", ">v2<", ">v3<", ">v4<"} {
+		assert.True(strings.Contains(svg, label), label)
+	}
+
+	// a dense series is thinned to the labels there is room for, unless thinning is turned off.
+	thinned := strings.Count(render(DataLabels{Show: true, Formatter: formatter}, 200), ">v")
+	assert.True(thinned > 0)
+	assert.True(thinned < 200)
+	assert.Equal(200, strings.Count(render(DataLabels{Show: true, Formatter: formatter, NoThinning: true}, 200), ">v"))
+}
+
+func TestBarChartDataLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, horizontal := range []bool{false, true} {
+		bc := BarChart{
+			Horizontal: horizontal,
+			DataLabels: DataLabels{Show: true, Offset: 8, Formatter: func(v interface{}) string { return fmt.Sprintf("%v units", v) }},
+			Bars: []Value{
+				{Value: 1.0, Label: "One"},
+				{Value: -2.0, Label: "Two"},
+			},
+		}
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(bc.Render(SVG, buffer))
+		assert.True(strings.Contains(buffer.String(), "1 units"))
+		assert.True(strings.Contains(buffer.String(), "-2 units"))
+	}
+}
@@ -0,0 +1,325 @@
+// Package dataload reads chart series from delimited text, i.e. csv and tsv files.
+//
+// The columns of the x and y values are given by header name, or by zero based index; each y column
+// becomes a series named after it:
+//
+//	series, err := dataload.TimeSeries(file, dataload.Options{X: "time", Y: []string{"p50", "p99"}})
+//
+// Empty fields, and fields like `NA` or `NaN`, are missing values; by default they are read as NaN,
+// which breaks a line with `chart.GapPolicyBreak`. Fields that aren't missing and don't parse are always an error.
+package dataload
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	chart "github.com/wcharczuk/go-chart"
+)
+
+var (
+	// ErrNoColumn is the kind of error for a column that isn't in the data.
+	ErrNoColumn = errors.New("no such column")
+	// ErrInvalidValue is the kind of error for a field that isn't missing but doesn't parse.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrMissingValue is the kind of error for a missing field, when missing values are errors.
+	ErrMissingValue = errors.New("missing value")
+)
+
+// TSV is the `Options.Comma` of tab separated values.
+const TSV = '\t'
+
+// The `Options.TimeLayout` of x values that are unix timestamps rather than formatted times.
+const (
+	UnixSeconds      = "unix"
+	UnixMilliseconds = "unixmilli"
+)
+
+// MissingPolicy is how missing values are read.
+type MissingPolicy int
+
+const (
+	// MissingNaN reads a missing y value as NaN, which a line breaks at with `chart.GapPolicyBreak`;
+	// rows with a missing x value are left out.
+	MissingNaN MissingPolicy = iota
+	// MissingSkip leaves the rows with a missing y value out of that series, so a line is drawn straight across them;
+	// rows with a missing x value are left out.
+	MissingSkip
+	// MissingError returns an `ErrMissingValue` error for any missing value.
+	MissingError
+)
+
+// DefaultMissingValues are the fields read as missing values besides empty fields, compared without case.
+var DefaultMissingValues = []string{"NA", "N/A", "NaN", "null", "-"}
+
+// Options are how delimited text is read into series.
+type Options struct {
+	// Comma is the field delimiter; it defaults to a comma, and is `TSV` for tab separated values.
+	Comma rune
+	// Comment starts lines that are skipped, if set.
+	Comment rune
+	// NoHeader reads the first row as data; columns can then only be given by index.
+	NoHeader bool
+
+	// X is the column of the x values, and Y the columns of the y values, one series each.
+	X string
+	Y []string
+
+	// TimeLayout is the layout of the x values of time series; it defaults to `time.RFC3339`,
+	// and can be `UnixSeconds` or `UnixMilliseconds`. Location is the time zone of times without one;
+	// it defaults to UTC.
+	TimeLayout string
+	Location   *time.Location
+
+	// Missing is how missing values are read; MissingValues are the fields read as missing values
+	// besides empty fields, and default to `DefaultMissingValues`.
+	Missing       MissingPolicy
+	MissingValues []string
+}
+
+// GetComma returns the field delimiter, or a default.
+func (o Options) GetComma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+// GetTimeLayout returns the layout of time x values, or a default.
+func (o Options) GetTimeLayout() string {
+	if len(o.TimeLayout) == 0 {
+		return time.RFC3339
+	}
+	return o.TimeLayout
+}
+
+// GetLocation returns the time zone of times without one, or a default.
+func (o Options) GetLocation() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+// GetMissingValues returns the fields read as missing values, or a default.
+func (o Options) GetMissingValues() []string {
+	if o.MissingValues == nil {
+		return DefaultMissingValues
+	}
+	return o.MissingValues
+}
+
+// ContinuousSeries reads a continuous series for each y column, with the x values of the x column.
+func ContinuousSeries(r io.Reader, opts Options) ([]chart.ContinuousSeries, error) {
+	series := make([]chart.ContinuousSeries, len(opts.Y))
+	err := read(r, opts, func(t *table) error {
+		for index, name := range opts.Y {
+			series[index].Name = t.name(name)
+		}
+		return t.each(func(row []string) error {
+			x, isMissing, err := t.float(row, opts.X)
+			if err != nil || isMissing {
+				return err
+			}
+			return t.values(row, func(index int, y float64) {
+				series[index].XValues = append(series[index].XValues, x)
+				series[index].YValues = append(series[index].YValues, y)
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// TimeSeries reads a time series for each y column, with the times of the x column.
+func TimeSeries(r io.Reader, opts Options) ([]chart.TimeSeries, error) {
+	series := make([]chart.TimeSeries, len(opts.Y))
+	err := read(r, opts, func(t *table) error {
+		for index, name := range opts.Y {
+			series[index].Name = t.name(name)
+		}
+		return t.each(func(row []string) error {
+			x, isMissing, err := t.time(row, opts.X)
+			if err != nil || isMissing {
+				return err
+			}
+			return t.values(row, func(index int, y float64) {
+				series[index].XValues = append(series[index].XValues, x)
+				series[index].YValues = append(series[index].YValues, y)
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// table is delimited text being read, with its header.
+type table struct {
+	opts    Options
+	reader  *csv.Reader
+	header  []string
+	columns map[string]int
+	line    int
+}
+
+// read reads the header of delimited text, resolves the columns of the options, and hands the table to `body`.
+func read(r io.Reader, opts Options, body func(*table) error) error {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.GetComma()
+	reader.Comment = opts.Comment
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	t := &table{opts: opts, reader: reader, columns: map[string]int{}}
+	if !opts.NoHeader {
+		header, err := reader.Read()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		for _, name := range header {
+			t.header = append(t.header, strings.TrimSpace(name))
+		}
+	}
+	for _, name := range append([]string{opts.X}, opts.Y...) {
+		index, err := t.resolve(name)
+		if err != nil {
+			return err
+		}
+		t.columns[name] = index
+	}
+	return body(t)
+}
+
+// resolve returns the index of a column, by header name or by index.
+func (t *table) resolve(name string) (int, error) {
+	for index, header := range t.header {
+		if header == name {
+			return index, nil
+		}
+	}
+	if index, err := strconv.Atoi(name); err == nil && index >= 0 && (t.opts.NoHeader || index < len(t.header)) {
+		return index, nil
+	}
+	return 0, fmt.Errorf("dataload: %w: %q", ErrNoColumn, name)
+}
+
+// name returns the name of a series read from a column; its header, or the column as given.
+func (t *table) name(column string) string {
+	if index := t.columns[column]; index < len(t.header) {
+		return t.header[index]
+	}
+	return column
+}
+
+// each calls `row` with each row of data.
+func (t *table) each(row func([]string) error) error {
+	for {
+		record, err := t.reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		t.line, _ = t.reader.FieldPos(0)
+		if err := row(record); err != nil {
+			return err
+		}
+	}
+}
+
+// values calls `value` with the index of each y column and its value in a row, leaving out missing values
+// if they are skipped.
+func (t *table) values(row []string, value func(index int, y float64)) error {
+	for index, column := range t.opts.Y {
+		y, isMissing, err := t.float(row, column)
+		if err != nil {
+			return err
+		}
+		if !isMissing {
+			value(index, y)
+		} else if t.opts.Missing == MissingNaN {
+			value(index, math.NaN())
+		}
+	}
+	return nil
+}
+
+// field returns the field of a column in a row, and if it is missing.
+func (t *table) field(row []string, column string) (field string, isMissing bool, err error) {
+	if index := t.columns[column]; index < len(row) {
+		field = strings.TrimSpace(row[index])
+	}
+	if !t.isMissing(field) {
+		return field, false, nil
+	}
+	if t.opts.Missing == MissingError {
+		return "", true, t.errorf(column, ErrMissingValue, field)
+	}
+	return "", true, nil
+}
+
+// isMissing returns if a field is a missing value.
+func (t *table) isMissing(field string) bool {
+	if len(field) == 0 {
+		return true
+	}
+	for _, missing := range t.opts.GetMissingValues() {
+		if strings.EqualFold(field, missing) {
+			return true
+		}
+	}
+	return false
+}
+
+// float returns the number in the field of a column in a row, and if it is missing.
+func (t *table) float(row []string, column string) (value float64, isMissing bool, err error) {
+	field, isMissing, err := t.field(row, column)
+	if err != nil || isMissing {
+		return 0, isMissing, err
+	}
+	if value, err = strconv.ParseFloat(field, 64); err != nil {
+		return 0, false, t.errorf(column, ErrInvalidValue, field)
+	}
+	return value, false, nil
+}
+
+// time returns the time in the field of a column in a row, and if it is missing.
+func (t *table) time(row []string, column string) (value time.Time, isMissing bool, err error) {
+	field, isMissing, err := t.field(row, column)
+	if err != nil || isMissing {
+		return time.Time{}, isMissing, err
+	}
+	switch layout := t.opts.GetTimeLayout(); layout {
+	case UnixSeconds, UnixMilliseconds:
+		var stamp int64
+		if stamp, err = strconv.ParseInt(field, 10, 64); err == nil {
+			if layout == UnixSeconds {
+				value = time.Unix(stamp, 0).In(t.opts.GetLocation())
+			} else {
+				value = time.UnixMilli(stamp).In(t.opts.GetLocation())
+			}
+		}
+	default:
+		value, err = time.ParseInLocation(layout, field, t.opts.GetLocation())
+	}
+	if err != nil {
+		return time.Time{}, false, t.errorf(column, ErrInvalidValue, field)
+	}
+	return value, false, nil
+}
+
+// errorf returns an error of a kind for the field of a column on the current line.
+func (t *table) errorf(column string, kind error, field string) error {
+	return fmt.Errorf("dataload: line %d, column %q: %w: %q", t.line, t.name(column), kind, field)
+}
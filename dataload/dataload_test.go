@@ -0,0 +1,80 @@
+package dataload
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestContinuousSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	data := "x, a, b\n1, 10, 100\n2, NA, 200\n3, 30,\n, 40, 400\n"
+	series, err := ContinuousSeries(strings.NewReader(data), Options{X: "x", Y: []string{"a", "b"}})
+	assert.Nil(err)
+	assert.Len(series, 2)
+	assert.Equal("a", series[0].Name)
+	assert.Equal([]float64{1, 2, 3}, series[0].XValues)
+	assert.Equal(10.0, series[0].YValues[0])
+	assert.True(math.IsNaN(series[0].YValues[1]))
+	assert.True(math.IsNaN(series[1].YValues[2]))
+	assert.Nil(series[0].Validate())
+
+	series, err = ContinuousSeries(strings.NewReader(data), Options{X: "x", Y: []string{"a", "b"}, Missing: MissingSkip})
+	assert.Nil(err)
+	assert.Equal([]float64{1, 3}, series[0].XValues)
+	assert.Equal([]float64{10, 30}, series[0].YValues)
+	assert.Equal([]float64{1, 2}, series[1].XValues)
+
+	_, err = ContinuousSeries(strings.NewReader(data), Options{X: "x", Y: []string{"a"}, Missing: MissingError})
+	assert.True(errors.Is(err, ErrMissingValue))
+	assert.True(strings.Contains(err.Error(), "line 3"), err.Error())
+}
+
+func TestContinuousSeriesColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	data := "# comment\n1\t2\t3\n4\t5\t6\n"
+	series, err := ContinuousSeries(strings.NewReader(data), Options{Comma: TSV, Comment: '#', NoHeader: true, X: "0", Y: []string{"2"}})
+	assert.Nil(err)
+	assert.Equal("2", series[0].Name)
+	assert.Equal([]float64{1, 4}, series[0].XValues)
+	assert.Equal([]float64{3, 6}, series[0].YValues)
+
+	_, err = ContinuousSeries(strings.NewReader("x,y\n1,2\n"), Options{X: "x", Y: []string{"z"}})
+	assert.True(errors.Is(err, ErrNoColumn))
+	_, err = ContinuousSeries(strings.NewReader("x,y\n1,2\n"), Options{X: "x", Y: []string{"5"}})
+	assert.True(errors.Is(err, ErrNoColumn))
+
+	_, err = ContinuousSeries(strings.NewReader("x,y\n1,2\n2,two\n"), Options{X: "x", Y: []string{"1"}})
+	assert.True(errors.Is(err, ErrInvalidValue))
+	assert.True(strings.Contains(err.Error(), `column "y"`), err.Error())
+}
+
+func TestTimeSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	data := "time,p50\n2017-03-01 00:00,10\n2017-03-01 01:00,20\nnull,30\n"
+	series, err := TimeSeries(strings.NewReader(data), Options{X: "time", Y: []string{"p50"}, TimeLayout: "2006-01-02 15:04"})
+	assert.Nil(err)
+	start := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal([]time.Time{start, start.Add(time.Hour)}, series[0].XValues)
+	assert.Equal([]float64{10, 20}, series[0].YValues)
+
+	data = "time,p50\n1488326400,10\n1488330000,20\n"
+	series, err = TimeSeries(strings.NewReader(data), Options{X: "time", Y: []string{"p50"}, TimeLayout: UnixSeconds})
+	assert.Nil(err)
+	assert.True(series[0].XValues[1].Equal(start.Add(time.Hour)))
+
+	data = "time,p50\n1488326400000,10\n"
+	series, err = TimeSeries(strings.NewReader(data), Options{X: "time", Y: []string{"p50"}, TimeLayout: UnixMilliseconds})
+	assert.Nil(err)
+	assert.True(series[0].XValues[0].Equal(start))
+
+	_, err = TimeSeries(strings.NewReader("time,p50\nyesterday,1\n"), Options{X: "time", Y: []string{"p50"}})
+	assert.True(errors.Is(err, ErrInvalidValue))
+}
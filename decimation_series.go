@@ -0,0 +1,178 @@
+package chart
+
+import "math"
+
+// DecimationSeries draws its inner series downsampled with the largest triangle three buckets algorithm (see `LTTB`),
+// so a series of far more points than the canvas has pixels renders quickly and legibly while keeping its shape.
+//
+// Its values are the values of the inner series, so the chart ranges span every point; only drawing is downsampled.
+type DecimationSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	// Threshold is how many points are drawn; it defaults to one per pixel of the canvas width.
+	Threshold int
+
+	InnerSeries ValuesProvider
+}
+
+// GetName returns the name of the series.
+func (ds DecimationSeries) GetName() string {
+	return ds.Name
+}
+
+// GetStyle returns the line style.
+func (ds DecimationSeries) GetStyle() Style {
+	return ds.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ds DecimationSeries) GetYAxis() YAxisType {
+	return ds.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (ds DecimationSeries) GetXAxis() XAxisType {
+	return ds.XAxis
+}
+
+// GetThreshold returns the number of points drawn on a canvas of a given width.
+func (ds DecimationSeries) GetThreshold(canvasWidth int) int {
+	if ds.Threshold > 0 {
+		return ds.Threshold
+	}
+	return canvasWidth
+}
+
+// Len returns the number of values of the inner series.
+func (ds DecimationSeries) Len() int {
+	return ds.InnerSeries.Len()
+}
+
+// GetValues gets the x,y values of the inner series at a given index.
+func (ds DecimationSeries) GetValues(index int) (float64, float64) {
+	return ds.InnerSeries.GetValues(index)
+}
+
+// GetValueFormatters returns the value formatters of the inner series, if it has them.
+func (ds DecimationSeries) GetValueFormatters() (x, y ValueFormatter) {
+	if vfp, isValueFormatterProvider := ds.InnerSeries.(ValueFormatterProvider); isValueFormatterProvider {
+		return vfp.GetValueFormatters()
+	}
+	return FloatValueFormatter, FloatValueFormatter
+}
+
+// Render renders the series.
+func (ds DecimationSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := ds.Style.InheritFrom(defaults)
+	xvalues, yvalues := LTTB(ds.InnerSeries, ds.GetThreshold(canvasBox.Width()))
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, ContinuousSeries{XValues: xvalues, YValues: yvalues})
+}
+
+// Validate validates the series.
+func (ds DecimationSeries) Validate() error {
+	if ds.InnerSeries == nil {
+		return newValidationError(ErrEmptySeries, "decimation series requires InnerSeries to be set")
+	}
+	return nil
+}
+
+// LTTB downsamples the values of a series to about `threshold` points with the largest triangle three buckets
+// algorithm: the first and last points are kept, the rest are split into buckets, and each bucket keeps the point
+// that forms the largest triangle with the point kept before it and the average of the next bucket.
+//
+// Points that are not finite split the series into runs, which are downsampled separately with a share of the
+// threshold by their length; the gaps between them are kept. Series of at most `threshold` points are returned as is.
+func LTTB(vp ValuesProvider, threshold int) (xvalues, yvalues []float64) {
+	length := vp.Len()
+	xvalues, yvalues = make([]float64, length), make([]float64, length)
+	for start := 0; start < length; {
+		count := GetValuesInto(vp, start, xvalues[start:], yvalues[start:])
+		if count == 0 {
+			break
+		}
+		start += count
+	}
+	if threshold <= 0 || length <= threshold {
+		return
+	}
+
+	var runs [][2]int
+	var finiteCount int
+	runStart := -1
+	for index := 0; index <= length; index++ {
+		if index < length && isFinitePoint(xvalues[index], yvalues[index]) {
+			if runStart < 0 {
+				runStart = index
+			}
+			finiteCount++
+			continue
+		}
+		if runStart >= 0 {
+			runs = append(runs, [2]int{runStart, index})
+			runStart = -1
+		}
+	}
+
+	var sampledX, sampledY []float64
+	for index, run := range runs {
+		if index > 0 {
+			sampledX, sampledY = append(sampledX, math.NaN()), append(sampledY, math.NaN())
+		}
+		runThreshold := int(math.Ceil(float64(threshold) * float64(run[1]-run[0]) / float64(finiteCount)))
+		for _, kept := range lttbIndices(xvalues[run[0]:run[1]], yvalues[run[0]:run[1]], runThreshold) {
+			sampledX, sampledY = append(sampledX, xvalues[run[0]+kept]), append(sampledY, yvalues[run[0]+kept])
+		}
+	}
+	return sampledX, sampledY
+}
+
+// lttbIndices returns the indices of the points kept by downsampling finite values to `threshold` points.
+func lttbIndices(xvalues, yvalues []float64, threshold int) []int {
+	length := len(xvalues)
+	if threshold >= length {
+		indices := make([]int, length)
+		for index := range indices {
+			indices[index] = index
+		}
+		return indices
+	}
+	if threshold < 3 {
+		return []int{0, length - 1}
+	}
+
+	indices := make([]int, 0, threshold)
+	indices = append(indices, 0)
+	every := float64(length-2) / float64(threshold-2)
+	previous := 0
+	for bucket := 0; bucket < threshold-2; bucket++ {
+		// the average of the next bucket is the third point of the triangles.
+		nextStart, nextEnd := int(float64(bucket+1)*every)+1, int(float64(bucket+2)*every)+1
+		if nextEnd > length {
+			nextEnd = length
+		}
+		var averageX, averageY float64
+		for index := nextStart; index < nextEnd; index++ {
+			averageX += xvalues[index]
+			averageY += yvalues[index]
+		}
+		if count := nextEnd - nextStart; count > 0 {
+			averageX, averageY = averageX/float64(count), averageY/float64(count)
+		}
+
+		start, end := int(float64(bucket)*every)+1, int(float64(bucket+1)*every)+1
+		px, py := xvalues[previous], yvalues[previous]
+		largest, kept := -1.0, start
+		for index := start; index < end; index++ {
+			area := math.Abs((px-averageX)*(yvalues[index]-py) - (px-xvalues[index])*(averageY-py))
+			if area > largest {
+				largest, kept = area, index
+			}
+		}
+		indices = append(indices, kept)
+		previous = kept
+	}
+	return append(indices, length-1)
+}
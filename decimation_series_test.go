@@ -0,0 +1,86 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestLTTB(t *testing.T) {
+	assert := assert.New(t)
+
+	var xvalues, yvalues []float64
+	for index := 0; index < 1000; index++ {
+		xvalues = append(xvalues, float64(index))
+		yvalues = append(yvalues, math.Sin(float64(index)/50))
+	}
+	// a spike in the middle of a bucket is kept.
+	yvalues[503] = 10
+
+	xs, ys := LTTB(ContinuousSeries{XValues: xvalues, YValues: yvalues}, 100)
+	assert.Len(xs, 100)
+	assert.Len(ys, 100)
+	assert.Equal(0.0, xs[0])
+	assert.Equal(999.0, xs[99])
+	var hasSpike bool
+	for index := range xs {
+		if ys[index] == 10 {
+			hasSpike = true
+		}
+		if index > 0 {
+			assert.True(xs[index] > xs[index-1])
+		}
+	}
+	assert.True(hasSpike)
+
+	xs, _ = LTTB(ContinuousSeries{XValues: xvalues[:50], YValues: yvalues[:50]}, 100)
+	assert.Len(xs, 50)
+}
+
+func TestLTTBGaps(t *testing.T) {
+	assert := assert.New(t)
+
+	var xvalues, yvalues []float64
+	for index := 0; index < 200; index++ {
+		xvalues = append(xvalues, float64(index))
+		yvalues = append(yvalues, float64(index%7))
+	}
+	yvalues[100] = math.NaN()
+
+	xs, ys := LTTB(ContinuousSeries{XValues: xvalues, YValues: yvalues}, 20)
+	var gaps int
+	for index := range xs {
+		if math.IsNaN(ys[index]) {
+			gaps++
+			assert.True(xs[index-1] < 100)
+			assert.True(xs[index+1] > 100)
+		}
+	}
+	assert.Equal(1, gaps)
+	assert.True(len(xs) <= 23, len(xs))
+}
+
+func TestDecimationSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := ContinuousSeries{}
+	for index := 0; index < 10000; index++ {
+		inner.XValues = append(inner.XValues, float64(index))
+		inner.YValues = append(inner.YValues, float64(index%100))
+	}
+	ds := DecimationSeries{InnerSeries: inner}
+	assert.Equal(10000, ds.Len())
+	assert.Equal(500, ds.GetThreshold(500))
+	assert.Equal(20, DecimationSeries{Threshold: 20}.GetThreshold(500))
+	assert.NotNil(DecimationSeries{}.Validate())
+
+	c := Chart{Series: []Series{ds}}
+	xr, yr, _ := c.getRanges()
+	assert.Equal(9999.0, xr.GetMax())
+	assert.Equal(99.0, yr.GetMax())
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+}
@@ -1,10 +1,18 @@
 package chart
 
+import "time"
+
 const (
 	// DefaultChartHeight is the default chart height.
 	DefaultChartHeight = 400
 	// DefaultChartWidth is the default chart width.
 	DefaultChartWidth = 1024
+	// DefaultSparklineWidth is the default sparkline width.
+	DefaultSparklineWidth = 120
+	// DefaultSparklineHeight is the default sparkline height.
+	DefaultSparklineHeight = 30
+	// DefaultSparklineMarkerWidth is the radius of the markers of sparklines.
+	DefaultSparklineMarkerWidth = 2.0
 	// DefaultStrokeWidth is the default chart stroke width.
 	DefaultStrokeWidth = 0.0
 	// DefaultDotWidth is the default chart dot width.
@@ -13,6 +21,8 @@ const (
 	DefaultSeriesLineWidth = 1.0
 	// DefaultAxisLineWidth is the line width of the axis lines.
 	DefaultAxisLineWidth = 1.0
+	// DefaultZeroLineWidth is the line width of the zero line of y axes.
+	DefaultZeroLineWidth = 1.5
 	//DefaultDPI is the default dots per inch for the chart.
 	DefaultDPI = 92.0
 	// DefaultMinimumFontSize is the default minimum font size.
@@ -25,10 +35,28 @@ const (
 	DefaultAnnotationDeltaWidth = 10
 	// DefaultAnnotationFontSize is the font size of annotations.
 	DefaultAnnotationFontSize = 10.0
+	// DefaultAnnotationArrowSize is the length of the arrow heads of annotations.
+	DefaultAnnotationArrowSize = 8
+	// DefaultReferenceLabelPadding is the distance of the labels of reference lines and regions from their edges.
+	DefaultReferenceLabelPadding = 4
+	// DefaultEventFlagFontSize is the font size of the labels of event flags.
+	DefaultEventFlagFontSize = 8.0
+	// DefaultEventFlagSpacing is the space between event flags stacked to keep them from overlapping.
+	DefaultEventFlagSpacing = 2
+	// DefaultEventFlagPoleLength is the distance of event flags pinned to y values from their values.
+	DefaultEventFlagPoleLength = 12
 	// DefaultAxisFontSize is the font size of the axis labels.
 	DefaultAxisFontSize = 10.0
 	// DefaultTitleTop is the default distance from the top of the chart to put the title.
 	DefaultTitleTop = 10
+	// DefaultSubtitleFontSize is the default font size of chart subtitles.
+	DefaultSubtitleFontSize = 12.0
+	// DefaultFooterFontSize is the default font size of chart footers.
+	DefaultFooterFontSize = 9.0
+	// DefaultSourceFontSize is the default font size of chart source attributions.
+	DefaultSourceFontSize = 8.0
+	// DefaultTitleBlockSpacing is the space between the plot and the title blocks above and below it.
+	DefaultTitleBlockSpacing = 10
 
 	// DefaultBackgroundStrokeWidth is the default stroke on the chart background.
 	DefaultBackgroundStrokeWidth = 0.0
@@ -37,6 +65,8 @@ const (
 
 	// DefaultLineSpacing is the default vertical distance between lines of text.
 	DefaultLineSpacing = 5
+	// DefaultDataLabelOffset is the default distance from a point, or the end of a bar, to its data label.
+	DefaultDataLabelOffset = DefaultLineSpacing
 
 	// DefaultYAxisMargin is the default distance from the right of the canvas to the y axis labels.
 	DefaultYAxisMargin = 10
@@ -57,6 +87,8 @@ const (
 	DefaultMinimumTickHorizontalSpacing = 20
 	// DefaultMinimumTickVerticalSpacing is the minimum distance between vertical ticks.
 	DefaultMinimumTickVerticalSpacing = 20
+	// DefaultLogarithmicMinorTickSpacing is the minimum distance between the minor ticks of a logarithmic range.
+	DefaultLogarithmicMinorTickSpacing = 2
 
 	// DefaultDateFormat is the default date format.
 	DefaultDateFormat = "2006-01-02"
@@ -66,13 +98,101 @@ const (
 	DefaultDateMinuteFormat = "01-02 3:04PM"
 	// DefaultFloatFormat is the default float format.
 	DefaultFloatFormat = "%.2f"
+	// DefaultFixedNotationMaxExponent is the magnitude (as a power of ten) from which tick labels switch to exponent notation.
+	DefaultFixedNotationMaxExponent = 15
+	// DefaultFixedNotationMinExponent is the tick step (as a power of ten) below which tick labels switch to exponent notation.
+	DefaultFixedNotationMinExponent = -6
 	// DefaultPercentValueFormat is the default percent format.
 	DefaultPercentValueFormat = "%0.2f%%"
 
+	// DefaultPieLeaderLength is the length of the radial part of the leader lines of outside pie labels.
+	DefaultPieLeaderLength = 12
+	// DefaultPieLeaderTail is the length of the horizontal part of the leader lines of outside pie labels.
+	DefaultPieLeaderTail = 10
+
+	// DefaultFunnelSegmentSpacing is the default space between the segments of funnel charts.
+	DefaultFunnelSegmentSpacing = 4
+
+	// DefaultCandleBodyWidth is the default width of candle bodies as a share of the smallest gap between candles.
+	DefaultCandleBodyWidth = 0.6
+
+	// DefaultScatterMarkerSize is the default radius of scatter series markers, i.e. half their width.
+	DefaultScatterMarkerSize = 4.0
+	// DefaultBubbleMinRadius is the default radius of the smallest bubbles of bubble series.
+	DefaultBubbleMinRadius = 4.0
+	// DefaultBubbleMaxRadius is the default radius of the largest bubbles of bubble series.
+	DefaultBubbleMaxRadius = 24.0
+
+	// DefaultBoxPlotWidth is the default width of box plot boxes as a share of the width of a category.
+	DefaultBoxPlotWidth = 0.6
+	// DefaultBoxPlotWhiskerRange is how many interquartile ranges past the box the whiskers of box plots
+	// summarized from samples reach; samples past them are outliers.
+	DefaultBoxPlotWhiskerRange = 1.5
+
+	// DefaultAnomalyZScore is the default number of standard deviations from the mean of the window before them
+	// past which values of anomaly series are anomalous.
+	DefaultAnomalyZScore = 3.0
+	// DefaultAnomalyMarkerSize is the default radius of the markers of anomalous values.
+	DefaultAnomalyMarkerSize = 5.0
+
+	// DefaultViolinWidth is the default width of violins at their widest as a share of the width of a category.
+	DefaultViolinWidth = 0.8
+	// DefaultViolinBoxWidth is the default width of the box plots inside violins as a share of the width of a category.
+	DefaultViolinBoxWidth = 0.08
+	// DefaultViolinResolution is the number of points the density of a violin is estimated at along its length.
+	DefaultViolinResolution = 64
+
+	// DefaultWatermarkFontSize is the default font size of watermarks.
+	DefaultWatermarkFontSize = 48.0
+	// DefaultWatermarkOpacity is the default opacity of the text of watermarks.
+	DefaultWatermarkOpacity = 0.15
+
+	// DefaultHistogramMaxBins is the most bins a histogram chart can have; fixed width bins that would be more are
+	// an error, and the other strategies fall back to fewer bins.
+	DefaultHistogramMaxBins = 10000
+	// DefaultRollingChartCapacity is the number of points each series of a rolling chart keeps.
+	DefaultRollingChartCapacity = 1000
+
+	// DefaultHeatMapLegendWidth is the width of the color legend bar of a heat map.
+	DefaultHeatMapLegendWidth = 16
+	// DefaultHeatMapLegendSteps is the number of bands the color legend bar of a heat map is drawn with.
+	DefaultHeatMapLegendSteps = 64
+
+	// DefaultTreeMapPadding is the space between the cells of a tree map and the cells of their children.
+	DefaultTreeMapPadding = 3
+	// DefaultTreeMapMinLabelSize is the width and height below which the cells of a tree map are not labeled.
+	DefaultTreeMapMinLabelSize = 24
+
+	// DefaultLegendSpacing is the spacing between a legend outside the canvas and the axes.
+	DefaultLegendSpacing = 10
+	// DefaultLegendLineLength is the length of the series lines in legends.
+	DefaultLegendLineLength = 25
+	// DefaultLegendLineTextGap is the gap between the labels and lines of legends.
+	DefaultLegendLineTextGap = 5
+
 	// DefaultBarSpacing is the default pixel spacing between bars.
 	DefaultBarSpacing = 100
 	// DefaultBarWidth is the default pixel width of bars in a bar chart.
 	DefaultBarWidth = 50
+	// DefaultBarGroupSpacing is the default pixel spacing between the bars of a category in a grouped bar chart.
+	DefaultBarGroupSpacing = 2
+
+	// DefaultTextMeasureCacheSize is the number of measurements kept by the default text measure cache.
+	DefaultTextMeasureCacheSize = 4096
+
+	// DefaultLineSeriesFastPathThreshold is the number of points at which line series switch to the
+	// high volume fast path (see `Draw.LineSeries`).
+	DefaultLineSeriesFastPathThreshold = 1 << 15
+
+	// DefaultMaxCanvasWidth is the default largest raster canvas width (see `CanvasLimits`).
+	DefaultMaxCanvasWidth = 1 << 14
+	// DefaultMaxCanvasHeight is the default largest raster canvas height (see `CanvasLimits`).
+	DefaultMaxCanvasHeight = 1 << 14
+	// DefaultMaxCanvasPixels is the default largest raster canvas pixel count, 256MB of pixel data (see `CanvasLimits`).
+	DefaultMaxCanvasPixels = 1 << 26
+
+	// DefaultAnimationFrameDelay is the default time each frame of an animated gif is shown for.
+	DefaultAnimationFrameDelay = 100 * time.Millisecond
 )
 
 var (
@@ -90,6 +210,9 @@ var (
 	// DefaultAnnotationPadding is the padding around an annotation.
 	DefaultAnnotationPadding = Box{Top: 5, Left: 5, Right: 5, Bottom: 5}
 
+	// DefaultEventFlagPadding is the padding around the label of an event flag.
+	DefaultEventFlagPadding = Box{Top: 3, Left: 4, Right: 4, Bottom: 3}
+
 	// DefaultBackgroundPadding is the default canvas padding config.
 	DefaultBackgroundPadding = Box{Top: 5, Left: 5, Right: 5, Bottom: 5}
 )
@@ -100,4 +223,13 @@ const (
 
 	// ContentTypeSVG is the svg mime type.
 	ContentTypeSVG = "image/svg+xml"
+
+	// ContentTypeGIF is the gif mime type.
+	ContentTypeGIF = "image/gif"
+
+	// ContentTypeWEBP is the webp mime type.
+	ContentTypeWEBP = "image/webp"
+
+	// ContentTypeJPEG is the jpeg mime type.
+	ContentTypeJPEG = "image/jpeg"
 )
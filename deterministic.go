@@ -0,0 +1,89 @@
+package chart
+
+import (
+	"image/png"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// DeterministicPNGCompression is the compression level `DeterministicPNG` renderers and golden files are encoded at.
+const DeterministicPNGCompression = png.DefaultCompression
+
+// DeterministicPNG returns a png/raster renderer whose output is byte for byte stable for a given chart,
+// suitable for golden file tests.
+// Series drawn concurrently (see `Chart.SeriesParallelism`) are recorded into layers of their own, which are
+// replayed in series order, so the output is the same as drawing them one after another. Text is measured by the
+// renderer rather than through `DefaultTextMeasureCache`, so the output doesn't depend on what was rendered before.
+func DeterministicPNG(width, height int) (Renderer, error) {
+	r, err := PNG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	r.(*rasterRenderer).deterministic = true
+	return r, nil
+}
+
+// DeterministicSVG returns a svg/vector renderer whose output is byte for byte stable for a given chart,
+// suitable for golden file tests.
+// Text is measured by the renderer rather than through `DefaultTextMeasureCache`, so the output doesn't depend on
+// what was rendered before.
+func DeterministicSVG(width, height int) (Renderer, error) {
+	r, err := SVG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	r.(*vectorRenderer).deterministic = true
+	return r, nil
+}
+
+// NormalizedPNG returns a deterministic png/raster renderer (see `DeterministicPNG`) that also draws all text
+// in the embedded font, whatever fonts the chart or the default font are, and writes no metadata, so its output
+// only depends on the chart and not on the fonts or environment of the machine rendering it.
+func NormalizedPNG(width, height int) (Renderer, error) {
+	font, err := GetEmbeddedFont()
+	if err != nil {
+		return nil, err
+	}
+	r, err := DeterministicPNG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	r.(*rasterRenderer).normalizedFont = font
+	return r, nil
+}
+
+// NormalizedSVG returns a deterministic svg/vector renderer (see `DeterministicSVG`) that also lays out and names
+// all text in the embedded font and writes no metadata, like `NormalizedPNG`.
+func NormalizedSVG(width, height int) (Renderer, error) {
+	font, err := GetEmbeddedFont()
+	if err != nil {
+		return nil, err
+	}
+	r, err := DeterministicSVG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	r.(*vectorRenderer).normalizedFont = font
+	return r, nil
+}
+
+// normalizedFontOr returns the font of a normalized renderer, or the font if it isn't normalized.
+func normalizedFontOr(normalized, f *truetype.Font) *truetype.Font {
+	if normalized != nil {
+		return normalized
+	}
+	return f
+}
+
+// deterministicRenderer is implemented by renderers that can be asked for stable output.
+type deterministicRenderer interface {
+	IsDeterministic() bool
+}
+
+// isDeterministic returns if a renderer was created by a deterministic provider.
+func isDeterministic(r Renderer) bool {
+	if typed, isTyped := r.(deterministicRenderer); isTyped {
+		return typed.IsDeterministic()
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func deterministicTestChart() Chart {
+	var series []Series
+	for s := 0; s < 3; s++ {
+		series = append(series, ContinuousSeries{
+			Name:    "series",
+			XValues: []float64{1, 2, 3, 4, 5},
+			YValues: []float64{float64(s), 2.5, float64(s * 2), 1, 3.25},
+		})
+	}
+	c := Chart{
+		Title:             "Deterministic",
+		TitleStyle:        StyleShow(),
+		XAxis:             XAxis{Style: StyleShow()},
+		YAxis:             YAxis{Style: StyleShow()},
+		Series:            series,
+		SeriesParallelism: 3,
+	}
+	c.Elements = []Renderable{Legend(&c)}
+	return c
+}
+
+func TestDeterministicRenderers(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, rp := range []RendererProvider{DeterministicPNG, DeterministicSVG} {
+		r, err := rp(10, 10)
+		assert.Nil(err)
+		assert.True(isDeterministic(r))
+
+		first := bytes.NewBuffer(nil)
+		assert.Nil(deterministicTestChart().Render(rp, first))
+		second := bytes.NewBuffer(nil)
+		assert.Nil(deterministicTestChart().Render(rp, second))
+		assert.Equal(first.String(), second.String())
+	}
+
+	r, err := PNG(10, 10)
+	assert.Nil(err)
+	assert.False(isDeterministic(r))
+}
+
+func TestDeterministicRenderersAfterPNG(t *testing.T) {
+	assert := assert.New(t)
+
+	DefaultTextMeasureCache.Reset()
+	first := bytes.NewBuffer(nil)
+	assert.Nil(deterministicTestChart().Render(DeterministicSVG, first))
+	assert.Zero(DefaultTextMeasureCache.Stats().Len)
+
+	// rendering a png in between fills the shared cache, which the deterministic renderers don't consult.
+	assert.Nil(deterministicTestChart().Render(PNG, bytes.NewBuffer(nil)))
+	stats := DefaultTextMeasureCache.Stats()
+	assert.NotZero(stats.Len)
+
+	second := bytes.NewBuffer(nil)
+	assert.Nil(deterministicTestChart().Render(DeterministicSVG, second))
+	assert.Equal(first.String(), second.String())
+	assert.Nil(deterministicTestChart().Render(DeterministicPNG, bytes.NewBuffer(nil)))
+	assert.Equal(stats, DefaultTextMeasureCache.Stats())
+}
+
+func TestDeterministicPNGIgnoresSeriesParallelism(t *testing.T) {
+	assert := assert.New(t)
+
+	parallel := bytes.NewBuffer(nil)
+	assert.Nil(deterministicTestChart().Render(DeterministicPNG, parallel))
+
+	c := deterministicTestChart()
+	c.SeriesParallelism = 0
+	sequential := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, sequential))
+
+	assert.Equal(sequential.String(), parallel.String())
+}
+
+func TestNormalizedRenderers(t *testing.T) {
+	assert := assert.New(t)
+
+	embedded, err := GetEmbeddedFont()
+	assert.Nil(err)
+
+	r, err := NormalizedPNG(10, 10)
+	assert.Nil(err)
+	assert.True(isDeterministic(r))
+	r.SetFont(nil)
+	assert.Equal(embedded, r.(*rasterRenderer).s.Font)
+
+	r, err = NormalizedSVG(10, 10)
+	assert.Nil(err)
+	r.SetFont(nil)
+	assert.Equal(embedded, r.(*vectorRenderer).s.Font)
+
+	c := deterministicTestChart()
+	c.Metadata = map[string]string{"Author": "charts"}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(NormalizedSVG, buffer))
+	assert.False(strings.Contains(buffer.String(), "<metadata"), buffer.String())
+	buffer.Reset()
+	assert.Nil(c.Render(DeterministicSVG, buffer))
+	assert.True(strings.Contains(buffer.String(), "<metadata"), buffer.String())
+}
@@ -14,15 +14,20 @@ var (
 type draw struct{}
 
 // LineSeries draws a line series with a renderer.
+// Series with at least `DefaultLineSeriesFastPathThreshold` points take a fast path that reads continuous
+// and time series values by index, collapses points sharing a pixel column and strokes the result in chunks;
+// at a million points this keeps the path handed to the rasterizer proportional to the canvas width.
+// Points with a NaN or infinite value are skipped. The line is drawn between points with the style's interpolation.
 func (d draw) LineSeries(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider) {
-	if vs.Len() == 0 {
+	first := firstFiniteIndex(vs)
+	if first < 0 {
 		return
 	}
 
 	cb := canvasBox.Bottom
 	cl := canvasBox.Left
 
-	v0x, v0y := vs.GetValues(0)
+	v0x, v0y := vs.GetValues(first)
 	x0 := cl + xrange.Translate(v0x)
 	y0 := cb - yrange.Translate(v0y)
 
@@ -31,40 +36,55 @@ func (d draw) LineSeries(r Renderer, canvasBox Box, xrange, yrange Range, style
 	var vx, vy float64
 	var x, y int
 
-	if style.ShouldDrawStroke() && style.ShouldDrawFill() {
-		style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
-		r.MoveTo(x0, y0)
-		for i := 1; i < vs.Len(); i++ {
-			vx, vy = vs.GetValues(i)
-			x = cl + xrange.Translate(vx)
-			y = cb - yrange.Translate(vy)
-			r.LineTo(x, y)
+	if vs.Len() >= DefaultLineSeriesFastPathThreshold {
+		d.lineSeriesFast(r, canvasBox, xrange, yrange, style, vs)
+	} else if style.GetInterpolation() != InterpolationLinear {
+		d.interpolatedLineSeries(r, canvasBox, xrange, yrange, style, vs)
+	} else {
+		if style.ShouldDrawStroke() && style.ShouldDrawFill() {
+			style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
+			r.MoveTo(x0, y0)
+			for i := first + 1; i < vs.Len(); i++ {
+				vx, vy = vs.GetValues(i)
+				if !isFinitePoint(vx, vy) {
+					continue
+				}
+				x = cl + xrange.Translate(vx)
+				y = cb - yrange.Translate(vy)
+				r.LineTo(x, y)
+			}
+			r.LineTo(x, util.Math.MinInt(cb, cb-yv0))
+			r.LineTo(x0, util.Math.MinInt(cb, cb-yv0))
+			r.LineTo(x0, y0)
+			r.Fill()
 		}
-		r.LineTo(x, util.Math.MinInt(cb, cb-yv0))
-		r.LineTo(x0, util.Math.MinInt(cb, cb-yv0))
-		r.LineTo(x0, y0)
-		r.Fill()
-	}
-
-	if style.ShouldDrawStroke() {
-		style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
 
-		r.MoveTo(x0, y0)
-		for i := 1; i < vs.Len(); i++ {
-			vx, vy = vs.GetValues(i)
-			x = cl + xrange.Translate(vx)
-			y = cb - yrange.Translate(vy)
-			r.LineTo(x, y)
+		if style.ShouldDrawStroke() {
+			style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+
+			r.MoveTo(x0, y0)
+			for i := first + 1; i < vs.Len(); i++ {
+				vx, vy = vs.GetValues(i)
+				if !isFinitePoint(vx, vy) {
+					continue
+				}
+				x = cl + xrange.Translate(vx)
+				y = cb - yrange.Translate(vy)
+				r.LineTo(x, y)
+			}
+			r.Stroke()
 		}
-		r.Stroke()
 	}
 
 	if style.ShouldDrawDot() {
 		defaultDotWidth := style.GetDotWidth()
 
 		style.GetDotOptions().WriteDrawingOptionsToRenderer(r)
-		for i := 0; i < vs.Len(); i++ {
+		for i := first; i < vs.Len(); i++ {
 			vx, vy = vs.GetValues(i)
+			if !isFinitePoint(vx, vy) {
+				continue
+			}
 			x = cl + xrange.Translate(vx)
 			y = cb - yrange.Translate(vy)
 
@@ -80,12 +100,130 @@ func (d draw) LineSeries(r Renderer, canvasBox Box, xrange, yrange Range, style
 				r.SetStrokeColor(dotColor)
 			}
 
+			setPointTooltip(r, vx, vy)
 			r.Circle(dotWidth, x, y)
 			r.FillStroke()
 		}
 	}
 }
 
+// StyledLineSeries draws a line series whose points are styled by a provider: the dot at a point and the segment
+// ending at it are drawn in the style the provider returns for the point, inherited from the series style.
+// Runs of segments with the same stroke are stroked as one path. The line is drawn with straight segments
+// and the fill, if any, in the series style.
+func (d draw) StyledLineSeries(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider, provider func(index int, x, y float64) Style) {
+	first := firstFiniteIndex(vs)
+	if first < 0 {
+		return
+	}
+
+	cb := canvasBox.Bottom
+	cl := canvasBox.Left
+
+	v0x, v0y := vs.GetValues(first)
+	x0 := cl + xrange.Translate(v0x)
+	y0 := cb - yrange.Translate(v0y)
+
+	var vx, vy float64
+	var x, y int
+
+	if style.ShouldDrawStroke() && style.ShouldDrawFill() {
+		yv0 := yrange.Translate(0)
+		style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(x0, y0)
+		for i := first + 1; i < vs.Len(); i++ {
+			vx, vy = vs.GetValues(i)
+			if !isFinitePoint(vx, vy) {
+				continue
+			}
+			x = cl + xrange.Translate(vx)
+			y = cb - yrange.Translate(vy)
+			r.LineTo(x, y)
+		}
+		r.LineTo(x, util.Math.MinInt(cb, cb-yv0))
+		r.LineTo(x0, util.Math.MinInt(cb, cb-yv0))
+		r.LineTo(x0, y0)
+		r.Fill()
+	}
+
+	var run Style
+	var inRun bool
+	px, py := x0, y0
+	for i := first + 1; i < vs.Len(); i++ {
+		vx, vy = vs.GetValues(i)
+		if !isFinitePoint(vx, vy) {
+			continue
+		}
+		x = cl + xrange.Translate(vx)
+		y = cb - yrange.Translate(vy)
+
+		segment := provider(i, vx, vy).InheritFrom(style)
+		if inRun && !isSameStroke(run, segment) {
+			r.Stroke()
+			inRun = false
+		}
+		if !inRun && segment.ShouldDrawStroke() {
+			run = segment
+			inRun = true
+			segment.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+			r.MoveTo(px, py)
+		}
+		if inRun {
+			r.LineTo(x, y)
+		}
+		px, py = x, y
+	}
+	if inRun {
+		r.Stroke()
+	}
+
+	for i := first; i < vs.Len(); i++ {
+		vx, vy = vs.GetValues(i)
+		if !isFinitePoint(vx, vy) {
+			continue
+		}
+		point := provider(i, vx, vy).InheritFrom(style)
+		if point.DotWidthProvider == nil {
+			point.DotWidthProvider = style.DotWidthProvider
+		}
+		if point.DotColorProvider == nil {
+			point.DotColorProvider = style.DotColorProvider
+		}
+		if !point.ShouldDrawDot() {
+			continue
+		}
+		x = cl + xrange.Translate(vx)
+		y = cb - yrange.Translate(vy)
+
+		dotWidth := point.GetDotWidth()
+		if point.DotWidthProvider != nil {
+			dotWidth = point.DotWidthProvider(xrange, yrange, i, vx, vy)
+		}
+		dot := point.GetDotOptions()
+		if point.DotColorProvider != nil {
+			dot.FillColor = point.DotColorProvider(xrange, yrange, i, vx, vy)
+			dot.StrokeColor = dot.FillColor
+		}
+		dot.WriteDrawingOptionsToRenderer(r)
+		setPointTooltip(r, vx, vy)
+		r.Circle(dotWidth, x, y)
+		r.FillStroke()
+	}
+}
+
+// isSameStroke returns if two styles stroke the same way.
+func isSameStroke(a, b Style) bool {
+	if !a.StrokeColor.Equals(b.StrokeColor) || a.StrokeWidth != b.StrokeWidth || len(a.StrokeDashArray) != len(b.StrokeDashArray) {
+		return false
+	}
+	for index := range a.StrokeDashArray {
+		if a.StrokeDashArray[index] != b.StrokeDashArray[index] {
+			return false
+		}
+	}
+	return true
+}
+
 // BoundedSeries draws a series that implements BoundedValuesProvider.
 func (d draw) BoundedSeries(r Renderer, canvasBox Box, xrange, yrange Range, style Style, bbs BoundedValuesProvider, drawOffsetIndexes ...int) {
 	drawOffsetIndex := 0
@@ -136,35 +274,94 @@ func (d draw) BoundedSeries(r Renderer, canvasBox Box, xrange, yrange Range, sty
 	r.FillStroke()
 }
 
+// Band fills the area between an upper and a lower line, and strokes the lines; non-finite values are skipped.
+func (d draw) Band(r Renderer, canvasBox Box, xrange, yrange Range, style Style, upper, lower ValuesProvider) {
+	upperPoints := bandPoints(canvasBox, xrange, yrange, upper)
+	lowerPoints := bandPoints(canvasBox, xrange, yrange, lower)
+	if len(upperPoints) == 0 || len(lowerPoints) == 0 {
+		return
+	}
+
+	if style.ShouldDrawFill() {
+		style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
+		outline := append([]Point(nil), upperPoints...)
+		for index := len(lowerPoints) - 1; index >= 0; index-- {
+			outline = append(outline, lowerPoints[index])
+		}
+		r.Polygon(outline)
+		r.Fill()
+	}
+
+	if style.ShouldDrawStroke() {
+		style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		for _, points := range [][]Point{upperPoints, lowerPoints} {
+			r.MoveTo(points[0].X, points[0].Y)
+			for _, p := range points[1:] {
+				r.LineTo(p.X, p.Y)
+			}
+			r.Stroke()
+		}
+	}
+}
+
+// bandPoints returns the canvas points of the finite values of a line.
+func bandPoints(canvasBox Box, xrange, yrange Range, vs ValuesProvider) []Point {
+	points := make([]Point, 0, vs.Len())
+	for index := 0; index < vs.Len(); index++ {
+		vx, vy := vs.GetValues(index)
+		if isFinitePoint(vx, vy) {
+			points = append(points, Point{X: canvasBox.Left + xrange.Translate(vx), Y: canvasBox.Bottom - yrange.Translate(vy)})
+		}
+	}
+	return points
+}
+
 // HistogramSeries draws a value provider as boxes from 0.
 func (d draw) HistogramSeries(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider, barWidths ...int) {
 	if vs.Len() == 0 {
 		return
 	}
 
-	//calculate bar width?
-	seriesLength := vs.Len()
-	barWidth := int(math.Floor(float64(xrange.GetDomain()) / float64(seriesLength)))
+	barWidth := histogramBarWidth(xrange, vs)
 	if len(barWidths) > 0 {
 		barWidth = barWidths[0]
 	}
+	d.histogramBars(r, canvasBox, xrange, yrange, style, vs, barWidth, nil)
+}
+
+// histogramBarWidth returns the width of histogram bars that split the domain of the x range evenly.
+func histogramBarWidth(xrange Range, vs ValuesProvider) int {
+	return int(math.Floor(float64(xrange.GetDomain()) / float64(vs.Len())))
+}
+
+// histogramBars draws a bar from zero to each value; the corners of the bars are rounded by the radii
+// `cornerRadii` returns for bars that extend up or down from zero, if it is set.
+func (d draw) histogramBars(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider, barWidth int, cornerRadii func(negative bool) [4]int) {
+	if vs.Len() == 0 {
+		return
+	}
 
 	cb := canvasBox.Bottom
 	cl := canvasBox.Left
 
 	//foreach datapoint, draw a box.
-	for index := 0; index < seriesLength; index++ {
+	for index := 0; index < vs.Len(); index++ {
 		vx, vy := vs.GetValues(index)
 		y0 := yrange.Translate(0)
 		x := cl + xrange.Translate(vx)
 		y := yrange.Translate(vy)
 
-		d.Box(r, Box{
+		box := Box{
 			Top:    cb - y0,
 			Left:   x - (barWidth >> 1),
 			Right:  x + (barWidth >> 1),
 			Bottom: cb - y,
-		}, style)
+		}
+		if cornerRadii == nil {
+			d.Box(r, box, style)
+			continue
+		}
+		d.RoundedBox(r, box, cornerRadii(y < y0), style)
 	}
 }
 
@@ -241,6 +438,91 @@ func (d draw) Annotation(r Renderer, canvasBox Box, style Style, lx, ly int, lab
 	r.Text(label, textX, textY)
 }
 
+// annotationLabelBox returns the box of an annotation label with its anchor at a point.
+func annotationLabelBox(r Renderer, style Style, x, y int, anchor AnnotationAnchor, label string) Box {
+	style.GetTextOptions().WriteToRenderer(r)
+	textBox := r.MeasureText(label)
+
+	width := style.Padding.GetLeft(DefaultAnnotationPadding.Left) + textBox.Width() + style.Padding.GetRight(DefaultAnnotationPadding.Right)
+	height := style.Padding.GetTop(DefaultAnnotationPadding.Top) + textBox.Height() + style.Padding.GetBottom(DefaultAnnotationPadding.Bottom)
+	return anchoredBox(x, y, width, height, anchor)
+}
+
+// anchoredBox returns the box of a size with its anchor at a point; an unset anchor is the middle of the left side.
+func anchoredBox(x, y, width, height int, anchor AnnotationAnchor) Box {
+	left, top := x, y-height>>1
+	switch anchor {
+	case AnnotationAnchorRight, AnnotationAnchorTopRight, AnnotationAnchorBottomRight:
+		left = x - width
+	case AnnotationAnchorTop, AnnotationAnchorBottom, AnnotationAnchorCenter:
+		left = x - width>>1
+	}
+	switch anchor {
+	case AnnotationAnchorTop, AnnotationAnchorTopLeft, AnnotationAnchorTopRight:
+		top = y
+	case AnnotationAnchorBottom, AnnotationAnchorBottomLeft, AnnotationAnchorBottomRight:
+		top = y - height
+	}
+	return Box{Top: top, Left: left, Right: left + width, Bottom: top + height}
+}
+
+// MeasureAnchoredAnnotation measures an annotation drawn with `AnchoredAnnotation`, including its arrow.
+func (d draw) MeasureAnchoredAnnotation(r Renderer, style Style, lx, ly int, a Value2) Box {
+	defer r.ResetStyle()
+
+	box := annotationLabelBox(r, style, lx+a.Offset.X, ly+a.Offset.Y, a.Anchor, a.Label)
+	if a.Arrow {
+		box = box.Grow(Box{Top: ly, Left: lx, Right: lx, Bottom: ly})
+	}
+	strokeWidth := int(math.Ceil(style.GetStrokeWidth() / 2))
+	return Box{Top: box.Top - strokeWidth, Left: box.Left - strokeWidth, Right: box.Right + strokeWidth, Bottom: box.Bottom + strokeWidth}
+}
+
+// AnchoredAnnotation draws the label of an annotation as a box with its anchor at the point (lx, ly) moved by its offset,
+// and an arrow from the anchor to the point if the annotation has one.
+func (d draw) AnchoredAnnotation(r Renderer, style Style, lx, ly int, a Value2) {
+	defer r.ResetStyle()
+
+	ax, ay := lx+a.Offset.X, ly+a.Offset.Y
+	box := annotationLabelBox(r, style, ax, ay, a.Anchor, a.Label)
+
+	if a.Arrow && (ax != lx || ay != ly) {
+		d.arrow(r, style, ax, ay, lx, ly)
+	}
+
+	style.GetFillAndStrokeOptions().WriteToRenderer(r)
+	r.Polygon([]Point{{X: box.Left, Y: box.Top}, {X: box.Right, Y: box.Top}, {X: box.Right, Y: box.Bottom}, {X: box.Left, Y: box.Bottom}})
+	r.FillStroke()
+
+	style.GetTextOptions().WriteToRenderer(r)
+	textBox := r.MeasureText(a.Label)
+	r.Text(a.Label, box.Left+style.Padding.GetLeft(DefaultAnnotationPadding.Left), box.Top+style.Padding.GetTop(DefaultAnnotationPadding.Top)+textBox.Height())
+}
+
+// arrow draws a line from (x0, y0) to (x1, y1) with an arrow head at (x1, y1), in the stroke color of the style.
+func (d draw) arrow(r Renderer, style Style, x0, y0, x1, y1 int) {
+	style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(x0, y0)
+	r.LineTo(x1, y1)
+	r.Stroke()
+
+	angle := math.Atan2(float64(y1-y0), float64(x1-x0))
+	size := float64(DefaultAnnotationArrowSize)
+	head := func(spread float64) (int, int) {
+		return x1 - int(math.Round(size*math.Cos(angle+spread))), y1 - int(math.Round(size*math.Sin(angle+spread)))
+	}
+	hx0, hy0 := head(math.Pi / 8)
+	hx1, hy1 := head(-math.Pi / 8)
+
+	r.SetFillColor(style.GetStrokeColor())
+	r.MoveTo(x1, y1)
+	r.LineTo(hx0, hy0)
+	r.LineTo(hx1, hy1)
+	r.LineTo(x1, y1)
+	r.Close()
+	r.FillStroke()
+}
+
 // Box draws a box with a given style.
 func (d draw) Box(r Renderer, b Box, s Style) {
 	s.GetFillAndStrokeOptions().WriteToRenderer(r)
@@ -254,6 +536,57 @@ func (d draw) Box(r Renderer, b Box, s Style) {
 	r.FillStroke()
 }
 
+// RoundedBox draws a box with rounded corners with a given style; the radii are of the top left, top right,
+// bottom right and bottom left corners. Like css border radii, they are scaled down together if the corners
+// of a side overlap. A box without rounded corners is drawn as by `Box`.
+func (d draw) RoundedBox(r Renderer, b Box, radii [4]int, s Style) {
+	if radii == [4]int{} {
+		d.Box(r, b, s)
+		return
+	}
+	s.GetFillAndStrokeOptions().WriteToRenderer(r)
+	defer r.ResetStyle()
+
+	left, top := util.Math.MinInt(b.Left, b.Right), util.Math.MinInt(b.Top, b.Bottom)
+	right, bottom := util.Math.MaxInt(b.Left, b.Right), util.Math.MaxInt(b.Top, b.Bottom)
+	width, height := float64(right-left), float64(bottom-top)
+	scale := 1.0
+	for index, side := range []float64{width, height, width, height} {
+		if sum := float64(radii[index] + radii[(index+1)%4]); sum > side {
+			scale = math.Min(scale, side/sum)
+		}
+	}
+	var rs [4]float64
+	for index, radius := range radii {
+		rs[index] = math.Max(float64(radius), 0) * scale
+	}
+
+	// the control points of a cubic curve are this share of the radius from the corner, to approximate a quarter circle.
+	const kappa = 1 - 0.5522847498
+	round := func(value float64) int { return int(math.Round(value)) }
+	fl, ft, fr, fb := float64(left), float64(top), float64(right), float64(bottom)
+
+	r.MoveTo(round(fl+rs[0]), top)
+	r.LineTo(round(fr-rs[1]), top)
+	if rs[1] > 0 {
+		r.CubicCurveTo(round(fr-rs[1]*kappa), top, right, round(ft+rs[1]*kappa), right, round(ft+rs[1]))
+	}
+	r.LineTo(right, round(fb-rs[2]))
+	if rs[2] > 0 {
+		r.CubicCurveTo(right, round(fb-rs[2]*kappa), round(fr-rs[2]*kappa), bottom, round(fr-rs[2]), bottom)
+	}
+	r.LineTo(round(fl+rs[3]), bottom)
+	if rs[3] > 0 {
+		r.CubicCurveTo(round(fl+rs[3]*kappa), bottom, left, round(fb-rs[3]*kappa), left, round(fb-rs[3]))
+	}
+	r.LineTo(left, round(ft+rs[0]))
+	if rs[0] > 0 {
+		r.CubicCurveTo(left, round(ft+rs[0]*kappa), round(fl+rs[0]*kappa), top, round(fl+rs[0]), top)
+	}
+	r.Close()
+	r.FillStroke()
+}
+
 func (d draw) BoxRotated(r Renderer, b Box, thetaDegrees float64, s Style) {
 	d.BoxCorners(r, b.Corners().Rotate(thetaDegrees), s)
 }
@@ -262,16 +595,45 @@ func (d draw) BoxCorners(r Renderer, bc BoxCorners, s Style) {
 	s.GetFillAndStrokeOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
 
-	r.MoveTo(bc.TopLeft.X, bc.TopLeft.Y)
-	r.LineTo(bc.TopRight.X, bc.TopRight.Y)
-	r.LineTo(bc.BottomRight.X, bc.BottomRight.Y)
-	r.LineTo(bc.BottomLeft.X, bc.BottomLeft.Y)
+	r.Polygon([]Point{bc.TopLeft, bc.TopRight, bc.BottomRight, bc.BottomLeft})
+	r.FillStroke()
+}
+
+// Sector draws a wedge of a circle with a given style, from the center out to a radius and clockwise from a start
+// angle by a delta, both in radians as for `Renderer.ArcTo`. Wedges of more than half a circle are drawn in pieces,
+// which svg arcs need.
+func (d draw) Sector(r Renderer, cx, cy int, radius, start, delta float64, s Style) {
+	s.GetFillAndStrokeOptions().WriteToRenderer(r)
+	defer r.ResetStyle()
+
+	r.MoveTo(cx, cy)
+	for delta > 0 {
+		piece := math.Min(delta, math.Pi)
+		r.ArcTo(cx, cy, radius, radius, start, piece)
+		start, delta = start+piece, delta-piece
+	}
+	r.LineTo(cx, cy)
+	r.Close()
+	r.FillStroke()
+}
+
+// Circle draws a circle with a given style from two arcs, which are rounder than `Renderer.Circle` for large radii.
+func (d draw) Circle(r Renderer, cx, cy int, radius float64, s Style) {
+	s.GetFillAndStrokeOptions().WriteToRenderer(r)
+	defer r.ResetStyle()
+
+	r.ArcTo(cx, cy, radius, radius, 0, math.Pi)
+	r.ArcTo(cx, cy, radius, radius, math.Pi, math.Pi)
 	r.Close()
 	r.FillStroke()
 }
 
 // DrawText draws text with a given style.
 func (d draw) Text(r Renderer, text string, x, y int, style Style) {
+	if style.TextWritingMode == TextWritingModeVertical {
+		d.verticalText(r, text, x, y, withTextAnchor(style, TextHorizontalAlignLeft, TextVerticalAlignBaseline))
+		return
+	}
 	style.GetTextOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
 
@@ -282,6 +644,10 @@ func (d draw) MeasureText(r Renderer, text string, style Style) Box {
 	style.GetTextOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
 
+	if style.TextWritingMode == TextWritingModeVertical {
+		_, _, box := measureVerticalText(r, text, style)
+		return box
+	}
 	return r.MeasureText(text)
 }
 
@@ -305,6 +671,22 @@ func (d draw) TextWithin(r Renderer, text string, box Box, style Style) {
 	var tx, ty int
 	for _, line := range lines {
 		lineBox := r.MeasureText(line)
+		if style.TextRotationDegrees == 0 {
+			// unrotated lines are anchored, so they stay aligned if the viewer lays them out with another font.
+			switch style.GetTextHorizontalAlign() {
+			case TextHorizontalAlignCenter:
+				tx = box.Left + box.Width()>>1
+			case TextHorizontalAlignRight:
+				tx = box.Right
+			default:
+				tx = box.Left
+			}
+			d.TextAnchored(r, line, tx, y+lineBox.Height(), withTextAnchor(style, style.GetTextHorizontalAlign(), TextVerticalAlignBaseline))
+			style.GetTextOptions().WriteToRenderer(r)
+			y += lineBox.Height() + style.GetTextLineSpacing()
+			continue
+		}
+
 		switch style.GetTextHorizontalAlign() {
 		case TextHorizontalAlignCenter:
 			tx = box.Left + ((box.Width() - lineBox.Width()) >> 1)
@@ -313,11 +695,7 @@ func (d draw) TextWithin(r Renderer, text string, box Box, style Style) {
 		default:
 			tx = box.Left
 		}
-		if style.TextRotationDegrees == 0 {
-			ty = y + lineBox.Height()
-		} else {
-			ty = y
-		}
+		ty = y
 
 		r.Text(line, tx, ty)
 		y += lineBox.Height() + style.GetTextLineSpacing()
@@ -0,0 +1,247 @@
+package chart
+
+import (
+	"math"
+	"sync"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// pointBufferPool holds the point buffers used by the line series fast path between renders.
+var pointBufferPool = sync.Pool{
+	New: func() interface{} {
+		return make([]Point, 0, 4096)
+	},
+}
+
+// valuesBatchSize is the number of values read at once by render loops using `GetValuesInto`.
+const valuesBatchSize = 256
+
+// lineSeriesFastChunkSize is the number of segments stroked at once by the fast path.
+const lineSeriesFastChunkSize = 256
+
+// rangeTranslator translates values for a range, inlining the math for continuous ranges
+// so the per point cost is not an interface call.
+type rangeTranslator struct {
+	r          Range
+	continuous bool
+	min        float64
+	delta      float64
+	domain     int
+	descending bool
+}
+
+func newRangeTranslator(r Range) rangeTranslator {
+	cr, isContinuous := r.(*ContinuousRange)
+	if tr, isTime := r.(*TimeRange); isTime {
+		cr, isContinuous = &tr.ContinuousRange, true
+	}
+	if !isContinuous {
+		return rangeTranslator{r: r}
+	}
+	return rangeTranslator{
+		r:          r,
+		continuous: true,
+		min:        cr.Min,
+		delta:      cr.GetDelta(),
+		domain:     cr.Domain,
+		descending: cr.IsDescending(),
+	}
+}
+
+// translate is equivalent to `Range.Translate`.
+func (rt rangeTranslator) translate(value float64) int {
+	if !rt.continuous {
+		return rt.r.Translate(value)
+	}
+	if rt.delta == 0 || math.IsNaN(rt.delta) || math.IsInf(rt.delta, 0) {
+		if rt.descending {
+			return rt.domain
+		}
+		return 0
+	}
+	ratio := (value - rt.min) / rt.delta
+	if rt.descending {
+		return rt.domain - int(math.Ceil(ratio*float64(rt.domain)))
+	}
+	return int(math.Ceil(ratio * float64(rt.domain)))
+}
+
+// linePoints translates the values of a line series into canvas points.
+//
+// Runs of consecutive points that land in the same pixel column are reduced to the first, lowest,
+// highest and last point of the run (in their original order), which draws the same line with at most
+// four segments per column. Values are read in batches (see `GetValuesInto`); non-finite values are skipped.
+func linePoints(buffer []Point, canvasBox Box, xrange, yrange Range, vs ValuesProvider) []Point {
+	xt, yt := newRangeTranslator(xrange), newRangeTranslator(yrange)
+	cb, cl := canvasBox.Bottom, canvasBox.Left
+
+	count := vs.Len()
+	points := buffer[:0]
+
+	var xvalues, yvalues [valuesBatchSize]float64
+	var batchStart, batchCount int
+
+	var column, first, low, high, last Point
+	var lowIndex, highIndex int
+	var started bool
+	var vx, vy float64
+	for i := 0; i < count; i++ {
+		if i == batchStart+batchCount {
+			batchStart = i
+			batchCount = GetValuesInto(vs, i, xvalues[:], yvalues[:])
+			if batchCount == 0 {
+				break
+			}
+		}
+		vx, vy = xvalues[i-batchStart], yvalues[i-batchStart]
+		if !isFinitePoint(vx, vy) {
+			continue
+		}
+		p := Point{X: cl + xt.translate(vx), Y: cb - yt.translate(vy)}
+
+		if started && p.X == column.X {
+			if p.Y < low.Y {
+				low, lowIndex = p, i
+			}
+			if p.Y > high.Y {
+				high, highIndex = p, i
+			}
+			last = p
+			continue
+		}
+		if started {
+			points = appendColumn(points, first, low, high, last, lowIndex, highIndex)
+		}
+		column, first, low, high, last = p, p, p, p, p
+		lowIndex, highIndex = i, i
+		started = true
+	}
+	if started {
+		points = appendColumn(points, first, low, high, last, lowIndex, highIndex)
+	}
+	return points
+}
+
+func appendColumn(points []Point, first, low, high, last Point, lowIndex, highIndex int) []Point {
+	points = appendDistinctPoint(points, first)
+	if lowIndex <= highIndex {
+		points = appendDistinctPoint(points, low)
+		points = appendDistinctPoint(points, high)
+	} else {
+		points = appendDistinctPoint(points, high)
+		points = appendDistinctPoint(points, low)
+	}
+	return appendDistinctPoint(points, last)
+}
+
+func appendDistinctPoint(points []Point, p Point) []Point {
+	if len(points) > 0 && points[len(points)-1] == p {
+		return points
+	}
+	return append(points, p)
+}
+
+// lineSeriesFast draws the stroke and fill of a line series from reduced points.
+func (d draw) lineSeriesFast(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider) {
+	buffer := pointBufferPool.Get().([]Point)
+	points := linePoints(buffer, canvasBox, xrange, yrange, vs)
+	defer pointBufferPool.Put(points[:0])
+
+	d.linePath(r, canvasBox, yrange, style, points)
+}
+
+// interpolatedLineSeries draws the stroke and fill of a line series through its points, with the style's interpolation.
+func (d draw) interpolatedLineSeries(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider) {
+	buffer := pointBufferPool.Get().([]Point)
+	points := buffer[:0]
+	cb, cl := canvasBox.Bottom, canvasBox.Left
+	for i := 0; i < vs.Len(); i++ {
+		vx, vy := vs.GetValues(i)
+		if isFinitePoint(vx, vy) {
+			points = append(points, Point{X: cl + xrange.Translate(vx), Y: cb - yrange.Translate(vy)})
+		}
+	}
+	defer pointBufferPool.Put(points[:0])
+
+	d.linePath(r, canvasBox, yrange, style, points)
+}
+
+// linePath draws the stroke and fill of a line through canvas points, with the style's interpolation,
+// in chunks of `lineSeriesFastChunkSize` segments; curved interpolations are drawn as cubic curves.
+func (d draw) linePath(r Renderer, canvasBox Box, yrange Range, style Style, points []Point) {
+	var segments []cubicSegment
+	if interpolation := style.GetInterpolation(); interpolation.isCurved() {
+		segments = curveSegments(points, interpolation)
+	} else if interpolation != InterpolationLinear {
+		buffer := pointBufferPool.Get().([]Point)
+		points = interpolatePoints(buffer, points, interpolation)
+		defer pointBufferPool.Put(points[:0])
+	}
+	if len(points) == 0 {
+		return
+	}
+
+	// traceLine adds the line from the point at `start` to the point at `end` to the path.
+	traceLine := func(start, end int) {
+		r.MoveTo(points[start].X, points[start].Y)
+		for index := start; index < end; index++ {
+			if segments != nil {
+				segments[index].curveTo(r)
+			} else {
+				r.LineTo(points[index+1].X, points[index+1].Y)
+			}
+		}
+	}
+
+	cb := canvasBox.Bottom
+	yv0 := yrange.Translate(0)
+
+	if style.ShouldDrawStroke() && style.ShouldDrawFill() {
+		// chunks of the area share vertical edges on pixel boundaries, so filling them separately leaves no seams.
+		style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
+		baseline := util.Math.MinInt(cb, cb-yv0)
+		// a gradient spans the whole area rather than each chunk.
+		setFillGradient(r, style, areaBounds(points, segments, baseline))
+		setFillPattern(r, style)
+		for start := 0; start < len(points)-1; start += lineSeriesFastChunkSize {
+			end := util.Math.MinInt(start+lineSeriesFastChunkSize, len(points)-1)
+			traceLine(start, end)
+			r.LineTo(points[end].X, baseline)
+			r.LineTo(points[start].X, baseline)
+			r.LineTo(points[start].X, points[start].Y)
+			r.Fill()
+		}
+	}
+
+	if style.ShouldDrawStroke() {
+		// the rasterizer searches the cells of a scanline linearly, so a single path that crosses
+		// the same scanlines thousands of times is quadratic; stroke it in short chunks instead.
+		style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		for start := 0; start < len(points)-1; start += lineSeriesFastChunkSize {
+			end := util.Math.MinInt(start+lineSeriesFastChunkSize, len(points)-1)
+			traceLine(start, end)
+			r.Stroke()
+		}
+	}
+}
+
+// areaBounds returns the bounds of the area between the points, or the curves through them, and a baseline;
+// a curve lies within its control points, so they bound it.
+func areaBounds(points []Point, segments []cubicSegment, baseline int) Box {
+	bounds := Box{Top: baseline, Left: points[0].X, Right: points[0].X, Bottom: baseline}
+	extend := func(p Point) {
+		bounds.Top = util.Math.MinInt(bounds.Top, p.Y)
+		bounds.Bottom = util.Math.MaxInt(bounds.Bottom, p.Y)
+		bounds.Left = util.Math.MinInt(bounds.Left, p.X)
+		bounds.Right = util.Math.MaxInt(bounds.Right, p.X)
+	}
+	for _, p := range points {
+		extend(p)
+	}
+	for _, segment := range segments {
+		extend(Point{X: int(math.Round(segment.C1X)), Y: int(math.Round(segment.C1Y))})
+		extend(Point{X: int(math.Round(segment.C2X)), Y: int(math.Round(segment.C2Y))})
+	}
+	return bounds
+}
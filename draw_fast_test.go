@@ -0,0 +1,98 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func millionPointSeries() ContinuousSeries {
+	xvalues := make([]float64, 1000000)
+	yvalues := make([]float64, 1000000)
+	for i := range xvalues {
+		xvalues[i] = float64(i)
+		yvalues[i] = math.Sin(float64(i)/1000.0) * float64(i%97)
+	}
+	return ContinuousSeries{XValues: xvalues, YValues: yvalues}
+}
+
+func TestRangeTranslator(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, r := range []Range{
+		&ContinuousRange{Min: -3, Max: 17, Domain: 311},
+		&ContinuousRange{Min: -3, Max: 17, Domain: 311, Descending: true},
+		&ContinuousRange{Min: 0, Max: 1, Domain: 1000},
+		// zero and infinite deltas map every value to the start of the range.
+		&ContinuousRange{Min: 5, Max: 5, Domain: 100},
+		&ContinuousRange{Min: 5, Max: 5, Domain: 100, Descending: true},
+		&ContinuousRange{Min: -math.MaxFloat64, Max: math.MaxFloat64, Domain: 100},
+	} {
+		rt := newRangeTranslator(r)
+		for v := -3.0; v < 17; v += 0.37 {
+			assert.Equal(r.Translate(v), rt.translate(v))
+		}
+	}
+}
+
+func TestLinePoints(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := ContinuousSeries{
+		XValues: []float64{0, 0.1, 0.2, 0.3, 5, 10},
+		YValues: []float64{5, 9, 1, 4, 5, 5},
+	}
+	xr := &ContinuousRange{Min: 0, Max: 10, Domain: 10}
+	yr := &ContinuousRange{Min: 0, Max: 10, Domain: 10}
+	canvasBox := Box{Top: 0, Left: 0, Right: 10, Bottom: 10}
+
+	points := linePoints(nil, canvasBox, xr, yr, cs)
+	// the first four points share a column; the highest value (lowest y) comes before the lowest value.
+	assert.Equal([]Point{{0, 5}, {1, 1}, {1, 9}, {1, 6}, {5, 5}, {10, 5}}, points)
+
+	gappy := ContinuousSeries{
+		XValues: []float64{math.NaN(), 0, 5, 10},
+		YValues: []float64{1, 5, math.Inf(-1), 5},
+	}
+	assert.Equal([]Point{{0, 5}, {10, 5}}, linePoints(nil, canvasBox, xr, yr, gappy))
+
+	large := millionPointSeries()
+	xr = &ContinuousRange{Min: 0, Max: 1000000, Domain: 1000}
+	yr = &ContinuousRange{Min: -100, Max: 100, Domain: 400}
+	points = linePoints(nil, Box{Right: 1000, Bottom: 400}, xr, yr, large)
+	assert.True(len(points) <= 4*1001, len(points))
+}
+
+func TestChartRenderMillionPoints(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{Series: []Series{millionPointSeries()}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func BenchmarkChartRenderMillionPoints(b *testing.B) {
+	c := Chart{Series: []Series{millionPointSeries()}}
+	buffer := bytes.NewBuffer(nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		if err := c.Render(PNG, buffer); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLinePointsMillionPoints(b *testing.B) {
+	cs := millionPointSeries()
+	xr := &ContinuousRange{Min: 0, Max: 1000000, Domain: 1000}
+	yr := &ContinuousRange{Min: -100, Max: 100, Domain: 400}
+	buffer := make([]Point, 0, 4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer = linePoints(buffer, Box{Right: 1000, Bottom: 400}, xr, yr, cs)
+	}
+}
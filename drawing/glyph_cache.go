@@ -0,0 +1,71 @@
+package drawing
+
+import (
+	"math"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// maxGlyphCacheSize is the number of glyphs the cache holds before it is cleared.
+const maxGlyphCacheSize = 1 << 16
+
+// glyphKey is a glyph of a font at a scale.
+type glyphKey struct {
+	font  *truetype.Font
+	scale fixed.Int26_6
+	index truetype.Index
+}
+
+// glyphMetrics are the bounds of the outline of a glyph and its advance width; glyphs without
+// an outline (i.e. spaces) have no bounds.
+type glyphMetrics struct {
+	left, top, right, bottom float64
+	hasOutline               bool
+	advance                  float64
+}
+
+// glyphCache holds the metrics of the glyphs measured by all graphic contexts, so measuring a string
+// doesn't load the outline of every glyph each time.
+var glyphCache = struct {
+	sync.RWMutex
+	metrics map[glyphKey]glyphMetrics
+}{metrics: map[glyphKey]glyphMetrics{}}
+
+// getGlyphMetrics returns the metrics of a glyph, loading it into the buffer if they aren't cached.
+func getGlyphMetrics(buffer *truetype.GlyphBuf, f *truetype.Font, scale fixed.Int26_6, index truetype.Index) (glyphMetrics, error) {
+	key := glyphKey{font: f, scale: scale, index: index}
+	glyphCache.RLock()
+	metrics, isCached := glyphCache.metrics[key]
+	glyphCache.RUnlock()
+	if isCached {
+		return metrics, nil
+	}
+
+	if err := buffer.Load(f, scale, index, font.HintingNone); err != nil {
+		return glyphMetrics{}, err
+	}
+	metrics = glyphMetrics{
+		left:    math.MaxFloat64,
+		top:     math.MaxFloat64,
+		right:   -math.MaxFloat64,
+		bottom:  -math.MaxFloat64,
+		advance: fUnitsToFloat64(f.HMetric(scale, index).AdvanceWidth),
+	}
+	for _, p := range buffer.Points {
+		x, y := pointToF64Point(p)
+		metrics.left, metrics.right = math.Min(metrics.left, x), math.Max(metrics.right, x)
+		metrics.top, metrics.bottom = math.Min(metrics.top, y), math.Max(metrics.bottom, y)
+		metrics.hasOutline = true
+	}
+
+	glyphCache.Lock()
+	if len(glyphCache.metrics) >= maxGlyphCacheSize {
+		glyphCache.metrics = map[glyphKey]glyphMetrics{}
+	}
+	glyphCache.metrics[key] = metrics
+	glyphCache.Unlock()
+	return metrics, nil
+}
@@ -0,0 +1,42 @@
+package drawing
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/roboto"
+)
+
+func TestGetStringBoundsGlyphCache(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := truetype.Parse(roboto.Roboto)
+	assert.Nil(err)
+	gc, err := NewRasterGraphicContext(image.NewRGBA(image.Rect(0, 0, 100, 100)))
+	assert.Nil(err)
+	gc.SetFont(f)
+	gc.SetFontSize(12)
+
+	left, top, right, bottom, err := gc.GetStringBounds("a b")
+	assert.Nil(err)
+
+	glyphCache.RLock()
+	_, isCached := glyphCache.metrics[glyphKey{font: f, scale: 12 * 96, index: f.Index('a')}]
+	space := glyphCache.metrics[glyphKey{font: f, scale: 12 * 96, index: f.Index(' ')}]
+	glyphCache.RUnlock()
+	assert.True(isCached)
+	// spaces have an advance but no outline.
+	assert.False(space.hasOutline)
+	assert.True(space.advance > 0)
+
+	// cached glyphs measure the same.
+	l2, t2, r2, b2, err := gc.GetStringBounds("a b")
+	assert.Nil(err)
+	assert.Equal(left, l2)
+	assert.Equal(top, t2)
+	assert.Equal(right, r2)
+	assert.Equal(bottom, b2)
+	assert.True(right > left && bottom > top)
+}
@@ -0,0 +1,92 @@
+package drawing
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/golang/freetype/raster"
+	"golang.org/x/image/draw"
+)
+
+// NewRecordingGraphicContext creates a graphic context that records what is drawn with it, rather than
+// drawing onto an image, so that drawings can be made concurrently and then painted in order with
+// `PaintRecorder.Replay`; replaying a recording paints exactly the pixels drawing onto the image directly would have.
+// Fills, strokes, text and image fills are recorded; images drawn with `DrawImage` or `ClearRect` are not.
+func NewRecordingGraphicContext(bounds image.Rectangle) (*RasterGraphicContext, *PaintRecorder) {
+	recorder := &PaintRecorder{}
+	return NewRasterGraphicContextWithPainter(discardImage{bounds}, recorder), recorder
+}
+
+// PaintRecorder is a painter that keeps the spans it is given to paint, with their color.
+type PaintRecorder struct {
+	color color.Color
+	ops   []paintOp
+}
+
+// paintOp is a rasterized shape: the batches of spans the rasterizer painted it with, and their color,
+// or for an image fill, the image the spans mask.
+type paintOp struct {
+	color   color.Color
+	image   image.Image
+	batches [][]raster.Span
+}
+
+// SetColor implements Painter; the next shape painted is recorded in the color.
+func (pr *PaintRecorder) SetColor(c color.Color) {
+	pr.color = c
+	pr.ops = append(pr.ops, paintOp{color: c})
+}
+
+// Paint implements raster.Painter; the rasterizer reuses its buffer, so the spans are copied.
+func (pr *PaintRecorder) Paint(ss []raster.Span, done bool) {
+	if len(pr.ops) == 0 {
+		pr.ops = append(pr.ops, paintOp{color: pr.color})
+	}
+	op := &pr.ops[len(pr.ops)-1]
+	op.batches = append(op.batches, append([]raster.Span(nil), ss...))
+}
+
+// paintImage records an image fill through the coverage of spans.
+func (pr *PaintRecorder) paintImage(spans []raster.Span, src image.Image) {
+	pr.ops = append(pr.ops, paintOp{image: src, batches: [][]raster.Span{spans}})
+}
+
+// Replay paints the recording onto an image, in the order it was drawn.
+func (pr *PaintRecorder) Replay(img *image.RGBA) {
+	painter := raster.NewRGBAPainter(img)
+	for _, op := range pr.ops {
+		if op.image != nil {
+			for _, spans := range op.batches {
+				drawSpansImage(img, spans, op.image)
+			}
+			continue
+		}
+		painter.SetColor(op.color)
+		for _, spans := range op.batches {
+			painter.Paint(spans, true)
+		}
+	}
+}
+
+// imagePainter is a painter that keeps image fills itself, rather than having them drawn onto the image.
+type imagePainter interface {
+	paintImage(spans []raster.Span, src image.Image)
+}
+
+// drawSpansImage composites an image onto another through the coverage of spans.
+func drawSpansImage(dst draw.Image, spans []raster.Span, src image.Image) {
+	for _, s := range spans {
+		mask := image.NewUniform(color.Alpha16{A: uint16(s.Alpha)})
+		draw.DrawMask(dst, image.Rect(s.X0, s.Y, s.X1, s.Y+1), src, image.Pt(s.X0, s.Y), mask, image.Point{}, draw.Over)
+	}
+}
+
+// discardImage is an image of a size that drops whatever is drawn onto it.
+type discardImage struct {
+	bounds image.Rectangle
+}
+
+func (di discardImage) ColorModel() color.Model     { return color.RGBAModel }
+func (di discardImage) Bounds() image.Rectangle     { return di.bounds }
+func (di discardImage) At(x, y int) color.Color     { return color.Transparent }
+func (di discardImage) Set(x, y int, c color.Color) {}
@@ -36,6 +36,7 @@ func NewRasterGraphicContextWithPainter(img draw.Image, painter Painter) *Raster
 		raster.NewRasterizer(width, height),
 		&truetype.GlyphBuf{},
 		DefaultDPI,
+		image.Rectangle{},
 	}
 }
 
@@ -48,6 +49,24 @@ type RasterGraphicContext struct {
 	strokeRasterizer *raster.Rasterizer
 	glyphBuf         *truetype.GlyphBuf
 	DPI              float64
+
+	// clip is the rectangle of pixels painting is clipped to; nothing is clipped if it is empty.
+	clip image.Rectangle
+}
+
+// Reset clears the graphic state stack and the rasterizers so the context
+// can be reused to draw a new image of the same size.
+func (rgc *RasterGraphicContext) Reset() {
+	rgc.StackGraphicContext = NewStackGraphicContext()
+	rgc.fillRasterizer.Clear()
+	rgc.strokeRasterizer.Clear()
+	rgc.DPI = DefaultDPI
+	rgc.clip = image.Rectangle{}
+}
+
+// SetClipRect clips what is painted afterwards to a rectangle of pixels; an empty rectangle clears the clip.
+func (rgc *RasterGraphicContext) SetClipRect(clip image.Rectangle) {
+	rgc.clip = clip
 }
 
 // SetDPI sets the screen resolution in dots per inch.
@@ -161,30 +180,26 @@ func (rgc *RasterGraphicContext) GetStringBounds(s string) (left, top, right, bo
 	left = math.MaxFloat64
 	top = math.MaxFloat64
 
+	scale := fixed.Int26_6(rgc.current.Scale)
 	cursor := 0.0
 	prev, hasPrev := truetype.Index(0), false
 	for _, rc := range s {
 		index := f.Index(rc)
 		if hasPrev {
-			cursor += fUnitsToFloat64(f.Kern(fixed.Int26_6(rgc.current.Scale), prev, index))
+			cursor += fUnitsToFloat64(f.Kern(scale, prev, index))
 		}
 
-		if err = rgc.glyphBuf.Load(rgc.current.Font, fixed.Int26_6(rgc.current.Scale), index, font.HintingNone); err != nil {
+		var metrics glyphMetrics
+		if metrics, err = getGlyphMetrics(rgc.glyphBuf, f, scale, index); err != nil {
 			return
 		}
-		e0 := 0
-		for _, e1 := range rgc.glyphBuf.Ends {
-			ps := rgc.glyphBuf.Points[e0:e1]
-			for _, p := range ps {
-				x, y := pointToF64Point(p)
-				top = math.Min(top, y)
-				bottom = math.Max(bottom, y)
-				left = math.Min(left, x+cursor)
-				right = math.Max(right, x+cursor)
-			}
-			e0 = e1
+		if metrics.hasOutline {
+			top = math.Min(top, metrics.top)
+			bottom = math.Max(bottom, metrics.bottom)
+			left = math.Min(left, metrics.left+cursor)
+			right = math.Max(right, metrics.right+cursor)
 		}
-		cursor += fUnitsToFloat64(f.HMetric(fixed.Int26_6(rgc.current.Scale), index).AdvanceWidth)
+		cursor += metrics.advance
 		prev, hasPrev = index, true
 	}
 	return
@@ -214,7 +229,11 @@ func (rgc *RasterGraphicContext) SetFontSize(fontSizePoints float64) {
 
 func (rgc *RasterGraphicContext) paint(rasterizer *raster.Rasterizer, color color.Color) {
 	rgc.painter.SetColor(color)
-	rasterizer.Rasterize(rgc.painter)
+	if rgc.clip.Empty() {
+		rasterizer.Rasterize(rgc.painter)
+	} else {
+		rasterizer.Rasterize(clipPainter{Painter: rgc.painter, clip: rgc.clip})
+	}
 	rasterizer.Clear()
 	rgc.current.Path.Clear()
 }
@@ -253,8 +272,111 @@ func (rgc *RasterGraphicContext) Fill(paths ...*Path) {
 	rgc.paint(rgc.fillRasterizer, rgc.current.FillColor)
 }
 
+// FillImage fills the paths like Fill, but with the pixels of an image rather than the fill color.
+// The image is made by `source` from the bounds of the pixels the fill covers, so it can fit itself to the shape.
+func (rgc *RasterGraphicContext) FillImage(source func(bounds image.Rectangle) image.Image, paths ...*Path) {
+	paths = append(paths, rgc.current.Path)
+	rgc.fillRasterizer.UseNonZeroWinding = rgc.current.FillRule == FillRuleWinding
+
+	flattener := Transformer{Tr: rgc.current.Tr, Flattener: FtLineBuilder{Adder: rgc.fillRasterizer}}
+	for _, p := range paths {
+		Flatten(p, flattener, rgc.current.Tr.GetScale())
+	}
+
+	rgc.paintImage(rgc.fillRasterizer, source)
+}
+
+// FillStrokeImage fills the paths like FillImage and then strokes them.
+func (rgc *RasterGraphicContext) FillStrokeImage(source func(bounds image.Rectangle) image.Image, paths ...*Path) {
+	rgc.fillStroke(paths)
+	rgc.paintImage(rgc.fillRasterizer, source)
+	rgc.paint(rgc.strokeRasterizer, rgc.current.StrokeColor)
+}
+
+// paintImage composites an image through the coverage of the rasterizer.
+func (rgc *RasterGraphicContext) paintImage(rasterizer *raster.Rasterizer, source func(bounds image.Rectangle) image.Image) {
+	var spans spanCollector
+	rasterizer.Rasterize(&spans)
+	rasterizer.Clear()
+	rgc.current.Path.Clear()
+	if !rgc.clip.Empty() {
+		spans = clipSpans(spans, rgc.clip)
+	}
+	if len(spans) == 0 {
+		return
+	}
+
+	src := source(spans.bounds())
+	if ip, isImagePainter := rgc.painter.(imagePainter); isImagePainter {
+		ip.paintImage(spans, src)
+		return
+	}
+	drawSpansImage(rgc.img, spans, src)
+}
+
+// spanCollector is a painter that keeps the spans it is given.
+type spanCollector []raster.Span
+
+// Paint implements raster.Painter; the rasterizer reuses its buffer, so the spans are copied.
+func (sc *spanCollector) Paint(ss []raster.Span, done bool) {
+	for _, s := range ss {
+		if s.X0 < s.X1 && s.Alpha > 0 {
+			*sc = append(*sc, s)
+		}
+	}
+}
+
+// clipPainter is a painter that paints only the parts of spans within a rectangle.
+type clipPainter struct {
+	raster.Painter
+	clip image.Rectangle
+}
+
+// Paint implements raster.Painter.
+func (cp clipPainter) Paint(ss []raster.Span, done bool) {
+	cp.Painter.Paint(clipSpans(ss, cp.clip), done)
+}
+
+// clipSpans returns the parts of spans within a rectangle, in the slice of the spans.
+func clipSpans(ss []raster.Span, clip image.Rectangle) []raster.Span {
+	clipped := ss[:0]
+	for _, s := range ss {
+		if s.Y < clip.Min.Y || s.Y >= clip.Max.Y {
+			continue
+		}
+		if s.X0 < clip.Min.X {
+			s.X0 = clip.Min.X
+		}
+		if s.X1 > clip.Max.X {
+			s.X1 = clip.Max.X
+		}
+		if s.X0 < s.X1 {
+			clipped = append(clipped, s)
+		}
+	}
+	return clipped
+}
+
+// bounds returns the rectangle of pixels the spans cover.
+func (sc spanCollector) bounds() (bounds image.Rectangle) {
+	for _, s := range sc {
+		bounds = bounds.Union(image.Rect(s.X0, s.Y, s.X1, s.Y+1))
+	}
+	return
+}
+
 // FillStroke first fills the paths and than strokes them
 func (rgc *RasterGraphicContext) FillStroke(paths ...*Path) {
+	rgc.fillStroke(paths)
+
+	// Fill
+	rgc.paint(rgc.fillRasterizer, rgc.current.FillColor)
+	// Stroke
+	rgc.paint(rgc.strokeRasterizer, rgc.current.StrokeColor)
+}
+
+// fillStroke flattens the paths into both the fill and stroke rasterizers.
+func (rgc *RasterGraphicContext) fillStroke(paths []*Path) {
 	paths = append(paths, rgc.current.Path)
 	rgc.fillRasterizer.UseNonZeroWinding = rgc.current.FillRule == FillRuleWinding
 	rgc.strokeRasterizer.UseNonZeroWinding = true
@@ -275,9 +397,4 @@ func (rgc *RasterGraphicContext) FillStroke(paths ...*Path) {
 	for _, p := range paths {
 		Flatten(p, demux, rgc.current.Tr.GetScale())
 	}
-
-	// Fill
-	rgc.paint(rgc.fillRasterizer, rgc.current.FillColor)
-	// Stroke
-	rgc.paint(rgc.strokeRasterizer, rgc.current.StrokeColor)
 }
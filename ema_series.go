@@ -1,6 +1,9 @@
 package chart
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 const (
 	// DefaultEMAPeriod is the default EMA period used in the sigma calculation.
@@ -12,8 +15,15 @@ type EMASeries struct {
 	Name  string
 	Style Style
 	YAxis YAxisType
-
-	Period      int
+	XAxis XAxisType
+
+	Period int
+	// Alpha is the smoothing factor, from 0 to 1, the weight of each new value; if set, it is used instead of
+	// the factor of the period.
+	Alpha float64
+	// HalfLife is the number of values after which the weight of a value has halved; if set, and alpha isn't,
+	// it is used instead of the period.
+	HalfLife    float64
 	InnerSeries ValuesProvider
 
 	cache []float64
@@ -34,6 +44,11 @@ func (ema EMASeries) GetYAxis() YAxisType {
 	return ema.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (ema EMASeries) GetXAxis() XAxisType {
+	return ema.XAxis
+}
+
 // GetPeriod returns the window size.
 func (ema EMASeries) GetPeriod() int {
 	if ema.Period == 0 {
@@ -47,8 +62,15 @@ func (ema EMASeries) Len() int {
 	return ema.InnerSeries.Len()
 }
 
-// GetSigma returns the smoothing factor for the serise.
+// GetSigma returns the smoothing factor for the serise: the alpha, or the factor of the half life,
+// or else of the period.
 func (ema EMASeries) GetSigma() float64 {
+	if ema.Alpha > 0 {
+		return math.Min(ema.Alpha, 1)
+	}
+	if ema.HalfLife > 0 {
+		return 1 - math.Pow(0.5, 1/ema.HalfLife)
+	}
 	return 2.0 / (float64(ema.GetPeriod()) + 1)
 }
 
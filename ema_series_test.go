@@ -104,3 +104,17 @@ func TestEMASeries(t *testing.T) {
 	assert.Equal(50.0, lvx)
 	assert.InDelta(lvy, emaExpected[49], emaDelta)
 }
+
+func TestEMASeriesGetSigma(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.InDelta(2.0/13.0, EMASeries{}.GetSigma(), 1e-9)
+	assert.Equal(0.3, EMASeries{Period: 10, Alpha: 0.3}.GetSigma())
+	assert.Equal(1.0, EMASeries{Alpha: 2}.GetSigma())
+	assert.InDelta(0.5, EMASeries{HalfLife: 1}.GetSigma(), 1e-9)
+
+	// after a half life of values, the weight of the first value has halved.
+	ema := &EMASeries{HalfLife: 4, InnerSeries: mockValuesProvider{X: []float64{0, 1, 2, 3, 4}, Y: []float64{1, 0, 0, 0, 0}}}
+	_, y := ema.GetLastValues()
+	assert.InDelta(0.5, y, 1e-9)
+}
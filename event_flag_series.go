@@ -0,0 +1,228 @@
+package chart
+
+import (
+	"math"
+	"sort"
+)
+
+// EventFlagPlacement is where the flags of an `EventFlagSeries` are drawn.
+type EventFlagPlacement int
+
+const (
+	// EventFlagPlacementTop draws the flags in a lane along the top of the canvas, with poles down its height.
+	EventFlagPlacementTop EventFlagPlacement = iota
+	// EventFlagPlacementBottom draws the flags in a lane along the bottom of the canvas, with poles up its height.
+	EventFlagPlacementBottom
+	// EventFlagPlacementValue draws each flag above its y value, or below it if there is no room above.
+	EventFlagPlacementValue
+)
+
+// eventFlagMaxRows is the number of rows flags are stacked into to keep them from overlapping.
+const eventFlagMaxRows = 8
+
+// EventFlag is an event marked by an `EventFlagSeries`, i.e. a deploy, an incident or a stock split.
+type EventFlag struct {
+	// XValue is the x value of the event; on a time axis it is `util.Time.ToFloat64` of the time.
+	XValue float64
+	// YValue is the value the flag is pinned to with `EventFlagPlacementValue`; it is ignored in lanes.
+	YValue float64
+	Label  string
+	// Style overrides the style of the series for the flag, i.e. a red incident among deploys.
+	Style Style
+}
+
+// EventFlagSeries draws small labeled flags pinned to x values, i.e. deploys and incidents on a metric chart, or
+// splits and dividends on a price chart. A flag is a box filled with the series color around its label, on a pole
+// at its x value; flags that would overlap are stacked into rows, away from the edge of the lane or the value.
+// Flags are laid out in order of their x values, and flipped to the left of their poles at the right of the canvas.
+type EventFlagSeries struct {
+	Name      string
+	Style     Style
+	XAxis     XAxisType
+	YAxis     YAxisType
+	Placement EventFlagPlacement
+
+	Flags []EventFlag
+}
+
+// GetName returns the name of the series.
+func (efs EventFlagSeries) GetName() string {
+	return efs.Name
+}
+
+// GetStyle returns the series style.
+func (efs EventFlagSeries) GetStyle() Style {
+	return efs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (efs EventFlagSeries) GetYAxis() YAxisType {
+	return efs.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (efs EventFlagSeries) GetXAxis() XAxisType {
+	return efs.XAxis
+}
+
+// GetBounds implements `BoundsProvider`; the x range includes the flags, and so does the y range for flags
+// pinned to values.
+func (efs EventFlagSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minX, maxX, minY, maxY = math.MaxFloat64, -math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64
+	for _, flag := range efs.Flags {
+		minX, maxX = math.Min(minX, flag.XValue), math.Max(maxX, flag.XValue)
+		if efs.Placement == EventFlagPlacementValue {
+			minY, maxY = math.Min(minY, flag.YValue), math.Max(maxY, flag.YValue)
+		}
+	}
+	return
+}
+
+// IsLegendExcluded implements `LegendExcluder`; event flags are never listed in legends.
+func (efs EventFlagSeries) IsLegendExcluded() bool {
+	return true
+}
+
+// IsClipped implements `ClipProvider`; flags are laid out within the canvas, and their boxes aren't clipped.
+func (efs EventFlagSeries) IsClipped() bool {
+	return false
+}
+
+// flagStyle returns the style of a flag: the pole and box are in the series color, and the label is white.
+func (efs EventFlagSeries) flagStyle(flag EventFlag, defaults Style) Style {
+	style := flag.Style.InheritFrom(efs.Style)
+	color := style.GetStrokeColor(defaults.StrokeColor)
+	return style.InheritFrom(Style{
+		StrokeColor: color,
+		StrokeWidth: 1,
+		FillColor:   color,
+		FontColor:   ColorWhite,
+		Font:        defaults.Font,
+		FontSize:    DefaultEventFlagFontSize,
+		Padding:     DefaultEventFlagPadding,
+	})
+}
+
+// eventFlagLayout is a flag placed on the canvas: the point its pole is pinned to and the box of its label.
+type eventFlagLayout struct {
+	flag  EventFlag
+	style Style
+	x, y  int
+	box   Box
+	// below is if a flag pinned to a value hangs below it.
+	below bool
+}
+
+// layoutFlags places the flags within the canvas in order of their x values; each flag goes in the first row
+// where it doesn't overlap the flags before it, or the row where it overlaps them the least.
+func (efs EventFlagSeries) layoutFlags(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) []eventFlagLayout {
+	defer r.ResetStyle()
+
+	flags := make([]EventFlag, 0, len(efs.Flags))
+	for _, flag := range efs.Flags {
+		if x := canvasBox.Left + xrange.Translate(flag.XValue); x >= canvasBox.Left && x <= canvasBox.Right {
+			flags = append(flags, flag)
+		}
+	}
+	sort.SliceStable(flags, func(i, j int) bool {
+		return flags[i].XValue < flags[j].XValue
+	})
+
+	laidOut := make([]eventFlagLayout, 0, len(flags))
+	placed := make([]Box, 0, len(flags))
+	for _, flag := range flags {
+		efl := eventFlagLayout{flag: flag, style: efs.flagStyle(flag, defaults)}
+		efl.x = canvasBox.Left + xrange.Translate(flag.XValue)
+		switch efs.Placement {
+		case EventFlagPlacementBottom:
+			efl.y = canvasBox.Bottom
+		case EventFlagPlacementValue:
+			efl.y = canvasBox.Bottom - yrange.Translate(flag.YValue)
+		default:
+			efl.y = canvasBox.Top
+		}
+
+		padding := efl.style.GetPadding()
+		tb := Draw.MeasureText(r, flag.Label, efl.style)
+		width, height := tb.Width()+padding.Left+padding.Right, tb.Height()+padding.Top+padding.Bottom
+		left := efl.x
+		if left+width > canvasBox.Right {
+			left = efl.x - width
+		}
+		// flags pinned to values hang below them if there is no room above.
+		efl.below = efs.Placement == EventFlagPlacementValue && efl.y-DefaultEventFlagPoleLength-height < canvasBox.Top
+
+		bestOverlap := math.MaxInt32
+		for row := 0; row < eventFlagMaxRows && bestOverlap > 0; row++ {
+			offset := row * (height + DefaultEventFlagSpacing)
+			var top int
+			switch {
+			case efs.Placement == EventFlagPlacementTop:
+				top = efl.y + offset
+			case efs.Placement == EventFlagPlacementBottom:
+				top = efl.y - height - offset
+			case efl.below:
+				top = efl.y + DefaultEventFlagPoleLength + offset
+			default:
+				top = efl.y - DefaultEventFlagPoleLength - height - offset
+			}
+			box := Box{Top: top, Left: left, Right: left + width, Bottom: top + height}
+			if overlap := overlapArea(box, placed); overlap < bestOverlap {
+				efl.box, bestOverlap = box, overlap
+			}
+		}
+		laidOut = append(laidOut, efl)
+		placed = append(placed, efl.box)
+	}
+	return laidOut
+}
+
+// Render draws the series; the poles are drawn first, so flags stacked over the poles of others cover them.
+func (efs EventFlagSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	flags := efs.layoutFlags(r, canvasBox, xrange, yrange, defaults)
+
+	for _, efl := range flags {
+		top, bottom := canvasBox.Top, canvasBox.Bottom
+		if efs.Placement == EventFlagPlacementValue {
+			top, bottom = efl.box.Top, efl.y
+			if efl.below {
+				top, bottom = efl.y, efl.box.Bottom
+			}
+		}
+		efl.style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(efl.x, top)
+		r.LineTo(efl.x, bottom)
+		r.Stroke()
+		r.ResetStyle()
+
+		if efs.Placement == EventFlagPlacementValue {
+			Draw.Circle(r, efl.x, efl.y, efl.style.GetDotWidth(), Style{FillColor: efl.style.GetStrokeColor(), StrokeColor: efl.style.GetStrokeColor(), StrokeWidth: 1})
+		}
+	}
+
+	for _, efl := range flags {
+		Draw.Box(r, efl.box, efl.style)
+		if efl.flag.Label != "" {
+			padding := efl.style.GetPadding()
+			textStyle := efl.style.GetTextOptions()
+			tb := Draw.MeasureText(r, efl.flag.Label, textStyle)
+			Draw.Text(r, efl.flag.Label, efl.box.Left+padding.Left, efl.box.Top+padding.Top+tb.Height(), textStyle)
+		}
+	}
+}
+
+// Validate validates the series.
+func (efs EventFlagSeries) Validate() error {
+	if len(efs.Flags) == 0 {
+		return newValidationError(ErrEmptySeries, "event flag series requires flags to be set and not empty")
+	}
+	for index, flag := range efs.Flags {
+		if math.IsNaN(flag.XValue) || math.IsInf(flag.XValue, 0) {
+			return newValidationError(ErrInvalidSeries, "event flag %d x value must be finite; got %v", index, flag.XValue)
+		}
+		if efs.Placement == EventFlagPlacementValue && (math.IsNaN(flag.YValue) || math.IsInf(flag.YValue, 0)) {
+			return newValidationError(ErrInvalidSeries, "event flag %d y value must be finite; got %v", index, flag.YValue)
+		}
+	}
+	return nil
+}
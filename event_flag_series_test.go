@@ -0,0 +1,81 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestEventFlagSeriesBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+			EventFlagSeries{Flags: []EventFlag{{XValue: 5, YValue: 100, Label: "deploy"}}},
+			EventFlagSeries{Placement: EventFlagPlacementValue, Flags: []EventFlag{{XValue: 0, YValue: 10, Label: "split"}}},
+		},
+	}
+	xrange, yrange, _ := c.getRanges()
+	assert.Equal(0.0, xrange.GetMin())
+	assert.Equal(5.0, xrange.GetMax())
+	// flags in lanes don't stretch the y range.
+	assert.Equal(1.0, yrange.GetMin())
+	assert.Equal(10.0, yrange.GetMax())
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func TestEventFlagSeriesLayout(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(400, 200)
+	assert.Nil(err)
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	canvasBox := Box{Top: 10, Left: 10, Right: 310, Bottom: 160}
+	xrange := &ContinuousRange{Min: 0, Max: 10, Domain: canvasBox.Width()}
+	yrange := &ContinuousRange{Min: 0, Max: 10, Domain: canvasBox.Height()}
+
+	efs := EventFlagSeries{Flags: []EventFlag{
+		{XValue: 9.9, Label: "last"},
+		{XValue: 1, Label: "first"},
+		{XValue: 1.1, Label: "second"},
+	}}
+	flags := efs.layoutFlags(r, canvasBox, xrange, yrange, Style{Font: f, StrokeColor: ColorBlue})
+	assert.Len(flags, 3)
+
+	// the flags are laid out in x order, and the second is stacked below the first it would overlap.
+	assert.Equal("first", flags[0].flag.Label)
+	assert.Equal(canvasBox.Top, flags[0].box.Top)
+	assert.Equal(flags[0].x, flags[0].box.Left)
+	assert.Equal("second", flags[1].flag.Label)
+	assert.Equal(flags[0].box.Bottom+DefaultEventFlagSpacing, flags[1].box.Top)
+	assert.Zero(overlapArea(flags[0].box, []Box{flags[1].box}))
+
+	// the last flag is flipped to the left of its pole to stay within the canvas.
+	assert.Equal("last", flags[2].flag.Label)
+	assert.Equal(canvasBox.Top, flags[2].box.Top)
+	assert.Equal(flags[2].x, flags[2].box.Right)
+
+	efs = EventFlagSeries{Placement: EventFlagPlacementValue, Flags: []EventFlag{{XValue: 5, YValue: 5, Label: "split"}, {XValue: 5, YValue: 10, Label: "dividend"}}}
+	flags = efs.layoutFlags(r, canvasBox, xrange, yrange, Style{Font: f})
+	assert.Equal(flags[0].y-DefaultEventFlagPoleLength, flags[0].box.Bottom)
+	// there is no room above the top of the canvas, so the flag hangs below its value.
+	assert.True(flags[1].below)
+	assert.Equal(canvasBox.Top+DefaultEventFlagPoleLength, flags[1].box.Top)
+}
+
+func TestEventFlagSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(EventFlagSeries{}.Validate())
+	assert.Nil(EventFlagSeries{Flags: []EventFlag{{XValue: 1, YValue: math.NaN()}}}.Validate())
+	assert.NotNil(EventFlagSeries{Flags: []EventFlag{{XValue: math.Inf(1)}}}.Validate())
+	assert.NotNil(EventFlagSeries{Placement: EventFlagPlacementValue, Flags: []EventFlag{{XValue: 1, YValue: math.NaN()}}}.Validate())
+}
@@ -0,0 +1,160 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// ExponentialRegressionSeries implements an exponential regression, of the
+// form `y = a*e^(bx)`, over a given inner series. Only positive inner values
+// are fit. If `Window` is set, each value is instead the regression over the
+// `Window` values leading up to it (a rolling regression).
+type ExponentialRegressionSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	Limit       int
+	Offset      int
+	Window      int
+	InnerSeries ValuesProvider
+
+	fit func(float64) float64
+}
+
+// GetName returns the name of the time series.
+func (ers ExponentialRegressionSeries) GetName() string {
+	return ers.Name
+}
+
+// GetStyle returns the line style.
+func (ers ExponentialRegressionSeries) GetStyle() Style {
+	return ers.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ers ExponentialRegressionSeries) GetYAxis() YAxisType {
+	return ers.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (ers ExponentialRegressionSeries) GetXAxis() XAxisType {
+	return ers.XAxis
+}
+
+// Len returns the number of elements in the series.
+func (ers ExponentialRegressionSeries) Len() int {
+	return util.Math.MinInt(ers.GetLimit(), ers.InnerSeries.Len()-ers.GetOffset())
+}
+
+// GetLimit returns the window size.
+func (ers ExponentialRegressionSeries) GetLimit() int {
+	if ers.Limit == 0 {
+		return ers.InnerSeries.Len()
+	}
+	return ers.Limit
+}
+
+// GetEndIndex returns the effective limit end.
+func (ers ExponentialRegressionSeries) GetEndIndex() int {
+	windowEnd := ers.GetOffset() + ers.GetLimit()
+	innerSeriesLastIndex := ers.InnerSeries.Len() - 1
+	return util.Math.MinInt(windowEnd, innerSeriesLastIndex)
+}
+
+// GetOffset returns the data offset.
+func (ers ExponentialRegressionSeries) GetOffset() int {
+	if ers.Offset == 0 {
+		return 0
+	}
+	return ers.Offset
+}
+
+// Validate validates the series.
+func (ers *ExponentialRegressionSeries) Validate() error {
+	if ers.InnerSeries == nil {
+		return fmt.Errorf("exponential regression series requires InnerSeries to be set")
+	}
+	if ers.Window < 0 {
+		return fmt.Errorf("exponential regression series window cannot be negative")
+	}
+	if ers.Window == 0 {
+		if _, err := ers.getFit(); err != nil {
+			return &ValidationError{
+				Kind:        ErrInvalidSeries,
+				Message:     fmt.Sprintf("exponential regression series can't be fit to its inner series: %v", err),
+				SeriesIndex: -1,
+				Cause:       err,
+			}
+		}
+	}
+	return nil
+}
+
+// GetValues returns the series value for a given index.
+func (ers *ExponentialRegressionSeries) GetValues(index int) (x, y float64) {
+	if ers.InnerSeries == nil || ers.InnerSeries.Len() == 0 {
+		return
+	}
+	offset := ers.GetOffset()
+	effectiveIndex := util.Math.MinInt(index+offset, ers.InnerSeries.Len())
+	return ers.valueAt(effectiveIndex)
+}
+
+// GetLastValues computes the last exponential regression value.
+func (ers *ExponentialRegressionSeries) GetLastValues() (x, y float64) {
+	if ers.InnerSeries == nil || ers.InnerSeries.Len() == 0 {
+		return
+	}
+	return ers.valueAt(ers.GetEndIndex())
+}
+
+// RSquared returns the coefficient of determination of the regression
+// against the inner series values it was fit to.
+func (ers *ExponentialRegressionSeries) RSquared() float64 {
+	if ers.InnerSeries == nil || ers.InnerSeries.Len() == 0 {
+		return 0
+	}
+	return rSquared(ers.InnerSeries, ers.GetOffset(), ers.GetEndIndex(), ers.valueAt)
+}
+
+// valueAt returns the regression value for an index of the inner series.
+func (ers *ExponentialRegressionSeries) valueAt(innerIndex int) (x, y float64) {
+	if ers.Window > 0 {
+		return rollingRegressionValues(ers.InnerSeries, ers.GetOffset(), innerIndex, ers.Window, exponentialFit)
+	}
+	x, y = ers.InnerSeries.GetValues(innerIndex)
+	fit, err := ers.getFit()
+	if err != nil {
+		return x, math.NaN()
+	}
+	y = fit(x)
+	return
+}
+
+// getFit returns the regression over the values of the inner series, fitting it once; it fails if there are too
+// few positive values to fit.
+func (ers *ExponentialRegressionSeries) getFit() (func(float64) float64, error) {
+	if ers.fit == nil {
+		fit, err := exponentialFit(regressionValues(ers.InnerSeries, ers.GetOffset(), ers.GetEndIndex()))
+		if err != nil {
+			return nil, err
+		}
+		ers.fit = fit
+	}
+	return ers.fit, nil
+}
+
+// Render renders the series; a series that can't be fit (see `Validate`) isn't drawn.
+func (ers *ExponentialRegressionSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	if ers.Window == 0 {
+		if _, err := ers.getFit(); err != nil {
+			return
+		}
+	}
+	style := ers.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, ers)
+}
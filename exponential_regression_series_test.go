@@ -0,0 +1,86 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/seq"
+)
+
+func TestExponentialRegressionSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	xvalues := seq.Range(0, 20)
+	yvalues := make([]float64, len(xvalues))
+	for index, x := range xvalues {
+		yvalues[index] = 3 * math.Exp(0.25*x)
+	}
+
+	expSeries := &ExponentialRegressionSeries{
+		InnerSeries: ContinuousSeries{XValues: xvalues, YValues: yvalues},
+	}
+	assert.Nil(expSeries.Validate())
+
+	for index := 0; index < expSeries.Len(); index++ {
+		_, y := expSeries.GetValues(index)
+		assert.InDelta(yvalues[index], y, 0.000001)
+	}
+	assert.InDelta(1.0, expSeries.RSquared(), 0.000001)
+}
+
+func TestExponentialRegressionSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil((&ExponentialRegressionSeries{}).Validate())
+
+	expSeries := &ExponentialRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3},
+			YValues: []float64{-1, 0, -2},
+		},
+	}
+	assert.NotNil(expSeries.Validate())
+}
+
+func TestExponentialRegressionSeriesNonPositive(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{-1, 0, -2}}
+	expSeries := &ExponentialRegressionSeries{InnerSeries: inner}
+	assert.True(errors.Is(expSeries.Validate(), ErrInvalidSeries))
+
+	// a series that can't be fit has no values, and draws nothing rather than panicking.
+	_, y := expSeries.GetValues(1)
+	assert.True(math.IsNaN(y))
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+	canvasBox := Box{Right: 100, Bottom: 100}
+	expSeries.Render(r, canvasBox, &ContinuousRange{Min: 0, Max: 4, Domain: 100}, &ContinuousRange{Min: -2, Max: 2, Domain: 100}, Style{StrokeColor: ColorBlack, StrokeWidth: 1})
+
+	c := Chart{Series: []Series{inner, &ExponentialRegressionSeries{InnerSeries: inner}}}
+	assert.True(errors.Is(c.Validate(), ErrInvalidSeries))
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestExponentialRegressionSeriesWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	expSeries := &ExponentialRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4, 5, 6},
+			YValues: []float64{2, 4, 8, 3, 9, 27},
+		},
+		Window: 3,
+	}
+	assert.Nil(expSeries.Validate())
+
+	_, y0 := expSeries.GetValues(0)
+	assert.InDelta(2.0, y0, 0.000001)
+	_, y2 := expSeries.GetValues(2)
+	assert.InDelta(8.0, y2, 0.000001)
+	_, y5 := expSeries.GetValues(5)
+	assert.InDelta(27.0, y5, 0.000001)
+}
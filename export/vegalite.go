@@ -0,0 +1,651 @@
+// Package export converts charts to and from the chart formats of other tools.
+//
+// `VegaLite` writes a chart as a Vega-Lite (https://vega.github.io/vega-lite/) json spec, so the same chart
+// definition can be handed to a web frontend while the server keeps rendering it with `chart.Render` as a fallback:
+//
+//	spec, err := export.VegaLite(c)
+//
+// `FromVegaLite` reads the subset of Vega-Lite that maps onto a chart back into a `chart.Chart`.
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	chart "github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// VegaLiteSchema is the schema of the specs written by `VegaLite`.
+const VegaLiteSchema = "https://vega.github.io/schema/vega-lite/v5.json"
+
+// ErrUnsupported is the kind of error for a chart or a spec that uses something the other format can't express.
+var ErrUnsupported = errors.New("unsupported")
+
+// The fields of the data rows written by `VegaLite`.
+const (
+	fieldSeries = "series"
+	fieldX      = "x"
+	fieldY      = "y"
+	fieldY2     = "y2"
+	fieldLabel  = "label"
+)
+
+// vegaLiteTimeFormat is the format of temporal values; browsers parse it with `Date.parse`.
+const vegaLiteTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+type vlSpec struct {
+	Schema    string        `json:"$schema,omitempty"`
+	Title     *vlTitle      `json:"title,omitempty"`
+	Width     int           `json:"width,omitempty"`
+	Height    int           `json:"height,omitempty"`
+	Data      *vlData       `json:"data,omitempty"`
+	Transform []vlTransform `json:"transform,omitempty"`
+	Mark      *vlMark       `json:"mark,omitempty"`
+	Encoding  *vlEncoding   `json:"encoding,omitempty"`
+	Layer     []vlSpec      `json:"layer,omitempty"`
+}
+
+// vlTitle is a title, which is either a string or an object with the text.
+type vlTitle struct {
+	Text string `json:"text"`
+}
+
+func (t vlTitle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Text)
+}
+
+func (t *vlTitle) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Text); err == nil {
+		return nil
+	}
+	type title vlTitle
+	return json.Unmarshal(data, (*title)(t))
+}
+
+type vlData struct {
+	URL    string                   `json:"url,omitempty"`
+	Values []map[string]interface{} `json:"values"`
+}
+
+type vlTransform struct {
+	Filter *vlFilter `json:"filter,omitempty"`
+}
+
+type vlFilter struct {
+	Field string      `json:"field"`
+	Equal interface{} `json:"equal"`
+}
+
+// vlMark is a mark, which is either a string with the type or an object with the type and its properties.
+type vlMark struct {
+	Type        string    `json:"type"`
+	Color       string    `json:"color,omitempty"`
+	Opacity     float64   `json:"opacity,omitempty"`
+	StrokeWidth float64   `json:"strokeWidth,omitempty"`
+	StrokeDash  []float64 `json:"strokeDash,omitempty"`
+	Point       bool      `json:"point,omitempty"`
+}
+
+func (m *vlMark) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.Type); err == nil {
+		return nil
+	}
+	type mark vlMark
+	return json.Unmarshal(data, (*mark)(m))
+}
+
+type vlEncoding struct {
+	X     *vlChannel `json:"x,omitempty"`
+	Y     *vlChannel `json:"y,omitempty"`
+	Y2    *vlChannel `json:"y2,omitempty"`
+	Color *vlChannel `json:"color,omitempty"`
+	Text  *vlChannel `json:"text,omitempty"`
+}
+
+type vlChannel struct {
+	Field  string          `json:"field,omitempty"`
+	Type   string          `json:"type,omitempty"`
+	Datum  interface{}     `json:"datum,omitempty"`
+	Title  string          `json:"title,omitempty"`
+	Scale  *vlScale        `json:"scale,omitempty"`
+	Legend json.RawMessage `json:"legend,omitempty"`
+}
+
+type vlScale struct {
+	Domain  []interface{} `json:"domain,omitempty"`
+	Range   []string      `json:"range,omitempty"`
+	Reverse bool          `json:"reverse,omitempty"`
+	Zero    *bool         `json:"zero,omitempty"`
+}
+
+type vlLegend struct {
+	Orient string `json:"orient,omitempty"`
+}
+
+// VegaLite returns a chart as a Vega-Lite spec with its values inline and a layer for each shown series.
+//
+// Series that provide values are written as lines (or points, if they draw only dots), band series as areas and
+// annotation series as text; other series return an `ErrUnsupported` error. The axes keep their names, set ranges and
+// direction, and the series keep their colors, stroke widths and dash arrays. Vega-Lite lays out and styles
+// everything else itself, and series on the secondary axes are drawn against the primary axes.
+func VegaLite(c chart.Chart) ([]byte, error) {
+	spec := vlSpec{
+		Schema: VegaLiteSchema,
+		Width:  c.GetWidth(),
+		Height: c.GetHeight(),
+		Data:   &vlData{Values: []map[string]interface{}{}},
+	}
+	if c.Title != "" {
+		spec.Title = &vlTitle{Text: c.Title}
+	}
+
+	temporal := isTemporal(c)
+	xValue := func(x float64) interface{} {
+		if temporal {
+			return util.Time.FromFloat64(x).UTC().Format(vegaLiteTimeFormat)
+		}
+		return x
+	}
+
+	var names []string
+	var colors []string
+	cp := c.GetColorPalette()
+	for index, s := range c.Series {
+		style := s.GetStyle()
+		if !style.IsZero() && !style.Show {
+			continue
+		}
+		name := s.GetName()
+		if name == "" {
+			name = fmt.Sprintf("series %d", index)
+		}
+		color := style.GetStrokeColor(cp.GetSeriesColor(index))
+
+		layer := vlSpec{
+			Transform: []vlTransform{{Filter: &vlFilter{Field: fieldSeries, Equal: name}}},
+			Encoding: &vlEncoding{
+				X: newAxisChannel(fieldX, c.XAxis.Name, c.XAxis.Range, c.XAxis.Descending, temporal),
+				Y: newAxisChannel(fieldY, c.YAxis.Name, c.YAxis.Range, c.YAxis.Descending, false),
+			},
+		}
+		switch typed := s.(type) {
+		case chart.AnnotationSeries:
+			layer.Mark = &vlMark{Type: "text"}
+			layer.Mark.Color, layer.Mark.Opacity = vegaLiteColor(style.GetFontColor(chart.DefaultTextColor))
+			layer.Encoding.Text = &vlChannel{Field: fieldLabel, Type: "nominal"}
+			for _, a := range typed.Annotations {
+				spec.Data.Values = append(spec.Data.Values, map[string]interface{}{
+					fieldSeries: name, fieldX: xValue(a.XValue), fieldY: a.YValue, fieldLabel: a.Label,
+				})
+			}
+			spec.Layer = append(spec.Layer, layer)
+			continue
+		case chart.BandSeries:
+			if typed.Upper == nil || typed.Lower == nil {
+				return nil, fmt.Errorf("series %d: band series must have upper and lower values", index)
+			}
+			layer.Mark = &vlMark{Type: "area"}
+			layer.Mark.Color, layer.Mark.Opacity = vegaLiteColor(style.GetFillColor(color.WithAlpha(64)))
+			layer.Encoding.Y2 = &vlChannel{Field: fieldY2}
+			for i := 0; i < typed.Lower.Len() && i < typed.Upper.Len(); i++ {
+				x, lower := typed.Lower.GetValues(i)
+				_, upper := typed.Upper.GetValues(i)
+				spec.Data.Values = append(spec.Data.Values, map[string]interface{}{
+					fieldSeries: name, fieldX: xValue(x), fieldY: lower, fieldY2: upper,
+				})
+			}
+			spec.Layer = append(spec.Layer, layer)
+			continue
+		case chart.ValuesProvider:
+			layer.Mark = newLineMark(style)
+			for i := 0; i < typed.Len(); i++ {
+				x, y := typed.GetValues(i)
+				spec.Data.Values = append(spec.Data.Values, map[string]interface{}{
+					fieldSeries: name, fieldX: xValue(x), fieldY: y,
+				})
+			}
+		default:
+			return nil, fmt.Errorf("%w: series %d: cannot export %T", ErrUnsupported, index, s)
+		}
+
+		// lines are colored by series through one shared scale, which also draws the legend.
+		hex, _ := vegaLiteColor(color)
+		names = append(names, name)
+		colors = append(colors, hex)
+		spec.Layer = append(spec.Layer, layer)
+	}
+	if len(spec.Layer) == 0 {
+		return nil, errors.New("please provide at least one shown series")
+	}
+
+	if len(names) > 0 {
+		domain := make([]interface{}, len(names))
+		for index, name := range names {
+			domain[index] = name
+		}
+		legend := json.RawMessage("null")
+		if c.Legend.Style.Show {
+			legend, _ = json.Marshal(vlLegend{Orient: vegaLiteLegendOrient(c.Legend.Placement)})
+		}
+		for index := range spec.Layer {
+			if spec.Layer[index].Mark.Type == "line" || spec.Layer[index].Mark.Type == "point" {
+				spec.Layer[index].Encoding.Color = &vlChannel{
+					Field:  fieldSeries,
+					Type:   "nominal",
+					Scale:  &vlScale{Domain: domain, Range: colors},
+					Legend: legend,
+				}
+			}
+		}
+	}
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// isTemporal returns if the x axis of a chart is a time axis.
+func isTemporal(c chart.Chart) bool {
+	if _, isTimeRange := c.XAxis.Range.(*chart.TimeRange); isTimeRange {
+		return true
+	}
+	for _, s := range c.Series {
+		if _, isTimeSeries := s.(chart.TimeSeries); isTimeSeries {
+			return true
+		}
+	}
+	return false
+}
+
+func newAxisChannel(field, title string, ra chart.Range, descending, temporal bool) *vlChannel {
+	noZero := false
+	channel := &vlChannel{
+		Field: field,
+		Type:  "quantitative",
+		Title: title,
+		Scale: &vlScale{Reverse: descending, Zero: &noZero},
+	}
+	if temporal {
+		channel.Type = "temporal"
+		channel.Scale.Zero = nil
+	}
+	if ra != nil && !ra.IsZero() {
+		channel.Scale.Reverse = channel.Scale.Reverse || ra.IsDescending()
+		if temporal {
+			channel.Scale.Domain = []interface{}{
+				util.Time.FromFloat64(ra.GetMin()).UTC().Format(vegaLiteTimeFormat),
+				util.Time.FromFloat64(ra.GetMax()).UTC().Format(vegaLiteTimeFormat),
+			}
+		} else {
+			channel.Scale.Domain = []interface{}{ra.GetMin(), ra.GetMax()}
+		}
+	}
+	return channel
+}
+
+func newLineMark(style chart.Style) *vlMark {
+	if style.StrokeWidth == chart.Disabled || (style.StrokeColor.IsTransparent() && !style.StrokeColor.IsZero()) {
+		return &vlMark{Type: "point"}
+	}
+	return &vlMark{
+		Type:        "line",
+		StrokeWidth: style.StrokeWidth,
+		StrokeDash:  style.StrokeDashArray,
+		Point:       style.DotWidth > 0,
+	}
+}
+
+// vegaLiteColor returns a color as a css hex code and an opacity, which is zero if the color is opaque.
+func vegaLiteColor(c drawing.Color) (string, float64) {
+	hex := fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	if c.A == 255 {
+		return hex, 0
+	}
+	return hex, float64(c.A) / 255
+}
+
+func vegaLiteLegendOrient(lp chart.LegendPlacement) string {
+	switch lp {
+	case chart.LegendPlacementInsideTopRight:
+		return "top-right"
+	case chart.LegendPlacementInsideBottomLeft:
+		return "bottom-left"
+	case chart.LegendPlacementInsideBottomRight:
+		return "bottom-right"
+	case chart.LegendPlacementTop:
+		return "top"
+	case chart.LegendPlacementBottom:
+		return "bottom"
+	case chart.LegendPlacementLeft:
+		return "left"
+	case chart.LegendPlacementRight:
+		return "right"
+	}
+	return "top-left"
+}
+
+func legendPlacement(orient string) chart.LegendPlacement {
+	for _, lp := range []chart.LegendPlacement{
+		chart.LegendPlacementInsideTopRight, chart.LegendPlacementInsideBottomLeft, chart.LegendPlacementInsideBottomRight,
+		chart.LegendPlacementTop, chart.LegendPlacementBottom, chart.LegendPlacementLeft, chart.LegendPlacementRight,
+	} {
+		if vegaLiteLegendOrient(lp) == orient {
+			return lp
+		}
+	}
+	if orient == "" {
+		// vega-lite draws legends to the right by default.
+		return chart.LegendPlacementRight
+	}
+	return chart.LegendPlacementInsideTopLeft
+}
+
+// FromVegaLite returns a chart from a Vega-Lite spec, i.e. one written by `VegaLite`.
+//
+// It reads single view and layered specs with inline data, `line`, `point`, `area` and `text` marks, quantitative or
+// temporal x and quantitative y fields, and `equal` filters. Each layer becomes a series, or a series for each value
+// of its color field; the title, size, axis titles, scale domains and reversed scales, mark colors and stroke styles,
+// and the color scale range are kept. Anything else returns an `ErrUnsupported` error.
+func FromVegaLite(data []byte) (chart.Chart, error) {
+	var spec vlSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return chart.Chart{}, err
+	}
+
+	c := chart.Chart{
+		Width:  spec.Width,
+		Height: spec.Height,
+		XAxis:  chart.XAxis{Style: chart.StyleShow()},
+		YAxis:  chart.YAxis{Style: chart.StyleShow()},
+	}
+	if spec.Title != nil && spec.Title.Text != "" {
+		c.Title = spec.Title.Text
+		c.TitleStyle = chart.StyleShow()
+	}
+
+	layers := spec.Layer
+	if len(layers) == 0 {
+		layers = []vlSpec{{Mark: spec.Mark}}
+	}
+	for index, layer := range layers {
+		if len(layer.Layer) > 0 {
+			return chart.Chart{}, fmt.Errorf("%w: layer %d: nested layers", ErrUnsupported, index)
+		}
+		series, err := readLayer(&c, spec, layer)
+		if err != nil {
+			return chart.Chart{}, fmt.Errorf("layer %d: %w", index, err)
+		}
+		c.Series = append(c.Series, series...)
+	}
+	if len(c.Series) == 0 {
+		return chart.Chart{}, errors.New("spec has no series")
+	}
+	return c, nil
+}
+
+// readLayer returns the series of a layer, which inherits the data, transforms and encoding of its parent.
+func readLayer(c *chart.Chart, parent, layer vlSpec) ([]chart.Series, error) {
+	if layer.Mark == nil {
+		return nil, fmt.Errorf("%w: layer has no mark", ErrUnsupported)
+	}
+	data := layer.Data
+	if data == nil {
+		data = parent.Data
+	}
+	if data == nil || data.URL != "" {
+		return nil, fmt.Errorf("%w: only inline data values can be read", ErrUnsupported)
+	}
+	encoding := mergeEncoding(parent.Encoding, layer.Encoding)
+	if encoding.X == nil || encoding.Y == nil || encoding.X.Field == "" || encoding.Y.Field == "" {
+		return nil, fmt.Errorf("%w: layer must encode x and y fields", ErrUnsupported)
+	}
+	temporal := encoding.X.Type == "temporal"
+	if err := readAxes(c, encoding, temporal); err != nil {
+		return nil, err
+	}
+
+	rows, err := filterRows(data.Values, append(parent.Transform, layer.Transform...))
+	if err != nil {
+		return nil, err
+	}
+	names, groups := groupRows(rows, encoding.Color)
+
+	var series []chart.Series
+	for _, name := range names {
+		xvalues, yvalues, err := readValues(groups[name], encoding.X.Field, encoding.Y.Field, temporal)
+		if err != nil {
+			return nil, fmt.Errorf("series %q: %w", name, err)
+		}
+		style := readMarkStyle(layer.Mark, seriesColor(layer.Mark, encoding.Color, name))
+
+		switch layer.Mark.Type {
+		case "line", "point":
+			if temporal {
+				times := make([]time.Time, len(xvalues))
+				for index, x := range xvalues {
+					times[index] = util.Time.FromFloat64(x)
+				}
+				series = append(series, chart.TimeSeries{Name: name, Style: style, XValues: times, YValues: yvalues})
+			} else {
+				series = append(series, chart.ContinuousSeries{Name: name, Style: style, XValues: xvalues, YValues: yvalues})
+			}
+		case "area":
+			if encoding.Y2 == nil || encoding.Y2.Field == "" {
+				style.FillColor = style.StrokeColor.WithAlpha(64)
+				series = append(series, chart.ContinuousSeries{Name: name, Style: style, XValues: xvalues, YValues: yvalues})
+				continue
+			}
+			_, upper, err := readValues(groups[name], encoding.X.Field, encoding.Y2.Field, temporal)
+			if err != nil {
+				return nil, fmt.Errorf("series %q: %w", name, err)
+			}
+			style.FillColor, style.StrokeColor = style.StrokeColor, drawing.Color{}
+			series = append(series, chart.BandSeries{
+				Name:  name,
+				Style: style,
+				Upper: chart.ContinuousSeries{XValues: xvalues, YValues: upper},
+				Lower: chart.ContinuousSeries{XValues: xvalues, YValues: yvalues},
+			})
+		case "text":
+			if encoding.Text == nil || encoding.Text.Field == "" {
+				return nil, fmt.Errorf("%w: text marks must encode a text field", ErrUnsupported)
+			}
+			as := chart.AnnotationSeries{Name: name, Style: chart.Style{Show: true, FontColor: style.StrokeColor}}
+			for index, row := range groups[name] {
+				as.Annotations = append(as.Annotations, chart.Value2{
+					XValue: xvalues[index], YValue: yvalues[index], Label: fmt.Sprint(row[encoding.Text.Field]),
+				})
+			}
+			series = append(series, as)
+		default:
+			return nil, fmt.Errorf("%w: mark %q", ErrUnsupported, layer.Mark.Type)
+		}
+	}
+
+	if encoding.Color != nil && encoding.Color.Field != "" && string(encoding.Color.Legend) != "null" {
+		var legend vlLegend
+		_ = json.Unmarshal(encoding.Color.Legend, &legend)
+		c.Legend = chart.LegendOptions{Style: chart.StyleShow(), Placement: legendPlacement(legend.Orient)}
+	}
+	return series, nil
+}
+
+func mergeEncoding(parent, layer *vlEncoding) vlEncoding {
+	var merged vlEncoding
+	if parent != nil {
+		merged = *parent
+	}
+	if layer == nil {
+		return merged
+	}
+	for _, channel := range []struct {
+		to   **vlChannel
+		from *vlChannel
+	}{
+		{&merged.X, layer.X}, {&merged.Y, layer.Y}, {&merged.Y2, layer.Y2}, {&merged.Color, layer.Color}, {&merged.Text, layer.Text},
+	} {
+		if channel.from != nil {
+			*channel.to = channel.from
+		}
+	}
+	return merged
+}
+
+func readAxes(c *chart.Chart, encoding vlEncoding, temporal bool) error {
+	if encoding.Y.Type != "" && encoding.Y.Type != "quantitative" {
+		return fmt.Errorf("%w: y field of type %q", ErrUnsupported, encoding.Y.Type)
+	}
+	if !temporal && encoding.X.Type != "" && encoding.X.Type != "quantitative" {
+		return fmt.Errorf("%w: x field of type %q", ErrUnsupported, encoding.X.Type)
+	}
+	if encoding.X.Title != "" {
+		c.XAxis.Name, c.XAxis.NameStyle = encoding.X.Title, chart.StyleShow()
+	}
+	if encoding.Y.Title != "" {
+		c.YAxis.Name, c.YAxis.NameStyle = encoding.Y.Title, chart.StyleShow()
+	}
+	if scale := encoding.X.Scale; scale != nil {
+		c.XAxis.Descending = scale.Reverse
+		if len(scale.Domain) == 2 {
+			min, err := readValue(scale.Domain[0], temporal)
+			if err != nil {
+				return err
+			}
+			max, err := readValue(scale.Domain[1], temporal)
+			if err != nil {
+				return err
+			}
+			if temporal {
+				c.XAxis.Range = &chart.TimeRange{ContinuousRange: chart.ContinuousRange{Min: min, Max: max}}
+			} else {
+				c.XAxis.Range = &chart.ContinuousRange{Min: min, Max: max}
+			}
+		}
+	}
+	if scale := encoding.Y.Scale; scale != nil {
+		c.YAxis.Descending = scale.Reverse
+		if len(scale.Domain) == 2 {
+			min, err := readValue(scale.Domain[0], false)
+			if err != nil {
+				return err
+			}
+			max, err := readValue(scale.Domain[1], false)
+			if err != nil {
+				return err
+			}
+			c.YAxis.Range = &chart.ContinuousRange{Min: min, Max: max}
+		}
+	}
+	return nil
+}
+
+func filterRows(rows []map[string]interface{}, transforms []vlTransform) ([]map[string]interface{}, error) {
+	for _, transform := range transforms {
+		if transform.Filter == nil {
+			return nil, fmt.Errorf("%w: only filter transforms can be read", ErrUnsupported)
+		}
+		var filtered []map[string]interface{}
+		for _, row := range rows {
+			if fmt.Sprint(row[transform.Filter.Field]) == fmt.Sprint(transform.Filter.Equal) {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+	return rows, nil
+}
+
+// groupRows returns the rows of each value of the color field in the order they first appear, or all rows as
+// one group named by the color datum.
+func groupRows(rows []map[string]interface{}, color *vlChannel) ([]string, map[string][]map[string]interface{}) {
+	groups := map[string][]map[string]interface{}{}
+	if color == nil || color.Field == "" {
+		name := ""
+		if color != nil && color.Datum != nil {
+			name = fmt.Sprint(color.Datum)
+		}
+		groups[name] = rows
+		return []string{name}, groups
+	}
+	var names []string
+	for _, row := range rows {
+		name := fmt.Sprint(row[color.Field])
+		if _, has := groups[name]; !has {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], row)
+	}
+	return names, groups
+}
+
+func readValues(rows []map[string]interface{}, xfield, yfield string, temporal bool) (xvalues, yvalues []float64, err error) {
+	xvalues, yvalues = make([]float64, len(rows)), make([]float64, len(rows))
+	for index, row := range rows {
+		if xvalues[index], err = readValue(row[xfield], temporal); err != nil {
+			return nil, nil, err
+		}
+		if yvalues[index], err = readValue(row[yfield], false); err != nil {
+			return nil, nil, err
+		}
+	}
+	return
+}
+
+// readValue returns a value as a float; temporal values are iso dates or, as in vega-lite, milliseconds since the epoch.
+func readValue(value interface{}, temporal bool) (float64, error) {
+	switch typed := value.(type) {
+	case float64:
+		if temporal {
+			return util.Time.ToFloat64(time.Unix(0, int64(typed*float64(time.Millisecond)))), nil
+		}
+		return typed, nil
+	case string:
+		if temporal {
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+				if t, err := time.Parse(layout, typed); err == nil {
+					return util.Time.ToFloat64(t), nil
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: value %v", ErrUnsupported, value)
+}
+
+// seriesColor returns the color of a series from the mark or the range of the color scale; it is zero if neither sets it.
+func seriesColor(mark *vlMark, color *vlChannel, name string) drawing.Color {
+	if mark.Color != "" {
+		return readColor(mark.Color, mark.Opacity)
+	}
+	if color == nil || color.Scale == nil || len(color.Scale.Range) == 0 {
+		return drawing.Color{}
+	}
+	for index, value := range color.Scale.Domain {
+		if fmt.Sprint(value) == name && index < len(color.Scale.Range) {
+			return readColor(color.Scale.Range[index], 0)
+		}
+	}
+	return drawing.Color{}
+}
+
+func readColor(hex string, opacity float64) drawing.Color {
+	if len(hex) == 0 || hex[0] != '#' {
+		return drawing.Color{}
+	}
+	color := drawing.ColorFromHex(hex[1:])
+	if opacity > 0 && opacity < 1 {
+		color.A = uint8(opacity * 255)
+	}
+	return color
+}
+
+func readMarkStyle(mark *vlMark, color drawing.Color) chart.Style {
+	style := chart.Style{Show: true, StrokeColor: color, StrokeWidth: mark.StrokeWidth, StrokeDashArray: mark.StrokeDash}
+	if mark.Type == "point" {
+		style.StrokeWidth = chart.Disabled
+		style.DotWidth, style.DotColor = 3, color
+	} else if mark.Point {
+		style.DotWidth, style.DotColor = 3, color
+	}
+	return style
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	chart "github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestVegaLite(t *testing.T) {
+	assert := assert.New(t)
+
+	c := chart.Chart{
+		Title:  "requests",
+		Width:  640,
+		Height: 480,
+		XAxis:  chart.XAxis{Name: "x", Range: &chart.ContinuousRange{Min: 0, Max: 10}},
+		YAxis:  chart.YAxis{Descending: true},
+		Legend: chart.LegendOptions{Style: chart.StyleShow(), Placement: chart.LegendPlacementBottom},
+		Series: []chart.Series{
+			chart.ContinuousSeries{Name: "a", Style: chart.Style{Show: true, StrokeColor: drawing.ColorRed, StrokeDashArray: []float64{4, 2}}, XValues: []float64{1, 2, 3}, YValues: []float64{1, 4, 9}},
+			chart.ContinuousSeries{Name: "hidden", Style: chart.Style{StrokeWidth: 2}, XValues: []float64{1}, YValues: []float64{1}},
+			chart.AnnotationSeries{Name: "notes", Annotations: []chart.Value2{{XValue: 2, YValue: 4, Label: "peak"}}},
+		},
+	}
+	data, err := VegaLite(c)
+	assert.Nil(err)
+
+	var spec map[string]interface{}
+	assert.Nil(json.Unmarshal(data, &spec))
+	assert.Equal(VegaLiteSchema, spec["$schema"])
+	assert.Equal("requests", spec["title"])
+	assert.Len(spec["layer"], 2)
+	assert.Len(spec["data"].(map[string]interface{})["values"], 4)
+	assert.True(strings.Contains(string(data), `"strokeDash"`))
+	assert.True(strings.Contains(string(data), `"orient": "bottom"`))
+	assert.True(strings.Contains(string(data), `"reverse": true`))
+	assert.True(strings.Contains(string(data), `"#ff0000"`))
+}
+
+func TestVegaLiteRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := chart.Chart{
+		Title:  "latency",
+		XAxis:  chart.XAxis{Name: "time"},
+		YAxis:  chart.YAxis{Name: "ms", Range: &chart.ContinuousRange{Min: 0, Max: 100}},
+		Legend: chart.LegendOptions{Style: chart.StyleShow(), Placement: chart.LegendPlacementRight},
+		Series: []chart.Series{
+			chart.TimeSeries{Name: "p50", XValues: []time.Time{start, start.Add(time.Hour)}, YValues: []float64{10, 20}},
+			chart.TimeSeries{Name: "p99", Style: chart.Style{Show: true, StrokeColor: drawing.ColorBlue}, XValues: []time.Time{start, start.Add(time.Hour)}, YValues: []float64{50, 90}},
+		},
+	}
+	data, err := VegaLite(c)
+	assert.Nil(err)
+
+	restored, err := FromVegaLite(data)
+	assert.Nil(err)
+	assert.Equal("latency", restored.Title)
+	assert.Equal("time", restored.XAxis.Name)
+	assert.Equal("ms", restored.YAxis.Name)
+	assert.Equal(100.0, restored.YAxis.Range.GetMax())
+	assert.True(restored.Legend.Style.Show)
+	assert.Equal(chart.LegendPlacementRight, restored.Legend.Placement)
+	assert.Len(restored.Series, 2)
+
+	p99, isTimeSeries := restored.Series[1].(chart.TimeSeries)
+	assert.True(isTimeSeries)
+	assert.Equal("p99", p99.Name)
+	assert.True(p99.XValues[1].Equal(start.Add(time.Hour)))
+	assert.Equal(90.0, p99.YValues[1])
+	assert.True(p99.Style.StrokeColor.Equals(drawing.ColorBlue))
+
+	assert.Nil(restored.Render(chart.PNG, &strings.Builder{}))
+}
+
+func TestFromVegaLiteColorField(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := FromVegaLite([]byte(`{
+		"title": {"text": "stocks"},
+		"data": {"values": [
+			{"symbol": "A", "x": 1, "price": 10},
+			{"symbol": "B", "x": 1, "price": 20},
+			{"symbol": "A", "x": 2, "price": 12},
+			{"symbol": "B", "x": 2, "price": 18}
+		]},
+		"mark": "point",
+		"encoding": {
+			"x": {"field": "x", "type": "quantitative"},
+			"y": {"field": "price", "type": "quantitative"},
+			"color": {"field": "symbol", "type": "nominal"}
+		}
+	}`))
+	assert.Nil(err)
+	assert.Equal("stocks", c.Title)
+	assert.Len(c.Series, 2)
+	b := c.Series[1].(chart.ContinuousSeries)
+	assert.Equal("B", b.Name)
+	assert.Equal([]float64{20, 18}, b.YValues)
+	assert.Equal(chart.Disabled, b.Style.StrokeWidth)
+}
+
+func TestFromVegaLiteUnsupported(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromVegaLite([]byte(`{"data": {"url": "data.csv"}, "mark": "line", "encoding": {"x": {"field": "x"}, "y": {"field": "y"}}}`))
+	assert.True(errors.Is(err, ErrUnsupported))
+
+	_, err = FromVegaLite([]byte(`{"data": {"values": []}, "mark": "arc", "encoding": {"x": {"field": "x"}, "y": {"field": "y"}}}`))
+	assert.NotNil(err)
+}
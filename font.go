@@ -1,29 +1,165 @@
 package chart
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/wcharczuk/go-chart/roboto"
 )
 
+// DefaultFontEnvVar is the environment variable that, when set to the path of a .ttf or .otf file,
+// replaces the embedded font as the default font; see `GetDefaultFont`. It can also be a list of paths
+// separated by the os path list separator, which are loaded as a fallback chain with `LoadFontChain`.
+const DefaultFontEnvVar = "GO_CHART_FONT"
+
 var (
-	_defaultFontLock sync.Mutex
+	_defaultFontLock sync.RWMutex
 	_defaultFont     *truetype.Font
+
+	_fontsLock sync.RWMutex
+	_fonts     = map[string]*truetype.Font{}
+
+	_embeddedFontOnce sync.Once
+	_embeddedFont     *truetype.Font
+	_embeddedFontErr  error
 )
 
-// GetDefaultFont returns the default font (Roboto-Medium).
+// GetDefaultFont returns the default font.
+// It is the font set with `SetDefaultFont`, or the font file named by `DefaultFontEnvVar`,
+// or the embedded Roboto-Medium font, in that order.
+// It is safe to call from multiple goroutines.
 func GetDefaultFont() (*truetype.Font, error) {
+	_defaultFontLock.RLock()
+	font := _defaultFont
+	_defaultFontLock.RUnlock()
+	if font != nil {
+		return font, nil
+	}
+
+	_defaultFontLock.Lock()
+	defer _defaultFontLock.Unlock()
 	if _defaultFont == nil {
-		_defaultFontLock.Lock()
-		defer _defaultFontLock.Unlock()
-		if _defaultFont == nil {
-			font, err := truetype.Parse(roboto.Roboto)
-			if err != nil {
+		var parsed *truetype.Font
+		var err error
+		if paths := os.Getenv(DefaultFontEnvVar); len(paths) > 0 {
+			parsed, err = LoadFontChain(filepath.SplitList(paths)...)
+		} else {
+			parsed, err = truetype.Parse(roboto.Roboto)
+		}
+		if err != nil {
+			return nil, err
+		}
+		_defaultFont = parsed
+	}
+	return _defaultFont, nil
+}
+
+// GetEmbeddedFont returns the embedded Roboto-Medium font, whatever the default font is.
+// It is safe to call from multiple goroutines.
+func GetEmbeddedFont() (*truetype.Font, error) {
+	_embeddedFontOnce.Do(func() {
+		_embeddedFont, _embeddedFontErr = truetype.Parse(roboto.Roboto)
+	})
+	return _embeddedFont, _embeddedFontErr
+}
+
+// SetDefaultFont overrides the font used by charts that do not set a font explicitly.
+// Passing nil restores the embedded Roboto-Medium font (or the `DefaultFontEnvVar` font).
+func SetDefaultFont(font *truetype.Font) {
+	_defaultFontLock.Lock()
+	defer _defaultFontLock.Unlock()
+	_defaultFont = font
+}
+
+// SetDefaultFontFile loads a .ttf or .otf file and sets it as the default font.
+func SetDefaultFontFile(path string) error {
+	font, err := LoadFont(path)
+	if err != nil {
+		return err
+	}
+	SetDefaultFont(font)
+	return nil
+}
+
+// LoadFont loads a font from a .ttf or .otf file.
+func LoadFont(path string) (*truetype.Font, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	font, err := ParseFont(contents)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse font %s: %v", path, err)
+	}
+	return font, nil
+}
+
+// ParseFont parses a TrueType font, or an OpenType font; the outlines of OpenType fonts with CFF outlines
+// are converted to TrueType outlines.
+func ParseFont(data []byte) (*truetype.Font, error) {
+	data, err := toTrueType(data)
+	if err != nil {
+		return nil, err
+	}
+	return truetype.Parse(data)
+}
+
+// LoadFontChain loads a fallback chain of fonts from .ttf or .otf files into one font; see `ParseFontChain`.
+func LoadFontChain(paths ...string) (*truetype.Font, error) {
+	data := make([][]byte, len(paths))
+	for index, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data[index] = contents
+	}
+	font, err := ParseFontChain(data...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse fonts %v: %v", paths, err)
+	}
+	return font, nil
+}
+
+// ParseFontChain parses a fallback chain of fonts, i.e. a latin font then a CJK font then an emoji font,
+// into one font that draws each character with the first font of the chain that has it, so characters
+// missing from the first font are not drawn as boxes. Since the chain is one font, every renderer measures
+// and draws text with it the same way.
+//
+// The metrics and kerning are those of the first font, and the glyphs of the other fonts are scaled to its
+// units. Emoji fonts that draw their glyphs as color images rather than outlines have no glyphs to fall back on.
+// Merging fonts takes a while, so chains are best parsed once, i.e. with `SetDefaultFont` or `RegisterFont`.
+func ParseFontChain(data ...[]byte) (*truetype.Font, error) {
+	if len(data) == 0 {
+		return nil, errors.New("please provide at least one font")
+	}
+	if len(data) == 1 {
+		return ParseFont(data[0])
+	}
+
+	fonts := make([]*truetype.Font, len(data))
+	var base map[string][]byte
+	for index, contents := range data {
+		converted, err := toTrueType(contents)
+		if err != nil {
+			return nil, fmt.Errorf("font %d: %v", index, err)
+		}
+		if fonts[index], err = truetype.Parse(converted); err != nil {
+			return nil, fmt.Errorf("font %d: %v", index, err)
+		}
+		if index == 0 {
+			if base, err = readSFNTTables(converted); err != nil {
 				return nil, err
 			}
-			_defaultFont = font
 		}
 	}
-	return _defaultFont, nil
+	merged, err := mergeFonts(base, fonts)
+	if err != nil {
+		return nil, err
+	}
+	return truetype.Parse(merged)
 }
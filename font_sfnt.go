@@ -0,0 +1,482 @@
+package chart
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"unicode"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/util"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// sfntGlyph is the outline of a glyph in font units, with y up, and its advance width.
+type sfntGlyph struct {
+	Advance  int
+	Contours [][]sfntPoint
+}
+
+// sfntPoint is a point of a glyph outline; points off the curve are the control points of quadratic curves.
+type sfntPoint struct {
+	X, Y    int
+	OnCurve bool
+}
+
+// encode returns the `glyf` table data of the glyph and its bounds; glyphs without contours have no data.
+func (g sfntGlyph) encode() (data []byte, xMin, yMin, xMax, yMax int) {
+	var points []sfntPoint
+	for _, contour := range g.Contours {
+		points = append(points, contour...)
+	}
+	if len(points) == 0 {
+		return nil, 0, 0, 0, 0
+	}
+	xMin, yMin, xMax, yMax = math.MaxInt32, math.MaxInt32, math.MinInt32, math.MinInt32
+	for _, p := range points {
+		xMin, yMin = util.Math.MinInt(xMin, p.X), util.Math.MinInt(yMin, p.Y)
+		xMax, yMax = util.Math.MaxInt(xMax, p.X), util.Math.MaxInt(yMax, p.Y)
+	}
+
+	put := func(v int) {
+		data = append(data, byte(uint16(v)>>8), byte(uint16(v)))
+	}
+	put(len(g.Contours))
+	put(xMin)
+	put(yMin)
+	put(xMax)
+	put(yMax)
+	end := -1
+	for _, contour := range g.Contours {
+		end += len(contour)
+		put(end)
+	}
+	put(0) // no instructions.
+	// with neither short vector nor same flag set, each coordinate is a 16 bit delta from the one before.
+	for _, p := range points {
+		if p.OnCurve {
+			data = append(data, 1)
+		} else {
+			data = append(data, 0)
+		}
+	}
+	var previous int
+	for _, p := range points {
+		put(p.X - previous)
+		previous = p.X
+	}
+	previous = 0
+	for _, p := range points {
+		put(p.Y - previous)
+		previous = p.Y
+	}
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+	return
+}
+
+// mergeFonts returns a TrueType font of the glyphs of fonts, with each character mapped to the glyph of the
+// first font that has it. The glyphs of the first font keep their indexes, so its kerning still applies.
+func mergeFonts(base map[string][]byte, fonts []*truetype.Font) ([]byte, error) {
+	if len(base["maxp"]) < 6 {
+		return nil, errors.New("font is missing its maxp table")
+	}
+	// glyphs are loaded in units of the first font, as 26.6 fixed point values.
+	scale := fixed.Int26_6(fonts[0].FUnitsPerEm()) << 6
+
+	type fontGlyph struct {
+		font  int
+		index truetype.Index
+	}
+	var buffer truetype.GlyphBuf
+	var glyphs []sfntGlyph
+	indexes := map[fontGlyph]int{}
+	add := func(fontIndex int, index truetype.Index) (int, error) {
+		key := fontGlyph{font: fontIndex, index: index}
+		if glyph, ok := indexes[key]; ok {
+			return glyph, nil
+		}
+		if len(glyphs) == math.MaxUint16 {
+			return 0, fmt.Errorf("fonts cannot have more than %d glyphs", math.MaxUint16)
+		}
+		if err := buffer.Load(fonts[fontIndex], scale, index, font.HintingNone); err != nil {
+			return 0, fmt.Errorf("font %d: cannot load glyph %d: %v", fontIndex, index, err)
+		}
+		glyphs = append(glyphs, glyphBufGlyph(&buffer))
+		indexes[key] = len(glyphs) - 1
+		return len(glyphs) - 1, nil
+	}
+
+	for index := 0; index < int(uint16(base["maxp"][4])<<8|uint16(base["maxp"][5])); index++ {
+		if _, err := add(0, truetype.Index(index)); err != nil {
+			return nil, err
+		}
+	}
+	runes := map[rune]int{}
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		for fontIndex, f := range fonts {
+			if index := f.Index(r); index != 0 {
+				glyph, err := add(fontIndex, index)
+				if err != nil {
+					return nil, err
+				}
+				runes[r] = glyph
+				break
+			}
+		}
+	}
+	return buildTrueType(base, glyphs, runes)
+}
+
+// glyphBufGlyph returns the outline of a loaded glyph.
+func glyphBufGlyph(buffer *truetype.GlyphBuf) sfntGlyph {
+	glyph := sfntGlyph{Advance: fixedToUnits(buffer.AdvanceWidth)}
+	start := 0
+	for _, end := range buffer.Ends {
+		contour := make([]sfntPoint, 0, end-start)
+		for _, p := range buffer.Points[start:end] {
+			contour = append(contour, sfntPoint{X: fixedToUnits(p.X), Y: fixedToUnits(p.Y), OnCurve: p.Flags&1 != 0})
+		}
+		if len(contour) > 0 {
+			glyph.Contours = append(glyph.Contours, contour)
+		}
+		start = end
+	}
+	return glyph
+}
+
+// RegisterFont registers a font by name, i.e. so a font loaded once at startup can be looked up with `GetFont`.
+// Registering a nil font removes the name.
+func RegisterFont(name string, font *truetype.Font) {
+	_fontsLock.Lock()
+	defer _fontsLock.Unlock()
+	if font == nil {
+		delete(_fonts, name)
+		return
+	}
+	_fonts[name] = font
+}
+
+// RegisterFontFile loads a .ttf file and registers it by name.
+func RegisterFontFile(name, path string) error {
+	font, err := LoadFont(path)
+	if err != nil {
+		return err
+	}
+	RegisterFont(name, font)
+	return nil
+}
+
+// GetFont returns a font registered with `RegisterFont`, or nil.
+func GetFont(name string) *truetype.Font {
+	_fontsLock.RLock()
+	defer _fontsLock.RUnlock()
+	return _fonts[name]
+}
+
+// isCFF returns if font data is an OpenType font with CFF outlines, which freetype cannot parse.
+func isCFF(data []byte) bool {
+	return len(data) >= 4 && string(data[:4]) == "OTTO"
+}
+
+// toTrueType returns font data with TrueType outlines; OpenType fonts with CFF outlines are converted,
+// other fonts are returned as they are.
+func toTrueType(data []byte) ([]byte, error) {
+	if !isCFF(data) {
+		return data, nil
+	}
+	return convertCFF(data)
+}
+
+// convertCFF converts an OpenType font with CFF outlines to a TrueType font; cubic curves are drawn
+// as two quadratic curves each, and the character map, metrics and kerning are kept.
+func convertCFF(data []byte) ([]byte, error) {
+	base, err := readSFNTTables(data)
+	if err != nil {
+		return nil, err
+	}
+	f, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ppem := fixed.Int26_6(f.UnitsPerEm()) << 6
+	var buffer sfnt.Buffer
+	glyphs := make([]sfntGlyph, f.NumGlyphs())
+	for index := range glyphs {
+		advance, err := f.GlyphAdvance(&buffer, sfnt.GlyphIndex(index), ppem, font.HintingNone)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert glyph %d: %v", index, err)
+		}
+		segments, err := f.LoadGlyph(&buffer, sfnt.GlyphIndex(index), ppem, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert glyph %d: %v", index, err)
+		}
+		glyphs[index] = sfntGlyph{Advance: fixedToUnits(advance), Contours: segmentContours(segments)}
+	}
+	return buildTrueType(base, glyphs, nil)
+}
+
+// segmentContours returns the contours of glyph segments in font units, with y flipped up.
+func segmentContours(segments sfnt.Segments) [][]sfntPoint {
+	var contours [][]sfntPoint
+	var contour []sfntPoint
+	var last fixed.Point26_6
+	point := func(p fixed.Point26_6, onCurve bool) sfntPoint {
+		return sfntPoint{X: fixedToUnits(p.X), Y: -fixedToUnits(p.Y), OnCurve: onCurve}
+	}
+	closeContour := func() {
+		// contours close by themselves, so a last point on the first is dropped.
+		if n := len(contour); n > 1 && contour[0] == contour[n-1] {
+			contour = contour[:n-1]
+		}
+		if len(contour) > 0 {
+			contours = append(contours, contour)
+		}
+		contour = nil
+	}
+	middle := func(a, b fixed.Point26_6) fixed.Point26_6 {
+		return fixed.Point26_6{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+	}
+	// quadControl returns the control point of the quadratic curve closest to a cubic curve.
+	quadControl := func(p0, c1, c2, p3 fixed.Point26_6) fixed.Point26_6 {
+		return fixed.Point26_6{X: (3*(c1.X+c2.X) - p0.X - p3.X) / 4, Y: (3*(c1.Y+c2.Y) - p0.Y - p3.Y) / 4}
+	}
+
+	for _, s := range segments {
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			closeContour()
+			contour = append(contour, point(s.Args[0], true))
+			last = s.Args[0]
+		case sfnt.SegmentOpLineTo:
+			contour = append(contour, point(s.Args[0], true))
+			last = s.Args[0]
+		case sfnt.SegmentOpQuadTo:
+			contour = append(contour, point(s.Args[0], false), point(s.Args[1], true))
+			last = s.Args[1]
+		case sfnt.SegmentOpCubeTo:
+			// split the curve in half, and draw each half as a quadratic curve.
+			p0, c1, c2, p3 := last, s.Args[0], s.Args[1], s.Args[2]
+			m01, m12, m23 := middle(p0, c1), middle(c1, c2), middle(c2, p3)
+			m012, m123 := middle(m01, m12), middle(m12, m23)
+			mid := middle(m012, m123)
+			contour = append(contour,
+				point(quadControl(p0, m01, m012, mid), false), point(mid, true),
+				point(quadControl(mid, m123, m23, p3), false), point(p3, true))
+			last = p3
+		}
+	}
+	closeContour()
+	return contours
+}
+
+// fixedToUnits returns a 26.6 fixed point value rounded to an integer.
+func fixedToUnits(v fixed.Int26_6) int {
+	return int(math.Round(float64(v) / 64))
+}
+
+// buildTrueType returns a TrueType font of glyphs, with the other tables taken from a base font;
+// the base font's glyph indexes must stay the same for its kerning to apply. Runes map characters to glyphs;
+// if they are nil the base font's character map is kept.
+func buildTrueType(base map[string][]byte, glyphs []sfntGlyph, runes map[rune]int) ([]byte, error) {
+	if len(glyphs) > math.MaxUint16 {
+		return nil, fmt.Errorf("fonts cannot have more than %d glyphs, not %d", math.MaxUint16, len(glyphs))
+	}
+	if len(base["head"]) != 54 || len(base["hhea"]) != 36 {
+		return nil, errors.New("font is missing its head or hhea table")
+	}
+
+	tables := map[string][]byte{}
+	for _, tag := range []string{"cmap", "kern", "name", "OS/2"} {
+		if table, ok := base[tag]; ok {
+			tables[tag] = table
+		}
+	}
+	if runes != nil {
+		tables["cmap"] = encodeCmap(runes)
+	}
+	if _, ok := tables["cmap"]; !ok {
+		return nil, errors.New("font is missing its cmap table")
+	}
+
+	var glyf []byte
+	loca := make([]byte, 4*(len(glyphs)+1))
+	hmtx := make([]byte, 4*len(glyphs))
+	xMin, yMin, xMax, yMax := math.MaxInt32, math.MaxInt32, math.MinInt32, math.MinInt32
+	var maxPoints, maxContours, maxAdvance int
+	for index, g := range glyphs {
+		binary.BigEndian.PutUint32(loca[4*index:], uint32(len(glyf)))
+		data, gxMin, gyMin, gxMax, gyMax := g.encode()
+		glyf = append(glyf, data...)
+		if len(data) > 0 {
+			xMin, yMin, xMax, yMax = util.Math.MinInt(xMin, gxMin), util.Math.MinInt(yMin, gyMin), util.Math.MaxInt(xMax, gxMax), util.Math.MaxInt(yMax, gyMax)
+			var points int
+			for _, contour := range g.Contours {
+				points += len(contour)
+			}
+			maxPoints, maxContours = util.Math.MaxInt(maxPoints, points), util.Math.MaxInt(maxContours, len(g.Contours))
+		}
+		maxAdvance = util.Math.MaxInt(maxAdvance, g.Advance)
+		binary.BigEndian.PutUint16(hmtx[4*index:], uint16(g.Advance))
+		binary.BigEndian.PutUint16(hmtx[4*index+2:], uint16(int16(gxMin)))
+	}
+	binary.BigEndian.PutUint32(loca[4*len(glyphs):], uint32(len(glyf)))
+
+	head := append([]byte(nil), base["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0) // the checksum adjustment is set once the font is written.
+	if xMin <= xMax {
+		binary.BigEndian.PutUint16(head[36:], uint16(int16(xMin)))
+		binary.BigEndian.PutUint16(head[38:], uint16(int16(yMin)))
+		binary.BigEndian.PutUint16(head[40:], uint16(int16(xMax)))
+		binary.BigEndian.PutUint16(head[42:], uint16(int16(yMax)))
+	}
+	binary.BigEndian.PutUint16(head[50:], 1) // long loca offsets.
+
+	hhea := append([]byte(nil), base["hhea"]...)
+	binary.BigEndian.PutUint16(hhea[10:], uint16(maxAdvance))
+	binary.BigEndian.PutUint16(hhea[34:], uint16(len(glyphs)))
+
+	maxp := make([]byte, 32)
+	if len(base["maxp"]) == 32 {
+		copy(maxp, base["maxp"])
+	} else {
+		binary.BigEndian.PutUint16(maxp[14:], 2) // max zones.
+	}
+	binary.BigEndian.PutUint32(maxp, 0x00010000)
+	binary.BigEndian.PutUint16(maxp[4:], uint16(len(glyphs)))
+	binary.BigEndian.PutUint16(maxp[6:], uint16(maxPoints))
+	binary.BigEndian.PutUint16(maxp[8:], uint16(maxContours))
+	// glyphs are written without components.
+	for _, offset := range []int{10, 12, 28, 30} {
+		binary.BigEndian.PutUint16(maxp[offset:], 0)
+	}
+
+	tables["head"], tables["hhea"], tables["hmtx"], tables["maxp"] = head, hhea, hmtx, maxp
+	tables["loca"], tables["glyf"] = loca, glyf
+	return writeSFNT(tables), nil
+}
+
+// encodeCmap returns a `cmap` table that maps characters to glyphs, with one format 12 subtable.
+func encodeCmap(runes map[rune]int) []byte {
+	keys := make([]rune, 0, len(runes))
+	for r := range runes {
+		keys = append(keys, r)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	type group struct {
+		start, end rune
+		glyph      int
+	}
+	var groups []group
+	for _, r := range keys {
+		if n := len(groups); n > 0 && groups[n-1].end == r-1 && groups[n-1].glyph+int(r-groups[n-1].start) == runes[r] {
+			groups[n-1].end = r
+			continue
+		}
+		groups = append(groups, group{start: r, end: r, glyph: runes[r]})
+	}
+
+	table := make([]byte, 12+16+12*len(groups))
+	binary.BigEndian.PutUint16(table[2:], 1)   // one subtable,
+	binary.BigEndian.PutUint16(table[4:], 3)   // for windows,
+	binary.BigEndian.PutUint16(table[6:], 10)  // in full unicode,
+	binary.BigEndian.PutUint32(table[8:], 12)  // right after the header.
+	binary.BigEndian.PutUint16(table[12:], 12) // format 12.
+	binary.BigEndian.PutUint32(table[16:], uint32(16+12*len(groups)))
+	binary.BigEndian.PutUint32(table[24:], uint32(len(groups)))
+	for index, g := range groups {
+		record := table[28+12*index:]
+		binary.BigEndian.PutUint32(record, uint32(g.start))
+		binary.BigEndian.PutUint32(record[4:], uint32(g.end))
+		binary.BigEndian.PutUint32(record[8:], uint32(g.glyph))
+	}
+	return table
+}
+
+// readSFNTTables returns the tables of TrueType or OpenType font data by tag; of a collection,
+// the tables of the first font.
+func readSFNTTables(data []byte) (map[string][]byte, error) {
+	start := 0
+	if len(data) >= 16 && string(data[:4]) == "ttcf" {
+		start = int(binary.BigEndian.Uint32(data[12:]))
+	}
+	if start < 0 || len(data) < start+12 {
+		return nil, errors.New("font data is too short")
+	}
+	count := int(binary.BigEndian.Uint16(data[start+4:]))
+	if len(data) < start+12+16*count {
+		return nil, errors.New("font data is too short")
+	}
+	tables := make(map[string][]byte, count)
+	for index := 0; index < count; index++ {
+		record := data[start+12+16*index:]
+		offset, length := int(binary.BigEndian.Uint32(record[8:])), int(binary.BigEndian.Uint32(record[12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("font table %q is out of bounds", record[:4])
+		}
+		tables[string(record[:4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// writeSFNT returns TrueType font data of tables by tag, with their checksums.
+func writeSFNT(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var entrySelector int
+	for 1<<uint(entrySelector+1) <= len(tags) {
+		entrySelector++
+	}
+	searchRange := (1 << uint(entrySelector)) * 16
+
+	offsets := make([]int, len(tags))
+	size := 12 + 16*len(tags)
+	for index, tag := range tags {
+		offsets[index] = size
+		size += (len(tables[tag]) + 3) &^ 3
+	}
+
+	data := make([]byte, size)
+	binary.BigEndian.PutUint32(data, 0x00010000)
+	binary.BigEndian.PutUint16(data[4:], uint16(len(tags)))
+	binary.BigEndian.PutUint16(data[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(data[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(data[10:], uint16(len(tags)*16-searchRange))
+	headOffset := -1
+	for index, tag := range tags {
+		record := data[12+16*index:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], sfntChecksum(tables[tag]))
+		binary.BigEndian.PutUint32(record[8:], uint32(offsets[index]))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(tables[tag])))
+		copy(data[offsets[index]:], tables[tag])
+		if tag == "head" {
+			headOffset = offsets[index]
+		}
+	}
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(data[headOffset+8:], 0xB1B0AFBA-sfntChecksum(data))
+	}
+	return data
+}
+
+// sfntChecksum returns the sum of data as big endian 32 bit words, padded with zeros.
+func sfntChecksum(data []byte) (sum uint32) {
+	for index := 0; index < len(data); index += 4 {
+		var word [4]byte
+		copy(word[:], data[index:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return
+}
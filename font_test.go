@@ -0,0 +1,175 @@
+package chart
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/roboto"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestGetDefaultFontConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	var wg sync.WaitGroup
+	fonts := make([]*truetype.Font, 16)
+	for index := range fonts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f, err := GetDefaultFont()
+			if err == nil {
+				fonts[i] = f
+			}
+		}(index)
+	}
+	wg.Wait()
+
+	for _, f := range fonts {
+		assert.NotNil(f)
+		assert.True(f == fonts[0])
+	}
+}
+
+func TestSetDefaultFont(t *testing.T) {
+	assert := assert.New(t)
+
+	original, err := GetDefaultFont()
+	assert.Nil(err)
+
+	other, err := truetype.Parse(roboto.Roboto)
+	assert.Nil(err)
+
+	SetDefaultFont(other)
+	defer SetDefaultFont(nil)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	assert.True(f == other)
+
+	SetDefaultFont(nil)
+	f, err = GetDefaultFont()
+	assert.Nil(err)
+	assert.NotNil(f)
+	assert.False(f == original)
+}
+
+func writeFontFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "font.ttf")
+	if err := os.WriteFile(path, roboto.Roboto, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFont(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := LoadFont(writeFontFile(t))
+	assert.Nil(err)
+	assert.NotNil(f)
+
+	_, err = LoadFont(filepath.Join(t.TempDir(), "missing.ttf"))
+	assert.NotNil(err)
+
+	garbage := filepath.Join(t.TempDir(), "garbage.ttf")
+	assert.Nil(os.WriteFile(garbage, []byte("not a font"), 0644))
+	_, err = LoadFont(garbage)
+	assert.NotNil(err)
+}
+
+func TestSetDefaultFontFile(t *testing.T) {
+	assert := assert.New(t)
+	defer SetDefaultFont(nil)
+
+	original, err := GetDefaultFont()
+	assert.Nil(err)
+
+	assert.Nil(SetDefaultFontFile(writeFontFile(t)))
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	assert.False(f == original)
+
+	assert.NotNil(SetDefaultFontFile(filepath.Join(t.TempDir(), "missing.ttf")))
+}
+
+func TestDefaultFontEnvVar(t *testing.T) {
+	assert := assert.New(t)
+	defer SetDefaultFont(nil)
+
+	t.Setenv(DefaultFontEnvVar, filepath.Join(t.TempDir(), "missing.ttf"))
+	SetDefaultFont(nil)
+	_, err := GetDefaultFont()
+	assert.NotNil(err)
+
+	t.Setenv(DefaultFontEnvVar, writeFontFile(t))
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	assert.NotNil(f)
+}
+
+func TestRegisterFont(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(GetFont("corporate"))
+	assert.Nil(RegisterFontFile("corporate", writeFontFile(t)))
+	assert.NotNil(GetFont("corporate"))
+
+	RegisterFont("corporate", nil)
+	assert.Nil(GetFont("corporate"))
+	assert.NotNil(RegisterFontFile("corporate", filepath.Join(t.TempDir(), "missing.ttf")))
+}
+
+func TestParseFontChain(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ParseFontChain()
+	assert.NotNil(err)
+
+	primary, err := truetype.Parse(roboto.Roboto)
+	assert.Nil(err)
+	fallback, err := truetype.Parse(goregular.TTF)
+	assert.Nil(err)
+	chain, err := ParseFontChain(roboto.Roboto, goregular.TTF)
+	assert.Nil(err)
+
+	// characters of the first font keep their glyphs and metrics.
+	scale := fixed.I(int(primary.FUnitsPerEm()))
+	assert.Equal(primary.Index('A'), chain.Index('A'))
+	assert.Equal(primary.HMetric(scale, primary.Index('A')), chain.HMetric(scale, chain.Index('A')))
+
+	// characters missing from it fall back on the second font, scaled to the units of the first.
+	assert.Zero(primary.Index('▲'))
+	assert.NotZero(fallback.Index('▲'))
+	assert.NotZero(chain.Index('▲'))
+	fallbackScale := fixed.I(int(fallback.FUnitsPerEm()))
+	assert.Equal(fallback.HMetric(fallbackScale, fallback.Index('▲')).AdvanceWidth, chain.HMetric(scale, chain.Index('▲')).AdvanceWidth)
+	assert.Zero(chain.Index('✓'))
+}
+
+func TestSegmentContours(t *testing.T) {
+	assert := assert.New(t)
+
+	p := func(x, y int) fixed.Point26_6 {
+		return fixed.P(x, y)
+	}
+	contours := segmentContours(sfnt.Segments{
+		{Op: sfnt.SegmentOpMoveTo, Args: [3]fixed.Point26_6{p(0, 0)}},
+		{Op: sfnt.SegmentOpLineTo, Args: [3]fixed.Point26_6{p(100, 0)}},
+		{Op: sfnt.SegmentOpCubeTo, Args: [3]fixed.Point26_6{p(100, -40), p(80, -80), p(40, -80)}},
+		{Op: sfnt.SegmentOpLineTo, Args: [3]fixed.Point26_6{p(0, 0)}},
+	})
+	assert.Len(contours, 1)
+	// the closing point is dropped, and the cubic curve becomes two quadratic curves with y flipped up.
+	assert.Len(contours[0], 6)
+	assert.Equal(sfntPoint{X: 0, Y: 0, OnCurve: true}, contours[0][0])
+	assert.False(contours[0][2].OnCurve)
+	assert.True(contours[0][3].OnCurve)
+	assert.Equal(sfntPoint{X: 40, Y: 80, OnCurve: true}, contours[0][5])
+}
@@ -0,0 +1,419 @@
+package chart
+
+import (
+	"io"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// FunnelAnnotationFormatter returns the annotation drawn beside a stage of a funnel from its value, its index,
+// and its share of the stage before it and of the first stage, from 0 to 1.
+type FunnelAnnotationFormatter func(v Value, index int, ofPrevious, ofFirst float64) string
+
+// FunnelAnnotationValue annotates stages with their value, i.e. `1,234`.
+func FunnelAnnotationValue(v Value, index int, ofPrevious, ofFirst float64) string {
+	return _pieValueFormat.Format(v.Value)
+}
+
+// FunnelAnnotationValueAndPercent annotates stages with their value and their share of the stage before,
+// i.e. `1,234 (42%)`; the first stage is annotated with its value.
+func FunnelAnnotationValueAndPercent(v Value, index int, ofPrevious, ofFirst float64) string {
+	if index == 0 {
+		return FunnelAnnotationValue(v, index, ofPrevious, ofFirst)
+	}
+	return _pieValueFormat.Format(v.Value) + " (" + _piePercentFormat.Format(ofPrevious*100) + "%)"
+}
+
+// FunnelAnnotationPercentOfFirst annotates stages with their share of the first stage, i.e. `12%`.
+func FunnelAnnotationPercentOfFirst(v Value, index int, ofPrevious, ofFirst float64) string {
+	return _piePercentFormat.Format(ofFirst*100) + "%"
+}
+
+// FunnelChart draws ordered stages, i.e. of a sales pipeline, as a funnel of segments from the top down,
+// each as wide as its value and tapering to the width of the next. Stages are labeled on the left, and
+// annotated on the right, by default with their value and their share of the stage before.
+type FunnelChart struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
+	// SegmentStyle is the style of the segments; the style of each value inherits from it.
+	SegmentStyle Style
+	// SegmentSpacing is the space between segments; it defaults to `DefaultFunnelSegmentSpacing`,
+	// and segments touch if it is negative.
+	SegmentSpacing int
+
+	// LabelStyle is the style of the stage labels; AnnotationStyle the style of the annotations.
+	LabelStyle      Style
+	AnnotationStyle Style
+	// AnnotationFormatter formats the annotations; it defaults to `FunnelAnnotationValueAndPercent`.
+	AnnotationFormatter FunnelAnnotationFormatter
+
+	Font        *truetype.Font
+	defaultFont *truetype.Font
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Values   []Value
+	Elements []Renderable
+}
+
+// GetDPI returns the dpi for the chart.
+func (fc FunnelChart) GetDPI(defaults ...float64) float64 {
+	if fc.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return fc.DPI
+}
+
+// GetFont returns the text font.
+func (fc FunnelChart) GetFont() *truetype.Font {
+	if fc.Font == nil {
+		return fc.defaultFont
+	}
+	return fc.Font
+}
+
+// GetWidth returns the chart width or the default value.
+func (fc FunnelChart) GetWidth() int {
+	if fc.Width == 0 {
+		return DefaultChartWidth
+	}
+	return fc.Width
+}
+
+// GetHeight returns the chart height or the default value.
+func (fc FunnelChart) GetHeight() int {
+	if fc.Height == 0 {
+		return DefaultChartHeight
+	}
+	return fc.Height
+}
+
+// GetSegmentSpacing returns the space between segments or a default.
+func (fc FunnelChart) GetSegmentSpacing() int {
+	if fc.SegmentSpacing == 0 {
+		return DefaultFunnelSegmentSpacing
+	}
+	return util.Math.MaxInt(fc.SegmentSpacing, 0)
+}
+
+// GetAnnotationFormatter returns the annotation formatter or a default.
+func (fc FunnelChart) GetAnnotationFormatter() FunnelAnnotationFormatter {
+	if fc.AnnotationFormatter != nil {
+		return fc.AnnotationFormatter
+	}
+	return FunnelAnnotationValueAndPercent
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (fc FunnelChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateFont(fc.Font, fc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(fc.Values) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one stage"))
+	}
+	for index, v := range fc.Values {
+		if math.IsNaN(v.Value) || math.IsInf(v.Value, 0) || v.Value < 0 {
+			errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "value must be finite and not negative", SeriesIndex: index, SeriesName: v.Label})
+		}
+	}
+	if len(errs) == 0 && fc.getMaxValue() == 0 {
+		errs = append(errs, newValidationError(ErrInvalidRange, "funnel chart must contain at least (1) positive value"))
+	}
+	return errs.asError()
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (fc FunnelChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = fc.Validate(); err != nil {
+		return err
+	}
+
+	r, err := rp(fc.GetWidth(), fc.GetHeight())
+	if err != nil {
+		return err
+	}
+
+	if fc.NoText {
+		r = textFreeRenderer{r}
+	} else if fc.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		fc.defaultFont = defaultFont
+	}
+	r.SetDPI(fc.GetDPI(DefaultDPI))
+
+	canvasBox := fc.getCanvasBox(r)
+	Draw.Box(r, Box{Right: fc.GetWidth(), Bottom: fc.GetHeight()}, fc.Background.InheritFrom(fc.styleDefaultsBackground()))
+	fc.BackgroundImage.draw(r, Box{Right: fc.GetWidth(), Bottom: fc.GetHeight()})
+	Draw.Box(r, canvasBox, fc.Canvas.InheritFrom(fc.styleDefaultsCanvas()))
+	fc.Watermark.draw(r, Box{Right: fc.GetWidth(), Bottom: fc.GetHeight()}, fc.GetFont(), fc.GetColorPalette(), false)
+	cl := ChartLayout{Canvas: canvasBox}
+	callRenderHook(fc.BeforeRender, r, cl)
+
+	annotations := fc.getAnnotations()
+	funnelBox := fc.getFunnelBox(r, canvasBox, annotations)
+	segments := fc.getSegments(funnelBox)
+	for index, v := range fc.Values {
+		fc.drawSegment(r, funnelBox, segments[index], index, v)
+	}
+	for index, v := range fc.Values {
+		fc.drawLabels(r, funnelBox, segments[index], v, annotations[index])
+	}
+
+	if len(fc.Title) > 0 && fc.TitleStyle.Show {
+		Draw.TextWithin(r, fc.Title, fc.Box(), fc.styleDefaultsTitle())
+	}
+	for _, a := range fc.Elements {
+		a(r, canvasBox, Style{Font: fc.GetFont()})
+	}
+	fc.Watermark.draw(r, Box{Right: fc.GetWidth(), Bottom: fc.GetHeight()}, fc.GetFont(), fc.GetColorPalette(), true)
+	callRenderHook(fc.AfterRender, r, cl)
+
+	setAccessibility(r, fc.Accessibility.withDefaults(fc.Title, func() string {
+		return describeValues("Funnel chart", fc.Values)
+	}))
+	return r.Save(w)
+}
+
+// funnelSegment is the trapezoid of a stage: its top and bottom, and its width at each.
+type funnelSegment struct {
+	Top, Bottom           int
+	TopWidth, BottomWidth float64
+}
+
+// getMaxValue returns the largest stage value, which is drawn the full width of the funnel.
+func (fc FunnelChart) getMaxValue() (max float64) {
+	for _, v := range fc.Values {
+		max = math.Max(max, v.Value)
+	}
+	return
+}
+
+// getAnnotations returns the annotation of each stage.
+func (fc FunnelChart) getAnnotations() []string {
+	formatter := fc.GetAnnotationFormatter()
+	annotations := make([]string, len(fc.Values))
+	for index, v := range fc.Values {
+		ofPrevious, ofFirst := 1.0, 1.0
+		if index > 0 {
+			ofPrevious, ofFirst = funnelShare(v.Value, fc.Values[index-1].Value), funnelShare(v.Value, fc.Values[0].Value)
+		}
+		annotations[index] = formatter(v, index, ofPrevious, ofFirst)
+	}
+	return annotations
+}
+
+// funnelShare returns the share of a value of another, or 0 if the other is 0.
+func funnelShare(value, of float64) float64 {
+	if of == 0 {
+		return 0
+	}
+	return value / of
+}
+
+// getCanvasBox returns the box of the chart below the title.
+func (fc FunnelChart) getCanvasBox(r Renderer) Box {
+	canvasBox := fc.Box()
+	if len(fc.Title) > 0 && fc.TitleStyle.Show {
+		fc.styleDefaultsTitle().GetTextOptions().WriteToRenderer(r)
+		lines := Text.WrapFit(r, fc.Title, canvasBox.Width(), fc.styleDefaultsTitle())
+		canvasBox.Top += Text.MeasureLines(r, lines, fc.styleDefaultsTitle()).Height() + DefaultLineSpacing
+	}
+	return canvasBox
+}
+
+// getFunnelBox returns the box of the segments, which leaves room for the labels on the left
+// and the annotations on the right.
+func (fc FunnelChart) getFunnelBox(r Renderer, canvasBox Box, annotations []string) Box {
+	funnelBox := canvasBox.Clone()
+	var labelWidth, annotationWidth int
+	for index, v := range fc.Values {
+		if len(v.Label) > 0 {
+			labelWidth = util.Math.MaxInt(labelWidth, Draw.MeasureText(r, v.Label, fc.getLabelStyle()).Width())
+		}
+		if len(annotations[index]) > 0 {
+			annotationWidth = util.Math.MaxInt(annotationWidth, Draw.MeasureText(r, annotations[index], fc.getAnnotationStyle()).Width())
+		}
+	}
+	if labelWidth > 0 {
+		funnelBox.Left += labelWidth + DefaultYAxisMargin
+	}
+	if annotationWidth > 0 {
+		funnelBox.Right -= annotationWidth + DefaultYAxisMargin
+	}
+	return funnelBox
+}
+
+// getSegments returns the segment of each stage, stacked from the top of a box down.
+func (fc FunnelChart) getSegments(funnelBox Box) []funnelSegment {
+	spacing := fc.GetSegmentSpacing()
+	count := len(fc.Values)
+	height := float64(funnelBox.Height()-spacing*(count-1)) / float64(count)
+	max := fc.getMaxValue()
+
+	segments := make([]funnelSegment, count)
+	for index, v := range fc.Values {
+		next := v.Value
+		if index < count-1 {
+			next = fc.Values[index+1].Value
+		}
+		top := float64(funnelBox.Top) + float64(index)*(height+float64(spacing))
+		segments[index] = funnelSegment{
+			Top:         int(math.Round(top)),
+			Bottom:      int(math.Round(top + height)),
+			TopWidth:    float64(funnelBox.Width()) * v.Value / max,
+			BottomWidth: float64(funnelBox.Width()) * next / max,
+		}
+	}
+	return segments
+}
+
+// drawSegment draws the segment of a stage, centered in the funnel.
+func (fc FunnelChart) drawSegment(r Renderer, funnelBox Box, segment funnelSegment, index int, v Value) {
+	style := v.Style.InheritFrom(fc.SegmentStyle.InheritFrom(fc.styleDefaultsSegment(index)))
+	cx := float64(funnelBox.Left) + float64(funnelBox.Width())/2
+	style.GetFillAndStrokeOptions().WriteToRenderer(r)
+	r.Polygon([]Point{
+		{X: int(math.Round(cx - segment.TopWidth/2)), Y: segment.Top},
+		{X: int(math.Round(cx + segment.TopWidth/2)), Y: segment.Top},
+		{X: int(math.Round(cx + segment.BottomWidth/2)), Y: segment.Bottom},
+		{X: int(math.Round(cx - segment.BottomWidth/2)), Y: segment.Bottom},
+	})
+	if style.ShouldDrawStroke() {
+		r.FillStroke()
+	} else {
+		r.Fill()
+	}
+}
+
+// drawLabels draws the label of a stage left of the funnel and its annotation right of it, level with the middle of its segment.
+func (fc FunnelChart) drawLabels(r Renderer, funnelBox Box, segment funnelSegment, v Value, annotation string) {
+	y := (segment.Top + segment.Bottom) >> 1
+	if len(v.Label) > 0 {
+		Draw.TextAnchored(r, v.Label, funnelBox.Left-DefaultYAxisMargin, y, withTextAnchor(fc.getLabelStyle(), TextHorizontalAlignRight, TextVerticalAlignMiddle))
+	}
+	if len(annotation) > 0 {
+		Draw.TextAnchored(r, annotation, funnelBox.Right+DefaultYAxisMargin, y, withTextAnchor(fc.getAnnotationStyle(), TextHorizontalAlignLeft, TextVerticalAlignMiddle))
+	}
+}
+
+func (fc FunnelChart) getLabelStyle() Style {
+	return fc.LabelStyle.InheritFrom(fc.styleDefaultsLabel())
+}
+
+func (fc FunnelChart) getAnnotationStyle() Style {
+	return fc.AnnotationStyle.InheritFrom(fc.styleDefaultsLabel())
+}
+
+func (fc FunnelChart) styleDefaultsBackground() Style {
+	return Style{
+		FillColor:   fc.GetColorPalette().BackgroundColor(),
+		StrokeColor: fc.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (fc FunnelChart) styleDefaultsCanvas() Style {
+	return Style{
+		FillColor:   fc.GetColorPalette().CanvasColor(),
+		StrokeColor: fc.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (fc FunnelChart) styleDefaultsSegment(index int) Style {
+	return Style{
+		FillColor: fc.GetColorPalette().GetSeriesColor(index),
+	}
+}
+
+func (fc FunnelChart) styleDefaultsLabel() Style {
+	return Style{
+		FontSize:  DefaultFontSize,
+		FontColor: fc.GetColorPalette().TextColor(),
+		Font:      fc.GetFont(),
+	}
+}
+
+func (fc FunnelChart) styleDefaultsTitle() Style {
+	return fc.TitleStyle.InheritFrom(Style{
+		FontColor:           fc.GetColorPalette().TextColor(),
+		Font:                fc.GetFont(),
+		FontSize:            fc.getTitleFontSize(),
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+}
+
+func (fc FunnelChart) getTitleFontSize() float64 {
+	effectiveDimension := util.Math.MinInt(fc.GetWidth(), fc.GetHeight())
+	if effectiveDimension >= 2048 {
+		return 48
+	} else if effectiveDimension >= 1024 {
+		return 24
+	} else if effectiveDimension >= 512 {
+		return 18
+	} else if effectiveDimension >= 256 {
+		return 12
+	}
+	return 10
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (fc FunnelChart) GetColorPalette() ColorPalette {
+	if fc.ColorPalette != nil {
+		return fc.ColorPalette
+	}
+	return AlternateColorPalette
+}
+
+// Box returns the chart bounds as a box.
+func (fc FunnelChart) Box() Box {
+	dpr := fc.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
+	dpb := fc.Background.Padding.GetBottom(DefaultBackgroundPadding.Bottom)
+
+	return Box{
+		Top:    fc.Background.Padding.GetTop(DefaultBackgroundPadding.Top),
+		Left:   fc.Background.Padding.GetLeft(DefaultBackgroundPadding.Left),
+		Right:  fc.GetWidth() - dpr,
+		Bottom: fc.GetHeight() - dpb,
+	}
+}
@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestFunnelChartRender(t *testing.T) {
+	assert := assert.New(t)
+
+	fc := FunnelChart{
+		Values: []Value{
+			{Label: "Visits", Value: 2000},
+			{Label: "Sign ups", Value: 500},
+			{Label: "Purchases", Value: 125},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(fc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	buf.Reset()
+	assert.Nil(fc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "Sign ups"))
+	assert.True(strings.Contains(buf.String(), "2,000"))
+	assert.True(strings.Contains(buf.String(), "125 (25%)"))
+
+	assert.NotNil(FunnelChart{}.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestFunnelChartAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	fc := FunnelChart{Values: []Value{{Value: 200}, {Value: 50}, {Value: 10}}}
+	assert.Equal([]string{"200", "50 (25%)", "10 (20%)"}, fc.getAnnotations())
+
+	fc.AnnotationFormatter = FunnelAnnotationPercentOfFirst
+	assert.Equal([]string{"100%", "25%", "5%"}, fc.getAnnotations())
+
+	fc = FunnelChart{Values: []Value{{Value: 0}, {Value: 5}}, AnnotationFormatter: FunnelAnnotationPercentOfFirst}
+	assert.Equal("0%", fc.getAnnotations()[1])
+}
+
+func TestFunnelChartGetSegments(t *testing.T) {
+	assert := assert.New(t)
+
+	fc := FunnelChart{SegmentSpacing: 10, Values: []Value{{Value: 100}, {Value: 50}, {Value: 25}}}
+	segments := fc.getSegments(Box{Left: 0, Top: 0, Right: 200, Bottom: 320})
+	assert.Len(segments, 3)
+	assert.Equal(0, segments[0].Top)
+	assert.Equal(100, segments[0].Bottom)
+	assert.Equal(110, segments[1].Top)
+	assert.Equal(320, segments[2].Bottom)
+	assert.Equal(200.0, segments[0].TopWidth)
+	assert.Equal(100.0, segments[0].BottomWidth)
+	assert.Equal(50.0, segments[2].TopWidth)
+	assert.Equal(50.0, segments[2].BottomWidth)
+
+	fc.SegmentSpacing = -1
+	segments = fc.getSegments(Box{Left: 0, Top: 0, Right: 200, Bottom: 300})
+	assert.Equal(segments[0].Bottom, segments[1].Top)
+}
+
+func TestFunnelChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	err := FunnelChart{}.Validate()
+	assert.True(errors.Is(err, ErrEmptySeries))
+
+	err = FunnelChart{Values: []Value{{Value: 0}, {Value: 0}}}.Validate()
+	assert.True(errors.Is(err, ErrInvalidRange))
+
+	err = FunnelChart{Values: []Value{{Value: 10}, {Label: "bad", Value: math.NaN()}, {Value: -1}}}.Validate()
+	assert.True(errors.Is(err, ErrInvalidRange))
+	var errs ValidationErrors
+	assert.True(errors.As(err, &errs))
+	assert.Len(errs, 2)
+	assert.Equal("bad", errs[0].SeriesName)
+
+	assert.Nil(FunnelChart{Values: []Value{{Value: 10}, {Value: 0}}}.Validate())
+}
@@ -0,0 +1,79 @@
+package chart
+
+import "math"
+
+// GapPolicy is how a continuous series is drawn across its missing values: NaN y values,
+// or y values equal to one of the series' `GapValues`.
+type GapPolicy int
+
+const (
+	// GapPolicySkip connects the points either side of a missing value, as if it weren't there; it is the default.
+	GapPolicySkip GapPolicy = iota
+	// GapPolicyBreak breaks the line at a missing value, and splits its fill there.
+	GapPolicyBreak
+	// GapPolicyZero draws a missing value as zero.
+	GapPolicyZero
+)
+
+// gapValue returns a y value with missing values as NaN, or as zero for `GapPolicyZero`.
+func gapValue(y float64, policy GapPolicy, sentinels []float64) float64 {
+	if !math.IsNaN(y) {
+		isSentinel := false
+		for _, sentinel := range sentinels {
+			if y == sentinel {
+				isSentinel = true
+				break
+			}
+		}
+		if !isSentinel {
+			return y
+		}
+	}
+	if policy == GapPolicyZero {
+		return 0
+	}
+	return math.NaN()
+}
+
+// gapRuns returns the [start, end) runs of drawable points of a values provider between its missing values.
+func gapRuns(vs ValuesProvider) (runs [][2]int) {
+	start := -1
+	for index := 0; index < vs.Len(); index++ {
+		if isFinitePoint(vs.GetValues(index)) {
+			if start < 0 {
+				start = index
+			}
+			continue
+		}
+		if start >= 0 {
+			runs = append(runs, [2]int{start, index})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		runs = append(runs, [2]int{start, vs.Len()})
+	}
+	return
+}
+
+// valuesRun is a run of the values of a provider, from `start` up to `end`.
+type valuesRun struct {
+	ValuesProvider
+	start, end int
+}
+
+// Len returns the number of values in the run.
+func (vr valuesRun) Len() int {
+	return vr.end - vr.start
+}
+
+// GetValues returns the values at an index of the run.
+func (vr valuesRun) GetValues(index int) (float64, float64) {
+	return vr.ValuesProvider.GetValues(vr.start + index)
+}
+
+// GetValuesInto copies the values of the run starting at an index into the given slices.
+func (vr valuesRun) GetValuesInto(start int, xvalues, yvalues []float64) int {
+	count := valuesIntoCount(vr.Len(), start, xvalues, yvalues)
+	return GetValuesInto(vr.ValuesProvider, vr.start+start, xvalues[:count], yvalues[:count])
+}
@@ -0,0 +1,106 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestGapValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(1.0, gapValue(1, GapPolicyBreak, nil))
+	assert.True(math.IsNaN(gapValue(math.NaN(), GapPolicyBreak, nil)))
+	assert.True(math.IsNaN(gapValue(-999, GapPolicySkip, []float64{-999})))
+	assert.Equal(0.0, gapValue(-999, GapPolicyZero, []float64{-999}))
+	assert.Equal(0.0, gapValue(math.NaN(), GapPolicyZero, nil))
+}
+
+func TestGapRuns(t *testing.T) {
+	assert := assert.New(t)
+
+	nan := math.NaN()
+	cs := ContinuousSeries{XValues: []float64{1, 2, 3, 4, 5, 6}, YValues: []float64{nan, 1, 2, nan, nan, 3}}
+	assert.Equal([][2]int{{1, 3}, {5, 6}}, gapRuns(cs))
+
+	run := valuesRun{ValuesProvider: cs, start: 1, end: 3}
+	assert.Equal(2, run.Len())
+	x, y := run.GetValues(1)
+	assert.Equal(3.0, x)
+	assert.Equal(2.0, y)
+
+	xvalues, yvalues := make([]float64, 4), make([]float64, 4)
+	assert.Equal(2, GetValuesInto(run, 0, xvalues, yvalues))
+	assert.Equal([]float64{2, 3, 0, 0}, xvalues)
+}
+
+func TestContinuousSeriesGapValues(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, -999, 3}, GapValues: []float64{-999}}
+	_, y := cs.GetValues(1)
+	assert.True(math.IsNaN(y))
+
+	cs.GapPolicy = GapPolicyZero
+	_, y = cs.GetValues(1)
+	assert.Zero(y)
+	xvalues, yvalues := make([]float64, 3), make([]float64, 3)
+	cs.GetValuesInto(0, xvalues, yvalues)
+	assert.Equal([]float64{1, 0, 3}, yvalues)
+
+	// sentinels are left out of the y range, rather than stretching it.
+	c := Chart{Series: []Series{ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, -999, 3}, GapValues: []float64{-999}}}}
+	_, yrange, _ := c.getRanges()
+	assert.Equal(1.0, yrange.GetMin())
+}
+
+func TestContinuousSeriesGapPolicyBreak(t *testing.T) {
+	assert := assert.New(t)
+
+	render := func(policy GapPolicy) string {
+		c := Chart{
+			Series: []Series{
+				ContinuousSeries{
+					Style:     Style{Show: true, StrokeColor: ColorBlue, StrokeWidth: 2, FillColor: ColorBlue.WithAlpha(64)},
+					GapPolicy: policy,
+					XValues:   []float64{1, 2, 3, 4, 5, 6},
+					YValues:   []float64{1, 2, 3, math.NaN(), 2, 1},
+				},
+			},
+		}
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(SVG, buffer))
+		return buffer.String()
+	}
+
+	fill := "fill:rgba(0,116,217,0.3)"
+	// the line is filled once across the gap when it is skipped, and once either side of it when broken.
+	assert.Equal(1, strings.Count(render(GapPolicySkip), fill))
+	assert.Equal(2, strings.Count(render(GapPolicyBreak), fill))
+}
+
+func TestContinuousSeriesGapPolicyStyleProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	var indexes []int
+	cs := ContinuousSeries{
+		GapPolicy: GapPolicyBreak,
+		XValues:   []float64{1, 2, 3, 4, 5},
+		YValues:   []float64{1, 2, math.NaN(), 4, 5},
+		StyleProvider: func(index int, x, y float64) Style {
+			indexes = append(indexes, index)
+			assert.Equal(float64(index+1), x)
+			return Style{}
+		},
+	}
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+	cs.Render(r, NewBox(0, 0, 100, 100), &ContinuousRange{Min: 1, Max: 5, Domain: 100}, &ContinuousRange{Min: 1, Max: 5, Domain: 100}, Style{StrokeColor: ColorBlue, StrokeWidth: 1})
+	assert.True(len(indexes) > 0)
+	for _, index := range indexes {
+		assert.NotEqual(2, index)
+	}
+}
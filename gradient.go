@@ -0,0 +1,161 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	util "github.com/blendlabs/go-util"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// GradientStop is a color at an offset along a gradient, from 0 at its start to 1 at its end.
+type GradientStop struct {
+	Offset float64
+	Color  drawing.Color
+}
+
+// Gradient is a fill that blends between colors across the shape it fills.
+// Like an svg gradient in `objectBoundingBox` units, it is stretched over the bounding box of the shape.
+type Gradient struct {
+	// Stops are the colors of the gradient, in order of offset.
+	Stops []GradientStop
+	// AngleDegrees is the direction of a linear gradient, clockwise from left to right;
+	// 90 runs from the top of the shape to its bottom.
+	AngleDegrees float64
+	// Radial makes the gradient run from the center of the shape out to its edges instead.
+	Radial bool
+}
+
+// NewLinearGradient returns a linear gradient through evenly spaced colors.
+func NewLinearGradient(angleDegrees float64, colors ...drawing.Color) *Gradient {
+	return &Gradient{
+		Stops:        evenGradientStops(colors),
+		AngleDegrees: angleDegrees,
+	}
+}
+
+// NewRadialGradient returns a radial gradient through evenly spaced colors, from the center out.
+func NewRadialGradient(colors ...drawing.Color) *Gradient {
+	return &Gradient{
+		Stops:  evenGradientStops(colors),
+		Radial: true,
+	}
+}
+
+func evenGradientStops(colors []drawing.Color) []GradientStop {
+	stops := make([]GradientStop, len(colors))
+	for index, c := range colors {
+		if len(colors) > 1 {
+			stops[index].Offset = float64(index) / float64(len(colors)-1)
+		}
+		stops[index].Color = c
+	}
+	return stops
+}
+
+// ColorAt returns the color at an offset along the gradient, blending the stops either side of it.
+// Offsets before the first stop or after the last take the color of that stop.
+func (g Gradient) ColorAt(offset float64) drawing.Color {
+	if len(g.Stops) == 0 {
+		return drawing.ColorTransparent
+	}
+	if offset <= g.Stops[0].Offset {
+		return g.Stops[0].Color
+	}
+	for index := 1; index < len(g.Stops); index++ {
+		start, end := g.Stops[index-1], g.Stops[index]
+		if offset > end.Offset {
+			continue
+		}
+		if end.Offset <= start.Offset {
+			return end.Color
+		}
+		t := (offset - start.Offset) / (end.Offset - start.Offset)
+		return drawing.Color{
+			R: lerpColorChannel(start.Color.R, end.Color.R, t),
+			G: lerpColorChannel(start.Color.G, end.Color.G, t),
+			B: lerpColorChannel(start.Color.B, end.Color.B, t),
+			A: lerpColorChannel(start.Color.A, end.Color.A, t),
+		}
+	}
+	return g.Stops[len(g.Stops)-1].Color
+}
+
+func lerpColorChannel(from, to uint8, t float64) uint8 {
+	return uint8(math.Floor(float64(from) + (float64(to)-float64(from))*t + 0.5))
+}
+
+// getDirection returns the unit vector of a linear gradient, and half of the extent of the unit square along it.
+func (g Gradient) getDirection() (dx, dy, halfExtent float64) {
+	radians := util.Math.DegreesToRadians(g.AngleDegrees)
+	dx, dy = math.Cos(radians), math.Sin(radians)
+	halfExtent = (math.Abs(dx) + math.Abs(dy)) / 2
+	return
+}
+
+// offsetAt returns the offset of the gradient at a point, for a gradient stretched over a rectangle.
+func (g Gradient) offsetAt(left, top, width, height, x, y float64) float64 {
+	u, v := 0.5, 0.5
+	if width > 0 {
+		u = (x - left) / width
+	}
+	if height > 0 {
+		v = (y - top) / height
+	}
+	if g.Radial {
+		return 2 * math.Hypot(u-0.5, v-0.5)
+	}
+	dx, dy, halfExtent := g.getDirection()
+	return ((u-0.5)*dx+(v-0.5)*dy)/(2*halfExtent) + 0.5
+}
+
+// gradientRenderer is a renderer that can fill with gradients.
+type gradientRenderer interface {
+	// SetFillGradient sets the gradient to fill with until the fill color is next set.
+	// The gradient is stretched over `box`, or over each shape it fills if the box is zero.
+	SetFillGradient(g *Gradient, box Box)
+}
+
+// setFillGradient sets the gradient to fill with, if the renderer supports it.
+// Other renderers fill with the middle color of the gradient, unless the style has its own fill color.
+func setFillGradient(r Renderer, s Style, box Box) {
+	if s.FillGradient == nil {
+		return
+	}
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if or, isOffset := r.(offsetRenderer); isOffset && !box.IsZero() {
+		r, box = or.Renderer, box.Shift(or.dx, or.dy)
+	}
+	if gr, isGradientRenderer := r.(gradientRenderer); isGradientRenderer {
+		gr.SetFillGradient(s.FillGradient, box)
+		return
+	}
+	if s.FillColor.IsZero() {
+		r.SetFillColor(s.FillGradient.ColorAt(0.5))
+	}
+}
+
+// gradientImage is an unbounded image of a gradient stretched over a rectangle of pixels.
+type gradientImage struct {
+	gradient Gradient
+	rect     image.Rectangle
+}
+
+// ColorModel implements image.Image.
+func (gi gradientImage) ColorModel() color.Model {
+	return color.NRGBAModel
+}
+
+// Bounds implements image.Image.
+func (gi gradientImage) Bounds() image.Rectangle {
+	return image.Rect(-1e9, -1e9, 1e9, 1e9)
+}
+
+// At implements image.Image; pixels are sampled at their centers.
+func (gi gradientImage) At(x, y int) color.Color {
+	offset := gi.gradient.offsetAt(float64(gi.rect.Min.X), float64(gi.rect.Min.Y), float64(gi.rect.Dx()), float64(gi.rect.Dy()), float64(x)+0.5, float64(y)+0.5)
+	return gi.gradient.ColorAt(offset)
+}
@@ -0,0 +1,133 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestGradientColorAt(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewLinearGradient(0, drawing.ColorBlack, drawing.ColorWhite)
+	assert.Len(g.Stops, 2)
+	assert.Equal(1.0, g.Stops[1].Offset)
+
+	assert.Equal(drawing.ColorBlack, g.ColorAt(-1))
+	assert.Equal(drawing.ColorWhite, g.ColorAt(2))
+	assert.Equal(drawing.Color{R: 128, G: 128, B: 128, A: 255}, g.ColorAt(0.5))
+
+	assert.Equal(drawing.ColorTransparent, Gradient{}.ColorAt(0.5))
+}
+
+func TestGradientOffsetAt(t *testing.T) {
+	assert := assert.New(t)
+
+	vertical := NewLinearGradient(90, drawing.ColorBlack, drawing.ColorWhite)
+	assert.InDelta(0, vertical.offsetAt(0, 0, 100, 50, 30, 0), 1e-9)
+	assert.InDelta(0.5, vertical.offsetAt(0, 0, 100, 50, 70, 25), 1e-9)
+	assert.InDelta(1, vertical.offsetAt(0, 0, 100, 50, 10, 50), 1e-9)
+
+	// a diagonal runs corner to corner, whatever the shape of the box.
+	diagonal := NewLinearGradient(45, drawing.ColorBlack, drawing.ColorWhite)
+	assert.InDelta(0, diagonal.offsetAt(0, 0, 100, 50, 0, 0), 1e-9)
+	assert.InDelta(1, diagonal.offsetAt(0, 0, 100, 50, 100, 50), 1e-9)
+
+	radial := NewRadialGradient(drawing.ColorBlack, drawing.ColorWhite)
+	assert.InDelta(0, radial.offsetAt(0, 0, 100, 50, 50, 25), 1e-9)
+	assert.InDelta(1, radial.offsetAt(0, 0, 100, 50, 100, 25), 1e-9)
+}
+
+func TestRasterRendererFillGradient(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+
+	Draw.Box(r, Box{Top: 10, Left: 10, Right: 90, Bottom: 90}, Style{
+		FillColor:    drawing.ColorRed,
+		FillGradient: NewLinearGradient(90, drawing.ColorBlack, drawing.ColorWhite),
+	})
+
+	img := r.(*rasterRenderer).i
+	top, bottom := img.RGBAAt(50, 11), img.RGBAAt(50, 88)
+	assert.True(top.R < 16)
+	assert.True(bottom.R > 240)
+	assert.Equal(top.R, top.G)
+	assert.Equal(uint8(0), img.RGBAAt(50, 5).A)
+
+	// setting the fill color clears the gradient.
+	r.SetFillColor(drawing.ColorRed)
+	r.MoveTo(0, 0)
+	r.LineTo(5, 0)
+	r.LineTo(5, 5)
+	r.LineTo(0, 5)
+	r.Close()
+	r.Fill()
+	assert.Equal(drawing.ColorRed.R, img.RGBAAt(2, 2).R)
+	assert.Equal(uint8(0), img.RGBAAt(2, 2).G)
+}
+
+func TestRasterRendererFillGradientBox(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+
+	// the gradient spans the box, so the half of it filled is dark.
+	setFillGradient(r, Style{FillGradient: NewLinearGradient(90, drawing.ColorBlack, drawing.ColorWhite)}, Box{Top: 0, Left: 0, Right: 100, Bottom: 100})
+	r.MoveTo(0, 0)
+	r.LineTo(100, 0)
+	r.LineTo(100, 50)
+	r.LineTo(0, 50)
+	r.Close()
+	r.Fill()
+
+	img := r.(*rasterRenderer).i
+	assert.True(img.RGBAAt(50, 49).R < 136)
+}
+
+func TestVectorRendererFillGradient(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := SVG(100, 100)
+	assert.Nil(err)
+
+	Draw.Box(r, Box{Top: 10, Left: 10, Right: 90, Bottom: 90}, Style{
+		FillGradient: NewLinearGradient(90, drawing.ColorBlack, drawing.ColorWhite),
+	})
+	Draw.Box(r, Box{Top: 10, Left: 10, Right: 90, Bottom: 90}, Style{
+		FillGradient: NewRadialGradient(drawing.ColorBlack, drawing.ColorWhite),
+	})
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	svg := buffer.String()
+	assert.True(strings.Contains(svg, `<linearGradient id="gradient-1" x1="0.5000" y1="0.0000" x2="0.5000" y2="1.0000">`))
+	assert.True(strings.Contains(svg, `<stop offset="1.0000" stop-color="rgba(255,255,255,1.0)"/>`))
+	assert.True(strings.Contains(svg, "fill:url(#gradient-1)"))
+	assert.True(strings.Contains(svg, `<radialGradient id="gradient-2"`))
+	assert.True(strings.Contains(svg, "fill:url(#gradient-2)"))
+}
+
+func TestSetFillGradientFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+
+	setFillGradient(r, Style{FillGradient: NewLinearGradient(0, drawing.ColorBlack, drawing.ColorWhite)}, Box{})
+	assert.Equal(drawing.Color{R: 128, G: 128, B: 128, A: 255}, r.(*pdfRenderer).s.FillColor)
+}
+
+func TestGradientImage(t *testing.T) {
+	assert := assert.New(t)
+
+	gi := gradientImage{gradient: *NewLinearGradient(0, drawing.ColorBlack, drawing.ColorWhite), rect: image.Rect(0, 0, 10, 10)}
+	assert.Equal(drawing.ColorBlack, gi.At(-5, 0))
+	assert.Equal(drawing.ColorWhite, gi.At(20, 0))
+}
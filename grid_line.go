@@ -1,5 +1,18 @@
 package chart
 
+import "sort"
+
+// GridPlacement is whether the grid lines of a chart are drawn below or above its series.
+type GridPlacement int
+
+const (
+	// GridPlacementBelowSeries draws the grid lines before the series, so the series cover them; it is the default.
+	GridPlacementBelowSeries GridPlacement = iota
+	// GridPlacementAboveSeries draws the grid lines and the zero line after the series, so they can be read across
+	// filled series, i.e. areas and bars. Grid bands are drawn below the series either way.
+	GridPlacementAboveSeries
+)
+
 // GridLineProvider is a type that provides grid lines.
 type GridLineProvider interface {
 	GetGridLines(ticks []Tick, isVertical bool, majorStyle, minorStyle Style) []GridLine
@@ -70,3 +83,31 @@ func GenerateGridLines(ticks []Tick, majorStyle, minorStyle Style) []GridLine {
 	}
 	return gl
 }
+
+// drawGridBands shades every other band between the major grid lines of an axis, from the second band from the
+// start of the range on, so the bands alternate out from the axis.
+func drawGridBands(r Renderer, canvasBox Box, ra Range, isVertical bool, gridLines []GridLine, style Style) {
+	edges := []float64{ra.GetMin(), ra.GetMax()}
+	for _, gl := range gridLines {
+		if !gl.IsMinor && gl.Value > ra.GetMin() && gl.Value < ra.GetMax() {
+			edges = append(edges, gl.Value)
+		}
+	}
+	sort.Float64s(edges)
+	for index := 1; index+1 < len(edges); index += 2 {
+		from, to := ra.Translate(edges[index]), ra.Translate(edges[index+1])
+		if from > to {
+			from, to = to, from
+		}
+		if isVertical {
+			Draw.Box(r, Box{Top: canvasBox.Top, Left: canvasBox.Left + from, Right: canvasBox.Left + to, Bottom: canvasBox.Bottom}, style)
+		} else {
+			Draw.Box(r, Box{Top: canvasBox.Bottom - to, Left: canvasBox.Left, Right: canvasBox.Right, Bottom: canvasBox.Bottom - from}, style)
+		}
+	}
+}
+
+// isInRange returns if a value is within the bounds of a range.
+func isInRange(ra Range, value float64) bool {
+	return value >= ra.GetMin() && value <= ra.GetMax()
+}
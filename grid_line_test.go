@@ -1,6 +1,8 @@
 package chart
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/blendlabs/go-assert"
@@ -22,3 +24,48 @@ func TestGenerateGridLines(t *testing.T) {
 	assert.Equal(2.0, gl[0].Value)
 	assert.Equal(3.0, gl[1].Value)
 }
+
+func TestDrawGridBands(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := SVG(100, 100)
+	assert.Nil(err)
+	ra := &ContinuousRange{Min: 0, Max: 5, Domain: 100}
+	gridLines := []GridLine{{Value: 1}, {Value: 2, IsMinor: true}, {Value: 3}, {Value: 4}}
+	drawGridBands(r, Box{Right: 100, Bottom: 100}, ra, false, gridLines, Style{FillColor: ColorRed})
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	// the edges are 0, 1, 3, 4 and 5; the bands are 1 to 3 and 4 to 5.
+	svg := buffer.String()
+	assert.Equal(2, strings.Count(svg, "fill:rgba(217,0,116,1.0)"), svg)
+	assert.True(strings.Contains(svg, "M 0 40\nL 100 40\nL 100 80\nL 0 80"), svg)
+}
+
+func TestChartGridPlacement(t *testing.T) {
+	assert := assert.New(t)
+
+	render := func(placement GridPlacement) string {
+		c := Chart{
+			GridPlacement: placement,
+			YAxis: YAxis{
+				Style:          Style{Show: true},
+				GridMajorStyle: Style{Show: true, StrokeColor: ColorRed, StrokeWidth: 1},
+				Zero:           GridLine{Style: Style{Show: true, StrokeColor: ColorGreen}},
+			},
+			Series: []Series{ContinuousSeries{Style: Style{Show: true, StrokeColor: ColorBlue, FillColor: ColorBlue}, XValues: []float64{0, 1, 2}, YValues: []float64{-1, 2, 1}}},
+		}
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(SVG, buffer))
+		return buffer.String()
+	}
+
+	below := render(GridPlacementBelowSeries)
+	grid, zero, series := strings.Index(below, "stroke:rgba(217,0,116,1.0)"), strings.Index(below, "stroke:rgba(0,217,101,1.0)"), strings.Index(below, "fill:rgba(0,116,217,1.0)")
+	assert.True(grid > 0 && zero > grid && series > zero, below)
+	assert.True(strings.Contains(below, "stroke-width:1.5;stroke:rgba(0,217,101,1.0)"), below)
+
+	above := render(GridPlacementAboveSeries)
+	grid, zero, series = strings.Index(above, "stroke:rgba(217,0,116,1.0)"), strings.Index(above, "stroke:rgba(0,217,101,1.0)"), strings.Index(above, "fill:rgba(0,116,217,1.0)")
+	assert.True(series > 0 && grid > series && zero > grid, above)
+}
@@ -0,0 +1,482 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// HeatMap is a chart that draws a grid of values as cells colored by a color scale,
+// with the first row at the top and the first column on the left.
+type HeatMap struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
+	// CellStyle is the style of the cells; their fill color comes from the color scale.
+	CellStyle Style
+
+	// ColorScale maps values to colors; it defaults to `Viridis`.
+	ColorScale ColorScale
+	// ValueRange is the range of values the color scale spans; it defaults to the smallest and largest value.
+	ValueRange     Range
+	ValueFormatter ValueFormatter
+
+	// XAxis is the style of the column labels and YAxis the style of the row labels; they are drawn if shown.
+	XAxis Style
+	YAxis Style
+
+	// ValueStyle is the style of the value labels of the cells; they are drawn if shown.
+	ValueStyle Style
+
+	// LegendStyle is the style of the color legend bar right of the cells; it is drawn if shown.
+	LegendStyle Style
+
+	Font        *truetype.Font
+	defaultFont *truetype.Font
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	// Rows and Columns are the labels of the rows and columns.
+	Rows    []string
+	Columns []string
+
+	// Values are the values of the cells by row, then column; NaN cells are not drawn.
+	Values   [][]float64
+	Elements []Renderable
+}
+
+// GetDPI returns the dpi for the chart.
+func (hm HeatMap) GetDPI(defaults ...float64) float64 {
+	if hm.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return hm.DPI
+}
+
+// GetFont returns the text font.
+func (hm HeatMap) GetFont() *truetype.Font {
+	if hm.Font == nil {
+		return hm.defaultFont
+	}
+	return hm.Font
+}
+
+// GetWidth returns the chart width or the default value.
+func (hm HeatMap) GetWidth() int {
+	if hm.Width == 0 {
+		return DefaultChartWidth
+	}
+	return hm.Width
+}
+
+// GetHeight returns the chart height or the default value.
+func (hm HeatMap) GetHeight() int {
+	if hm.Height == 0 {
+		return DefaultChartHeight
+	}
+	return hm.Height
+}
+
+// GetColorScale returns the color scale or a default.
+func (hm HeatMap) GetColorScale() ColorScale {
+	if hm.ColorScale != nil {
+		return hm.ColorScale
+	}
+	return Viridis
+}
+
+// GetValueFormatter returns the value formatter or a default.
+func (hm HeatMap) GetValueFormatter() ValueFormatter {
+	if hm.ValueFormatter != nil {
+		return hm.ValueFormatter
+	}
+	return Unit{}.Format
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (hm HeatMap) Validate() error {
+	var errs ValidationErrors
+	if err := validateFont(hm.Font, hm.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if hm.getColumnCount() == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one value"))
+	}
+	if _, err := hm.getValueRange(); err != nil {
+		errs = append(errs, err.(*ValidationError))
+	}
+	return errs.asError()
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (hm HeatMap) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = hm.Validate(); err != nil {
+		return err
+	}
+	vr, _ := hm.getValueRange()
+
+	r, err := rp(hm.GetWidth(), hm.GetHeight())
+	if err != nil {
+		return err
+	}
+
+	if hm.NoText {
+		r = textFreeRenderer{r}
+	} else if hm.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		hm.defaultFont = defaultFont
+	}
+	r.SetDPI(hm.GetDPI(DefaultDPI))
+
+	canvasBox, legendTicks := hm.getCanvasBox(r, vr)
+	Draw.Box(r, Box{Right: hm.GetWidth(), Bottom: hm.GetHeight()}, hm.Background.InheritFrom(hm.styleDefaultsBackground()))
+	hm.BackgroundImage.draw(r, Box{Right: hm.GetWidth(), Bottom: hm.GetHeight()})
+	Draw.Box(r, canvasBox, hm.Canvas.InheritFrom(hm.styleDefaultsCanvas()))
+	hm.Watermark.draw(r, Box{Right: hm.GetWidth(), Bottom: hm.GetHeight()}, hm.GetFont(), hm.GetColorPalette(), false)
+	cl := ChartLayout{Canvas: canvasBox, YRange: vr, YTicks: legendTicks}
+	callRenderHook(hm.BeforeRender, r, cl)
+
+	hm.drawCells(r, canvasBox, vr)
+	hm.drawColumnLabels(r, canvasBox)
+	hm.drawRowLabels(r, canvasBox)
+	if hm.LegendStyle.Show {
+		hm.drawLegend(r, canvasBox, vr, legendTicks)
+	}
+
+	if len(hm.Title) > 0 && hm.TitleStyle.Show {
+		Draw.TextWithin(r, hm.Title, hm.Box(), hm.styleDefaultsTitle())
+	}
+	for _, a := range hm.Elements {
+		a(r, canvasBox, Style{Font: hm.GetFont()})
+	}
+	hm.Watermark.draw(r, Box{Right: hm.GetWidth(), Bottom: hm.GetHeight()}, hm.GetFont(), hm.GetColorPalette(), true)
+	callRenderHook(hm.AfterRender, r, cl)
+
+	setAccessibility(r, hm.Accessibility.withDefaults(hm.Title, func() string {
+		return fmt.Sprintf("Heat map of %d rows and %d columns, with values from %s to %s.",
+			len(hm.Values), hm.getColumnCount(), FloatValueFormatter(vr.GetMin()), FloatValueFormatter(vr.GetMax()))
+	}))
+	return r.Save(w)
+}
+
+// getColumnCount returns the number of columns; the length of the longest row.
+func (hm HeatMap) getColumnCount() (columns int) {
+	for _, row := range hm.Values {
+		columns = util.Math.MaxInt(columns, len(row))
+	}
+	return
+}
+
+// getValueRange returns the range the color scale spans.
+func (hm HeatMap) getValueRange() (Range, error) {
+	if hm.ValueRange != nil && !hm.ValueRange.IsZero() {
+		return hm.ValueRange, nil
+	}
+	min, max := math.MaxFloat64, -math.MaxFloat64
+	for _, row := range hm.Values {
+		for _, v := range row {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				min, max = math.Min(min, v), math.Max(max, v)
+			}
+		}
+	}
+	if min > max {
+		return nil, newValidationError(ErrInvalidRange, "heat map must contain at least (1) finite value")
+	}
+	if min == max {
+		min, max = min-1, max+1
+	}
+	return &ContinuousRange{Min: min, Max: max}, nil
+}
+
+// getCanvasBox returns the box of the cells, which leaves room for the title, labels and legend,
+// and the ticks of the legend.
+func (hm HeatMap) getCanvasBox(r Renderer, vr Range) (Box, []Tick) {
+	canvasBox := hm.Box()
+	if len(hm.Title) > 0 && hm.TitleStyle.Show {
+		hm.styleDefaultsTitle().GetTextOptions().WriteToRenderer(r)
+		lines := Text.WrapFit(r, hm.Title, canvasBox.Width(), hm.styleDefaultsTitle())
+		canvasBox.Top += Text.MeasureLines(r, lines, hm.styleDefaultsTitle()).Height() + DefaultLineSpacing
+	}
+	if hm.XAxis.Show {
+		canvasBox.Bottom -= hm.measureLabels(r, hm.Columns, hm.XAxis).Height() + DefaultLineSpacing
+	}
+	if hm.YAxis.Show {
+		canvasBox.Left += hm.measureLabels(r, hm.Rows, hm.YAxis).Width() + DefaultLineSpacing
+	}
+
+	var ticks []Tick
+	if hm.LegendStyle.Show {
+		vr.SetDomain(canvasBox.Height())
+		legendStyle := hm.LegendStyle.InheritFrom(hm.styleDefaultsLegend())
+		ticks = GenerateUnitTicks(r, vr, true, legendStyle, &Unit{}, hm.ValueFormatter)
+		legendStyle.GetTextOptions().WriteToRenderer(r)
+		var labelWidth int
+		for _, t := range ticks {
+			labelWidth = util.Math.MaxInt(labelWidth, r.MeasureText(t.Label).Width())
+		}
+		canvasBox.Right -= DefaultHeatMapLegendWidth + labelWidth + 3*DefaultLineSpacing
+	}
+	return canvasBox, ticks
+}
+
+// measureLabels returns a box as wide as the widest label and as tall as the tallest label.
+func (hm HeatMap) measureLabels(r Renderer, labels []string, style Style) Box {
+	style.InheritFrom(hm.styleDefaultsAxes()).GetTextOptions().WriteToRenderer(r)
+	var box Box
+	for _, label := range labels {
+		tb := r.MeasureText(label)
+		box.Right = util.Math.MaxInt(box.Right, tb.Width())
+		box.Bottom = util.Math.MaxInt(box.Bottom, tb.Height())
+	}
+	return box
+}
+
+// getCell returns the box of a cell; cells are spread over the canvas so they tile it without gaps.
+func (hm HeatMap) getCell(canvasBox Box, row, column int) Box {
+	cellWidth := float64(canvasBox.Width()) / float64(hm.getColumnCount())
+	cellHeight := float64(canvasBox.Height()) / float64(len(hm.Values))
+	return Box{
+		Top:    canvasBox.Top + int(float64(row)*cellHeight),
+		Left:   canvasBox.Left + int(float64(column)*cellWidth),
+		Right:  canvasBox.Left + int(float64(column+1)*cellWidth),
+		Bottom: canvasBox.Top + int(float64(row+1)*cellHeight),
+	}
+}
+
+// getColor returns the color of a value, which is clamped to the value range.
+func (hm HeatMap) getColor(vr Range, v float64) drawing.Color {
+	min, max := vr.GetMin(), vr.GetMax()
+	return hm.GetColorScale()(math.Min(math.Max(v, min), max), min, max)
+}
+
+func (hm HeatMap) drawCells(r Renderer, canvasBox Box, vr Range) {
+	cellStyle := hm.CellStyle.InheritFrom(hm.styleDefaultsCell())
+	valueStyle := hm.ValueStyle.InheritFrom(hm.styleDefaultsValue())
+	for row, values := range hm.Values {
+		for column, v := range values {
+			if math.IsNaN(v) {
+				continue
+			}
+			cell := hm.getCell(canvasBox, row, column)
+			style := cellStyle
+			style.FillColor = hm.getColor(vr, v)
+			Draw.Box(r, cell, style)
+
+			if hm.ValueStyle.Show {
+				labelStyle := valueStyle
+				if hm.ValueStyle.FontColor.IsZero() {
+					labelStyle.FontColor = contrastingTextColor(style.FillColor)
+				}
+				label := hm.GetValueFormatter()(v)
+				labelStyle.GetTextOptions().WriteToRenderer(r)
+				tb := r.MeasureText(label)
+				cx, cy := cell.Center()
+				r.Text(label, cx-(tb.Width()>>1), cy+(tb.Height()>>1))
+			}
+		}
+	}
+}
+
+// drawColumnLabels draws the column labels centered below the columns.
+func (hm HeatMap) drawColumnLabels(r Renderer, canvasBox Box) {
+	if !hm.XAxis.Show {
+		return
+	}
+	style := hm.XAxis.InheritFrom(hm.styleDefaultsAxes())
+	for column, label := range hm.Columns {
+		if column >= hm.getColumnCount() {
+			break
+		}
+		cell := hm.getCell(canvasBox, len(hm.Values)-1, column)
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+		r.Text(label, cell.Left+(cell.Width()>>1)-(tb.Width()>>1), canvasBox.Bottom+DefaultLineSpacing+tb.Height())
+	}
+}
+
+// drawRowLabels draws the row labels right aligned left of the rows.
+func (hm HeatMap) drawRowLabels(r Renderer, canvasBox Box) {
+	if !hm.YAxis.Show {
+		return
+	}
+	style := hm.YAxis.InheritFrom(hm.styleDefaultsAxes())
+	for row, label := range hm.Rows {
+		if row >= len(hm.Values) {
+			break
+		}
+		cell := hm.getCell(canvasBox, row, 0)
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+		r.Text(label, canvasBox.Left-DefaultLineSpacing-tb.Width(), cell.Top+(cell.Height()>>1)+(tb.Height()>>1))
+	}
+}
+
+// drawLegend draws the color legend bar right of the cells, with the smallest value at the bottom,
+// and the ticks of the value range right of it.
+func (hm HeatMap) drawLegend(r Renderer, canvasBox Box, vr Range, ticks []Tick) {
+	style := hm.LegendStyle.InheritFrom(hm.styleDefaultsLegend())
+	bar := Box{
+		Top:    canvasBox.Top,
+		Left:   canvasBox.Right + 2*DefaultLineSpacing,
+		Right:  canvasBox.Right + 2*DefaultLineSpacing + DefaultHeatMapLegendWidth,
+		Bottom: canvasBox.Bottom,
+	}
+
+	min, max := vr.GetMin(), vr.GetMax()
+	step := float64(bar.Height()) / float64(DefaultHeatMapLegendSteps)
+	for index := 0; index < DefaultHeatMapLegendSteps; index++ {
+		value := min + (max-min)*(float64(index)+0.5)/float64(DefaultHeatMapLegendSteps)
+		Draw.Box(r, Box{
+			Top:    bar.Bottom - int(float64(index+1)*step),
+			Left:   bar.Left,
+			Right:  bar.Right,
+			Bottom: bar.Bottom - int(float64(index)*step),
+		}, Style{FillColor: hm.getColor(vr, value)})
+	}
+	Draw.Box(r, bar, Style{StrokeColor: style.GetStrokeColor(), StrokeWidth: style.GetStrokeWidth()})
+
+	vr.SetDomain(bar.Height())
+	for _, t := range ticks {
+		y := bar.Bottom - vr.Translate(t.Value)
+		style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(bar.Right, y)
+		r.LineTo(bar.Right+DefaultHorizontalTickWidth, y)
+		r.Stroke()
+
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(t.Label)
+		r.Text(t.Label, bar.Right+DefaultHorizontalTickWidth+DefaultLineSpacing, y+(tb.Height()>>1))
+	}
+}
+
+func (hm HeatMap) styleDefaultsBackground() Style {
+	return Style{
+		FillColor:   hm.GetColorPalette().BackgroundColor(),
+		StrokeColor: hm.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (hm HeatMap) styleDefaultsCanvas() Style {
+	return Style{
+		FillColor:   hm.GetColorPalette().CanvasColor(),
+		StrokeColor: hm.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (hm HeatMap) styleDefaultsCell() Style {
+	return Style{
+		StrokeColor: hm.GetColorPalette().CanvasColor(),
+		StrokeWidth: 1,
+	}
+}
+
+func (hm HeatMap) styleDefaultsValue() Style {
+	return Style{
+		Font:     hm.GetFont(),
+		FontSize: DefaultAxisFontSize,
+	}
+}
+
+func (hm HeatMap) styleDefaultsAxes() Style {
+	return Style{
+		Font:      hm.GetFont(),
+		FontSize:  DefaultAxisFontSize,
+		FontColor: hm.GetColorPalette().TextColor(),
+	}
+}
+
+func (hm HeatMap) styleDefaultsLegend() Style {
+	return Style{
+		StrokeColor: hm.GetColorPalette().AxisStrokeColor(),
+		StrokeWidth: DefaultAxisLineWidth,
+		Font:        hm.GetFont(),
+		FontSize:    DefaultAxisFontSize,
+		FontColor:   hm.GetColorPalette().TextColor(),
+	}
+}
+
+func (hm HeatMap) styleDefaultsTitle() Style {
+	return hm.TitleStyle.InheritFrom(Style{
+		FontColor:           hm.GetColorPalette().TextColor(),
+		Font:                hm.GetFont(),
+		FontSize:            hm.getTitleFontSize(),
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+}
+
+func (hm HeatMap) getTitleFontSize() float64 {
+	effectiveDimension := util.Math.MinInt(hm.GetWidth(), hm.GetHeight())
+	if effectiveDimension >= 2048 {
+		return 48
+	} else if effectiveDimension >= 1024 {
+		return 24
+	} else if effectiveDimension >= 512 {
+		return 18
+	} else if effectiveDimension >= 256 {
+		return 12
+	}
+	return 10
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (hm HeatMap) GetColorPalette() ColorPalette {
+	if hm.ColorPalette != nil {
+		return hm.ColorPalette
+	}
+	return DefaultColorPalette
+}
+
+// Box returns the chart bounds as a box.
+func (hm HeatMap) Box() Box {
+	dpr := hm.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
+	dpb := hm.Background.Padding.GetBottom(DefaultBackgroundPadding.Bottom)
+
+	return Box{
+		Top:    hm.Background.Padding.GetTop(DefaultBackgroundPadding.Top),
+		Left:   hm.Background.Padding.GetLeft(DefaultBackgroundPadding.Left),
+		Right:  hm.GetWidth() - dpr,
+		Bottom: hm.GetHeight() - dpb,
+	}
+}
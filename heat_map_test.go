@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestHeatMapRender(t *testing.T) {
+	assert := assert.New(t)
+
+	hm := HeatMap{
+		Title:       "Commits",
+		TitleStyle:  StyleShow(),
+		XAxis:       StyleShow(),
+		YAxis:       StyleShow(),
+		ValueStyle:  StyleShow(),
+		LegendStyle: StyleShow(),
+		Rows:        []string{"Mon", "Tue", "Wed"},
+		Columns:     []string{"9am", "12pm", "3pm", "6pm"},
+		Values: [][]float64{
+			{1, 4, 2, 0},
+			{3, math.NaN(), 8, 1},
+			{0, 2},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(hm.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	buf.Reset()
+	assert.Nil(hm.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "Wed"))
+	assert.True(strings.Contains(buf.String(), "6pm"))
+	assert.True(strings.Contains(buf.String(), "Heat map of 3 rows and 4 columns"))
+}
+
+func TestHeatMapRenderNoValues(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(HeatMap{}.Render(PNG, bytes.NewBuffer(nil)))
+	assert.NotNil(HeatMap{Values: [][]float64{{math.NaN()}}}.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestHeatMapValueRange(t *testing.T) {
+	assert := assert.New(t)
+
+	vr, err := HeatMap{Values: [][]float64{{2, math.NaN()}, {-3, math.Inf(1)}}}.getValueRange()
+	assert.Nil(err)
+	assert.Equal(-3.0, vr.GetMin())
+	assert.Equal(2.0, vr.GetMax())
+
+	vr, err = HeatMap{Values: [][]float64{{5}}}.getValueRange()
+	assert.Nil(err)
+	assert.Equal(4.0, vr.GetMin())
+	assert.Equal(6.0, vr.GetMax())
+
+	vr, err = HeatMap{ValueRange: &ContinuousRange{Min: 0, Max: 10}, Values: [][]float64{{5}}}.getValueRange()
+	assert.Nil(err)
+	assert.Equal(10.0, vr.GetMax())
+}
+
+func TestHeatMapGetCell(t *testing.T) {
+	assert := assert.New(t)
+
+	hm := HeatMap{Values: [][]float64{{1, 2, 3}, {4, 5, 6}}}
+	canvas := Box{Top: 10, Left: 10, Right: 110, Bottom: 60}
+	assert.Equal(Box{Top: 10, Left: 10, Right: 43, Bottom: 35}, hm.getCell(canvas, 0, 0))
+	assert.Equal(Box{Top: 35, Left: 76, Right: 110, Bottom: 60}, hm.getCell(canvas, 1, 2))
+}
+
+func TestGradientColorScale(t *testing.T) {
+	assert := assert.New(t)
+
+	black, white := drawing.Color{A: 255}, drawing.Color{R: 255, G: 255, B: 255, A: 255}
+	scale := GradientColorScale(black, white)
+	assert.Equal(black, scale(0, 0, 10))
+	assert.Equal(white, scale(10, 0, 10))
+	assert.Equal(white, scale(20, 0, 10))
+	assert.Equal(uint8(128), scale(5, 0, 10).R)
+
+	assert.Equal(ColorRed, GradientColorScale(ColorRed)(5, 0, 10))
+	assert.Equal(drawing.ColorTransparent, GradientColorScale()(5, 0, 10))
+}
+
+func TestContrastingTextColor(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(ColorWhite, contrastingTextColor(drawing.Color{A: 255}))
+	assert.Equal(DefaultTextColor, contrastingTextColor(ColorWhite))
+}
@@ -0,0 +1,343 @@
+package chart
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// BinningStrategy is how a histogram chart sizes its bins.
+type BinningStrategy int
+
+const (
+	// BinningSturges makes ⌈log2(n)⌉+1 bins of equal width from the smallest to the largest sample (Sturges' rule);
+	// it suits samples that are roughly normally distributed.
+	BinningSturges BinningStrategy = iota
+	// BinningFreedmanDiaconis makes bins 2·IQR/∛n wide (the Freedman–Diaconis rule), which is robust to outliers.
+	// Samples with no spread between their quartiles, or whose bins would be more than `DefaultHistogramMaxBins`
+	// (i.e. with a far outlier), fall back to Sturges' rule.
+	BinningFreedmanDiaconis
+	// BinningFixedWidth makes bins `BinWidth` wide, with edges on multiples of the width.
+	BinningFixedWidth
+)
+
+// HistogramBin is a bin of a histogram, the samples from its start up to its end; the last bin includes its end.
+type HistogramBin struct {
+	Start float64
+	End   float64
+	Count int
+}
+
+// HistogramChart is a chart of the distribution of raw samples: it bins them and draws a bar per bin,
+// of the number of samples in it or of their density. Unlike `HistogramSeries`, which draws values as bars,
+// the chart does the binning.
+type HistogramChart struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+
+	XAxis XAxis
+	YAxis YAxis
+	// CumulativeAxis is the secondary y axis the cumulative overlay is measured on.
+	// Its range defaults to 0 to 1 and its values are formatted as percentages.
+	CumulativeAxis YAxis
+
+	Font *truetype.Font
+
+	// Samples are the values binned; samples that are not finite are left out.
+	Samples []float64
+
+	// Binning is how the bins are sized; it defaults to `BinningSturges`.
+	Binning BinningStrategy
+	// BinWidth is the width of the bins of `BinningFixedWidth`.
+	BinWidth float64
+
+	// Density draws the bars as the density of the samples, the share of samples per unit of x, rather than
+	// their count, so the bars have a total area of one.
+	Density bool
+	// Cumulative overlays a line of the share of samples up to each bin edge, on the cumulative axis.
+	Cumulative bool
+
+	// BarStyle is the style of the bars; CumulativeStyle is the style of the cumulative line.
+	BarStyle        Style
+	CumulativeStyle Style
+
+	Elements []Renderable
+}
+
+// GetBins returns the bins of the samples, in order.
+func (hc HistogramChart) GetBins() []HistogramBin {
+	sorted := finiteSortedSamples(hc.Samples)
+	if len(sorted) == 0 {
+		return nil
+	}
+	start, width, count := hc.getBinLayout(sorted)
+	bins := make([]HistogramBin, count)
+	for index := range bins {
+		bins[index].Start = start + float64(index)*width
+		bins[index].End = start + float64(index+1)*width
+	}
+	for _, sample := range sorted {
+		index := util.Math.MinInt(int(math.Floor((sample-start)/width)), count-1)
+		bins[util.Math.MaxInt(index, 0)].Count++
+	}
+	return bins
+}
+
+// getBinLayout returns where the first bin starts, how wide the bins are and how many there are.
+// Layouts of more than `DefaultHistogramMaxBins` bins, i.e. narrow Freedman–Diaconis bins over an outlier,
+// fall back to Sturges' rule; the bins are counted as a float first, as too many may not fit an int.
+func (hc HistogramChart) getBinLayout(sorted []float64) (start, width float64, count int) {
+	min, max := sorted[0], sorted[len(sorted)-1]
+
+	if hc.Binning == BinningFixedWidth && hc.BinWidth > 0 && !math.IsInf(hc.BinWidth, 0) {
+		width = hc.BinWidth
+		start = math.Floor(min/width) * width
+		if bins := math.Floor((max-start)/width) + 1; bins <= DefaultHistogramMaxBins {
+			return start, width, int(bins)
+		}
+		width = 0
+	}
+
+	if max == min {
+		return min - 0.5, 1, 1
+	}
+	if hc.Binning == BinningFreedmanDiaconis {
+		width = 2 * (quantile(sorted, 0.75) - quantile(sorted, 0.25)) / math.Cbrt(float64(len(sorted)))
+		if width > 0 && math.Ceil((max-min)/width) > DefaultHistogramMaxBins {
+			width = 0
+		}
+	}
+	if width <= 0 {
+		width = (max - min) / (math.Ceil(math.Log2(float64(len(sorted)))) + 1)
+	}
+	return min, width, util.Math.MaxInt(1, int(math.Ceil((max-min)/width)))
+}
+
+// finiteSortedSamples returns the finite samples, sorted.
+func finiteSortedSamples(samples []float64) []float64 {
+	var sorted []float64
+	for _, sample := range samples {
+		if !math.IsNaN(sample) && !math.IsInf(sample, 0) {
+			sorted = append(sorted, sample)
+		}
+	}
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// Chart returns the chart the histogram is drawn as, i.e. to set options `HistogramChart` doesn't have.
+func (hc HistogramChart) Chart() Chart {
+	bins := hc.GetBins()
+	var total int
+	for _, bin := range bins {
+		total += bin.Count
+	}
+
+	c := Chart{
+		Title:        hc.Title,
+		TitleStyle:   hc.TitleStyle,
+		ColorPalette: hc.ColorPalette,
+		Width:        hc.Width,
+		Height:       hc.Height,
+		DPI:          hc.DPI,
+		Background:   hc.Background,
+		Canvas:       hc.Canvas,
+		XAxis:        hc.XAxis,
+		YAxis:        hc.YAxis,
+		Font:         hc.Font,
+		Elements:     hc.Elements,
+		Series: []Series{
+			histogramBinSeries{Style: hc.BarStyle, Bins: bins, Total: total, Density: hc.Density},
+		},
+	}
+	if hc.Cumulative && total > 0 {
+		c.YAxisSecondary = hc.CumulativeAxis
+		if c.YAxisSecondary.Range == nil {
+			c.YAxisSecondary.Range = &ContinuousRange{Min: 0, Max: 1}
+		}
+		if c.YAxisSecondary.ValueFormatter == nil {
+			c.YAxisSecondary.ValueFormatter = PercentValueFormatter
+		}
+		c.Series = append(c.Series, hc.getCumulativeSeries(bins, total))
+	}
+	return c
+}
+
+// getCumulativeSeries returns the line of the share of samples up to each bin edge.
+func (hc HistogramChart) getCumulativeSeries(bins []HistogramBin, total int) Series {
+	xvalues := []float64{bins[0].Start}
+	yvalues := []float64{0}
+	var count int
+	for _, bin := range bins {
+		count += bin.Count
+		xvalues = append(xvalues, bin.End)
+		yvalues = append(yvalues, float64(count)/float64(total))
+	}
+	style := hc.CumulativeStyle.InheritFrom(hc.styleDefaultsCumulative())
+	style.Show = true
+	return ContinuousSeries{
+		Name:    "cumulative",
+		Style:   style,
+		YAxis:   YAxisSecondary,
+		XValues: xvalues,
+		YValues: yvalues,
+	}
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while binning or rendering are returned as a `RenderPanicError`.
+func (hc HistogramChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = hc.checkBinning(finiteSortedSamples(hc.Samples)); err != nil {
+		return err
+	}
+	return hc.Chart().Render(rp, w)
+}
+
+// checkBinning returns an error if the bins can't be laid out, before they are.
+func (hc HistogramChart) checkBinning(sorted []float64) error {
+	if hc.Binning != BinningFixedWidth {
+		return nil
+	}
+	if hc.BinWidth <= 0 || math.IsNaN(hc.BinWidth) || math.IsInf(hc.BinWidth, 0) {
+		return newValidationError(ErrInvalidRange, "bin width must be positive and finite; got %v", hc.BinWidth)
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	// count the bins as a float, as too many may not fit an int.
+	start := math.Floor(sorted[0]/hc.BinWidth) * hc.BinWidth
+	if count := math.Floor((sorted[len(sorted)-1]-start)/hc.BinWidth) + 1; count > DefaultHistogramMaxBins {
+		return newValidationError(ErrInvalidRange, "bin width %v makes %.0f bins; at most %d are allowed", hc.BinWidth, count, DefaultHistogramMaxBins)
+	}
+	return nil
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (hc HistogramChart) Validate() error {
+	var errs ValidationErrors
+	sorted := finiteSortedSamples(hc.Samples)
+	if len(sorted) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one finite sample"))
+	}
+	if err := hc.checkBinning(sorted); err != nil {
+		return append(errs, err.(*ValidationError))
+	}
+	if err := hc.Chart().Validate(); err != nil {
+		errs = append(errs, err.(ValidationErrors)...)
+	}
+	return errs.asError()
+}
+
+func (hc HistogramChart) styleDefaultsCumulative() Style {
+	return Style{
+		StrokeColor: hc.GetColorPalette().TextColor(),
+		StrokeWidth: DefaultSeriesLineWidth,
+	}
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (hc HistogramChart) GetColorPalette() ColorPalette {
+	if hc.ColorPalette != nil {
+		return hc.ColorPalette
+	}
+	return DefaultColorPalette
+}
+
+// histogramBinSeries draws the bins of a histogram as bars spanning their bins.
+type histogramBinSeries struct {
+	Style   Style
+	Bins    []HistogramBin
+	Total   int
+	Density bool
+}
+
+// GetName implements Series.GetName.
+func (hbs histogramBinSeries) GetName() string {
+	if hbs.Density {
+		return "density"
+	}
+	return "count"
+}
+
+// GetStyle implements Series.GetStyle.
+func (hbs histogramBinSeries) GetStyle() Style {
+	return hbs.Style
+}
+
+// GetYAxis implements Series.GetYAxis.
+func (hbs histogramBinSeries) GetYAxis() YAxisType {
+	return YAxisPrimary
+}
+
+// getValue returns the height of the bar of a bin.
+func (hbs histogramBinSeries) getValue(bin HistogramBin) float64 {
+	if hbs.Density {
+		return float64(bin.Count) / (float64(hbs.Total) * (bin.End - bin.Start))
+	}
+	return float64(bin.Count)
+}
+
+// GetBounds implements BoundsProvider; the bars start at zero.
+func (hbs histogramBinSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	if len(hbs.Bins) == 0 {
+		return 0, 1, 0, 1
+	}
+	for _, bin := range hbs.Bins {
+		maxY = math.Max(maxY, hbs.getValue(bin))
+	}
+	if maxY == 0 {
+		maxY = 1
+	}
+	return hbs.Bins[0].Start, hbs.Bins[len(hbs.Bins)-1].End, 0, maxY
+}
+
+// Render implements Series.Render.
+func (hbs histogramBinSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := hbs.Style.InheritFrom(hbs.styleDefaultsBar(defaults))
+	baseline := canvasBox.Bottom - yrange.Translate(0)
+	for _, bin := range hbs.Bins {
+		if bin.Count == 0 {
+			continue
+		}
+		Draw.Box(r, Box{
+			Top:    canvasBox.Bottom - yrange.Translate(hbs.getValue(bin)),
+			Left:   canvasBox.Left + xrange.Translate(bin.Start),
+			Right:  canvasBox.Left + xrange.Translate(bin.End),
+			Bottom: baseline,
+		}, style)
+	}
+}
+
+// styleDefaultsBar returns the default bar style; the bars are filled with the series color.
+func (hbs histogramBinSeries) styleDefaultsBar(defaults Style) Style {
+	color := defaults.GetStrokeColor(drawing.ColorBlack)
+	return Style{
+		StrokeColor: color,
+		StrokeWidth: 1,
+		FillColor:   color.WithAlpha(160),
+	}
+}
+
+// Validate implements Series.Validate.
+func (hbs histogramBinSeries) Validate() error {
+	if len(hbs.Bins) == 0 {
+		return newValidationError(ErrEmptySeries, "histogram must have at least one bin")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestHistogramChartBinsSturges(t *testing.T) {
+	assert := assert.New(t)
+
+	hc := HistogramChart{Samples: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 16, math.NaN()}}
+	bins := hc.GetBins()
+	// 16 finite samples make log2(16)+1 = 5 bins.
+	assert.Len(bins, 5)
+	assert.Equal(0.0, bins[0].Start)
+	assert.InDelta(16.0, bins[4].End, 1e-9)
+
+	var total int
+	for _, bin := range bins {
+		total += bin.Count
+	}
+	assert.Equal(16, total)
+	// the largest sample is in the last bin.
+	assert.NotZero(bins[4].Count)
+}
+
+func TestHistogramChartBinsFixedWidth(t *testing.T) {
+	assert := assert.New(t)
+
+	hc := HistogramChart{Samples: []float64{1.5, 2.5, 2.9, 7}, Binning: BinningFixedWidth, BinWidth: 2}
+	bins := hc.GetBins()
+	assert.Len(bins, 4)
+	assert.Equal(0.0, bins[0].Start)
+	assert.Equal(8.0, bins[3].End)
+	assert.Equal(1, bins[0].Count)
+	assert.Equal(2, bins[1].Count)
+	assert.Equal(0, bins[2].Count)
+	assert.Equal(1, bins[3].Count)
+}
+
+func TestHistogramChartBinsFreedmanDiaconis(t *testing.T) {
+	assert := assert.New(t)
+
+	samples := make([]float64, 1000)
+	for index := range samples {
+		samples[index] = float64(index)
+	}
+	samples[999] = 100000
+
+	fd := HistogramChart{Samples: samples, Binning: BinningFreedmanDiaconis}
+	start, width, _ := fd.getBinLayout(finiteSortedSamples(samples))
+	assert.Equal(0.0, start)
+	// the quartiles are ~250 and ~749, so the bins are 2*499.5/10 wide whatever the outlier.
+	assert.InDelta(99.9, width, 1e-9)
+
+	// samples without spread between their quartiles fall back to sturges.
+	flat := HistogramChart{Samples: []float64{1, 1, 1, 1, 1, 1, 5}, Binning: BinningFreedmanDiaconis}
+	assert.Len(flat.GetBins(), 4)
+
+	// a far outlier would make ~1e10 bins, so those samples fall back to sturges too.
+	outlier := make([]float64, 1001)
+	for index := 0; index < 1000; index++ {
+		outlier[index] = float64(index) / 1000
+	}
+	outlier[1000] = 1e9
+	fd = HistogramChart{Samples: outlier, Binning: BinningFreedmanDiaconis}
+	assert.Len(fd.GetBins(), 11)
+	assert.Nil(fd.Validate())
+	assert.Nil(fd.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestHistogramChartDensity(t *testing.T) {
+	assert := assert.New(t)
+
+	hc := HistogramChart{Samples: []float64{1, 2, 2, 3, 3, 3, 4, 4, 5}, Density: true}
+	c := hc.Chart()
+	series := c.Series[0].(histogramBinSeries)
+
+	var area float64
+	for _, bin := range series.Bins {
+		area += series.getValue(bin) * (bin.End - bin.Start)
+	}
+	assert.InDelta(1.0, area, 1e-9)
+}
+
+func TestHistogramChartCumulative(t *testing.T) {
+	assert := assert.New(t)
+
+	hc := HistogramChart{Samples: []float64{1, 2, 2, 3, 3, 3, 4, 4, 5}, Cumulative: true}
+	c := hc.Chart()
+	assert.Len(c.Series, 2)
+	cumulative := c.Series[1].(ContinuousSeries)
+	assert.Equal(YAxisSecondary, cumulative.YAxis)
+	assert.Equal(0.0, cumulative.YValues[0])
+	assert.Equal(1.0, cumulative.YValues[len(cumulative.YValues)-1])
+	assert.Equal(1.0, c.YAxisSecondary.Range.GetMax())
+
+	buffer := bytes.NewBuffer(nil)
+	hc.XAxis = XAxis{Style: StyleShow()}
+	hc.YAxis = YAxis{Style: StyleShow()}
+	hc.CumulativeAxis = YAxis{Style: StyleShow()}
+	assert.Nil(hc.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func TestHistogramChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(HistogramChart{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(HistogramChart{Samples: []float64{1, 2}, Binning: BinningFixedWidth}.Validate(), ErrInvalidRange))
+
+	tooMany := HistogramChart{Samples: []float64{0, 1e12}, Binning: BinningFixedWidth, BinWidth: 1}
+	assert.True(errors.Is(tooMany.Validate(), ErrInvalidRange))
+	assert.NotNil(tooMany.Render(PNG, bytes.NewBuffer(nil)))
+	// the bins are still capped for callers that don't validate.
+	assert.True(len(tooMany.GetBins()) <= DefaultHistogramMaxBins)
+	assert.NotEmpty(tooMany.Chart().Series)
+
+	assert.Nil(HistogramChart{Samples: []float64{1, 2, 3}}.Validate())
+	assert.Nil(HistogramChart{Samples: []float64{2, 2}}.Validate())
+}
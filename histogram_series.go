@@ -9,7 +9,12 @@ type HistogramSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	InnerSeries ValuesProvider
+
+	// CornerRadius rounds the corners of the bars that `Corners` picks, by default the corners at their ends.
+	CornerRadius int
+	Corners      BarCorners
 }
 
 // GetName implements Series.GetName.
@@ -27,6 +32,11 @@ func (hs HistogramSeries) GetYAxis() YAxisType {
 	return hs.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (hs HistogramSeries) GetXAxis() XAxisType {
+	return hs.XAxis
+}
+
 // Len implements BoundedValuesProvider.Len.
 func (hs HistogramSeries) Len() int {
 	return hs.InnerSeries.Len()
@@ -55,7 +65,9 @@ func (hs HistogramSeries) GetBoundedValues(index int) (x, y1, y2 float64) {
 // Render implements Series.Render.
 func (hs HistogramSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
 	style := hs.Style.InheritFrom(defaults)
-	Draw.HistogramSeries(r, canvasBox, xrange, yrange, style, hs)
+	Draw.histogramBars(r, canvasBox, xrange, yrange, style, hs, histogramBarWidth(xrange, hs), func(down bool) [4]int {
+		return barCornerRadii(hs.CornerRadius, false, down, hs.Corners == BarCornersAll, true)
+	})
 }
 
 // Validate validates the series.
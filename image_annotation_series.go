@@ -0,0 +1,162 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// imageRenderer is a renderer that can draw images.
+type imageRenderer interface {
+	// DrawImage draws an image scaled into a box.
+	DrawImage(img image.Image, box Box)
+}
+
+// drawImage draws an image scaled into a box, if the renderer supports it.
+func drawImage(r Renderer, img image.Image, box Box) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if or, isOffset := r.(offsetRenderer); isOffset {
+		r, box = or.Renderer, box.Shift(or.dx, or.dy)
+	}
+	if ir, isImageRenderer := r.(imageRenderer); isImageRenderer {
+		ir.DrawImage(img, box)
+	}
+}
+
+// ImageAnnotation is an image pinned to a value, i.e. a weather icon or a logo.
+type ImageAnnotation struct {
+	XValue, YValue float64
+	Image          image.Image
+
+	// Width and Height are the size the image is drawn at, in pixels; if only one is set, the other keeps
+	// the aspect ratio of the image. They default to the size set on the series, and then to the size of the image.
+	Width, Height int
+
+	// Anchor is the side or corner of the image placed at the value; it defaults to the center.
+	// Offset moves the image from the value by pixels.
+	Anchor AnnotationAnchor
+	Offset Point
+}
+
+// ImageAnnotationSeries is a series of images pinned to values on the chart.
+// Images are drawn into raster output scaled to their size, and inlined into svg output as png data.
+type ImageAnnotationSeries struct {
+	Name   string
+	Style  Style
+	YAxis  YAxisType
+	XAxis  XAxisType
+	Images []ImageAnnotation
+
+	// Width and Height are the size images are drawn at unless they set their own; see `ImageAnnotation`.
+	Width, Height int
+
+	// Clip clips the images to the canvas; they are drawn over the edges of the canvas by default,
+	// even if the chart clips its series (see `Chart.ClipSeries`).
+	Clip bool
+}
+
+// GetName returns the name of the series.
+func (ias ImageAnnotationSeries) GetName() string {
+	return ias.Name
+}
+
+// GetStyle returns the series style.
+func (ias ImageAnnotationSeries) GetStyle() Style {
+	return ias.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ias ImageAnnotationSeries) GetYAxis() YAxisType {
+	return ias.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (ias ImageAnnotationSeries) GetXAxis() XAxisType {
+	return ias.XAxis
+}
+
+// getSize returns the size an image is drawn at.
+func (ias ImageAnnotationSeries) getSize(ia ImageAnnotation) (width, height int) {
+	width, height = ia.Width, ia.Height
+	if width == 0 && height == 0 {
+		width, height = ias.Width, ias.Height
+	}
+	bounds := ia.Image.Bounds()
+	switch {
+	case width == 0 && height == 0:
+		return bounds.Dx(), bounds.Dy()
+	case width == 0 && bounds.Dy() > 0:
+		width = int(math.Round(float64(height) * float64(bounds.Dx()) / float64(bounds.Dy())))
+	case height == 0 && bounds.Dx() > 0:
+		height = int(math.Round(float64(width) * float64(bounds.Dy()) / float64(bounds.Dx())))
+	}
+	return
+}
+
+// getBox returns the box an image is drawn into.
+func (ias ImageAnnotationSeries) getBox(canvasBox Box, xrange, yrange Range, ia ImageAnnotation) Box {
+	lx := canvasBox.Left + xrange.Translate(ia.XValue) + ia.Offset.X
+	ly := canvasBox.Bottom - yrange.Translate(ia.YValue) + ia.Offset.Y
+	anchor := ia.Anchor
+	if anchor == AnnotationAnchorUnset {
+		anchor = AnnotationAnchorCenter
+	}
+	width, height := ias.getSize(ia)
+	return anchoredBox(lx, ly, width, height, anchor)
+}
+
+// Measure returns a bounds box of the series.
+func (ias ImageAnnotationSeries) Measure(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) Box {
+	box := Box{
+		Top:    math.MaxInt32,
+		Left:   math.MaxInt32,
+		Right:  0,
+		Bottom: 0,
+	}
+	if ias.Style.IsZero() || ias.Style.Show {
+		for _, ia := range ias.Images {
+			ib := ias.getBox(canvasBox, xrange, yrange, ia)
+			box.Top = util.Math.MinInt(box.Top, ib.Top)
+			box.Left = util.Math.MinInt(box.Left, ib.Left)
+			box.Right = util.Math.MaxInt(box.Right, ib.Right)
+			box.Bottom = util.Math.MaxInt(box.Bottom, ib.Bottom)
+		}
+	}
+	return box
+}
+
+// Render draws the series.
+func (ias ImageAnnotationSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	if ias.Style.IsZero() || ias.Style.Show {
+		for _, ia := range ias.Images {
+			drawImage(r, ia.Image, ias.getBox(canvasBox, xrange, yrange, ia))
+		}
+	}
+}
+
+// IsClipped implements `ClipProvider`; images are clipped to the canvas only if the series has `Clip` set.
+func (ias ImageAnnotationSeries) IsClipped() bool {
+	return ias.Clip
+}
+
+// IsLegendExcluded implements `LegendExcluder`; images are never listed in legends.
+func (ias ImageAnnotationSeries) IsLegendExcluded() bool {
+	return true
+}
+
+// Validate validates the series.
+func (ias ImageAnnotationSeries) Validate() error {
+	if len(ias.Images) == 0 {
+		return fmt.Errorf("image annotation series requires images to be set and not empty")
+	}
+	for index, ia := range ias.Images {
+		if ia.Image == nil {
+			return fmt.Errorf("image annotation series image %d is nil", index)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func testIcon(width, height int) image.Image {
+	icon := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			icon.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	return icon
+}
+
+func TestImageAnnotationSeriesGetSize(t *testing.T) {
+	assert := assert.New(t)
+
+	icon := testIcon(20, 10)
+	ias := ImageAnnotationSeries{}
+	width, height := ias.getSize(ImageAnnotation{Image: icon})
+	assert.Equal(20, width)
+	assert.Equal(10, height)
+
+	width, height = ias.getSize(ImageAnnotation{Image: icon, Width: 40})
+	assert.Equal(40, width)
+	assert.Equal(20, height)
+
+	ias.Height = 5
+	width, height = ias.getSize(ImageAnnotation{Image: icon})
+	assert.Equal(10, width)
+	assert.Equal(5, height)
+
+	width, height = ias.getSize(ImageAnnotation{Image: icon, Width: 8, Height: 8})
+	assert.Equal(8, width)
+	assert.Equal(8, height)
+}
+
+func TestImageAnnotationSeriesGetBox(t *testing.T) {
+	assert := assert.New(t)
+
+	canvas := Box{Top: 0, Left: 0, Right: 100, Bottom: 100}
+	ra := &ContinuousRange{Min: 0, Max: 100, Domain: 100}
+	ias := ImageAnnotationSeries{}
+
+	box := ias.getBox(canvas, ra, ra, ImageAnnotation{XValue: 50, YValue: 50, Image: testIcon(10, 10)})
+	assert.Equal(Box{Top: 45, Left: 45, Right: 55, Bottom: 55}, box)
+
+	box = ias.getBox(canvas, ra, ra, ImageAnnotation{XValue: 50, YValue: 50, Image: testIcon(10, 10), Anchor: AnnotationAnchorBottom, Offset: Point{X: 0, Y: -2}})
+	assert.Equal(Box{Top: 38, Left: 45, Right: 55, Bottom: 48}, box)
+}
+
+func TestImageAnnotationSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  200,
+		Height: 200,
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{0, 10}},
+			ImageAnnotationSeries{Width: 20, Images: []ImageAnnotation{{XValue: 5, YValue: 5, Image: testIcon(4, 4)}}},
+		},
+	}
+
+	img, err := RenderImage(c)
+	assert.Nil(err)
+	center := img.At(100, 100)
+	r, g, b, _ := center.RGBA()
+	assert.Equal(uint32(0xffff), r)
+	assert.Zero(g)
+	assert.Zero(b)
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), `<image x="90" y="90" width="20" height="20"`))
+	assert.True(strings.Contains(buf.String(), "data:image/png;base64,"))
+}
+
+func TestImageAnnotationSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(ImageAnnotationSeries{}.Validate())
+	assert.NotNil(ImageAnnotationSeries{Images: []ImageAnnotation{{}}}.Validate())
+	assert.Nil(ImageAnnotationSeries{Images: []ImageAnnotation{{Image: testIcon(1, 1)}}}.Validate())
+}
@@ -0,0 +1,50 @@
+package chart
+
+import (
+	"image"
+	"image/jpeg"
+	"io"
+
+	"github.com/wcharczuk/go-chart/webp"
+	xdraw "golang.org/x/image/draw"
+)
+
+// DefaultJPEGQuality is the quality of jpegs if it isn't set.
+const DefaultJPEGQuality = 90
+
+// WEBP returns a raster renderer that saves charts as lossless webp, which is typically about half the size
+// of the same chart as a png. Metadata is only written into pngs.
+func WEBP(width, height int) (Renderer, error) {
+	r, err := PNG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	r.(*rasterRenderer).encoder = webp.Encode
+	return r, nil
+}
+
+// JPEG returns a renderer provider for raster renderers that save charts as jpegs of a quality from 1 to 100;
+// it defaults to `DefaultJPEGQuality`. Jpegs have no transparency, so transparent backgrounds are flattened
+// onto white. Metadata is only written into pngs.
+func JPEG(quality int) RendererProvider {
+	if quality <= 0 {
+		quality = DefaultJPEGQuality
+	}
+	if quality > 100 {
+		quality = 100
+	}
+	return func(width, height int) (Renderer, error) {
+		r, err := PNG(width, height)
+		if err != nil {
+			return nil, err
+		}
+		r.(*rasterRenderer).encoder = func(w io.Writer, i image.Image) error {
+			bounds := i.Bounds()
+			flattened := image.NewRGBA(bounds)
+			xdraw.Draw(flattened, bounds, image.White, image.Point{}, xdraw.Src)
+			xdraw.Draw(flattened, bounds, i, bounds.Min, xdraw.Over)
+			return jpeg.Encode(w, flattened, &jpeg.Options{Quality: quality})
+		}
+		return r, nil
+	}
+}
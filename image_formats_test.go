@@ -0,0 +1,65 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"golang.org/x/image/webp"
+)
+
+func TestWEBP(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{ContinuousSeries{XValues: []float64{1, 2, 3, 4}, YValues: []float64{1, 3, 2, 4}}},
+	}
+	encoded := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(WEBP, encoded))
+	size := encoded.Len()
+	decoded, err := webp.Decode(encoded)
+	assert.Nil(err)
+
+	// the webp is lossless; it has the same pixels as the png.
+	collector := &ImageWriter{}
+	assert.Nil(c.Render(PNG, collector))
+	expected, err := collector.Image()
+	assert.Nil(err)
+	assert.Equal(expected.Bounds(), decoded.Bounds())
+	for _, p := range []image.Point{{0, 0}, {c.GetWidth() / 2, c.GetHeight() / 2}, {c.GetWidth() - 1, c.GetHeight() - 1}} {
+		r, g, b, a := expected.At(p.X, p.Y).RGBA()
+		dr, dg, db, da := decoded.At(p.X, p.Y).RGBA()
+		assert.Equal([]uint32{r, g, b, a}, []uint32{dr, dg, db, da})
+	}
+
+	png := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, png))
+	assert.True(size < png.Len())
+}
+
+func TestJPEG(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Background: Style{FillColor: ColorTransparent},
+		Canvas:     Style{FillColor: ColorTransparent},
+		Series:     []Series{ContinuousSeries{XValues: []float64{1, 2, 3, 4}, YValues: []float64{1, 3, 2, 4}}},
+	}
+	encoded := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(JPEG(0), encoded))
+	decoded, err := jpeg.Decode(encoded)
+	assert.Nil(err)
+	assert.Equal(c.GetWidth(), decoded.Bounds().Dx())
+
+	// transparent backgrounds are flattened onto white.
+	r, g, b, _ := decoded.At(1, 1).RGBA()
+	assert.True(r > 0xf000 && g > 0xf000 && b > 0xf000)
+
+	low := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(JPEG(10), low))
+	high := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(JPEG(100), high))
+	assert.True(low.Len() < high.Len())
+}
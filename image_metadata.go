@@ -0,0 +1,210 @@
+package chart
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+)
+
+const (
+	// MetadataKeySpec is the metadata key of the chart spec written by `Chart.EmbedSpec`.
+	MetadataKeySpec = "go-chart:spec"
+	// MetadataKeyDataHash is the metadata key of the source data hash written by `Chart.EmbedSpec`.
+	MetadataKeyDataHash = "go-chart:data-sha256"
+)
+
+// metadataRenderer is a renderer that can write text metadata into its output,
+// as png iTXt chunks, as an svg `<metadata>` element or as pdf document information.
+type metadataRenderer interface {
+	SetMetadata(metadata map[string]string)
+}
+
+// setMetadata writes metadata into the output of the renderer, if the renderer supports it.
+func setMetadata(r Renderer, metadata map[string]string) {
+	if len(metadata) == 0 {
+		return
+	}
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if mr, isMetadataRenderer := r.(metadataRenderer); isMetadataRenderer {
+		mr.SetMetadata(metadata)
+	}
+}
+
+// getImageMetadata returns the metadata to write into the output; the user metadata, and the spec if asked for.
+func (c Chart) getImageMetadata() map[string]string {
+	if !c.EmbedSpec {
+		return c.Metadata
+	}
+	metadata := make(map[string]string, len(c.Metadata)+2)
+	for key, value := range c.Metadata {
+		metadata[key] = value
+	}
+	if spec, err := json.Marshal(c.spec()); err == nil {
+		metadata[MetadataKeySpec] = string(spec)
+	}
+	metadata[MetadataKeyDataHash] = c.dataHash()
+	return metadata
+}
+
+// chartSpec is the serialized chart spec: the settings that shape the output, without the data.
+type chartSpec struct {
+	Title       string       `json:"title,omitempty"`
+	Width       int          `json:"width"`
+	Height      int          `json:"height"`
+	DPI         float64      `json:"dpi"`
+	ScaleFactor float64      `json:"scaleFactor"`
+	XAxis       axisSpec     `json:"xAxis"`
+	YAxis       axisSpec     `json:"yAxis"`
+	YAxisAlt    axisSpec     `json:"yAxisSecondary"`
+	Series      []seriesSpec `json:"series"`
+}
+
+type axisSpec struct {
+	Name  string    `json:"name,omitempty"`
+	Show  bool      `json:"show"`
+	Range string    `json:"range,omitempty"`
+	Ticks []float64 `json:"ticks,omitempty"`
+}
+
+type seriesSpec struct {
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+	YAxis string `json:"yAxis"`
+	Len   int    `json:"len"`
+}
+
+func (c Chart) spec() chartSpec {
+	spec := chartSpec{
+		Title:       c.Title,
+		Width:       c.GetWidth(),
+		Height:      c.GetHeight(),
+		DPI:         c.GetDPI(),
+		ScaleFactor: c.GetScaleFactor(),
+		XAxis:       newAxisSpec(c.XAxis.Name, c.XAxis.Style, c.XAxis.Range, c.XAxis.Ticks),
+		YAxis:       newAxisSpec(c.YAxis.Name, c.YAxis.Style, c.YAxis.Range, c.YAxis.Ticks),
+		YAxisAlt:    newAxisSpec(c.YAxisSecondary.Name, c.YAxisSecondary.Style, c.YAxisSecondary.Range, c.YAxisSecondary.Ticks),
+	}
+	for _, s := range c.Series {
+		ss := seriesSpec{Name: s.GetName(), Type: reflect.TypeOf(s).String(), YAxis: "primary"}
+		if s.GetYAxis() == YAxisSecondary {
+			ss.YAxis = "secondary"
+		}
+		if vp, isValuesProvider := s.(ValuesProvider); isValuesProvider {
+			ss.Len = vp.Len()
+		} else if bvp, isBoundedValuesProvider := s.(BoundedValuesProvider); isBoundedValuesProvider {
+			ss.Len = bvp.Len()
+		}
+		spec.Series = append(spec.Series, ss)
+	}
+	return spec
+}
+
+func newAxisSpec(name string, style Style, ra Range, ticks []Tick) axisSpec {
+	spec := axisSpec{Name: name, Show: style.Show}
+	if ra != nil {
+		spec.Range = ra.String()
+	}
+	for _, t := range ticks {
+		spec.Ticks = append(spec.Ticks, t.Value)
+	}
+	return spec
+}
+
+// dataHash returns the hex sha256 of the series values, in order.
+func (c Chart) dataHash() string {
+	hash := sha256.New()
+	var buffer [8]byte
+	write := func(values ...float64) {
+		for _, v := range values {
+			binary.BigEndian.PutUint64(buffer[:], math.Float64bits(v))
+			hash.Write(buffer[:])
+		}
+	}
+	for _, s := range c.Series {
+		if bvp, isBoundedValuesProvider := s.(BoundedValuesProvider); isBoundedValuesProvider {
+			for index := 0; index < bvp.Len(); index++ {
+				write(bvp.GetBoundedValues(index))
+			}
+		} else if vp, isValuesProvider := s.(ValuesProvider); isValuesProvider {
+			for index := 0; index < vp.Len(); index++ {
+				write(vp.GetValues(index))
+			}
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// sortedMetadataKeys returns the metadata keys in order, so output is stable.
+func sortedMetadataKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pngSignatureAndHeaderLength is the length of the png signature and IHDR chunk, which text chunks must follow.
+const pngSignatureAndHeaderLength = 8 + 4 + 4 + 13 + 4
+
+// writePNGMetadata writes an encoded png with the metadata inserted as iTXt chunks after the header.
+func writePNGMetadata(w io.Writer, encoded []byte, metadata map[string]string) error {
+	if len(encoded) < pngSignatureAndHeaderLength || string(encoded[12:16]) != "IHDR" {
+		return errors.New("invalid png; missing header")
+	}
+	output := bytes.NewBuffer(make([]byte, 0, len(encoded)+1024))
+	output.Write(encoded[:pngSignatureAndHeaderLength])
+	for _, key := range sortedMetadataKeys(metadata) {
+		if len(key) == 0 || len(key) > 79 {
+			return fmt.Errorf("invalid png metadata key %q; must be 1 to 79 bytes", key)
+		}
+		// keyword, null, compression flag and method, empty language tag and translated keyword, text.
+		data := make([]byte, 0, len(key)+5+len(metadata[key]))
+		data = append(data, key...)
+		data = append(data, 0, 0, 0, 0, 0)
+		data = append(data, metadata[key]...)
+		writePNGChunk(output, "iTXt", data)
+	}
+	output.Write(encoded[pngSignatureAndHeaderLength:])
+	_, err := w.Write(output.Bytes())
+	return err
+}
+
+func writePNGChunk(w *bytes.Buffer, chunkType string, data []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	w.Write(length[:])
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(chunkType))
+	crc.Write(data)
+	w.WriteString(chunkType)
+	w.Write(data)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	w.Write(sum[:])
+}
+
+// writeSVGMetadata writes the metadata as a svg `<metadata>` element.
+func writeSVGMetadata(w io.Writer, metadata map[string]string) {
+	io.WriteString(w, `<metadata>`)
+	for _, key := range sortedMetadataKeys(metadata) {
+		io.WriteString(w, `<chart:entry xmlns:chart="https://github.com/wcharczuk/go-chart" key="`)
+		xml.EscapeText(w, []byte(key))
+		io.WriteString(w, `">`)
+		xml.EscapeText(w, []byte(metadata[key]))
+		io.WriteString(w, `</chart:entry>`)
+	}
+	io.WriteString(w, `</metadata>`)
+}
@@ -0,0 +1,97 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+// readPNGText returns the iTXt chunks of a png.
+func readPNGText(encoded []byte) map[string]string {
+	text := map[string]string{}
+	for offset := 8; offset+12 <= len(encoded); {
+		length := int(encoded[offset])<<24 | int(encoded[offset+1])<<16 | int(encoded[offset+2])<<8 | int(encoded[offset+3])
+		chunkType := string(encoded[offset+4 : offset+8])
+		data := encoded[offset+8 : offset+8+length]
+		if chunkType == "iTXt" {
+			key := data[:bytes.IndexByte(data, 0)]
+			text[string(key)] = string(data[len(key)+5:])
+		}
+		offset += 12 + length
+	}
+	return text
+}
+
+func TestChartEmbedSpecPNG(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Title:     "töitle",
+		EmbedSpec: true,
+		Metadata:  map[string]string{"Author": "reports"},
+		Series: []Series{
+			ContinuousSeries{Name: "a", XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+
+	// the image still decodes.
+	_, err := png.Decode(bytes.NewReader(buffer.Bytes()))
+	assert.Nil(err)
+
+	text := readPNGText(buffer.Bytes())
+	assert.Equal("reports", text["Author"])
+	assert.Equal(c.dataHash(), text[MetadataKeyDataHash])
+
+	var spec chartSpec
+	assert.Nil(json.Unmarshal([]byte(text[MetadataKeySpec]), &spec))
+	assert.Equal("töitle", spec.Title)
+	assert.Equal(DefaultChartWidth, spec.Width)
+	assert.Len(spec.Series, 1)
+	assert.Equal("chart.ContinuousSeries", spec.Series[0].Type)
+	assert.Equal(3, spec.Series[0].Len)
+
+	// the hash changes with the data.
+	changed := c
+	changed.Series = []Series{ContinuousSeries{Name: "a", XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 4}}}
+	assert.NotEqual(c.dataHash(), changed.dataHash())
+
+	// no metadata is written unless asked for.
+	buffer.Reset()
+	assert.Nil(Chart{Series: c.Series}.Render(PNG, buffer))
+	assert.Empty(readPNGText(buffer.Bytes()))
+}
+
+func TestChartEmbedSpecSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		EmbedSpec: true,
+		Metadata:  map[string]string{"note": "a < b"},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	svg := buffer.String()
+	assert.True(strings.Contains(svg, `key="go-chart:data-sha256">`+c.dataHash()+`</chart:entry>`))
+	assert.True(strings.Contains(svg, `key="note">a &lt; b</chart:entry>`))
+	assert.True(strings.HasSuffix(svg, "</metadata></svg>"))
+}
+
+func TestWritePNGMetadataInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(writePNGMetadata(bytes.NewBuffer(nil), []byte("not a png"), map[string]string{"a": "b"}))
+
+	r, err := PNG(10, 10)
+	assert.Nil(err)
+	r.(*rasterRenderer).SetMetadata(map[string]string{strings.Repeat("k", 80): "v"})
+	assert.NotNil(r.Save(bytes.NewBuffer(nil)))
+}
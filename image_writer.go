@@ -5,6 +5,7 @@ import (
 	"errors"
 	"image"
 	"image/png"
+	"io"
 )
 
 // RGBACollector is a render target for a chart.
@@ -40,3 +41,30 @@ func (ir *ImageWriter) Image() (image.Image, error) {
 	}
 	return nil, errors.New("no valid sources for image data, cannot continue")
 }
+
+// ImageRenderable is a chart that renders to a writer; all of the chart types are.
+type ImageRenderable interface {
+	Render(rp RendererProvider, w io.Writer) error
+}
+
+// RenderImage renders a chart with the raster renderer and returns the image, without encoding it to png
+// and decoding it again, e.g. to draw it into a larger image or encode it to another format.
+func RenderImage(c ImageRenderable) (image.Image, error) {
+	iw := &ImageWriter{}
+	if err := c.Render(PNG, iw); err != nil {
+		return nil, err
+	}
+	return iw.Image()
+}
+
+// RasterImage returns the image a raster renderer draws to, e.g. for a render hook to draw on directly;
+// it returns false for other renderers.
+func RasterImage(r Renderer) (*image.RGBA, bool) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if rr, isRaster := r.(*rasterRenderer); isRaster {
+		return rr.i, true
+	}
+	return nil, false
+}
@@ -0,0 +1,60 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestChartRenderToImage(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  300,
+		Height: 200,
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{0, 1, 2}, YValues: []float64{1, 3, 2}},
+		},
+	}
+	img, err := c.RenderToImage()
+	assert.Nil(err)
+	assert.Equal(image.Rect(0, 0, 300, 200), img.Bounds())
+	_, isRGBA := img.(*image.RGBA)
+	assert.True(isRGBA)
+
+	pie, err := RenderImage(PieChart{Width: 100, Height: 100, Values: []Value{{Value: 1, Label: "a"}}})
+	assert.Nil(err)
+	assert.Equal(100, pie.Bounds().Dx())
+
+	_, err = RenderImage(PieChart{})
+	assert.NotNil(err)
+}
+
+func TestRasterImage(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  100,
+		Height: 100,
+		NoText: true,
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{0, 1}, YValues: []float64{0, 1}},
+		},
+		AfterRender: func(r Renderer, _ ChartLayout) {
+			// a watermark pixel in the corner.
+			i, isRaster := RasterImage(r)
+			assert.True(isRaster)
+			i.Set(0, 0, color.RGBA{R: 255, A: 255})
+		},
+	}
+	img, err := c.RenderToImage()
+	assert.Nil(err)
+	assert.Equal(color.RGBA{R: 255, A: 255}, img.At(0, 0))
+
+	r, err := SVG(10, 10)
+	assert.Nil(err)
+	_, isRaster := RasterImage(r)
+	assert.False(isRaster)
+}
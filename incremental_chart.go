@@ -0,0 +1,357 @@
+package chart
+
+import (
+	"image"
+	imagedraw "image/draw"
+	"image/png"
+	"io"
+	"math"
+	"runtime/debug"
+	"sync"
+
+	"github.com/golang/freetype/raster"
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// IncrementalChart wraps a chart for high frequency (live) rendering to png.
+//
+// It keeps the last rendered raster between calls to `Render`. When the only change between renders
+// is points appended to the series, and the x and y axes have fixed ranges, only the region of the
+// raster covering the new points is redrawn; everything else is reused as is.
+//
+// The incremental path requires series x values to be ascending. Any other change to the chart
+// (styles, titles, series order etc.) is not detected; call `Reset` after making one.
+type IncrementalChart struct {
+	Chart Chart
+
+	lock        sync.Mutex
+	state       *incrementalState
+	incremental bool
+}
+
+// incrementalState is what is kept from the last render.
+type incrementalState struct {
+	width, height int
+	dpi           float64
+	layout        ChartLayout
+	ranges        [3][2]float64
+	lengths       []int
+
+	// base is the background, canvas and axes without any series.
+	base *image.RGBA
+	// frame is the last complete frame.
+	frame *image.RGBA
+}
+
+// Reset drops the retained state, forcing the next render to be a full render.
+func (ic *IncrementalChart) Reset() {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+	ic.state = nil
+	ic.incremental = false
+}
+
+// LastRenderIncremental returns if the last call to render only redrew the dirty region.
+func (ic *IncrementalChart) LastRenderIncremental() bool {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+	return ic.incremental
+}
+
+// Render renders the chart as a png to the given writer.
+// If the writer is an RGBACollector it is handed a copy of the raster instead.
+// Panics raised while rendering are returned as a `RenderPanicError`, and drop the retained state.
+func (ic *IncrementalChart) Render(w io.Writer) (err error) {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			ic.state = nil
+			err = &RenderPanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	c := ic.Chart
+	if len(c.Series) == 0 {
+		return newValidationError(ErrEmptySeries, "please provide at least one series")
+	}
+	if err := c.checkHasVisibleSeries(); err != nil {
+		return err
+	}
+	if err := c.checkSeriesLengths(); err != nil {
+		return err
+	}
+	c.XAxisSecondary.AxisType = XAxisSecondary
+	c.YAxisSecondary.AxisType = YAxisSecondary
+	if c.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		c.defaultFont = defaultFont
+	}
+
+	if ic.canRenderIncremental(c) {
+		ic.incremental = true
+		ic.renderIncremental(c)
+	} else {
+		ic.incremental = false
+		err = ic.renderFull(c)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rc, isRGBACollector := w.(RGBACollector); isRGBACollector {
+		rc.SetRGBA(copyRGBA(ic.state.frame))
+		return nil
+	}
+	return png.Encode(w, ic.state.frame)
+}
+
+func (ic *IncrementalChart) canRenderIncremental(c Chart) bool {
+	s := ic.state
+	if s == nil {
+		return false
+	}
+	if s.width != c.GetWidth() || s.height != c.GetHeight() || s.dpi != c.GetDPI(DefaultDPI) {
+		return false
+	}
+	// dirty regions are tracked in logical pixels.
+	if c.GetScaleFactor() != 1 {
+		return false
+	}
+	if c.hasMeasurableSeries() {
+		return false
+	}
+	if !isFixedRange(c.XAxis.Range) || !isFixedRange(c.YAxis.Range) {
+		return false
+	}
+	if c.hasSecondarySeries() && !isFixedRange(c.YAxisSecondary.Range) {
+		return false
+	}
+	if c.hasSecondaryXSeries() {
+		return false
+	}
+	if c.incrementalRanges() != s.ranges {
+		return false
+	}
+	if len(c.Series) != len(s.lengths) {
+		return false
+	}
+	for index, series := range c.Series {
+		vp, isValuesProvider := series.(ValuesProvider)
+		if !isValuesProvider {
+			return false
+		}
+		if vp.Len() < s.lengths[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func (ic *IncrementalChart) renderFull(c Chart) error {
+	if err := DefaultCanvasLimits.Check(c.GetWidth(), c.GetHeight()); err != nil {
+		return err
+	}
+	i := image.NewRGBA(image.Rect(0, 0, c.GetWidth(), c.GetHeight()))
+	gc, err := drawing.NewRasterGraphicContext(i)
+	if err != nil {
+		return err
+	}
+	r := &rasterRenderer{i: i, gc: gc}
+	r.SetDPI(c.GetDPI(DefaultDPI))
+	if err = applyScaleFactor(r, c.GetScaleFactor()); err != nil {
+		return err
+	}
+	i = r.i
+
+	c.drawBackground(r)
+	cl, err := c.Layout(r, c.Measure())
+	if err != nil {
+		ic.state = nil
+		return err
+	}
+	c.logWarnings(r, cl)
+	c.drawCanvas(r, cl.Canvas)
+	c.drawAxes(r, cl)
+
+	base := copyRGBA(i)
+	c.drawIncrementalOverlay(r, cl, nil)
+
+	ic.state = &incrementalState{
+		width:   c.GetWidth(),
+		height:  c.GetHeight(),
+		dpi:     c.GetDPI(DefaultDPI),
+		layout:  cl,
+		ranges:  c.incrementalRanges(),
+		lengths: c.incrementalLengths(),
+		base:    base,
+		frame:   i,
+	}
+	return nil
+}
+
+func (ic *IncrementalChart) renderIncremental(c Chart) {
+	s := ic.state
+	cl := s.layout
+
+	// the dirty region spans from the last point of the previous render to the last point of this one,
+	// for every series, padded by the widest stroke or dot; curves bend into the last point towards the points
+	// after it, so for curved series it spans from the point before.
+	left, right := math.MaxInt32, math.MinInt32
+	var pad int
+	for index, series := range c.Series {
+		vp := series.(ValuesProvider)
+		if vp.Len() == s.lengths[index] {
+			continue
+		}
+		xr := cl.XRange
+		from := s.lengths[index] - 1
+		if series.GetStyle().Interpolation.isCurved() {
+			from--
+		}
+		for i := util.Math.MaxInt(from, 0); i < vp.Len(); i++ {
+			vx, _ := vp.GetValues(i)
+			x := cl.Canvas.Left + xr.Translate(vx)
+			left = util.Math.MinInt(left, x)
+			right = util.Math.MaxInt(right, x)
+		}
+		style := series.GetStyle().InheritFrom(c.styleDefaultsSeries(index))
+		pad = util.Math.MaxInt(pad, int(math.Ceil(style.GetStrokeWidth()+style.GetDotWidth()))+1)
+	}
+	if left > right {
+		return
+	}
+
+	dirty := image.Rect(left-pad, 0, right+pad+1, s.height).Intersect(s.frame.Bounds())
+	if dirty.Empty() {
+		s.lengths = c.incrementalLengths()
+		return
+	}
+
+	imagedraw.Draw(s.frame, dirty, s.base, dirty.Min, imagedraw.Src)
+
+	sub := s.frame.SubImage(dirty).(*image.RGBA)
+	r := &rasterRenderer{
+		i:  s.frame,
+		gc: drawing.NewRasterGraphicContextWithPainter(s.frame, raster.NewRGBAPainter(sub)),
+	}
+	r.SetDPI(s.dpi)
+
+	c.drawIncrementalOverlay(r, cl, &dirty)
+	s.lengths = c.incrementalLengths()
+}
+
+// drawIncrementalOverlay draws the series, title and elements; if a dirty region is given, line series are
+// only drawn from the last drawable point before the first point whose marks can reach it.
+func (c Chart) drawIncrementalOverlay(r Renderer, cl ChartLayout, dirty *image.Rectangle) {
+	for index, series := range c.Series {
+		if dirty == nil {
+			c.drawSeries(r, cl, series, index)
+			continue
+		}
+
+		vp, isValuesProvider := series.(ValuesProvider)
+		if !isValuesProvider || !isWindowableSeries(series) {
+			c.drawSeries(r, cl, series, index)
+			continue
+		}
+		style := series.GetStyle().InheritFrom(c.styleDefaultsSeries(index))
+		reach := int(math.Ceil(style.GetStrokeWidth() + style.GetDotWidth()))
+		start := vp.Len()
+		for start > 0 {
+			vx, _ := vp.GetValues(start - 1)
+			if cl.Canvas.Left+cl.XRange.Translate(vx) < dirty.Min.X-reach {
+				break
+			}
+			start--
+		}
+		// the window starts at a drawable point, so the line into the dirty region is drawn across skipped gaps.
+		from := util.Math.MaxInt(start-1, 0)
+		for from > 0 && !isFinitePoint(vp.GetValues(from)) {
+			from--
+		}
+		c.drawSeries(r, cl, incrementalWindowSeries(series, from), index)
+	}
+	c.drawGridAboveSeries(r, cl)
+
+	c.drawTitle(r)
+
+	for _, a := range c.Elements {
+		a(r, cl.Canvas, c.styleDefaultsElements())
+	}
+}
+
+func (c Chart) incrementalRanges() (ranges [3][2]float64) {
+	for index, r := range []Range{c.XAxis.Range, c.YAxis.Range, c.YAxisSecondary.Range} {
+		if r != nil {
+			ranges[index] = [2]float64{r.GetMin(), r.GetMax()}
+		}
+	}
+	return
+}
+
+func (c Chart) incrementalLengths() []int {
+	lengths := make([]int, len(c.Series))
+	for index, series := range c.Series {
+		if vp, isValuesProvider := series.(ValuesProvider); isValuesProvider {
+			lengths[index] = vp.Len()
+		}
+	}
+	return lengths
+}
+
+func isFixedRange(r Range) bool {
+	return r != nil && !r.IsZero()
+}
+
+func copyRGBA(i *image.RGBA) *image.RGBA {
+	c := image.NewRGBA(i.Bounds())
+	copy(c.Pix, i.Pix)
+	return c
+}
+
+// isWindowableSeries returns if a series can be redrawn from a point on by `incrementalWindowSeries`; series with
+// data labels aren't, as the labels of the points before the window can reach into it, and neither are curved
+// series, as their curves bend with the points around them.
+func isWindowableSeries(series Series) bool {
+	if series.GetStyle().Interpolation.isCurved() {
+		return false
+	}
+	switch typed := series.(type) {
+	case ContinuousSeries:
+		return !typed.DataLabels.Show
+	case TimeSeries:
+		return true
+	}
+	return false
+}
+
+// incrementalWindowSeries returns a copy of a line series with the values from an index on, which draws them the
+// way the series would, with the indexes its style provider is called with offset to match.
+func incrementalWindowSeries(series Series, start int) Series {
+	switch typed := series.(type) {
+	case ContinuousSeries:
+		typed.XValues, typed.YValues = typed.XValues[start:], typed.YValues[start:]
+		typed.StyleProvider = offsetStyleProvider(typed.StyleProvider, start)
+		return typed
+	case TimeSeries:
+		typed.XValues, typed.YValues = typed.XValues[start:], typed.YValues[start:]
+		typed.StyleProvider = offsetStyleProvider(typed.StyleProvider, start)
+		return typed
+	}
+	return series
+}
+
+// offsetStyleProvider returns a style provider called with indexes offset by a number of points.
+func offsetStyleProvider(sp func(index int, x, y float64) Style, offset int) func(index int, x, y float64) Style {
+	if sp == nil || offset == 0 {
+		return sp
+	}
+	return func(index int, x, y float64) Style {
+		return sp(offset+index, x, y)
+	}
+}
@@ -0,0 +1,166 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+type rgbaCollector struct {
+	i *image.RGBA
+}
+
+func (rc *rgbaCollector) Write(buffer []byte) (int, error) {
+	return len(buffer), nil
+}
+
+func (rc *rgbaCollector) SetRGBA(i *image.RGBA) {
+	rc.i = i
+}
+
+func incrementalTestChart(count int) Chart {
+	xvalues := make([]float64, count)
+	yvalues := make([]float64, count)
+	for i := 0; i < count; i++ {
+		xvalues[i] = float64(i)
+		yvalues[i] = float64((i * 7) % 11)
+	}
+	return Chart{
+		Width:  512,
+		Height: 256,
+		XAxis:  XAxis{Style: StyleShow(), Range: &ContinuousRange{Min: 0, Max: 100}},
+		YAxis:  YAxis{Style: StyleShow(), Range: &ContinuousRange{Min: 0, Max: 10}},
+		Series: []Series{
+			ContinuousSeries{XValues: xvalues, YValues: yvalues},
+		},
+	}
+}
+
+func TestIncrementalChartRender(t *testing.T) {
+	assert := assert.New(t)
+
+	ic := &IncrementalChart{Chart: incrementalTestChart(50)}
+	first := &rgbaCollector{}
+	assert.Nil(ic.Render(first))
+	assert.False(ic.LastRenderIncremental())
+
+	ic.Chart = incrementalTestChart(60)
+	appended := &rgbaCollector{}
+	assert.Nil(ic.Render(appended))
+	assert.True(ic.LastRenderIncremental())
+
+	full := &rgbaCollector{}
+	assert.Nil((&IncrementalChart{Chart: incrementalTestChart(60)}).Render(full))
+	assert.True(bytes.Equal(full.i.Pix, appended.i.Pix))
+	assert.False(bytes.Equal(first.i.Pix, appended.i.Pix))
+}
+
+func TestIncrementalChartRenderFallsBack(t *testing.T) {
+	assert := assert.New(t)
+
+	ic := &IncrementalChart{Chart: incrementalTestChart(50)}
+	assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+
+	// removing points forces a full render.
+	ic.Chart = incrementalTestChart(40)
+	assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+	assert.False(ic.LastRenderIncremental())
+
+	// as does an unfixed range.
+	ic.Chart = incrementalTestChart(45)
+	ic.Chart.XAxis.Range = nil
+	assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+	assert.False(ic.LastRenderIncremental())
+
+	ic.Reset()
+	ic.Chart = incrementalTestChart(50)
+	assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+	assert.False(ic.LastRenderIncremental())
+}
+
+func incrementalGapTestChart(count int) Chart {
+	c := incrementalTestChart(count)
+	cs := c.Series[0].(ContinuousSeries)
+	for index := range cs.YValues {
+		if index%9 == 4 {
+			cs.YValues[index] = math.NaN()
+		}
+	}
+	cs.GapPolicy = GapPolicyBreak
+	cs.StyleProvider = func(index int, x, y float64) Style {
+		if index%2 == 0 {
+			return Style{StrokeColor: ColorRed}
+		}
+		return Style{}
+	}
+	c.Series[0] = cs
+	return c
+}
+
+func TestIncrementalChartRenderGaps(t *testing.T) {
+	assert := assert.New(t)
+
+	ic := &IncrementalChart{Chart: incrementalGapTestChart(50)}
+	assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+
+	// the appended points span a gap, which the incremental frame breaks the line across like a full render.
+	ic.Chart = incrementalGapTestChart(60)
+	appended := &rgbaCollector{}
+	assert.Nil(ic.Render(appended))
+	assert.True(ic.LastRenderIncremental())
+
+	full := &rgbaCollector{}
+	assert.Nil((&IncrementalChart{Chart: incrementalGapTestChart(60)}).Render(full))
+	assert.True(bytes.Equal(full.i.Pix, appended.i.Pix))
+}
+
+func TestIncrementalChartRenderCurves(t *testing.T) {
+	assert := assert.New(t)
+
+	curved := func(count int, interpolation Interpolation) Chart {
+		c := incrementalTestChart(count)
+		cs := c.Series[0].(ContinuousSeries)
+		cs.Style.Interpolation = interpolation
+		c.Series[0] = cs
+		return c
+	}
+	for _, interpolation := range []Interpolation{InterpolationCatmullRom, InterpolationMonotone, InterpolationStepAfter} {
+		ic := &IncrementalChart{Chart: curved(50, interpolation)}
+		assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+
+		ic.Chart = curved(60, interpolation)
+		appended := &rgbaCollector{}
+		assert.Nil(ic.Render(appended))
+		assert.True(ic.LastRenderIncremental())
+
+		full := &rgbaCollector{}
+		assert.Nil((&IncrementalChart{Chart: curved(60, interpolation)}).Render(full))
+		assert.True(bytes.Equal(full.i.Pix, appended.i.Pix), interpolation)
+	}
+}
+
+func incrementalSkipTestChart(count int) Chart {
+	c := incrementalTestChart(count)
+	c.Series[0].(ContinuousSeries).YValues[48] = math.NaN()
+	return c
+}
+
+func TestIncrementalChartRenderSkippedGap(t *testing.T) {
+	assert := assert.New(t)
+
+	ic := &IncrementalChart{Chart: incrementalSkipTestChart(50)}
+	assert.Nil(ic.Render(bytes.NewBuffer(nil)))
+
+	// the point before the appended ones is skipped, so the line into them starts from the point before it.
+	ic.Chart = incrementalSkipTestChart(60)
+	appended := &rgbaCollector{}
+	assert.Nil(ic.Render(appended))
+	assert.True(ic.LastRenderIncremental())
+
+	full := &rgbaCollector{}
+	assert.Nil((&IncrementalChart{Chart: incrementalSkipTestChart(60)}).Render(full))
+	assert.True(bytes.Equal(full.i.Pix, appended.i.Pix))
+}
@@ -0,0 +1,162 @@
+package chart
+
+import (
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// Interpolation is how a line series is drawn between its points.
+type Interpolation int
+
+const (
+	// InterpolationLinear draws straight segments between points; it is the default.
+	InterpolationLinear Interpolation = iota
+	// InterpolationStep draws steps that change to each value at the previous point,
+	// so a value holds from the x of the point before it up to its own x.
+	InterpolationStep
+	// InterpolationStepAfter draws steps that change to each value at its own point,
+	// so a value holds from its x up to the x of the next point.
+	InterpolationStepAfter
+	// InterpolationCatmullRom draws a smooth (uniform Catmull-Rom) spline through the points.
+	// The spline can overshoot the points, i.e. dip below zero between non negative values.
+	InterpolationCatmullRom
+	// InterpolationMonotone draws a smooth (monotone cubic, Fritsch-Carlson) curve through the points that
+	// never overshoots them: the curve is flat at peaks and troughs, and rises or falls only where the points do.
+	// It expects the x values to be in order.
+	InterpolationMonotone
+)
+
+// interpolationSegmentLength is the length in pixels of the straight segments curves are flattened into.
+const interpolationSegmentLength = 3
+
+// isCurved returns if an interpolation draws curves between points, rather than straight segments.
+func (i Interpolation) isCurved() bool {
+	return i == InterpolationCatmullRom || i == InterpolationMonotone
+}
+
+// cubicSegment is a cubic bezier curve to `End` from the end of the segment before it, or the first point.
+type cubicSegment struct {
+	C1X, C1Y, C2X, C2Y float64
+	End                Point
+}
+
+// curveTo adds the segment to the path of a renderer.
+func (cs cubicSegment) curveTo(r Renderer) {
+	r.CubicCurveTo(int(math.Round(cs.C1X)), int(math.Round(cs.C1Y)), int(math.Round(cs.C2X)), int(math.Round(cs.C2Y)), cs.End.X, cs.End.Y)
+}
+
+// curveSegments returns the curves between each point and the next of a curved interpolation, or nil for
+// an interpolation that isn't curved.
+func curveSegments(points []Point, interpolation Interpolation) []cubicSegment {
+	if len(points) < 2 || !interpolation.isCurved() {
+		return nil
+	}
+	segments := make([]cubicSegment, len(points)-1)
+	switch interpolation {
+	case InterpolationCatmullRom:
+		last := len(points) - 1
+		for index := range segments {
+			p0, p1 := points[util.Math.MaxInt(index-1, 0)], points[index]
+			p2, p3 := points[index+1], points[util.Math.MinInt(index+2, last)]
+			segments[index] = cubicSegment{
+				C1X: float64(p1.X) + float64(p2.X-p0.X)/6, C1Y: float64(p1.Y) + float64(p2.Y-p0.Y)/6,
+				C2X: float64(p2.X) - float64(p3.X-p1.X)/6, C2Y: float64(p2.Y) - float64(p3.Y-p1.Y)/6,
+				End: p2,
+			}
+		}
+	case InterpolationMonotone:
+		tangents := monotoneTangents(points)
+		for index := range segments {
+			p1, p2 := points[index], points[index+1]
+			third := float64(p2.X-p1.X) / 3
+			segments[index] = cubicSegment{
+				C1X: float64(p1.X) + third, C1Y: float64(p1.Y) + tangents[index]*third,
+				C2X: float64(p2.X) - third, C2Y: float64(p2.Y) - tangents[index+1]*third,
+				End: p2,
+			}
+		}
+	}
+	return segments
+}
+
+// interpolatePoints returns the points of the path drawn through canvas points, reusing the storage of `buffer`.
+func interpolatePoints(buffer, points []Point, interpolation Interpolation) []Point {
+	if len(points) < 2 {
+		return append(buffer[:0], points...)
+	}
+
+	switch interpolation {
+	case InterpolationStep, InterpolationStepAfter:
+		output := append(buffer[:0], points[0])
+		for index, p := range points[1:] {
+			previous := points[index]
+			if interpolation == InterpolationStep {
+				output = appendDistinctPoint(output, Point{X: previous.X, Y: p.Y})
+			} else {
+				output = appendDistinctPoint(output, Point{X: p.X, Y: previous.Y})
+			}
+			output = appendDistinctPoint(output, p)
+		}
+		return output
+
+	case InterpolationCatmullRom, InterpolationMonotone:
+		output := append(buffer[:0], points[0])
+		for index, segment := range curveSegments(points, interpolation) {
+			output = appendCubic(output, points[index], segment.C1X, segment.C1Y, segment.C2X, segment.C2Y, segment.End)
+		}
+		return output
+	}
+	return append(buffer[:0], points...)
+}
+
+// monotoneTangents returns the slopes of a monotone cubic curve at each point (the Fritsch-Carlson method).
+// Segments without width are drawn as straight lines, so their slopes are left flat.
+func monotoneTangents(points []Point) []float64 {
+	secants := make([]float64, len(points)-1)
+	for index := range secants {
+		if dx := points[index+1].X - points[index].X; dx != 0 {
+			secants[index] = float64(points[index+1].Y-points[index].Y) / float64(dx)
+		}
+	}
+
+	tangents := make([]float64, len(points))
+	tangents[0], tangents[len(points)-1] = secants[0], secants[len(secants)-1]
+	for index := 1; index < len(points)-1; index++ {
+		if secants[index-1]*secants[index] > 0 {
+			tangents[index] = (secants[index-1] + secants[index]) / 2
+		}
+	}
+
+	// flatten the tangents either side of each segment enough that the curve doesn't overshoot.
+	for index, secant := range secants {
+		if secant == 0 {
+			tangents[index], tangents[index+1] = 0, 0
+			continue
+		}
+		a, b := tangents[index]/secant, tangents[index+1]/secant
+		if magnitude := a*a + b*b; magnitude > 9 {
+			t := 3 / math.Sqrt(magnitude)
+			tangents[index], tangents[index+1] = t*a*secant, t*b*secant
+		}
+	}
+	return tangents
+}
+
+// appendCubic appends the points of a cubic bezier curve from `start` to `end`, less `start` itself,
+// in segments of about `interpolationSegmentLength` pixels.
+func appendCubic(points []Point, start Point, c1x, c1y, c2x, c2y float64, end Point) []Point {
+	x0, y0, x3, y3 := float64(start.X), float64(start.Y), float64(end.X), float64(end.Y)
+	length := math.Hypot(c1x-x0, c1y-y0) + math.Hypot(c2x-c1x, c2y-c1y) + math.Hypot(x3-c2x, y3-c2y)
+	segments := util.Math.MinInt(util.Math.MaxInt(int(math.Ceil(length/interpolationSegmentLength)), 1), 64)
+	for segment := 1; segment < segments; segment++ {
+		t := float64(segment) / float64(segments)
+		mt := 1 - t
+		a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+		points = appendDistinctPoint(points, Point{
+			X: int(math.Floor(a*x0 + b*c1x + c*c2x + d*x3 + 0.5)),
+			Y: int(math.Floor(a*y0 + b*c1y + c*c2y + d*y3 + 0.5)),
+		})
+	}
+	return appendDistinctPoint(points, end)
+}
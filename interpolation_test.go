@@ -0,0 +1,93 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestInterpolatePointsStep(t *testing.T) {
+	assert := assert.New(t)
+
+	points := []Point{{X: 0, Y: 10}, {X: 10, Y: 20}, {X: 20, Y: 5}}
+	assert.Equal([]Point{{X: 0, Y: 10}, {X: 0, Y: 20}, {X: 10, Y: 20}, {X: 10, Y: 5}, {X: 20, Y: 5}}, interpolatePoints(nil, points, InterpolationStep))
+	assert.Equal([]Point{{X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 20}, {X: 20, Y: 20}, {X: 20, Y: 5}}, interpolatePoints(nil, points, InterpolationStepAfter))
+	assert.Equal(points, interpolatePoints(nil, points, InterpolationLinear))
+}
+
+func TestInterpolatePointsCurves(t *testing.T) {
+	assert := assert.New(t)
+
+	points := []Point{{X: 0, Y: 50}, {X: 30, Y: 0}, {X: 60, Y: 0}, {X: 90, Y: 50}}
+	for _, interpolation := range []Interpolation{InterpolationCatmullRom, InterpolationMonotone} {
+		curve := interpolatePoints(nil, points, interpolation)
+		assert.True(len(curve) > len(points))
+		assert.Equal(points[0], curve[0])
+		assert.Equal(points[3], curve[len(curve)-1])
+	}
+
+	// the monotone curve is flat between equal values, where the spline overshoots them.
+	var overshoots bool
+	for _, p := range interpolatePoints(nil, points, InterpolationCatmullRom) {
+		overshoots = overshoots || p.Y < 0
+	}
+	assert.True(overshoots)
+	for _, p := range interpolatePoints(nil, points, InterpolationMonotone) {
+		assert.True(p.Y >= 0 && p.Y <= 50)
+		if p.X >= 30 && p.X <= 60 {
+			assert.Equal(0, p.Y)
+		}
+	}
+}
+
+func TestCurveSegments(t *testing.T) {
+	assert := assert.New(t)
+
+	points := []Point{{X: 0, Y: 50}, {X: 30, Y: 0}, {X: 60, Y: 0}, {X: 90, Y: 50}}
+	assert.Nil(curveSegments(points, InterpolationLinear))
+	assert.Nil(curveSegments(points, InterpolationStep))
+	assert.Nil(curveSegments(points[:1], InterpolationMonotone))
+
+	segments := curveSegments(points, InterpolationMonotone)
+	assert.Len(segments, 3)
+	for index, segment := range segments {
+		assert.Equal(points[index+1], segment.End)
+	}
+	// the monotone curve is flat between equal values, so the control points of the middle segment are too.
+	assert.Equal(0.0, segments[1].C1Y)
+	assert.Equal(0.0, segments[1].C2Y)
+}
+
+func TestCurvedLineSeriesDrawsCubicCurves(t *testing.T) {
+	assert := assert.New(t)
+
+	render := func(interpolation Interpolation) string {
+		c := Chart{
+			Series: []Series{
+				ContinuousSeries{
+					Style:   Style{Show: true, StrokeColor: ColorBlue, StrokeWidth: 2, Interpolation: interpolation},
+					XValues: []float64{1, 2, 3, 4, 5},
+					YValues: []float64{1, 4, 2, 5, 3},
+				},
+			},
+		}
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(SVG, buffer))
+		return buffer.String()
+	}
+
+	// a curve is one cubic command a point, rather than many short line segments.
+	assert.Equal(4, strings.Count(render(InterpolationMonotone), "\nC"))
+	assert.Zero(strings.Count(render(InterpolationLinear), "\nC"))
+}
+
+func TestStyleInheritInterpolation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(InterpolationMonotone, Style{}.InheritFrom(Style{Interpolation: InterpolationMonotone}).Interpolation)
+	assert.Equal(InterpolationStep, Style{Interpolation: InterpolationStep}.InheritFrom(Style{Interpolation: InterpolationMonotone}).Interpolation)
+	// interpolation alone doesn't hide a series.
+	assert.True(Style{Interpolation: InterpolationStep}.IsZero())
+}
@@ -0,0 +1,65 @@
+package chart
+
+// LayoutEngine positions the canvas of a chart, the box the series are plotted in.
+//
+// A chart asks the engine for an initial canvas box before anything is measured, then measures what is drawn
+// around the canvas (axes, tick labels and annotations) and asks the engine to fit the canvas box around it,
+// repeating until the layout settles.
+type LayoutEngine interface {
+	// CanvasBox returns the initial canvas box within the chart bounds, which are the chart minus its background padding.
+	CanvasBox(bounds Box) Box
+	// FitCanvasBox returns the canvas box adjusted so that `outer`, the canvas plus everything drawn around it
+	// as measured for `canvas`, fits within `bounds`.
+	FitCanvasBox(bounds, canvas, outer Box) Box
+}
+
+// DefaultLayoutEngine is the layout engine used by charts that don't set one.
+// The canvas fills the chart bounds and shrinks on each side by as much as the axes overflow that side.
+var DefaultLayoutEngine LayoutEngine = defaultLayoutEngine{}
+
+type defaultLayoutEngine struct{}
+
+func (defaultLayoutEngine) CanvasBox(bounds Box) Box {
+	return bounds
+}
+
+func (defaultLayoutEngine) FitCanvasBox(bounds, canvas, outer Box) Box {
+	return canvas.OuterConstrain(bounds, outer)
+}
+
+// AspectRatioLayout is a layout engine that keeps the canvas at a fixed width to height ratio,
+// centered in the space the default layout would give it.
+type AspectRatioLayout struct {
+	// Ratio is the canvas width divided by its height, i.e. 1 for a square canvas.
+	Ratio float64
+}
+
+// CanvasBox implements LayoutEngine.
+func (arl AspectRatioLayout) CanvasBox(bounds Box) Box {
+	return arl.constrain(bounds)
+}
+
+// FitCanvasBox implements LayoutEngine.
+func (arl AspectRatioLayout) FitCanvasBox(bounds, canvas, outer Box) Box {
+	return arl.constrain(canvas.OuterConstrain(bounds, outer))
+}
+
+func (arl AspectRatioLayout) constrain(b Box) Box {
+	if arl.Ratio <= 0 || b.IsZero() {
+		return b
+	}
+	width, height := b.Right-b.Left, b.Bottom-b.Top
+	if width <= 0 || height <= 0 {
+		return b
+	}
+	if float64(width) > float64(height)*arl.Ratio {
+		fitted := int(float64(height) * arl.Ratio)
+		b.Left += (width - fitted) >> 1
+		b.Right = b.Left + fitted
+	} else {
+		fitted := int(float64(width) / arl.Ratio)
+		b.Top += (height - fitted) >> 1
+		b.Bottom = b.Top + fitted
+	}
+	return b
+}
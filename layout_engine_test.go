@@ -0,0 +1,68 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+type recordingLayoutEngine struct {
+	fits int
+}
+
+func (rle *recordingLayoutEngine) CanvasBox(bounds Box) Box {
+	return Box{Top: bounds.Top + 20, Left: bounds.Left + 20, Right: bounds.Right - 20, Bottom: bounds.Bottom - 20}
+}
+
+func (rle *recordingLayoutEngine) FitCanvasBox(bounds, canvas, outer Box) Box {
+	rle.fits++
+	return DefaultLayoutEngine.FitCanvasBox(bounds, canvas, outer)
+}
+
+func layoutEngineTestChart(engine LayoutEngine) Chart {
+	return Chart{
+		Width:        400,
+		Height:       200,
+		LayoutEngine: engine,
+		XAxis:        XAxis{Style: StyleShow()},
+		YAxis:        YAxis{Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+}
+
+func TestAspectRatioLayoutCanvasBox(t *testing.T) {
+	assert := assert.New(t)
+
+	arl := AspectRatioLayout{Ratio: 2}
+	assert.Equal(Box{Top: 25, Left: 0, Right: 100, Bottom: 75}, arl.CanvasBox(Box{Right: 100, Bottom: 100}))
+	assert.Equal(Box{Top: 0, Left: 50, Right: 250, Bottom: 100}, arl.CanvasBox(Box{Right: 300, Bottom: 100}))
+	assert.Equal(Box{Right: 300, Bottom: 100}, AspectRatioLayout{}.CanvasBox(Box{Right: 300, Bottom: 100}))
+}
+
+func TestChartLayoutEngine(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(400, 200)
+	assert.Nil(err)
+
+	c := layoutEngineTestChart(AspectRatioLayout{Ratio: 1})
+	cl, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+	assert.True(cl.Canvas.Width()-cl.Canvas.Height() <= 1, cl.Canvas.String())
+	assert.True(cl.Canvas.Height()-cl.Canvas.Width() <= 1, cl.Canvas.String())
+
+	engine := &recordingLayoutEngine{}
+	c = layoutEngineTestChart(engine)
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Equal(2, engine.fits)
+
+	cl, err = c.Layout(r, c.Measure())
+	assert.Nil(err)
+	defaultChart := layoutEngineTestChart(nil)
+	defaultLayout, err := defaultChart.Layout(r, defaultChart.Measure())
+	assert.Nil(err)
+	assert.True(cl.Canvas.Left > defaultLayout.Canvas.Left)
+}
@@ -37,7 +37,7 @@ func Legend(c *Chart, userDefaults ...Style) Renderable {
 		var lines []Style
 		for index, s := range c.Series {
 			if s.GetStyle().IsZero() || s.GetStyle().Show {
-				if _, isAnnotationSeries := s.(AnnotationSeries); !isAnnotationSeries {
+				if isLegendSeries(s) {
 					labels = append(labels, s.GetName())
 					lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
 				}
@@ -151,7 +151,7 @@ func LegendThin(c *Chart, userDefaults ...Style) Renderable {
 		var lines []Style
 		for index, s := range c.Series {
 			if s.GetStyle().IsZero() || s.GetStyle().Show {
-				if _, isAnnotationSeries := s.(AnnotationSeries); !isAnnotationSeries {
+				if isLegendSeries(s) {
 					labels = append(labels, s.GetName())
 					lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
 				}
@@ -249,7 +249,7 @@ func LegendLeft(c *Chart, userDefaults ...Style) Renderable {
 		var lines []Style
 		for index, s := range c.Series {
 			if s.GetStyle().IsZero() || s.GetStyle().Show {
-				if _, isAnnotationSeries := s.(AnnotationSeries); !isAnnotationSeries {
+				if isLegendSeries(s) {
 					labels = append(labels, s.GetName())
 					lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
 				}
@@ -330,3 +330,66 @@ func LegendLeft(c *Chart, userDefaults ...Style) Renderable {
 		}
 	}
 }
+
+// drawSwatchLegend draws a legend box in the top left of the canvas that names the colors of the swatches.
+func drawSwatchLegend(r Renderer, cb Box, chartDefaults Style, userDefaults []Style, labels []string, swatches []Style) {
+	legendDefaults := Style{
+		FillColor:   ColorWhite,
+		FontColor:   DefaultTextColor,
+		FontSize:    8.0,
+		StrokeColor: DefaultAxisColor,
+		StrokeWidth: DefaultAxisLineWidth,
+	}
+
+	var legendStyle Style
+	if len(userDefaults) > 0 {
+		legendStyle = userDefaults[0].InheritFrom(chartDefaults.InheritFrom(legendDefaults))
+	} else {
+		legendStyle = chartDefaults.InheritFrom(legendDefaults)
+	}
+
+	legendPadding := 5
+	swatchTextGap := 5
+
+	if len(labels) == 0 {
+		return
+	}
+
+	legendStyle.GetTextOptions().WriteToRenderer(r)
+	var contentWidth, contentHeight, swatchSize int
+	for index, label := range labels {
+		tb := r.MeasureText(label)
+		swatchSize = util.Math.MaxInt(swatchSize, tb.Height())
+		if index > 0 {
+			contentHeight += DefaultLineSpacing
+		}
+		contentHeight += tb.Height()
+		contentWidth = util.Math.MaxInt(contentWidth, tb.Width())
+	}
+	contentWidth += swatchSize + swatchTextGap
+
+	legend := Box{
+		Top:    cb.Top,
+		Left:   cb.Left,
+		Right:  cb.Left + contentWidth + 2*legendPadding,
+		Bottom: cb.Top + contentHeight + 2*legendPadding,
+	}
+	Draw.Box(r, legend, legendStyle)
+
+	ycursor := legend.Top + legendPadding
+	for index, label := range labels {
+		legendStyle.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+		swatch := Box{
+			Top:    ycursor + ((tb.Height() - swatchSize) >> 1),
+			Left:   legend.Left + legendPadding,
+			Right:  legend.Left + legendPadding + swatchSize,
+			Bottom: ycursor + ((tb.Height() - swatchSize) >> 1) + swatchSize,
+		}
+		Draw.Box(r, swatch, swatches[index])
+
+		legendStyle.GetTextOptions().WriteToRenderer(r)
+		r.Text(label, swatch.Right+swatchTextGap, ycursor+tb.Height())
+		ycursor += tb.Height() + DefaultLineSpacing
+	}
+}
@@ -0,0 +1,283 @@
+package chart
+
+import (
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// LegendPlacement is where a chart draws its legend.
+type LegendPlacement int
+
+const (
+	// LegendPlacementInsideTopLeft draws the legend over the top left corner of the canvas, like `Legend`.
+	LegendPlacementInsideTopLeft LegendPlacement = iota
+	// LegendPlacementInsideTopRight draws the legend over the top right corner of the canvas.
+	LegendPlacementInsideTopRight
+	// LegendPlacementInsideBottomLeft draws the legend over the bottom left corner of the canvas.
+	LegendPlacementInsideBottomLeft
+	// LegendPlacementInsideBottomRight draws the legend over the bottom right corner of the canvas.
+	LegendPlacementInsideBottomRight
+	// LegendPlacementTop draws the legend above the canvas and its axes, centered on the canvas.
+	LegendPlacementTop
+	// LegendPlacementBottom draws the legend below the canvas and its axes, centered on the canvas.
+	LegendPlacementBottom
+	// LegendPlacementLeft draws the legend left of the canvas and its axes, centered on the canvas.
+	LegendPlacementLeft
+	// LegendPlacementRight draws the legend right of the canvas and its axes, centered on the canvas.
+	LegendPlacementRight
+)
+
+// IsOutside returns if the legend is drawn outside the canvas, which is shrunk to make room for it.
+func (lp LegendPlacement) IsOutside() bool {
+	return lp >= LegendPlacementTop
+}
+
+// anchor returns the edge of the chart a legend outside the canvas is docked against.
+func (lp LegendPlacement) anchor() LayoutAnchor {
+	switch lp {
+	case LegendPlacementBottom:
+		return LayoutAnchorBottom
+	case LegendPlacementLeft:
+		return LayoutAnchorLeft
+	case LegendPlacementRight:
+		return LayoutAnchorRight
+	}
+	return LayoutAnchorTop
+}
+
+// LegendOrientation is the direction the entries of a legend run in.
+type LegendOrientation int
+
+const (
+	// LegendOrientationDefault is horizontal for legends above or below the canvas, and vertical otherwise.
+	LegendOrientationDefault LegendOrientation = iota
+	// LegendOrientationVertical lists the entries down each column.
+	LegendOrientationVertical
+	// LegendOrientationHorizontal lists the entries along each row, wrapping onto more rows.
+	LegendOrientationHorizontal
+)
+
+// LegendOptions are the options of the legend a chart draws itself, i.e. `Chart.Legend`.
+// Unlike the `Legend` elements, the chart lays out its canvas around legends placed outside it,
+// so the legend never overlaps the plot.
+type LegendOptions struct {
+	// Style is the style of the legend box and its labels; the legend is drawn if it is shown.
+	Style Style
+	// Placement is where the legend is drawn.
+	Placement LegendPlacement
+	// Orientation is the direction the entries run in.
+	Orientation LegendOrientation
+	// Columns is the number of columns of entries. It defaults to one for vertical legends,
+	// and to as many as fit for horizontal legends: the width of the chart for legends outside the canvas,
+	// or of the canvas for legends inside it.
+	Columns int
+}
+
+// GetOrientation returns the orientation or the default for the placement.
+func (lo LegendOptions) GetOrientation() LegendOrientation {
+	if lo.Orientation != LegendOrientationDefault {
+		return lo.Orientation
+	}
+	if lo.Placement == LegendPlacementTop || lo.Placement == LegendPlacementBottom {
+		return LegendOrientationHorizontal
+	}
+	return LegendOrientationVertical
+}
+
+// legendLayout is the entries of a legend measured and laid out in a grid.
+type legendLayout struct {
+	style  Style
+	labels []string
+	lines  []Style
+	// cells are the boxes of the entries, relative to the top left of the legend.
+	cells         []Box
+	width, height int
+}
+
+// shouldDrawLegend returns if the chart draws its own legend.
+func (c Chart) shouldDrawLegend() bool {
+	return c.Legend.Style.Show && !c.NoText
+}
+
+// getLegendEntries returns the labels and styles of the series named in the legend.
+func (c Chart) getLegendEntries() (labels []string, lines []Style) {
+	for index, s := range c.Series {
+		if (s.GetStyle().IsZero() || s.GetStyle().Show) && isLegendSeries(s) && len(s.GetName()) > 0 {
+			labels = append(labels, s.GetName())
+			lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
+		}
+	}
+	return
+}
+
+// measureLegend lays out the legend, wrapping horizontal legends within `maxWidth`.
+func (c Chart) measureLegend(r Renderer, maxWidth int) (ll legendLayout) {
+	ll.style = c.Legend.Style.InheritFrom(c.styleDefaultsElements().InheritFrom(c.styleDefaultsLegend()))
+	ll.labels, ll.lines = c.getLegendEntries()
+	if len(ll.labels) == 0 {
+		return
+	}
+
+	ll.style.GetTextOptions().WriteToRenderer(r)
+	entries := make([]Box, len(ll.labels))
+	var rowHeight int
+	for index, label := range ll.labels {
+		tb := r.MeasureText(label)
+		entries[index] = Box{Right: tb.Width() + DefaultLegendLineTextGap + DefaultLegendLineLength, Bottom: tb.Height()}
+		rowHeight = util.Math.MaxInt(rowHeight, tb.Height())
+	}
+
+	padding := ll.style.Padding
+	horizontal := c.Legend.GetOrientation() == LegendOrientationHorizontal
+	columns := c.Legend.Columns
+	if columns <= 0 && horizontal {
+		for columns = len(entries); columns > 1; columns-- {
+			if legendGridWidth(legendColumnWidths(entries, columns, horizontal))+padding.Left+padding.Right <= maxWidth {
+				break
+			}
+		}
+	}
+	columns = util.Math.MinInt(util.Math.MaxInt(columns, 1), len(entries))
+	rows := (len(entries) + columns - 1) / columns
+
+	columnWidths := legendColumnWidths(entries, columns, horizontal)
+	ll.cells = make([]Box, len(entries))
+	for index := range entries {
+		row, column := legendCell(index, columns, rows, horizontal)
+		left := padding.Left
+		for _, width := range columnWidths[:column] {
+			left += width + DefaultMinimumTickHorizontalSpacing
+		}
+		top := padding.Top + row*(rowHeight+DefaultMinimumTickVerticalSpacing)
+		ll.cells[index] = Box{Top: top, Left: left, Right: left + entries[index].Width(), Bottom: top + rowHeight}
+	}
+	ll.width = legendGridWidth(columnWidths) + padding.Left + padding.Right
+	ll.height = rows*rowHeight + (rows-1)*DefaultMinimumTickVerticalSpacing + padding.Top + padding.Bottom
+	return
+}
+
+// legendCell returns the row and column of an entry; horizontal legends fill rows first, vertical legends columns.
+func legendCell(index, columns, rows int, horizontal bool) (row, column int) {
+	if horizontal {
+		return index / columns, index % columns
+	}
+	return index % rows, index / rows
+}
+
+// legendColumnWidths returns the width of each column, the widest of its entries.
+func legendColumnWidths(entries []Box, columns int, horizontal bool) []int {
+	rows := (len(entries) + columns - 1) / columns
+	widths := make([]int, columns)
+	for index, entry := range entries {
+		_, column := legendCell(index, columns, rows, horizontal)
+		widths[column] = util.Math.MaxInt(widths[column], entry.Width())
+	}
+	return widths
+}
+
+func legendGridWidth(columnWidths []int) (width int) {
+	for index, columnWidth := range columnWidths {
+		if index > 0 {
+			width += DefaultMinimumTickHorizontalSpacing
+		}
+		width += columnWidth
+	}
+	return
+}
+
+// getLegendMaxWidth returns the width a horizontal legend wraps within.
+func (c Chart) getLegendMaxWidth(canvasBox Box) int {
+	if c.Legend.Placement.IsOutside() {
+		return c.getContentBox().Width()
+	}
+	return canvasBox.Width()
+}
+
+// getLegendAdjustedBox returns the chart box less the space taken by a legend outside the canvas.
+func (c Chart) getLegendAdjustedBox(r Renderer) Box {
+	b := c.getContentBox()
+	if !c.shouldDrawLegend() || !c.Legend.Placement.IsOutside() {
+		return b
+	}
+	ll := c.measureLegend(r, c.getLegendMaxWidth(b))
+	if len(ll.labels) == 0 {
+		return b
+	}
+	item := BoxLayoutItem{Anchor: c.Legend.Placement.anchor(), Intrinsic: ll.height, Gap: DefaultLegendSpacing}
+	if item.Anchor.isHorizontal() {
+		item.Intrinsic = ll.width
+	}
+	_, b = BoxLayout{Items: []BoxLayoutItem{item}}.Solve(b)
+	return b
+}
+
+// getLegendBox returns where a legend is drawn.
+func (c Chart) getLegendBox(canvasBox Box, width, height int) Box {
+	b := c.getContentBox()
+	// outside legends are centered on the canvas, but kept within the chart.
+	centerX := util.Math.MaxInt(b.Left, util.Math.MinInt(canvasBox.Left+((canvasBox.Width()-width)>>1), b.Right-width))
+	centerY := util.Math.MaxInt(b.Top, util.Math.MinInt(canvasBox.Top+((canvasBox.Height()-height)>>1), b.Bottom-height))
+
+	var left, top int
+	switch c.Legend.Placement {
+	case LegendPlacementInsideTopRight:
+		left, top = canvasBox.Right-width, canvasBox.Top
+	case LegendPlacementInsideBottomLeft:
+		left, top = canvasBox.Left, canvasBox.Bottom-height
+	case LegendPlacementInsideBottomRight:
+		left, top = canvasBox.Right-width, canvasBox.Bottom-height
+	case LegendPlacementTop:
+		left, top = centerX, b.Top
+	case LegendPlacementBottom:
+		left, top = centerX, b.Bottom-height
+	case LegendPlacementLeft:
+		left, top = b.Left, centerY
+	case LegendPlacementRight:
+		left, top = b.Right-width, centerY
+	default:
+		left, top = canvasBox.Left, canvasBox.Top
+	}
+	return Box{Top: top, Left: left, Right: left + width, Bottom: top + height}
+}
+
+// drawLegend draws the chart's own legend.
+func (c Chart) drawLegend(r Renderer, canvasBox Box) {
+	if !c.shouldDrawLegend() {
+		return
+	}
+	ll := c.measureLegend(r, c.getLegendMaxWidth(canvasBox))
+	if len(ll.labels) == 0 {
+		return
+	}
+
+	legend := c.getLegendBox(canvasBox, ll.width, ll.height)
+	Draw.Box(r, legend, ll.style)
+
+	for index, label := range ll.labels {
+		cell := ll.cells[index].Shift(legend.Left, legend.Top)
+
+		ll.style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(label)
+		r.Text(label, cell.Left, cell.Bottom)
+
+		ly := cell.Bottom - (tb.Height() >> 1)
+		lx := cell.Left + tb.Width() + DefaultLegendLineTextGap
+		r.SetStrokeColor(ll.lines[index].GetStrokeColor())
+		r.SetStrokeWidth(ll.lines[index].GetStrokeWidth())
+		r.SetStrokeDashArray(ll.lines[index].GetStrokeDashArray())
+		r.MoveTo(lx, ly)
+		r.LineTo(lx+DefaultLegendLineLength, ly)
+		r.Stroke()
+	}
+}
+
+func (c Chart) styleDefaultsLegend() Style {
+	return Style{
+		FillColor:   drawing.ColorWhite,
+		FontColor:   DefaultTextColor,
+		FontSize:    8.0,
+		StrokeColor: DefaultAxisColor,
+		StrokeWidth: DefaultAxisLineWidth,
+		Padding:     Box{Top: 5, Left: 5, Right: 5, Bottom: 5},
+	}
+}
@@ -2,6 +2,7 @@ package chart
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/blendlabs/go-assert"
@@ -29,3 +30,104 @@ func TestLegend(t *testing.T) {
 	assert.Nil(err)
 	assert.NotZero(buf.Len())
 }
+
+func testLegendChart(options LegendOptions) Chart {
+	var series []Series
+	for _, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		series = append(series, ContinuousSeries{
+			Name:    name,
+			XValues: []float64{1.0, 2.0, 3.0},
+			YValues: []float64{1.0, 3.0, 2.0},
+		})
+	}
+	options.Style.Show = true
+	return Chart{Width: 600, Height: 400, Series: series, Legend: options}
+}
+
+func TestLegendOptionsOutside(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(600, 400)
+	assert.Nil(err)
+
+	plain := testLegendChart(LegendOptions{})
+	plain.Legend.Style.Show = false
+	plainLayout, err := plain.Layout(r, plain.Measure())
+	assert.Nil(err)
+
+	for _, placement := range []LegendPlacement{LegendPlacementTop, LegendPlacementBottom, LegendPlacementLeft, LegendPlacementRight} {
+		c := testLegendChart(LegendOptions{Placement: placement})
+		cl, err := c.Layout(r, c.Measure())
+		assert.Nil(err)
+
+		ll := c.measureLegend(r, c.getLegendMaxWidth(cl.Canvas))
+		legend := c.getLegendBox(cl.Canvas, ll.width, ll.height)
+		switch placement {
+		case LegendPlacementTop:
+			assert.True(legend.Bottom < cl.Canvas.Top)
+			assert.True(cl.Canvas.Top > plainLayout.Canvas.Top)
+		case LegendPlacementBottom:
+			assert.True(legend.Top > cl.Canvas.Bottom)
+			assert.True(cl.Canvas.Bottom < plainLayout.Canvas.Bottom)
+		case LegendPlacementLeft:
+			assert.True(legend.Right < cl.Canvas.Left)
+		case LegendPlacementRight:
+			assert.True(cl.Canvas.Right < plainLayout.Canvas.Right)
+			assert.True(legend.Left > cl.Canvas.Right)
+		}
+	}
+}
+
+func TestLegendOptionsInside(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(600, 400)
+	assert.Nil(err)
+
+	c := testLegendChart(LegendOptions{Placement: LegendPlacementInsideBottomRight})
+	cl, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+	assert.Equal(c.getDefaultCanvasBox().Bottom, c.getPlotBox().Bottom)
+
+	ll := c.measureLegend(r, c.getLegendMaxWidth(cl.Canvas))
+	legend := c.getLegendBox(cl.Canvas, ll.width, ll.height)
+	assert.Equal(cl.Canvas.Right, legend.Right)
+	assert.Equal(cl.Canvas.Bottom, legend.Bottom)
+}
+
+func TestLegendOptionsColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(600, 400)
+	assert.Nil(err)
+
+	vertical := testLegendChart(LegendOptions{Placement: LegendPlacementRight})
+	ll := vertical.measureLegend(r, 600)
+	assert.Len(ll.cells, 5)
+	assert.Equal(ll.cells[0].Left, ll.cells[4].Left)
+	assert.True(ll.cells[4].Top > ll.cells[0].Top)
+
+	// vertical legends with more columns fill each column first.
+	columns := testLegendChart(LegendOptions{Placement: LegendPlacementRight, Columns: 2})
+	ll = columns.measureLegend(r, 600)
+	assert.Equal(ll.cells[0].Left, ll.cells[2].Left)
+	assert.True(ll.cells[3].Left > ll.cells[2].Left)
+	assert.Equal(ll.cells[0].Top, ll.cells[3].Top)
+
+	// horizontal legends wrap within the width they are given.
+	horizontal := testLegendChart(LegendOptions{Placement: LegendPlacementBottom})
+	wide := horizontal.measureLegend(r, 600)
+	assert.Equal(wide.cells[0].Top, wide.cells[4].Top)
+	narrow := horizontal.measureLegend(r, 150)
+	assert.True(narrow.cells[4].Top > narrow.cells[0].Top)
+	assert.True(narrow.width < wide.width)
+}
+
+func TestLegendOptionsRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := testLegendChart(LegendOptions{Placement: LegendPlacementBottom})
+	buf := bytes.NewBuffer([]byte{})
+	assert.Nil(c.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "Charlie"))
+}
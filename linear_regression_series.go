@@ -8,14 +8,17 @@ import (
 )
 
 // LinearRegressionSeries is a series that plots the n-nearest neighbors
-// linear regression for the values.
+// linear regression for the values. If `Window` is set, each value is instead
+// the regression over the `Window` values leading up to it (a rolling regression).
 type LinearRegressionSeries struct {
 	Name  string
 	Style Style
 	YAxis YAxisType
+	XAxis XAxisType
 
 	Limit       int
 	Offset      int
+	Window      int
 	InnerSeries ValuesProvider
 
 	m       float64
@@ -39,6 +42,11 @@ func (lrs LinearRegressionSeries) GetYAxis() YAxisType {
 	return lrs.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (lrs LinearRegressionSeries) GetXAxis() XAxisType {
+	return lrs.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (lrs LinearRegressionSeries) Len() int {
 	return util.Math.MinInt(lrs.GetLimit(), lrs.InnerSeries.Len()-lrs.GetOffset())
@@ -72,14 +80,9 @@ func (lrs *LinearRegressionSeries) GetValues(index int) (x, y float64) {
 	if lrs.InnerSeries == nil || lrs.InnerSeries.Len() == 0 {
 		return
 	}
-	if lrs.m == 0 && lrs.b == 0 {
-		lrs.computeCoefficients()
-	}
 	offset := lrs.GetOffset()
 	effectiveIndex := util.Math.MinInt(index+offset, lrs.InnerSeries.Len())
-	x, y = lrs.InnerSeries.GetValues(effectiveIndex)
-	y = (lrs.m * lrs.normalize(x)) + lrs.b
-	return
+	return lrs.valueAt(effectiveIndex)
 }
 
 // GetLastValues computes the last linear regression value.
@@ -87,11 +90,27 @@ func (lrs *LinearRegressionSeries) GetLastValues() (x, y float64) {
 	if lrs.InnerSeries == nil || lrs.InnerSeries.Len() == 0 {
 		return
 	}
+	return lrs.valueAt(lrs.GetEndIndex())
+}
+
+// RSquared returns the coefficient of determination of the regression
+// against the inner series values it was fit to.
+func (lrs *LinearRegressionSeries) RSquared() float64 {
+	if lrs.InnerSeries == nil || lrs.InnerSeries.Len() == 0 {
+		return 0
+	}
+	return rSquared(lrs.InnerSeries, lrs.GetOffset(), lrs.GetEndIndex(), lrs.valueAt)
+}
+
+// valueAt returns the regression value for an index of the inner series.
+func (lrs *LinearRegressionSeries) valueAt(innerIndex int) (x, y float64) {
+	if lrs.Window > 0 {
+		return rollingRegressionValues(lrs.InnerSeries, lrs.GetOffset(), innerIndex, lrs.Window, linearFit)
+	}
 	if lrs.m == 0 && lrs.b == 0 {
 		lrs.computeCoefficients()
 	}
-	endIndex := lrs.GetEndIndex()
-	x, y = lrs.InnerSeries.GetValues(endIndex)
+	x, y = lrs.InnerSeries.GetValues(innerIndex)
 	y = (lrs.m * lrs.normalize(x)) + lrs.b
 	return
 }
@@ -143,5 +162,8 @@ func (lrs *LinearRegressionSeries) Validate() error {
 	if lrs.InnerSeries == nil {
 		return fmt.Errorf("linear regression series requires InnerSeries to be set")
 	}
+	if lrs.Window < 0 {
+		return fmt.Errorf("linear regression series window cannot be negative")
+	}
 	return nil
 }
@@ -76,3 +76,47 @@ func TestLinearRegressionSeriesWindowAndOffset(t *testing.T) {
 	assert.InDelta(80.0, lrxn, 0.0000001)
 	assert.InDelta(80.0, lryn, 0.0000001)
 }
+
+func TestLinearRegressionSeriesRSquared(t *testing.T) {
+	assert := assert.New(t)
+
+	linRegSeries := &LinearRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: seq.Range(1.0, 100.0),
+			YValues: seq.Range(1.0, 100.0),
+		},
+	}
+	assert.InDelta(1.0, linRegSeries.RSquared(), 0.0000001)
+
+	linRegSeries = &LinearRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4},
+			YValues: []float64{1, 3, 2, 4},
+		},
+		Limit: 4,
+	}
+	// the fit is over the first three values; y = 0.5x + 1 has an R² of 0.25.
+	assert.InDelta(0.25, linRegSeries.RSquared(), 0.0000001)
+}
+
+func TestLinearRegressionSeriesRolling(t *testing.T) {
+	assert := assert.New(t)
+
+	linRegSeries := &LinearRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4, 5, 6},
+			YValues: []float64{1, 2, 3, 10, 20, 30},
+		},
+		Window: 3,
+	}
+	assert.Nil(linRegSeries.Validate())
+
+	_, y0 := linRegSeries.GetValues(0)
+	assert.InDelta(1.0, y0, 0.0000001)
+	_, y2 := linRegSeries.GetValues(2)
+	assert.InDelta(3.0, y2, 0.0000001)
+	_, y5 := linRegSeries.GetValues(5)
+	assert.InDelta(30.0, y5, 0.0000001)
+	_, yn := linRegSeries.GetLastValues()
+	assert.InDelta(30.0, yn, 0.0000001)
+}
@@ -0,0 +1,252 @@
+package chart
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale is the month and weekday names and date formats used to label time ticks in a language.
+// Formats are `time.Format` layouts; the english names in them (`January`, `Jan`, `Monday`, `Mon`)
+// are replaced with the locale's names, see `Locale.Format`.
+type Locale struct {
+	// Tag is the language tag of the locale, i.e. `de` or `en-US`.
+	Tag string
+
+	Months        [12]string
+	ShortMonths   [12]string
+	Weekdays      [7]string
+	ShortWeekdays [7]string
+
+	// DateFormat labels day ticks.
+	DateFormat string
+	// DateHourFormat labels hour ticks.
+	DateHourFormat string
+	// DateMinuteFormat labels minute ticks.
+	DateMinuteFormat string
+	// MonthFormat labels month ticks.
+	MonthFormat string
+	// YearFormat labels year ticks.
+	YearFormat string
+
+	// GroupSeparator and DecimalMark are the separators of numbers; see `NumberFormat.WithLocale`.
+	GroupSeparator string
+	DecimalMark    string
+}
+
+// IsZero returns if the locale is unset.
+func (l Locale) IsZero() bool {
+	return len(l.Tag) == 0
+}
+
+// Format formats a time with a `time.Format` layout, with month and weekday names in the locale's language.
+func (l Locale) Format(t time.Time, layout string) string {
+	var output strings.Builder
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			output.WriteString(t.Format(literal.String()))
+			literal.Reset()
+		}
+	}
+	for len(layout) > 0 {
+		switch {
+		case strings.HasPrefix(layout, "January"):
+			flush()
+			output.WriteString(l.Months[t.Month()-1])
+			layout = layout[len("January"):]
+		case strings.HasPrefix(layout, "Jan"):
+			flush()
+			output.WriteString(l.ShortMonths[t.Month()-1])
+			layout = layout[len("Jan"):]
+		case strings.HasPrefix(layout, "Monday"):
+			flush()
+			output.WriteString(l.Weekdays[t.Weekday()])
+			layout = layout[len("Monday"):]
+		case strings.HasPrefix(layout, "Mon"):
+			flush()
+			output.WriteString(l.ShortWeekdays[t.Weekday()])
+			layout = layout[len("Mon"):]
+		default:
+			literal.WriteByte(layout[0])
+			layout = layout[1:]
+		}
+	}
+	flush()
+	return output.String()
+}
+
+// TimeValueFormatter returns a value formatter for timestamps in a layout, with names in the locale's language.
+func (l Locale) TimeValueFormatter(layout string) ValueFormatter {
+	return func(v interface{}) string {
+		switch typed := v.(type) {
+		case time.Time:
+			return l.Format(typed, layout)
+		case int64:
+			return l.Format(time.Unix(0, typed), layout)
+		case float64:
+			return l.Format(time.Unix(0, int64(typed)), layout)
+		}
+		return ""
+	}
+}
+
+var (
+	_localesLock sync.RWMutex
+	_locales     = map[string]Locale{}
+)
+
+// RegisterLocale registers a locale by its tag, replacing a built in locale with the same tag.
+func RegisterLocale(l Locale) {
+	_localesLock.Lock()
+	defer _localesLock.Unlock()
+	_locales[strings.ToLower(l.Tag)] = l
+}
+
+// GetLocale returns the locale for a language tag, i.e. `de-AT`; if there is no locale for the full tag,
+// the locale for its language (`de`) is returned. Built in locales are `en`, `en-US`, `en-GB`, `de`, `es`, `fr`,
+// `it`, `nl`, `pt` and `ja`.
+func GetLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.Replace(tag, "_", "-", -1))
+	_localesLock.RLock()
+	defer _localesLock.RUnlock()
+	if l, hasLocale := _locales[tag]; hasLocale {
+		return l, true
+	}
+	if index := strings.Index(tag, "-"); index > 0 {
+		if l, hasLocale := _locales[tag[:index]]; hasLocale {
+			return l, true
+		}
+	}
+	return Locale{}, false
+}
+
+func init() {
+	english := Locale{
+		Tag:              "en",
+		Months:           [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:      [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:         [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays:    [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DateFormat:       "Jan 2",
+		DateHourFormat:   "Jan 2 3PM",
+		DateMinuteFormat: "Jan 2 3:04PM",
+		MonthFormat:      "Jan 2006",
+		YearFormat:       "2006",
+		GroupSeparator:   ",",
+		DecimalMark:      ".",
+	}
+	american := english
+	american.Tag = "en-US"
+	british := english
+	british.Tag = "en-GB"
+	british.DateFormat = "2 Jan"
+	british.DateHourFormat = "2 Jan 15:04"
+	british.DateMinuteFormat = "2 Jan 15:04"
+
+	for _, l := range []Locale{
+		english,
+		american,
+		british,
+		{
+			Tag:              "de",
+			Months:           [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+			ShortMonths:      [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+			Weekdays:         [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+			ShortWeekdays:    [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+			DateFormat:       "2. Jan",
+			DateHourFormat:   "2. Jan 15:04",
+			DateMinuteFormat: "2. Jan 15:04",
+			MonthFormat:      "Jan 2006",
+			YearFormat:       "2006",
+			GroupSeparator:   ".",
+			DecimalMark:      ",",
+		},
+		{
+			Tag:              "es",
+			Months:           [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			ShortMonths:      [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+			Weekdays:         [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			ShortWeekdays:    [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+			DateFormat:       "2 Jan",
+			DateHourFormat:   "2 Jan 15:04",
+			DateMinuteFormat: "2 Jan 15:04",
+			MonthFormat:      "Jan 2006",
+			YearFormat:       "2006",
+			GroupSeparator:   ".",
+			DecimalMark:      ",",
+		},
+		{
+			Tag:              "fr",
+			Months:           [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+			ShortMonths:      [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+			Weekdays:         [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+			ShortWeekdays:    [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+			DateFormat:       "2 Jan",
+			DateHourFormat:   "2 Jan 15:04",
+			DateMinuteFormat: "2 Jan 15:04",
+			MonthFormat:      "Jan 2006",
+			YearFormat:       "2006",
+			GroupSeparator:   "\u00a0",
+			DecimalMark:      ",",
+		},
+		{
+			Tag:              "it",
+			Months:           [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+			ShortMonths:      [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+			Weekdays:         [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+			ShortWeekdays:    [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+			DateFormat:       "2 Jan",
+			DateHourFormat:   "2 Jan 15:04",
+			DateMinuteFormat: "2 Jan 15:04",
+			MonthFormat:      "Jan 2006",
+			YearFormat:       "2006",
+			GroupSeparator:   ".",
+			DecimalMark:      ",",
+		},
+		{
+			Tag:              "nl",
+			Months:           [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+			ShortMonths:      [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+			Weekdays:         [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+			ShortWeekdays:    [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+			DateFormat:       "2 Jan",
+			DateHourFormat:   "2 Jan 15:04",
+			DateMinuteFormat: "2 Jan 15:04",
+			MonthFormat:      "Jan 2006",
+			YearFormat:       "2006",
+			GroupSeparator:   ".",
+			DecimalMark:      ",",
+		},
+		{
+			Tag:              "pt",
+			Months:           [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+			ShortMonths:      [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+			Weekdays:         [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+			ShortWeekdays:    [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+			DateFormat:       "2 Jan",
+			DateHourFormat:   "2 Jan 15:04",
+			DateMinuteFormat: "2 Jan 15:04",
+			MonthFormat:      "Jan 2006",
+			YearFormat:       "2006",
+			GroupSeparator:   ".",
+			DecimalMark:      ",",
+		},
+		{
+			Tag:              "ja",
+			Months:           [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			ShortMonths:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			Weekdays:         [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+			ShortWeekdays:    [7]string{"日", "月", "火", "水", "木", "金", "土"},
+			DateFormat:       "Jan2日",
+			DateHourFormat:   "Jan2日 15時",
+			DateMinuteFormat: "Jan2日 15:04",
+			MonthFormat:      "2006年Jan",
+			YearFormat:       "2006年",
+			GroupSeparator:   ",",
+			DecimalMark:      ".",
+		},
+	} {
+		_locales[strings.ToLower(l.Tag)] = l
+	}
+}
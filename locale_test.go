@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestGetLocale(t *testing.T) {
+	assert := assert.New(t)
+
+	de, hasLocale := GetLocale("de-AT")
+	assert.True(hasLocale)
+	assert.Equal("de", de.Tag)
+
+	gb, hasLocale := GetLocale("en_GB")
+	assert.True(hasLocale)
+	assert.Equal("en-GB", gb.Tag)
+
+	_, hasLocale = GetLocale("xx")
+	assert.False(hasLocale)
+	_, hasLocale = GetLocale("")
+	assert.False(hasLocale)
+}
+
+func TestLocaleFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := time.Date(2017, 3, 6, 15, 4, 0, 0, time.UTC)
+	de, _ := GetLocale("de")
+	assert.Equal("Montag, 6. März 2017", de.Format(ts, "Monday, 2. January 2006"))
+	assert.Equal("Mo 6. Mär 15:04", de.Format(ts, "Mon 2. Jan 15:04"))
+
+	fr, _ := GetLocale("fr")
+	assert.Equal("6 mars", fr.TimeValueFormatter(fr.DateFormat)(ts))
+	assert.Equal("mars 2017", fr.TimeValueFormatter(fr.MonthFormat)(float64(ts.UnixNano())))
+
+	ja, _ := GetLocale("ja")
+	assert.Equal("3月6日", ja.Format(ts, ja.DateFormat))
+	assert.Equal("2017年3月", ja.Format(ts, ja.MonthFormat))
+
+	en, _ := GetLocale("en")
+	assert.Equal(ts.Format("Mon Jan 2 2006"), en.Format(ts, "Mon Jan 2 2006"))
+	assert.Equal("", en.TimeValueFormatter(en.DateFormat)("nope"))
+}
+
+func TestRegisterLocale(t *testing.T) {
+	assert := assert.New(t)
+
+	de, _ := GetLocale("de")
+	custom := de
+	custom.Tag = "de-CH"
+	custom.DateFormat = "02.01."
+	RegisterLocale(custom)
+	defer func() {
+		_localesLock.Lock()
+		delete(_locales, "de-ch")
+		_localesLock.Unlock()
+	}()
+
+	l, hasLocale := GetLocale("de-CH")
+	assert.True(hasLocale)
+	assert.Equal("02.01.", l.DateFormat)
+}
+
+func TestTimeRangeLanguage(t *testing.T) {
+	assert := assert.New(t)
+
+	min := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC)
+	tr := &TimeRange{Location: time.UTC, Language: "de"}
+	tr.SetMin(float64(min.UnixNano()))
+	tr.SetMax(float64(max.UnixNano()))
+	tr.SetDomain(2000)
+
+	r, style := timeRangeTestRenderer(t)
+	ticks := tr.GetTicks(r, style, TimeValueFormatter)
+	assert.Len(ticks, 12)
+	assert.Equal("Jan 2017", ticks[0].Label)
+	assert.Equal("Mär 2017", ticks[2].Label)
+	assert.Equal("Dez 2017", ticks[11].Label)
+
+	// custom value formatters take precedence.
+	ticks = tr.GetTicks(r, style, TimeDateValueFormatter)
+	assert.Equal("2017-03-01", ticks[2].Label)
+}
@@ -0,0 +1,186 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// LogarithmicRange is a range that translates values in log space, for data that spans several orders of magnitude.
+// Its ticks land on powers of 10, with unlabeled minor ticks at the multiples of each power in between if they fit.
+//
+// Values that are not positive have no logarithm; they translate to the start of the range. A chart sets the minimum
+// of a logarithmic range to the smallest positive value of its series, and, if the axis is shown, rounds the bounds
+// out to powers of 10.
+type LogarithmicRange struct {
+	ContinuousRange
+}
+
+// String returns a simple string for the range.
+func (lr LogarithmicRange) String() string {
+	return fmt.Sprintf("LogarithmicRange [%.2f,%.2f] => %d", lr.Min, lr.Max, lr.Domain)
+}
+
+// Translate maps a given value into the range space by its logarithm.
+func (lr LogarithmicRange) Translate(value float64) int {
+	min, max := lr.getLogBounds()
+	delta := max - min
+	if delta == 0 || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		if lr.IsDescending() {
+			return lr.Domain
+		}
+		return 0
+	}
+
+	ratio := (lr.log(value) - min) / delta
+	if lr.IsDescending() {
+		return lr.Domain - int(math.Ceil(ratio*float64(lr.Domain)))
+	}
+	return int(math.Ceil(ratio * float64(lr.Domain)))
+}
+
+// log returns the logarithm of a value, or of the minimum if the value is not positive.
+func (lr LogarithmicRange) log(value float64) float64 {
+	if value <= 0 || math.IsNaN(value) {
+		value = lr.Min
+	}
+	return math.Log10(value)
+}
+
+// getLogBounds returns the logarithms of the bounds in ascending order.
+func (lr LogarithmicRange) getLogBounds() (min, max float64) {
+	min, max = math.Log10(lr.Min), math.Log10(lr.Max)
+	if max < min {
+		min, max = max, min
+	}
+	return
+}
+
+// fit pads the bounds by the padding fraction of their span in log space, and rounds them out to powers of 10
+// for nice numbers; absolute padding doesn't apply to logarithmic ranges.
+func (lr *LogarithmicRange) fit() {
+	min, max := lr.getLogBounds()
+	if headroom := (max - min) * lr.Padding.Fraction; headroom > 0 && !math.IsInf(headroom, 0) {
+		lr.Min, lr.Max = math.Pow(10, min-headroom), math.Pow(10, max+headroom)
+	}
+	if lr.NiceNumbers {
+		lr.roundToPowers()
+	}
+}
+
+// roundToPowers rounds the bounds out to the nearest powers of 10.
+func (lr *LogarithmicRange) roundToPowers() {
+	min, max := lr.getLogBounds()
+	if math.IsNaN(min) || math.IsInf(min, 0) || math.IsNaN(max) || math.IsInf(max, 0) {
+		return
+	}
+	lr.Min, lr.Max = math.Pow(10, math.Floor(min)), math.Pow(10, math.Ceil(max))
+}
+
+// GetTicks returns a labeled tick for every power of 10 in the range, or every Nth power if their labels would overlap,
+// and unlabeled minor ticks in between if they fit. Ranges that span less than a power of 10 get continuous ticks.
+// It implements `TicksProvider`, spacing the labels for a horizontal axis; axes space them by their orientation.
+func (lr *LogarithmicRange) GetTicks(r Renderer, defaults Style, vf ValueFormatter) []Tick {
+	return lr.getAxisTicks(r, false, defaults, vf)
+}
+
+func (lr *LogarithmicRange) getAxisTicks(r Renderer, isVertical bool, defaults Style, vf ValueFormatter) []Tick {
+	min, max := lr.getLogBounds()
+	if math.IsNaN(min) || math.IsInf(min, 0) || math.IsNaN(max) || math.IsInf(max, 0) {
+		return GenerateContinuousTicks(r, lr, isVertical, defaults, vf)
+	}
+	if vf == nil || isDefaultFloatValueFormatter(vf) {
+		vf = logarithmicValueFormatter
+	}
+
+	var exponents []int
+	for exponent := int(math.Ceil(min - logarithmicEpsilon)); float64(exponent) <= max+logarithmicEpsilon; exponent++ {
+		exponents = append(exponents, exponent)
+		if len(exponents) > DefaultTickCountSanityCheck {
+			break
+		}
+	}
+	if len(exponents) < 2 {
+		return GenerateContinuousTicks(r, lr, isVertical, defaults, vf)
+	}
+
+	defaults.GetTextOptions().WriteToRenderer(r)
+	var labelSize int
+	for _, exponent := range exponents {
+		tb := r.MeasureText(vf(math.Pow(10, float64(exponent))))
+		if isVertical {
+			labelSize = util.Math.MaxInt(labelSize, tb.Height()+DefaultMinimumTickVerticalSpacing)
+		} else {
+			labelSize = util.Math.MaxInt(labelSize, tb.Width()+DefaultMinimumTickHorizontalSpacing)
+		}
+	}
+	pixelsPerPower := float64(lr.Domain) / (max - min)
+	every := util.Math.MaxInt(1, int(math.Ceil(float64(labelSize)/pixelsPerPower)))
+	// the closest minor ticks are 9 and 10 times a power.
+	withMinor := every == 1 && pixelsPerPower*math.Log10(10.0/9.0) >= DefaultLogarithmicMinorTickSpacing
+
+	var ticks []Tick
+	for exponent := exponents[0] - 1; exponent <= exponents[len(exponents)-1]; exponent++ {
+		power := math.Pow(10, float64(exponent))
+		if exponent >= exponents[0] && (exponent-exponents[0])%every == 0 {
+			ticks = append(ticks, Tick{Value: power, Label: vf(power)})
+		}
+		if !withMinor {
+			continue
+		}
+		for multiple := 2; multiple < 10; multiple++ {
+			if value := power * float64(multiple); lr.contains(value) {
+				ticks = append(ticks, Tick{Value: value})
+			}
+		}
+	}
+	return ticks
+}
+
+// contains returns if a value is within the bounds of the range.
+func (lr LogarithmicRange) contains(value float64) bool {
+	return value >= math.Min(lr.Min, lr.Max) && value <= math.Max(lr.Min, lr.Max)
+}
+
+// GetGridLines returns a major grid line for every tick on a power of 10, and a minor one for the rest,
+// leaving out the ticks on the bounds of the range. It implements `GridLineProvider`.
+func (lr LogarithmicRange) GetGridLines(ticks []Tick, isVertical bool, majorStyle, minorStyle Style) []GridLine {
+	var gl []GridLine
+	for _, t := range ticks {
+		if t.Value == lr.Min || t.Value == lr.Max || !lr.contains(t.Value) {
+			continue
+		}
+		exponent := math.Log10(t.Value)
+		isMinor := math.Abs(exponent-math.Round(exponent)) > logarithmicEpsilon
+		s := majorStyle
+		if isMinor {
+			s = minorStyle
+		}
+		gl = append(gl, GridLine{
+			Style:   s,
+			IsMinor: isMinor,
+			Value:   t.Value,
+		})
+	}
+	return gl
+}
+
+// logarithmicEpsilon is how far off a whole exponent the logarithm of a power of 10 can be due to rounding.
+const logarithmicEpsilon = 1e-9
+
+// logarithmicValueFormatter labels powers of 10 without trailing zeros, and with exponent notation
+// for very large or small powers.
+func logarithmicValueFormatter(v interface{}) string {
+	typed, isTyped := toFloat64(v)
+	if !isTyped {
+		return ""
+	}
+	if typed != 0 {
+		if exponent := math.Log10(math.Abs(typed)); exponent >= DefaultFixedNotationMaxExponent || exponent < DefaultFixedNotationMinExponent {
+			return strconv.FormatFloat(typed, 'g', -1, 64)
+		}
+	}
+	return strconv.FormatFloat(typed, 'f', -1, 64)
+}
@@ -0,0 +1,99 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestLogarithmicRangeTranslate(t *testing.T) {
+	assert := assert.New(t)
+
+	lr := LogarithmicRange{ContinuousRange{Min: 1, Max: 1000, Domain: 300}}
+	assert.Equal(0, lr.Translate(1))
+	assert.Equal(100, lr.Translate(10))
+	assert.Equal(200, lr.Translate(100))
+	assert.Equal(300, lr.Translate(1000))
+	assert.Equal(0, lr.Translate(0))
+	assert.Equal(0, lr.Translate(-10))
+
+	lr.Descending = true
+	assert.Equal(300, lr.Translate(1))
+	assert.Equal(200, lr.Translate(10))
+}
+
+func TestLogarithmicRangeRoundToPowers(t *testing.T) {
+	assert := assert.New(t)
+
+	lr := &LogarithmicRange{ContinuousRange{Min: 3, Max: 4500}}
+	lr.roundToPowers()
+	assert.Equal(1.0, lr.Min)
+	assert.Equal(10000.0, lr.Max)
+}
+
+func TestLogarithmicRangeGetTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	r, style := timeRangeTestRenderer(t)
+	lr := &LogarithmicRange{ContinuousRange{Min: 1, Max: 1000, Domain: 600}}
+
+	ticks := lr.GetTicks(r, style, nil)
+	var labeled []Tick
+	for _, tick := range ticks {
+		if len(tick.Label) > 0 {
+			labeled = append(labeled, tick)
+		}
+	}
+	assert.Len(ticks, 28)
+	assert.Len(labeled, 4)
+	assert.Equal("1", labeled[0].Label)
+	assert.Equal("1000", labeled[3].Label)
+	assert.Equal(2.0, ticks[1].Value)
+
+	gridLines := lr.GetGridLines(ticks, false, Style{}, Style{})
+	assert.Len(gridLines, 26)
+	assert.Equal(2.0, gridLines[0].Value)
+	assert.True(gridLines[0].IsMinor)
+	assert.Equal(10.0, gridLines[8].Value)
+	assert.False(gridLines[8].IsMinor)
+}
+
+func TestLogarithmicRangeGetTicksCrowded(t *testing.T) {
+	assert := assert.New(t)
+
+	r, style := timeRangeTestRenderer(t)
+	lr := &LogarithmicRange{ContinuousRange{Min: 1, Max: 1e12, Domain: 200}}
+
+	ticks := lr.GetTicks(r, style, nil)
+	assert.True(len(ticks) > 1)
+	assert.True(len(ticks) < 13)
+	for _, tick := range ticks {
+		assert.NotEmpty(tick.Label)
+	}
+}
+
+func TestChartLogarithmicRange(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		YAxis: YAxis{
+			Style:          StyleShow(),
+			Range:          &LogarithmicRange{},
+			GridMajorStyle: StyleShow(),
+		},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3, 4}, YValues: []float64{0, 3, 450, 25000}},
+		},
+	}
+	xr, yr, _ := c.getRanges()
+	assert.Equal(1.0, xr.GetMin())
+	assert.Equal(1.0, yr.GetMin())
+	assert.Equal(100000.0, yr.GetMax())
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+
+	c.YAxis.Range = &LogarithmicRange{}
+	c.Series = []Series{ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{0, -3}}}
+	assert.NotNil(c.Validate())
+	assert.NotNil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
@@ -0,0 +1,101 @@
+package chart
+
+import (
+	"unicode"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// Logger receives warnings about problems that don't stop a chart from rendering but make it wrong,
+// i.e. values that were dropped, labels that were clipped or characters missing from the font.
+// Warnings are a message followed by alternating keys and values, so a `*slog.Logger` can be used as is:
+//
+//	graph := chart.Chart{Log: slog.Default(), ...}
+type Logger interface {
+	Warn(msg string, args ...interface{})
+}
+
+// warn logs a warning if the chart has a logger.
+func (c Chart) warn(msg string, args ...interface{}) {
+	if c.Log != nil {
+		c.Log.Warn(msg, args...)
+	}
+}
+
+// logWarnings checks the series and the layout for data quality problems and logs them.
+func (c Chart) logWarnings(r Renderer, cl ChartLayout) {
+	if c.Log == nil {
+		return
+	}
+
+	for index, s := range c.Series {
+		if !(s.GetStyle().IsZero() || s.GetStyle().Show) {
+			continue
+		}
+		if vp, isValuesProvider := s.(ValuesProvider); isValuesProvider {
+			if count := countNonFinite(vp); count > 0 {
+				c.warn("dropped non-finite values", "series", index, "name", s.GetName(), "count", count)
+			}
+		}
+	}
+
+	bounds := Box{Right: c.GetWidth(), Bottom: c.GetHeight()}
+	if c.XAxis.Style.Show {
+		c.warnClippedAxis("x", c.XAxis.Measure(r, cl.Canvas, cl.XRange, c.styleDefaultsAxes(), cl.XTicks), bounds)
+		c.warnMissingGlyphs("x axis", c.XAxis.Style.GetFont(c.GetFont()), cl.XTicks)
+	}
+	if c.XAxisSecondary.Style.Show {
+		c.warnClippedAxis("secondary x", c.XAxisSecondary.Measure(r, cl.Canvas, cl.XRangeSecondary, c.styleDefaultsAxes(), cl.XTicksSecondary), bounds)
+		c.warnMissingGlyphs("secondary x axis", c.XAxisSecondary.Style.GetFont(c.GetFont()), cl.XTicksSecondary)
+	}
+	if c.YAxis.Style.Show {
+		c.warnClippedAxis("y", c.YAxis.Measure(r, cl.Canvas, cl.YRange, c.styleDefaultsAxes(), cl.YTicks), bounds)
+		c.warnMissingGlyphs("y axis", c.YAxis.Style.GetFont(c.GetFont()), cl.YTicks)
+	}
+	if c.YAxisSecondary.Style.Show {
+		c.warnClippedAxis("secondary y", c.YAxisSecondary.Measure(r, cl.Canvas, cl.YRangeSecondary, c.styleDefaultsAxes(), cl.YTicksSecondary), bounds)
+		c.warnMissingGlyphs("secondary y axis", c.YAxisSecondary.Style.GetFont(c.GetFont()), cl.YTicksSecondary)
+	}
+
+	if len(c.Title) > 0 && c.TitleStyle.Show {
+		titleStyle := c.TitleStyle.InheritFrom(Style{Font: c.GetFont(), FontSize: DefaultTitleFontSize})
+		if width := Draw.MeasureText(r, c.Title, titleStyle).Width(); width > bounds.Width() {
+			c.warn("title is clipped", "title", c.Title, "width", width, "chartWidth", bounds.Width())
+		}
+		if missing := missingGlyphs(titleStyle.GetFont(), c.Title); len(missing) > 0 {
+			c.warn("text contains characters missing from the font", "text", c.Title, "characters", missing)
+		}
+	}
+}
+
+func (c Chart) warnClippedAxis(axis string, axisBox, bounds Box) {
+	if axisBox.Left < bounds.Left || axisBox.Right > bounds.Right || axisBox.Top < bounds.Top || axisBox.Bottom > bounds.Bottom {
+		c.warn("axis labels are clipped", "axis", axis, "labels", axisBox.String(), "chart", bounds.String())
+	}
+}
+
+func (c Chart) warnMissingGlyphs(axis string, font *truetype.Font, ticks []Tick) {
+	for _, t := range ticks {
+		if missing := missingGlyphs(font, t.Label); len(missing) > 0 {
+			c.warn("text contains characters missing from the font", "axis", axis, "text", t.Label, "characters", missing)
+		}
+	}
+}
+
+// missingGlyphs returns the printable characters of the text the font has no glyph for,
+// which render as the font's fallback glyph (usually an empty box).
+func missingGlyphs(font *truetype.Font, text string) string {
+	if font == nil {
+		return ""
+	}
+	var missing []rune
+	for _, character := range text {
+		if unicode.IsSpace(character) || !unicode.IsPrint(character) {
+			continue
+		}
+		if font.Index(character) == 0 {
+			missing = append(missing, character)
+		}
+	}
+	return string(missing)
+}
@@ -0,0 +1,92 @@
+package chart
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+var _ Logger = slog.Default()
+
+type recordingLogger struct {
+	messages []string
+	args     [][]interface{}
+}
+
+func (rl *recordingLogger) Warn(msg string, args ...interface{}) {
+	rl.messages = append(rl.messages, msg)
+	rl.args = append(rl.args, args)
+}
+
+func (rl *recordingLogger) count(msg string) (count int) {
+	for _, message := range rl.messages {
+		if message == msg {
+			count++
+		}
+	}
+	return
+}
+
+func TestChartRenderLogsNonFiniteValues(t *testing.T) {
+	assert := assert.New(t)
+
+	log := &recordingLogger{}
+	c := Chart{
+		Log: log,
+		Series: []Series{
+			ContinuousSeries{Name: "gappy", XValues: []float64{1, 2, 3, 4}, YValues: []float64{1, math.NaN(), 3, math.Inf(1)}},
+			ContinuousSeries{XValues: []float64{1, 2, 3, 4}, YValues: []float64{4, 3, 2, 1}},
+		},
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Equal(1, log.count("dropped non-finite values"))
+	assert.Equal([]interface{}{"series", 0, "name", "gappy", "count", 2}, log.args[0])
+
+	// the non-finite values are left out of the ranges.
+	xr, yr, _ := c.getRanges()
+	assert.Equal(1.0, xr.GetMin())
+	assert.Equal(4.0, yr.GetMax())
+}
+
+func TestChartRenderLogsClippedText(t *testing.T) {
+	assert := assert.New(t)
+
+	log := &recordingLogger{}
+	c := Chart{
+		Width:      120,
+		Log:        log,
+		Title:      "a title much too long for such a narrow chart 漢",
+		TitleStyle: StyleShow(),
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Equal(1, log.count("title is clipped"))
+	assert.Equal(1, log.count("text contains characters missing from the font"))
+}
+
+func TestChartRenderWithoutLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{math.NaN(), 3, 2}},
+		},
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Equal(-1, firstFiniteIndex(ContinuousSeries{XValues: []float64{1}, YValues: []float64{math.NaN()}}))
+}
+
+func TestMissingGlyphs(t *testing.T) {
+	assert := assert.New(t)
+
+	font, err := GetDefaultFont()
+	assert.Nil(err)
+	assert.Empty(missingGlyphs(font, "Hello, World 1.25%"))
+	assert.Equal("漢字", missingGlyphs(font, "漢 字 ok"))
+	assert.Empty(missingGlyphs(nil, "漢"))
+}
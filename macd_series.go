@@ -17,6 +17,7 @@ type MACDSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	InnerSeries ValuesProvider
 
 	PrimaryPeriod   int
@@ -80,6 +81,11 @@ func (macd MACDSeries) GetYAxis() YAxisType {
 	return macd.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (macd MACDSeries) GetXAxis() XAxisType {
+	return macd.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (macd MACDSeries) Len() int {
 	if macd.InnerSeries == nil {
@@ -130,6 +136,7 @@ type MACDSignalSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	InnerSeries ValuesProvider
 
 	PrimaryPeriod   int
@@ -182,6 +189,11 @@ func (macds MACDSignalSeries) GetYAxis() YAxisType {
 	return macds.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (macds MACDSignalSeries) GetXAxis() XAxisType {
+	return macds.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (macds *MACDSignalSeries) Len() int {
 	if macds.InnerSeries == nil {
@@ -229,6 +241,7 @@ type MACDLineSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	InnerSeries ValuesProvider
 
 	PrimaryPeriod   int
@@ -276,6 +289,11 @@ func (macdl MACDLineSeries) GetYAxis() YAxisType {
 	return macdl.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (macdl MACDLineSeries) GetXAxis() XAxisType {
+	return macdl.XAxis
+}
+
 // GetPeriods returns the primary and secondary periods.
 func (macdl MACDLineSeries) GetPeriods() (w1, w2 int) {
 	if macdl.PrimaryPeriod == 0 {
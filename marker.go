@@ -0,0 +1,44 @@
+package chart
+
+import "math"
+
+// Marker draws the marker of a point centered on x,y, with a given size; the size is its radius, i.e. half its width.
+// The renderer has the fill and stroke of the marker set; a marker fills and strokes the shapes it draws.
+type Marker func(r Renderer, x, y int, size float64)
+
+// MarkerCircle draws a circle.
+func MarkerCircle(r Renderer, x, y int, size float64) {
+	r.Circle(size, x, y)
+	r.FillStroke()
+}
+
+// MarkerSquare draws a square.
+func MarkerSquare(r Renderer, x, y int, size float64) {
+	s := int(math.Round(size))
+	r.Polygon([]Point{{X: x - s, Y: y - s}, {X: x + s, Y: y - s}, {X: x + s, Y: y + s}, {X: x - s, Y: y + s}})
+	r.FillStroke()
+}
+
+// MarkerTriangle draws a triangle pointing up, with its corners on the circle of the size.
+func MarkerTriangle(r Renderer, x, y int, size float64) {
+	dx, dy := int(math.Round(size*math.Sqrt(3)/2)), int(math.Round(size/2))
+	r.Polygon([]Point{{X: x, Y: y - int(math.Round(size))}, {X: x + dx, Y: y + dy}, {X: x - dx, Y: y + dy}})
+	r.FillStroke()
+}
+
+// MarkerDiamond draws a square standing on a corner.
+func MarkerDiamond(r Renderer, x, y int, size float64) {
+	s := int(math.Round(size))
+	r.Polygon([]Point{{X: x, Y: y - s}, {X: x + s, Y: y}, {X: x, Y: y + s}, {X: x - s, Y: y}})
+	r.FillStroke()
+}
+
+// MarkerCross draws an x; it has no fill.
+func MarkerCross(r Renderer, x, y int, size float64) {
+	s := int(math.Round(size))
+	r.MoveTo(x-s, y-s)
+	r.LineTo(x+s, y+s)
+	r.MoveTo(x+s, y-s)
+	r.LineTo(x-s, y+s)
+	r.Stroke()
+}
@@ -10,6 +10,7 @@ type MinSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	InnerSeries ValuesProvider
 
 	minValue *float64
@@ -30,6 +31,11 @@ func (ms MinSeries) GetYAxis() YAxisType {
 	return ms.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (ms MinSeries) GetXAxis() XAxisType {
+	return ms.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (ms MinSeries) Len() int {
 	return ms.InnerSeries.Len()
@@ -76,6 +82,7 @@ type MaxSeries struct {
 	Name        string
 	Style       Style
 	YAxis       YAxisType
+	XAxis       XAxisType
 	InnerSeries ValuesProvider
 
 	maxValue *float64
@@ -96,6 +103,11 @@ func (ms MaxSeries) GetYAxis() YAxisType {
 	return ms.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (ms MaxSeries) GetXAxis() XAxisType {
+	return ms.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (ms MaxSeries) Len() int {
 	return ms.InnerSeries.Len()
@@ -0,0 +1,108 @@
+package chart
+
+import "math"
+
+// maxMinorTicks is the most minor ticks an axis draws; a step too small for the range draws none.
+const maxMinorTicks = 1000
+
+// MinorTicks are the unlabeled ticks between the major ticks of an axis. They are drawn half as long and lighter
+// than the major ticks, and the axis draws a grid line in `GridMinorStyle` for each, behind the major grid lines.
+type MinorTicks struct {
+	// Count is the number of minor ticks between two major ticks.
+	Count int
+	// Step places the minor ticks at the multiples of a step instead; it takes precedence over `Count`.
+	Step float64
+	// Style is the style of the minor tick marks; it defaults to a lighter version of the tick style.
+	Style Style
+}
+
+// IsZero returns if there are no minor ticks.
+func (mt MinorTicks) IsZero() bool {
+	return mt.Count <= 0 && mt.Step <= 0
+}
+
+// GetValues returns the values of the minor ticks within a range, leaving out values at major ticks.
+// Counted minor ticks continue past the first and last major tick with the spacing next to them.
+func (mt MinorTicks) GetValues(ra Range, ticks []Tick) []float64 {
+	if mt.IsZero() || len(ticks) == 0 {
+		return nil
+	}
+	min, max := math.Min(ra.GetMin(), ra.GetMax()), math.Max(ra.GetMin(), ra.GetMax())
+
+	var values []float64
+	if mt.Step > 0 {
+		if (max-min)/mt.Step > maxMinorTicks {
+			return nil
+		}
+		for k := math.Ceil(min / mt.Step); k*mt.Step <= max; k++ {
+			if value := k * mt.Step; !isMajorTickValue(value, ticks, mt.Step) {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	if len(ticks) < 2 || (len(ticks)-1)*mt.Count > maxMinorTicks {
+		return nil
+	}
+	divisions := float64(mt.Count + 1)
+	first := (ticks[1].Value - ticks[0].Value) / divisions
+	for index := mt.Count; index > 0; index-- {
+		if value := ticks[0].Value - float64(index)*first; value >= min {
+			values = append(values, value)
+		}
+	}
+	for index := 0; index < len(ticks)-1; index++ {
+		step := (ticks[index+1].Value - ticks[index].Value) / divisions
+		for i := 1; i <= mt.Count; i++ {
+			values = append(values, ticks[index].Value+float64(i)*step)
+		}
+	}
+	last := (ticks[len(ticks)-1].Value - ticks[len(ticks)-2].Value) / divisions
+	for index := 1; index <= mt.Count; index++ {
+		if value := ticks[len(ticks)-1].Value + float64(index)*last; value <= max {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getStyle returns the style of the minor tick marks.
+func (mt MinorTicks) getStyle(tickStyle Style) Style {
+	return mt.Style.InheritFrom(Style{
+		StrokeColor: tickStyle.StrokeColor.WithAlpha(tickStyle.StrokeColor.A >> 1),
+		StrokeWidth: tickStyle.StrokeWidth,
+	})
+}
+
+// isMajorTickValue returns if a value is at one of the major ticks, to within a small share of the minor step.
+func isMajorTickValue(value float64, ticks []Tick, step float64) bool {
+	for _, t := range ticks {
+		if math.Abs(t.Value-value) < step*1e-6 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateMinorGridLines returns a minor grid line for each minor tick and a major grid line for each major tick
+// but the first and last, which are at the edges of the canvas; the minor lines come first, so they are drawn behind.
+func generateMinorGridLines(ticks []Tick, minorValues []float64, majorStyle, minorStyle Style) []GridLine {
+	gl := make([]GridLine, 0, len(minorValues)+len(ticks))
+	for _, value := range minorValues {
+		gl = append(gl, GridLine{Style: minorStyle, IsMinor: true, Value: value})
+	}
+	if len(ticks) > 2 {
+		for _, t := range ticks[1 : len(ticks)-1] {
+			gl = append(gl, GridLine{Style: majorStyle, Value: t.Value})
+		}
+	}
+	return gl
+}
+
+// minorGridDefaults returns the defaults of minor grid lines, which are lighter than major grid lines.
+func minorGridDefaults(defaults Style) Style {
+	minor := defaults
+	minor.StrokeColor = defaults.StrokeColor.WithAlpha(defaults.StrokeColor.A >> 1)
+	return minor
+}
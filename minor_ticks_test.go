@@ -0,0 +1,62 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestMinorTicksGetValuesCount(t *testing.T) {
+	assert := assert.New(t)
+
+	ra := &ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	ticks := []Tick{{Value: 2}, {Value: 4}, {Value: 6}}
+
+	values := MinorTicks{Count: 1}.GetValues(ra, ticks)
+	assert.Equal([]float64{1, 3, 5, 7}, values)
+
+	assert.Empty(MinorTicks{}.GetValues(ra, ticks))
+}
+
+func TestMinorTicksGetValuesStep(t *testing.T) {
+	assert := assert.New(t)
+
+	ra := &ContinuousRange{Min: 0, Max: 2, Domain: 100}
+	ticks := []Tick{{Value: 0}, {Value: 1}, {Value: 2}}
+
+	values := MinorTicks{Step: 0.5, Count: 3}.GetValues(ra, ticks)
+	assert.Equal([]float64{0.5, 1.5}, values)
+
+	assert.Empty(MinorTicks{Step: 1e-6}.GetValues(ra, ticks))
+}
+
+func TestGenerateMinorGridLines(t *testing.T) {
+	assert := assert.New(t)
+
+	ticks := []Tick{{Value: 0}, {Value: 1}, {Value: 2}, {Value: 3}}
+	gl := generateMinorGridLines(ticks, []float64{0.5, 1.5, 2.5}, Style{StrokeWidth: 2}, Style{StrokeWidth: 1})
+	assert.Len(gl, 5)
+	for _, line := range gl[:3] {
+		assert.True(line.Minor())
+		assert.Equal(1.0, line.Style.StrokeWidth)
+	}
+	assert.True(gl[3].Major())
+	assert.Equal(1.0, gl[3].Value)
+	assert.Equal(2.0, gl[4].Value)
+}
+
+func TestChartRenderMinorTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow(), MinorTicks: MinorTicks{Count: 4}, GridMajorStyle: StyleShow(), GridMinorStyle: StyleShow()},
+		YAxis: YAxis{Style: StyleShow(), MinorTicks: MinorTicks{Step: 0.25}, GridMajorStyle: StyleShow(), GridMinorStyle: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3, 4}, YValues: []float64{1, 3, 2, 4}},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
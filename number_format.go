@@ -0,0 +1,236 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// NumberFormat formats numbers with grouped thousands, i.e. `12,345,678.90`.
+// It can also scale numbers to percentages or by SI prefixes, and add a prefix or suffix such as a currency symbol,
+// i.e. `-$1,234.50`, `12.5%` or `3.4M`.
+type NumberFormat struct {
+	// GroupSeparator separates groups of three integer digits; it defaults to ",".
+	GroupSeparator string
+	// DecimalMark separates the integer and fractional digits; it defaults to ".".
+	DecimalMark string
+	// NoGroups writes the integer digits without group separators.
+	NoGroups bool
+	// Decimals is the number of fractional digits.
+	Decimals int
+	// TrimZeros drops trailing fractional zeros, and the decimal mark if no digits are left.
+	TrimZeros bool
+
+	// Percent multiplies numbers by 100 and adds a percent sign.
+	Percent bool
+	// SI scales numbers by the closest SI prefix (`p`, `n`, `µ`, `m`, `k`, `M`, `G`, `T`, `P` and `E`),
+	// so there are one to three integer digits, i.e. `1.2k`.
+	SI bool
+
+	// Prefix is written before the digits and after the minus sign, i.e. a currency symbol.
+	Prefix string
+	// Suffix is written after the digits, SI prefix and percent sign, i.e. a unit.
+	Suffix string
+}
+
+// DefaultNumberFormat is the number format of `GroupedValueFormatter`.
+var DefaultNumberFormat = NumberFormat{Decimals: 2, TrimZeros: true}
+
+// SIValueFormatter is a ValueFormatter for numbers scaled by SI prefixes, i.e. `1.2k` or `3.4M`.
+func SIValueFormatter(v interface{}) string {
+	return NumberFormat{Decimals: 1, TrimZeros: true, SI: true}.Format(v)
+}
+
+// CurrencyValueFormatter returns a ValueFormatter for amounts of a currency, i.e. `$1,234.50` for the symbol `$`.
+func CurrencyValueFormatter(symbol string) ValueFormatter {
+	return NumberFormat{Decimals: 2, Prefix: symbol}.Format
+}
+
+// NumberFormatFromLayout returns the number format of a spreadsheet style layout, i.e. `#,##0.00`, `$#,##0` or `0.0%`.
+// The digits are written with `0` for a digit that is always written and `#` for one that is trimmed if it
+// is a trailing zero; a `,` in the integer digits groups them, and a `.` starts the fractional digits.
+// A `%` right after the digits makes a percentage, and any other text before or after the digits is written as is.
+// The `,` and `.` are placeholders; the format's separators (see `WithLocale`) are written in their place.
+func NumberFormatFromLayout(layout string) (nf NumberFormat, err error) {
+	start := strings.IndexAny(layout, "#0")
+	if start < 0 {
+		err = fmt.Errorf("number layout %q has no digits", layout)
+		return
+	}
+	end := strings.LastIndexAny(layout, "#0") + 1
+	nf.Prefix, nf.Suffix = layout[:start], layout[end:]
+	if strings.HasPrefix(nf.Suffix, "%") {
+		nf.Percent = true
+		nf.Suffix = nf.Suffix[1:]
+	}
+
+	integer, fraction := layout[start:end], ""
+	if index := strings.IndexByte(integer, '.'); index >= 0 {
+		integer, fraction = integer[:index], integer[index+1:]
+	}
+	if strings.Trim(integer, "#0,") != "" || strings.Trim(fraction, "#0") != "" {
+		err = fmt.Errorf("number layout %q has invalid digits %q", layout, layout[start:end])
+		return
+	}
+	nf.NoGroups = !strings.Contains(integer, ",")
+	nf.Decimals = len(fraction)
+	nf.TrimZeros = len(fraction) > 0 && !strings.Contains(fraction, "0")
+	return
+}
+
+// WithLocale returns the number format with the group separator and decimal mark of a locale,
+// where the format doesn't set its own.
+func (nf NumberFormat) WithLocale(l Locale) NumberFormat {
+	if len(nf.GroupSeparator) == 0 {
+		nf.GroupSeparator = l.GroupSeparator
+	}
+	if len(nf.DecimalMark) == 0 {
+		nf.DecimalMark = l.DecimalMark
+	}
+	return nf
+}
+
+// GroupedValueFormatter is a ValueFormatter for numbers with thousands separators, i.e. `12,345,678.9`.
+// Used for an axis, the ticks all get as many decimals as needed to tell them apart instead.
+func GroupedValueFormatter(v interface{}) string {
+	return DefaultNumberFormat.Format(v)
+}
+
+// GetGroupSeparator returns the group separator or a default.
+func (nf NumberFormat) GetGroupSeparator() string {
+	if len(nf.GroupSeparator) == 0 {
+		return ","
+	}
+	return nf.GroupSeparator
+}
+
+// GetDecimalMark returns the decimal mark or a default.
+func (nf NumberFormat) GetDecimalMark() string {
+	if len(nf.DecimalMark) == 0 {
+		return "."
+	}
+	return nf.DecimalMark
+}
+
+// Format formats a number; non numbers format as an empty string.
+// It is a ValueFormatter.
+func (nf NumberFormat) Format(v interface{}) string {
+	value, isNumber := toFloat64(v)
+	if !isNumber {
+		return ""
+	}
+	if nf.Percent {
+		value *= 100
+	}
+	var exponent int
+	if nf.SI {
+		exponent = nf.getSIExponent(value)
+	}
+	return nf.format(value, exponent)
+}
+
+// siPrefixes are the SI prefixes by their power of ten.
+var siPrefixes = map[int]string{
+	-12: "p", -9: "n", -6: "µ", -3: "m", 0: "", 3: "k", 6: "M", 9: "G", 12: "T", 15: "P", 18: "E",
+}
+
+// getSIExponent returns the power of ten of the SI prefix of a number, the one that leaves one to three integer
+// digits once the number is rounded to the format's decimals.
+func (nf NumberFormat) getSIExponent(value float64) int {
+	if !isFiniteNonZero(value) {
+		return 0
+	}
+	exponent := util.Math.MinInt(util.Math.MaxInt(int(math.Floor(math.Log10(math.Abs(value))/3))*3, -12), 18)
+	scale := math.Pow(10, float64(nf.getDecimals()))
+	if rounded := math.Round(math.Abs(value)/math.Pow(10, float64(exponent))*scale) / scale; rounded >= 1000 && exponent < 18 {
+		exponent += 3
+	}
+	return exponent
+}
+
+func (nf NumberFormat) getDecimals() int {
+	if nf.Decimals < 0 {
+		return 0
+	}
+	return nf.Decimals
+}
+
+// format formats a number, scaled by the SI prefix of an exponent.
+func (nf NumberFormat) format(value float64, exponent int) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	if exponent != 0 {
+		value /= math.Pow(10, float64(exponent))
+	}
+
+	formatted := strconv.FormatFloat(math.Abs(value), 'f', nf.getDecimals(), 64)
+	integer, fraction := formatted, ""
+	if index := strings.IndexByte(formatted, '.'); index >= 0 {
+		integer, fraction = formatted[:index], formatted[index+1:]
+	}
+	if nf.TrimZeros {
+		fraction = strings.TrimRight(fraction, "0")
+	}
+
+	var output strings.Builder
+	if value < 0 && strings.Trim(integer+fraction, "0") != "" {
+		output.WriteByte('-')
+	}
+	output.WriteString(nf.Prefix)
+	separator := nf.GetGroupSeparator()
+	for index, digit := range integer {
+		if index > 0 && (len(integer)-index)%3 == 0 && !nf.NoGroups {
+			output.WriteString(separator)
+		}
+		output.WriteRune(digit)
+	}
+	if len(fraction) > 0 {
+		output.WriteString(nf.GetDecimalMark())
+		output.WriteString(fraction)
+	}
+	output.WriteString(siPrefixes[exponent])
+	if nf.Percent {
+		output.WriteByte('%')
+	}
+	output.WriteString(nf.Suffix)
+	return output.String()
+}
+
+// ForRange returns a value formatter with enough decimals to tell apart values `step` apart,
+// for every value, so labels of ticks line up. SI formats scale every value by the prefix of the largest.
+func (nf NumberFormat) ForRange(min, max, step float64) ValueFormatter {
+	scale := 1.0
+	if nf.Percent {
+		scale = 100
+	}
+	var exponent int
+	if nf.SI {
+		exponent = nf.getSIExponent(math.Max(math.Abs(min), math.Abs(max)) * scale)
+	}
+	step = math.Abs(step) * scale / math.Pow(10, float64(exponent))
+
+	nf.TrimZeros = false
+	nf.Decimals = 0
+	if isFiniteNonZero(step) {
+		if decimals := 1 - int(math.Floor(math.Log10(step))); decimals > 0 {
+			nf.Decimals = decimals
+		}
+	}
+	return func(v interface{}) string {
+		value, isNumber := toFloat64(v)
+		if !isNumber {
+			return ""
+		}
+		return nf.format(value*scale, exponent)
+	}
+}
+
+// isDefaultGroupedValueFormatter returns if a formatter is `GroupedValueFormatter`.
+func isDefaultGroupedValueFormatter(vf ValueFormatter) bool {
+	return vf != nil && reflect.ValueOf(vf).Pointer() == reflect.ValueOf(GroupedValueFormatter).Pointer()
+}
@@ -0,0 +1,120 @@
+package chart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestNumberFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("12,345,678.9", GroupedValueFormatter(12345678.9))
+	assert.Equal("-1,000", GroupedValueFormatter(-1000))
+	assert.Equal("999", GroupedValueFormatter(int64(999)))
+	assert.Equal("0", GroupedValueFormatter(-0.001))
+	assert.Equal("0.25", GroupedValueFormatter(float32(0.25)))
+	assert.Equal("", GroupedValueFormatter("nope"))
+	assert.Equal("NaN", GroupedValueFormatter(math.NaN()))
+	assert.Equal("+Inf", GroupedValueFormatter(math.Inf(1)))
+
+	european := NumberFormat{GroupSeparator: ".", DecimalMark: ",", Decimals: 2}
+	assert.Equal("1.234.567,50", european.Format(1234567.5))
+	assert.Equal("100,00", european.Format(100))
+	assert.Equal("1,235", NumberFormat{}.Format(1234.6))
+}
+
+func TestNumberFormatForRange(t *testing.T) {
+	assert := assert.New(t)
+
+	vf := DefaultNumberFormat.ForRange(0, 10000000, 2500000)
+	assert.Equal("2,500,000", vf(2500000.0))
+
+	vf = DefaultNumberFormat.ForRange(0, 1, 0.25)
+	assert.Equal("0.50", vf(0.5))
+
+	vf = DefaultNumberFormat.ForRange(0, 1, 0)
+	assert.Equal("1", vf(1.0))
+}
+
+func TestGenerateContinuousTicksGrouped(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+	r.SetFont(f)
+
+	ra := &ContinuousRange{Min: 0, Max: 10000000, Domain: 1024}
+	ticks := GenerateContinuousTicks(r, ra, false, Style{Font: f, FontSize: DefaultAxisFontSize}, GroupedValueFormatter)
+	assert.NotEmpty(ticks)
+	assert.Equal("0", ticks[0].Label)
+	assert.Equal("10,000,000", ticks[len(ticks)-1].Label)
+}
+
+func TestNumberFormatSI(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0", SIValueFormatter(0))
+	assert.Equal("999", SIValueFormatter(999))
+	assert.Equal("1.2k", SIValueFormatter(1234))
+	assert.Equal("-3.4M", SIValueFormatter(-3400000))
+	assert.Equal("250m", SIValueFormatter(0.25))
+	assert.Equal("5µ", SIValueFormatter(0.000005))
+	// rounding up to 1000 moves to the next prefix.
+	assert.Equal("1M", SIValueFormatter(999960))
+
+	vf := NumberFormat{SI: true}.ForRange(0, 2000000, 500000)
+	assert.Equal("0.50M", vf(500000))
+	assert.Equal("2.00M", vf(2000000))
+}
+
+func TestNumberFormatAffixes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("$1,234.50", CurrencyValueFormatter("$")(1234.5))
+	assert.Equal("-$1,234.50", CurrencyValueFormatter("$")(-1234.5))
+	assert.Equal("12.5%", NumberFormat{Decimals: 1, Percent: true}.Format(0.125))
+	assert.Equal("3 kg", NumberFormat{Suffix: " kg"}.Format(3))
+	assert.Equal("1234", NumberFormat{NoGroups: true}.Format(1234))
+
+	vf := NumberFormat{Percent: true}.ForRange(0, 1, 0.25)
+	assert.Equal("25%", vf(0.25))
+}
+
+func TestNumberFormatFromLayout(t *testing.T) {
+	assert := assert.New(t)
+
+	nf, err := NumberFormatFromLayout("$#,##0.00")
+	assert.Nil(err)
+	assert.Equal(NumberFormat{Decimals: 2, Prefix: "$"}, nf)
+	assert.Equal("$1,234.50", nf.Format(1234.5))
+
+	nf, err = NumberFormatFromLayout("0.#%")
+	assert.Nil(err)
+	assert.Equal("12.5%", nf.Format(0.125))
+	assert.Equal("50%", nf.Format(0.5))
+
+	nf, err = NumberFormatFromLayout("0 ms")
+	assert.Nil(err)
+	assert.Equal("1500 ms", nf.Format(1500))
+
+	_, err = NumberFormatFromLayout("none")
+	assert.NotNil(err)
+	_, err = NumberFormatFromLayout("0.0.0")
+	assert.NotNil(err)
+}
+
+func TestNumberFormatWithLocale(t *testing.T) {
+	assert := assert.New(t)
+
+	de, _ := GetLocale("de")
+	assert.Equal("1.234,5", NumberFormat{Decimals: 1}.WithLocale(de).Format(1234.5))
+	// the format's own separators take precedence.
+	assert.Equal("1'234,5", NumberFormat{Decimals: 1, GroupSeparator: "'"}.WithLocale(de).Format(1234.5))
+
+	fr, _ := GetLocale("fr")
+	assert.Equal("1 234,50 €", NumberFormat{Decimals: 2, Suffix: " €"}.WithLocale(fr).Format(1234.5))
+}
@@ -0,0 +1,285 @@
+package chart
+
+import "github.com/golang/freetype/truetype"
+
+// Option configures a chart; see `New`.
+// User defined options are plain functions, and can be grouped with `Options`.
+type Option func(c *Chart)
+
+// New returns a new chart configured with the given options, applied in order, i.e.
+//
+//	c := chart.New(chart.WithSize(800, 300), chart.WithTheme(chart.DarkColorPalette), chart.WithSeries(series))
+//
+// It is equivalent to a `Chart` struct literal; fields left unset use the usual defaults.
+func New(opts ...Option) *Chart {
+	c := &Chart{}
+	c.Apply(opts...)
+	return c
+}
+
+// Apply applies options to the chart in order.
+func (c *Chart) Apply(opts ...Option) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+}
+
+// Options groups options into one.
+func Options(opts ...Option) Option {
+	return func(c *Chart) {
+		c.Apply(opts...)
+	}
+}
+
+// WithTitle sets and shows the chart title.
+func WithTitle(title string, userDefaults ...Style) Option {
+	return func(c *Chart) {
+		c.Title = title
+		c.TitleStyle = StyleShow()
+		if len(userDefaults) > 0 {
+			c.TitleStyle = userDefaults[0].InheritFrom(c.TitleStyle)
+		}
+	}
+}
+
+// WithSubtitle sets the chart subtitle, which lays the title and subtitle out above the canvas.
+func WithSubtitle(subtitle string, userDefaults ...Style) Option {
+	return func(c *Chart) {
+		c.Subtitle = subtitle
+		if len(userDefaults) > 0 {
+			c.SubtitleStyle = userDefaults[0]
+		}
+	}
+}
+
+// WithFooter sets the chart footer and source attribution, which are laid out below the canvas.
+func WithFooter(footer, source string) Option {
+	return func(c *Chart) {
+		c.Footer = footer
+		c.Source = source
+	}
+}
+
+// WithWidth sets the chart width.
+func WithWidth(width int) Option {
+	return func(c *Chart) {
+		c.Width = width
+	}
+}
+
+// WithHeight sets the chart height.
+func WithHeight(height int) Option {
+	return func(c *Chart) {
+		c.Height = height
+	}
+}
+
+// WithSize sets the chart width and height.
+func WithSize(width, height int) Option {
+	return Options(WithWidth(width), WithHeight(height))
+}
+
+// WithDPI sets the chart dpi.
+func WithDPI(dpi float64) Option {
+	return func(c *Chart) {
+		c.DPI = dpi
+	}
+}
+
+// WithScaleFactor sets the chart scale factor.
+func WithScaleFactor(scale float64) Option {
+	return func(c *Chart) {
+		c.ScaleFactor = scale
+	}
+}
+
+// WithTheme sets the chart color palette, i.e. a `Theme`.
+func WithTheme(palette ColorPalette) Option {
+	return func(c *Chart) {
+		c.ColorPalette = palette
+	}
+}
+
+// WithFont sets the chart font.
+func WithFont(font *truetype.Font) Option {
+	return func(c *Chart) {
+		c.Font = font
+	}
+}
+
+// WithBackground sets the background style.
+func WithBackground(style Style) Option {
+	return func(c *Chart) {
+		c.Background = style
+	}
+}
+
+// WithCanvas sets the canvas style.
+func WithCanvas(style Style) Option {
+	return func(c *Chart) {
+		c.Canvas = style
+	}
+}
+
+// WithXAxis sets the x axis.
+func WithXAxis(xa XAxis) Option {
+	return func(c *Chart) {
+		c.XAxis = xa
+	}
+}
+
+// WithYAxis sets the y axis.
+func WithYAxis(ya YAxis) Option {
+	return func(c *Chart) {
+		c.YAxis = ya
+	}
+}
+
+// WithYAxisSecondary sets the secondary y axis.
+func WithYAxisSecondary(ya YAxis) Option {
+	return func(c *Chart) {
+		c.YAxisSecondary = ya
+	}
+}
+
+// WithXAxisSecondary sets the secondary x axis, which is drawn along the top of the chart.
+func WithXAxisSecondary(xa XAxis) Option {
+	return func(c *Chart) {
+		c.XAxisSecondary = xa
+	}
+}
+
+// WithThemeName sets the chart color palette to the theme registered with a name (see `GetTheme`);
+// unknown names leave the palette as it is.
+func WithThemeName(name string) Option {
+	return func(c *Chart) {
+		if t, hasTheme := GetTheme(name); hasTheme {
+			c.ColorPalette = t
+		}
+	}
+}
+
+// WithPadding sets the padding of the background around the canvas.
+func WithPadding(padding Box) Option {
+	return func(c *Chart) {
+		c.Background.Padding = padding
+	}
+}
+
+// WithAxes shows the x and y axes.
+func WithAxes() Option {
+	return func(c *Chart) {
+		c.XAxis.Style.Show = true
+		c.YAxis.Style.Show = true
+	}
+}
+
+// WithXAxisName sets and shows the name of the x axis.
+func WithXAxisName(name string) Option {
+	return func(c *Chart) {
+		c.XAxis.Name = name
+		c.XAxis.NameStyle.Show = true
+	}
+}
+
+// WithYAxisName sets and shows the name of the y axis.
+func WithYAxisName(name string) Option {
+	return func(c *Chart) {
+		c.YAxis.Name = name
+		c.YAxis.NameStyle.Show = true
+	}
+}
+
+// WithXAxisRange sets the range of the x axis.
+func WithXAxisRange(r Range) Option {
+	return func(c *Chart) {
+		c.XAxis.Range = r
+	}
+}
+
+// WithYAxisRange sets the range of the y axis.
+func WithYAxisRange(r Range) Option {
+	return func(c *Chart) {
+		c.YAxis.Range = r
+	}
+}
+
+// WithXAxisValueFormatter sets the value formatter of the x axis.
+func WithXAxisValueFormatter(vf ValueFormatter) Option {
+	return func(c *Chart) {
+		c.XAxis.ValueFormatter = vf
+	}
+}
+
+// WithYAxisValueFormatter sets the value formatter of the y axis.
+func WithYAxisValueFormatter(vf ValueFormatter) Option {
+	return func(c *Chart) {
+		c.YAxis.ValueFormatter = vf
+	}
+}
+
+// WithTickStyle sets the style of the tick labels of the x and y axes.
+func WithTickStyle(style Style) Option {
+	return func(c *Chart) {
+		c.XAxis.TickStyle = style
+		c.YAxis.TickStyle = style
+	}
+}
+
+// WithGridLines shows the major grid lines of the x and y axes, optionally with a style.
+func WithGridLines(userDefaults ...Style) Option {
+	return func(c *Chart) {
+		var style Style
+		if len(userDefaults) > 0 {
+			style = userDefaults[0]
+		}
+		style.Show = true
+		c.XAxis.GridMajorStyle = style
+		c.YAxis.GridMajorStyle = style
+	}
+}
+
+// WithSeries adds series to the chart.
+func WithSeries(series ...Series) Option {
+	return func(c *Chart) {
+		c.Series = append(c.Series, series...)
+	}
+}
+
+// WithElements adds elements to the chart.
+func WithElements(elements ...Renderable) Option {
+	return func(c *Chart) {
+		c.Elements = append(c.Elements, elements...)
+	}
+}
+
+// WithLegend adds a `Legend` element to the chart.
+func WithLegend(userDefaults ...Style) Option {
+	return func(c *Chart) {
+		c.Elements = append(c.Elements, Legend(c, userDefaults...))
+	}
+}
+
+// WithLegendOptions shows the legend the chart draws itself with the given options; see `Chart.Legend`.
+func WithLegendOptions(options LegendOptions) Option {
+	return func(c *Chart) {
+		c.Legend = options
+		c.Legend.Style.Show = true
+	}
+}
+
+// WithLayoutEngine sets the chart layout engine.
+func WithLayoutEngine(le LayoutEngine) Option {
+	return func(c *Chart) {
+		c.LayoutEngine = le
+	}
+}
+
+// WithLogger sets the chart logger.
+func WithLogger(log Logger) Option {
+	return func(c *Chart) {
+		c.Log = log
+	}
+}
@@ -0,0 +1,78 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(Chart{}, *New())
+
+	// user defined options compose with the built in ones.
+	report := func(title string) Option {
+		return Options(WithTitle(title), WithSize(800, 300), WithTheme(DarkColorPalette))
+	}
+	c := New(
+		report("cpu"),
+		WithSeries(ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}}),
+		WithSeries(ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{3, 2, 1}}),
+		WithLegend(),
+		nil,
+	)
+	assert.Equal("cpu", c.Title)
+	assert.True(c.TitleStyle.Show)
+	assert.Equal(800, c.GetWidth())
+	assert.Equal(300, c.GetHeight())
+	assert.Equal(DarkColorPalette, c.GetColorPalette())
+	assert.Len(c.Series, 2)
+	assert.Len(c.Elements, 1)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func TestChartApply(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New(WithWidth(100))
+	c.Apply(WithWidth(200), WithDPI(72))
+	assert.Equal(200, c.Width)
+	assert.Equal(72.0, c.DPI)
+}
+
+func TestNestedOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	grid := Style{StrokeColor: ColorLightGray, StrokeWidth: 1}
+	c := New(
+		WithThemeName("dark"),
+		WithThemeName("unknown"),
+		WithPadding(Box{Top: 20, Left: 10, Right: 10, Bottom: 10}),
+		WithAxes(),
+		WithXAxisName("time"),
+		WithYAxisName("cpu"),
+		WithYAxisRange(&ContinuousRange{Min: 0, Max: 100}),
+		WithYAxisValueFormatter(PercentValueFormatter),
+		WithGridLines(grid),
+		WithSeries(ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{10, 50, 30}}),
+	)
+	assert.Equal(DarkTheme, c.ColorPalette)
+	assert.Equal(20, c.Background.Padding.Top)
+	assert.True(c.XAxis.Style.Show)
+	assert.True(c.YAxis.Style.Show)
+	assert.Equal("time", c.XAxis.Name)
+	assert.True(c.YAxis.NameStyle.Show)
+	assert.Equal(100.0, c.YAxis.Range.GetMax())
+	assert.NotNil(c.YAxis.ValueFormatter)
+	assert.True(c.XAxis.GridMajorStyle.Show)
+	assert.Equal(ColorLightGray, c.YAxis.GridMajorStyle.StrokeColor)
+	// the grid style passed in is not changed.
+	assert.False(grid.Show)
+
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
@@ -0,0 +1,221 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+const (
+	// DefaultPaletteSize is the number of colors sequential and diverging palettes spread over when the number
+	// of series isn't known.
+	DefaultPaletteSize = 9
+
+	// goldenAngle is the hue step, in degrees, of categorical palettes; consecutive colors are far apart
+	// and no hue repeats.
+	goldenAngle = 137.50776405003785
+)
+
+var (
+	// BrewerDark2Colors are the ColorBrewer Dark2 qualitative colors, which read on a white background.
+	BrewerDark2Colors = []drawing.Color{
+		drawing.ColorFromHex("1B9E77"),
+		drawing.ColorFromHex("D95F02"),
+		drawing.ColorFromHex("7570B3"),
+		drawing.ColorFromHex("E7298A"),
+		drawing.ColorFromHex("66A61E"),
+		drawing.ColorFromHex("E6AB02"),
+		drawing.ColorFromHex("A6761D"),
+		drawing.ColorFromHex("666666"),
+	}
+
+	// BrewerPalette is a palette of the `BrewerDark2Colors`.
+	BrewerPalette Palette = ColorSetPalette{Set: BrewerDark2Colors}
+	// ColorBlindPalette is a palette of the `OkabeItoColors`, which stay distinguishable with the common kinds of
+	// color blindness; past them they repeat lighter and darker, which color blindness doesn't hide.
+	ColorBlindPalette Palette = ColorSetPalette{Set: OkabeItoColors}
+)
+
+// Palette is a color palette that generates the colors of the series of a chart for the number of them,
+// so charts with many series don't cycle back to the same colors. Charts take the colors of their series,
+// bars or slices from it when it is their color palette.
+type Palette interface {
+	ColorPalette
+	// Colors returns the colors of a number of series.
+	Colors(count int) []drawing.Color
+}
+
+// getSeriesColor returns the color of a series of a number of series from a color palette.
+func getSeriesColor(cp ColorPalette, index, count int) drawing.Color {
+	if p, isPalette := cp.(Palette); isPalette && index < count {
+		return p.Colors(count)[index]
+	}
+	return cp.GetSeriesColor(index)
+}
+
+// CategoricalPalette is a palette of colors of equal lightness and chroma in CIE LCh, with hues stepped by the golden
+// angle from a seed hue, so every color is far from the ones before it; lightness alternates between three levels,
+// which keeps charts with 15 or more series apart. The colors of the first series don't change with the count.
+type CategoricalPalette struct {
+	defaultColorPalette
+	// SeedHue is the hue of the first color, in degrees of CIE LCh, i.e. 0 for magenta red or 250 for blue.
+	SeedHue float64
+}
+
+// Colors returns the colors of a number of series.
+func (cp CategoricalPalette) Colors(count int) []drawing.Color {
+	lightness := [3]float64{58, 42, 74}
+	colors := make([]drawing.Color, count)
+	for index := range colors {
+		colors[index] = lchToColor(lightness[index%3], 56, cp.SeedHue+float64(index)*goldenAngle)
+	}
+	return colors
+}
+
+// GetSeriesColor returns the color of a series by index.
+func (cp CategoricalPalette) GetSeriesColor(index int) drawing.Color {
+	return cp.Colors(index + 1)[index]
+}
+
+// ColorSetPalette is a palette of a set of colors; past the end of the set the colors repeat lighter, then darker,
+// and so on, so no two series share a color.
+type ColorSetPalette struct {
+	defaultColorPalette
+	Set []drawing.Color
+}
+
+// Colors returns the colors of a number of series.
+func (csp ColorSetPalette) Colors(count int) []drawing.Color {
+	colors := make([]drawing.Color, count)
+	for index := range colors {
+		colors[index] = csp.GetSeriesColor(index)
+	}
+	return colors
+}
+
+// GetSeriesColor returns the color of a series by index.
+func (csp ColorSetPalette) GetSeriesColor(index int) drawing.Color {
+	if len(csp.Set) == 0 {
+		return GetDefaultColor(index)
+	}
+	color := csp.Set[index%len(csp.Set)]
+	round := index / len(csp.Set)
+	if round == 0 {
+		return color
+	}
+	// rounds 1, 2, 3, 4 are 16 lighter, 16 darker, 32 lighter and 32 darker, and so on.
+	shift := float64((round+1)/2) * 16
+	if round%2 == 0 {
+		shift = -shift
+	}
+	l, a, b := colorToLab(color)
+	return labToColor(math.Max(8, math.Min(94, l+shift)), a, b)
+}
+
+// SequentialPalette is a palette that blends from one color to another in CIE L*a*b*, for series that are ordered,
+// i.e. years; it defaults to light to dark blue.
+type SequentialPalette struct {
+	defaultColorPalette
+	From, To drawing.Color
+}
+
+// Colors returns the colors of a number of series, from the first color to the last.
+func (sp SequentialPalette) Colors(count int) []drawing.Color {
+	from, to := themeColor(sp.From, drawing.ColorFromHex("C6DBEF")), themeColor(sp.To, drawing.ColorFromHex("08306B"))
+	colors := make([]drawing.Color, count)
+	for index := range colors {
+		colors[index] = blendLab(from, to, paletteStep(index, count))
+	}
+	return colors
+}
+
+// GetSeriesColor returns the color of a series by index, of `DefaultPaletteSize` series or more.
+func (sp SequentialPalette) GetSeriesColor(index int) drawing.Color {
+	return sp.Colors(util.Math.MaxInt(index+1, DefaultPaletteSize))[index]
+}
+
+// DivergingPalette is a palette that blends from a low color through a middle color to a high color in CIE L*a*b*,
+// for series either side of a midpoint, i.e. changes; it defaults to red, through light gray, to blue.
+type DivergingPalette struct {
+	defaultColorPalette
+	Low, Middle, High drawing.Color
+}
+
+// Colors returns the colors of a number of series, from the low color to the high color; the middle series of an odd
+// number is the middle color.
+func (dp DivergingPalette) Colors(count int) []drawing.Color {
+	low := themeColor(dp.Low, drawing.ColorFromHex("B2182B"))
+	middle := themeColor(dp.Middle, drawing.ColorFromHex("F7F7F7"))
+	high := themeColor(dp.High, drawing.ColorFromHex("2166AC"))
+	colors := make([]drawing.Color, count)
+	for index := range colors {
+		if t := paletteStep(index, count); t < 0.5 {
+			colors[index] = blendLab(low, middle, 2*t)
+		} else {
+			colors[index] = blendLab(middle, high, 2*t-1)
+		}
+	}
+	return colors
+}
+
+// GetSeriesColor returns the color of a series by index, of `DefaultPaletteSize` series or more.
+func (dp DivergingPalette) GetSeriesColor(index int) drawing.Color {
+	return dp.Colors(util.Math.MaxInt(index+1, DefaultPaletteSize))[index]
+}
+
+// paletteStep returns how far along a number of series a series is, from 0 to 1; a single series is at the end.
+func paletteStep(index, count int) float64 {
+	if count < 2 {
+		return 1
+	}
+	return float64(index) / float64(count-1)
+}
+
+// blendLab returns the color a share `t` of the way from `a` to `b` in CIE L*a*b*, which looks evenly spaced.
+func blendLab(a, b drawing.Color, t float64) drawing.Color {
+	al, aa, ab := colorToLab(a)
+	bl, ba, bb := colorToLab(b)
+	return labToColor(al+(bl-al)*t, aa+(ba-aa)*t, ab+(bb-ab)*t)
+}
+
+// lchToColor converts CIE LCh, with the hue in degrees, to a color; chroma the srgb gamut can't show at the lightness
+// and hue is reduced until it can, so the hue is kept.
+func lchToColor(l, c, h float64) drawing.Color {
+	theta := util.Math.DegreesToRadians(math.Mod(h, 360))
+	for ; c > 0; c -= 2 {
+		if r, g, b := labToLinear(l, c*math.Cos(theta), c*math.Sin(theta)); inUnitRange(r, g, b) {
+			break
+		}
+	}
+	return labToColor(l, math.Max(c, 0)*math.Cos(theta), math.Max(c, 0)*math.Sin(theta))
+}
+
+// labToColor converts CIE L*a*b* with a D65 white point to an opaque color, clipped to the srgb gamut.
+func labToColor(l, a, b float64) drawing.Color {
+	r, g, bl := labToLinear(l, a, b)
+	return drawing.Color{R: linearToSRGB(r), G: linearToSRGB(g), B: linearToSRGB(bl), A: 255}
+}
+
+// labToLinear converts CIE L*a*b* with a D65 white point to linear rgb, which may be out of gamut.
+func labToLinear(l, a, b float64) (r, g, bl float64) {
+	finv := func(t float64) float64 {
+		if t > 0.206893 {
+			return t * t * t
+		}
+		return (t - 16.0/116.0) / 7.787
+	}
+	fy := (l + 16) / 116
+	x, y, z := finv(fy+a/500)*0.95047, finv(fy), finv(fy-b/200)*1.08883
+	return 3.2406*x - 1.5372*y - 0.4986*z, -0.9689*x + 1.8758*y + 0.0415*z, 0.0557*x - 0.2040*y + 1.0570*z
+}
+
+// inUnitRange returns if linear rgb channels are within the srgb gamut.
+func inUnitRange(channels ...float64) bool {
+	for _, channel := range channels {
+		if channel < 0 || channel > 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestCategoricalPalette(t *testing.T) {
+	assert := assert.New(t)
+
+	p := CategoricalPalette{SeedHue: 250}
+	colors := p.Colors(16)
+	assert.Len(colors, 16)
+	for i := range colors {
+		assert.Equal(uint8(255), colors[i].A)
+		for j := i + 1; j < len(colors); j++ {
+			assert.True(colorDistance(colors[i], colors[j]) > 10, i, j, colors[i], colors[j])
+		}
+	}
+	// the first colors don't change with the count.
+	assert.Equal(colors[:4], p.Colors(4))
+	assert.Equal(colors[5], p.GetSeriesColor(5))
+	// the seed hue is blue.
+	assert.True(colors[0].B > colors[0].R, colors[0])
+}
+
+func TestColorSetPalette(t *testing.T) {
+	assert := assert.New(t)
+
+	colors := ColorBlindPalette.Colors(24)
+	assert.Equal(OkabeItoColors, colors[:len(OkabeItoColors)])
+	for i := range colors {
+		for j := i + 1; j < len(colors); j++ {
+			assert.NotEqual(colors[i], colors[j], i, j)
+		}
+	}
+	// the second round is lighter, and the third darker.
+	l, _, _ := colorToLab(OkabeItoColors[0])
+	lighter, _, _ := colorToLab(colors[len(OkabeItoColors)])
+	darker, _, _ := colorToLab(colors[2*len(OkabeItoColors)])
+	assert.InDelta(l+16, lighter, 1)
+	// saturated colors lose some lightness to the gamut when darkened.
+	assert.InDelta(l-16, darker, 2)
+
+	assert.Equal(BrewerDark2Colors[1], BrewerPalette.GetSeriesColor(1))
+	assert.Equal(GetDefaultColor(2), ColorSetPalette{}.GetSeriesColor(2))
+}
+
+func TestSequentialAndDivergingPalettes(t *testing.T) {
+	assert := assert.New(t)
+
+	from, to := ColorWhite, ColorBlack
+	colors := SequentialPalette{From: from, To: to}.Colors(5)
+	assert.Equal(from, colors[0])
+	assert.Equal(to, colors[4])
+	previous, _, _ := colorToLab(colors[0])
+	for _, color := range colors[1:] {
+		l, _, _ := colorToLab(color)
+		assert.True(l < previous)
+		previous = l
+	}
+	assert.Equal(to, SequentialPalette{From: from, To: to}.Colors(1)[0])
+
+	diverging := DivergingPalette{}.Colors(5)
+	assert.Equal(drawing.ColorFromHex("B2182B"), diverging[0])
+	assert.Equal(drawing.ColorFromHex("F7F7F7"), diverging[2])
+	assert.Equal(drawing.ColorFromHex("2166AC"), diverging[4])
+	assert.Equal(DivergingPalette{}.Colors(DefaultPaletteSize)[3], DivergingPalette{}.GetSeriesColor(3))
+}
+
+func TestChartPaletteSeriesColors(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{ColorPalette: SequentialPalette{From: ColorWhite, To: ColorBlack}}
+	for index := 0; index < 3; index++ {
+		c.Series = append(c.Series, ContinuousSeries{XValues: []float64{0, 1}, YValues: []float64{float64(index), float64(index + 1)}})
+	}
+	assert.Equal(ColorBlack, c.styleDefaultsSeries(2).StrokeColor)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), "stroke:rgba(51,51,51,1.0)"))
+
+	bc := BarChart{ColorPalette: CategoricalPalette{}, Bars: []Value{{Value: 1}, {Value: 2}}}
+	assert.Equal(CategoricalPalette{}.GetSeriesColor(1), bc.styleDefaultsBar(1).FillColor)
+}
@@ -0,0 +1,336 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// Panel is a chart in a `PanelGrid`.
+type Panel struct {
+	Chart Chart
+	// Height is the height of the panel's row relative to the other rows; a row is as high as its highest panel,
+	// and panels default to 1, so i.e. a price panel of 3 over a volume panel of 1 takes three quarters of the height.
+	Height float64
+}
+
+// GetHeight returns the relative height of the panel or the default.
+func (p Panel) GetHeight() float64 {
+	if p.Height <= 0 {
+		return 1
+	}
+	return p.Height
+}
+
+// PanelGrid renders charts into one image, in rows of `Columns` panels, i.e. a price chart over a volume chart or
+// a 2x2 grid of facets. The charts are sized to their panels, so their own widths and heights are ignored.
+//
+// With `SharedXAxis` set, the panels of each column share an x axis: their x ranges are the union of the ranges
+// of the column, their canvases are aligned to the same left and right edges, and only the bottom panel of the
+// column draws the x axis. The other panels draw the vertical grid lines of their x axis, if it has any, at the ticks
+// of the bottom panel.
+type PanelGrid struct {
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+
+	// Columns is the number of panels in a row; it defaults to 1.
+	Columns int
+	// Gap is the space between panels in pixels.
+	Gap int
+
+	SharedXAxis bool
+
+	// Accessibility describes the grid for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Panels []Panel
+}
+
+// GetDPI returns the dpi for the grid.
+func (pg PanelGrid) GetDPI(defaults ...float64) float64 {
+	if pg.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return pg.DPI
+}
+
+// GetWidth returns the grid width or the default value.
+func (pg PanelGrid) GetWidth() int {
+	if pg.Width == 0 {
+		return DefaultChartWidth
+	}
+	return pg.Width
+}
+
+// GetHeight returns the grid height or the default value.
+func (pg PanelGrid) GetHeight() int {
+	if pg.Height == 0 {
+		return DefaultChartHeight
+	}
+	return pg.Height
+}
+
+// GetColumns returns the number of panels in a row or the default.
+func (pg PanelGrid) GetColumns() int {
+	if pg.Columns <= 0 {
+		return 1
+	}
+	return pg.Columns
+}
+
+// GetPanelBoxes returns the box of each panel, in rows from the top left.
+func (pg PanelGrid) GetPanelBoxes() []Box {
+	columns := pg.GetColumns()
+	rows := (len(pg.Panels) + columns - 1) / columns
+
+	rowHeights := make([]float64, rows)
+	for index, p := range pg.Panels {
+		rowHeights[index/columns] = math.Max(rowHeights[index/columns], p.GetHeight())
+	}
+
+	var rowLayout, columnLayout BoxLayout
+	for row, height := range rowHeights {
+		item := BoxLayoutItem{Anchor: LayoutAnchorTop, Size: Weight(height)}
+		if row < rows-1 {
+			item.Gap = pg.Gap
+		}
+		rowLayout.Items = append(rowLayout.Items, item)
+	}
+	// every row has all the columns, so the panels of a short last row line up with the rows above.
+	for column := 0; column < columns; column++ {
+		item := BoxLayoutItem{Anchor: LayoutAnchorLeft, Size: Weight(1)}
+		if column < columns-1 {
+			item.Gap = pg.Gap
+		}
+		columnLayout.Items = append(columnLayout.Items, item)
+	}
+
+	rowBoxes, _ := rowLayout.Solve(Box{Right: pg.GetWidth(), Bottom: pg.GetHeight()})
+	boxes := make([]Box, len(pg.Panels))
+	for index := range pg.Panels {
+		row, column := index/columns, index%columns
+		if column == 0 {
+			columnBoxes, _ := columnLayout.Solve(rowBoxes[row])
+			copy(boxes[index:], columnBoxes)
+		}
+	}
+	return boxes
+}
+
+// isBottomPanel returns if a panel is the last one of its column.
+func (pg PanelGrid) isBottomPanel(index int) bool {
+	return index+pg.GetColumns() >= len(pg.Panels)
+}
+
+// Render renders the grid with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (pg PanelGrid) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if len(pg.Panels) == 0 {
+		return newValidationError(ErrEmptySeries, "please provide at least one panel")
+	}
+
+	r, err := rp(pg.GetWidth(), pg.GetHeight())
+	if err != nil {
+		return err
+	}
+	r.SetDPI(pg.GetDPI(DefaultDPI))
+	Draw.Box(r, Box{Right: pg.GetWidth(), Bottom: pg.GetHeight()}, pg.Background.InheritFrom(Style{FillColor: DefaultBackgroundColor}))
+
+	boxes := pg.GetPanelBoxes()
+	charts := make([]Chart, len(pg.Panels))
+	renderers := make([]Renderer, len(pg.Panels))
+	layouts := make([]ChartLayout, len(pg.Panels))
+	for index, p := range pg.Panels {
+		c := p.Chart
+		if len(c.Series) == 0 {
+			return newValidationError(ErrEmptySeries, "panel %d: please provide at least one series", index)
+		}
+		c.Width, c.Height, c.DPI, c.ScaleFactor = boxes[index].Width(), boxes[index].Height(), pg.GetDPI(), 0
+		if pg.SharedXAxis && !pg.isBottomPanel(index) {
+			c.XAxis.Style.Show = false
+		}
+		charts[index] = c.withDefaults()
+		renderers[index] = offsetRenderer{Renderer: r, dx: boxes[index].Left, dy: boxes[index].Top}
+		if c.NoText {
+			renderers[index] = textFreeRenderer{renderers[index]}
+		}
+		layouts[index] = charts[index].Measure()
+	}
+	if pg.SharedXAxis {
+		pg.shareXRanges(layouts)
+	}
+
+	for index := range pg.Panels {
+		if layouts[index], err = charts[index].Layout(renderers[index], layouts[index]); err != nil {
+			return fmt.Errorf("panel %d: %w", index, err)
+		}
+	}
+	if pg.SharedXAxis {
+		pg.alignCanvases(layouts)
+	}
+
+	for index := range pg.Panels {
+		c := charts[index]
+		if pg.SharedXAxis && !pg.isBottomPanel(index) {
+			c.BeforeRender = pg.sharedXGridHook(pg.Panels[index].Chart, c.BeforeRender, layouts[pg.getBottomPanel(index)].XTicks)
+		}
+		startGroup(r, fmt.Sprintf("panel-%d", index), "panel")
+		err = c.Paint(renderers[index], layouts[index], ioutil.Discard)
+		endGroup(r)
+		if err != nil {
+			return fmt.Errorf("panel %d: %w", index, err)
+		}
+	}
+
+	setAccessibility(r, pg.Accessibility.withDefaults("", func() string {
+		descriptions := make([]string, len(charts))
+		for index, c := range charts {
+			descriptions[index] = c.describeSeries()
+		}
+		return fmt.Sprintf("Grid of %d charts: %s", len(charts), strings.Join(descriptions, " "))
+	}))
+	return r.Save(w)
+}
+
+// getBottomPanel returns the index of the last panel of the column of a panel.
+func (pg PanelGrid) getBottomPanel(index int) int {
+	columns := pg.GetColumns()
+	for index+columns < len(pg.Panels) {
+		index += columns
+	}
+	return index
+}
+
+// shareXRanges sets the x ranges of the panels of each column to their union.
+func (pg PanelGrid) shareXRanges(layouts []ChartLayout) {
+	columns := pg.GetColumns()
+	for column := 0; column < columns && column < len(layouts); column++ {
+		min, max := math.MaxFloat64, -math.MaxFloat64
+		for index := column; index < len(layouts); index += columns {
+			min = math.Min(min, layouts[index].XRange.GetMin())
+			max = math.Max(max, layouts[index].XRange.GetMax())
+		}
+		for index := column; index < len(layouts); index += columns {
+			layouts[index].XRange.SetMin(min)
+			layouts[index].XRange.SetMax(max)
+		}
+	}
+}
+
+// alignCanvases narrows the canvases of the panels of each column to the same left and right edges.
+func (pg PanelGrid) alignCanvases(layouts []ChartLayout) {
+	columns := pg.GetColumns()
+	for column := 0; column < columns && column < len(layouts); column++ {
+		left, right := 0, math.MaxInt32
+		for index := column; index < len(layouts); index += columns {
+			left = util.Math.MaxInt(left, layouts[index].Canvas.Left)
+			right = util.Math.MinInt(right, layouts[index].Canvas.Right)
+		}
+		for index := column; index < len(layouts); index += columns {
+			layouts[index].Canvas.Left, layouts[index].Canvas.Right = left, right
+		}
+	}
+}
+
+// sharedXGridHook returns a render hook that draws the grid lines of the hidden x axis of a panel
+// at the ticks of the bottom panel of its column, before calling the panel's own hook.
+func (pg PanelGrid) sharedXGridHook(c Chart, hook RenderHook, ticks []Tick) RenderHook {
+	return func(r Renderer, cl ChartLayout) {
+		if c.XAxis.Style.Show {
+			xa := c.XAxis
+			gridDefaults := c.getGridStyleDefaults()
+			xa.GridMajorStyle, xa.GridMinorStyle = withGridDefaults(xa.GridMajorStyle, gridDefaults), withGridDefaults(xa.GridMinorStyle, minorGridDefaults(gridDefaults))
+			if xa.GridMajorStyle.Show || xa.GridMinorStyle.Show {
+				startGroup(r, xa.getGridLinesGroupID(), "gridlines")
+				for _, gl := range xa.getGridLines(cl.XRange, ticks) {
+					if (gl.IsMinor && xa.GridMinorStyle.Show) || (!gl.IsMinor && xa.GridMajorStyle.Show) {
+						defaults := xa.GridMajorStyle
+						if gl.IsMinor {
+							defaults = xa.GridMinorStyle
+						}
+						gl.Render(r, cl.Canvas, cl.XRange, true, gl.Style.InheritFrom(defaults))
+					}
+				}
+				endGroup(r)
+			}
+		}
+		callRenderHook(hook, r, cl)
+	}
+}
+
+// offsetRenderer draws a chart at an offset within a larger renderer; saving it does nothing.
+type offsetRenderer struct {
+	Renderer
+	dx, dy int
+}
+
+// MoveTo implements the interface method.
+func (or offsetRenderer) MoveTo(x, y int) {
+	or.Renderer.MoveTo(x+or.dx, y+or.dy)
+}
+
+// LineTo implements the interface method.
+func (or offsetRenderer) LineTo(x, y int) {
+	or.Renderer.LineTo(x+or.dx, y+or.dy)
+}
+
+// QuadCurveTo implements the interface method.
+func (or offsetRenderer) QuadCurveTo(cx, cy, x, y int) {
+	or.Renderer.QuadCurveTo(cx+or.dx, cy+or.dy, x+or.dx, y+or.dy)
+}
+
+// CubicCurveTo implements the interface method.
+func (or offsetRenderer) CubicCurveTo(c1x, c1y, c2x, c2y, x, y int) {
+	or.Renderer.CubicCurveTo(c1x+or.dx, c1y+or.dy, c2x+or.dx, c2y+or.dy, x+or.dx, y+or.dy)
+}
+
+// Polygon implements the interface method.
+func (or offsetRenderer) Polygon(points []Point) {
+	shifted := make([]Point, len(points))
+	for index, p := range points {
+		shifted[index] = Point{X: p.X + or.dx, Y: p.Y + or.dy}
+	}
+	or.Renderer.Polygon(shifted)
+}
+
+// ArcTo implements the interface method.
+func (or offsetRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	or.Renderer.ArcTo(cx+or.dx, cy+or.dy, rx, ry, startAngle, delta)
+}
+
+// Circle implements the interface method.
+func (or offsetRenderer) Circle(radius float64, x, y int) {
+	or.Renderer.Circle(radius, x+or.dx, y+or.dy)
+}
+
+// Text implements the interface method.
+func (or offsetRenderer) Text(body string, x, y int) {
+	or.Renderer.Text(body, x+or.dx, y+or.dy)
+}
+
+// Save implements the interface method; the renderer the chart is drawn into is saved instead.
+func (or offsetRenderer) Save(w io.Writer) error {
+	return nil
+}
+
+// StartGroup implements groupRenderer, if the renderer the chart is drawn into does.
+func (or offsetRenderer) StartGroup(id string, classes ...string) {
+	startGroup(or.Renderer, id, classes...)
+}
+
+// EndGroup implements groupRenderer, if the renderer the chart is drawn into does.
+func (or offsetRenderer) EndGroup() {
+	endGroup(or.Renderer)
+}
@@ -0,0 +1,81 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestPanelGridGetPanelBoxes(t *testing.T) {
+	assert := assert.New(t)
+
+	pg := PanelGrid{
+		Width:  400,
+		Height: 410,
+		Gap:    10,
+		Panels: []Panel{{Height: 3}, {}},
+	}
+	boxes := pg.GetPanelBoxes()
+	assert.Len(boxes, 2)
+	assert.Equal(Box{Top: 0, Left: 0, Right: 400, Bottom: 300}, boxes[0])
+	assert.Equal(Box{Top: 310, Left: 0, Right: 400, Bottom: 410}, boxes[1])
+
+	pg = PanelGrid{Width: 410, Height: 410, Gap: 10, Columns: 2, Panels: []Panel{{}, {}, {}, {}}}
+	boxes = pg.GetPanelBoxes()
+	assert.Equal(Box{Top: 0, Left: 0, Right: 200, Bottom: 200}, boxes[0])
+	assert.Equal(Box{Top: 210, Left: 210, Right: 410, Bottom: 410}, boxes[3])
+	assert.False(pg.isBottomPanel(1))
+	assert.True(pg.isBottomPanel(2))
+	assert.Equal(3, pg.getBottomPanel(1))
+}
+
+func TestPanelGridSharedXAxis(t *testing.T) {
+	assert := assert.New(t)
+
+	layouts := []ChartLayout{
+		{Canvas: Box{Left: 40, Right: 380}, XRange: &ContinuousRange{Min: 0, Max: 10}},
+		{Canvas: Box{Left: 60, Right: 390}, XRange: &ContinuousRange{Min: 5, Max: 20}},
+	}
+	pg := PanelGrid{SharedXAxis: true, Panels: []Panel{{}, {}}}
+	pg.shareXRanges(layouts)
+	pg.alignCanvases(layouts)
+	for _, cl := range layouts {
+		assert.Equal(0.0, cl.XRange.GetMin())
+		assert.Equal(20.0, cl.XRange.GetMax())
+		assert.Equal(60, cl.Canvas.Left)
+		assert.Equal(380, cl.Canvas.Right)
+	}
+}
+
+func TestPanelGridRender(t *testing.T) {
+	assert := assert.New(t)
+
+	pg := PanelGrid{
+		Width:       600,
+		Height:      400,
+		SharedXAxis: true,
+		Panels: []Panel{
+			{Height: 3, Chart: Chart{
+				XAxis:  XAxis{Style: StyleShow(), GridMajorStyle: StyleShow()},
+				YAxis:  YAxis{Style: StyleShow()},
+				Series: []Series{ContinuousSeries{Name: "price", XValues: []float64{1, 2, 3}, YValues: []float64{100, 120, 110}}},
+			}},
+			{Chart: Chart{
+				XAxis:  XAxis{Style: StyleShow()},
+				YAxis:  YAxis{Style: StyleShow()},
+				Series: []Series{ContinuousSeries{Name: "volume", XValues: []float64{1, 2, 3, 4}, YValues: []float64{1000, 3000, 2000, 500}}},
+			}},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(pg.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), "Grid of 2 charts"))
+
+	buffer.Reset()
+	assert.Nil(pg.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+
+	assert.NotNil(PanelGrid{}.Render(PNG, buffer))
+}
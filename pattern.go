@@ -0,0 +1,201 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// PatternKind is the kind of lines or dots a fill pattern draws.
+type PatternKind int
+
+const (
+	// PatternKindUnset is the unset pattern kind; it draws diagonal lines.
+	PatternKindUnset PatternKind = 0
+	// PatternKindDiagonal draws lines from the bottom left to the top right.
+	PatternKindDiagonal PatternKind = 1
+	// PatternKindCrossHatch draws diagonal lines both ways.
+	PatternKindCrossHatch PatternKind = 2
+	// PatternKindDots draws a grid of dots.
+	PatternKindDots PatternKind = 3
+	// PatternKindVertical draws vertical lines.
+	PatternKindVertical PatternKind = 4
+	// PatternKindHorizontal draws horizontal lines.
+	PatternKindHorizontal PatternKind = 5
+)
+
+// Pattern is a fill of lines or dots drawn over the fill color, i.e. to tell bars or slices apart in black and
+// white print. Patterns are laid out from the top left of the image, so neighboring shapes filled with the same
+// pattern line up.
+type Pattern struct {
+	Kind PatternKind
+	// Color is the color of the lines or dots; it defaults to black.
+	Color drawing.Color
+	// Spacing is the distance between the lines or dots in pixels; it defaults to 6.
+	Spacing float64
+	// Width is the width of the lines or the radius of the dots in pixels; it defaults to 1.
+	Width float64
+}
+
+// NewPattern returns a pattern of a kind in a color, with the default spacing and width.
+func NewPattern(kind PatternKind, color drawing.Color) *Pattern {
+	return &Pattern{Kind: kind, Color: color}
+}
+
+// GetColor returns the color of the lines or dots or the default.
+func (p Pattern) GetColor() drawing.Color {
+	if p.Color.IsZero() {
+		return drawing.ColorBlack
+	}
+	return p.Color
+}
+
+// GetSpacing returns the distance between the lines or dots or the default.
+func (p Pattern) GetSpacing() float64 {
+	if p.Spacing <= 0 {
+		return 6
+	}
+	return p.Spacing
+}
+
+// GetWidth returns the width of the lines or the radius of the dots or the default.
+func (p Pattern) GetWidth() float64 {
+	if p.Width <= 0 {
+		return 1
+	}
+	return p.Width
+}
+
+// getMiddle returns where in its cell a line or dot is centered; it is half a pixel past the middle,
+// so lines of a pixel are drawn on a pixel rather than across two.
+func (p Pattern) getMiddle() float64 {
+	return p.GetSpacing()/2 + 0.5
+}
+
+// coverageAt returns how much of the pixel centered on x,y the lines or dots cover, from 0 to 1, at a scale factor.
+// Diagonal lines are vertical lines turned by 45 degrees.
+func (p Pattern) coverageAt(x, y, scale float64) float64 {
+	spacing, width, middle := p.GetSpacing()*scale, p.GetWidth()*scale, p.getMiddle()*scale
+	switch p.Kind {
+	case PatternKindVertical:
+		return lineCoverage(cellDistance(x, spacing, middle), width)
+	case PatternKindHorizontal:
+		return lineCoverage(cellDistance(y, spacing, middle), width)
+	case PatternKindDots:
+		return math.Max(0, math.Min(1, width+0.5-math.Hypot(cellDistance(x, spacing, middle), cellDistance(y, spacing, middle))))
+	case PatternKindCrossHatch:
+		u, v := (x+y)/math.Sqrt2, (y-x)/math.Sqrt2
+		return math.Max(lineCoverage(cellDistance(u, spacing, middle), width), lineCoverage(cellDistance(v, spacing, middle), width))
+	}
+	return lineCoverage(cellDistance((x+y)/math.Sqrt2, spacing, middle), width)
+}
+
+// cellDistance returns the distance of a coordinate from the line or dot of its cell of the pattern.
+func cellDistance(value, spacing, middle float64) float64 {
+	offset := value - middle
+	return math.Abs(offset - spacing*math.Round(offset/spacing))
+}
+
+// lineCoverage returns how much of a pixel a line covers, from the distance of the pixel's center from the line.
+func lineCoverage(distance, width float64) float64 {
+	return math.Max(0, math.Min(1, width/2+0.5-distance))
+}
+
+// patternRenderer is a renderer that can fill with patterns.
+type patternRenderer interface {
+	// SetFillPattern sets the pattern to fill with until the fill color is next set.
+	SetFillPattern(p *Pattern)
+}
+
+// setFillPattern sets the pattern to fill with, if the renderer supports it.
+// Other renderers fill with a translucent version of the pattern color, unless the style has its own fill color.
+func setFillPattern(r Renderer, s Style) {
+	if s.FillPattern == nil {
+		return
+	}
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if or, isOffset := r.(offsetRenderer); isOffset {
+		r = or.Renderer
+	}
+	if pr, isPatternRenderer := r.(patternRenderer); isPatternRenderer {
+		pr.SetFillPattern(s.FillPattern)
+		return
+	}
+	if s.FillColor.IsZero() {
+		r.SetFillColor(s.FillPattern.GetColor().WithAlpha(64))
+	}
+}
+
+// patternImage is an unbounded image of a pattern drawn over a background color.
+type patternImage struct {
+	pattern    Pattern
+	background drawing.Color
+	scale      float64
+}
+
+// ColorModel implements image.Image.
+func (pi patternImage) ColorModel() color.Model {
+	return color.NRGBAModel
+}
+
+// Bounds implements image.Image.
+func (pi patternImage) Bounds() image.Rectangle {
+	return image.Rect(-1e9, -1e9, 1e9, 1e9)
+}
+
+// At implements image.Image; pixels are sampled at their centers.
+func (pi patternImage) At(x, y int) color.Color {
+	coverage := pi.pattern.coverageAt(float64(x)+0.5, float64(y)+0.5, pi.scale)
+	if coverage <= 0 {
+		return pi.background
+	}
+	fg := pi.pattern.GetColor()
+	fg = fg.WithAlpha(uint8(float64(fg.A)*coverage + 0.5))
+	return blendOver(fg, pi.background)
+}
+
+// blendOver returns a color composited over another.
+func blendOver(fg, bg drawing.Color) drawing.Color {
+	fa, ba := float64(fg.A)/255, float64(bg.A)/255
+	a := fa + ba*(1-fa)
+	if a == 0 {
+		return drawing.ColorTransparent
+	}
+	channel := func(f, b uint8) uint8 {
+		return uint8((float64(f)*fa+float64(b)*ba*(1-fa))/a + 0.5)
+	}
+	return drawing.Color{R: channel(fg.R, bg.R), G: channel(fg.G, bg.G), B: channel(fg.B, bg.B), A: uint8(a*255 + 0.5)}
+}
+
+// writePatternDefinition writes the svg `<pattern>` element of a pattern over a background color.
+func writePatternDefinition(w io.Writer, id string, p Pattern, background drawing.Color) {
+	spacing, width := p.GetSpacing(), p.GetWidth()
+	var transform string
+	if p.Kind == PatternKindUnset || p.Kind == PatternKindDiagonal || p.Kind == PatternKindCrossHatch {
+		transform = ` patternTransform="rotate(45)"`
+	}
+	fmt.Fprintf(w, `<defs><pattern id="%s" patternUnits="userSpaceOnUse" width="%0.2f" height="%0.2f"%s>`, id, spacing, spacing, transform)
+	if !background.IsZero() {
+		fmt.Fprintf(w, `<rect width="%0.2f" height="%0.2f" style="fill:%s"/>`, spacing, spacing, background.String())
+	}
+	line := `<line x1="%0.2f" y1="%0.2f" x2="%0.2f" y2="%0.2f" style="stroke:%s;stroke-width:%0.2f"/>`
+	middle := p.getMiddle()
+	switch p.Kind {
+	case PatternKindDots:
+		fmt.Fprintf(w, `<circle cx="%0.2f" cy="%0.2f" r="%0.2f" style="fill:%s"/>`, middle, middle, width, p.GetColor().String())
+	case PatternKindHorizontal:
+		fmt.Fprintf(w, line, 0.0, middle, spacing, middle, p.GetColor().String(), width)
+	case PatternKindCrossHatch:
+		fmt.Fprintf(w, line, 0.0, middle, spacing, middle, p.GetColor().String(), width)
+		fmt.Fprintf(w, line, middle, 0.0, middle, spacing, p.GetColor().String(), width)
+	default:
+		fmt.Fprintf(w, line, middle, 0.0, middle, spacing, p.GetColor().String(), width)
+	}
+	io.WriteString(w, "</pattern></defs>")
+}
@@ -0,0 +1,69 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestPatternCoverage(t *testing.T) {
+	assert := assert.New(t)
+
+	vertical := Pattern{Kind: PatternKindVertical}
+	assert.Equal(1.0, vertical.coverageAt(3.5, 0, 1))
+	assert.Equal(0.0, vertical.coverageAt(2.5, 0, 1))
+	assert.Equal(0.0, vertical.coverageAt(0.5, 0, 1))
+	assert.Equal(1.0, vertical.coverageAt(6.5, 0, 2))
+	assert.Equal(1.0, vertical.coverageAt(7.5, 0, 2))
+
+	horizontal := Pattern{Kind: PatternKindHorizontal, Spacing: 10}
+	assert.Equal(1.0, horizontal.coverageAt(0, 15.5, 1))
+	assert.Equal(0.0, horizontal.coverageAt(0, 10, 1))
+
+	dots := Pattern{Kind: PatternKindDots}
+	assert.Equal(1.0, dots.coverageAt(3.5, 3.5, 1))
+	assert.Equal(0.0, dots.coverageAt(0, 0, 1))
+
+	// diagonal lines run from the bottom left to the top right.
+	diagonal := Pattern{}
+	center := 3.5 * 1.4142135623730951 / 2
+	assert.Equal(diagonal.coverageAt(center, center, 1), diagonal.coverageAt(center+2, center-2, 1))
+	assert.True(diagonal.coverageAt(center, center, 1) > 0.9)
+}
+
+func TestPatternImage(t *testing.T) {
+	assert := assert.New(t)
+
+	pi := patternImage{pattern: Pattern{Kind: PatternKindVertical, Color: drawing.ColorBlack}, background: drawing.ColorWhite, scale: 1}
+	assert.Equal(drawing.ColorBlack, pi.At(3, 0))
+	assert.Equal(drawing.ColorWhite, pi.At(0, 0))
+}
+
+func TestStyleFillPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	style := Style{FillPattern: NewPattern(PatternKindDots, drawing.ColorBlack)}
+	assert.False(style.IsZero())
+	assert.True(style.ShouldDrawFill())
+	assert.Equal(style.FillPattern, Style{}.InheritFrom(style).FillPattern)
+
+	bc := BarChart{
+		Width:  200,
+		Height: 200,
+		Bars: []Value{
+			{Value: 1, Label: "a", Style: Style{FillColor: drawing.ColorWhite, FillPattern: NewPattern(PatternKindCrossHatch, drawing.ColorBlack)}},
+			{Value: 2, Label: "b"},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(bc.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), `<pattern id="pattern-1"`))
+	assert.True(strings.Contains(buffer.String(), "fill:url(#pattern-1)"))
+
+	buffer.Reset()
+	assert.Nil(bc.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
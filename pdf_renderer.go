@@ -0,0 +1,591 @@
+package chart
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/drawing"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// PDF returns a new pdf/vector renderer.
+// The chart is drawn on a single page sized to the chart at its dpi, so text is drawn at its point size.
+// Text is drawn as the outlines of the glyphs of its font; it matches the measured text exactly and needs no
+// embedded fonts, but it can't be selected or searched.
+func PDF(width, height int) (Renderer, error) {
+	return &pdfRenderer{
+		width:  width,
+		height: height,
+		dpi:    DefaultDPI,
+		s:      &Style{},
+		b:      bytes.NewBuffer(nil),
+		p:      bytes.NewBuffer(nil),
+		alphas: map[pdfAlpha]string{},
+	}, nil
+}
+
+// pdfAlpha is the stroke and fill opacity of a pdf graphics state.
+type pdfAlpha struct {
+	Stroke, Fill uint8
+}
+
+// pdfRenderer renders chart commands to a pdf content stream.
+type pdfRenderer struct {
+	width  int
+	height int
+	dpi    float64
+	s      *Style
+
+	// b is the content stream, and p the path being built.
+	b *bytes.Buffer
+	p *bytes.Buffer
+
+	// x and y are the current point of the path.
+	x, y     float64
+	hasPoint bool
+
+	textTheta *float64
+	glyphs    truetype.GlyphBuf
+
+	// alphas are the names of the graphics states with opacity that the content stream uses.
+	alphas map[pdfAlpha]string
+
+	accessibility *Accessibility
+	metadata      map[string]string
+}
+
+// IsDeterministic returns if the renderer produces byte for byte stable output; pdf output always is.
+func (pr *pdfRenderer) IsDeterministic() bool {
+	return true
+}
+
+// ResetStyle implements the interface method.
+func (pr *pdfRenderer) ResetStyle() {
+	pr.s = &Style{Font: pr.s.Font}
+	pr.ClearTextRotation()
+}
+
+// GetDPI returns the dpi.
+func (pr *pdfRenderer) GetDPI() float64 {
+	return pr.dpi
+}
+
+// SetDPI implements the interface method.
+func (pr *pdfRenderer) SetDPI(dpi float64) {
+	pr.dpi = dpi
+}
+
+// SetStrokeColor implements the interface method.
+func (pr *pdfRenderer) SetStrokeColor(c drawing.Color) {
+	pr.s.StrokeColor = c
+}
+
+// SetFillColor implements the interface method.
+func (pr *pdfRenderer) SetFillColor(c drawing.Color) {
+	pr.s.FillColor = c
+}
+
+// SetStrokeWidth implements the interface method.
+func (pr *pdfRenderer) SetStrokeWidth(width float64) {
+	pr.s.StrokeWidth = width
+}
+
+// SetStrokeDashArray implements the interface method.
+func (pr *pdfRenderer) SetStrokeDashArray(dashArray []float64) {
+	pr.s.StrokeDashArray = dashArray
+}
+
+// MoveTo implements the interface method.
+func (pr *pdfRenderer) MoveTo(x, y int) {
+	pr.moveTo(float64(x), float64(y))
+}
+
+func (pr *pdfRenderer) moveTo(x, y float64) {
+	fmt.Fprintf(pr.p, "%s %s m\n", pdfNumber(x), pdfNumber(y))
+	pr.x, pr.y, pr.hasPoint = x, y, true
+}
+
+// LineTo implements the interface method.
+func (pr *pdfRenderer) LineTo(x, y int) {
+	pr.lineTo(float64(x), float64(y))
+}
+
+func (pr *pdfRenderer) lineTo(x, y float64) {
+	if !pr.hasPoint {
+		pr.moveTo(x, y)
+		return
+	}
+	fmt.Fprintf(pr.p, "%s %s l\n", pdfNumber(x), pdfNumber(y))
+	pr.x, pr.y = x, y
+}
+
+// QuadCurveTo implements the interface method; pdf only has cubic curves, so the curve is raised to one.
+func (pr *pdfRenderer) QuadCurveTo(cx, cy, x, y int) {
+	pr.quadCurveTo(float64(cx), float64(cy), float64(x), float64(y))
+}
+
+func (pr *pdfRenderer) quadCurveTo(cx, cy, x, y float64) {
+	if !pr.hasPoint {
+		pr.moveTo(cx, cy)
+	}
+	c1x, c1y := pr.x+(2.0/3.0)*(cx-pr.x), pr.y+(2.0/3.0)*(cy-pr.y)
+	c2x, c2y := x+(2.0/3.0)*(cx-x), y+(2.0/3.0)*(cy-y)
+	pr.curveTo(c1x, c1y, c2x, c2y, x, y)
+}
+
+// CubicCurveTo implements the interface method.
+func (pr *pdfRenderer) CubicCurveTo(c1x, c1y, c2x, c2y, x, y int) {
+	if !pr.hasPoint {
+		pr.moveTo(float64(c1x), float64(c1y))
+	}
+	pr.curveTo(float64(c1x), float64(c1y), float64(c2x), float64(c2y), float64(x), float64(y))
+}
+
+func (pr *pdfRenderer) curveTo(c1x, c1y, c2x, c2y, x, y float64) {
+	fmt.Fprintf(pr.p, "%s %s %s %s %s %s c\n", pdfNumber(c1x), pdfNumber(c1y), pdfNumber(c2x), pdfNumber(c2y), pdfNumber(x), pdfNumber(y))
+	pr.x, pr.y = x, y
+}
+
+// ArcTo implements the interface method; like the png renderer, it draws a line to the start of the arc
+// if the path has a current point.
+func (pr *pdfRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	x, y := float64(cx), float64(cy)
+	sx, sy := x+rx*math.Cos(startAngle), y+ry*math.Sin(startAngle)
+	if pr.hasPoint {
+		pr.lineTo(sx, sy)
+	} else {
+		pr.moveTo(sx, sy)
+	}
+	pr.arc(x, y, rx, ry, startAngle, delta)
+}
+
+// arc draws an arc from the current point as cubic curves of at most a quarter circle each.
+func (pr *pdfRenderer) arc(cx, cy, rx, ry, start, delta float64) {
+	pieces := int(math.Ceil(math.Abs(delta) / _pi2))
+	if pieces == 0 {
+		return
+	}
+	step := delta / float64(pieces)
+	k := (4.0 / 3.0) * math.Tan(step/4)
+	for piece := 0; piece < pieces; piece++ {
+		a1 := start + float64(piece)*step
+		a2 := a1 + step
+		x1, y1 := cx+rx*math.Cos(a1), cy+ry*math.Sin(a1)
+		x2, y2 := cx+rx*math.Cos(a2), cy+ry*math.Sin(a2)
+		pr.curveTo(
+			x1-k*rx*math.Sin(a1), y1+k*ry*math.Cos(a1),
+			x2+k*rx*math.Sin(a2), y2-k*ry*math.Cos(a2),
+			x2, y2,
+		)
+	}
+}
+
+// Close implements the interface method.
+func (pr *pdfRenderer) Close() {
+	pr.p.WriteString("h\n")
+}
+
+// Polygon implements the interface method.
+func (pr *pdfRenderer) Polygon(points []Point) {
+	if len(points) == 0 {
+		return
+	}
+	pr.moveTo(float64(points[0].X), float64(points[0].Y))
+	for _, p := range points[1:] {
+		pr.lineTo(float64(p.X), float64(p.Y))
+	}
+	pr.Close()
+}
+
+// Stroke implements the interface method.
+func (pr *pdfRenderer) Stroke() {
+	pr.drawPath(false, true)
+}
+
+// Fill implements the interface method.
+func (pr *pdfRenderer) Fill() {
+	pr.drawPath(true, false)
+}
+
+// FillStroke implements the interface method.
+func (pr *pdfRenderer) FillStroke() {
+	pr.drawPath(true, true)
+}
+
+// drawPath paints the path with the parts of the style that are set, then clears it.
+func (pr *pdfRenderer) drawPath(fill, stroke bool) {
+	defer pr.clearPath()
+
+	fill = fill && !pr.s.FillColor.IsZero()
+	stroke = stroke && !pr.s.StrokeColor.IsZero() && pr.s.StrokeWidth > 0
+	if pr.p.Len() == 0 || (!fill && !stroke) {
+		return
+	}
+
+	pr.b.WriteString("q\n")
+	var alpha pdfAlpha
+	if fill {
+		alpha.Fill = pr.s.FillColor.A
+		pr.writeColor(pr.s.FillColor, "rg")
+	} else {
+		alpha.Fill = 255
+	}
+	if stroke {
+		alpha.Stroke = pr.s.StrokeColor.A
+		pr.writeColor(pr.s.StrokeColor, "RG")
+		fmt.Fprintf(pr.b, "%s w\n", pdfNumber(pr.s.StrokeWidth))
+		pr.writeDashArray(pr.s.StrokeDashArray)
+	} else {
+		alpha.Stroke = 255
+	}
+	pr.writeAlpha(alpha)
+	pr.b.Write(pr.p.Bytes())
+
+	switch {
+	case fill && stroke:
+		pr.b.WriteString("B\n")
+	case fill:
+		pr.b.WriteString("f\n")
+	default:
+		pr.b.WriteString("S\n")
+	}
+	pr.b.WriteString("Q\n")
+}
+
+func (pr *pdfRenderer) clearPath() {
+	pr.p.Reset()
+	pr.hasPoint = false
+}
+
+func (pr *pdfRenderer) writeColor(c drawing.Color, operator string) {
+	fmt.Fprintf(pr.b, "%s %s %s %s\n", pdfNumber(float64(c.R)/255), pdfNumber(float64(c.G)/255), pdfNumber(float64(c.B)/255), operator)
+}
+
+func (pr *pdfRenderer) writeDashArray(dashArray []float64) {
+	if len(dashArray) == 0 {
+		return
+	}
+	pr.b.WriteString("[")
+	for index, dash := range dashArray {
+		if index > 0 {
+			pr.b.WriteString(" ")
+		}
+		pr.b.WriteString(pdfNumber(dash))
+	}
+	pr.b.WriteString("] 0 d\n")
+}
+
+// writeAlpha sets a graphics state with the opacity if it isn't opaque.
+func (pr *pdfRenderer) writeAlpha(alpha pdfAlpha) {
+	if alpha.Stroke == 255 && alpha.Fill == 255 {
+		return
+	}
+	name, hasName := pr.alphas[alpha]
+	if !hasName {
+		name = fmt.Sprintf("GS%d", len(pr.alphas))
+		pr.alphas[alpha] = name
+	}
+	fmt.Fprintf(pr.b, "/%s gs\n", name)
+}
+
+// Circle implements the interface method; like the png renderer, it adds the circle to the path
+// without painting it.
+func (pr *pdfRenderer) Circle(radius float64, x, y int) {
+	cx, cy := float64(x), float64(y)
+	pr.moveTo(cx+radius, cy)
+	pr.arc(cx, cy, radius, radius, 0, 2*math.Pi)
+	pr.Close()
+}
+
+// SetFont implements the interface method.
+func (pr *pdfRenderer) SetFont(f *truetype.Font) {
+	pr.s.Font = f
+}
+
+// SetFontColor implements the interface method.
+func (pr *pdfRenderer) SetFontColor(c drawing.Color) {
+	pr.s.FontColor = c
+}
+
+// SetFontSize implements the interface method.
+func (pr *pdfRenderer) SetFontSize(size float64) {
+	pr.s.FontSize = size
+}
+
+// Text draws the glyph outlines of the text, with the baseline starting at x, y.
+func (pr *pdfRenderer) Text(body string, x, y int) {
+	f := pr.s.GetFont()
+	if f == nil || pr.s.FontColor.IsZero() {
+		return
+	}
+
+	var sin, cos float64 = 0, 1
+	if pr.textTheta != nil {
+		sin, cos = math.Sin(*pr.textTheta), math.Cos(*pr.textTheta)
+	}
+	// point maps a point of a glyph, in 26.6 pixels with y up from the pen, to the page.
+	point := func(pen, px, py fixed.Int26_6) (float64, float64) {
+		dx, dy := float64(pen+px)/64, -float64(py)/64
+		return float64(x) + dx*cos - dy*sin, float64(y) + dx*sin + dy*cos
+	}
+
+	scale := fixed.Int26_6(drawing.PointsToPixels(pr.dpi, pr.s.FontSize) * 64)
+	var pen fixed.Int26_6
+	previous, hasPrevious := truetype.Index(0), false
+	for _, r := range visualText(body) {
+		index := f.Index(r)
+		if hasPrevious {
+			pen += f.Kern(scale, previous, index)
+		}
+		if err := pr.glyphs.Load(f, scale, index, font.HintingNone); err == nil {
+			pr.glyphPath(pen, point)
+		}
+		pen += f.HMetric(scale, index).AdvanceWidth
+		previous, hasPrevious = index, true
+	}
+
+	style := *pr.s
+	pr.s = &Style{FillColor: style.FontColor}
+	pr.Fill()
+	pr.s = &style
+}
+
+// glyphPath adds the contours of the loaded glyph to the path; contours are quadratic curves whose
+// consecutive off curve points have an implied on curve point half way between them.
+func (pr *pdfRenderer) glyphPath(pen fixed.Int26_6, point func(pen, px, py fixed.Int26_6) (float64, float64)) {
+	start := 0
+	for _, end := range pr.glyphs.Ends {
+		contour := pr.glyphs.Points[start:end]
+		start = end
+		if len(contour) == 0 {
+			continue
+		}
+
+		// start the contour on an on curve point, or half way between two off curve points.
+		first := 0
+		for first < len(contour) && contour[first].Flags&0x01 == 0 {
+			first++
+		}
+		var sx, sy fixed.Int26_6
+		if first == len(contour) {
+			first = 0
+			sx, sy = (contour[0].X+contour[len(contour)-1].X)/2, (contour[0].Y+contour[len(contour)-1].Y)/2
+		} else {
+			sx, sy = contour[first].X, contour[first].Y
+			first++
+		}
+		pr.moveTo(point(pen, sx, sy))
+
+		var control *truetype.Point
+		for offset := 0; offset < len(contour); offset++ {
+			p := contour[(first+offset)%len(contour)]
+			if p.Flags&0x01 != 0 {
+				if control == nil {
+					pr.lineTo(point(pen, p.X, p.Y))
+				} else {
+					pr.glyphCurve(pen, point, *control, p.X, p.Y)
+					control = nil
+				}
+				continue
+			}
+			if control != nil {
+				pr.glyphCurve(pen, point, *control, (control.X+p.X)/2, (control.Y+p.Y)/2)
+			}
+			control = &truetype.Point{X: p.X, Y: p.Y}
+		}
+		if control != nil {
+			pr.glyphCurve(pen, point, *control, sx, sy)
+		} else {
+			pr.lineTo(point(pen, sx, sy))
+		}
+		pr.Close()
+	}
+}
+
+func (pr *pdfRenderer) glyphCurve(pen fixed.Int26_6, point func(pen, px, py fixed.Int26_6) (float64, float64), control truetype.Point, x, y fixed.Int26_6) {
+	cx, cy := point(pen, control.X, control.Y)
+	ex, ey := point(pen, x, y)
+	pr.quadCurveTo(cx, cy, ex, ey)
+}
+
+// MeasureText uses the truetype font drawer to measure the width of text.
+func (pr *pdfRenderer) MeasureText(body string) (box Box) {
+	if pr.s.GetFont() == nil {
+		return
+	}
+	var isCached bool
+	box, isCached = DefaultTextMeasureCache.Get(TextMeasureAdvance, pr.s.GetFont(), pr.s.FontSize, pr.dpi, body)
+	if !isCached {
+		drawer := &font.Drawer{
+			Face: truetype.NewFace(pr.s.GetFont(), &truetype.Options{
+				DPI:  pr.dpi,
+				Size: pr.s.FontSize,
+			}),
+		}
+		box.Right = drawer.MeasureString(visualText(body)).Ceil()
+		box.Bottom = int(drawing.PointsToPixels(pr.dpi, pr.s.FontSize))
+		DefaultTextMeasureCache.Put(TextMeasureAdvance, pr.s.GetFont(), pr.s.FontSize, pr.dpi, body, box)
+	}
+	if pr.textTheta == nil {
+		return
+	}
+	return box.Corners().Rotate(util.Math.RadiansToDegrees(*pr.textTheta)).Box()
+}
+
+// SetTextRotation sets the text rotation.
+func (pr *pdfRenderer) SetTextRotation(radians float64) {
+	pr.textTheta = &radians
+}
+
+// ClearTextRotation clears the text rotation.
+func (pr *pdfRenderer) ClearTextRotation() {
+	pr.textTheta = nil
+}
+
+// SetAccessibility sets the title and subject of the pdf document information.
+func (pr *pdfRenderer) SetAccessibility(a Accessibility) {
+	pr.accessibility = &a
+}
+
+// SetMetadata sets text metadata to write into the pdf document information.
+func (pr *pdfRenderer) SetMetadata(metadata map[string]string) {
+	pr.metadata = metadata
+}
+
+// Save writes the pdf document to a writer.
+func (pr *pdfRenderer) Save(w io.Writer) error {
+	scale := 72.0 / pr.dpi
+	pageWidth, pageHeight := float64(pr.width)*scale, float64(pr.height)*scale
+
+	// the content is drawn in pixels from the top left, so it is scaled to points and flipped.
+	content := bytes.NewBuffer(nil)
+	zw := zlib.NewWriter(content)
+	fmt.Fprintf(zw, "%s 0 0 %s 0 %s cm\n", pdfNumber(scale), pdfNumber(-scale), pdfNumber(pageHeight))
+	zw.Write(pr.b.Bytes())
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	pw := &pdfWriter{w: bytes.NewBuffer(nil)}
+	pw.w.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	pw.object("<< /Type /Catalog /Pages 2 0 R >>")
+	pw.object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	pw.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /ExtGState << %s>> >> /Contents 4 0 R >>",
+		pdfNumber(pageWidth), pdfNumber(pageHeight), pr.graphicsStates()))
+	pw.stream(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", content.Len()), content.Bytes())
+	pw.object(pr.documentInformation())
+	pw.end()
+
+	_, err := w.Write(pw.w.Bytes())
+	return err
+}
+
+// graphicsStates returns the graphics states with opacity, sorted by name.
+func (pr *pdfRenderer) graphicsStates() string {
+	states := make([]string, 0, len(pr.alphas))
+	for alpha, name := range pr.alphas {
+		states = append(states, fmt.Sprintf("/%s << /CA %s /ca %s >> ", name, pdfNumber(float64(alpha.Stroke)/255), pdfNumber(float64(alpha.Fill)/255)))
+	}
+	sort.Strings(states)
+	var buffer bytes.Buffer
+	for _, state := range states {
+		buffer.WriteString(state)
+	}
+	return buffer.String()
+}
+
+// documentInformation returns the document information dictionary, with the accessibility title and
+// description as the title and subject, and the metadata as custom keys.
+func (pr *pdfRenderer) documentInformation() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("<< /Producer " + pdfString("go-chart"))
+	if pr.accessibility != nil {
+		buffer.WriteString(" /Title " + pdfString(pr.accessibility.Title))
+		buffer.WriteString(" /Subject " + pdfString(pr.accessibility.Description))
+	}
+	keys := make([]string, 0, len(pr.metadata))
+	for key := range pr.metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buffer.WriteString(" " + pdfName(key) + " " + pdfString(pr.metadata[key]))
+	}
+	buffer.WriteString(" >>")
+	return buffer.String()
+}
+
+// pdfWriter writes numbered pdf objects and the cross reference table that locates them.
+type pdfWriter struct {
+	w       *bytes.Buffer
+	offsets []int
+}
+
+func (pw *pdfWriter) object(body string) {
+	pw.offsets = append(pw.offsets, pw.w.Len())
+	fmt.Fprintf(pw.w, "%d 0 obj\n%s\nendobj\n", len(pw.offsets), body)
+}
+
+func (pw *pdfWriter) stream(dictionary string, data []byte) {
+	pw.offsets = append(pw.offsets, pw.w.Len())
+	fmt.Fprintf(pw.w, "%d 0 obj\n%s\nstream\n", len(pw.offsets), dictionary)
+	pw.w.Write(data)
+	pw.w.WriteString("\nendstream\nendobj\n")
+}
+
+// end writes the cross reference table and the trailer; the last object is the document information.
+func (pw *pdfWriter) end() {
+	xref := pw.w.Len()
+	fmt.Fprintf(pw.w, "xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, offset := range pw.offsets {
+		fmt.Fprintf(pw.w, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(pw.w, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, len(pw.offsets), xref)
+}
+
+// pdfNumber formats a number with at most 3 decimals, which pdf readers don't need more than.
+func pdfNumber(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "0"
+	}
+	v = math.Round(v*1000) / 1000
+	if v == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// pdfString returns a text string as utf-16 hex, which holds any text without escaping.
+func pdfString(s string) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&buffer, "%04X", unit)
+	}
+	buffer.WriteString(">")
+	return buffer.String()
+}
+
+// pdfName returns a name, with the characters that aren't regular written as hex codes.
+func pdfName(s string) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("/")
+	for _, c := range []byte(s) {
+		if c > ' ' && c < '~' && !bytes.ContainsRune([]byte("#%()/<>[]{}"), rune(c)) {
+			buffer.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buffer, "#%02X", c)
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,165 @@
+package chart
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// pdfContent returns the decompressed content stream of a pdf written by the pdf renderer.
+func pdfContent(t *testing.T, raw []byte) string {
+	start := bytes.Index(raw, []byte("stream\n"))
+	end := bytes.Index(raw, []byte("\nendstream"))
+	if start < 0 || end < start {
+		t.Fatal("pdf has no content stream")
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(raw[start+len("stream\n") : end]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestPDFRendererPath(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+
+	r.SetStrokeColor(drawing.ColorBlack)
+	r.SetStrokeWidth(2)
+	r.SetStrokeDashArray([]float64{4, 2})
+	r.SetFillColor(ColorBlue.WithAlpha(128))
+	r.MoveTo(0, 0)
+	r.LineTo(100, 100)
+	r.LineTo(0, 100)
+	r.Close()
+	r.FillStroke()
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	raw := buffer.String()
+	assert.True(strings.HasPrefix(raw, "%PDF-1.4"))
+	assert.True(strings.HasSuffix(raw, "%%EOF\n"))
+	assert.True(strings.Contains(raw, "/GS0 << /CA 1 /ca 0.502 >>"))
+
+	content := pdfContent(t, buffer.Bytes())
+	assert.True(strings.Contains(content, "[4 2] 0 d\n"))
+	assert.True(strings.Contains(content, "2 w\n"))
+	assert.True(strings.Contains(content, "/GS0 gs\n"))
+	assert.True(strings.Contains(content, "0 0 m\n100 100 l\n0 100 l\nh\nB\n"))
+}
+
+func TestPDFRendererCurvesAndPolygons(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+
+	r.SetStrokeColor(drawing.ColorBlack)
+	r.SetStrokeWidth(1)
+	r.SetFillColor(drawing.ColorBlack)
+	r.MoveTo(0, 50)
+	r.CubicCurveTo(10, 0, 40, 0, 50, 50)
+	r.Stroke()
+	r.Polygon([]Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}})
+	r.Fill()
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	content := pdfContent(t, buffer.Bytes())
+	assert.True(strings.Contains(content, "0 50 m\n10 0 40 0 50 50 c\n"), content)
+	assert.True(strings.Contains(content, "0 0 m\n10 0 l\n10 10 l\nh\n"), content)
+}
+
+func TestPDFRendererSkipsUnpaintedPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+
+	r.SetFillColor(ColorBlue)
+	r.MoveTo(0, 0)
+	r.LineTo(100, 100)
+	r.Stroke()
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	assert.False(strings.Contains(pdfContent(t, buffer.Bytes()), " m\n"))
+}
+
+func TestPDFRendererCrossReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(PieChart{Values: []Value{{Value: 2, Label: "a"}, {Value: 3, Label: "b"}}}.Render(PDF, buffer))
+	raw := buffer.String()
+
+	xref := strings.LastIndex(raw, "startxref\n")
+	offset, err := strconv.Atoi(strings.Fields(raw[xref+len("startxref\n"):])[0])
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(raw[offset:], "xref\n0 6\n"))
+
+	entries := strings.Split(raw[offset:], "\n")[3:8]
+	for index, entry := range entries {
+		objectOffset, err := strconv.Atoi(entry[:10])
+		assert.Nil(err)
+		assert.True(strings.HasPrefix(raw[objectOffset:], strconv.Itoa(index+1)+" 0 obj\n"))
+	}
+}
+
+func TestPDFRendererText(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+	vr, err := SVG(100, 100)
+	assert.Nil(err)
+	for _, renderer := range []Renderer{r, vr} {
+		renderer.SetFont(f)
+		renderer.SetFontSize(12)
+		renderer.SetFontColor(drawing.ColorBlack)
+	}
+	assert.Equal(vr.MeasureText("Hello World"), r.MeasureText("Hello World"))
+
+	r.Text("Hi", 10, 50)
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	content := pdfContent(t, buffer.Bytes())
+	assert.True(strings.Contains(content, " c\n"))
+	assert.True(strings.Contains(content, "f\n"))
+}
+
+func TestPDFRendererDocumentInformation(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	c := Chart{
+		Title:    "Hi",
+		Metadata: map[string]string{"key": "A"},
+		Series:   []Series{ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}}},
+	}
+	assert.Nil(c.Render(PDF, buffer))
+	assert.True(strings.Contains(buffer.String(), "/Title <FEFF00480069>"))
+	assert.True(strings.Contains(buffer.String(), "/key <FEFF0041>"))
+}
+
+func TestPDFName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("/go-chart:spec", pdfName("go-chart:spec"))
+	assert.Equal("/a#20b#2F", pdfName("a b/"))
+}
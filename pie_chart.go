@@ -1,8 +1,6 @@
 package chart
 
 import (
-	"errors"
-	"fmt"
 	"io"
 	"math"
 
@@ -20,18 +18,67 @@ type PieChart struct {
 	Title      string
 	TitleStyle Style
 
+	// Subtitle is drawn under the title; once it is set, the title and subtitle are laid out above the pie
+	// rather than drawn over it. Footer and Source are laid out below the pie, left and right aligned.
+	Subtitle      string
+	SubtitleStyle Style
+	Footer        string
+	FooterStyle   Style
+	Source        string
+	SourceStyle   Style
+
 	ColorPalette ColorPalette
 
 	Width  int
 	Height int
 	DPI    float64
 
+	// ScaleFactor renders raster output at a multiple of the width and height, with fonts, strokes and
+	// positions scaled to match, so a 2x chart for Retina displays looks like the 1x chart; it defaults to 1.
+	ScaleFactor float64
+
 	Background Style
 	Canvas     Style
 	SliceStyle Style
 
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
 	Font        *truetype.Font
 	defaultFont *truetype.Font
+	// contentBox is the chart box less the space taken by the title blocks; it is set while rendering.
+	contentBox Box
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// LabelFormatter formats the slice labels, i.e. `PieLabelPercent`; by default slices are labeled with `Value.Label`.
+	LabelFormatter PieLabelFormatter
+
+	// OtherThreshold groups the slices with a share of the pie below it, from 0 to 1, into a single slice
+	// labeled `OtherLabel` (by default "Other") and styled with `OtherStyle`.
+	OtherThreshold float64
+	OtherLabel     string
+	OtherStyle     Style
+
+	// LabelPlacement is where the slice labels are drawn; by default they are drawn inside the slices.
+	LabelPlacement PieLabelPlacement
+
+	// InnerRadius draws the chart as a donut, with a hole in the middle of this share of the radius, from 0 to 1.
+	InnerRadius float64
+	// Explode moves slices out from the center by a share of the radius, by index of `Values`, i.e. to highlight them.
+	// The pie is shrunk to keep the moved slices within the canvas; the slice of grouped small slices is not moved.
+	Explode []float64
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
 
 	Values   []Value
 	Elements []Renderable
@@ -48,6 +95,14 @@ func (pc PieChart) GetDPI(defaults ...float64) float64 {
 	return pc.DPI
 }
 
+// GetScaleFactor returns the device scale factor, or a default.
+func (pc PieChart) GetScaleFactor() float64 {
+	if pc.ScaleFactor == 0 {
+		return 1
+	}
+	return pc.ScaleFactor
+}
+
 // GetFont returns the text font.
 func (pc PieChart) GetFont() *truetype.Font {
 	if pc.Font == nil {
@@ -73,41 +128,59 @@ func (pc PieChart) GetHeight() int {
 }
 
 // Render renders the chart with the given renderer to the given io.Writer.
-func (pc PieChart) Render(rp RendererProvider, w io.Writer) error {
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (pc PieChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
 	if len(pc.Values) == 0 {
-		return errors.New("please provide at least one value")
+		return newValidationError(ErrEmptySeries, "please provide at least one value")
 	}
 
 	r, err := rp(pc.GetWidth(), pc.GetHeight())
 	if err != nil {
 		return err
 	}
+	if err := applyScaleFactor(r, pc.GetScaleFactor()); err != nil {
+		return err
+	}
 
-	if pc.Font == nil {
-		defaultFont, err := GetDefaultFont()
+	if pc.NoText {
+		r = textFreeRenderer{r}
+	} else if pc.Font == nil {
+		defaultFont, err := loadDefaultFont()
 		if err != nil {
 			return err
 		}
 		pc.defaultFont = defaultFont
 	}
 	r.SetDPI(pc.GetDPI(DefaultDPI))
+	pc.contentBox = pc.getTitleBlocks().adjust(r, pc.Box())
 
 	canvasBox := pc.getDefaultCanvasBox()
 	canvasBox = pc.getCircleAdjustedCanvasBox(canvasBox)
 
 	pc.drawBackground(r)
+	pc.BackgroundImage.draw(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()})
 	pc.drawCanvas(r, canvasBox)
+	pc.Watermark.draw(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()}, pc.GetFont(), pc.GetColorPalette(), false)
+	cl := ChartLayout{Canvas: canvasBox}
+	callRenderHook(pc.BeforeRender, r, cl)
 
 	finalValues, err := pc.finalizeValues(pc.Values)
 	if err != nil {
 		return err
 	}
-	pc.drawSlices(r, canvasBox, finalValues)
+	pc.drawSlices(r, canvasBox, finalValues, pc.getExplodes(pc.Values))
 	pc.drawTitle(r)
 	for _, a := range pc.Elements {
 		a(r, canvasBox, pc.styleDefaultsElements())
 	}
+	pc.Watermark.draw(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()}, pc.GetFont(), pc.GetColorPalette(), true)
+	callRenderHook(pc.AfterRender, r, cl)
 
+	setAccessibility(r, pc.Accessibility.withDefaults(pc.Title, func() string {
+		return describeValues("Pie chart", pc.Values)
+	}))
 	return r.Save(w)
 }
 
@@ -123,16 +196,40 @@ func (pc PieChart) drawCanvas(r Renderer, canvasBox Box) {
 }
 
 func (pc PieChart) drawTitle(r Renderer) {
+	if blocks := pc.getTitleBlocks(); blocks.hasHeader() || len(blocks.footer()) > 0 {
+		blocks.draw(r, pc.Box())
+		if blocks.hasHeader() {
+			return
+		}
+	}
 	if len(pc.Title) > 0 && pc.TitleStyle.Show {
 		Draw.TextWithin(r, pc.Title, pc.Box(), pc.styleDefaultsTitle())
 	}
 }
 
-func (pc PieChart) drawSlices(r Renderer, canvasBox Box, values []Value) {
+func (pc PieChart) drawSlices(r Renderer, canvasBox Box, values []Value, explodes []float64) {
 	cx, cy := canvasBox.Center()
 	diameter := util.Math.MinInt(canvasBox.Width(), canvasBox.Height())
 	radius := float64(diameter >> 1)
-	labelRadius := (radius * 2.0) / 3.0
+
+	// labels that don't fit inside their slices are drawn outside.
+	inside, outside := values, []Value(nil)
+	switch pc.LabelPlacement {
+	case PieLabelsOutside:
+		inside, outside = nil, values
+		radius = pc.getOutsideLabelRadius(r, canvasBox, values)
+	case PieLabelsAuto:
+		for pass := 0; pass < 2; pass++ {
+			inside, outside = pc.splitPieLabels(r, radius, values, explodes)
+			if outside == nil {
+				break
+			}
+			radius = pc.getOutsideLabelRadius(r, canvasBox, outside)
+		}
+	}
+	radius = radius / (1 + maxExplode(explodes))
+	inner := radius * pc.GetInnerRadius()
+	labelRadius := inner + ((radius-inner)*2.0)/3.0
 
 	// draw the pie slices
 	var rads, delta, delta2, total float64
@@ -140,26 +237,32 @@ func (pc PieChart) drawSlices(r Renderer, canvasBox Box, values []Value) {
 	for index, v := range values {
 		v.Style.InheritFrom(pc.stylePieChartValue(index)).WriteToRenderer(r)
 
-		r.MoveTo(cx, cy)
 		rads = util.Math.PercentToRadians(total)
 		delta = util.Math.PercentToRadians(v.Value)
-
-		r.ArcTo(cx, cy, radius, radius, rads, delta)
-
-		r.LineTo(cx, cy)
-		r.Close()
+		// exploded slices move out along the middle of the slice.
+		sx, sy := util.Math.CirclePoint(cx, cy, explodes[index]*radius, util.Math.RadianAdd(rads+delta/2.0, _pi2))
+
+		setTooltip(r, Tooltip{
+			Title: tooltipTitle(v.Label, PercentValueFormatter(v.Value)),
+			X:     v.Label,
+			Y:     formatTooltipValue(v.Value),
+		})
+		drawPieSlice(r, sx, sy, radius, inner, rads, delta)
 		r.FillStroke()
 		total = total + v.Value
 	}
 
 	// draw the labels
+	if outside != nil {
+		pc.drawOutsideLabels(r, cx, cy, radius, outside, explodes)
+	}
 	total = 0
-	for index, v := range values {
+	for index, v := range inside {
 		v.Style.InheritFrom(pc.stylePieChartValue(index)).WriteToRenderer(r)
 		if len(v.Label) > 0 {
 			delta2 = util.Math.PercentToRadians(total + (v.Value / 2.0))
 			delta2 = util.Math.RadianAdd(delta2, _pi2)
-			lx, ly = util.Math.CirclePoint(cx, cy, labelRadius, delta2)
+			lx, ly = util.Math.CirclePoint(cx, cy, labelRadius+explodes[index]*radius, delta2)
 
 			tb := r.MeasureText(v.Label)
 			lx = lx - (tb.Width() >> 1)
@@ -171,16 +274,100 @@ func (pc PieChart) drawSlices(r Renderer, canvasBox Box, values []Value) {
 	}
 }
 
+// drawPieSlice adds the path of a slice from `start` through `delta` radians to the renderer;
+// with an inner radius the slice is a segment of a ring.
+func drawPieSlice(r Renderer, cx, cy int, radius, inner, start, delta float64) {
+	if inner <= 0 {
+		r.MoveTo(cx, cy)
+		r.ArcTo(cx, cy, radius, radius, start, delta)
+		r.LineTo(cx, cy)
+		r.Close()
+		return
+	}
+
+	// the outer arc is drawn in pieces of at most half a circle, which svg arcs need.
+	for remaining, angle := delta, start; remaining > 0; {
+		piece := math.Min(remaining, math.Pi)
+		r.ArcTo(cx, cy, radius, radius, angle, piece)
+		angle, remaining = angle+piece, remaining-piece
+	}
+	// the inner arc runs back to the start, as straight segments of a few pixels.
+	segments := util.Math.MaxInt(2, int(math.Ceil(delta*inner/3)))
+	for segment := 0; segment <= segments; segment++ {
+		angle := start + delta - delta*float64(segment)/float64(segments)
+		r.LineTo(cx+int(math.Floor(inner*math.Cos(angle)+0.5)), cy+int(math.Floor(inner*math.Sin(angle)+0.5)))
+	}
+	r.Close()
+}
+
+// GetInnerRadius returns the share of the radius of the hole of a donut, or zero for a pie.
+func (pc PieChart) GetInnerRadius() float64 {
+	if pc.InnerRadius <= 0 || pc.InnerRadius >= 1 {
+		return 0
+	}
+	return pc.InnerRadius
+}
+
+// getExplodes returns how far each slice drawn is moved out, following the values through the grouping of
+// small slices and the dropping of empty ones.
+func (pc PieChart) getExplodes(values []Value) []float64 {
+	grouped := pc.getGroupedIndexes(values)
+	explodes := make([]float64, 0, len(values))
+	for index, v := range values {
+		if v.Value <= 0 || grouped[index] {
+			continue
+		}
+		var explode float64
+		if index < len(pc.Explode) && pc.Explode[index] > 0 {
+			explode = pc.Explode[index]
+		}
+		explodes = append(explodes, explode)
+	}
+	if len(grouped) > 0 {
+		explodes = append(explodes, 0)
+	}
+	return explodes
+}
+
+func maxExplode(explodes []float64) (max float64) {
+	for _, explode := range explodes {
+		max = math.Max(max, explode)
+	}
+	return
+}
 func (pc PieChart) finalizeValues(values []Value) ([]Value, error) {
+	values = pc.formatLabels(pc.groupOtherValues(values))
 	finalValues := Values(values).Normalize()
 	if len(finalValues) == 0 {
-		return nil, fmt.Errorf("pie chart must contain at least (1) non-zero value")
+		return nil, newValidationError(ErrInvalidRange, "pie chart must contain at least (1) non-zero value")
 	}
 	return finalValues, nil
 }
 
 func (pc PieChart) getDefaultCanvasBox() Box {
-	return pc.Box()
+	return pc.getContentBox()
+}
+
+// getContentBox returns the box the pie and its labels are laid out in, the chart box less the space taken
+// by the title blocks.
+func (pc PieChart) getContentBox() Box {
+	if pc.contentBox.IsZero() {
+		return pc.Box()
+	}
+	return pc.contentBox
+}
+
+// getTitleBlocks returns the title, subtitle, footer and source of the chart with their styles.
+func (pc PieChart) getTitleBlocks() titleBlocks {
+	subtitle, footer, source := styleDefaultsTitleBlocks(Style{Font: pc.GetFont(), FontColor: pc.GetColorPalette().TextColor()})
+	title := pc.styleDefaultsTitle()
+	title.Show = pc.TitleStyle.Show
+	return titleBlocks{
+		Title:    titleBlock{Text: pc.Title, Style: title},
+		Subtitle: titleBlock{Text: pc.Subtitle, Style: pc.SubtitleStyle.InheritFrom(subtitle)},
+		Footer:   titleBlock{Text: pc.Footer, Style: pc.FooterStyle.InheritFrom(footer)},
+		Source:   titleBlock{Text: pc.Source, Style: pc.SourceStyle.InheritFrom(source)},
+	}
 }
 
 func (pc PieChart) getCircleAdjustedCanvasBox(canvasBox Box) Box {
@@ -222,7 +409,7 @@ func (pc PieChart) stylePieChartValue(index int) Style {
 	return pc.SliceStyle.InheritFrom(Style{
 		StrokeColor: ColorWhite,
 		StrokeWidth: 5.0,
-		FillColor:   pc.GetColorPalette().GetSeriesColor(index),
+		FillColor:   getSeriesColor(pc.GetColorPalette(), index, len(pc.Values)),
 		FontSize:    pc.getScaledFontSize(),
 		FontColor:   pc.GetColorPalette().TextColor(),
 		Font:        pc.GetFont(),
@@ -0,0 +1,110 @@
+package chart
+
+// PieLabelFormatter returns the label drawn for a slice from its value and its share of the pie, from 0 to 1.
+type PieLabelFormatter func(v Value, share float64) string
+
+// DefaultPieOtherLabel is the label of the slice that groups the small slices of a pie.
+const DefaultPieOtherLabel = "Other"
+
+var (
+	_pieValueFormat   = NumberFormat{Decimals: 2, TrimZeros: true}
+	_piePercentFormat = NumberFormat{Decimals: 1, TrimZeros: true}
+)
+
+// PieLabelValue labels slices with their value, i.e. `Blue: 1,234`.
+func PieLabelValue(v Value, share float64) string {
+	return pieLabelText(v.Label, _pieValueFormat.Format(v.Value))
+}
+
+// PieLabelPercent labels slices with their share of the pie, i.e. `Blue: 42%`.
+func PieLabelPercent(v Value, share float64) string {
+	return pieLabelText(v.Label, _piePercentFormat.Format(share*100)+"%")
+}
+
+// PieLabelValueAndPercent labels slices with their value and share of the pie, i.e. `Blue: 1,234 (42%)`.
+func PieLabelValueAndPercent(v Value, share float64) string {
+	return pieLabelText(v.Label, _pieValueFormat.Format(v.Value)+" ("+_piePercentFormat.Format(share*100)+"%)")
+}
+
+func pieLabelText(name, value string) string {
+	if len(name) == 0 {
+		return value
+	}
+	return name + ": " + value
+}
+
+// GetOtherLabel returns the label of the slice that groups small slices or a default.
+func (pc PieChart) GetOtherLabel() string {
+	if len(pc.OtherLabel) == 0 {
+		return DefaultPieOtherLabel
+	}
+	return pc.OtherLabel
+}
+
+// groupOtherValues replaces the slices with a share of the pie below `OtherThreshold` with a single slice,
+// added after the other slices. Nothing is grouped if fewer than two slices are below the threshold.
+func (pc PieChart) groupOtherValues(values []Value) []Value {
+	grouped := pc.getGroupedIndexes(values)
+	if len(grouped) == 0 {
+		return values
+	}
+
+	output := make([]Value, 0, len(values)-len(grouped)+1)
+	other := Value{Label: pc.GetOtherLabel(), Style: pc.OtherStyle}
+	for index, v := range values {
+		if grouped[index] {
+			other.Value += v.Value
+			continue
+		}
+		output = append(output, v)
+	}
+	return append(output, other)
+}
+
+// getGroupedIndexes returns the indexes of the values grouped into the slice of small slices, if any are.
+func (pc PieChart) getGroupedIndexes(values []Value) map[int]bool {
+	if pc.OtherThreshold <= 0 {
+		return nil
+	}
+	total := pieTotal(values)
+	if total == 0 {
+		return nil
+	}
+
+	grouped := map[int]bool{}
+	for index, v := range values {
+		if v.Value > 0 && v.Value/total < pc.OtherThreshold {
+			grouped[index] = true
+		}
+	}
+	if len(grouped) < 2 {
+		return nil
+	}
+	return grouped
+}
+
+// formatLabels replaces the labels of the slices with the output of the label formatter.
+func (pc PieChart) formatLabels(values []Value) []Value {
+	if pc.LabelFormatter == nil {
+		return values
+	}
+	total := pieTotal(values)
+	output := make([]Value, len(values))
+	for index, v := range values {
+		output[index] = v
+		if v.Value > 0 {
+			output[index].Label = pc.LabelFormatter(v, v.Value/total)
+		}
+	}
+	return output
+}
+
+// pieTotal returns the sum of the values drawn as slices.
+func pieTotal(values []Value) (total float64) {
+	for _, v := range values {
+		if v.Value > 0 {
+			total += v.Value
+		}
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestPieLabelFormatters(t *testing.T) {
+	assert := assert.New(t)
+
+	v := Value{Label: "Blue", Value: 1234}
+	assert.Equal("Blue: 1,234", PieLabelValue(v, 0.42))
+	assert.Equal("Blue: 42%", PieLabelPercent(v, 0.42))
+	assert.Equal("Blue: 1,234 (42.5%)", PieLabelValueAndPercent(v, 0.425))
+	assert.Equal("42%", PieLabelPercent(Value{Value: 1}, 0.42))
+}
+
+func TestPieChartGroupOtherValues(t *testing.T) {
+	assert := assert.New(t)
+
+	pc := PieChart{OtherThreshold: 0.05}
+	values := pc.groupOtherValues([]Value{
+		{Label: "A", Value: 50},
+		{Label: "B", Value: 2},
+		{Label: "C", Value: 45},
+		{Label: "D", Value: 3},
+	})
+	assert.Len(values, 3)
+	assert.Equal("A", values[0].Label)
+	assert.Equal("C", values[1].Label)
+	assert.Equal(DefaultPieOtherLabel, values[2].Label)
+	assert.Equal(5.0, values[2].Value)
+
+	// a single small slice is kept as it is.
+	values = pc.groupOtherValues([]Value{{Label: "A", Value: 98}, {Label: "B", Value: 2}})
+	assert.Len(values, 2)
+	assert.Equal("B", values[1].Label)
+
+	pc.OtherLabel = "Rest"
+	values = pc.groupOtherValues([]Value{{Value: 96}, {Value: 2}, {Value: 2}})
+	assert.Equal("Rest", values[1].Label)
+}
+
+func TestPieChartFormatLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	pc := PieChart{LabelFormatter: PieLabelPercent, OtherThreshold: 0.05}
+	values, err := pc.finalizeValues([]Value{
+		{Label: "A", Value: 75},
+		{Label: "B", Value: 21},
+		{Label: "C", Value: 2},
+		{Label: "D", Value: 2},
+	})
+	assert.Nil(err)
+	assert.Len(values, 3)
+	assert.Equal("A: 75%", values[0].Label)
+	assert.Equal("B: 21%", values[1].Label)
+	assert.Equal("Other: 4%", values[2].Label)
+
+	b := bytes.NewBuffer([]byte{})
+	pc.Values = []Value{{Label: "A", Value: 75}, {Label: "B", Value: 25}}
+	assert.Nil(pc.Render(PNG, b))
+}
@@ -0,0 +1,232 @@
+package chart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// PieLabelPlacement is where a pie chart draws the slice labels.
+type PieLabelPlacement int
+
+const (
+	// PieLabelsInside draws the labels inside the slices; it is the default.
+	PieLabelsInside PieLabelPlacement = iota
+	// PieLabelsOutside draws the labels outside the circle, connected to their slices by leader lines.
+	// The circle is shrunk to make room for the labels, and labels on the same side are moved apart so they don't overlap.
+	PieLabelsOutside
+	// PieLabelsAuto draws the labels inside the slices, except for the labels of slices too thin to fit them,
+	// which are drawn outside like `PieLabelsOutside`.
+	PieLabelsAuto
+)
+
+// pieLabel is the layout of a label outside the pie.
+type pieLabel struct {
+	Text  string
+	Index int
+	Right bool
+
+	// Anchor is on the edge of the slice, Elbow is where the leader line bends and End is next to the label.
+	Anchor Point
+	Elbow  Point
+	End    Point
+
+	// Box is the box of the label text.
+	Box Box
+}
+
+// getOutsideLabelRadius returns the radius that leaves room for outside labels on both sides of the circle.
+func (pc PieChart) getOutsideLabelRadius(r Renderer, canvasBox Box, values []Value) float64 {
+	cx, cy := canvasBox.Center()
+	radius := float64(util.Math.MinInt(canvasBox.Width(), canvasBox.Height()) >> 1)
+
+	var labelWidth, labelHeight int
+	for index, v := range values {
+		if len(v.Label) == 0 {
+			continue
+		}
+		v.Style.InheritFrom(pc.stylePieChartValue(index)).WriteToRenderer(r)
+		tb := r.MeasureText(v.Label)
+		labelWidth = util.Math.MaxInt(labelWidth, tb.Width())
+		labelHeight = util.Math.MaxInt(labelHeight, tb.Height())
+	}
+
+	bounds := pc.getContentBox()
+	halfWidth := util.Math.MinInt(cx-bounds.Left, bounds.Right-cx)
+	halfHeight := util.Math.MinInt(cy-bounds.Top, bounds.Bottom-cy)
+	horizontal := float64(halfWidth - labelWidth - DefaultPieLeaderLength - DefaultPieLeaderTail - DefaultLineSpacing)
+	vertical := float64(halfHeight - DefaultPieLeaderLength - labelHeight)
+
+	// keep the pie legible even if the labels end up clipped.
+	return math.Max(radius/2.0, math.Min(radius, math.Min(horizontal, vertical)))
+}
+
+// splitPieLabels splits the labels of the slices into those that fit inside their slices and those that don't;
+// each is a copy of the values with the labels of the other left out, or nil if there are none.
+func (pc PieChart) splitPieLabels(r Renderer, radius float64, values []Value, explodes []float64) (inside, outside []Value) {
+	radius = radius / (1 + maxExplode(explodes))
+	inner := radius * pc.GetInnerRadius()
+	labelRadius := inner + ((radius-inner)*2.0)/3.0
+
+	inside, outside = make([]Value, len(values)), make([]Value, len(values))
+	var outsideCount int
+	var total float64
+	for index, v := range values {
+		inside[index], outside[index] = v, v
+		start := total
+		total += v.Value
+		if len(v.Label) == 0 {
+			continue
+		}
+		v.Style.InheritFrom(pc.stylePieChartValue(index)).WriteToRenderer(r)
+		tb := r.MeasureText(v.Label)
+		if pieLabelFits(tb, start, v.Value, radius, inner, labelRadius) {
+			outside[index].Label = ""
+		} else {
+			inside[index].Label = ""
+			outsideCount++
+		}
+	}
+	if outsideCount == 0 {
+		return values, nil
+	}
+	return inside, outside
+}
+
+// pieLabelFits returns if a label centered at `labelRadius` in the middle of a slice fits inside it:
+// the corners of the label must be within the ring and between the sides of the slice.
+// The slice starts at a share of the pie and spans another.
+func pieLabelFits(tb Box, start, share, radius, inner, labelRadius float64) bool {
+	startAngle, delta := util.Math.PercentToRadians(start), util.Math.PercentToRadians(share)
+	middle := startAngle + delta/2
+	lx, ly := labelRadius*math.Cos(middle), labelRadius*math.Sin(middle)
+	halfWidth, halfHeight := float64(tb.Width())/2, float64(tb.Height())/2
+	for _, corner := range [][2]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+		x, y := lx+corner[0]*halfWidth, ly+corner[1]*halfHeight
+		if distance := math.Hypot(x, y); distance > radius || distance < inner {
+			return false
+		}
+		if angle := math.Mod(math.Atan2(y, x)-startAngle+4*math.Pi, 2*math.Pi); delta < 2*math.Pi && angle > delta {
+			return false
+		}
+	}
+	return true
+}
+
+// layoutPieOutsideLabels places the labels of the slices outside a circle, within `bounds`.
+// Labels are placed next to the middle of their slice on the side of the circle the slice is on,
+// then labels on each side are moved apart vertically until they don't overlap.
+// Slices moved out from the center by `explodes`, a share of the radius for each slice, have their leader lines moved out too.
+func layoutPieOutsideLabels(cx, cy int, radius float64, bounds Box, values []Value, explodes []float64, measure func(index int, label string) Box) []pieLabel {
+	var left, right []pieLabel
+	var total float64
+	for index, v := range values {
+		if len(v.Label) > 0 {
+			var offset float64
+			if index < len(explodes) {
+				offset = explodes[index] * radius
+			}
+			angle := util.Math.RadianAdd(util.Math.PercentToRadians(total+(v.Value/2.0)), _pi2)
+			ax, ay := util.Math.CirclePoint(cx, cy, radius+offset, angle)
+			ex, ey := util.Math.CirclePoint(cx, cy, radius+offset+DefaultPieLeaderLength, angle)
+
+			label := pieLabel{
+				Text:   v.Label,
+				Index:  index,
+				Right:  ex >= cx,
+				Anchor: Point{X: ax, Y: ay},
+				Elbow:  Point{X: ex, Y: ey},
+			}
+			tb := measure(index, v.Label)
+			label.Box = Box{Top: ey - (tb.Height() >> 1), Bottom: ey - (tb.Height() >> 1) + tb.Height(), Right: tb.Width()}
+			if label.Right {
+				right = append(right, label)
+			} else {
+				left = append(left, label)
+			}
+		}
+		total = total + v.Value
+	}
+
+	labels := make([]pieLabel, 0, len(left)+len(right))
+	for _, side := range [][]pieLabel{right, left} {
+		resolvePieLabelOverlaps(side, bounds)
+		for _, label := range side {
+			width := label.Box.Right
+			centerY := label.Box.Top + (label.Box.Height() >> 1)
+			if label.Right {
+				label.End = Point{X: label.Elbow.X + DefaultPieLeaderTail, Y: centerY}
+				label.Box.Left = label.End.X + DefaultLineSpacing
+			} else {
+				label.End = Point{X: label.Elbow.X - DefaultPieLeaderTail, Y: centerY}
+				label.Box.Left = label.End.X - DefaultLineSpacing - width
+			}
+			label.Box.Right = label.Box.Left + width
+			labels = append(labels, label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Index < labels[j].Index })
+	return labels
+}
+
+// resolvePieLabelOverlaps moves the labels on one side of a pie apart vertically, keeping them within `bounds` if they fit.
+func resolvePieLabelOverlaps(labels []pieLabel, bounds Box) {
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].Box.Top < labels[j].Box.Top })
+
+	move := func(label *pieLabel, top int) {
+		height := label.Box.Height()
+		label.Box.Top, label.Box.Bottom = top, top+height
+	}
+
+	// push labels down below the label above them ...
+	for index := 1; index < len(labels); index++ {
+		if minimum := labels[index-1].Box.Bottom + DefaultLineSpacing; labels[index].Box.Top < minimum {
+			move(&labels[index], minimum)
+		}
+	}
+	// ... then back up above the label below them if that pushed them out of bounds.
+	if last := len(labels) - 1; last >= 0 && labels[last].Box.Bottom > bounds.Bottom {
+		move(&labels[last], bounds.Bottom-labels[last].Box.Height())
+		for index := last - 1; index >= 0; index-- {
+			if maximum := labels[index+1].Box.Top - DefaultLineSpacing; labels[index].Box.Bottom > maximum {
+				move(&labels[index], maximum-labels[index].Box.Height())
+			}
+		}
+	}
+	// labels that don't fit at all overflow at the bottom rather than the top.
+	for index := range labels {
+		minimum := bounds.Top
+		if index > 0 {
+			minimum = labels[index-1].Box.Bottom + DefaultLineSpacing
+		}
+		if labels[index].Box.Top < minimum {
+			move(&labels[index], minimum)
+		}
+	}
+}
+
+// drawOutsideLabels draws the labels outside the circle with their leader lines.
+func (pc PieChart) drawOutsideLabels(r Renderer, cx, cy int, radius float64, values []Value, explodes []float64) {
+	labels := layoutPieOutsideLabels(cx, cy, radius, pc.getContentBox(), values, explodes, func(index int, label string) Box {
+		values[index].Style.InheritFrom(pc.stylePieChartValue(index)).WriteToRenderer(r)
+		return r.MeasureText(label)
+	})
+
+	for _, label := range labels {
+		style := values[label.Index].Style.InheritFrom(pc.stylePieChartValue(label.Index))
+
+		leader := Style{
+			StrokeColor: style.GetFontColor(),
+			StrokeWidth: DefaultAxisLineWidth,
+		}
+		leader.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		r.MoveTo(label.Anchor.X, label.Anchor.Y)
+		r.LineTo(label.Elbow.X, label.Elbow.Y)
+		r.LineTo(label.End.X, label.End.Y)
+		r.Stroke()
+
+		style.GetTextOptions().WriteToRenderer(r)
+		r.Text(label.Text, label.Box.Left, label.Box.Bottom)
+	}
+}
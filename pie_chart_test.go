@@ -67,3 +67,122 @@ func TestPieChartAllZeroValues(t *testing.T) {
 	err := pie.Render(PNG, b)
 	assert.NotNil(err)
 }
+
+func TestPieChartOutsideLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	pie := PieChart{
+		LabelPlacement: PieLabelsOutside,
+		Values: []Value{
+			{Value: 80, Label: "Large"},
+			{Value: 1, Label: "Tiny A"},
+			{Value: 1, Label: "Tiny B"},
+			{Value: 1, Label: "Tiny C"},
+			{Value: 17, Label: "Medium"},
+		},
+	}
+
+	b := bytes.NewBuffer([]byte{})
+	assert.Nil(pie.Render(PNG, b))
+	assert.NotZero(b.Len())
+}
+
+func TestLayoutPieOutsideLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	values := Values([]Value{
+		{Value: 80, Label: "Large"},
+		{Value: 1, Label: "Tiny A"},
+		{Value: 1, Label: "Tiny B"},
+		{Value: 1, Label: "Tiny C"},
+		{Value: 17},
+	}).Normalize()
+	bounds := Box{Top: 0, Left: 0, Right: 400, Bottom: 400}
+	labels := layoutPieOutsideLabels(200, 200, 100, bounds, values, nil, func(index int, label string) Box {
+		return Box{Right: 40, Bottom: 10}
+	})
+	assert.Len(labels, 4)
+
+	for index, label := range labels {
+		assert.Equal(10, label.Box.Height())
+		assert.Equal(40, label.Box.Width())
+		assert.True(label.Box.Top >= bounds.Top)
+		assert.True(label.Box.Bottom <= bounds.Bottom)
+		if label.Right {
+			assert.True(label.Box.Left > label.End.X)
+		} else {
+			assert.True(label.Box.Right < label.End.X)
+		}
+		for _, other := range labels[index+1:] {
+			if other.Right == label.Right {
+				assert.False(label.Box.Top < other.Box.Bottom && other.Box.Top < label.Box.Bottom, "labels overlap")
+			}
+		}
+	}
+}
+
+func TestResolvePieLabelOverlapsStaysInBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	labels := []pieLabel{
+		{Box: Box{Top: 85, Bottom: 95}},
+		{Box: Box{Top: 88, Bottom: 98}},
+		{Box: Box{Top: 90, Bottom: 100}},
+	}
+	resolvePieLabelOverlaps(labels, Box{Top: 0, Bottom: 100})
+	assert.Equal(100, labels[2].Box.Bottom)
+	assert.Equal(labels[2].Box.Top-DefaultLineSpacing, labels[1].Box.Bottom)
+	assert.Equal(labels[1].Box.Top-DefaultLineSpacing, labels[0].Box.Bottom)
+}
+
+func TestPieChartDonutAndExplode(t *testing.T) {
+	assert := assert.New(t)
+
+	pie := PieChart{
+		Width:          400,
+		Height:         400,
+		InnerRadius:    0.5,
+		Explode:        []float64{0.1, 0, 0.2},
+		LabelPlacement: PieLabelsAuto,
+		Values: []Value{
+			{Value: 60, Label: "Blue"},
+			{Value: 37, Label: "Green"},
+			{Value: 2, Label: "Gray"},
+			{Value: 1, Label: "Orange"},
+		},
+	}
+	assert.Equal(0.5, pie.GetInnerRadius())
+	assert.Equal([]float64{0.1, 0, 0.2, 0}, pie.getExplodes(pie.Values))
+
+	r, err := PNG(400, 400)
+	assert.Nil(err)
+	pie.drawSlices(r, pie.getCircleAdjustedCanvasBox(pie.Box()), Values(pie.Values).Normalize(), pie.getExplodes(pie.Values))
+	// the middle of the donut is left empty.
+	assert.Equal(uint8(0), r.(*rasterRenderer).i.RGBAAt(200, 200).A)
+
+	b := bytes.NewBuffer([]byte{})
+	assert.Nil(pie.Render(PNG, b))
+	assert.NotZero(b.Len())
+	b.Reset()
+	assert.Nil(pie.Render(SVG, b))
+	assert.NotZero(b.Len())
+}
+
+func TestPieChartExplodeGrouped(t *testing.T) {
+	assert := assert.New(t)
+
+	// grouped and empty values have no slice of their own to move.
+	pie := PieChart{OtherThreshold: 0.05, Explode: []float64{0, 0.3, 0, 0.5, 0.1}}
+	values := []Value{{Value: 50}, {Value: 2}, {Value: 0}, {Value: 45}, {Value: 3}}
+	assert.Equal([]float64{0, 0.5, 0}, pie.getExplodes(values))
+}
+
+func TestPieLabelFits(t *testing.T) {
+	assert := assert.New(t)
+
+	label := Box{Right: 40, Bottom: 10}
+	assert.True(pieLabelFits(label, 0, 0.5, 100, 0, 66))
+	// a thin slice is too narrow, and a thin ring too shallow.
+	assert.False(pieLabelFits(label, 0, 0.02, 100, 0, 66))
+	assert.False(pieLabelFits(label, 0, 0.5, 100, 90, 96))
+}
@@ -0,0 +1,564 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// PolarSeriesKind is how a polar series is drawn.
+type PolarSeriesKind int
+
+const (
+	// PolarSeriesKindLine joins the points of the series with lines.
+	PolarSeriesKindLine PolarSeriesKind = 0
+	// PolarSeriesKindSectors draws each value as a filled sector centered on its angle, as in a rose or wind rose.
+	PolarSeriesKindSectors PolarSeriesKind = 1
+)
+
+// CompassAngleTicks are angle ticks at the eight compass points, i.e. for wind roses.
+var CompassAngleTicks = []Tick{
+	{Value: 0, Label: "N"}, {Value: 45, Label: "NE"}, {Value: 90, Label: "E"}, {Value: 135, Label: "SE"},
+	{Value: 180, Label: "S"}, {Value: 225, Label: "SW"}, {Value: 270, Label: "W"}, {Value: 315, Label: "NW"},
+}
+
+// PolarSeries is a series of angle and radius values in a polar chart.
+type PolarSeries struct {
+	Name  string
+	Style Style
+	Kind  PolarSeriesKind
+
+	// Closed joins the last point of a line back to the first.
+	Closed bool
+	// SectorWidth is the width of sectors in degrees; it defaults to a full circle divided by the number of values.
+	SectorWidth float64
+
+	// Angles are in degrees clockwise from the top, and Radii are distances from the center in the units
+	// of the radius range.
+	Angles []float64
+	Radii  []float64
+}
+
+// GetSectorWidth returns the width of the sectors in degrees or the default.
+func (ps PolarSeries) GetSectorWidth() float64 {
+	if ps.SectorWidth <= 0 && len(ps.Angles) > 0 {
+		return 360 / float64(len(ps.Angles))
+	}
+	return ps.SectorWidth
+}
+
+// Validate validates the series.
+func (ps PolarSeries) Validate() error {
+	if len(ps.Angles) == 0 {
+		return newValidationError(ErrEmptySeries, "polar series must have angles set")
+	}
+	if len(ps.Angles) != len(ps.Radii) {
+		return newValidationError(ErrLengthMismatch, "polar series must have as many radii as angles; got %d angles and %d radii", len(ps.Angles), len(ps.Radii))
+	}
+	return nil
+}
+
+// PolarChart plots series of angle and radius values around a center, as lines or as filled sectors,
+// over circular grid lines at the ticks of the radius range and spokes at the angle ticks. Angles are
+// in degrees clockwise from the top, like compass bearings.
+type PolarChart struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
+	// RadiusRange is the range of radii from the center out; it defaults to 0 to the largest radius.
+	RadiusRange     Range
+	RadiusFormatter ValueFormatter
+
+	// AngleTicks are the angles in degrees of the spokes and their labels around the circle;
+	// they default to every 30 degrees. See `CompassAngleTicks`.
+	AngleTicks []Tick
+
+	// GridStyle is the style of the circular grid lines, the spokes and their labels;
+	// they are drawn if the style is zero or shown.
+	GridStyle Style
+
+	// StackSectors stacks the sectors of each series on the sectors of the series before it at the same angle,
+	// as in a wind rose of wind speeds by direction.
+	StackSectors bool
+
+	Font        *truetype.Font
+	defaultFont *truetype.Font
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Series   []PolarSeries
+	Elements []Renderable
+}
+
+// GetDPI returns the dpi for the chart.
+func (pc PolarChart) GetDPI(defaults ...float64) float64 {
+	if pc.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return pc.DPI
+}
+
+// GetFont returns the text font.
+func (pc PolarChart) GetFont() *truetype.Font {
+	if pc.Font == nil {
+		return pc.defaultFont
+	}
+	return pc.Font
+}
+
+// GetWidth returns the chart width or the default value.
+func (pc PolarChart) GetWidth() int {
+	if pc.Width == 0 {
+		return DefaultChartWidth
+	}
+	return pc.Width
+}
+
+// GetHeight returns the chart height or the default value.
+func (pc PolarChart) GetHeight() int {
+	if pc.Height == 0 {
+		return DefaultChartWidth
+	}
+	return pc.Height
+}
+
+// GetRadiusFormatter returns the value formatter of the radius labels or a default.
+func (pc PolarChart) GetRadiusFormatter() ValueFormatter {
+	if pc.RadiusFormatter != nil {
+		return pc.RadiusFormatter
+	}
+	return GroupedValueFormatter
+}
+
+// GetAngleTicks returns the angle ticks or the default of every 30 degrees.
+func (pc PolarChart) GetAngleTicks() []Tick {
+	if len(pc.AngleTicks) > 0 {
+		return pc.AngleTicks
+	}
+	ticks := make([]Tick, 12)
+	for index := range ticks {
+		ticks[index] = Tick{Value: float64(30 * index), Label: fmt.Sprintf("%d°", 30*index)}
+	}
+	return ticks
+}
+
+// Validate validates the chart and its series.
+func (pc PolarChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateFont(pc.Font, pc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(pc.Series) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one series"))
+	}
+	for index, s := range pc.Series {
+		if err := s.Validate(); err != nil {
+			ve := err.(*ValidationError)
+			ve.SeriesIndex, ve.SeriesName = index, s.Name
+			errs = append(errs, ve)
+		}
+	}
+	if len(errs) == 0 {
+		if _, err := pc.getRadiusRange(pc.getOuterRadii()); err != nil {
+			errs = append(errs, err.(*ValidationError))
+		}
+	}
+	return errs.asError()
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (pc PolarChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = pc.Validate(); err != nil {
+		return err
+	}
+	outer := pc.getOuterRadii()
+	rr, _ := pc.getRadiusRange(outer)
+
+	r, err := rp(pc.GetWidth(), pc.GetHeight())
+	if err != nil {
+		return err
+	}
+
+	if pc.NoText {
+		r = textFreeRenderer{r}
+	} else if pc.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		pc.defaultFont = defaultFont
+	}
+	r.SetDPI(pc.GetDPI(DefaultDPI))
+
+	canvasBox := pc.getCanvasBox(r)
+	Draw.Box(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()}, pc.Background.InheritFrom(pc.styleDefaultsBackground()))
+	pc.BackgroundImage.draw(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()})
+	Draw.Box(r, canvasBox, pc.Canvas.InheritFrom(pc.styleDefaultsCanvas()))
+	pc.Watermark.draw(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()}, pc.GetFont(), pc.GetColorPalette(), false)
+
+	cx, cy := canvasBox.Center()
+	radius := pc.getRadius(r, canvasBox)
+	rr.SetDomain(int(radius))
+	cl := ChartLayout{Canvas: canvasBox, YRange: rr}
+	callRenderHook(pc.BeforeRender, r, cl)
+
+	// the grid is drawn over the sectors, as it is on rose charts, and under the lines.
+	pc.drawSectors(r, cx, cy, rr, outer)
+	showGrid := pc.GridStyle.IsZero() || pc.GridStyle.Show
+	var ticks []Tick
+	if showGrid {
+		ticks = pc.drawGrid(r, cx, cy, radius, rr)
+	}
+	pc.drawLines(r, cx, cy, rr)
+	if showGrid {
+		pc.drawGridLabels(r, cx, cy, radius, rr, ticks)
+	}
+
+	if len(pc.Title) > 0 && pc.TitleStyle.Show {
+		Draw.TextWithin(r, pc.Title, pc.Box(), pc.styleDefaultsTitle())
+	}
+	for _, a := range pc.Elements {
+		a(r, canvasBox, Style{Font: pc.GetFont()})
+	}
+	pc.Watermark.draw(r, Box{Right: pc.GetWidth(), Bottom: pc.GetHeight()}, pc.GetFont(), pc.GetColorPalette(), true)
+	callRenderHook(pc.AfterRender, r, cl)
+
+	setAccessibility(r, pc.Accessibility.withDefaults(pc.Title, func() string {
+		names := make([]string, 0, len(pc.Series))
+		for _, s := range pc.Series {
+			if len(s.Name) > 0 {
+				names = append(names, s.Name)
+			}
+		}
+		description := fmt.Sprintf("Polar chart of %d series, with radii from %s to %s", len(pc.Series),
+			FloatValueFormatter(rr.GetMin()), FloatValueFormatter(rr.GetMax()))
+		if len(names) > 0 {
+			description += ": " + strings.Join(names, ", ")
+		}
+		return description + "."
+	}))
+	return r.Save(w)
+}
+
+// isShown returns if a series is drawn.
+func (pc PolarChart) isShown(s PolarSeries) bool {
+	return s.Style.IsZero() || s.Style.Show
+}
+
+// getOuterRadii returns the radius each value reaches; the radius of sectors stacked on the sectors
+// of earlier series at the same angle includes theirs.
+func (pc PolarChart) getOuterRadii() [][]float64 {
+	outer := make([][]float64, len(pc.Series))
+	stacks := map[float64]float64{}
+	for index, s := range pc.Series {
+		outer[index] = make([]float64, len(s.Radii))
+		for vi, radius := range s.Radii {
+			outer[index][vi] = radius
+			if pc.StackSectors && s.Kind == PolarSeriesKindSectors && pc.isShown(s) && vi < len(s.Angles) && !math.IsNaN(radius) {
+				angle := normalizeDegrees(s.Angles[vi])
+				stacks[angle] += radius
+				outer[index][vi] = stacks[angle]
+			}
+		}
+	}
+	return outer
+}
+
+// getRadiusRange returns the radius range, which defaults to 0 to the largest radius.
+func (pc PolarChart) getRadiusRange(outer [][]float64) (Range, error) {
+	if pc.RadiusRange != nil && !pc.RadiusRange.IsZero() {
+		return pc.RadiusRange, nil
+	}
+	var max float64
+	for index, radii := range outer {
+		if !pc.isShown(pc.Series[index]) {
+			continue
+		}
+		for _, radius := range radii {
+			if !math.IsInf(radius, 0) && radius > max {
+				max = radius
+			}
+		}
+	}
+	if max <= 0 {
+		return nil, newValidationError(ErrInvalidRange, "polar chart must contain at least (1) positive radius")
+	}
+	return &ContinuousRange{Min: 0, Max: max}, nil
+}
+
+// getCanvasBox returns the box of the chart below the title.
+func (pc PolarChart) getCanvasBox(r Renderer) Box {
+	canvasBox := pc.Box()
+	if len(pc.Title) > 0 && pc.TitleStyle.Show {
+		pc.styleDefaultsTitle().GetTextOptions().WriteToRenderer(r)
+		lines := Text.WrapFit(r, pc.Title, canvasBox.Width(), pc.styleDefaultsTitle())
+		canvasBox.Top += Text.MeasureLines(r, lines, pc.styleDefaultsTitle()).Height() + DefaultLineSpacing
+	}
+	return canvasBox
+}
+
+// getRadius returns the radius of the plot, which leaves room for the angle labels around the circle.
+func (pc PolarChart) getRadius(r Renderer, canvasBox Box) float64 {
+	var labelWidth, labelHeight int
+	if pc.GridStyle.IsZero() || pc.GridStyle.Show {
+		pc.GridStyle.InheritFrom(pc.styleDefaultsGrid()).GetTextOptions().WriteToRenderer(r)
+		for _, t := range pc.GetAngleTicks() {
+			tb := r.MeasureText(t.Label)
+			labelWidth = util.Math.MaxInt(labelWidth, tb.Width())
+			labelHeight = util.Math.MaxInt(labelHeight, tb.Height())
+		}
+	}
+	horizontal := (canvasBox.Width() >> 1) - labelWidth - DefaultLineSpacing
+	vertical := (canvasBox.Height() >> 1) - labelHeight - DefaultLineSpacing
+	radius := float64(util.Math.MinInt(horizontal, vertical))
+	return math.Max(radius, float64(util.Math.MinInt(canvasBox.Width(), canvasBox.Height())>>2))
+}
+
+// translate returns the distance from the center of a radius, which is 0 for radii inside the range.
+func (pc PolarChart) translate(rr Range, radius float64) float64 {
+	return math.Max(0, float64(rr.Translate(math.Min(radius, rr.GetMax()))))
+}
+
+// drawGrid draws a circle at each tick of the radius range and a spoke at each angle tick,
+// and returns the radius ticks.
+func (pc PolarChart) drawGrid(r Renderer, cx, cy int, radius float64, rr Range) []Tick {
+	gridStyle := pc.GridStyle.InheritFrom(pc.styleDefaultsGrid())
+	ticks := GenerateUnitTicks(r, rr, true, gridStyle, &Unit{}, pc.GetRadiusFormatter())
+	gridStyle.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	for _, t := range ticks {
+		if gr := pc.translate(rr, t.Value); gr > 0 {
+			strokeArc(r, cx, cy, gr, 0, 2*math.Pi)
+		}
+	}
+	for _, t := range pc.GetAngleTicks() {
+		x, y := util.Math.CirclePoint(cx, cy, radius, util.Math.DegreesToRadians(t.Value))
+		r.MoveTo(cx, cy)
+		r.LineTo(x, y)
+		r.Stroke()
+	}
+	return ticks
+}
+
+// drawGridLabels draws the labels of the radius ticks along the spoke from the center up,
+// and the labels of the angle ticks around the circle.
+func (pc PolarChart) drawGridLabels(r Renderer, cx, cy int, radius float64, rr Range, ticks []Tick) {
+	gridStyle := pc.GridStyle.InheritFrom(pc.styleDefaultsGrid())
+	gridStyle.GetTextOptions().WriteToRenderer(r)
+	for _, t := range ticks {
+		if gr := pc.translate(rr, t.Value); gr > 0 {
+			tb := r.MeasureText(t.Label)
+			r.Text(t.Label, cx+DefaultLineSpacing, cy-int(gr)+tb.Height()+DefaultLineSpacing)
+		}
+	}
+	for _, t := range pc.GetAngleTicks() {
+		tb := r.MeasureText(t.Label)
+		// the label box touches the circle at the point nearest to the angle.
+		angle := util.Math.DegreesToRadians(t.Value)
+		lx, ly := util.Math.CirclePoint(cx, cy, radius+DefaultLineSpacing, angle)
+		sin, cos := math.Sin(angle), math.Cos(angle)
+		lx = lx - tb.Width()>>1 + int(sin*float64(tb.Width()>>1))
+		ly = ly + tb.Height()>>1 - int(cos*float64(tb.Height()>>1))
+		r.Text(t.Label, lx, ly)
+	}
+}
+
+// drawSectors draws the sectors of the sector series; stacked sectors are drawn from the outermost in,
+// so each covers the rest of the ones it stacks on.
+func (pc PolarChart) drawSectors(r Renderer, cx, cy int, rr Range, outer [][]float64) {
+	for order := range pc.Series {
+		index := order
+		if pc.StackSectors {
+			index = len(pc.Series) - 1 - order
+		}
+		s := pc.Series[index]
+		if s.Kind != PolarSeriesKindSectors || !pc.isShown(s) {
+			continue
+		}
+		style := s.Style.InheritFrom(pc.styleDefaultsSector(index))
+		width := util.Math.DegreesToRadians(s.GetSectorWidth())
+		for vi, angle := range s.Angles {
+			if math.IsNaN(outer[index][vi]) {
+				continue
+			}
+			if sr := pc.translate(rr, outer[index][vi]); sr > 0 {
+				Draw.Sector(r, cx, cy, sr, util.Math.DegreesToRadians(angle)-_pi2-width/2, width, style)
+			}
+		}
+	}
+}
+
+// drawLines draws the line series, with dots at their points if their style has a dot width.
+func (pc PolarChart) drawLines(r Renderer, cx, cy int, rr Range) {
+	for index, s := range pc.Series {
+		if s.Kind != PolarSeriesKindLine || !pc.isShown(s) {
+			continue
+		}
+		style := s.Style.InheritFrom(pc.styleDefaultsLine(index))
+		var xs, ys []int
+		for vi, angle := range s.Angles {
+			if math.IsNaN(s.Radii[vi]) || math.IsNaN(angle) {
+				continue
+			}
+			x, y := util.Math.CirclePoint(cx, cy, pc.translate(rr, s.Radii[vi]), util.Math.DegreesToRadians(angle))
+			xs, ys = append(xs, x), append(ys, y)
+		}
+		if len(xs) == 0 {
+			continue
+		}
+
+		if style.ShouldDrawStroke() || (s.Closed && style.ShouldDrawFill()) {
+			style.GetFillAndStrokeOptions().WriteToRenderer(r)
+			r.MoveTo(xs[0], ys[0])
+			for vi := 1; vi < len(xs); vi++ {
+				r.LineTo(xs[vi], ys[vi])
+			}
+			if s.Closed {
+				r.Close()
+				r.FillStroke()
+			} else {
+				r.Stroke()
+			}
+			r.ResetStyle()
+		}
+		if style.ShouldDrawDot() {
+			style.GetDotOptions().WriteDrawingOptionsToRenderer(r)
+			for vi := range xs {
+				r.Circle(style.DotWidth, xs[vi], ys[vi])
+				r.FillStroke()
+			}
+			r.ResetStyle()
+		}
+	}
+}
+
+// normalizeDegrees returns an angle in degrees from 0 up to 360.
+func normalizeDegrees(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+func (pc PolarChart) styleDefaultsBackground() Style {
+	return Style{
+		FillColor:   pc.GetColorPalette().BackgroundColor(),
+		StrokeColor: pc.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (pc PolarChart) styleDefaultsCanvas() Style {
+	return Style{
+		FillColor:   pc.GetColorPalette().CanvasColor(),
+		StrokeColor: pc.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (pc PolarChart) styleDefaultsSector(index int) Style {
+	return Style{
+		StrokeColor: pc.GetColorPalette().CanvasColor(),
+		StrokeWidth: 1.0,
+		FillColor:   pc.GetColorPalette().GetSeriesColor(index),
+	}
+}
+
+func (pc PolarChart) styleDefaultsLine(index int) Style {
+	color := pc.GetColorPalette().GetSeriesColor(index)
+	return Style{
+		StrokeColor: color,
+		StrokeWidth: DefaultSeriesLineWidth,
+		DotColor:    color,
+	}
+}
+
+func (pc PolarChart) styleDefaultsGrid() Style {
+	return Style{
+		StrokeColor:     pc.GetColorPalette().AxisStrokeColor(),
+		StrokeWidth:     DefaultAxisLineWidth,
+		StrokeDashArray: []float64{4, 4},
+		FontSize:        DefaultAxisFontSize,
+		FontColor:       pc.GetColorPalette().TextColor(),
+		Font:            pc.GetFont(),
+	}
+}
+
+func (pc PolarChart) styleDefaultsTitle() Style {
+	return pc.TitleStyle.InheritFrom(Style{
+		FontColor:           pc.GetColorPalette().TextColor(),
+		Font:                pc.GetFont(),
+		FontSize:            pc.getTitleFontSize(),
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+}
+
+func (pc PolarChart) getTitleFontSize() float64 {
+	effectiveDimension := util.Math.MinInt(pc.GetWidth(), pc.GetHeight())
+	if effectiveDimension >= 2048 {
+		return 48
+	} else if effectiveDimension >= 1024 {
+		return 24
+	} else if effectiveDimension >= 512 {
+		return 18
+	} else if effectiveDimension >= 256 {
+		return 12
+	}
+	return 10
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (pc PolarChart) GetColorPalette() ColorPalette {
+	if pc.ColorPalette != nil {
+		return pc.ColorPalette
+	}
+	return DefaultColorPalette
+}
+
+// Box returns the chart bounds as a box.
+func (pc PolarChart) Box() Box {
+	dpr := pc.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
+	dpb := pc.Background.Padding.GetBottom(DefaultBackgroundPadding.Bottom)
+
+	return Box{
+		Top:    pc.Background.Padding.GetTop(DefaultBackgroundPadding.Top),
+		Left:   pc.Background.Padding.GetLeft(DefaultBackgroundPadding.Left),
+		Right:  pc.GetWidth() - dpr,
+		Bottom: pc.GetHeight() - dpb,
+	}
+}
@@ -0,0 +1,64 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestPolarChartRender(t *testing.T) {
+	assert := assert.New(t)
+
+	pc := PolarChart{
+		AngleTicks: CompassAngleTicks,
+		Series: []PolarSeries{
+			{Name: "Antenna", Closed: true, Angles: []float64{0, 90, 180, 270}, Radii: []float64{4, 2, 1, 2}},
+			{Name: "Wind", Kind: PolarSeriesKindSectors, Angles: []float64{0, 90, 180, 270}, Radii: []float64{1, 2, 3, 4}},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(pc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	buf.Reset()
+	assert.Nil(pc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), ">NE</text>"))
+	assert.True(strings.Contains(buf.String(), "Antenna, Wind"))
+
+	assert.NotNil(PolarChart{}.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestPolarChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(PolarChart{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(PolarChart{Series: []PolarSeries{{Angles: []float64{0, 90}, Radii: []float64{1}}}}.Validate(), ErrLengthMismatch))
+	assert.True(errors.Is(PolarChart{Series: []PolarSeries{{Angles: []float64{0}, Radii: []float64{0}}}}.Validate(), ErrInvalidRange))
+	assert.Nil(PolarChart{Series: []PolarSeries{{Angles: []float64{0}, Radii: []float64{1}}}}.Validate())
+}
+
+func TestPolarChartStackSectors(t *testing.T) {
+	assert := assert.New(t)
+
+	pc := PolarChart{
+		Series: []PolarSeries{
+			{Kind: PolarSeriesKindSectors, Angles: []float64{0, 90}, Radii: []float64{1, 2}},
+			{Kind: PolarSeriesKindSectors, Angles: []float64{360, 90}, Radii: []float64{3, 4}},
+			{Angles: []float64{0}, Radii: []float64{5}},
+		},
+	}
+	assert.Equal([][]float64{{1, 2}, {3, 4}, {5}}, pc.getOuterRadii())
+
+	pc.StackSectors = true
+	outer := pc.getOuterRadii()
+	assert.Equal([][]float64{{1, 2}, {4, 6}, {5}}, outer)
+	rr, err := pc.getRadiusRange(outer)
+	assert.Nil(err)
+	assert.Equal(6.0, rr.GetMax())
+
+	assert.Equal(180.0, PolarSeries{Angles: []float64{0, 180}}.GetSectorWidth())
+}
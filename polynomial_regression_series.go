@@ -9,15 +9,18 @@ import (
 )
 
 // PolynomialRegressionSeries implements a polynomial regression over a given
-// inner series.
+// inner series. If `Window` is set, each value is instead the regression over
+// the `Window` values leading up to it (a rolling regression).
 type PolynomialRegressionSeries struct {
 	Name  string
 	Style Style
 	YAxis YAxisType
+	XAxis XAxisType
 
 	Limit       int
 	Offset      int
 	Degree      int
+	Window      int
 	InnerSeries ValuesProvider
 
 	coeffs []float64
@@ -38,6 +41,11 @@ func (prs PolynomialRegressionSeries) GetYAxis() YAxisType {
 	return prs.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (prs PolynomialRegressionSeries) GetXAxis() XAxisType {
+	return prs.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (prs PolynomialRegressionSeries) Len() int {
 	return util.Math.MinInt(prs.GetLimit(), prs.InnerSeries.Len()-prs.GetOffset())
@@ -77,6 +85,10 @@ func (prs *PolynomialRegressionSeries) Validate() error {
 		return fmt.Errorf("invalid window; inner series has length %d but end index is %d", prs.InnerSeries.Len(), endIndex)
 	}
 
+	if prs.Window != 0 && prs.Window <= prs.Degree {
+		return fmt.Errorf("invalid rolling window; a window of %d cannot fit a polynomial of degree %d", prs.Window, prs.Degree)
+	}
+
 	return nil
 }
 
@@ -86,19 +98,9 @@ func (prs *PolynomialRegressionSeries) GetValues(index int) (x, y float64) {
 		return
 	}
 
-	if prs.coeffs == nil {
-		coeffs, err := prs.computeCoefficients()
-		if err != nil {
-			panic(err)
-		}
-		prs.coeffs = coeffs
-	}
-
 	offset := prs.GetOffset()
 	effectiveIndex := util.Math.MinInt(index+offset, prs.InnerSeries.Len())
-	x, y = prs.InnerSeries.GetValues(effectiveIndex)
-	y = prs.apply(x)
-	return
+	return prs.valueAt(effectiveIndex)
 }
 
 // GetLastValues computes the last poly regression value.
@@ -106,6 +108,23 @@ func (prs *PolynomialRegressionSeries) GetLastValues() (x, y float64) {
 	if prs.InnerSeries == nil || prs.InnerSeries.Len() == 0 {
 		return
 	}
+	return prs.valueAt(prs.GetEndIndex())
+}
+
+// RSquared returns the coefficient of determination of the regression
+// against the inner series values it was fit to.
+func (prs *PolynomialRegressionSeries) RSquared() float64 {
+	if prs.InnerSeries == nil || prs.InnerSeries.Len() == 0 {
+		return 0
+	}
+	return rSquared(prs.InnerSeries, prs.GetOffset(), prs.GetEndIndex(), prs.valueAt)
+}
+
+// valueAt returns the regression value for an index of the inner series.
+func (prs *PolynomialRegressionSeries) valueAt(innerIndex int) (x, y float64) {
+	if prs.Window > 0 {
+		return rollingRegressionValues(prs.InnerSeries, prs.GetOffset(), innerIndex, prs.Window, polynomialFit(prs.Degree))
+	}
 	if prs.coeffs == nil {
 		coeffs, err := prs.computeCoefficients()
 		if err != nil {
@@ -113,8 +132,7 @@ func (prs *PolynomialRegressionSeries) GetLastValues() (x, y float64) {
 		}
 		prs.coeffs = coeffs
 	}
-	endIndex := prs.GetEndIndex()
-	x, y = prs.InnerSeries.GetValues(endIndex)
+	x, y = prs.InnerSeries.GetValues(innerIndex)
 	y = prs.apply(x)
 	return
 }
@@ -33,3 +33,43 @@ func TestPolynomialRegression(t *testing.T) {
 		assert.InDelta(float64(i*i), y, matrix.DefaultEpsilon)
 	}
 }
+
+func TestPolynomialRegressionRSquared(t *testing.T) {
+	assert := assert.New(t)
+
+	poly := &PolynomialRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{0, 1, 2, 3, 4},
+			YValues: []float64{0, 1, 4, 9, 16},
+		},
+		Degree: 2,
+	}
+	assert.InDelta(1.0, poly.RSquared(), matrix.DefaultEpsilon)
+
+	poly.Degree = 1
+	poly.coeffs = nil
+	assert.True(poly.RSquared() < 1.0)
+}
+
+func TestPolynomialRegressionRolling(t *testing.T) {
+	assert := assert.New(t)
+
+	poly := &PolynomialRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{0, 1, 2, 3, 4, 5},
+			YValues: []float64{0, 1, 4, 1, 0, 1},
+		},
+		Degree: 2,
+		Window: 3,
+	}
+	assert.Nil(poly.Validate())
+
+	for i := 0; i < 5; i++ {
+		_, y := poly.GetValues(i)
+		_, expected := poly.InnerSeries.GetValues(i)
+		assert.InDelta(expected, y, matrix.DefaultEpsilon)
+	}
+
+	poly.Window = 2
+	assert.NotNil(poly.Validate())
+}
@@ -0,0 +1,228 @@
+// Package promchart converts the results of Prometheus range queries into chart time series, so metric
+// dashboards can be rendered server side:
+//
+//	streams, err := promchart.ReadMatrix(resp.Body)
+//	if err != nil {
+//		return err
+//	}
+//	graph := chart.Chart{Series: promchart.Series(streams, promchart.Options{Step: 30 * time.Second})}
+//
+// `ReadMatrix` reads the json of the `/api/v1/query_range` endpoint; the results of a client library,
+// i.e. a `model.Matrix`, convert to `[]Stream` field by field, as the timestamps are milliseconds and the
+// metrics are label sets in both.
+//
+// A range query has no sample at the steps a series was stale or absent at, so the series are broken wherever
+// samples are further apart than a step, rather than drawn straight across the gap.
+package promchart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	chart "github.com/wcharczuk/go-chart"
+)
+
+var (
+	// ErrQueryFailed is the kind of error for a response with an error status.
+	ErrQueryFailed = errors.New("query failed")
+	// ErrNotMatrix is the kind of error for a response whose result isn't a matrix, i.e. of an instant query.
+	ErrNotMatrix = errors.New("result is not a matrix")
+	// ErrInvalidSample is the kind of error for a sample of a response that doesn't parse.
+	ErrInvalidSample = errors.New("invalid sample")
+)
+
+// MetricNameLabel is the label of the metric name.
+const MetricNameLabel = "__name__"
+
+// DefaultGapFactor is how many steps apart samples have to be, by default, for a series to be broken between them.
+const DefaultGapFactor = 1.5
+
+// Sample is a value of a series at a time, in milliseconds since the unix epoch.
+type Sample struct {
+	T int64
+	V float64
+}
+
+// Time returns the time of the sample.
+func (s Sample) Time() time.Time {
+	return time.Unix(0, s.T*int64(time.Millisecond))
+}
+
+// Stream is the samples of a series, and the labels of its metric.
+type Stream struct {
+	Metric map[string]string
+	Values []Sample
+}
+
+// Options are how streams are converted into time series.
+type Options struct {
+	// Step is the step of the query; it defaults to the shortest time between samples.
+	Step time.Duration
+	// GapFactor is how many steps apart samples have to be for a series to be broken between them;
+	// it defaults to `DefaultGapFactor`, and a negative factor never breaks series.
+	GapFactor float64
+
+	// Name returns the name of the series of a metric; it defaults to `MetricName`.
+	Name func(metric map[string]string) string
+	// Style returns the style of the series of a metric, if set.
+	Style func(index int, metric map[string]string) chart.Style
+}
+
+// GetGapFactor returns the gap factor or a default.
+func (o Options) GetGapFactor() float64 {
+	if o.GapFactor == 0 {
+		return DefaultGapFactor
+	}
+	return o.GapFactor
+}
+
+// GetStep returns the step or the shortest time between the samples of any stream; it is 0 if no stream
+// has two samples.
+func (o Options) GetStep(streams ...Stream) time.Duration {
+	if o.Step > 0 {
+		return o.Step
+	}
+	var step int64
+	for _, stream := range streams {
+		for index := 1; index < len(stream.Values); index++ {
+			if delta := stream.Values[index].T - stream.Values[index-1].T; delta > 0 && (step == 0 || delta < step) {
+				step = delta
+			}
+		}
+	}
+	return time.Duration(step) * time.Millisecond
+}
+
+// getName returns the name of the series of a metric.
+func (o Options) getName(metric map[string]string) string {
+	if o.Name != nil {
+		return o.Name(metric)
+	}
+	return MetricName(metric)
+}
+
+// MetricName returns a metric the way Prometheus prints it, i.e. `http_requests_total{code="200", job="api"}`.
+func MetricName(metric map[string]string) string {
+	var labels []string
+	for label := range metric {
+		if label != MetricNameLabel {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	for index, label := range labels {
+		labels[index] = fmt.Sprintf("%s=%q", label, metric[label])
+	}
+	if len(labels) == 0 {
+		return metric[MetricNameLabel]
+	}
+	return metric[MetricNameLabel] + "{" + strings.Join(labels, ", ") + "}"
+}
+
+// Series converts streams into time series, with the same step for all of them.
+func Series(streams []Stream, opts Options) []chart.Series {
+	opts.Step = opts.GetStep(streams...)
+	series := make([]chart.Series, len(streams))
+	for index, stream := range streams {
+		ts := TimeSeries(stream, opts)
+		if opts.Style != nil {
+			ts.Style = opts.Style(index, stream.Metric)
+		}
+		series[index] = ts
+	}
+	return series
+}
+
+// TimeSeries converts a stream into a time series; a NaN sample is put between samples further apart
+// than the gap factor of steps, and the series breaks the line at them.
+func TimeSeries(stream Stream, opts Options) chart.TimeSeries {
+	values := stream.Values
+	if !sort.SliceIsSorted(values, func(i, j int) bool { return values[i].T < values[j].T }) {
+		values = append([]Sample(nil), values...)
+		sort.SliceStable(values, func(i, j int) bool { return values[i].T < values[j].T })
+	}
+
+	step := opts.GetStep(Stream{Values: values})
+	maxGap := int64(math.MaxInt64)
+	if factor := opts.GetGapFactor(); factor > 0 && step > 0 {
+		maxGap = int64(float64(step/time.Millisecond) * factor)
+	}
+
+	ts := chart.TimeSeries{
+		Name:      opts.getName(stream.Metric),
+		GapPolicy: chart.GapPolicyBreak,
+		XValues:   make([]time.Time, 0, len(values)),
+		YValues:   make([]float64, 0, len(values)),
+	}
+	for index, sample := range values {
+		if index > 0 && sample.T-values[index-1].T > maxGap {
+			ts.XValues = append(ts.XValues, values[index-1].Time().Add(step))
+			ts.YValues = append(ts.YValues, math.NaN())
+		}
+		ts.XValues = append(ts.XValues, sample.Time())
+		ts.YValues = append(ts.YValues, sample.V)
+	}
+	return ts
+}
+
+// queryResponse is the json of a query response.
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][2]interface{}  `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// ReadMatrix reads the streams of the json response of a range query.
+func ReadMatrix(r io.Reader) ([]Stream, error) {
+	var response queryResponse
+	if err := json.NewDecoder(r).Decode(&response); err != nil {
+		return nil, err
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("promchart: %w: %s", ErrQueryFailed, response.Error)
+	}
+	if response.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("promchart: %w: it is a %s", ErrNotMatrix, response.Data.ResultType)
+	}
+
+	streams := make([]Stream, len(response.Data.Result))
+	for index, result := range response.Data.Result {
+		streams[index].Metric = result.Metric
+		streams[index].Values = make([]Sample, len(result.Values))
+		for vindex, pair := range result.Values {
+			sample, isValid := parseSample(pair)
+			if !isValid {
+				return nil, fmt.Errorf("promchart: series %s, sample %d: %w: %v", MetricName(result.Metric), vindex, ErrInvalidSample, pair)
+			}
+			streams[index].Values[vindex] = sample
+		}
+	}
+	return streams, nil
+}
+
+// parseSample parses a sample of a response, a pair of a timestamp in seconds and a value as a string.
+func parseSample(pair [2]interface{}) (Sample, bool) {
+	seconds, isNumber := pair[0].(float64)
+	text, isString := pair[1].(string)
+	if !isNumber || !isString {
+		return Sample{}, false
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return Sample{}, false
+	}
+	return Sample{T: int64(math.Round(seconds * 1000)), V: value}, true
+}
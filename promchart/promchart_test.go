@@ -0,0 +1,99 @@
+package promchart
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	chart "github.com/wcharczuk/go-chart"
+)
+
+func TestMetricName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`up{instance="a:9090", job="api"}`, MetricName(map[string]string{MetricNameLabel: "up", "job": "api", "instance": "a:9090"}))
+	assert.Equal("up", MetricName(map[string]string{MetricNameLabel: "up"}))
+	assert.Equal(`{job="api"}`, MetricName(map[string]string{"job": "api"}))
+}
+
+func TestOptionsGetStep(t *testing.T) {
+	assert := assert.New(t)
+
+	streams := []Stream{
+		{Values: []Sample{{T: 0}, {T: 30000}, {T: 90000}}},
+		{Values: []Sample{{T: 0}, {T: 15000}}},
+	}
+	assert.Equal(15*time.Second, Options{}.GetStep(streams...))
+	assert.Equal(time.Minute, Options{Step: time.Minute}.GetStep(streams...))
+	assert.Zero(Options{}.GetStep(Stream{Values: []Sample{{T: 0}}}))
+}
+
+func TestTimeSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	stream := Stream{
+		Metric: map[string]string{MetricNameLabel: "up"},
+		Values: []Sample{{T: 60000, V: 2}, {T: 0, V: 1}, {T: 180000, V: 4}, {T: 240000, V: 5}},
+	}
+	ts := TimeSeries(stream, Options{})
+	assert.Equal("up", ts.Name)
+	assert.Equal(chart.GapPolicyBreak, ts.GapPolicy)
+	// the samples are sorted, and the missing step at 120s breaks the series.
+	assert.Len(ts.XValues, 5)
+	assert.Equal(time.Unix(120, 0), ts.XValues[2])
+	assert.Equal(1.0, ts.YValues[0])
+	assert.True(math.IsNaN(ts.YValues[2]))
+	assert.Equal(5.0, ts.YValues[4])
+	assert.Nil(ts.Validate())
+
+	assert.Len(TimeSeries(stream, Options{GapFactor: -1}).XValues, 4)
+	assert.Len(TimeSeries(stream, Options{Step: 2 * time.Minute}).XValues, 4)
+	assert.Equal("custom", TimeSeries(stream, Options{Name: func(map[string]string) string { return "custom" }}).Name)
+}
+
+func TestSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	streams := []Stream{
+		{Metric: map[string]string{"job": "a"}, Values: []Sample{{T: 0, V: 1}, {T: 60000, V: 2}}},
+		{Metric: map[string]string{"job": "b"}, Values: []Sample{{T: 0, V: 1}, {T: 30000, V: 2}, {T: 60000, V: 3}}},
+	}
+	series := Series(streams, Options{Style: func(index int, metric map[string]string) chart.Style {
+		return chart.Style{Show: true, StrokeWidth: float64(index + 1)}
+	}})
+	assert.Len(series, 2)
+	// the shared step is 30s, so the first stream is missing a sample.
+	assert.Len(series[0].(chart.TimeSeries).XValues, 3)
+	assert.Len(series[1].(chart.TimeSeries).XValues, 3)
+	assert.Equal(2.0, series[1].GetStyle().StrokeWidth)
+
+	graph := chart.Chart{Series: series}
+	assert.Nil(graph.Render(chart.PNG, &strings.Builder{}))
+}
+
+func TestReadMatrix(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `{"status":"success","data":{"resultType":"matrix","result":[
+		{"metric":{"__name__":"up","job":"api"},"values":[[1435781430.781,"1"],[1435781445.781,"NaN"]]}
+	]}}`
+	streams, err := ReadMatrix(strings.NewReader(data))
+	assert.Nil(err)
+	assert.Len(streams, 1)
+	assert.Equal("api", streams[0].Metric["job"])
+	assert.Equal(Sample{T: 1435781430781, V: 1}, streams[0].Values[0])
+	assert.True(math.IsNaN(streams[0].Values[1].V))
+
+	_, err = ReadMatrix(strings.NewReader(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	assert.True(errors.Is(err, ErrQueryFailed))
+	assert.True(strings.Contains(err.Error(), "parse error"), err.Error())
+
+	_, err = ReadMatrix(strings.NewReader(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	assert.True(errors.Is(err, ErrNotMatrix))
+
+	_, err = ReadMatrix(strings.NewReader(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1,"one"]]}]}}`))
+	assert.True(errors.Is(err, ErrInvalidSample))
+}
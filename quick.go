@@ -0,0 +1,54 @@
+package chart
+
+import "time"
+
+// QuickLine returns a line chart of y values over x values, with both axes shown.
+func QuickLine(xvalues, yvalues []float64) Chart {
+	return Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		YAxis: YAxis{Style: StyleShow(), ValueFormatter: GroupedValueFormatter},
+		Series: []Series{
+			ContinuousSeries{XValues: xvalues, YValues: yvalues},
+		},
+	}
+}
+
+// QuickTime returns a line chart of values over times, with both axes shown and calendar aware time ticks.
+func QuickTime(times []time.Time, values []float64) Chart {
+	return Chart{
+		XAxis: XAxis{Style: StyleShow(), Range: &TimeRange{}},
+		YAxis: YAxis{Style: StyleShow(), ValueFormatter: GroupedValueFormatter},
+		Series: []Series{
+			TimeSeries{XValues: times, YValues: values},
+		},
+	}
+}
+
+// QuickBar returns a bar chart of values, with both axes shown.
+// Values are labeled by the label at the same index; values without a label are unlabeled.
+func QuickBar(labels []string, values []float64) BarChart {
+	return BarChart{
+		XAxis: StyleShow(),
+		YAxis: YAxis{Style: StyleShow(), ValueFormatter: GroupedValueFormatter},
+		Bars:  quickValues(labels, values),
+	}
+}
+
+// QuickPie returns a pie chart of values.
+// Values are labeled by the label at the same index; values without a label are unlabeled.
+func QuickPie(labels []string, values []float64) PieChart {
+	return PieChart{
+		Values: quickValues(labels, values),
+	}
+}
+
+func quickValues(labels []string, values []float64) []Value {
+	output := make([]Value, len(values))
+	for index, value := range values {
+		output[index].Value = value
+		if index < len(labels) {
+			output[index].Label = labels[index]
+		}
+	}
+	return output
+}
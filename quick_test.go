@@ -0,0 +1,40 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestQuickCharts(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2)}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(QuickLine([]float64{1, 2, 3}, []float64{1, 4, 9}).Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+
+	buffer.Reset()
+	assert.Nil(QuickTime(times, []float64{1, 4, 9}).Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+
+	buffer.Reset()
+	assert.Nil(QuickBar([]string{"a", "b", "c"}, []float64{1, 4, 9}).Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+
+	buffer.Reset()
+	assert.Nil(QuickPie([]string{"a", "b", "c"}, []float64{1, 4, 9}).Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func TestQuickValues(t *testing.T) {
+	assert := assert.New(t)
+
+	values := quickValues([]string{"a"}, []float64{1, 2})
+	assert.Equal([]Value{{Label: "a", Value: 1}, {Value: 2}}, values)
+	assert.Empty(quickValues([]string{"a"}, nil))
+}
@@ -0,0 +1,341 @@
+package chart
+
+import (
+	"io"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// RadialBarChart draws each value as a concentric ring (or progress ring) filled clockwise from the top
+// by the share of `Max` it is, with the first value on the outside. Rings are labeled at their start,
+// by default with the label and percentage of the value, i.e. `Move: 75%`.
+type RadialBarChart struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
+	// BarStyle is the style of the rings; TrackStyle is the style of the unfilled rest of the rings.
+	BarStyle   Style
+	TrackStyle Style
+
+	// Max is the value of a full ring; it defaults to 1, so values are ratios.
+	Max float64
+
+	// LabelFormatter formats the ring labels; it defaults to `PieLabelPercent`.
+	LabelFormatter PieLabelFormatter
+
+	// InnerRadius is the radius of the hole in the middle as a share of the radius; it defaults to 0.3.
+	InnerRadius float64
+
+	Font        *truetype.Font
+	defaultFont *truetype.Font
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Values   []Value
+	Elements []Renderable
+}
+
+// GetDPI returns the dpi for the chart.
+func (rbc RadialBarChart) GetDPI(defaults ...float64) float64 {
+	if rbc.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return rbc.DPI
+}
+
+// GetFont returns the text font.
+func (rbc RadialBarChart) GetFont() *truetype.Font {
+	if rbc.Font == nil {
+		return rbc.defaultFont
+	}
+	return rbc.Font
+}
+
+// GetWidth returns the chart width or the default value.
+func (rbc RadialBarChart) GetWidth() int {
+	if rbc.Width == 0 {
+		return DefaultChartWidth
+	}
+	return rbc.Width
+}
+
+// GetHeight returns the chart height or the default value.
+func (rbc RadialBarChart) GetHeight() int {
+	if rbc.Height == 0 {
+		return DefaultChartWidth
+	}
+	return rbc.Height
+}
+
+// GetMax returns the value of a full ring or a default.
+func (rbc RadialBarChart) GetMax() float64 {
+	if rbc.Max <= 0 {
+		return 1
+	}
+	return rbc.Max
+}
+
+// GetLabelFormatter returns the label formatter or a default.
+func (rbc RadialBarChart) GetLabelFormatter() PieLabelFormatter {
+	if rbc.LabelFormatter != nil {
+		return rbc.LabelFormatter
+	}
+	return PieLabelPercent
+}
+
+// GetInnerRadius returns the radius of the hole in the middle as a share of the radius or a default.
+func (rbc RadialBarChart) GetInnerRadius() float64 {
+	if rbc.InnerRadius <= 0 || rbc.InnerRadius >= 1 {
+		return 0.3
+	}
+	return rbc.InnerRadius
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (rbc RadialBarChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateFont(rbc.Font, rbc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(rbc.Values) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one value"))
+	}
+	for index, v := range rbc.Values {
+		if math.IsInf(v.Value, 0) {
+			errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "value must not be infinite", SeriesIndex: index, SeriesName: v.Label})
+		}
+	}
+	return errs.asError()
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (rbc RadialBarChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = rbc.Validate(); err != nil {
+		return err
+	}
+
+	r, err := rp(rbc.GetWidth(), rbc.GetHeight())
+	if err != nil {
+		return err
+	}
+
+	if rbc.NoText {
+		r = textFreeRenderer{r}
+	} else if rbc.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		rbc.defaultFont = defaultFont
+	}
+	r.SetDPI(rbc.GetDPI(DefaultDPI))
+
+	canvasBox := rbc.Box()
+	Draw.Box(r, Box{Right: rbc.GetWidth(), Bottom: rbc.GetHeight()}, rbc.Background.InheritFrom(rbc.styleDefaultsBackground()))
+	rbc.BackgroundImage.draw(r, Box{Right: rbc.GetWidth(), Bottom: rbc.GetHeight()})
+	Draw.Box(r, canvasBox, rbc.Canvas.InheritFrom(rbc.styleDefaultsCanvas()))
+	rbc.Watermark.draw(r, Box{Right: rbc.GetWidth(), Bottom: rbc.GetHeight()}, rbc.GetFont(), rbc.GetColorPalette(), false)
+	cl := ChartLayout{Canvas: canvasBox}
+	callRenderHook(rbc.BeforeRender, r, cl)
+
+	cx, cy := canvasBox.Center()
+	radius := float64(util.Math.MinInt(canvasBox.Width(), canvasBox.Height()) >> 1)
+	rings := rbc.getRings(radius)
+	for index, v := range rbc.Values {
+		rbc.drawRing(r, cx, cy, rings[index], index, v)
+	}
+	for index, v := range rbc.Values {
+		rbc.drawRingLabel(r, cx, cy, rings[index], index, v)
+	}
+
+	if len(rbc.Title) > 0 && rbc.TitleStyle.Show {
+		Draw.TextWithin(r, rbc.Title, canvasBox, rbc.styleDefaultsTitle())
+	}
+	for _, a := range rbc.Elements {
+		a(r, canvasBox, Style{Font: rbc.GetFont()})
+	}
+	rbc.Watermark.draw(r, Box{Right: rbc.GetWidth(), Bottom: rbc.GetHeight()}, rbc.GetFont(), rbc.GetColorPalette(), true)
+	callRenderHook(rbc.AfterRender, r, cl)
+
+	setAccessibility(r, rbc.Accessibility.withDefaults(rbc.Title, func() string {
+		return describeValues("Radial bar chart", rbc.Values)
+	}))
+	return r.Save(w)
+}
+
+// radialRing is the radius of the middle of a ring and its thickness.
+type radialRing struct {
+	Radius    float64
+	Thickness float64
+}
+
+// getRings returns the rings of the values from the outside in, with a gap of a quarter ring between them.
+func (rbc RadialBarChart) getRings(radius float64) []radialRing {
+	inner := radius * rbc.GetInnerRadius()
+	step := (radius - inner) / float64(len(rbc.Values))
+	thickness := step * 0.75
+
+	rings := make([]radialRing, len(rbc.Values))
+	for index := range rings {
+		outer := radius - float64(index)*step
+		rings[index] = radialRing{Radius: outer - thickness/2, Thickness: thickness}
+	}
+	return rings
+}
+
+// getShare returns the share of a full ring of a value, from 0 to 1.
+func (rbc RadialBarChart) getShare(v Value) float64 {
+	if math.IsNaN(v.Value) || v.Value <= 0 {
+		return 0
+	}
+	return math.Min(v.Value/rbc.GetMax(), 1)
+}
+
+func (rbc RadialBarChart) drawRing(r Renderer, cx, cy int, ring radialRing, index int, v Value) {
+	track := rbc.TrackStyle.InheritFrom(rbc.styleDefaultsTrack(ring))
+	track.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	strokeArc(r, cx, cy, ring.Radius, -_pi2, 2*math.Pi)
+
+	if share := rbc.getShare(v); share > 0 {
+		bar := v.Style.InheritFrom(rbc.BarStyle.InheritFrom(rbc.styleDefaultsBar(ring, index)))
+		bar.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		strokeArc(r, cx, cy, ring.Radius, -_pi2, share*2*math.Pi)
+	}
+}
+
+// drawRingLabel draws the label of a ring left of where it starts at the top.
+func (rbc RadialBarChart) drawRingLabel(r Renderer, cx, cy int, ring radialRing, index int, v Value) {
+	label := rbc.GetLabelFormatter()(v, rbc.getShare(v))
+	if len(label) == 0 {
+		return
+	}
+	v.Style.InheritFrom(rbc.styleDefaultsLabel(ring)).GetTextOptions().WriteToRenderer(r)
+	tb := r.MeasureText(label)
+	r.Text(label, cx-DefaultLineSpacing-tb.Width(), cy-int(ring.Radius)+(tb.Height()>>1))
+}
+
+// strokeArc strokes an arc; arcs are drawn in pieces of at most half a circle, which svg arcs need.
+func strokeArc(r Renderer, cx, cy int, radius, start, delta float64) {
+	for delta > 0 {
+		piece := math.Min(delta, math.Pi)
+		r.ArcTo(cx, cy, radius, radius, start, piece)
+		start, delta = start+piece, delta-piece
+	}
+	r.Stroke()
+}
+
+func (rbc RadialBarChart) styleDefaultsBackground() Style {
+	return Style{
+		FillColor:   rbc.GetColorPalette().BackgroundColor(),
+		StrokeColor: rbc.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (rbc RadialBarChart) styleDefaultsCanvas() Style {
+	return Style{
+		FillColor:   rbc.GetColorPalette().CanvasColor(),
+		StrokeColor: rbc.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (rbc RadialBarChart) styleDefaultsTrack(ring radialRing) Style {
+	return Style{
+		StrokeColor: ColorLightGray,
+		StrokeWidth: ring.Thickness,
+	}
+}
+
+func (rbc RadialBarChart) styleDefaultsBar(ring radialRing, index int) Style {
+	return Style{
+		StrokeColor: rbc.GetColorPalette().GetSeriesColor(index),
+		StrokeWidth: ring.Thickness,
+	}
+}
+
+func (rbc RadialBarChart) styleDefaultsLabel(ring radialRing) Style {
+	return Style{
+		FontSize:  math.Max(6, math.Min(ring.Thickness/2, rbc.getTitleFontSize())),
+		FontColor: rbc.GetColorPalette().TextColor(),
+		Font:      rbc.GetFont(),
+	}
+}
+
+func (rbc RadialBarChart) styleDefaultsTitle() Style {
+	return rbc.TitleStyle.InheritFrom(Style{
+		FontColor:           rbc.GetColorPalette().TextColor(),
+		Font:                rbc.GetFont(),
+		FontSize:            rbc.getTitleFontSize(),
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+}
+
+func (rbc RadialBarChart) getTitleFontSize() float64 {
+	effectiveDimension := util.Math.MinInt(rbc.GetWidth(), rbc.GetHeight())
+	if effectiveDimension >= 2048 {
+		return 48
+	} else if effectiveDimension >= 1024 {
+		return 24
+	} else if effectiveDimension >= 512 {
+		return 18
+	} else if effectiveDimension >= 256 {
+		return 12
+	}
+	return 10
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (rbc RadialBarChart) GetColorPalette() ColorPalette {
+	if rbc.ColorPalette != nil {
+		return rbc.ColorPalette
+	}
+	return AlternateColorPalette
+}
+
+// Box returns the chart bounds as a box.
+func (rbc RadialBarChart) Box() Box {
+	dpr := rbc.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
+	dpb := rbc.Background.Padding.GetBottom(DefaultBackgroundPadding.Bottom)
+
+	return Box{
+		Top:    rbc.Background.Padding.GetTop(DefaultBackgroundPadding.Top),
+		Left:   rbc.Background.Padding.GetLeft(DefaultBackgroundPadding.Left),
+		Right:  rbc.GetWidth() - dpr,
+		Bottom: rbc.GetHeight() - dpb,
+	}
+}
@@ -0,0 +1,52 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestRadialBarChartRender(t *testing.T) {
+	assert := assert.New(t)
+
+	rbc := RadialBarChart{
+		Values: []Value{
+			{Label: "Move", Value: 0.75},
+			{Label: "Exercise", Value: 1.2},
+			{Label: "Stand", Value: 0.5},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(rbc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	buf.Reset()
+	assert.Nil(rbc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "Move: 75%"))
+	assert.True(strings.Contains(buf.String(), "Exercise: 100%"))
+
+	assert.NotNil(RadialBarChart{}.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestRadialBarChartGetRings(t *testing.T) {
+	assert := assert.New(t)
+
+	rbc := RadialBarChart{InnerRadius: 0.2, Values: []Value{{Value: 1}, {Value: 2}}}
+	rings := rbc.getRings(100)
+	assert.Len(rings, 2)
+	assert.Equal(30.0, rings[0].Thickness)
+	assert.Equal(85.0, rings[0].Radius)
+	assert.Equal(45.0, rings[1].Radius)
+}
+
+func TestRadialBarChartGetShare(t *testing.T) {
+	assert := assert.New(t)
+
+	rbc := RadialBarChart{Max: 200}
+	assert.Equal(0.25, rbc.getShare(Value{Value: 50}))
+	assert.Equal(1.0, rbc.getShare(Value{Value: 500}))
+	assert.Zero(rbc.getShare(Value{Value: -5}))
+}
@@ -0,0 +1,101 @@
+package chart
+
+import "math"
+
+const (
+	// DefaultNiceNumbersTicks is the number of ticks nice numbers aim for across a range.
+	DefaultNiceNumbersTicks = 5
+)
+
+// RangePadding is headroom added below the minimum and above the maximum of a range computed from the values of
+// a chart, so the series don't touch the edges of the plot. The fraction and the absolute headroom add up.
+type RangePadding struct {
+	// Fraction is headroom as a share of the delta of the values, i.e. 0.05 for 5% on each side.
+	Fraction float64
+	// Absolute is headroom in the units of the values.
+	Absolute float64
+}
+
+// IsZero returns if the padding adds no headroom.
+func (rp RangePadding) IsZero() bool {
+	return rp.Fraction == 0 && rp.Absolute == 0
+}
+
+// pad returns bounds widened by the padding. Padding doesn't push a bound that isn't negative below zero,
+// or one that isn't positive above it, so i.e. a range of counts still starts at zero.
+func (rp RangePadding) pad(min, max float64) (float64, float64) {
+	headroom := math.Abs(max-min)*rp.Fraction + rp.Absolute
+	if headroom <= 0 || math.IsNaN(headroom) || math.IsInf(headroom, 0) {
+		return min, max
+	}
+	paddedMin, paddedMax := min-headroom, max+headroom
+	if min >= 0 && paddedMin < 0 {
+		paddedMin = 0
+	}
+	if max <= 0 && paddedMax > 0 {
+		paddedMax = 0
+	}
+	return paddedMin, paddedMax
+}
+
+// fittedRange is a range whose bounds, once a chart sets them from its values, are padded and rounded
+// as it asks for.
+type fittedRange interface {
+	fit()
+}
+
+// fitRange pads and rounds the bounds a chart set on a range from its values; it returns if the range
+// rounds its bounds to nice numbers, so the chart doesn't round them again.
+func fitRange(ra Range) bool {
+	if fr, isFitted := ra.(fittedRange); isFitted {
+		fr.fit()
+	}
+	if cr, isContinuous := ra.(interface{ getNiceNumbers() bool }); isContinuous {
+		return cr.getNiceNumbers()
+	}
+	return false
+}
+
+// niceNumber returns a number of 1, 2 or 5 times a power of 10 close to a value; rounded to the closest one,
+// or else the smallest one at least the value.
+func niceNumber(value float64, round bool) float64 {
+	exponent := math.Floor(math.Log10(value))
+	power := math.Pow(10, exponent)
+	fraction := value / power
+	var nice float64
+	if round {
+		switch {
+		case fraction < 1.5:
+			nice = 1
+		case fraction < 3:
+			nice = 2
+		case fraction < 7:
+			nice = 5
+		default:
+			nice = 10
+		}
+	} else {
+		switch {
+		case fraction <= 1:
+			nice = 1
+		case fraction <= 2:
+			nice = 2
+		case fraction <= 5:
+			nice = 5
+		default:
+			nice = 10
+		}
+	}
+	return nice * power
+}
+
+// niceBounds returns bounds rounded out to multiples of a nice step, a step that splits them into about
+// `DefaultNiceNumbersTicks` ticks, i.e. 0 to 100 for 3.7 to 97.2.
+func niceBounds(min, max float64) (float64, float64) {
+	delta := max - min
+	if delta <= 0 || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return min, max
+	}
+	step := niceNumber(niceNumber(delta, false)/(DefaultNiceNumbersTicks-1), true)
+	return math.Floor(min/step) * step, math.Ceil(max/step) * step
+}
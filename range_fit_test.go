@@ -0,0 +1,92 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestRangePaddingPad(t *testing.T) {
+	assert := assert.New(t)
+
+	min, max := RangePadding{Fraction: 0.1}.pad(10, 20)
+	assert.InDelta(9, min, 1e-9)
+	assert.InDelta(21, max, 1e-9)
+
+	min, max = RangePadding{Fraction: 0.1, Absolute: 1}.pad(-10, 10)
+	assert.InDelta(-13, min, 1e-9)
+	assert.InDelta(13, max, 1e-9)
+
+	// padding doesn't cross zero.
+	min, max = RangePadding{Absolute: 5}.pad(2, 10)
+	assert.Zero(min)
+	assert.Equal(15.0, max)
+	min, max = RangePadding{Absolute: 5}.pad(-10, 0)
+	assert.Equal(-15.0, min)
+	assert.Zero(max)
+}
+
+func TestNiceBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	min, max := niceBounds(3.7, 97.2)
+	assert.Zero(min)
+	assert.Equal(100.0, max)
+
+	min, max = niceBounds(-0.013, 0.087)
+	assert.InDelta(-0.02, min, 1e-9)
+	assert.InDelta(0.1, max, 1e-9)
+
+	min, max = niceBounds(1234, 1234)
+	assert.Equal(1234.0, min)
+	assert.Equal(1234.0, max)
+}
+
+func TestChartGetRangesFit(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Range: &ContinuousRange{Padding: RangePadding{Fraction: 0.05}}},
+		YAxis: YAxis{Range: &ContinuousRange{NiceNumbers: true, Padding: RangePadding{Absolute: 1}}},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{10, 30}, YValues: []float64{3.7, 97.2}},
+		},
+	}
+	xr, yr, _ := c.getRanges()
+	assert.InDelta(9, xr.GetMin(), 1e-9)
+	assert.InDelta(31, xr.GetMax(), 1e-9)
+	assert.Zero(yr.GetMin())
+	assert.Equal(100.0, yr.GetMax())
+
+	// set bounds aren't fit.
+	c.YAxis.Range = &ContinuousRange{Min: 1, Max: 2, NiceNumbers: true, Padding: RangePadding{Fraction: 1}}
+	_, yr, _ = c.getRanges()
+	assert.Equal(1.0, yr.GetMin())
+	assert.Equal(2.0, yr.GetMax())
+}
+
+func TestLogarithmicRangeFit(t *testing.T) {
+	assert := assert.New(t)
+
+	lr := &LogarithmicRange{ContinuousRange: ContinuousRange{Min: 10, Max: 1000, Padding: RangePadding{Fraction: 0.5}}}
+	fitRange(lr)
+	assert.InDelta(1, lr.Min, 1e-9)
+	assert.InDelta(10000, lr.Max, 1e-9)
+
+	lr = &LogarithmicRange{ContinuousRange: ContinuousRange{Min: 3, Max: 420, NiceNumbers: true}}
+	assert.True(fitRange(lr))
+	assert.Equal(1.0, lr.Min)
+	assert.Equal(1000.0, lr.Max)
+}
+
+func TestBarChartGetRangesFit(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		YAxis: YAxis{Range: &ContinuousRange{NiceNumbers: true, Padding: RangePadding{Fraction: 0.1}}},
+		Bars:  []Value{{Value: 12}, {Value: 87}},
+	}
+	yr := bc.getRanges()
+	assert.Zero(yr.GetMin())
+	assert.Equal(100.0, yr.GetMax())
+}
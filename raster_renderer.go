@@ -1,6 +1,8 @@
 package chart
 
 import (
+	"bytes"
+	"fmt"
 	"image"
 	"image/png"
 	"io"
@@ -9,10 +11,14 @@ import (
 	util "github.com/blendlabs/go-util"
 	"github.com/golang/freetype/truetype"
 	"github.com/wcharczuk/go-chart/drawing"
+	xdraw "golang.org/x/image/draw"
 )
 
 // PNG returns a new png/raster renderer.
 func PNG(width, height int) (Renderer, error) {
+	if err := DefaultCanvasLimits.Check(width, height); err != nil {
+		return nil, err
+	}
 	i := image.NewRGBA(image.Rect(0, 0, width, height))
 	gc, err := drawing.NewRasterGraphicContext(i)
 	if err == nil {
@@ -31,7 +37,32 @@ type rasterRenderer struct {
 
 	rotateRadians *float64
 
-	s Style
+	s           Style
+	gradientBox Box
+
+	pool *RendererPool
+
+	deterministic bool
+	// encoder encodes the image in place of png, i.e. for `WEBP` and `JPEG` renderers.
+	encoder func(w io.Writer, i image.Image) error
+	// normalizedFont is the font normalized renderers draw all text in; they write no metadata either.
+	normalizedFont *truetype.Font
+
+	scale float64
+
+	metadata map[string]string
+
+	// measured holds the text this renderer has measured, in front of `DefaultTextMeasureCache`
+	// so repeated measurements don't wait on its lock; it is kept when the renderer is pooled.
+	measured map[textMeasureKey]Box
+}
+
+// rendererTextMeasureCacheSize is the number of measurements a raster renderer holds before it starts over.
+const rendererTextMeasureCacheSize = 1024
+
+// IsDeterministic returns if the renderer produces byte for byte stable output.
+func (rr *rasterRenderer) IsDeterministic() bool {
+	return rr.deterministic
 }
 
 func (rr *rasterRenderer) ResetStyle() {
@@ -67,6 +98,38 @@ func (rr *rasterRenderer) SetStrokeDashArray(dashArray []float64) {
 // SetFillColor implements the interface method.
 func (rr *rasterRenderer) SetFillColor(c drawing.Color) {
 	rr.s.FillColor = c
+	rr.s.FillGradient = nil
+	rr.s.FillPattern = nil
+}
+
+// SetFillGradient sets the gradient to fill with until the fill color is next set.
+func (rr *rasterRenderer) SetFillGradient(g *Gradient, box Box) {
+	rr.s.FillGradient = g
+	rr.gradientBox = box
+}
+
+// SetFillPattern sets the pattern to fill with until the fill color is next set.
+func (rr *rasterRenderer) SetFillPattern(p *Pattern) {
+	rr.s.FillPattern = p
+}
+
+// getPatternImage returns the fill pattern over the fill color.
+func (rr *rasterRenderer) getPatternImage(bounds image.Rectangle) image.Image {
+	return patternImage{pattern: *rr.s.FillPattern, background: rr.s.FillColor, scale: rr.getScaleFactor()}
+}
+
+// getGradientImage returns the fill gradient stretched over the gradient box, or else over the pixels being filled.
+func (rr *rasterRenderer) getGradientImage(bounds image.Rectangle) image.Image {
+	if !rr.gradientBox.IsZero() {
+		scale := rr.getScaleFactor()
+		bounds = image.Rect(
+			int(math.Floor(float64(rr.gradientBox.Left)*scale)),
+			int(math.Floor(float64(rr.gradientBox.Top)*scale)),
+			int(math.Ceil(float64(rr.gradientBox.Right)*scale)),
+			int(math.Ceil(float64(rr.gradientBox.Bottom)*scale)),
+		)
+	}
+	return gradientImage{gradient: *rr.s.FillGradient, rect: bounds}
 }
 
 // MoveTo implements the interface method.
@@ -84,6 +147,11 @@ func (rr *rasterRenderer) QuadCurveTo(cx, cy, x, y int) {
 	rr.gc.QuadCurveTo(float64(cx), float64(cy), float64(x), float64(y))
 }
 
+// CubicCurveTo implements the interface method.
+func (rr *rasterRenderer) CubicCurveTo(c1x, c1y, c2x, c2y, x, y int) {
+	rr.gc.CubicCurveTo(float64(c1x), float64(c1y), float64(c2x), float64(c2y), float64(x), float64(y))
+}
+
 // ArcTo implements the interface method.
 func (rr *rasterRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
 	rr.gc.ArcTo(float64(cx), float64(cy), rx, ry, startAngle, delta)
@@ -94,6 +162,18 @@ func (rr *rasterRenderer) Close() {
 	rr.gc.Close()
 }
 
+// Polygon implements the interface method.
+func (rr *rasterRenderer) Polygon(points []Point) {
+	if len(points) == 0 {
+		return
+	}
+	rr.gc.MoveTo(float64(points[0].X), float64(points[0].Y))
+	for _, p := range points[1:] {
+		rr.gc.LineTo(float64(p.X), float64(p.Y))
+	}
+	rr.gc.Close()
+}
+
 // Stroke implements the interface method.
 func (rr *rasterRenderer) Stroke() {
 	rr.gc.SetStrokeColor(rr.s.StrokeColor)
@@ -104,6 +184,14 @@ func (rr *rasterRenderer) Stroke() {
 
 // Fill implements the interface method.
 func (rr *rasterRenderer) Fill() {
+	if rr.s.FillPattern != nil {
+		rr.gc.FillImage(rr.getPatternImage)
+		return
+	}
+	if rr.s.FillGradient != nil {
+		rr.gc.FillImage(rr.getGradientImage)
+		return
+	}
 	rr.gc.SetFillColor(rr.s.FillColor)
 	rr.gc.Fill()
 }
@@ -114,6 +202,14 @@ func (rr *rasterRenderer) FillStroke() {
 	rr.gc.SetStrokeColor(rr.s.StrokeColor)
 	rr.gc.SetLineWidth(rr.s.StrokeWidth)
 	rr.gc.SetLineDash(rr.s.StrokeDashArray, 0)
+	if rr.s.FillPattern != nil {
+		rr.gc.FillStrokeImage(rr.getPatternImage)
+		return
+	}
+	if rr.s.FillGradient != nil {
+		rr.gc.FillStrokeImage(rr.getGradientImage)
+		return
+	}
 	rr.gc.FillStroke()
 }
 
@@ -129,9 +225,48 @@ func (rr *rasterRenderer) Circle(radius float64, x, y int) {
 	rr.gc.QuadCurveTo(xf-radius, yf+radius, xf-radius, yf) //9
 }
 
+// DrawImage draws an image scaled into a box. The image is filled into the box like a pattern,
+// so it is composited, and recorded when series are drawn concurrently, like any other fill.
+func (rr *rasterRenderer) DrawImage(img image.Image, box Box) {
+	rect := rr.getPixelRect(box)
+	if rect.Empty() || img.Bounds().Empty() {
+		return
+	}
+	scaled := image.NewRGBA(rect)
+	xdraw.BiLinear.Scale(scaled, rect, img, img.Bounds(), xdraw.Src, nil)
+
+	rr.gc.MoveTo(float64(box.Left), float64(box.Top))
+	rr.gc.LineTo(float64(box.Right), float64(box.Top))
+	rr.gc.LineTo(float64(box.Right), float64(box.Bottom))
+	rr.gc.LineTo(float64(box.Left), float64(box.Bottom))
+	rr.gc.Close()
+	rr.gc.FillImage(func(image.Rectangle) image.Image { return scaled })
+}
+
+// SetClip clips what is drawn afterwards to a box, until the clip is cleared.
+func (rr *rasterRenderer) SetClip(box Box) {
+	rr.gc.SetClipRect(rr.getPixelRect(box))
+}
+
+// ClearClip clears the clip.
+func (rr *rasterRenderer) ClearClip() {
+	rr.gc.SetClipRect(image.Rectangle{})
+}
+
+// getPixelRect returns the raster pixels of a box.
+func (rr *rasterRenderer) getPixelRect(box Box) image.Rectangle {
+	scale := rr.getScaleFactor()
+	return image.Rect(
+		int(math.Round(float64(box.Left)*scale)),
+		int(math.Round(float64(box.Top)*scale)),
+		int(math.Round(float64(box.Right)*scale)),
+		int(math.Round(float64(box.Bottom)*scale)),
+	)
+}
+
 // SetFont implements the interface method.
 func (rr *rasterRenderer) SetFont(f *truetype.Font) {
-	rr.s.Font = f
+	rr.s.Font = normalizedFontOr(rr.normalizedFont, f)
 }
 
 // SetFontSize implements the interface method.
@@ -150,18 +285,53 @@ func (rr *rasterRenderer) Text(body string, x, y int) {
 	rr.gc.SetFont(rr.s.Font)
 	rr.gc.SetFontSize(rr.s.FontSize)
 	rr.gc.SetFillColor(rr.s.FontColor)
-	rr.gc.CreateStringPath(body, float64(xf), float64(yf))
+	rr.gc.CreateStringPath(visualText(body), float64(xf), float64(yf))
 	rr.gc.Fill()
 }
 
 // MeasureText returns the height and width in pixels of a string.
 func (rr *rasterRenderer) MeasureText(body string) Box {
+	key := textMeasureKey{method: TextMeasureGlyphBounds, font: rr.s.Font, size: rr.s.FontSize, dpi: rr.GetDPI(), body: body}
+	textBox, isMeasured := rr.measured[key]
+	if !isMeasured {
+		// deterministic renderers measure all text themselves, whatever was rendered before them.
+		var isCached bool
+		if !rr.deterministic {
+			textBox, isCached = DefaultTextMeasureCache.Get(key.method, key.font, key.size, key.dpi, body)
+		}
+		if !isCached {
+			var err error
+			textBox, err = rr.measureText(body)
+			if err != nil {
+				return Box{}
+			}
+			if !rr.deterministic {
+				DefaultTextMeasureCache.Put(key.method, key.font, key.size, key.dpi, body, textBox)
+			}
+		}
+		if DefaultTextMeasureCache.Enabled() {
+			if rr.measured == nil || len(rr.measured) >= rendererTextMeasureCacheSize {
+				rr.measured = make(map[textMeasureKey]Box)
+			}
+			rr.measured[key] = textBox
+		}
+	}
+
+	if rr.rotateRadians == nil {
+		return textBox
+	}
+
+	return textBox.Corners().Rotate(util.Math.RadiansToDegrees(*rr.rotateRadians)).Box()
+}
+
+// measureText computes the unrotated bounds of a string with the current font settings.
+func (rr *rasterRenderer) measureText(body string) (Box, error) {
 	rr.gc.SetFont(rr.s.Font)
 	rr.gc.SetFontSize(rr.s.FontSize)
 	rr.gc.SetFillColor(rr.s.FontColor)
-	l, t, r, b, err := rr.gc.GetStringBounds(body)
+	l, t, r, b, err := rr.gc.GetStringBounds(visualText(body))
 	if err != nil {
-		return Box{}
+		return Box{}, err
 	}
 	if l < 0 {
 		r = r - l // equivalent to r+(-1*l)
@@ -182,17 +352,12 @@ func (rr *rasterRenderer) MeasureText(body string) Box {
 		t = 0
 	}
 
-	textBox := Box{
+	return Box{
 		Top:    int(math.Ceil(t)),
 		Left:   int(math.Ceil(l)),
 		Right:  int(math.Ceil(r)),
 		Bottom: int(math.Ceil(b)),
-	}
-	if rr.rotateRadians == nil {
-		return textBox
-	}
-
-	return textBox.Corners().Rotate(util.Math.RadiansToDegrees(*rr.rotateRadians)).Box()
+	}, nil
 }
 
 // SetTextRotation sets a text rotation.
@@ -214,15 +379,92 @@ func (rr *rasterRenderer) getCoords(x, y int) (xf, yf int) {
 
 // ClearTextRotation clears text rotation.
 func (rr *rasterRenderer) ClearTextRotation() {
-	rr.gc.SetMatrixTransform(drawing.NewIdentityMatrix())
+	rr.gc.SetMatrixTransform(rr.baseTransform())
 	rr.rotateRadians = nil
 }
 
+// SetScaleFactor resizes the raster to a multiple of its logical size and scales all drawing to match,
+// so callers keep drawing in logical pixels.
+func (rr *rasterRenderer) SetScaleFactor(scale float64) error {
+	if scale <= 0 || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return fmt.Errorf("invalid scale factor: %v", scale)
+	}
+	bounds := rr.i.Bounds()
+	if current := rr.getScaleFactor(); current != 1 {
+		bounds = image.Rect(0, 0, int(math.Ceil(float64(bounds.Dx())/current)), int(math.Ceil(float64(bounds.Dy())/current)))
+	}
+	if scale != 1 {
+		width, height := scaledSize(bounds.Dx(), bounds.Dy(), scale)
+		if err := DefaultCanvasLimits.Check(width, height); err != nil {
+			return err
+		}
+		i := image.NewRGBA(image.Rect(0, 0, width, height))
+		gc, err := drawing.NewRasterGraphicContext(i)
+		if err != nil {
+			return err
+		}
+		gc.SetDPI(rr.gc.GetDPI())
+		rr.i, rr.gc = i, gc
+	}
+	rr.scale = scale
+	rr.gc.SetMatrixTransform(rr.baseTransform())
+	return nil
+}
+
+func (rr *rasterRenderer) getScaleFactor() float64 {
+	if rr.scale == 0 {
+		return 1
+	}
+	return rr.scale
+}
+
+// baseTransform is the transform drawing starts from; it maps logical pixels to raster pixels.
+func (rr *rasterRenderer) baseTransform() drawing.Matrix {
+	if scale := rr.getScaleFactor(); scale != 1 {
+		return drawing.NewScaleMatrix(scale, scale)
+	}
+	return drawing.NewIdentityMatrix()
+}
+
 // Save implements the interface method.
 func (rr *rasterRenderer) Save(w io.Writer) error {
 	if typed, isTyped := w.(RGBACollector); isTyped {
+		// the collector keeps the pixel buffer, so it can't be returned to a pool.
 		typed.SetRGBA(rr.i)
 		return nil
 	}
-	return png.Encode(w, rr.i)
+	if len(rr.metadata) > 0 && rr.encoder == nil {
+		buffer := bytes.NewBuffer(nil)
+		if err := rr.encode(buffer); err != nil {
+			return err
+		}
+		return writePNGMetadata(w, buffer.Bytes(), rr.metadata)
+	}
+	return rr.encode(w)
+}
+
+// SetMetadata sets text metadata to write into the png as iTXt chunks.
+func (rr *rasterRenderer) SetMetadata(metadata map[string]string) {
+	if rr.normalizedFont != nil {
+		return
+	}
+	rr.metadata = metadata
+}
+
+func (rr *rasterRenderer) encode(w io.Writer) error {
+	if rr.encoder != nil {
+		return rr.encoder(w, rr.i)
+	}
+	if rr.deterministic {
+		encoder := png.Encoder{CompressionLevel: DeterministicPNGCompression}
+		return encoder.Encode(w, rr.i)
+	}
+	if rr.pool == nil {
+		return png.Encode(w, rr.i)
+	}
+
+	encoder := png.Encoder{BufferPool: rr.pool}
+	err := encoder.Encode(w, rr.i)
+	rr.pool.releaseRaster(rr)
+	return err
 }
@@ -0,0 +1,231 @@
+package chart
+
+import (
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// HorizontalLine is a line across the full width of the canvas at a y value, i.e. a threshold or an SLO target.
+// Its label is drawn above the right end of the line.
+type HorizontalLine struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+
+	Value float64
+	Label string
+}
+
+// GetName returns the name of the line.
+func (hl HorizontalLine) GetName() string {
+	return hl.Name
+}
+
+// GetStyle returns the line style.
+func (hl HorizontalLine) GetStyle() Style {
+	return hl.Style
+}
+
+// GetYAxis returns which YAxis the line draws on.
+func (hl HorizontalLine) GetYAxis() YAxisType {
+	return hl.YAxis
+}
+
+// GetBounds implements `BoundsProvider`; the y range includes the value, so the line is always drawn.
+func (hl HorizontalLine) GetBounds() (minX, maxX, minY, maxY float64) {
+	return math.MaxFloat64, -math.MaxFloat64, hl.Value, hl.Value
+}
+
+// Render renders the line.
+func (hl HorizontalLine) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := hl.Style.InheritFrom(defaults)
+	y := canvasBox.Bottom - yrange.Translate(hl.Value)
+	if y < canvasBox.Top || y > canvasBox.Bottom {
+		return
+	}
+
+	style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(canvasBox.Left, y)
+	r.LineTo(canvasBox.Right, y)
+	r.Stroke()
+
+	if hl.Label != "" {
+		labelStyle := referenceLabelStyle(style)
+		tb := Draw.MeasureText(r, hl.Label, labelStyle)
+		ly := y - DefaultReferenceLabelPadding
+		if ly-tb.Height() < canvasBox.Top {
+			ly = y + DefaultReferenceLabelPadding + tb.Height()
+		}
+		Draw.Text(r, hl.Label, canvasBox.Right-tb.Width()-DefaultReferenceLabelPadding, ly, labelStyle)
+	}
+}
+
+// Validate validates the line.
+func (hl HorizontalLine) Validate() error {
+	if math.IsNaN(hl.Value) || math.IsInf(hl.Value, 0) {
+		return newValidationError(ErrInvalidSeries, "horizontal line value must be finite; got %v", hl.Value)
+	}
+	return nil
+}
+
+// VerticalLine is a line across the full height of the canvas at an x value, i.e. a deploy marker; on a time axis
+// the value is `util.Time.ToFloat64` of the time. Its label is drawn to the right of the top of the line.
+type VerticalLine struct {
+	Name  string
+	Style Style
+	XAxis XAxisType
+	YAxis YAxisType
+
+	Value float64
+	Label string
+}
+
+// GetName returns the name of the line.
+func (vl VerticalLine) GetName() string {
+	return vl.Name
+}
+
+// GetStyle returns the line style.
+func (vl VerticalLine) GetStyle() Style {
+	return vl.Style
+}
+
+// GetYAxis returns which YAxis the line draws on.
+func (vl VerticalLine) GetYAxis() YAxisType {
+	return vl.YAxis
+}
+
+// GetXAxis returns which XAxis the line draws on.
+func (vl VerticalLine) GetXAxis() XAxisType {
+	return vl.XAxis
+}
+
+// GetBounds implements `BoundsProvider`; the x range includes the value, so the line is always drawn.
+func (vl VerticalLine) GetBounds() (minX, maxX, minY, maxY float64) {
+	return vl.Value, vl.Value, math.MaxFloat64, -math.MaxFloat64
+}
+
+// Render renders the line.
+func (vl VerticalLine) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := vl.Style.InheritFrom(defaults)
+	x := canvasBox.Left + xrange.Translate(vl.Value)
+	if x < canvasBox.Left || x > canvasBox.Right {
+		return
+	}
+
+	style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(x, canvasBox.Top)
+	r.LineTo(x, canvasBox.Bottom)
+	r.Stroke()
+
+	if vl.Label != "" {
+		labelStyle := referenceLabelStyle(style)
+		tb := Draw.MeasureText(r, vl.Label, labelStyle)
+		lx := x + DefaultReferenceLabelPadding
+		if lx+tb.Width() > canvasBox.Right {
+			lx = x - DefaultReferenceLabelPadding - tb.Width()
+		}
+		Draw.Text(r, vl.Label, lx, canvasBox.Top+DefaultReferenceLabelPadding+tb.Height(), labelStyle)
+	}
+}
+
+// Validate validates the line.
+func (vl VerticalLine) Validate() error {
+	if math.IsNaN(vl.Value) || math.IsInf(vl.Value, 0) {
+		return newValidationError(ErrInvalidSeries, "vertical line value must be finite; got %v", vl.Value)
+	}
+	return nil
+}
+
+// ShadedRegion shades an interval of x values across the full height of the canvas, i.e. a maintenance window;
+// with `Horizontal` set it shades an interval of y values across the full width instead, i.e. a target band.
+// The region is filled with a translucent version of the series color and stroked only if the style has a stroke
+// color. Its label is drawn in its top left corner.
+type ShadedRegion struct {
+	Name  string
+	Style Style
+	XAxis XAxisType
+	YAxis YAxisType
+
+	From, To   float64
+	Horizontal bool
+	Label      string
+}
+
+// GetName returns the name of the region.
+func (sr ShadedRegion) GetName() string {
+	return sr.Name
+}
+
+// GetStyle returns the region style.
+func (sr ShadedRegion) GetStyle() Style {
+	return sr.Style
+}
+
+// GetYAxis returns which YAxis the region draws on.
+func (sr ShadedRegion) GetYAxis() YAxisType {
+	return sr.YAxis
+}
+
+// GetXAxis returns which XAxis the region draws on.
+func (sr ShadedRegion) GetXAxis() XAxisType {
+	return sr.XAxis
+}
+
+// GetBounds implements `BoundsProvider`; the range of the interval's axis includes the interval.
+func (sr ShadedRegion) GetBounds() (minX, maxX, minY, maxY float64) {
+	min, max := math.Min(sr.From, sr.To), math.Max(sr.From, sr.To)
+	if sr.Horizontal {
+		return math.MaxFloat64, -math.MaxFloat64, min, max
+	}
+	return min, max, math.MaxFloat64, -math.MaxFloat64
+}
+
+// Render renders the region.
+func (sr ShadedRegion) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := sr.Style.InheritFrom(defaults.InheritFrom(Style{FillColor: defaults.StrokeColor.WithAlpha(32)}))
+
+	region := canvasBox
+	if sr.Horizontal {
+		y0, y1 := canvasBox.Bottom-yrange.Translate(sr.From), canvasBox.Bottom-yrange.Translate(sr.To)
+		region.Top, region.Bottom = util.Math.MaxInt(canvasBox.Top, util.Math.MinInt(y0, y1)), util.Math.MinInt(canvasBox.Bottom, util.Math.MaxInt(y0, y1))
+	} else {
+		x0, x1 := canvasBox.Left+xrange.Translate(sr.From), canvasBox.Left+xrange.Translate(sr.To)
+		region.Left, region.Right = util.Math.MaxInt(canvasBox.Left, util.Math.MinInt(x0, x1)), util.Math.MinInt(canvasBox.Right, util.Math.MaxInt(x0, x1))
+	}
+	if region.Right < region.Left || region.Bottom < region.Top {
+		return
+	}
+
+	fill := style.GetFillOptions()
+	if !sr.Style.StrokeColor.IsZero() {
+		fill = style.GetFillAndStrokeOptions()
+	}
+	Draw.Box(r, region, fill)
+
+	if sr.Label != "" {
+		labelStyle := referenceLabelStyle(style)
+		tb := Draw.MeasureText(r, sr.Label, labelStyle)
+		Draw.Text(r, sr.Label, region.Left+DefaultReferenceLabelPadding, region.Top+DefaultReferenceLabelPadding+tb.Height(), labelStyle)
+	}
+}
+
+// Validate validates the region.
+func (sr ShadedRegion) Validate() error {
+	for _, value := range []float64{sr.From, sr.To} {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return newValidationError(ErrInvalidSeries, "shaded region bounds must be finite; got %v to %v", sr.From, sr.To)
+		}
+	}
+	return nil
+}
+
+// referenceLabelStyle returns the style of the label of a reference line or region, which is in the series color.
+func referenceLabelStyle(style Style) Style {
+	return Style{
+		Font:      style.Font,
+		FontSize:  style.GetFontSize(DefaultFontSize),
+		FontColor: style.GetFontColor(style.GetStrokeColor()),
+	}
+}
@@ -0,0 +1,64 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestReferenceSeriesBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+			HorizontalLine{Value: 10, Label: "slo"},
+			VerticalLine{Value: -1, Label: "deploy"},
+			ShadedRegion{From: 5, To: 4, Label: "maintenance"},
+		},
+	}
+	xrange, yrange, _ := c.getRanges()
+	assert.Equal(-1.0, xrange.GetMin())
+	assert.Equal(5.0, xrange.GetMax())
+	assert.Equal(1.0, yrange.GetMin())
+	assert.Equal(10.0, yrange.GetMax())
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
+
+func TestReferenceSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(HorizontalLine{Value: 1}.Validate())
+	assert.NotNil(HorizontalLine{Value: math.NaN()}.Validate())
+	assert.NotNil(VerticalLine{Value: math.Inf(1)}.Validate())
+	assert.NotNil(ShadedRegion{From: 0, To: math.NaN()}.Validate())
+}
+
+func TestShadedRegionRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  200,
+		Height: 200,
+		YAxis:  YAxis{Range: &ContinuousRange{Min: 0, Max: 10}},
+		Series: []Series{
+			ContinuousSeries{Style: Style{Show: true, StrokeColor: ColorBlack, StrokeWidth: 1}, XValues: []float64{0, 10}, YValues: []float64{0, 10}},
+			ShadedRegion{Style: Style{Show: true, FillColor: ColorRed}, From: 8, To: 20, Horizontal: true},
+		},
+	}
+	img, err := c.RenderToImage()
+	assert.Nil(err)
+
+	// the region is clamped to the canvas, so the top of the canvas is shaded and the bottom is not.
+	r, g, b, _ := img.At(190, 5).RGBA()
+	assert.Equal(ColorRed.R, uint8(r>>8))
+	assert.Equal(ColorRed.G, uint8(g>>8))
+	assert.Equal(ColorRed.B, uint8(b>>8))
+	r, _, _, _ = img.At(190, 190).RGBA()
+	assert.Equal(uint8(255), uint8(r>>8))
+}
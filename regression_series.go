@@ -0,0 +1,130 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart/matrix"
+	"github.com/wcharczuk/go-chart/seq"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// regressionFit fits a model to a set of points and returns the fitted function.
+type regressionFit func(xvalues, yvalues []float64) (func(float64) float64, error)
+
+// linearFit fits `y = mx+b` over normalized x values, which keeps large
+// x values (i.e. timestamps) well conditioned.
+func linearFit(xvalues, yvalues []float64) (func(float64) float64, error) {
+	if len(xvalues) < 2 {
+		return nil, fmt.Errorf("linear regression requires at least 2 values, has %d", len(xvalues))
+	}
+
+	avgx := seq.New(seq.NewArray(xvalues...)).Average()
+	stddevx := seq.New(seq.NewArray(xvalues...)).StdDev()
+	if stddevx == 0 {
+		return nil, fmt.Errorf("linear regression requires distinct x values")
+	}
+
+	p := float64(len(xvalues))
+	var sumx, sumy, sumxx, sumxy float64
+	for index, x := range xvalues {
+		x = (x - avgx) / stddevx
+		y := yvalues[index]
+		sumx += x
+		sumy += y
+		sumxx += x * x
+		sumxy += x * y
+	}
+
+	m := (p*sumxy - sumx*sumy) / (p*sumxx - sumx*sumx)
+	b := (sumy / p) - (m * sumx / p)
+	return func(x float64) float64 {
+		return m*((x-avgx)/stddevx) + b
+	}, nil
+}
+
+// polynomialFit returns a fit of the given degree.
+func polynomialFit(degree int) regressionFit {
+	return func(xvalues, yvalues []float64) (func(float64) float64, error) {
+		if len(xvalues) <= degree {
+			return nil, fmt.Errorf("polynomial regression of degree %d requires at least %d values, has %d", degree, degree+1, len(xvalues))
+		}
+		coeffs, err := matrix.Poly(xvalues, yvalues, degree)
+		if err != nil {
+			return nil, err
+		}
+		return func(x float64) (out float64) {
+			for index, coeff := range coeffs {
+				out = out + (coeff * math.Pow(x, float64(index)))
+			}
+			return
+		}, nil
+	}
+}
+
+// exponentialFit fits `y = a*e^(bx)` by fitting a line to `ln(y)`; values
+// that are not positive have no logarithm and are skipped.
+func exponentialFit(xvalues, yvalues []float64) (func(float64) float64, error) {
+	var logx, logy []float64
+	for index, y := range yvalues {
+		if y > 0 {
+			logx = append(logx, xvalues[index])
+			logy = append(logy, math.Log(y))
+		}
+	}
+	fit, err := linearFit(logx, logy)
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64) float64 {
+		return math.Exp(fit(x))
+	}, nil
+}
+
+// regressionValues returns the inner series values in [start, end).
+func regressionValues(inner ValuesProvider, start, end int) (xvalues, yvalues []float64) {
+	if end < start {
+		return
+	}
+	xvalues = make([]float64, end-start)
+	yvalues = make([]float64, end-start)
+	for index := start; index < end; index++ {
+		xvalues[index-start], yvalues[index-start] = inner.GetValues(index)
+	}
+	return
+}
+
+// rollingRegressionValues fits the (up to) `window` values ending at `index`,
+// never reaching before `start`, and evaluates the fit at `index`. Until there
+// are enough values to fit, the inner value is returned as is.
+func rollingRegressionValues(inner ValuesProvider, start, index, window int, fit regressionFit) (x, y float64) {
+	x, y = inner.GetValues(index)
+	xvalues, yvalues := regressionValues(inner, util.Math.MaxInt(start, index-window+1), index+1)
+	if f, err := fit(xvalues, yvalues); err == nil {
+		y = f(x)
+	}
+	return
+}
+
+// rSquared returns the coefficient of determination of the regression values
+// given by `valueAt` against the inner series values in [start, end).
+func rSquared(inner ValuesProvider, start, end int, valueAt func(int) (float64, float64)) float64 {
+	_, yvalues := regressionValues(inner, start, end)
+	if len(yvalues) == 0 {
+		return 0
+	}
+	avgy := seq.New(seq.NewArray(yvalues...)).Average()
+	var ssres, sstot float64
+	for index, y := range yvalues {
+		_, fitted := valueAt(start + index)
+		ssres += (y - fitted) * (y - fitted)
+		sstot += (y - avgy) * (y - avgy)
+	}
+	if sstot == 0 {
+		if ssres == 0 {
+			return 1
+		}
+		return 0
+	}
+	return 1 - ssres/sstot
+}
@@ -0,0 +1,220 @@
+package chart
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"image"
+	imagedraw "image/draw"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// RenderCache memoizes the layers of a chart that don't depend on its series values, for dashboards that render
+// the same chart every few seconds with new data: the background, canvas and axes under the series, and the grid
+// lines placed above the series, the title and the legend over them. While the settings of the chart and its laid
+// out ranges and ticks are unchanged, a render copies the cached layers rather than drawing them, and only draws
+// the series, elements and hooks; a chart with fixed ranges only draws its series.
+//
+// The layers are keyed by a hash of the chart settings, the layout and the legend entries. Values behind pointers,
+// other than the ranges, are compared by address, and functions by their code, so changes made in place to i.e.
+// a `Unit` or a background image, or to the variables a formatter closes over, aren't seen; call `Reset` after
+// making one. The cache is only used for raster output, and not by charts with a `BeforeRender` hook, which draws
+// between the layers it holds. It holds the layers of one chart, and is safe for concurrent use.
+type RenderCache struct {
+	lock  sync.Mutex
+	key   uint64
+	under *image.RGBA
+	over  *image.RGBA
+
+	hits   uint64
+	misses uint64
+}
+
+// RenderCacheStats are counters describing the effectiveness of a render cache.
+type RenderCacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// Stats returns the hit and miss counters of the cache.
+func (rc *RenderCache) Stats() RenderCacheStats {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	return RenderCacheStats{Hits: rc.hits, Misses: rc.misses}
+}
+
+// Reset drops the cached layers, forcing the next render to draw them.
+func (rc *RenderCache) Reset() {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	rc.key, rc.under, rc.over = 0, nil, nil
+}
+
+// get returns the layers cached for a key, or nils if there are none.
+func (rc *RenderCache) get(key uint64) (under, over *image.RGBA) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	if rc.under == nil || rc.key != key {
+		rc.misses++
+		return nil, nil
+	}
+	rc.hits++
+	return rc.under, rc.over
+}
+
+// put caches the layers of a key, replacing the layers of any other key.
+func (rc *RenderCache) put(key uint64, under, over *image.RGBA) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	rc.key, rc.under, rc.over = key, under, over
+}
+
+// getRenderCacheRaster returns the raster renderer a render can be cached for, if the chart has a cache.
+func (c Chart) getRenderCacheRaster(r Renderer) (*rasterRenderer, bool) {
+	if c.RenderCache == nil || c.BeforeRender != nil {
+		return nil, false
+	}
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	rr, isRaster := r.(*rasterRenderer)
+	return rr, isRaster
+}
+
+// paintCached paints the layers under and over the series from the render cache, drawing and caching them first
+// if the settings or layout of the chart have changed; the layer over the series is drawn on its own transparent
+// raster and composited over them.
+func (c Chart) paintCached(r Renderer, rr *rasterRenderer, cl ChartLayout) {
+	key := c.getRenderCacheKey(cl)
+	under, over := c.RenderCache.get(key)
+	if under != nil {
+		imagedraw.Draw(rr.i, rr.i.Bounds(), under, image.Point{}, imagedraw.Src)
+		c.paintSeries(r, cl)
+		imagedraw.Draw(rr.i, rr.i.Bounds(), over, image.Point{}, imagedraw.Over)
+		return
+	}
+
+	c.paintUnderSeries(r, cl)
+	under = copyRGBA(rr.i)
+	layer, err := c.NewRenderer(PNG)
+	if err != nil {
+		c.paintSeries(r, cl)
+		c.paintOverSeries(r, cl)
+		return
+	}
+	c.paintOverSeries(layer, cl)
+	lr, _ := c.getRenderCacheRaster(layer)
+	over = lr.i
+	c.RenderCache.put(key, under, over)
+
+	c.paintSeries(r, cl)
+	imagedraw.Draw(rr.i, rr.i.Bounds(), over, image.Point{}, imagedraw.Over)
+}
+
+// getRenderCacheKey returns the hash of everything the cached layers are drawn from: the chart without its series,
+// the laid out ranges and ticks, and the legend entries of the series.
+func (c Chart) getRenderCacheKey(cl ChartLayout) uint64 {
+	keyed := c
+	keyed.Series, keyed.Elements, keyed.RenderCache = nil, nil, nil
+	keyed.Log, keyed.SpanHook, keyed.OnRenderTimings, keyed.timer, keyed.AfterRender = nil, nil, nil, nil, nil
+	// the ranges are hashed as they are laid out.
+	keyed.XAxis.Range, keyed.XAxisSecondary.Range, keyed.YAxis.Range, keyed.YAxisSecondary.Range = nil, nil, nil, nil
+
+	h := renderCacheHash{fnv.New64a()}
+	h.write(reflect.ValueOf(keyed))
+	h.write(reflect.ValueOf(cl.Canvas))
+	for _, ra := range []Range{cl.XRange, cl.XRangeSecondary, cl.YRange, cl.YRangeSecondary} {
+		if ra == nil {
+			h.writeUint64(0)
+			continue
+		}
+		h.writeFloat64(ra.GetMin(), ra.GetMax(), float64(ra.GetDomain()))
+		if ra.IsDescending() {
+			h.writeUint64(1)
+		} else {
+			h.writeUint64(0)
+		}
+	}
+	for _, ticks := range [][]Tick{cl.XTicks, cl.XTicksSecondary, cl.YTicks, cl.YTicksSecondary} {
+		h.write(reflect.ValueOf(ticks))
+	}
+	if c.shouldDrawLegend() {
+		labels, lines := c.getLegendEntries()
+		h.write(reflect.ValueOf(labels))
+		h.write(reflect.ValueOf(lines))
+	}
+	return h.Sum64()
+}
+
+// renderCacheHash hashes values by their contents; pointers, channels and functions are hashed by address.
+type renderCacheHash struct {
+	hash.Hash64
+}
+
+func (h renderCacheHash) writeUint64(values ...uint64) {
+	var buffer [8]byte
+	for _, v := range values {
+		binary.LittleEndian.PutUint64(buffer[:], v)
+		h.Write(buffer[:])
+	}
+}
+
+func (h renderCacheHash) writeFloat64(values ...float64) {
+	for _, v := range values {
+		h.writeUint64(math.Float64bits(v))
+	}
+}
+
+func (h renderCacheHash) write(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		h.writeUint64(0)
+	case reflect.Bool:
+		if v.Bool() {
+			h.writeUint64(1)
+		} else {
+			h.writeUint64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.writeUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.writeUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.writeFloat64(v.Float())
+	case reflect.String:
+		h.writeUint64(uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Ptr, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		h.writeUint64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.writeUint64(0)
+			return
+		}
+		h.Write([]byte(v.Elem().Type().String()))
+		h.write(v.Elem())
+	case reflect.Struct:
+		for index := 0; index < v.NumField(); index++ {
+			h.write(v.Field(index))
+		}
+	case reflect.Slice, reflect.Array:
+		h.writeUint64(uint64(v.Len()))
+		for index := 0; index < v.Len(); index++ {
+			h.write(v.Index(index))
+		}
+	case reflect.Map:
+		// map entries are unordered, so their hashes are summed.
+		var sum uint64
+		for iter := v.MapRange(); iter.Next(); {
+			entry := renderCacheHash{fnv.New64a()}
+			entry.write(iter.Key())
+			entry.write(iter.Value())
+			sum += entry.Sum64()
+		}
+		h.writeUint64(uint64(v.Len()), sum)
+	default:
+		h.writeUint64(uint64(v.Kind()))
+	}
+}
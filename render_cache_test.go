@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func renderCacheTestChart(yvalues []float64) Chart {
+	return Chart{
+		Title:         "Requests",
+		TitleStyle:    Style{Show: true},
+		GridPlacement: GridPlacementAboveSeries,
+		Legend:        LegendOptions{Style: Style{Show: true}, Placement: LegendPlacementTop},
+		XAxis:         XAxis{Style: Style{Show: true}, Range: &ContinuousRange{Min: 0, Max: 10}},
+		YAxis: YAxis{
+			Style:          Style{Show: true},
+			Range:          &ContinuousRange{Min: -10, Max: 10},
+			GridMajorStyle: Style{Show: true, StrokeColor: ColorBlack, StrokeWidth: 1},
+			GridBandStyle:  Style{Show: true},
+			Zero:           GridLine{Style: Style{Show: true}},
+		},
+		Series: []Series{
+			ContinuousSeries{Name: "latency", Style: Style{Show: true, StrokeColor: ColorBlue, FillColor: ColorBlue.WithAlpha(128)}, XValues: []float64{0, 2, 4, 6, 8, 10}, YValues: yvalues},
+		},
+	}
+}
+
+func renderCacheTestImage(t *testing.T, c Chart) *image.RGBA {
+	collector := &ImageWriter{}
+	if err := c.Render(PNG, collector); err != nil {
+		t.Fatal(err)
+	}
+	i, err := collector.Image()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i.(*image.RGBA)
+}
+
+func TestRenderCache(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &RenderCache{}
+	for _, yvalues := range [][]float64{{1, 5, -3, 8, 2, -6}, {2, -4, 6, 1, -8, 3}} {
+		c := renderCacheTestChart(yvalues)
+		uncached := renderCacheTestImage(t, c)
+		c.RenderCache = cache
+		cached := renderCacheTestImage(t, c)
+		// the layer over the series is composited, which rounds antialiased edges differently.
+		assert.True(maxChannelDelta(uncached, cached) <= 2)
+	}
+	assert.Equal(RenderCacheStats{Hits: 1, Misses: 1}, cache.Stats())
+
+	c := renderCacheTestChart([]float64{1, 2, 3, 4, 5, 6})
+	c.RenderCache = cache
+	c.Title = "Errors"
+	renderCacheTestImage(t, c)
+	assert.Equal(RenderCacheStats{Hits: 1, Misses: 2}, cache.Stats())
+
+	cache.Reset()
+	renderCacheTestImage(t, c)
+	assert.Equal(RenderCacheStats{Hits: 1, Misses: 3}, cache.Stats())
+
+	c.BeforeRender = func(r Renderer, cl ChartLayout) {}
+	renderCacheTestImage(t, c)
+	assert.Equal(RenderCacheStats{Hits: 1, Misses: 3}, cache.Stats())
+}
+
+func TestRenderCacheKey(t *testing.T) {
+	assert := assert.New(t)
+
+	c := renderCacheTestChart([]float64{1, 2, 3, 4, 5, 6}).withDefaults()
+	cl := ChartLayout{Canvas: Box{Right: 100, Bottom: 100}, XRange: &ContinuousRange{Min: 0, Max: 10, Domain: 100}}
+	key := c.getRenderCacheKey(cl)
+
+	c.Series = []Series{ContinuousSeries{Name: "latency", Style: c.Series[0].GetStyle(), XValues: []float64{0}, YValues: []float64{9}}}
+	assert.Equal(key, c.getRenderCacheKey(cl))
+
+	cl.XRange = &ContinuousRange{Min: 0, Max: 10.001, Domain: 100}
+	assert.NotEqual(key, c.getRenderCacheKey(cl))
+
+	cl.XRange = &ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	c.Series = []Series{ContinuousSeries{Name: "throughput", Style: c.Series[0].GetStyle()}}
+	assert.NotEqual(key, c.getRenderCacheKey(cl))
+}
@@ -0,0 +1,37 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrRenderPanic is the kind of error returned by `Render` when rendering panicked.
+var ErrRenderPanic = errors.New("render panicked")
+
+// RenderPanicError is returned by `Render` in place of a panic raised while rendering, i.e. by a malformed series.
+// It can be tested for with `errors.Is(err, ErrRenderPanic)`; if the panic value was an error it is also unwrapped.
+type RenderPanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error implements error.
+func (rpe *RenderPanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrRenderPanic, rpe.Value)
+}
+
+// Unwrap returns `ErrRenderPanic` and the panic value if it is an error.
+func (rpe *RenderPanicError) Unwrap() []error {
+	if err, isError := rpe.Value.(error); isError {
+		return []error{ErrRenderPanic, err}
+	}
+	return []error{ErrRenderPanic}
+}
+
+// recoverRender turns a panic in a render into a `RenderPanicError`; it must be deferred directly.
+func recoverRender(err *error) {
+	if r := recover(); r != nil {
+		*err = &RenderPanicError{Value: r, Stack: debug.Stack()}
+	}
+}
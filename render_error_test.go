@@ -0,0 +1,62 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+// panicSeries is a series that panics when rendered.
+type panicSeries struct {
+	ContinuousSeries
+}
+
+func (ps panicSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	var values []float64
+	_ = values[len(ps.XValues)]
+}
+
+func TestChartRenderRecoversPanics(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			panicSeries{ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}}},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	err := c.Render(PNG, buffer)
+	assert.NotNil(err)
+	assert.True(errors.Is(err, ErrRenderPanic))
+
+	var rpe *RenderPanicError
+	assert.True(errors.As(err, &rpe))
+	assert.NotEmpty(rpe.Stack)
+	assert.Zero(buffer.Len())
+
+	ic := &IncrementalChart{Chart: c}
+	assert.True(errors.Is(ic.Render(buffer), ErrRenderPanic))
+}
+
+func TestChartRenderMismatchedLengths(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2}},
+		},
+	}
+	err := c.Render(PNG, bytes.NewBuffer(nil))
+	assert.True(errors.Is(err, ErrLengthMismatch))
+	assert.False(errors.Is(err, ErrRenderPanic))
+}
+
+func TestPieChartRenderRecoversPanics(t *testing.T) {
+	assert := assert.New(t)
+
+	pie := PieChart{Values: []Value{{Value: 1}}}
+	err := pie.Render(func(int, int) (Renderer, error) { return nil, nil }, bytes.NewBuffer(nil))
+	assert.True(errors.Is(err, ErrRenderPanic))
+}
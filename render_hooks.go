@@ -0,0 +1,13 @@
+package chart
+
+// RenderHook draws on a chart with its final layout, i.e. custom decorations that need the canvas box
+// or the ranges; see `Chart.BeforeRender` and `Chart.AfterRender`. Charts other than `Chart` fill in
+// the parts of the layout they have: the canvas, and the value range (and ticks) if they have one.
+type RenderHook func(r Renderer, cl ChartLayout)
+
+// callRenderHook calls a render hook if it is set.
+func callRenderHook(hook RenderHook, r Renderer, cl ChartLayout) {
+	if hook != nil {
+		hook(r, cl)
+	}
+}
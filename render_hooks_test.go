@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestChartRenderHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls []string
+	var before, after ChartLayout
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		YAxis: YAxis{Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 4, 9}},
+		},
+		BeforeRender: func(r Renderer, cl ChartLayout) {
+			calls = append(calls, "before")
+			before = cl
+		},
+		AfterRender: func(r Renderer, cl ChartLayout) {
+			calls = append(calls, "after")
+			after = cl
+			r.SetStrokeColor(ColorRed)
+			r.MoveTo(cl.Canvas.Left, cl.Canvas.Bottom-cl.YRange.Translate(5))
+			r.LineTo(cl.Canvas.Right, cl.Canvas.Bottom-cl.YRange.Translate(5))
+			r.Stroke()
+		},
+	}
+
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Equal([]string{"before", "after"}, calls)
+	assert.False(before.Canvas.IsZero())
+	assert.Equal(before.Canvas, after.Canvas)
+	assert.NotEmpty(after.XTicks)
+	assert.Equal(9.0, after.YRange.GetMax())
+}
+
+func TestBarChartRenderHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	var cl ChartLayout
+	var called int
+	bc := BarChart{
+		YAxis: YAxis{Style: StyleShow()},
+		Bars:  []Value{{Value: 1}, {Value: 3}},
+		BeforeRender: func(r Renderer, layout ChartLayout) {
+			called++
+		},
+		AfterRender: func(r Renderer, layout ChartLayout) {
+			called++
+			cl = layout
+		},
+	}
+	assert.Nil(bc.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Equal(2, called)
+	assert.False(cl.Canvas.IsZero())
+	assert.NotNil(cl.YRange)
+	assert.NotEmpty(cl.YTicks)
+}
+
+func TestPieChartRenderHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	var cl ChartLayout
+	pc := PieChart{
+		Values:      []Value{{Value: 1}, {Value: 3}},
+		AfterRender: func(r Renderer, layout ChartLayout) { cl = layout },
+	}
+	assert.Nil(pc.Render(PNG, bytes.NewBuffer(nil)))
+	assert.False(cl.Canvas.IsZero())
+	assert.Nil(cl.YRange)
+}
@@ -0,0 +1,83 @@
+package chart
+
+import "time"
+
+// RenderPhase is a phase of a chart render.
+type RenderPhase string
+
+const (
+	// RenderPhaseRender is the whole render; the other phases happen within it.
+	RenderPhaseRender RenderPhase = "render"
+	// RenderPhaseMeasure is reading the series values to compute the ranges.
+	RenderPhaseMeasure RenderPhase = "measure"
+	// RenderPhaseLayout is generating and measuring ticks and fitting the canvas around the axes.
+	RenderPhaseLayout RenderPhase = "layout"
+	// RenderPhaseSeries is drawing the series.
+	RenderPhaseSeries RenderPhase = "series"
+	// RenderPhaseEncode is encoding the output (i.e. png compression) and writing it.
+	RenderPhaseEncode RenderPhase = "encode"
+)
+
+// RenderTimings are the durations of the phases of a render.
+// Drawing the background, canvas, axes and title is included in `Total` only.
+type RenderTimings struct {
+	Measure time.Duration
+	Layout  time.Duration
+	Series  time.Duration
+	Encode  time.Duration
+	Total   time.Duration
+}
+
+// SpanHook is called when a render phase starts and returns a func called when it ends.
+// It is meant for tracing, i.e. with OpenTelemetry:
+//
+//	graph.SpanHook = func(phase chart.RenderPhase) func() {
+//		_, span := tracer.Start(ctx, "chart."+string(phase))
+//		return func() { span.End() }
+//	}
+type SpanHook func(phase RenderPhase) (end func())
+
+// renderTimer records the timings of a render and reports phases to the span hook.
+// A nil timer records nothing.
+type renderTimer struct {
+	hook    SpanHook
+	timings RenderTimings
+}
+
+// newRenderTimer returns a timer if the chart wants timings or spans.
+func (c Chart) newRenderTimer() *renderTimer {
+	if c.SpanHook == nil && c.OnRenderTimings == nil {
+		return nil
+	}
+	return &renderTimer{hook: c.SpanHook}
+}
+
+// start starts a phase; the returned func ends it.
+func (rt *renderTimer) start(phase RenderPhase) func() {
+	if rt == nil {
+		return func() {}
+	}
+	var end func()
+	if rt.hook != nil {
+		end = rt.hook(phase)
+	}
+	started := time.Now()
+	return func() {
+		elapsed := time.Since(started)
+		switch phase {
+		case RenderPhaseMeasure:
+			rt.timings.Measure += elapsed
+		case RenderPhaseLayout:
+			rt.timings.Layout += elapsed
+		case RenderPhaseSeries:
+			rt.timings.Series += elapsed
+		case RenderPhaseEncode:
+			rt.timings.Encode += elapsed
+		case RenderPhaseRender:
+			rt.timings.Total += elapsed
+		}
+		if end != nil {
+			end()
+		}
+	}
+}
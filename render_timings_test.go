@@ -0,0 +1,65 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestChartRenderTimings(t *testing.T) {
+	assert := assert.New(t)
+
+	var phases []string
+	var timings []RenderTimings
+	c := Chart{
+		SpanHook: func(phase RenderPhase) func() {
+			phases = append(phases, "start "+string(phase))
+			return func() {
+				phases = append(phases, "end "+string(phase))
+			}
+		},
+		OnRenderTimings: func(rt RenderTimings) {
+			timings = append(timings, rt)
+		},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+
+	assert.Equal([]string{
+		"start render",
+		"start measure", "end measure",
+		"start layout", "end layout",
+		"start series", "end series",
+		"start encode", "end encode",
+		"end render",
+	}, phases)
+	assert.Len(timings, 1)
+	assert.True(timings[0].Encode > 0)
+	assert.True(timings[0].Total >= timings[0].Measure+timings[0].Layout+timings[0].Series+timings[0].Encode)
+}
+
+func TestChartRenderTimingsOnError(t *testing.T) {
+	assert := assert.New(t)
+
+	var timings []RenderTimings
+	c := Chart{
+		OnRenderTimings: func(rt RenderTimings) {
+			timings = append(timings, rt)
+		},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 1}, YValues: []float64{1, 1}},
+		},
+	}
+	assert.NotNil(c.Render(PNG, bytes.NewBuffer(nil)))
+	assert.Len(timings, 1)
+	assert.Zero(timings[0].Series)
+}
+
+func TestRenderTimerNil(t *testing.T) {
+	var rt *renderTimer
+	rt.start(RenderPhaseSeries)()
+	assert.New(t).Nil(Chart{}.newRenderTimer())
+}
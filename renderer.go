@@ -41,6 +41,9 @@ type Renderer interface {
 	// cx and cy represent the bezier "control points".
 	QuadCurveTo(cx, cy, x, y int)
 
+	// CubicCurveTo draws a cubic bezier curve to a given point, with two control points (c1x,c1y) and (c2x,c2y).
+	CubicCurveTo(c1x, c1y, c2x, c2y, x, y int)
+
 	// ArcTo draws an arc with a given center (cx,cy)
 	// a given set of radii (rx,ry), a startAngle and delta (in radians).
 	ArcTo(cx, cy int, rx, ry, startAngle, delta float64)
@@ -48,6 +51,10 @@ type Renderer interface {
 	// Close finalizes a shape as drawn by LineTo.
 	Close()
 
+	// Polygon adds a closed shape through the given points to the path; like Circle, it is
+	// drawn by the following Stroke, Fill or FillStroke.
+	Polygon(points []Point)
+
 	// Stroke strokes the path.
 	Stroke()
 
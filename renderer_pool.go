@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"sync"
+)
+
+// NewRendererPool returns a new renderer pool.
+func NewRendererPool() *RendererPool {
+	return &RendererPool{
+		raster: map[rendererPoolKey]*sync.Pool{},
+	}
+}
+
+type rendererPoolKey struct {
+	width, height int
+}
+
+// RendererPool hands out renderers whose pixel buffers, raster contexts and svg byte buffers
+// are reused across renders. Its `PNG` and `SVG` methods are `RendererProvider`s:
+//
+//	pool := chart.NewRendererPool()
+//	graph.Render(pool.PNG, w)
+//
+// Renderers are returned to the pool when `Save` completes, so a renderer must not be used after it is saved.
+// It is safe for concurrent use.
+type RendererPool struct {
+	lock   sync.Mutex
+	raster map[rendererPoolKey]*sync.Pool
+	vector sync.Pool
+	encode sync.Pool
+}
+
+// PNG returns a pooled png/raster renderer.
+func (rp *RendererPool) PNG(width, height int) (Renderer, error) {
+	if pooled := rp.rasterPool(width, height).Get(); pooled != nil {
+		rr := pooled.(*rasterRenderer)
+		rr.reset()
+		return rr, nil
+	}
+
+	r, err := PNG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	rr := r.(*rasterRenderer)
+	rr.pool = rp
+	return rr, nil
+}
+
+// SVG returns a pooled svg/vector renderer.
+func (rp *RendererPool) SVG(width, height int) (Renderer, error) {
+	var buffer *bytes.Buffer
+	if pooled := rp.vector.Get(); pooled != nil {
+		buffer = pooled.(*bytes.Buffer)
+		buffer.Reset()
+	} else {
+		buffer = bytes.NewBuffer([]byte{})
+	}
+	vr := newVectorRenderer(buffer, width, height)
+	vr.pool = rp
+	return vr, nil
+}
+
+// Get implements png.EncoderBufferPool.
+func (rp *RendererPool) Get() *png.EncoderBuffer {
+	if pooled := rp.encode.Get(); pooled != nil {
+		return pooled.(*png.EncoderBuffer)
+	}
+	return nil
+}
+
+// Put implements png.EncoderBufferPool.
+func (rp *RendererPool) Put(eb *png.EncoderBuffer) {
+	rp.encode.Put(eb)
+}
+
+func (rp *RendererPool) rasterPool(width, height int) *sync.Pool {
+	rp.lock.Lock()
+	defer rp.lock.Unlock()
+	key := rendererPoolKey{width: width, height: height}
+	if pool, hasPool := rp.raster[key]; hasPool {
+		return pool
+	}
+	pool := &sync.Pool{}
+	rp.raster[key] = pool
+	return pool
+}
+
+func (rp *RendererPool) releaseRaster(rr *rasterRenderer) {
+	bounds := rr.i.Bounds()
+	rp.rasterPool(bounds.Dx(), bounds.Dy()).Put(rr)
+}
+
+func (rp *RendererPool) releaseVector(buffer *bytes.Buffer) {
+	rp.vector.Put(buffer)
+}
+
+// reset prepares a pooled raster renderer for a new render.
+func (rr *rasterRenderer) reset() {
+	pix := rr.i.Pix
+	for index := range pix {
+		pix[index] = 0
+	}
+	rr.gc.Reset()
+	rr.rotateRadians = nil
+	rr.s = Style{}
+	rr.gradientBox = Box{}
+	rr.scale = 0
+	rr.metadata = nil
+}
@@ -0,0 +1,79 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestRendererPoolPNG(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewRendererPool()
+	c := Chart{
+		Width:  200,
+		Height: 100,
+		Series: []Series{
+			ContinuousSeries{
+				XValues: []float64{1.0, 2.0, 3.0},
+				YValues: []float64{1.0, 2.0, 3.0},
+			},
+		},
+	}
+
+	first := bytes.NewBuffer([]byte{})
+	assert.Nil(c.Render(pool.PNG, first))
+
+	second := bytes.NewBuffer([]byte{})
+	assert.Nil(c.Render(pool.PNG, second))
+	assert.Equal(first.Bytes(), second.Bytes())
+
+	unpooled := bytes.NewBuffer([]byte{})
+	assert.Nil(c.Render(PNG, unpooled))
+	assert.Equal(unpooled.Bytes(), second.Bytes())
+}
+
+func TestRendererPoolReusesRaster(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewRendererPool()
+	r, err := pool.PNG(10, 10)
+	assert.Nil(err)
+	rr := r.(*rasterRenderer)
+	rr.i.Pix[0] = 0xff
+	rr.SetFillGradient(&Gradient{}, Box{Right: 10, Bottom: 10})
+	assert.Nil(r.Save(bytes.NewBuffer([]byte{})))
+
+	reused, err := pool.PNG(10, 10)
+	assert.Nil(err)
+	if reused.(*rasterRenderer) == rr { // sync.Pool may drop items, only check state when reused.
+		assert.Zero(rr.i.Pix[0])
+		assert.True(rr.gradientBox.IsZero())
+	}
+
+	other, err := pool.PNG(20, 10)
+	assert.Nil(err)
+	assert.Equal(20, other.(*rasterRenderer).i.Bounds().Dx())
+}
+
+func TestRendererPoolSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewRendererPool()
+	for x := 0; x < 2; x++ {
+		r, err := pool.SVG(100, 100)
+		assert.Nil(err)
+		r.MoveTo(0, 0)
+		r.LineTo(100, 100)
+		r.Stroke()
+
+		buffer := bytes.NewBuffer([]byte{})
+		assert.Nil(r.Save(buffer))
+		raw := buffer.String()
+		assert.True(strings.HasPrefix(raw, "<svg"))
+		assert.Equal(1, strings.Count(raw, "<svg"))
+		assert.True(strings.HasSuffix(raw, "</svg>"))
+	}
+}
@@ -0,0 +1,196 @@
+package chart
+
+import (
+	"io"
+	"sync"
+)
+
+// NewRollingChart returns a rolling chart whose series keep the last `capacity` points pushed to them.
+func NewRollingChart(capacity int) *RollingChart {
+	return &RollingChart{
+		Capacity: capacity,
+		pool:     NewRendererPool(),
+	}
+}
+
+// RollingChart is a chart of live data. Each of its series keeps the most recent points pushed to it in a
+// fixed size ring buffer, dropping the oldest points as new ones arrive, and points can be pushed from any
+// goroutine while the chart renders.
+//
+// Renders reuse the buffers the points are copied into, and the pixel buffers of the chart's renderer pool
+// (see `PNG` and `SVG`); text measurements are cached by `DefaultTextMeasureCache`. A handler serving the chart
+// to a dashboard can render it on every request:
+//
+//	graph := chart.NewRollingChart(300)
+//	go func() { for sample := range samples { graph.Push("cpu", sample.X, sample.Y) } }()
+//	...
+//	res.Header().Set("Content-Type", chart.ContentTypePNG)
+//	graph.Render(graph.PNG, res)
+type RollingChart struct {
+	// Chart is the chart the series are drawn on; its own series are drawn under the rolling series.
+	// It must not be changed while the chart renders.
+	Chart Chart
+	// Capacity is the number of points each series keeps; it defaults to `DefaultRollingChartCapacity`.
+	// It is read when a series is added.
+	Capacity int
+
+	lock   sync.Mutex
+	series []*RollingSeries
+	pool   *RendererPool
+
+	renderLock   sync.Mutex
+	renderSeries []Series
+}
+
+// GetCapacity returns the capacity of the series or a default.
+func (rc *RollingChart) GetCapacity() int {
+	if rc.Capacity > 0 {
+		return rc.Capacity
+	}
+	return DefaultRollingChartCapacity
+}
+
+// Series returns the series with the given name, adding it if there isn't one.
+func (rc *RollingChart) Series(name string) *RollingSeries {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	for _, rs := range rc.series {
+		if rs.Name == name {
+			return rs
+		}
+	}
+	rs := NewRollingSeries(name, rc.GetCapacity())
+	rc.series = append(rc.series, rs)
+	return rs
+}
+
+// Push adds a point to the series with the given name, adding the series if there isn't one.
+func (rc *RollingChart) Push(name string, x, y float64) {
+	rc.Series(name).Push(x, y)
+}
+
+// PNG is a `RendererProvider` of png renderers from the chart's renderer pool.
+func (rc *RollingChart) PNG(width, height int) (Renderer, error) {
+	return rc.getPool().PNG(width, height)
+}
+
+// SVG is a `RendererProvider` of svg renderers from the chart's renderer pool.
+func (rc *RollingChart) SVG(width, height int) (Renderer, error) {
+	return rc.getPool().SVG(width, height)
+}
+
+func (rc *RollingChart) getPool() *RendererPool {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	if rc.pool == nil {
+		rc.pool = NewRendererPool()
+	}
+	return rc.pool
+}
+
+// Render renders the points of the series at the time of the call with the given renderer to the given io.Writer.
+// Series without points are left out. Renders are serialized; pushes are not blocked while the chart renders.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (rc *RollingChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	rc.renderLock.Lock()
+	defer rc.renderLock.Unlock()
+	defer recoverRender(&err)
+
+	rc.lock.Lock()
+	series := rc.series
+	rc.lock.Unlock()
+
+	c := rc.Chart
+	rc.renderSeries = append(rc.renderSeries[:0], c.Series...)
+	for _, rs := range series {
+		if snapshot := rs.snapshot(); len(snapshot.XValues) > 0 {
+			rc.renderSeries = append(rc.renderSeries, snapshot)
+		}
+	}
+	if len(rc.renderSeries) == 0 {
+		return newValidationError(ErrEmptySeries, "please push at least one point")
+	}
+	c.Series = rc.renderSeries
+	return c.Render(rp, w)
+}
+
+// NewRollingSeries returns a rolling series that keeps the last `capacity` points pushed to it.
+func NewRollingSeries(name string, capacity int) *RollingSeries {
+	if capacity <= 0 {
+		capacity = DefaultRollingChartCapacity
+	}
+	return &RollingSeries{
+		Name:    name,
+		xvalues: make([]float64, capacity),
+		yvalues: make([]float64, capacity),
+	}
+}
+
+// RollingSeries is a ring buffer of the most recent points of a series of a `RollingChart`.
+// Points can be pushed concurrently; its style and axis must be set before it is rendered from another goroutine.
+type RollingSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+
+	lock             sync.Mutex
+	xvalues, yvalues []float64
+	head, size       int
+
+	// xsnapshot and ysnapshot are reused by every render, which are serialized by the chart.
+	xsnapshot, ysnapshot []float64
+}
+
+// Push adds a point, dropping the oldest point if the series is full.
+func (rs *RollingSeries) Push(x, y float64) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	tail := (rs.head + rs.size) % len(rs.xvalues)
+	rs.xvalues[tail], rs.yvalues[tail] = x, y
+	if rs.size < len(rs.xvalues) {
+		rs.size++
+	} else {
+		rs.head = (rs.head + 1) % len(rs.xvalues)
+	}
+}
+
+// Len returns the number of points kept.
+func (rs *RollingSeries) Len() int {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	return rs.size
+}
+
+// Values returns a copy of the points kept, oldest first.
+func (rs *RollingSeries) Values() (xvalues, yvalues []float64) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	return rs.copyInto(nil, nil)
+}
+
+// copyInto copies the points into the given slices, reusing their storage, oldest first.
+func (rs *RollingSeries) copyInto(xvalues, yvalues []float64) ([]float64, []float64) {
+	xvalues, yvalues = xvalues[:0], yvalues[:0]
+	end := rs.head + rs.size
+	if end <= len(rs.xvalues) {
+		return append(xvalues, rs.xvalues[rs.head:end]...), append(yvalues, rs.yvalues[rs.head:end]...)
+	}
+	end -= len(rs.xvalues)
+	xvalues = append(append(xvalues, rs.xvalues[rs.head:]...), rs.xvalues[:end]...)
+	yvalues = append(append(yvalues, rs.yvalues[rs.head:]...), rs.yvalues[:end]...)
+	return xvalues, yvalues
+}
+
+// snapshot returns the series as a continuous series of its current points.
+func (rs *RollingSeries) snapshot() ContinuousSeries {
+	rs.lock.Lock()
+	rs.xsnapshot, rs.ysnapshot = rs.copyInto(rs.xsnapshot, rs.ysnapshot)
+	rs.lock.Unlock()
+	return ContinuousSeries{
+		Name:    rs.Name,
+		Style:   rs.Style,
+		YAxis:   rs.YAxis,
+		XValues: rs.xsnapshot,
+		YValues: rs.ysnapshot,
+	}
+}
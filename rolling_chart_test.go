@@ -0,0 +1,68 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestRollingSeriesPush(t *testing.T) {
+	assert := assert.New(t)
+
+	rs := NewRollingSeries("test", 3)
+	rs.Push(1, 10)
+	rs.Push(2, 20)
+	xvalues, yvalues := rs.Values()
+	assert.Equal([]float64{1, 2}, xvalues)
+	assert.Equal([]float64{10, 20}, yvalues)
+
+	// the oldest points are dropped once the series is full.
+	for x := 3.0; x <= 5; x++ {
+		rs.Push(x, x*10)
+	}
+	assert.Equal(3, rs.Len())
+	xvalues, yvalues = rs.Values()
+	assert.Equal([]float64{3, 4, 5}, xvalues)
+	assert.Equal([]float64{30, 40, 50}, yvalues)
+}
+
+func TestRollingChartRender(t *testing.T) {
+	assert := assert.New(t)
+
+	rc := NewRollingChart(50)
+	assert.True(errors.Is(rc.Render(rc.PNG, bytes.NewBuffer(nil)), ErrEmptySeries))
+
+	rc.Push("a", -2, 0)
+	rc.Push("a", -1, 1)
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for x := 0; x < 200; x++ {
+				rc.Push(name, float64(x), float64(x%7))
+			}
+		}(name)
+	}
+	for i := 0; i < 5; i++ {
+		assert.Nil(rc.Render(rc.PNG, bytes.NewBuffer(nil)))
+	}
+	wg.Wait()
+
+	assert.Equal(50, rc.Series("a").Len())
+	xvalues, _ := rc.Series("b").Values()
+	assert.Equal(150.0, xvalues[0])
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(rc.Render(rc.SVG, buffer))
+	assert.NotZero(buffer.Len())
+
+	// panics raised while rendering are returned, and don't hold the chart's locks.
+	panicking := func(int, int) (Renderer, error) { panic("provider") }
+	assert.True(errors.Is(rc.Render(panicking, bytes.NewBuffer(nil)), ErrRenderPanic))
+	assert.Nil(rc.Render(rc.PNG, bytes.NewBuffer(nil)))
+}
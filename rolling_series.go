@@ -0,0 +1,322 @@
+package chart
+
+import (
+	"math"
+	"sort"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+const (
+	// DefaultRollingPeriod is the default number of values in the window of rolling series.
+	DefaultRollingPeriod = 16
+)
+
+// rollingValue returns the x value of the inner series at an index, and the statistic of the finite y values of
+// the window of a period of values ending there; windows at the start of the series hold the values so far, and
+// it is NaN for windows without finite values.
+// The window is read from the inner series on every call, so values edited in place (i.e. by a ring buffer that
+// is full) are never stale.
+func rollingValue(inner ValuesProvider, period, index int, statistic func(sorted []float64) float64) (x, y float64) {
+	if inner == nil || index < 0 || index >= inner.Len() {
+		return
+	}
+	x, _ = inner.GetValues(index)
+	window := make([]float64, 0, period)
+	for cursor := util.Math.MaxInt(index-period+1, 0); cursor <= index; cursor++ {
+		if _, vy := inner.GetValues(cursor); !math.IsNaN(vy) && !math.IsInf(vy, 0) {
+			window = append(window, vy)
+		}
+	}
+	if len(window) == 0 {
+		return x, math.NaN()
+	}
+	sort.Float64s(window)
+	return x, statistic(window)
+}
+
+// getRollingPeriod returns a period or the default.
+func getRollingPeriod(period int) int {
+	if period <= 0 {
+		return DefaultRollingPeriod
+	}
+	return period
+}
+
+// validateRolling validates the inner series of a rolling series.
+func validateRolling(kind string, inner ValuesProvider) error {
+	if inner == nil {
+		return newValidationError(ErrInvalidSeries, "%s series requires InnerSeries to be set", kind)
+	}
+	return nil
+}
+
+// RollingMinSeries is the minimum of a trailing window of values of an inner series,
+// i.e. the lower line of an envelope.
+type RollingMinSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	// Period is the number of values in the window; it defaults to `DefaultRollingPeriod`.
+	Period      int
+	InnerSeries ValuesProvider
+}
+
+// GetName returns the name of the time series.
+func (rms RollingMinSeries) GetName() string {
+	return rms.Name
+}
+
+// GetStyle returns the line style.
+func (rms RollingMinSeries) GetStyle() Style {
+	return rms.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (rms RollingMinSeries) GetYAxis() YAxisType {
+	return rms.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (rms RollingMinSeries) GetXAxis() XAxisType {
+	return rms.XAxis
+}
+
+// GetPeriod returns the window size.
+func (rms RollingMinSeries) GetPeriod() int {
+	return getRollingPeriod(rms.Period)
+}
+
+// Len returns the number of elements in the series.
+func (rms RollingMinSeries) Len() int {
+	return rms.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (rms *RollingMinSeries) GetValues(index int) (x, y float64) {
+	return rollingValue(rms.InnerSeries, rms.GetPeriod(), index, func(sorted []float64) float64 {
+		return sorted[0]
+	})
+}
+
+// GetLastValues gets the last value.
+func (rms *RollingMinSeries) GetLastValues() (x, y float64) {
+	return rms.GetValues(rms.Len() - 1)
+}
+
+// Render renders the series.
+func (rms *RollingMinSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := rms.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, rms)
+}
+
+// Validate validates the series.
+func (rms *RollingMinSeries) Validate() error {
+	return validateRolling("rolling min", rms.InnerSeries)
+}
+
+// RollingMaxSeries is the maximum of a trailing window of values of an inner series,
+// i.e. the upper line of an envelope.
+type RollingMaxSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	// Period is the number of values in the window; it defaults to `DefaultRollingPeriod`.
+	Period      int
+	InnerSeries ValuesProvider
+}
+
+// GetName returns the name of the time series.
+func (rms RollingMaxSeries) GetName() string {
+	return rms.Name
+}
+
+// GetStyle returns the line style.
+func (rms RollingMaxSeries) GetStyle() Style {
+	return rms.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (rms RollingMaxSeries) GetYAxis() YAxisType {
+	return rms.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (rms RollingMaxSeries) GetXAxis() XAxisType {
+	return rms.XAxis
+}
+
+// GetPeriod returns the window size.
+func (rms RollingMaxSeries) GetPeriod() int {
+	return getRollingPeriod(rms.Period)
+}
+
+// Len returns the number of elements in the series.
+func (rms RollingMaxSeries) Len() int {
+	return rms.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (rms *RollingMaxSeries) GetValues(index int) (x, y float64) {
+	return rollingValue(rms.InnerSeries, rms.GetPeriod(), index, func(sorted []float64) float64 {
+		return sorted[len(sorted)-1]
+	})
+}
+
+// GetLastValues gets the last value.
+func (rms *RollingMaxSeries) GetLastValues() (x, y float64) {
+	return rms.GetValues(rms.Len() - 1)
+}
+
+// Render renders the series.
+func (rms *RollingMaxSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := rms.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, rms)
+}
+
+// Validate validates the series.
+func (rms *RollingMaxSeries) Validate() error {
+	return validateRolling("rolling max", rms.InnerSeries)
+}
+
+// RollingMedianSeries is the median of a trailing window of values of an inner series; unlike a moving
+// average, it isn't pulled off by single outliers.
+type RollingMedianSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	// Period is the number of values in the window; it defaults to `DefaultRollingPeriod`.
+	Period      int
+	InnerSeries ValuesProvider
+}
+
+// GetName returns the name of the time series.
+func (rms RollingMedianSeries) GetName() string {
+	return rms.Name
+}
+
+// GetStyle returns the line style.
+func (rms RollingMedianSeries) GetStyle() Style {
+	return rms.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (rms RollingMedianSeries) GetYAxis() YAxisType {
+	return rms.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (rms RollingMedianSeries) GetXAxis() XAxisType {
+	return rms.XAxis
+}
+
+// GetPeriod returns the window size.
+func (rms RollingMedianSeries) GetPeriod() int {
+	return getRollingPeriod(rms.Period)
+}
+
+// Len returns the number of elements in the series.
+func (rms RollingMedianSeries) Len() int {
+	return rms.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (rms *RollingMedianSeries) GetValues(index int) (x, y float64) {
+	return rollingValue(rms.InnerSeries, rms.GetPeriod(), index, func(sorted []float64) float64 {
+		return quantile(sorted, 0.5)
+	})
+}
+
+// GetLastValues gets the last value.
+func (rms *RollingMedianSeries) GetLastValues() (x, y float64) {
+	return rms.GetValues(rms.Len() - 1)
+}
+
+// Render renders the series.
+func (rms *RollingMedianSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := rms.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, rms)
+}
+
+// Validate validates the series.
+func (rms *RollingMedianSeries) Validate() error {
+	return validateRolling("rolling median", rms.InnerSeries)
+}
+
+// RollingPercentileSeries is a percentile of a trailing window of values of an inner series, interpolated
+// linearly between the closest values; i.e. the 95th percentile as a threshold values are anomalous above.
+type RollingPercentileSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	// Percentile is the percentile from 0 to 100.
+	Percentile float64
+	// Period is the number of values in the window; it defaults to `DefaultRollingPeriod`.
+	Period      int
+	InnerSeries ValuesProvider
+}
+
+// GetName returns the name of the time series.
+func (rps RollingPercentileSeries) GetName() string {
+	return rps.Name
+}
+
+// GetStyle returns the line style.
+func (rps RollingPercentileSeries) GetStyle() Style {
+	return rps.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (rps RollingPercentileSeries) GetYAxis() YAxisType {
+	return rps.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (rps RollingPercentileSeries) GetXAxis() XAxisType {
+	return rps.XAxis
+}
+
+// GetPeriod returns the window size.
+func (rps RollingPercentileSeries) GetPeriod() int {
+	return getRollingPeriod(rps.Period)
+}
+
+// Len returns the number of elements in the series.
+func (rps RollingPercentileSeries) Len() int {
+	return rps.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (rps *RollingPercentileSeries) GetValues(index int) (x, y float64) {
+	q := math.Min(math.Max(rps.Percentile/100, 0), 1)
+	return rollingValue(rps.InnerSeries, rps.GetPeriod(), index, func(sorted []float64) float64 {
+		return quantile(sorted, q)
+	})
+}
+
+// GetLastValues gets the last value.
+func (rps *RollingPercentileSeries) GetLastValues() (x, y float64) {
+	return rps.GetValues(rps.Len() - 1)
+}
+
+// Render renders the series.
+func (rps *RollingPercentileSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := rps.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, rps)
+}
+
+// Validate validates the series.
+func (rps *RollingPercentileSeries) Validate() error {
+	if rps.Percentile < 0 || rps.Percentile > 100 || math.IsNaN(rps.Percentile) {
+		return newValidationError(ErrInvalidRange, "rolling percentile must be from 0 to 100; got %v", rps.Percentile)
+	}
+	return validateRolling("rolling percentile", rps.InnerSeries)
+}
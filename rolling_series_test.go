@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func rollingTestSeries() mockValuesProvider {
+	return mockValuesProvider{
+		X: []float64{1, 2, 3, 4, 5, 6, 7},
+		Y: []float64{5, 1, 4, math.NaN(), 2, 8, 3},
+	}
+}
+
+func rollingSeriesValues(vp ValuesProvider) []float64 {
+	values := make([]float64, vp.Len())
+	for index := range values {
+		_, values[index] = vp.GetValues(index)
+	}
+	return values
+}
+
+func TestRollingValues(t *testing.T) {
+	assert := assert.New(t)
+
+	// the window of 3 values slides past the NaN, which is left out.
+	assert.Equal([]float64{5, 1, 1, 1, 2, 2, 2}, rollingSeriesValues(&RollingMinSeries{Period: 3, InnerSeries: rollingTestSeries()}))
+	assert.Equal([]float64{5, 5, 5, 4, 4, 8, 8}, rollingSeriesValues(&RollingMaxSeries{Period: 3, InnerSeries: rollingTestSeries()}))
+	assert.Equal([]float64{5, 3, 4, 2.5, 3, 5, 3}, rollingSeriesValues(&RollingMedianSeries{Period: 3, InnerSeries: rollingTestSeries()}))
+
+	first := func(sorted []float64) float64 {
+		return sorted[0]
+	}
+	_, y := rollingValue(mockValuesProvider{X: []float64{1, 2}, Y: []float64{math.NaN(), 1}}, 1, 0, first)
+	assert.True(math.IsNaN(y))
+	_, y = rollingValue(mockValuesProvider{X: []float64{1, 2}, Y: []float64{math.NaN(), 1}}, 1, 1, first)
+	assert.Equal(1.0, y)
+}
+
+func TestRollingPercentileSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	rps := &RollingPercentileSeries{Percentile: 75, Period: 4, InnerSeries: mockValuesProvider{
+		X: []float64{1, 2, 3, 4, 5},
+		Y: []float64{1, 2, 3, 4, 5},
+	}}
+	x, y := rps.GetLastValues()
+	assert.Equal(5.0, x)
+	assert.Equal(4.25, y)
+	assert.Equal(DefaultRollingPeriod, RollingPercentileSeries{}.GetPeriod())
+
+	assert.Nil(rps.Validate())
+	assert.True(errors.Is((&RollingPercentileSeries{Percentile: 101, InnerSeries: rps.InnerSeries}).Validate(), ErrInvalidRange))
+	assert.True(errors.Is((&RollingMinSeries{}).Validate(), ErrInvalidSeries))
+}
+
+func TestRollingSeriesChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := mockValuesProvider{X: []float64{1, 2, 3, 4}, Y: []float64{1, 2, 3, 4}}
+	rps := &RollingPercentileSeries{Percentile: 100, Period: 2, InnerSeries: inner}
+	_, y := rps.GetLastValues()
+	assert.Equal(4.0, y)
+
+	// changes to the series and to its inner values are seen by the next call, whatever the length.
+	rps.Percentile = 0
+	_, y = rps.GetLastValues()
+	assert.Equal(3.0, y)
+	rps.Period = 4
+	_, y = rps.GetLastValues()
+	assert.Equal(1.0, y)
+	inner.Y[0] = 10
+	_, y = rps.GetLastValues()
+	assert.Equal(2.0, y)
+}
+
+func TestRollingSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := ContinuousSeries{XValues: []float64{1, 2, 3, 4, 5, 6}, YValues: []float64{3, 1, 4, 1, 5, 9}}
+	c := Chart{
+		Series: []Series{
+			inner,
+			&RollingMinSeries{Period: 3, InnerSeries: inner},
+			&RollingMaxSeries{Period: 3, InnerSeries: inner},
+			&RollingMedianSeries{Period: 3, InnerSeries: inner},
+			&RollingPercentileSeries{Percentile: 90, Period: 3, InnerSeries: inner},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+}
@@ -0,0 +1,360 @@
+package chart
+
+import (
+	"io"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// RoseChart is a nightingale rose (or polar area) chart; it draws a wedge for each value at equal angles,
+// with the area of the wedge proportional to the value. The first wedge starts at the top and they go clockwise,
+// which suits cyclical data like months of the year.
+type RoseChart struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+	SliceStyle Style
+
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
+	// GridStyle is the style of the circular grid lines and their value labels;
+	// they are drawn if the style is zero or shown.
+	GridStyle      Style
+	ValueFormatter ValueFormatter
+
+	Font        *truetype.Font
+	defaultFont *truetype.Font
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Values   []Value
+	Elements []Renderable
+}
+
+// GetDPI returns the dpi for the chart.
+func (rc RoseChart) GetDPI(defaults ...float64) float64 {
+	if rc.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return rc.DPI
+}
+
+// GetFont returns the text font.
+func (rc RoseChart) GetFont() *truetype.Font {
+	if rc.Font == nil {
+		return rc.defaultFont
+	}
+	return rc.Font
+}
+
+// GetWidth returns the chart width or the default value.
+func (rc RoseChart) GetWidth() int {
+	if rc.Width == 0 {
+		return DefaultChartWidth
+	}
+	return rc.Width
+}
+
+// GetHeight returns the chart height or the default value.
+func (rc RoseChart) GetHeight() int {
+	if rc.Height == 0 {
+		return DefaultChartWidth
+	}
+	return rc.Height
+}
+
+// GetValueFormatter returns the value formatter of the grid labels or a default.
+func (rc RoseChart) GetValueFormatter() ValueFormatter {
+	if rc.ValueFormatter != nil {
+		return rc.ValueFormatter
+	}
+	return GroupedValueFormatter
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (rc RoseChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateFont(rc.Font, rc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(rc.Values) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one value"))
+	}
+	if rc.getMaxValue() <= 0 {
+		errs = append(errs, newValidationError(ErrInvalidRange, "rose chart must contain at least (1) positive value"))
+	}
+	return errs.asError()
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (rc RoseChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = rc.Validate(); err != nil {
+		return err
+	}
+	max := rc.getMaxValue()
+
+	r, err := rp(rc.GetWidth(), rc.GetHeight())
+	if err != nil {
+		return err
+	}
+
+	if rc.NoText {
+		r = textFreeRenderer{r}
+	} else if rc.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		rc.defaultFont = defaultFont
+	}
+	r.SetDPI(rc.GetDPI(DefaultDPI))
+
+	canvasBox := rc.Box()
+	rc.drawBackground(r)
+	rc.BackgroundImage.draw(r, Box{Right: rc.GetWidth(), Bottom: rc.GetHeight()})
+	Draw.Box(r, canvasBox, rc.Canvas.InheritFrom(rc.styleDefaultsCanvas()))
+	rc.Watermark.draw(r, Box{Right: rc.GetWidth(), Bottom: rc.GetHeight()}, rc.GetFont(), rc.GetColorPalette(), false)
+
+	cx, cy := canvasBox.Center()
+	radius := rc.getRadius(r, canvasBox)
+	yr := &ContinuousRange{Min: 0, Max: max, Domain: int(radius)}
+	cl := ChartLayout{Canvas: canvasBox, YRange: yr}
+	callRenderHook(rc.BeforeRender, r, cl)
+
+	rc.drawWedges(r, cx, cy, radius, max)
+	if rc.GridStyle.IsZero() || rc.GridStyle.Show {
+		rc.drawGrid(r, cx, cy, radius, yr)
+	}
+	rc.drawLabels(r, cx, cy, radius)
+	rc.drawTitle(r)
+	for _, a := range rc.Elements {
+		a(r, canvasBox, rc.styleDefaultsElements())
+	}
+	rc.Watermark.draw(r, Box{Right: rc.GetWidth(), Bottom: rc.GetHeight()}, rc.GetFont(), rc.GetColorPalette(), true)
+	callRenderHook(rc.AfterRender, r, cl)
+
+	setAccessibility(r, rc.Accessibility.withDefaults(rc.Title, func() string {
+		return describeValues("Rose chart", rc.Values)
+	}))
+	return r.Save(w)
+}
+
+// getMaxValue returns the largest value.
+func (rc RoseChart) getMaxValue() (max float64) {
+	for _, v := range rc.Values {
+		if v.Value > max && !math.IsInf(v.Value, 0) {
+			max = v.Value
+		}
+	}
+	return
+}
+
+// getRadius returns the radius of the largest wedge, which leaves room for the labels around the circle.
+func (rc RoseChart) getRadius(r Renderer, canvasBox Box) float64 {
+	var labelWidth, labelHeight int
+	for index, v := range rc.Values {
+		if len(v.Label) > 0 {
+			v.Style.InheritFrom(rc.styleDefaultsLabel(index)).WriteToRenderer(r)
+			tb := r.MeasureText(v.Label)
+			labelWidth = util.Math.MaxInt(labelWidth, tb.Width())
+			labelHeight = util.Math.MaxInt(labelHeight, tb.Height())
+		}
+	}
+	horizontal := (canvasBox.Width() >> 1) - labelWidth - DefaultLineSpacing
+	vertical := (canvasBox.Height() >> 1) - labelHeight - DefaultLineSpacing
+	radius := float64(util.Math.MinInt(horizontal, vertical))
+	return math.Max(radius, float64(util.Math.MinInt(canvasBox.Width(), canvasBox.Height())>>2))
+}
+
+// wedgeRadius returns the radius of the wedge for a value; the area of the wedge is proportional to the value.
+func wedgeRadius(radius, value, max float64) float64 {
+	if value <= 0 || max <= 0 {
+		return 0
+	}
+	return radius * math.Sqrt(value/max)
+}
+
+func (rc RoseChart) drawBackground(r Renderer) {
+	Draw.Box(r, Box{
+		Right:  rc.GetWidth(),
+		Bottom: rc.GetHeight(),
+	}, rc.Background.InheritFrom(rc.styleDefaultsBackground()))
+}
+
+func (rc RoseChart) drawWedges(r Renderer, cx, cy int, radius, max float64) {
+	delta := (2 * math.Pi) / float64(len(rc.Values))
+	for index, v := range rc.Values {
+		wr := wedgeRadius(radius, v.Value, max)
+		if wr == 0 {
+			continue
+		}
+		Draw.Sector(r, cx, cy, wr, float64(index)*delta-_pi2, delta, v.Style.InheritFrom(rc.styleDefaultsWedge(index)))
+	}
+}
+
+// drawGrid draws a circle for each tick of the value range, with its label along the vertical from the center up.
+func (rc RoseChart) drawGrid(r Renderer, cx, cy int, radius float64, yr Range) {
+	gridStyle := rc.GridStyle.InheritFrom(rc.styleDefaultsGrid())
+	ticks := GenerateUnitTicks(r, yr, true, gridStyle, &Unit{}, rc.GetValueFormatter())
+	max := yr.GetMax()
+	for _, t := range ticks {
+		gr := wedgeRadius(radius, t.Value, max)
+		if gr == 0 {
+			continue
+		}
+		gridStyle.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+		strokeArc(r, cx, cy, gr, 0, 2*math.Pi)
+
+		gridStyle.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(t.Label)
+		r.Text(t.Label, cx+DefaultLineSpacing, cy-int(gr)+tb.Height()+DefaultLineSpacing)
+	}
+}
+
+// drawLabels draws the label of each value around the circle, at the middle of its wedge.
+func (rc RoseChart) drawLabels(r Renderer, cx, cy int, radius float64) {
+	delta := (2 * math.Pi) / float64(len(rc.Values))
+	for index, v := range rc.Values {
+		if len(v.Label) == 0 {
+			continue
+		}
+		v.Style.InheritFrom(rc.styleDefaultsLabel(index)).WriteToRenderer(r)
+		tb := r.MeasureText(v.Label)
+
+		// the label box touches the circle at the point nearest to the middle of the wedge.
+		angle := (float64(index) + 0.5) * delta
+		lx, ly := util.Math.CirclePoint(cx, cy, radius+DefaultLineSpacing, angle)
+		sin, cos := math.Sin(angle), math.Cos(angle)
+		lx = lx - tb.Width()>>1 + int(sin*float64(tb.Width()>>1))
+		ly = ly + tb.Height()>>1 - int(cos*float64(tb.Height()>>1))
+		r.Text(v.Label, lx, ly)
+	}
+}
+
+func (rc RoseChart) drawTitle(r Renderer) {
+	if len(rc.Title) > 0 && rc.TitleStyle.Show {
+		Draw.TextWithin(r, rc.Title, rc.Box(), rc.styleDefaultsTitle())
+	}
+}
+
+func (rc RoseChart) styleDefaultsBackground() Style {
+	return Style{
+		FillColor:   rc.GetColorPalette().BackgroundColor(),
+		StrokeColor: rc.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (rc RoseChart) styleDefaultsCanvas() Style {
+	return Style{
+		FillColor:   rc.GetColorPalette().CanvasColor(),
+		StrokeColor: rc.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (rc RoseChart) styleDefaultsWedge(index int) Style {
+	return rc.SliceStyle.InheritFrom(Style{
+		StrokeColor: ColorWhite,
+		StrokeWidth: 2.0,
+		FillColor:   rc.GetColorPalette().GetSeriesColor(index),
+	})
+}
+
+func (rc RoseChart) styleDefaultsLabel(index int) Style {
+	return Style{
+		FontSize:  rc.getScaledFontSize(),
+		FontColor: rc.GetColorPalette().TextColor(),
+		Font:      rc.GetFont(),
+	}
+}
+
+func (rc RoseChart) styleDefaultsGrid() Style {
+	return Style{
+		StrokeColor:     rc.GetColorPalette().AxisStrokeColor(),
+		StrokeWidth:     DefaultAxisLineWidth,
+		StrokeDashArray: []float64{4, 4},
+		FontSize:        DefaultAxisFontSize,
+		FontColor:       rc.GetColorPalette().TextColor(),
+		Font:            rc.GetFont(),
+	}
+}
+
+func (rc RoseChart) styleDefaultsElements() Style {
+	return Style{
+		Font: rc.GetFont(),
+	}
+}
+
+func (rc RoseChart) styleDefaultsTitle() Style {
+	return rc.TitleStyle.InheritFrom(Style{
+		FontColor:           rc.GetColorPalette().TextColor(),
+		Font:                rc.GetFont(),
+		FontSize:            rc.getScaledFontSize() * 1.5,
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+}
+
+func (rc RoseChart) getScaledFontSize() float64 {
+	effectiveDimension := util.Math.MinInt(rc.GetWidth(), rc.GetHeight())
+	if effectiveDimension >= 2048 {
+		return 32.0
+	} else if effectiveDimension >= 1024 {
+		return 16.0
+	} else if effectiveDimension > 512 {
+		return 12.0
+	}
+	return 10.0
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (rc RoseChart) GetColorPalette() ColorPalette {
+	if rc.ColorPalette != nil {
+		return rc.ColorPalette
+	}
+	return AlternateColorPalette
+}
+
+// Box returns the chart bounds as a box.
+func (rc RoseChart) Box() Box {
+	dpr := rc.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
+	dpb := rc.Background.Padding.GetBottom(DefaultBackgroundPadding.Bottom)
+
+	return Box{
+		Top:    rc.Background.Padding.GetTop(DefaultBackgroundPadding.Top),
+		Left:   rc.Background.Padding.GetLeft(DefaultBackgroundPadding.Left),
+		Right:  rc.GetWidth() - dpr,
+		Bottom: rc.GetHeight() - dpb,
+	}
+}
@@ -0,0 +1,46 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestRoseChartRender(t *testing.T) {
+	assert := assert.New(t)
+
+	rc := RoseChart{
+		Title:      "Rainfall",
+		TitleStyle: StyleShow(),
+		Values: []Value{
+			{Label: "Jan", Value: 80}, {Label: "Feb", Value: 60}, {Label: "Mar", Value: 55},
+			{Label: "Apr", Value: 40}, {Label: "May", Value: 0}, {Label: "Jun", Value: 20},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(rc.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	buf.Reset()
+	assert.Nil(rc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "Jun"))
+}
+
+func TestRoseChartRenderNoValues(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(RoseChart{}.Render(PNG, bytes.NewBuffer(nil)))
+	assert.NotNil(RoseChart{Values: []Value{{Value: 0}, {Value: -1}}}.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestWedgeRadius(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(100.0, wedgeRadius(100, 16, 16))
+	assert.Equal(50.0, wedgeRadius(100, 4, 16))
+	assert.Zero(wedgeRadius(100, -4, 16))
+	assert.Zero(wedgeRadius(100, 4, 0))
+}
@@ -0,0 +1,25 @@
+package chart
+
+import "math"
+
+// scaledRenderer is implemented by renderers that can draw at a multiple of their logical size.
+type scaledRenderer interface {
+	SetScaleFactor(scale float64) error
+}
+
+// applyScaleFactor asks the renderer to draw at the given scale factor; renderers without a
+// fixed resolution (i.e. svg) are left as is.
+func applyScaleFactor(r Renderer, scale float64) error {
+	if scale == 1 {
+		return nil
+	}
+	if typed, isTyped := r.(scaledRenderer); isTyped {
+		return typed.SetScaleFactor(scale)
+	}
+	return nil
+}
+
+// scaledSize returns the raster size of a logical size at a scale factor.
+func scaledSize(width, height int, scale float64) (int, int) {
+	return int(math.Ceil(float64(width) * scale)), int(math.Ceil(float64(height) * scale))
+}
@@ -0,0 +1,122 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func scaleFactorTestChart(scale float64) Chart {
+	return Chart{
+		Width:       200,
+		Height:      100,
+		ScaleFactor: scale,
+		Background:  Style{FillColor: drawing.ColorRed},
+		XAxis:       XAxis{Style: StyleShow()},
+		YAxis:       YAxis{Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+}
+
+func TestChartRenderScaleFactor(t *testing.T) {
+	assert := assert.New(t)
+
+	unscaled := &rgbaCollector{}
+	assert.Nil(scaleFactorTestChart(0).Render(PNG, unscaled))
+	assert.Equal(200, unscaled.i.Bounds().Dx())
+
+	scaled := &rgbaCollector{}
+	assert.Nil(scaleFactorTestChart(2).Render(PNG, scaled))
+	assert.Equal(400, scaled.i.Bounds().Dx())
+	assert.Equal(200, scaled.i.Bounds().Dy())
+
+	// the background padding is scaled along with everything else.
+	assert.Equal(unscaled.i.At(2, 2), scaled.i.At(4, 4))
+	assert.Equal(unscaled.i.At(2, 2), scaled.i.At(9, 9))
+
+	// vector output is resolution independent.
+	svg := bytes.NewBuffer(nil)
+	assert.Nil(scaleFactorTestChart(2).Render(SVG, svg))
+	assert.True(strings.Contains(svg.String(), `width="200" height="100"`), svg.String())
+}
+
+func TestChartRenderScaleFactorPooled(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewRendererPool()
+	for index := 0; index < 3; index++ {
+		scaled := &rgbaCollector{}
+		assert.Nil(scaleFactorTestChart(3).Render(pool.PNG, scaled))
+		assert.Equal(600, scaled.i.Bounds().Dx())
+
+		unscaled := bytes.NewBuffer(nil)
+		assert.Nil(scaleFactorTestChart(1).Render(pool.PNG, unscaled))
+	}
+}
+
+func TestChartRenderScaleFactorParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	sequential := scaleFactorTestChart(2)
+	sequential.Series = append(sequential.Series, ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{2, 1, 3}})
+	parallel := sequential
+	parallel.SeriesParallelism = 2
+
+	expected, actual := &rgbaCollector{}, &rgbaCollector{}
+	assert.Nil(sequential.Render(PNG, expected))
+	assert.Nil(parallel.Render(PNG, actual))
+	assert.Equal(expected.i.Bounds(), actual.i.Bounds())
+	maxDelta := maxChannelDelta(expected.i, actual.i)
+	assert.True(maxDelta <= 8, maxDelta)
+}
+
+func TestChartValidateScaleFactor(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(scaleFactorTestChart(2).Validate())
+	assert.True(errors.Is(scaleFactorTestChart(-1).Validate(), ErrInvalidCanvas))
+	assert.NotNil(scaleFactorTestChart(-1).Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestBarAndPieChartScaleFactor(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Width:       200,
+		Height:      100,
+		ScaleFactor: 2,
+		Bars:        []Value{{Value: 1, Label: "a"}, {Value: 2, Label: "b"}},
+	}
+	scaled := &rgbaCollector{}
+	assert.Nil(bc.Render(PNG, scaled))
+	assert.Equal(400, scaled.i.Bounds().Dx())
+	assert.Equal(200, scaled.i.Bounds().Dy())
+
+	pc := PieChart{
+		Width:       100,
+		Height:      100,
+		ScaleFactor: 3,
+		Values:      []Value{{Value: 1, Label: "a"}, {Value: 2, Label: "b"}},
+	}
+	scaled = &rgbaCollector{}
+	assert.Nil(pc.Render(PNG, scaled))
+	assert.Equal(300, scaled.i.Bounds().Dx())
+
+	// svg output keeps its logical size.
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(pc.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), `width="100"`))
+
+	pc.ScaleFactor = -1
+	assert.NotNil(pc.Validate())
+	bc.ScaleFactor = 1000
+	assert.NotNil(bc.Validate())
+	bc.ScaleFactor = 1
+	assert.Nil(bc.Validate())
+}
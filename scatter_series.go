@@ -0,0 +1,144 @@
+package chart
+
+import (
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// ScatterSeries draws a marker at each point, without lines between them.
+// The markers are filled and stroked with the dot color of the style, and are `DotWidth` in size;
+// `Sizes` and `Colors` override them by index, as do the dot width and color providers of the style.
+type ScatterSeries struct {
+	Name  string
+	Style Style
+
+	// Marker draws the markers; it defaults to `MarkerCircle`.
+	Marker Marker
+
+	YAxis YAxisType
+	XAxis XAxisType
+
+	XValueFormatter ValueFormatter
+	YValueFormatter ValueFormatter
+
+	XValues []float64
+	YValues []float64
+
+	// Sizes and Colors are the size and color of each point, by index; points without one,
+	// or with a zero size or color, use the style.
+	Sizes  []float64
+	Colors []drawing.Color
+}
+
+// GetName returns the name of the series.
+func (ss ScatterSeries) GetName() string {
+	return ss.Name
+}
+
+// GetStyle returns the series style.
+func (ss ScatterSeries) GetStyle() Style {
+	return ss.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ss ScatterSeries) GetYAxis() YAxisType {
+	return ss.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (ss ScatterSeries) GetXAxis() XAxisType {
+	return ss.XAxis
+}
+
+// GetMarker returns the marker or a default.
+func (ss ScatterSeries) GetMarker() Marker {
+	if ss.Marker == nil {
+		return MarkerCircle
+	}
+	return ss.Marker
+}
+
+// Len returns the number of points in the series.
+func (ss ScatterSeries) Len() int {
+	return len(ss.XValues)
+}
+
+// GetValues gets the x,y values at a given index.
+func (ss ScatterSeries) GetValues(index int) (float64, float64) {
+	return ss.XValues[index], ss.YValues[index]
+}
+
+// GetLastValues gets the last x,y values.
+func (ss ScatterSeries) GetLastValues() (float64, float64) {
+	return ss.GetValues(len(ss.XValues) - 1)
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (ss ScatterSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x, y = FloatValueFormatter, FloatValueFormatter
+	if ss.XValueFormatter != nil {
+		x = ss.XValueFormatter
+	}
+	if ss.YValueFormatter != nil {
+		y = ss.YValueFormatter
+	}
+	return
+}
+
+// Render renders the series.
+func (ss ScatterSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := ss.Style.InheritFrom(defaults)
+	marker := ss.GetMarker()
+	for index := range ss.XValues {
+		vx, vy := ss.GetValues(index)
+		if !isFinitePoint(vx, vy) {
+			continue
+		}
+		size, color := ss.getPointSize(style, xrange, yrange, index), ss.getPointColor(style, xrange, yrange, index)
+		if size <= 0 || color.IsZero() {
+			continue
+		}
+
+		r.SetFillColor(color)
+		r.SetStrokeColor(color)
+		r.SetStrokeWidth(style.GetStrokeWidth())
+		r.SetStrokeDashArray(nil)
+		setPointTooltip(r, vx, vy)
+		marker(r, canvasBox.Left+xrange.Translate(vx), canvasBox.Bottom-yrange.Translate(vy), size)
+	}
+}
+
+// getPointSize returns the marker size of the point at a given index.
+func (ss ScatterSeries) getPointSize(style Style, xrange, yrange Range, index int) float64 {
+	if index < len(ss.Sizes) && ss.Sizes[index] > 0 {
+		return ss.Sizes[index]
+	}
+	if style.DotWidthProvider != nil {
+		return style.DotWidthProvider(xrange, yrange, index, ss.XValues[index], ss.YValues[index])
+	}
+	return style.GetDotWidth(DefaultScatterMarkerSize)
+}
+
+// getPointColor returns the marker color of the point at a given index.
+func (ss ScatterSeries) getPointColor(style Style, xrange, yrange Range, index int) drawing.Color {
+	if index < len(ss.Colors) && !ss.Colors[index].IsZero() {
+		return ss.Colors[index]
+	}
+	if style.DotColorProvider != nil {
+		return style.DotColorProvider(xrange, yrange, index, ss.XValues[index], ss.YValues[index])
+	}
+	return style.GetDotColor()
+}
+
+// Validate validates the series.
+func (ss ScatterSeries) Validate() error {
+	if len(ss.XValues) == 0 {
+		return newValidationError(ErrEmptySeries, "scatter series must have xvalues set")
+	}
+	if len(ss.YValues) != len(ss.XValues) {
+		return newValidationError(ErrLengthMismatch, "scatter series has %d xvalues but %d yvalues", len(ss.XValues), len(ss.YValues))
+	}
+	if len(ss.Sizes) > len(ss.XValues) || len(ss.Colors) > len(ss.XValues) {
+		return newValidationError(ErrLengthMismatch, "scatter series has %d points but %d sizes and %d colors", len(ss.XValues), len(ss.Sizes), len(ss.Colors))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestScatterSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, marker := range []Marker{MarkerSquare, MarkerTriangle, MarkerDiamond, MarkerCross} {
+		c := Chart{
+			Series: []Series{
+				ScatterSeries{
+					Marker:  marker,
+					XValues: []float64{1, 2, 3, 4},
+					YValues: []float64{4, 1, 3, 2},
+				},
+			},
+		}
+
+		buf := bytes.NewBuffer([]byte{})
+		assert.Nil(c.Render(SVG, buf))
+		// the background, the canvas and one path per marker; the axes are not shown.
+		assert.Equal(6, strings.Count(buf.String(), "<path"))
+	}
+}
+
+func TestScatterSeriesPointOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	red := drawing.Color{R: 255, A: 255}
+	ss := ScatterSeries{
+		XValues: []float64{1, 2, 3},
+		YValues: []float64{1, 2, 3},
+		Sizes:   []float64{10, 0},
+		Colors:  []drawing.Color{{}, red},
+	}
+	style := ss.Style.InheritFrom(Style{DotColor: ColorBlue})
+	xr, yr := &ContinuousRange{}, &ContinuousRange{}
+
+	assert.Equal(10.0, ss.getPointSize(style, xr, yr, 0))
+	assert.Equal(DefaultScatterMarkerSize, ss.getPointSize(style, xr, yr, 1))
+	assert.Equal(DefaultScatterMarkerSize, ss.getPointSize(style, xr, yr, 2))
+	assert.Equal(ColorBlue, ss.getPointColor(style, xr, yr, 0))
+	assert.Equal(red, ss.getPointColor(style, xr, yr, 1))
+
+	style.DotWidthProvider = func(_, _ Range, index int, x, y float64) float64 {
+		return x * 2
+	}
+	assert.Equal(10.0, ss.getPointSize(style, xr, yr, 0))
+	assert.Equal(6.0, ss.getPointSize(style, xr, yr, 2))
+}
+
+func TestScatterSeriesCustomMarker(t *testing.T) {
+	assert := assert.New(t)
+
+	var points []Point
+	c := Chart{
+		Series: []Series{
+			ScatterSeries{
+				Marker: func(r Renderer, x, y int, size float64) {
+					points = append(points, Point{X: x, Y: y})
+				},
+				XValues: []float64{1, 2, 3},
+				YValues: []float64{1, 2, 3},
+			},
+		},
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer([]byte{})))
+	assert.Len(points, 3)
+	assert.True(points[0].X < points[2].X)
+	assert.True(points[0].Y > points[2].Y)
+}
+
+func TestScatterSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	ss := ScatterSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}}
+	assert.Nil(ss.Validate())
+
+	ss.YValues = ss.YValues[:1]
+	assert.True(errors.Is(ss.Validate(), ErrLengthMismatch))
+
+	ss = ScatterSeries{XValues: []float64{1}, YValues: []float64{1}, Sizes: []float64{1, 2}}
+	assert.True(errors.Is(ss.Validate(), ErrLengthMismatch))
+
+	assert.True(errors.Is(ScatterSeries{}.Validate(), ErrEmptySeries))
+}
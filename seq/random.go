@@ -23,6 +23,13 @@ func NewRandom() *Random {
 	}
 }
 
+// NewRandomWithSeed creates a new random seq that generates the same values for the same seed.
+func NewRandomWithSeed(seed int64) *Random {
+	return &Random{
+		rnd: rand.New(rand.NewSource(seed)),
+	}
+}
+
 // Random is a random number seq generator.
 type Random struct {
 	rnd *rand.Rand
@@ -18,3 +18,12 @@ func TestRandomRegression(t *testing.T) {
 	assert.Len(randomValues, 4096)
 	assert.InDelta(128, randomSequence.Average(), 10.0)
 }
+
+func TestRandomWithSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	first := New(NewRandomWithSeed(42).WithLen(16).WithMax(100)).Array()
+	second := New(NewRandomWithSeed(42).WithLen(16).WithMax(100)).Array()
+	assert.Equal(first, second)
+	assert.NotEqual(first, New(NewRandomWithSeed(43).WithLen(16).WithMax(100)).Array())
+}
@@ -1,6 +1,22 @@
 package chart
 
-// Series is an alias to Renderable.
+// Series is a set of values drawn on a chart.
+//
+// A series takes part in a `Chart` as follows:
+//   - Ranges: unless an axis has a fixed range or ticks, the chart reads every visible series to size its ranges;
+//     from `GetBounds` if it is a `BoundsProvider`, otherwise from every value if it is a `BoundedValuesProvider`
+//     or a `ValuesProvider`. Series that are none of these don't affect the ranges.
+//   - Axes: `GetYAxis` picks the y range the series contributes to and is rendered against; likewise
+//     `GetXAxis` picks the x range if the series is an `XAxisProvider`, i.e. `XAxisSecondary` for values on a
+//     different scale along the top. Series that aren't use the primary x axis range.
+//   - Layout: a `MeasurableSeries` that draws outside of the canvas (i.e. labels) reports the box it needs
+//     and the canvas is shrunk to fit it.
+//   - Rendering: `Render` is called with the final canvas box and ranges, and the chart's default style
+//     for the series' index, which `GetStyle` is expected to inherit from; a series is drawn only if its style
+//     is zero or shown. `Validate` is called before rendering.
+//
+// Series types that can't implement the optional interfaces, i.e. types from another package,
+// can register the equivalent hooks with `RegisterSeriesHooks`.
 type Series interface {
 	GetName() string
 	GetYAxis() YAxisType
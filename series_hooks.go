@@ -0,0 +1,100 @@
+package chart
+
+import (
+	"reflect"
+	"sync"
+)
+
+// BoundsProvider is a series that reports its extents directly, instead of having every value read to size the ranges.
+type BoundsProvider interface {
+	GetBounds() (minX, maxX, minY, maxY float64)
+}
+
+// MeasurableSeries is a series that draws outside of the canvas; the chart shrinks the canvas until the box
+// returned by `Measure` fits within the chart. `AnnotationSeries` is a measurable series.
+type MeasurableSeries interface {
+	Series
+	Measure(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) Box
+}
+
+// LegendExcluder is a series that can opt out of legends.
+type LegendExcluder interface {
+	IsLegendExcluded() bool
+}
+
+// SeriesHooks are the optional series behaviors, as funcs, for series types that can't implement the optional interfaces.
+// Nil hooks are ignored. Interfaces implemented by the series take precedence over hooks.
+type SeriesHooks struct {
+	// Bounds is the equivalent of `BoundsProvider`.
+	Bounds func(s Series) (minX, maxX, minY, maxY float64)
+	// Measure is the equivalent of `MeasurableSeries`.
+	Measure func(s Series, r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) Box
+	// ExcludeFromLegend is the equivalent of `LegendExcluder`.
+	ExcludeFromLegend bool
+}
+
+var (
+	seriesHooksLock sync.RWMutex
+	seriesHooks     = map[reflect.Type]SeriesHooks{}
+)
+
+// RegisterSeriesHooks registers hooks for the dynamic type of `prototype`, i.e. `RegisterSeriesHooks(mypkg.Series{}, hooks)`.
+// Registering again for a type replaces its hooks. It is safe to call from multiple goroutines.
+func RegisterSeriesHooks(prototype Series, hooks SeriesHooks) {
+	seriesHooksLock.Lock()
+	defer seriesHooksLock.Unlock()
+	seriesHooks[reflect.TypeOf(prototype)] = hooks
+}
+
+func getSeriesHooks(s Series) (hooks SeriesHooks, hasHooks bool) {
+	seriesHooksLock.RLock()
+	defer seriesHooksLock.RUnlock()
+	if len(seriesHooks) == 0 {
+		return
+	}
+	hooks, hasHooks = seriesHooks[reflect.TypeOf(s)]
+	return
+}
+
+// getSeriesBounds returns the bounds a series reports, if it does.
+func getSeriesBounds(s Series) (minX, maxX, minY, maxY float64, hasBounds bool) {
+	if bp, isBoundsProvider := s.(BoundsProvider); isBoundsProvider {
+		minX, maxX, minY, maxY = bp.GetBounds()
+		hasBounds = true
+		return
+	}
+	if hooks, hasHooks := getSeriesHooks(s); hasHooks && hooks.Bounds != nil {
+		minX, maxX, minY, maxY = hooks.Bounds(s)
+		hasBounds = true
+	}
+	return
+}
+
+// isMeasurableSeries returns if a series needs to be measured for the layout.
+func isMeasurableSeries(s Series) bool {
+	if _, isMeasurable := s.(MeasurableSeries); isMeasurable {
+		return true
+	}
+	hooks, hasHooks := getSeriesHooks(s)
+	return hasHooks && hooks.Measure != nil
+}
+
+// measureSeries returns the box a measurable series draws in.
+func measureSeries(s Series, r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) Box {
+	if ms, isMeasurable := s.(MeasurableSeries); isMeasurable {
+		return ms.Measure(r, canvasBox, xrange, yrange, defaults)
+	}
+	if hooks, hasHooks := getSeriesHooks(s); hasHooks && hooks.Measure != nil {
+		return hooks.Measure(s, r, canvasBox, xrange, yrange, defaults)
+	}
+	return canvasBox
+}
+
+// isLegendSeries returns if a series should be listed in legends.
+func isLegendSeries(s Series) bool {
+	if le, isLegendExcluder := s.(LegendExcluder); isLegendExcluder {
+		return !le.IsLegendExcluded()
+	}
+	hooks, hasHooks := getSeriesHooks(s)
+	return !(hasHooks && hooks.ExcludeFromLegend)
+}
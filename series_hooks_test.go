@@ -0,0 +1,105 @@
+package chart
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+// markerSeries stands in for a third party series type without any optional interfaces.
+type markerSeries struct {
+	Name  string
+	X, Y  float64
+	YAxis YAxisType
+}
+
+func (ms markerSeries) GetName() string                                         { return ms.Name }
+func (ms markerSeries) GetYAxis() YAxisType                                     { return ms.YAxis }
+func (ms markerSeries) GetStyle() Style                                         { return Style{} }
+func (ms markerSeries) Validate() error                                         { return nil }
+func (ms markerSeries) Render(r Renderer, canvasBox Box, xr, yr Range, s Style) {}
+
+type boundedMarkerSeries struct {
+	markerSeries
+}
+
+func (bms boundedMarkerSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	return bms.X - 1, bms.X + 1, bms.Y - 1, bms.Y + 1
+}
+
+func TestSeriesBoundsProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}},
+			boundedMarkerSeries{markerSeries{X: 10, Y: -10, YAxis: YAxisSecondary}},
+		},
+	}
+	xr, yr, yra := c.getRanges()
+	assert.Equal(1.0, xr.GetMin())
+	assert.Equal(11.0, xr.GetMax())
+	assert.Equal(1.0, yr.GetMin())
+	assert.Equal(2.0, yr.GetMax())
+	assert.Equal(-11.0, yra.GetMin())
+	assert.Equal(-9.0, yra.GetMax())
+}
+
+func TestRegisterSeriesHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(isMeasurableSeries(markerSeries{}))
+	assert.True(isLegendSeries(markerSeries{}))
+	_, _, _, _, hasBounds := getSeriesBounds(markerSeries{})
+	assert.False(hasBounds)
+
+	var measured int
+	RegisterSeriesHooks(markerSeries{}, SeriesHooks{
+		Bounds: func(s Series) (minX, maxX, minY, maxY float64) {
+			ms := s.(markerSeries)
+			return ms.X, ms.X, ms.Y - 5, ms.Y + 5
+		},
+		Measure: func(s Series, r Renderer, canvasBox Box, xr, yr Range, defaults Style) Box {
+			measured++
+			return canvasBox.Grow(Box{Top: canvasBox.Top - 20, Left: canvasBox.Left, Right: canvasBox.Right, Bottom: canvasBox.Bottom})
+		},
+		ExcludeFromLegend: true,
+	})
+	defer func() {
+		seriesHooksLock.Lock()
+		delete(seriesHooks, reflect.TypeOf(markerSeries{}))
+		seriesHooksLock.Unlock()
+	}()
+
+	assert.True(isMeasurableSeries(markerSeries{}))
+	assert.False(isLegendSeries(markerSeries{}))
+	// hooks are keyed by the dynamic type.
+	assert.False(isMeasurableSeries(&markerSeries{}))
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}},
+			markerSeries{Name: "marker", X: 4, Y: 0},
+		},
+	}
+	xr, yr, _ := c.getRanges()
+	assert.Equal(4.0, xr.GetMax())
+	assert.Equal(-5.0, yr.GetMin())
+	assert.Equal(5.0, yr.GetMax())
+
+	assert.True(c.hasMeasurableSeries())
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(measured)
+}
+
+func TestAnnotationSeriesContracts(t *testing.T) {
+	assert := assert.New(t)
+
+	var s Series = AnnotationSeries{}
+	assert.True(isMeasurableSeries(s))
+	assert.False(isLegendSeries(s))
+	assert.True(isLegendSeries(ContinuousSeries{}))
+}
@@ -0,0 +1,84 @@
+package chart
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// SeriesParallelismAuto is a `Chart.SeriesParallelism` that draws as many series at once as GOMAXPROCS.
+const SeriesParallelismAuto = -1
+
+// drawAllSeries draws every series in declared order.
+// If the chart asks for parallelism and the renderer is a raster renderer, each series is drawn into a recording
+// of its own, concurrently, and the recordings are then painted in series order; the pixels are the same as
+// drawing the series one after another, so deterministic renderers are drawn concurrently too.
+func (c Chart) drawAllSeries(r Renderer, cl ChartLayout) {
+	workers := c.getSeriesWorkers()
+	rr, isRaster := r.(*rasterRenderer)
+	if !isRaster || workers < 2 {
+		for index, series := range c.Series {
+			c.drawSeries(r, cl, series, index)
+		}
+		return
+	}
+
+	recordings := make([]*drawing.PaintRecorder, len(c.Series))
+	var panicked interface{}
+	var panicOnce sync.Once
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer wg.Done()
+			// a panic is raised again once the workers are done, so the render recovers it as it would sequentially.
+			defer func() {
+				if p := recover(); p != nil {
+					panicOnce.Do(func() { panicked = p })
+					for range indexes {
+					}
+				}
+			}()
+			for index := range indexes {
+				recordings[index] = c.recordSeries(rr, cl, index)
+			}
+		}()
+	}
+	for index := range c.Series {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+
+	for _, recording := range recordings {
+		recording.Replay(rr.i)
+	}
+}
+
+// recordSeries draws a series into a recording, with a renderer set up like the chart renderer.
+func (c Chart) recordSeries(rr *rasterRenderer, cl ChartLayout, index int) *drawing.PaintRecorder {
+	gc, recording := drawing.NewRecordingGraphicContext(rr.i.Bounds())
+	sr := &rasterRenderer{i: rr.i, gc: gc, scale: rr.scale, deterministic: rr.deterministic, normalizedFont: rr.normalizedFont}
+	sr.SetDPI(rr.GetDPI())
+	gc.SetMatrixTransform(sr.baseTransform())
+	c.drawSeries(sr, cl, c.Series[index], index)
+	return recording
+}
+
+// getSeriesWorkers returns the number of series drawn at once: the series parallelism of the chart,
+// or GOMAXPROCS for `SeriesParallelismAuto`, but never more than GOMAXPROCS or the number of series.
+func (c Chart) getSeriesWorkers() int {
+	maxProcs := runtime.GOMAXPROCS(0)
+	workers := c.GetSeriesParallelism()
+	if workers == SeriesParallelismAuto {
+		workers = maxProcs
+	}
+	return util.Math.MinInt(workers, maxProcs, len(c.Series))
+}
@@ -0,0 +1,85 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"runtime"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestChartGetSeriesWorkers(t *testing.T) {
+	assert := assert.New(t)
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	series := make([]Series, 6)
+	assert.Equal(1, Chart{Series: series}.getSeriesWorkers())
+	assert.Equal(3, Chart{Series: series, SeriesParallelism: 3}.getSeriesWorkers())
+	assert.Equal(4, Chart{Series: series, SeriesParallelism: 16}.getSeriesWorkers())
+	assert.Equal(4, Chart{Series: series, SeriesParallelism: SeriesParallelismAuto}.getSeriesWorkers())
+	assert.Equal(2, Chart{Series: series[:2], SeriesParallelism: SeriesParallelismAuto}.getSeriesWorkers())
+}
+
+func TestChartRenderSeriesParallelism(t *testing.T) {
+	assert := assert.New(t)
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	makeChart := func(parallelism int) Chart {
+		var series []Series
+		for s := 0; s < 6; s++ {
+			xvalues := make([]float64, 100)
+			yvalues := make([]float64, 100)
+			for i := range xvalues {
+				xvalues[i] = float64(i)
+				yvalues[i] = float64((i*(s+3))%17 + s)
+			}
+			style := Style{Show: true, StrokeWidth: 2, FillColor: GetDefaultColor(s).WithAlpha(64)}
+			if s%3 == 0 {
+				style.FillGradient = NewLinearGradient(90, ColorBlue, ColorTransparent)
+			}
+			series = append(series, ContinuousSeries{XValues: xvalues, YValues: yvalues, Style: style, DataLabels: DataLabels{Show: s == 1}})
+		}
+		return Chart{Width: 320, Height: 240, SeriesParallelism: parallelism, Series: series}
+	}
+
+	sequential := &rgbaCollector{}
+	assert.Nil(makeChart(0).Render(PNG, sequential))
+	for _, parallelism := range []int{2, 4, SeriesParallelismAuto} {
+		parallel := &rgbaCollector{}
+		assert.Nil(makeChart(parallelism).Render(PNG, parallel))
+		assert.True(bytes.Equal(sequential.i.Pix, parallel.i.Pix), parallelism)
+	}
+}
+
+func TestChartRenderSeriesParallelismPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		SeriesParallelism: 2,
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}},
+			panicSeries{ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{2, 2}}},
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{2, 1}},
+		},
+	}
+	assert.True(errors.Is(c.Render(PNG, &rgbaCollector{}), ErrRenderPanic))
+}
+
+// maxChannelDelta returns the largest per channel difference between two images of the same size.
+func maxChannelDelta(a, b *image.RGBA) int {
+	var maxDelta int
+	for index := range a.Pix {
+		delta := int(a.Pix[index]) - int(b.Pix[index])
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > maxDelta {
+			maxDelta = delta
+		}
+	}
+	return maxDelta
+}
@@ -16,6 +16,7 @@ type SMASeries struct {
 	Name  string
 	Style Style
 	YAxis YAxisType
+	XAxis XAxisType
 
 	Period      int
 	InnerSeries ValuesProvider
@@ -36,6 +37,11 @@ func (sma SMASeries) GetYAxis() YAxisType {
 	return sma.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (sma SMASeries) GetXAxis() XAxisType {
+	return sma.XAxis
+}
+
 // Len returns the number of elements in the series.
 func (sma SMASeries) Len() int {
 	return sma.InnerSeries.Len()
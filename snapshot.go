@@ -0,0 +1,698 @@
+package chart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// SnapshotVersion is the version of the snapshots written by `Chart.Snapshot`;
+// `RestoreSnapshot` reads snapshots up to this version.
+const SnapshotVersion = 1
+
+// ErrSnapshotVersion is returned when restoring a snapshot written by a newer version of the package.
+var ErrSnapshotVersion = errors.New("unsupported snapshot version")
+
+// Snapshot returns the resolved state of the chart as json, so a render can be reproduced exactly later, i.e. for
+// audits or bug reports. It captures the series values, the ranges and ticks the chart lays out (with their labels),
+// the styles and the colors of the color palette, and is restored with `RestoreSnapshot`.
+//
+// Charts have no random state, so a snapshot does not need a seed. Things that are code rather than data are not
+// captured: fonts (restored charts use the default font), elements, hooks, loggers and style providers.
+// Series are captured by how they draw: annotation, candlestick, histogram and bounded (i.e. bollinger band) series
+// as themselves, and any other series that provides values as a line of its values.
+// Panics raised while laying out the chart are returned as a `RenderPanicError`.
+func (c Chart) Snapshot() (data []byte, err error) {
+	defer recoverRender(&err)
+
+	if len(c.Series) == 0 {
+		return nil, errors.New("please provide at least one series")
+	}
+
+	c = c.withDefaults()
+	measured := c.Measure()
+	for _, ra := range []Range{measured.XRange, measured.XRangeSecondary, measured.YRange, measured.YRangeSecondary} {
+		if !isSnapshotRange(ra) {
+			return nil, fmt.Errorf("cannot snapshot range %T; only continuous and time ranges can be restored", ra)
+		}
+	}
+	r, err := c.NewRenderer(PNG)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := c.Layout(r, measured)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := chartSnapshot{
+		Version:     SnapshotVersion,
+		Title:       c.Title,
+		TitleStyle:  newStyleSnapshot(c.TitleStyle),
+		Subtitle:    c.Subtitle,
+		Footer:      c.Footer,
+		Source:      c.Source,
+		Width:       c.GetWidth(),
+		Height:      c.GetHeight(),
+		DPI:         c.GetDPI(),
+		ScaleFactor: c.ScaleFactor,
+		NoText:      c.NoText,
+		ClipSeries:  c.ClipSeries,
+		Grid:        c.GridPlacement,
+		Palette:     newPaletteSnapshot(c.GetColorPalette(), len(c.Series)),
+		Background:  newStyleSnapshot(c.Background),
+		Canvas:      newStyleSnapshot(c.Canvas),
+	}
+	if len(c.Subtitle) > 0 || len(c.Footer) > 0 || len(c.Source) > 0 {
+		subtitle, footer, source := newStyleSnapshot(c.SubtitleStyle), newStyleSnapshot(c.FooterStyle), newStyleSnapshot(c.SourceStyle)
+		snapshot.SubtitleStyle, snapshot.FooterStyle, snapshot.SourceStyle = &subtitle, &footer, &source
+	}
+	snapshot.XAxis = newXAxisSnapshot(c.XAxis, cl.XRange, cl.XTicks)
+	snapshot.XAxisSecondary = newXAxisSnapshot(c.XAxisSecondary, cl.XRangeSecondary, cl.XTicksSecondary)
+	snapshot.YAxis = newYAxisSnapshot(c.YAxis, cl.YRange, cl.YTicks)
+	snapshot.YAxisSecondary = newYAxisSnapshot(c.YAxisSecondary, cl.YRangeSecondary, cl.YTicksSecondary)
+	for index, s := range c.Series {
+		ss, err := newSeriesSnapshot(s)
+		if err != nil {
+			return nil, fmt.Errorf("series %d: %v", index, err)
+		}
+		snapshot.Series = append(snapshot.Series, ss)
+	}
+	return json.Marshal(snapshot)
+}
+
+// RestoreSnapshot returns a chart from a snapshot written by `Chart.Snapshot`; it renders the same as the chart
+// the snapshot was taken of, with the ranges and ticks fixed to the ones captured.
+func RestoreSnapshot(data []byte) (Chart, error) {
+	var snapshot chartSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Chart{}, err
+	}
+	if snapshot.Version < 1 || snapshot.Version > SnapshotVersion {
+		return Chart{}, fmt.Errorf("%w: %d", ErrSnapshotVersion, snapshot.Version)
+	}
+
+	c := Chart{
+		Title:          snapshot.Title,
+		TitleStyle:     snapshot.TitleStyle.Style(),
+		Subtitle:       snapshot.Subtitle,
+		Footer:         snapshot.Footer,
+		Source:         snapshot.Source,
+		ColorPalette:   snapshot.Palette,
+		Width:          snapshot.Width,
+		Height:         snapshot.Height,
+		DPI:            snapshot.DPI,
+		ScaleFactor:    snapshot.ScaleFactor,
+		NoText:         snapshot.NoText,
+		ClipSeries:     snapshot.ClipSeries,
+		GridPlacement:  snapshot.Grid,
+		Background:     snapshot.Background.Style(),
+		Canvas:         snapshot.Canvas.Style(),
+		XAxis:          snapshot.XAxis.XAxis(),
+		XAxisSecondary: snapshot.XAxisSecondary.XAxis(),
+		YAxis:          snapshot.YAxis.YAxis(),
+		YAxisSecondary: snapshot.YAxisSecondary.YAxis(),
+	}
+	if snapshot.SubtitleStyle != nil {
+		c.SubtitleStyle = snapshot.SubtitleStyle.Style()
+	}
+	if snapshot.FooterStyle != nil {
+		c.FooterStyle = snapshot.FooterStyle.Style()
+	}
+	if snapshot.SourceStyle != nil {
+		c.SourceStyle = snapshot.SourceStyle.Style()
+	}
+	for index, ss := range snapshot.Series {
+		s, err := ss.Series()
+		if err != nil {
+			return Chart{}, fmt.Errorf("series %d: %v", index, err)
+		}
+		c.Series = append(c.Series, s)
+	}
+	return c, nil
+}
+
+type chartSnapshot struct {
+	Version        int              `json:"version"`
+	Title          string           `json:"title,omitempty"`
+	TitleStyle     styleSnapshot    `json:"titleStyle"`
+	Subtitle       string           `json:"subtitle,omitempty"`
+	SubtitleStyle  *styleSnapshot   `json:"subtitleStyle,omitempty"`
+	Footer         string           `json:"footer,omitempty"`
+	FooterStyle    *styleSnapshot   `json:"footerStyle,omitempty"`
+	Source         string           `json:"source,omitempty"`
+	SourceStyle    *styleSnapshot   `json:"sourceStyle,omitempty"`
+	Width          int              `json:"width"`
+	Height         int              `json:"height"`
+	DPI            float64          `json:"dpi"`
+	ScaleFactor    float64          `json:"scaleFactor,omitempty"`
+	NoText         bool             `json:"noText,omitempty"`
+	ClipSeries     bool             `json:"clipSeries,omitempty"`
+	Grid           GridPlacement    `json:"gridPlacement,omitempty"`
+	Palette        paletteSnapshot  `json:"palette"`
+	Background     styleSnapshot    `json:"background"`
+	Canvas         styleSnapshot    `json:"canvas"`
+	XAxis          axisSnapshot     `json:"xAxis"`
+	XAxisSecondary axisSnapshot     `json:"xAxisSecondary"`
+	YAxis          axisSnapshot     `json:"yAxis"`
+	YAxisSecondary axisSnapshot     `json:"yAxisSecondary"`
+	Series         []seriesSnapshot `json:"series"`
+}
+
+// styleSnapshot is a style without its font and providers.
+type styleSnapshot struct {
+	Show                bool                `json:"show,omitempty"`
+	Padding             Box                 `json:"padding"`
+	StrokeWidth         float64             `json:"strokeWidth,omitempty"`
+	StrokeColor         drawing.Color       `json:"strokeColor"`
+	StrokeDashArray     []float64           `json:"strokeDashArray,omitempty"`
+	DotColor            drawing.Color       `json:"dotColor"`
+	DotWidth            float64             `json:"dotWidth,omitempty"`
+	FillColor           drawing.Color       `json:"fillColor"`
+	FillGradient        *Gradient           `json:"fillGradient,omitempty"`
+	FillPattern         *Pattern            `json:"fillPattern,omitempty"`
+	FontSize            float64             `json:"fontSize,omitempty"`
+	FontColor           drawing.Color       `json:"fontColor"`
+	TextHorizontalAlign TextHorizontalAlign `json:"textHorizontalAlign,omitempty"`
+	TextVerticalAlign   TextVerticalAlign   `json:"textVerticalAlign,omitempty"`
+	TextWrap            TextWrap            `json:"textWrap,omitempty"`
+	TextLineSpacing     int                 `json:"textLineSpacing,omitempty"`
+	TextRotationDegrees float64             `json:"textRotationDegrees,omitempty"`
+	TextWritingMode     TextWritingMode     `json:"textWritingMode,omitempty"`
+	Interpolation       Interpolation       `json:"interpolation,omitempty"`
+}
+
+func newStyleSnapshot(s Style) styleSnapshot {
+	return styleSnapshot{
+		Show:                s.Show,
+		Padding:             s.Padding,
+		StrokeWidth:         s.StrokeWidth,
+		StrokeColor:         s.StrokeColor,
+		StrokeDashArray:     s.StrokeDashArray,
+		DotColor:            s.DotColor,
+		DotWidth:            s.DotWidth,
+		FillColor:           s.FillColor,
+		FillGradient:        s.FillGradient,
+		FillPattern:         s.FillPattern,
+		FontSize:            s.FontSize,
+		FontColor:           s.FontColor,
+		TextHorizontalAlign: s.TextHorizontalAlign,
+		TextVerticalAlign:   s.TextVerticalAlign,
+		TextWrap:            s.TextWrap,
+		TextLineSpacing:     s.TextLineSpacing,
+		TextRotationDegrees: s.TextRotationDegrees,
+		TextWritingMode:     s.TextWritingMode,
+		Interpolation:       s.Interpolation,
+	}
+}
+
+// Style returns the style the snapshot was taken of.
+func (ss styleSnapshot) Style() Style {
+	return Style{
+		Show:                ss.Show,
+		Padding:             ss.Padding,
+		StrokeWidth:         ss.StrokeWidth,
+		StrokeColor:         ss.StrokeColor,
+		StrokeDashArray:     ss.StrokeDashArray,
+		DotColor:            ss.DotColor,
+		DotWidth:            ss.DotWidth,
+		FillColor:           ss.FillColor,
+		FillGradient:        ss.FillGradient,
+		FillPattern:         ss.FillPattern,
+		FontSize:            ss.FontSize,
+		FontColor:           ss.FontColor,
+		TextHorizontalAlign: ss.TextHorizontalAlign,
+		TextVerticalAlign:   ss.TextVerticalAlign,
+		TextWrap:            ss.TextWrap,
+		TextLineSpacing:     ss.TextLineSpacing,
+		TextRotationDegrees: ss.TextRotationDegrees,
+		TextWritingMode:     ss.TextWritingMode,
+		Interpolation:       ss.Interpolation,
+	}
+}
+
+// paletteSnapshot is the colors of a color palette; it is a color palette itself.
+type paletteSnapshot struct {
+	Background       drawing.Color   `json:"background"`
+	BackgroundStroke drawing.Color   `json:"backgroundStroke"`
+	Canvas           drawing.Color   `json:"canvas"`
+	CanvasStroke     drawing.Color   `json:"canvasStroke"`
+	AxisStroke       drawing.Color   `json:"axisStroke"`
+	Grid             drawing.Color   `json:"grid"`
+	Text             drawing.Color   `json:"text"`
+	Series           []drawing.Color `json:"series"`
+}
+
+func newPaletteSnapshot(cp ColorPalette, seriesCount int) paletteSnapshot {
+	ps := paletteSnapshot{
+		Background:       cp.BackgroundColor(),
+		BackgroundStroke: cp.BackgroundStrokeColor(),
+		Canvas:           cp.CanvasColor(),
+		CanvasStroke:     cp.CanvasStrokeColor(),
+		AxisStroke:       cp.AxisStrokeColor(),
+		Text:             cp.TextColor(),
+	}
+	if gcp, isGridColorProvider := cp.(gridColorProvider); isGridColorProvider {
+		ps.Grid = gcp.GridColor()
+	}
+	for index := 0; index < seriesCount; index++ {
+		ps.Series = append(ps.Series, getSeriesColor(cp, index, seriesCount))
+	}
+	return ps
+}
+
+func (ps paletteSnapshot) BackgroundColor() drawing.Color {
+	return ps.Background
+}
+
+func (ps paletteSnapshot) BackgroundStrokeColor() drawing.Color {
+	return ps.BackgroundStroke
+}
+
+func (ps paletteSnapshot) CanvasColor() drawing.Color {
+	return ps.Canvas
+}
+
+func (ps paletteSnapshot) CanvasStrokeColor() drawing.Color {
+	return ps.CanvasStroke
+}
+
+func (ps paletteSnapshot) AxisStrokeColor() drawing.Color {
+	return ps.AxisStroke
+}
+
+// GridColor returns the grid color of the palette, which is zero if it had none.
+func (ps paletteSnapshot) GridColor() drawing.Color {
+	return ps.Grid
+}
+
+func (ps paletteSnapshot) TextColor() drawing.Color {
+	return ps.Text
+}
+
+func (ps paletteSnapshot) GetSeriesColor(index int) drawing.Color {
+	if len(ps.Series) == 0 {
+		return GetDefaultColor(index)
+	}
+	return ps.Series[index%len(ps.Series)]
+}
+
+// axisSnapshot is an axis with its laid out range, ticks and grid lines.
+type axisSnapshot struct {
+	Name            string             `json:"name,omitempty"`
+	NameStyle       styleSnapshot      `json:"nameStyle"`
+	NamePlacement   AxisNamePlacement  `json:"namePlacement,omitempty"`
+	Style           styleSnapshot      `json:"style"`
+	TickStyle       styleSnapshot      `json:"tickStyle"`
+	TickPosition    TickPosition       `json:"tickPosition,omitempty"`
+	TickLabelLayout TickLabelLayout    `json:"tickLabelLayout,omitempty"`
+	GridMajorStyle  styleSnapshot      `json:"gridMajorStyle"`
+	GridMinorStyle  styleSnapshot      `json:"gridMinorStyle"`
+	GridBandStyle   *styleSnapshot     `json:"gridBandStyle,omitempty"`
+	Zero            *gridLineSnapshot  `json:"zero,omitempty"`
+	Min             float64            `json:"min"`
+	Max             float64            `json:"max"`
+	Descending      bool               `json:"descending,omitempty"`
+	Mirror          bool               `json:"mirror,omitempty"`
+	Ticks           []Tick             `json:"ticks,omitempty"`
+	GridLines       []gridLineSnapshot `json:"gridLines,omitempty"`
+}
+
+type gridLineSnapshot struct {
+	IsMinor bool          `json:"isMinor,omitempty"`
+	Style   styleSnapshot `json:"style"`
+	Value   float64       `json:"value"`
+}
+
+func newXAxisSnapshot(xa XAxis, ra Range, ticks []Tick) axisSnapshot {
+	// the tick rotation is restored as the rotation of the tick style, which draws the same.
+	tickStyle := xa.TickStyle
+	if xa.TickRotationDegrees != 0 {
+		tickStyle.TextRotationDegrees = xa.TickRotationDegrees
+	}
+	as := newAxisSnapshot(xa.Name, xa.NameStyle, xa.Style, tickStyle, ra, ticks, xa.getGridLines(ra, ticks))
+	as.GridMajorStyle, as.GridMinorStyle = newStyleSnapshot(xa.GridMajorStyle), newStyleSnapshot(xa.GridMinorStyle)
+	as.GridBandStyle = newGridBandSnapshot(xa.GridBandStyle)
+	as.TickPosition = xa.TickPosition
+	as.TickLabelLayout = xa.TickLabelLayout
+	as.NamePlacement = xa.NamePlacement
+	return as
+}
+
+func newYAxisSnapshot(ya YAxis, ra Range, ticks []Tick) axisSnapshot {
+	as := newAxisSnapshot(ya.Name, ya.NameStyle, ya.Style, ya.TickStyle, ra, ticks, ya.getGridLines(ra, ticks))
+	as.GridMajorStyle, as.GridMinorStyle = newStyleSnapshot(ya.GridMajorStyle), newStyleSnapshot(ya.GridMinorStyle)
+	as.GridBandStyle = newGridBandSnapshot(ya.GridBandStyle)
+	if ya.Zero.Style.Show {
+		as.Zero = &gridLineSnapshot{Style: newStyleSnapshot(ya.Zero.Style), Value: ya.Zero.Value}
+	}
+	as.Mirror = ya.Mirror
+	as.NamePlacement = ya.NamePlacement
+	return as
+}
+
+// newGridBandSnapshot returns the snapshot of a grid band style, or nil if the bands aren't shown.
+func newGridBandSnapshot(style Style) *styleSnapshot {
+	if !style.Show {
+		return nil
+	}
+	ss := newStyleSnapshot(style)
+	return &ss
+}
+
+func newAxisSnapshot(name string, nameStyle, style, tickStyle Style, ra Range, ticks []Tick, gridLines []GridLine) axisSnapshot {
+	as := axisSnapshot{
+		Name:       name,
+		NameStyle:  newStyleSnapshot(nameStyle),
+		Style:      newStyleSnapshot(style),
+		TickStyle:  newStyleSnapshot(tickStyle),
+		Ticks:      ticks,
+		Min:        ra.GetMin(),
+		Max:        ra.GetMax(),
+		Descending: ra.IsDescending(),
+	}
+	for _, gl := range gridLines {
+		as.GridLines = append(as.GridLines, gridLineSnapshot{IsMinor: gl.IsMinor, Style: newStyleSnapshot(gl.Style), Value: gl.Value})
+	}
+	return as
+}
+
+// isSnapshotRange returns if a range translates linearly, so it can be restored as a continuous range.
+func isSnapshotRange(ra Range) bool {
+	switch ra.(type) {
+	case *ContinuousRange, *TimeRange, *snapshotRange:
+		return true
+	}
+	return false
+}
+
+func (as axisSnapshot) getRange() Range {
+	if as.Min == 0 && as.Max == 0 {
+		return nil
+	}
+	return &snapshotRange{
+		ContinuousRange: ContinuousRange{Min: as.Min, Max: as.Max, Descending: as.Descending},
+		Ticks:           as.Ticks,
+	}
+}
+
+func (as axisSnapshot) getGridLines() (gridLines []GridLine) {
+	for _, gl := range as.GridLines {
+		gridLines = append(gridLines, GridLine{IsMinor: gl.IsMinor, Style: gl.Style.Style(), Value: gl.Value})
+	}
+	return
+}
+
+// XAxis returns the x axis the snapshot was taken of.
+func (as axisSnapshot) XAxis() XAxis {
+	return XAxis{
+		Name:            as.Name,
+		NameStyle:       as.NameStyle.Style(),
+		NamePlacement:   as.NamePlacement,
+		Style:           as.Style.Style(),
+		TickStyle:       as.TickStyle.Style(),
+		TickPosition:    as.TickPosition,
+		TickLabelLayout: as.TickLabelLayout,
+		Range:           as.getRange(),
+		GridLines:       as.getGridLines(),
+		GridMajorStyle:  as.GridMajorStyle.Style(),
+		GridMinorStyle:  as.GridMinorStyle.Style(),
+		GridBandStyle:   as.getGridBandStyle(),
+	}
+}
+
+// YAxis returns the y axis the snapshot was taken of.
+func (as axisSnapshot) YAxis() YAxis {
+	return YAxis{
+		Name:           as.Name,
+		NameStyle:      as.NameStyle.Style(),
+		NamePlacement:  as.NamePlacement,
+		Style:          as.Style.Style(),
+		TickStyle:      as.TickStyle.Style(),
+		Range:          as.getRange(),
+		GridLines:      as.getGridLines(),
+		GridMajorStyle: as.GridMajorStyle.Style(),
+		GridMinorStyle: as.GridMinorStyle.Style(),
+		Mirror:         as.Mirror,
+		GridBandStyle:  as.getGridBandStyle(),
+		Zero:           as.getZero(),
+	}
+}
+
+func (as axisSnapshot) getGridBandStyle() Style {
+	if as.GridBandStyle == nil {
+		return Style{}
+	}
+	return as.GridBandStyle.Style()
+}
+
+func (as axisSnapshot) getZero() GridLine {
+	if as.Zero == nil {
+		return GridLine{}
+	}
+	return GridLine{Style: as.Zero.Style.Style(), Value: as.Zero.Value}
+}
+
+// snapshotRange is a continuous range with the ticks captured by a snapshot.
+// The ticks are provided by the range rather than set on the axis, as ticks on the axis would reset the range to their bounds.
+type snapshotRange struct {
+	ContinuousRange
+	Ticks []Tick
+}
+
+// GetTicks implements TicksProvider.
+func (sr snapshotRange) GetTicks(r Renderer, defaults Style, vf ValueFormatter) []Tick {
+	return sr.Ticks
+}
+
+const (
+	snapshotKindLine       = "line"
+	snapshotKindBand       = "band"
+	snapshotKindHistogram  = "histogram"
+	snapshotKindAnnotation = "annotation"
+	snapshotKindCandle     = "candlestick"
+)
+
+type seriesSnapshot struct {
+	Name        string               `json:"name,omitempty"`
+	Kind        string               `json:"kind"`
+	XAxis       XAxisType            `json:"xAxis,omitempty"`
+	YAxis       YAxisType            `json:"yAxis,omitempty"`
+	Style       styleSnapshot        `json:"style"`
+	XValues     snapshotValues       `json:"xValues,omitempty"`
+	YValues     snapshotValues       `json:"yValues,omitempty"`
+	Y2Values    snapshotValues       `json:"y2Values,omitempty"`
+	Offset      int                  `json:"offset,omitempty"`
+	Annotations []annotationSnapshot `json:"annotations,omitempty"`
+	Candles     *candleSnapshot      `json:"candles,omitempty"`
+	GapPolicy   GapPolicy            `json:"gapPolicy,omitempty"`
+}
+
+// candleSnapshot is the candles of a candlestick series; the close values are the series' y values.
+type candleSnapshot struct {
+	UpStyle    styleSnapshot  `json:"upStyle"`
+	DownStyle  styleSnapshot  `json:"downStyle"`
+	BodyWidth  float64        `json:"bodyWidth,omitempty"`
+	OpenValues snapshotValues `json:"openValues"`
+	HighValues snapshotValues `json:"highValues"`
+	LowValues  snapshotValues `json:"lowValues"`
+}
+
+type annotationSnapshot struct {
+	Style  styleSnapshot `json:"style"`
+	Label  string        `json:"label"`
+	XValue float64       `json:"x"`
+	YValue float64       `json:"y"`
+}
+
+func newSeriesSnapshot(s Series) (seriesSnapshot, error) {
+	ss := seriesSnapshot{
+		Name:  s.GetName(),
+		XAxis: getSeriesXAxis(s),
+		YAxis: s.GetYAxis(),
+		Style: newStyleSnapshot(s.GetStyle()),
+	}
+	switch typed := s.(type) {
+	case AnnotationSeries:
+		ss.Kind = snapshotKindAnnotation
+		for _, a := range typed.Annotations {
+			ss.Annotations = append(ss.Annotations, annotationSnapshot{Style: newStyleSnapshot(a.Style), Label: a.Label, XValue: a.XValue, YValue: a.YValue})
+		}
+	case CandlestickSeries:
+		ss.Kind = snapshotKindCandle
+		ss.XValues, ss.YValues = typed.XValues, typed.CloseValues
+		ss.Candles = &candleSnapshot{
+			UpStyle:    newStyleSnapshot(typed.UpStyle),
+			DownStyle:  newStyleSnapshot(typed.DownStyle),
+			BodyWidth:  typed.BodyWidth,
+			OpenValues: typed.OpenValues,
+			HighValues: typed.HighValues,
+			LowValues:  typed.LowValues,
+		}
+	case HistogramSeries:
+		ss.Kind = snapshotKindHistogram
+		ss.XValues, ss.YValues = snapshotValuesOf(typed.InnerSeries)
+	case BoundedValuesProvider:
+		ss.Kind = snapshotKindBand
+		switch bounded := s.(type) {
+		case *BollingerBandsSeries:
+			ss.Offset = bounded.GetPeriod()
+		case bandSeries:
+			ss.Offset = bounded.Offset
+		}
+		for index := 0; index < typed.Len(); index++ {
+			x, y1, y2 := typed.GetBoundedValues(index)
+			ss.XValues = append(ss.XValues, x)
+			ss.YValues = append(ss.YValues, y1)
+			ss.Y2Values = append(ss.Y2Values, y2)
+		}
+	case ValuesProvider:
+		ss.Kind = snapshotKindLine
+		// missing values are kept as NaN, or zero, so only breaking the line at them is left to restore.
+		switch line := s.(type) {
+		case ContinuousSeries:
+			ss.GapPolicy = line.GapPolicy
+		case TimeSeries:
+			ss.GapPolicy = line.GapPolicy
+		}
+		ss.XValues, ss.YValues = snapshotValuesOf(typed)
+	default:
+		return ss, fmt.Errorf("cannot snapshot series %T; it does not provide values", s)
+	}
+	return ss, nil
+}
+
+func snapshotValuesOf(vp ValuesProvider) (xvalues, yvalues snapshotValues) {
+	xvalues, yvalues = make(snapshotValues, vp.Len()), make(snapshotValues, vp.Len())
+	for index := range xvalues {
+		xvalues[index], yvalues[index] = vp.GetValues(index)
+	}
+	return
+}
+
+// Series returns a series that draws the same as the series the snapshot was taken of.
+func (ss seriesSnapshot) Series() (Series, error) {
+	switch ss.Kind {
+	case snapshotKindLine:
+		return ContinuousSeries{Name: ss.Name, Style: ss.Style.Style(), XAxis: ss.XAxis, YAxis: ss.YAxis, XValues: ss.XValues, YValues: ss.YValues, GapPolicy: ss.GapPolicy}, nil
+	case snapshotKindCandle:
+		if ss.Candles == nil {
+			return nil, errors.New("candlestick series is missing its candles")
+		}
+		return CandlestickSeries{
+			Name:        ss.Name,
+			Style:       ss.Style.Style(),
+			UpStyle:     ss.Candles.UpStyle.Style(),
+			DownStyle:   ss.Candles.DownStyle.Style(),
+			BodyWidth:   ss.Candles.BodyWidth,
+			XAxis:       ss.XAxis,
+			YAxis:       ss.YAxis,
+			XValues:     ss.XValues,
+			OpenValues:  ss.Candles.OpenValues,
+			HighValues:  ss.Candles.HighValues,
+			LowValues:   ss.Candles.LowValues,
+			CloseValues: ss.YValues,
+		}, nil
+	case snapshotKindHistogram:
+		return HistogramSeries{Name: ss.Name, Style: ss.Style.Style(), XAxis: ss.XAxis, YAxis: ss.YAxis, InnerSeries: ContinuousSeries{XValues: ss.XValues, YValues: ss.YValues}}, nil
+	case snapshotKindBand:
+		return bandSeries{Name: ss.Name, Style: ss.Style.Style(), XAxis: ss.XAxis, YAxis: ss.YAxis, XValues: ss.XValues, Y1Values: ss.YValues, Y2Values: ss.Y2Values, Offset: ss.Offset}, nil
+	case snapshotKindAnnotation:
+		as := AnnotationSeries{Name: ss.Name, Style: ss.Style.Style(), XAxis: ss.XAxis, YAxis: ss.YAxis}
+		for _, a := range ss.Annotations {
+			as.Annotations = append(as.Annotations, Value2{Style: a.Style.Style(), Label: a.Label, XValue: a.XValue, YValue: a.YValue})
+		}
+		return as, nil
+	}
+	return nil, fmt.Errorf("unknown series kind %q", ss.Kind)
+}
+
+// bandSeries is a restored bounded series, drawn like a bollinger band series.
+type bandSeries struct {
+	Name     string
+	Style    Style
+	XAxis    XAxisType
+	YAxis    YAxisType
+	XValues  []float64
+	Y1Values []float64
+	Y2Values []float64
+	Offset   int
+}
+
+func (bs bandSeries) GetName() string {
+	return bs.Name
+}
+
+func (bs bandSeries) GetStyle() Style {
+	return bs.Style
+}
+
+func (bs bandSeries) GetYAxis() YAxisType {
+	return bs.YAxis
+}
+
+func (bs bandSeries) GetXAxis() XAxisType {
+	return bs.XAxis
+}
+
+func (bs bandSeries) Len() int {
+	return len(bs.XValues)
+}
+
+func (bs bandSeries) Validate() error {
+	return nil
+}
+
+// GetBoundedValues implements BoundedValuesProvider.
+func (bs bandSeries) GetBoundedValues(index int) (x, y1, y2 float64) {
+	return bs.XValues[index], bs.Y1Values[index], bs.Y2Values[index]
+}
+
+// Render renders the series.
+func (bs bandSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	Draw.BoundedSeries(r, canvasBox, xrange, yrange, bs.Style.InheritFrom(defaults.InheritFrom(styleDefaultsBands())), bs, bs.Offset)
+}
+
+// snapshotValues are values that are written as json with NaN and infinities as strings, which json numbers can't be.
+type snapshotValues []float64
+
+// MarshalJSON implements json.Marshaler.
+func (sv snapshotValues) MarshalJSON() ([]byte, error) {
+	values := make([]interface{}, len(sv))
+	for index, v := range sv {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			values[index] = strconv.FormatFloat(v, 'f', -1, 64)
+		} else {
+			values[index] = v
+		}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (sv *snapshotValues) UnmarshalJSON(data []byte) error {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*sv = make(snapshotValues, len(values))
+	for index, raw := range values {
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return err
+			}
+			(*sv)[index] = v
+			continue
+		}
+		if err := json.Unmarshal(raw, &(*sv)[index]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
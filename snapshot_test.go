@@ -0,0 +1,121 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func snapshotTestChart() Chart {
+	prices := ContinuousSeries{
+		Name:    "prices",
+		XValues: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		YValues: []float64{3, 4, 3.5, 5, 4.5, 6, 5.5, 7, 6.5, 8},
+	}
+	return Chart{
+		Title:        "snapshot",
+		TitleStyle:   StyleShow(),
+		ColorPalette: AlternateColorPalette,
+		Width:        640,
+		Height:       320,
+		XAxis:        XAxis{Style: StyleShow(), TickLabelLayout: TickLabelLayoutThin},
+		YAxis:        YAxis{Style: StyleShow(), GridMajorStyle: Style{Show: true, StrokeColor: drawing.ColorBlack, StrokeWidth: 1}},
+		Series: []Series{
+			&BollingerBandsSeries{Name: "bands", Period: 3, InnerSeries: prices},
+			prices,
+			SMASeries{Name: "average", Period: 3, InnerSeries: prices, YAxis: YAxisSecondary},
+			HistogramSeries{Name: "changes", InnerSeries: ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, -1, 2}}},
+			LastValueAnnotation(prices),
+			ContinuousSeries{Name: "gaps", XValues: []float64{1, 2, 3, 4}, YValues: []float64{2, math.NaN(), 3, 4}},
+		},
+	}
+}
+
+func TestChartSnapshotRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	c := snapshotTestChart()
+	snapshot, err := c.Snapshot()
+	assert.Nil(err)
+
+	restored, err := RestoreSnapshot(snapshot)
+	assert.Nil(err)
+	assert.Len(restored.Series, len(c.Series))
+
+	// snapshots capture the ticks as laid out in png, so they restore to the same png.
+	expected := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(DeterministicPNG, expected))
+	actual := bytes.NewBuffer(nil)
+	assert.Nil(restored.Render(DeterministicPNG, actual))
+	assert.Equal(expected.String(), actual.String())
+
+	// a restored chart snapshots the same.
+	again, err := restored.Snapshot()
+	assert.Nil(err)
+	assert.Equal(string(snapshot), string(again))
+}
+
+func TestChartSnapshotValues(t *testing.T) {
+	assert := assert.New(t)
+
+	snapshot, err := snapshotTestChart().Snapshot()
+	assert.Nil(err)
+
+	var decoded chartSnapshot
+	assert.Nil(json.Unmarshal(snapshot, &decoded))
+	assert.Equal(SnapshotVersion, decoded.Version)
+	assert.Equal(snapshotKindBand, decoded.Series[0].Kind)
+	assert.Equal(3, decoded.Series[0].Offset)
+	assert.Equal(snapshotKindLine, decoded.Series[1].Kind)
+	assert.True(math.IsNaN(decoded.Series[5].YValues[1]))
+	assert.Equal(YAxisSecondary, decoded.Series[2].YAxis)
+	assert.Equal(snapshotKindHistogram, decoded.Series[3].Kind)
+	assert.Equal(snapshotKindAnnotation, decoded.Series[4].Kind)
+	assert.NotEmpty(decoded.XAxis.Ticks)
+	assert.NotEmpty(decoded.YAxis.GridLines)
+	assert.Equal(AlternateColorPalette.GetSeriesColor(1), decoded.Palette.GetSeriesColor(1))
+}
+
+func TestRestoreSnapshotVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := RestoreSnapshot([]byte(`{"version":2}`))
+	assert.NotNil(err)
+	assert.True(errors.Is(err, ErrSnapshotVersion))
+}
+
+func TestChartSnapshotUnsupportedRange(t *testing.T) {
+	assert := assert.New(t)
+
+	c := snapshotTestChart()
+	c.XAxis.Range = &MarketHoursRange{}
+	_, err := c.Snapshot()
+	assert.NotNil(err)
+}
+
+func TestSnapshotTitleBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Subtitle:      "Subtitle",
+		SubtitleStyle: Style{FontSize: 14},
+		Footer:        "Footer",
+		Source:        "Source",
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	snapshot, err := c.Snapshot()
+	assert.Nil(err)
+	restored, err := RestoreSnapshot(snapshot)
+	assert.Nil(err)
+	assert.Equal("Subtitle", restored.Subtitle)
+	assert.Equal(14.0, restored.SubtitleStyle.FontSize)
+	assert.Equal("Footer", restored.Footer)
+	assert.Equal("Source", restored.Source)
+}
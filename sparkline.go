@@ -0,0 +1,273 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// Sparkline is a word sized chart of a run of values, i.e. 120x30 pixels in a table cell or a dashboard tile.
+// It has no axes, title or legend, and only pads its edges by as much as its line and markers need, so the line uses
+// the whole image; a `Chart` that small spends all of its canvas on the axes.
+//
+// The values are drawn as a line, evenly spaced, with optional markers on the minimum, maximum and last values.
+// With `WinLoss` set they are drawn as bars instead, up for positive values and down for negative ones.
+type Sparkline struct {
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+
+	// Style is the style of the line, or of the bars of positive values of win/loss sparklines.
+	Style Style
+	// LossStyle is the style of the bars of negative values of win/loss sparklines; it defaults to red.
+	LossStyle Style
+
+	// MinMarker, MaxMarker and LastMarker are the styles of the markers on the minimum, maximum and last values;
+	// each marker is drawn if its style is shown, as a dot in the line color unless the style sets a dot color.
+	MinMarker  Style
+	MaxMarker  Style
+	LastMarker Style
+
+	// WinLoss draws the values as bars of the same height, up for positive values and down for negative ones.
+	WinLoss bool
+
+	// Accessibility describes the sparkline for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Values []float64
+}
+
+// GetDPI returns the dpi for the sparkline.
+func (sl Sparkline) GetDPI(defaults ...float64) float64 {
+	if sl.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return sl.DPI
+}
+
+// GetWidth returns the sparkline width or the default value.
+func (sl Sparkline) GetWidth() int {
+	if sl.Width == 0 {
+		return DefaultSparklineWidth
+	}
+	return sl.Width
+}
+
+// GetHeight returns the sparkline height or the default value.
+func (sl Sparkline) GetHeight() int {
+	if sl.Height == 0 {
+		return DefaultSparklineHeight
+	}
+	return sl.Height
+}
+
+// GetColorPalette returns the color palette for the sparkline.
+func (sl Sparkline) GetColorPalette() ColorPalette {
+	if sl.ColorPalette != nil {
+		return sl.ColorPalette
+	}
+	return DefaultColorPalette
+}
+
+// Validate checks the sparkline for problems that would prevent it from rendering; a line needs at least one
+// finite value. It returns nil or `ValidationErrors`.
+func (sl Sparkline) Validate() error {
+	var errs ValidationErrors
+	if len(sl.Values) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one value"))
+	}
+	if _, _, last := sl.getExtremes(); last < 0 && !sl.WinLoss {
+		errs = append(errs, newValidationError(ErrInvalidRange, "sparkline must contain at least (1) finite value"))
+	}
+	return errs.asError()
+}
+
+// Render renders the sparkline with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (sl Sparkline) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = sl.Validate(); err != nil {
+		return err
+	}
+
+	r, err := rp(sl.GetWidth(), sl.GetHeight())
+	if err != nil {
+		return err
+	}
+	r = textFreeRenderer{r}
+	r.SetDPI(sl.GetDPI(DefaultDPI))
+
+	Draw.Box(r, Box{Right: sl.GetWidth(), Bottom: sl.GetHeight()}, sl.Background.InheritFrom(sl.styleDefaultsBackground()))
+
+	canvasBox := sl.Box()
+	if sl.WinLoss {
+		sl.drawWinLoss(r, canvasBox)
+	} else {
+		sl.drawLine(r, canvasBox)
+	}
+
+	setAccessibility(r, sl.Accessibility.withDefaults("sparkline", sl.describe))
+	return r.Save(w)
+}
+
+// Box returns the box the values are drawn in: the sparkline less the padding of the background, which defaults
+// to as much as the line and the markers reach past the values.
+func (sl Sparkline) Box() Box {
+	pad := int(math.Ceil(sl.getReach()))
+	return Box{
+		Top:    sl.Background.Padding.GetTop(pad),
+		Left:   sl.Background.Padding.GetLeft(pad),
+		Right:  sl.GetWidth() - sl.Background.Padding.GetRight(pad),
+		Bottom: sl.GetHeight() - sl.Background.Padding.GetBottom(pad),
+	}
+}
+
+// getReach returns how far the line and the markers are drawn past the values.
+func (sl Sparkline) getReach() float64 {
+	if sl.WinLoss {
+		return 0
+	}
+	reach := sl.Style.GetStrokeWidth(DefaultSeriesLineWidth) / 2
+	for _, marker := range []Style{sl.MinMarker, sl.MaxMarker, sl.LastMarker} {
+		if marker.Show {
+			reach = math.Max(reach, marker.GetDotWidth(DefaultSparklineMarkerWidth)+marker.GetStrokeWidth(1)/2)
+		}
+	}
+	return reach
+}
+
+// getExtremes returns the indexes of the minimum, maximum and last finite values; they are -1 if there are none.
+func (sl Sparkline) getExtremes() (minIndex, maxIndex, lastIndex int) {
+	minIndex, maxIndex, lastIndex = -1, -1, -1
+	for index, v := range sl.Values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if minIndex < 0 || v < sl.Values[minIndex] {
+			minIndex = index
+		}
+		if maxIndex < 0 || v > sl.Values[maxIndex] {
+			maxIndex = index
+		}
+		lastIndex = index
+	}
+	return
+}
+
+// getRanges returns the ranges the values are drawn in; a run of equal values is drawn across the middle.
+func (sl Sparkline) getRanges(canvasBox Box, minIndex, maxIndex int) (xrange, yrange Range) {
+	xrange = &ContinuousRange{Min: 0, Max: math.Max(1, float64(len(sl.Values)-1)), Domain: canvasBox.Width()}
+	min, max := sl.Values[minIndex], sl.Values[maxIndex]
+	if min == max {
+		min, max = min-1, max+1
+	}
+	yrange = &ContinuousRange{Min: min, Max: max, Domain: canvasBox.Height()}
+	return
+}
+
+func (sl Sparkline) drawLine(r Renderer, canvasBox Box) {
+	minIndex, maxIndex, lastIndex := sl.getExtremes()
+	if lastIndex < 0 {
+		return
+	}
+	xrange, yrange := sl.getRanges(canvasBox, minIndex, maxIndex)
+
+	style := sl.Style.InheritFrom(sl.styleDefaultsLine())
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, sl.getValues())
+
+	for _, marker := range []struct {
+		Style Style
+		Index int
+	}{{sl.MinMarker, minIndex}, {sl.MaxMarker, maxIndex}, {sl.LastMarker, lastIndex}} {
+		if !marker.Style.Show {
+			continue
+		}
+		ms := marker.Style.InheritFrom(Style{DotColor: style.StrokeColor, DotWidth: DefaultSparklineMarkerWidth})
+		ms.GetDotOptions().WriteDrawingOptionsToRenderer(r)
+		x := canvasBox.Left + xrange.Translate(float64(marker.Index))
+		y := canvasBox.Bottom - yrange.Translate(sl.Values[marker.Index])
+		MarkerCircle(r, x, y, ms.DotWidth)
+	}
+}
+
+// drawWinLoss draws a bar for each value with a gap of a pixel between bars that are at least three pixels wide.
+func (sl Sparkline) drawWinLoss(r Renderer, canvasBox Box) {
+	win := sl.Style.InheritFrom(sl.styleDefaultsWin())
+	loss := sl.LossStyle.InheritFrom(sl.styleDefaultsLoss())
+
+	barWidth := float64(canvasBox.Width()) / float64(len(sl.Values))
+	gap := 0
+	if barWidth >= 3 {
+		gap = 1
+	}
+	middle := canvasBox.Top + canvasBox.Height()>>1
+	for index, v := range sl.Values {
+		if math.IsNaN(v) || v == 0 {
+			continue
+		}
+		bar := Box{
+			Left:  canvasBox.Left + int(math.Round(float64(index)*barWidth)),
+			Right: canvasBox.Left + int(math.Round(float64(index+1)*barWidth)) - gap,
+		}
+		style := win
+		if v > 0 {
+			bar.Top, bar.Bottom = canvasBox.Top, middle-gap
+		} else {
+			bar.Top, bar.Bottom = middle+gap, canvasBox.Bottom
+			style = loss
+		}
+		Draw.Box(r, bar, style)
+	}
+}
+
+// getValues returns the values as a series with their indexes as x values.
+func (sl Sparkline) getValues() ContinuousSeries {
+	xvalues := make([]float64, len(sl.Values))
+	for index := range xvalues {
+		xvalues[index] = float64(index)
+	}
+	return ContinuousSeries{XValues: xvalues, YValues: sl.Values}
+}
+
+// describe summarizes the values, i.e. "Sparkline of 10 values from 1.00 to 5.00, last 3.00.".
+func (sl Sparkline) describe() string {
+	minIndex, maxIndex, lastIndex := sl.getExtremes()
+	if lastIndex < 0 {
+		return fmt.Sprintf("Sparkline of %d values.", len(sl.Values))
+	}
+	return fmt.Sprintf("Sparkline of %d values from %s to %s, last %s.", len(sl.Values),
+		FloatValueFormatter(sl.Values[minIndex]), FloatValueFormatter(sl.Values[maxIndex]), FloatValueFormatter(sl.Values[lastIndex]))
+}
+
+func (sl Sparkline) styleDefaultsBackground() Style {
+	return Style{
+		FillColor: sl.GetColorPalette().BackgroundColor(),
+	}
+}
+
+func (sl Sparkline) styleDefaultsLine() Style {
+	return Style{
+		StrokeColor: sl.GetColorPalette().GetSeriesColor(0),
+		StrokeWidth: DefaultSeriesLineWidth,
+	}
+}
+
+func (sl Sparkline) styleDefaultsWin() Style {
+	return Style{
+		FillColor: sl.GetColorPalette().GetSeriesColor(0),
+	}
+}
+
+func (sl Sparkline) styleDefaultsLoss() Style {
+	return Style{
+		FillColor: ColorRed,
+	}
+}
@@ -0,0 +1,68 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestSparklineRender(t *testing.T) {
+	assert := assert.New(t)
+
+	sl := Sparkline{
+		Values:     []float64{3, 1, 4, 1, 5, math.NaN(), 9, 2, 6},
+		MinMarker:  Style{Show: true, DotColor: ColorRed},
+		MaxMarker:  Style{Show: true, DotColor: ColorGreen},
+		LastMarker: StyleShow(),
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(sl.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+
+	minIndex, maxIndex, lastIndex := sl.getExtremes()
+	assert.Equal(1, minIndex)
+	assert.Equal(6, maxIndex)
+	assert.Equal(8, lastIndex)
+
+	// the padding is only as much as the markers reach past the values.
+	assert.Equal(Box{Top: 3, Left: 3, Right: 117, Bottom: 27}, sl.Box())
+
+	buffer.Reset()
+	assert.Nil(sl.Render(SVG, buffer))
+	assert.True(strings.Contains(buffer.String(), "Sparkline of 9 values from 1.00 to 9.00, last 6.00."))
+}
+
+func TestSparklineWinLoss(t *testing.T) {
+	assert := assert.New(t)
+
+	sl := Sparkline{
+		Width:   40,
+		Height:  20,
+		WinLoss: true,
+		Style:   Style{FillColor: ColorBlue},
+		Values:  []float64{1, -1, 0, 2},
+	}
+	img, err := RenderImage(sl)
+	assert.Nil(err)
+
+	// four bars of ten pixels: a win, a loss, nothing and a win.
+	r, g, b, _ := img.At(5, 2).RGBA()
+	assert.Equal([]uint8{ColorBlue.R, ColorBlue.G, ColorBlue.B}, []uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)})
+	r, g, b, _ = img.At(15, 17).RGBA()
+	assert.Equal([]uint8{ColorRed.R, ColorRed.G, ColorRed.B}, []uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)})
+	r, g, b, _ = img.At(25, 2).RGBA()
+	assert.Equal([]uint8{255, 255, 255}, []uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)})
+}
+
+func TestSparklineFlat(t *testing.T) {
+	assert := assert.New(t)
+
+	sl := Sparkline{Values: []float64{2, 2, 2}}
+	_, yrange := sl.getRanges(sl.Box(), 0, 0)
+	assert.Equal(1.0, yrange.GetMin())
+	assert.Equal(3.0, yrange.GetMax())
+	assert.NotNil(Sparkline{}.Render(PNG, bytes.NewBuffer(nil)))
+}
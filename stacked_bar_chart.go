@@ -1,10 +1,10 @@
 package chart
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"math"
+	"strings"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/wcharczuk/go-chart/seq"
@@ -40,14 +40,39 @@ type StackedBarChart struct {
 	Background Style
 	Canvas     Style
 
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
 	XAxis Style
 	YAxis Style
 
+	// ValueAxis, if shown, draws the bars to scale against an axis of their values with ticks and grid lines,
+	// instead of as shares of 100% against `YAxis`.
+	ValueAxis YAxis
+
 	BarSpacing int
 
+	// CornerRadius rounds the corners of the bars that `Corners` picks, by default the top corners;
+	// the bars are rounded at the ends of the stack rather than each segment.
+	CornerRadius int
+	Corners      BarCorners
+
 	Font        *truetype.Font
 	defaultFont *truetype.Font
 
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
 	Bars     []StackedBar
 	Elements []Renderable
 }
@@ -96,9 +121,12 @@ func (sbc StackedBarChart) GetBarSpacing() int {
 }
 
 // Render renders the chart with the given renderer to the given io.Writer.
-func (sbc StackedBarChart) Render(rp RendererProvider, w io.Writer) error {
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (sbc StackedBarChart) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
 	if len(sbc.Bars) == 0 {
-		return errors.New("please provide at least one bar")
+		return newValidationError(ErrEmptySeries, "please provide at least one bar")
 	}
 
 	r, err := rp(sbc.GetWidth(), sbc.GetHeight())
@@ -106,8 +134,10 @@ func (sbc StackedBarChart) Render(rp RendererProvider, w io.Writer) error {
 		return err
 	}
 
-	if sbc.Font == nil {
-		defaultFont, err := GetDefaultFont()
+	if sbc.NoText {
+		r = textFreeRenderer{r}
+	} else if sbc.Font == nil {
+		defaultFont, err := loadDefaultFont()
 		if err != nil {
 			return err
 		}
@@ -116,16 +146,34 @@ func (sbc StackedBarChart) Render(rp RendererProvider, w io.Writer) error {
 	r.SetDPI(sbc.GetDPI(DefaultDPI))
 
 	canvasBox := sbc.getAdjustedCanvasBox(r, sbc.getDefaultCanvasBox())
+	sbc.BackgroundImage.draw(r, Box{Right: sbc.GetWidth(), Bottom: sbc.GetHeight()})
 	sbc.drawCanvas(r, canvasBox)
-	sbc.drawBars(r, canvasBox)
+	sbc.Watermark.draw(r, Box{Right: sbc.GetWidth(), Bottom: sbc.GetHeight()}, sbc.GetFont(), sbc.GetColorPalette(), false)
+	cl := ChartLayout{Canvas: canvasBox}
+	if sbc.hasValueAxis() {
+		yr := sbc.getValueRange(canvasBox)
+		if yr.GetMax()-yr.GetMin() == 0 {
+			return newValidationError(ErrInvalidRange, "invalid data range; cannot be zero")
+		}
+		cl.YRange = yr
+		callRenderHook(sbc.BeforeRender, r, cl)
+		sbc.drawValueAxis(r, canvasBox, yr)
+		sbc.drawBars(r, canvasBox, yr)
+	} else {
+		callRenderHook(sbc.BeforeRender, r, cl)
+		sbc.drawBars(r, canvasBox, nil)
+		sbc.drawYAxis(r, canvasBox)
+	}
 	sbc.drawXAxis(r, canvasBox)
-	sbc.drawYAxis(r, canvasBox)
 
 	sbc.drawTitle(r)
 	for _, a := range sbc.Elements {
 		a(r, canvasBox, sbc.styleDefaultsElements())
 	}
+	sbc.Watermark.draw(r, Box{Right: sbc.GetWidth(), Bottom: sbc.GetHeight()}, sbc.GetFont(), sbc.GetColorPalette(), true)
+	callRenderHook(sbc.AfterRender, r, cl)
 
+	setAccessibility(r, sbc.Accessibility.withDefaults(sbc.Title, sbc.describeBars))
 	return r.Save(w)
 }
 
@@ -133,10 +181,15 @@ func (sbc StackedBarChart) drawCanvas(r Renderer, canvasBox Box) {
 	Draw.Box(r, canvasBox, sbc.getCanvasStyle())
 }
 
-func (sbc StackedBarChart) drawBars(r Renderer, canvasBox Box) {
+// drawBars draws the bars as shares of 100%, or to scale on `yr` if it is not nil.
+func (sbc StackedBarChart) drawBars(r Renderer, canvasBox Box, yr Range) {
 	xoffset := canvasBox.Left
 	for _, bar := range sbc.Bars {
-		sbc.drawBar(r, canvasBox, xoffset, bar)
+		if yr != nil {
+			sbc.drawScaledBar(r, canvasBox, yr, xoffset, bar)
+		} else {
+			sbc.drawBar(r, canvasBox, xoffset, bar)
+		}
 		xoffset += (sbc.GetBarSpacing() + bar.GetWidth())
 	}
 }
@@ -147,6 +200,7 @@ func (sbc StackedBarChart) drawBar(r Renderer, canvasBox Box, xoffset int, bar S
 	bxr := bxl + bar.GetWidth()
 
 	normalizedBarComponents := Values(bar.Values).Normalize()
+	first, last := stackEnds(normalizedBarComponents)
 	yoffset := canvasBox.Top
 	for index, bv := range normalizedBarComponents {
 		barHeight := int(math.Ceil(bv.Value * float64(canvasBox.Height())))
@@ -156,7 +210,12 @@ func (sbc StackedBarChart) drawBar(r Renderer, canvasBox Box, xoffset int, bar S
 			Right:  bxr,
 			Bottom: util.Math.MinInt(yoffset+barHeight, canvasBox.Bottom-DefaultStrokeWidth),
 		}
-		Draw.Box(r, barBox, bv.Style.InheritFrom(sbc.styleDefaultsStackedBarValue(index)))
+		setTooltip(r, Tooltip{
+			Title: tooltipTitle(bar.Name, bv.Label, PercentValueFormatter(bv.Value)),
+			X:     bv.Label,
+			Y:     formatTooltipValue(bv.Value),
+		})
+		Draw.RoundedBox(r, barBox, sbc.getCornerRadii(index == first, index == last), bv.Style.InheritFrom(sbc.styleDefaultsStackedBarValue(index)))
 		yoffset += barHeight
 	}
 
@@ -375,3 +434,16 @@ func (sbc StackedBarChart) styleDefaultsElements() Style {
 		Font: sbc.GetFont(),
 	}
 }
+
+// describeBars summarizes the bars, i.e. "Stacked bar chart of 2 bars: a 3.00 (x 1.00, y 2.00); ...".
+func (sbc StackedBarChart) describeBars() string {
+	summaries := make([]string, len(sbc.Bars))
+	for index, bar := range sbc.Bars {
+		var total float64
+		for _, v := range bar.Values {
+			total += v.Value
+		}
+		summaries[index] = fmt.Sprintf("%s %s (%s)", bar.Name, FloatValueFormatter(total), listValues(bar.Values))
+	}
+	return fmt.Sprintf("Stacked bar chart of %d bars: %s.", len(sbc.Bars), strings.Join(summaries, "; "))
+}
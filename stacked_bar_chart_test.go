@@ -0,0 +1,59 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func testStackedBarChart() StackedBarChart {
+	return StackedBarChart{
+		XAxis: StyleShow(),
+		Bars: []StackedBar{
+			{Name: "Q1", Values: []Value{{Label: "North", Value: 10}, {Label: "South", Value: 5}}},
+			{Name: "Q2", Values: []Value{{Value: 20}, {Value: 8}, {Label: "West", Value: 2}}},
+		},
+	}
+}
+
+func TestStackedBarChartGetValueRange(t *testing.T) {
+	assert := assert.New(t)
+
+	sbc := testStackedBarChart()
+	yr := sbc.getValueRange(Box{Top: 0, Bottom: 100})
+	assert.Zero(yr.GetMin())
+	assert.Equal(30, yr.GetMax())
+	assert.Equal(100, yr.GetDomain())
+
+	sbc.ValueAxis.Range = &ContinuousRange{Min: 0, Max: 50}
+	yr = sbc.getValueRange(Box{Top: 0, Bottom: 100})
+	assert.Equal(50, yr.GetMax())
+}
+
+func TestStackedBarChartGetLegendEntries(t *testing.T) {
+	assert := assert.New(t)
+
+	sbc := testStackedBarChart()
+	labels, styles := sbc.getLegendEntries()
+	assert.Equal([]string{"North", "South", "West"}, labels)
+	assert.Len(styles, 3)
+	assert.Equal(sbc.GetColorPalette().GetSeriesColor(2), styles[2].FillColor)
+}
+
+func TestStackedBarChartRenderValueAxis(t *testing.T) {
+	assert := assert.New(t)
+
+	sbc := testStackedBarChart()
+	sbc.ValueAxis = YAxis{
+		Style:          StyleShow(),
+		GridMajorStyle: StyleShow(),
+	}
+	sbc.Elements = []Renderable{StackedBarLegend(&sbc)}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(sbc.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "West"))
+	assert.True(strings.Contains(buf.String(), "30"))
+}
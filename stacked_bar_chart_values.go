@@ -0,0 +1,133 @@
+package chart
+
+import (
+	"math"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// hasValueAxis returns if the bars are drawn to scale against the value axis.
+func (sbc StackedBarChart) hasValueAxis() bool {
+	return sbc.ValueAxis.Style.Show
+}
+
+// getValueRange returns the range of the value axis, from zero to the largest bar total unless the axis sets a range.
+func (sbc StackedBarChart) getValueRange(canvasBox Box) Range {
+	var yr Range = &ContinuousRange{}
+	if sbc.ValueAxis.Range != nil && !sbc.ValueAxis.Range.IsZero() {
+		yr = sbc.ValueAxis.Range
+	} else {
+		var max float64
+		for _, bar := range sbc.Bars {
+			max = math.Max(max, stackedBarTotal(bar))
+		}
+		yr.SetMin(0)
+		yr.SetMax(max)
+	}
+	yr.SetDomain(canvasBox.Height())
+	return yr
+}
+
+// getValueFormatter returns the value formatter of the value axis.
+func (sbc StackedBarChart) getValueFormatter() ValueFormatter {
+	if sbc.ValueAxis.ValueFormatter != nil {
+		return sbc.ValueAxis.ValueFormatter
+	}
+	if sbc.ValueAxis.Unit != nil {
+		return sbc.ValueAxis.Unit.Format
+	}
+	return FloatValueFormatter
+}
+
+// drawValueAxis draws the value axis with its ticks and grid lines.
+func (sbc StackedBarChart) drawValueAxis(r Renderer, canvasBox Box, yr Range) {
+	ticks := sbc.ValueAxis.GetTicks(r, yr, sbc.styleDefaultsAxes(), sbc.getValueFormatter())
+	sbc.ValueAxis.Render(r, canvasBox, yr, sbc.styleDefaultsAxes(), ticks)
+}
+
+// drawScaledBar draws a bar to scale, with its first value on top like the bars drawn as shares of 100%.
+func (sbc StackedBarChart) drawScaledBar(r Renderer, canvasBox Box, yr Range, xoffset int, bar StackedBar) {
+	bxl := xoffset + (sbc.GetBarSpacing() >> 1)
+	bxr := bxl + bar.GetWidth()
+
+	first, last := stackEnds(bar.Values)
+	remaining := stackedBarTotal(bar)
+	for index, bv := range bar.Values {
+		if bv.Value <= 0 {
+			continue
+		}
+		top := canvasBox.Bottom - yr.Translate(remaining)
+		remaining -= bv.Value
+		bottom := canvasBox.Bottom - yr.Translate(remaining)
+		barBox := Box{
+			Top:    top,
+			Left:   bxl,
+			Right:  bxr,
+			Bottom: bottom,
+		}
+		setTooltip(r, Tooltip{
+			Title: tooltipTitle(bar.Name, bv.Label, sbc.getValueFormatter()(bv.Value)),
+			X:     bv.Label,
+			Y:     formatTooltipValue(bv.Value),
+		})
+		Draw.RoundedBox(r, barBox, sbc.getCornerRadii(index == first, index == last), bv.Style.InheritFrom(sbc.styleDefaultsStackedBarValue(index)))
+	}
+}
+
+// stackEnds returns the indexes of the first and last values of a bar that are drawn, i.e. are positive;
+// the first is at the top of the stack.
+func stackEnds(values []Value) (first, last int) {
+	first, last = -1, -1
+	for index, v := range values {
+		if v.Value > 0 {
+			if first < 0 {
+				first = index
+			}
+			last = index
+		}
+	}
+	return
+}
+
+// getCornerRadii returns the radii of the corners of a segment; the top of the stack can be rounded,
+// and its bottom too if all corners are.
+func (sbc StackedBarChart) getCornerRadii(isTop, isBottom bool) [4]int {
+	return barCornerRadii(sbc.CornerRadius, false, false, isBottom && sbc.Corners == BarCornersAll, isTop)
+}
+
+// stackedBarTotal returns the sum of the positive values of a bar.
+func stackedBarTotal(bar StackedBar) (total float64) {
+	for _, v := range bar.Values {
+		if v.Value > 0 {
+			total += v.Value
+		}
+	}
+	return
+}
+
+// StackedBarLegend returns a legend renderable for a stacked bar chart, which names the segment colors.
+// Segments are named by the label of the first bar with a label for the segment.
+func StackedBarLegend(sbc *StackedBarChart, userDefaults ...Style) Renderable {
+	return func(r Renderer, cb Box, chartDefaults Style) {
+		labels, swatches := sbc.getLegendEntries()
+		drawSwatchLegend(r, cb, chartDefaults, userDefaults, labels, swatches)
+	}
+}
+
+// getLegendEntries returns the names and styles of the segments, in segment order.
+func (sbc StackedBarChart) getLegendEntries() (labels []string, styles []Style) {
+	var segments int
+	for _, bar := range sbc.Bars {
+		segments = util.Math.MaxInt(segments, len(bar.Values))
+	}
+	for index := 0; index < segments; index++ {
+		for _, bar := range sbc.Bars {
+			if index < len(bar.Values) && len(bar.Values[index].Label) > 0 {
+				labels = append(labels, bar.Values[index].Label)
+				styles = append(styles, bar.Values[index].Style.InheritFrom(sbc.styleDefaultsStackedBarValue(index)))
+				break
+			}
+		}
+	}
+	return
+}
@@ -50,6 +50,10 @@ type Style struct {
 	DotColorProvider DotColorProvider
 
 	FillColor drawing.Color
+	// FillGradient fills with a gradient rather than the fill color, where the renderer supports it.
+	FillGradient *Gradient
+	// FillPattern draws a pattern of lines or dots over the fill color, in place of the gradient, where the renderer supports it.
+	FillPattern *Pattern
 
 	FontSize  float64
 	FontColor drawing.Color
@@ -60,6 +64,10 @@ type Style struct {
 	TextWrap            TextWrap
 	TextLineSpacing     int
 	TextRotationDegrees float64 //0 is unset or normal
+	TextWritingMode     TextWritingMode
+
+	// Interpolation is how line series are drawn between their points.
+	Interpolation Interpolation
 }
 
 // IsZero returns if the object is set or not.
@@ -69,6 +77,8 @@ func (s Style) IsZero() bool {
 		s.DotColor.IsZero() &&
 		s.DotWidth == 0 &&
 		s.FillColor.IsZero() &&
+		s.FillGradient == nil &&
+		s.FillPattern == nil &&
 		s.FontColor.IsZero() &&
 		s.FontSize == 0 &&
 		s.Font == nil
@@ -177,6 +187,22 @@ func (s Style) GetFillColor(defaults ...drawing.Color) drawing.Color {
 	return s.FillColor
 }
 
+// GetFillGradient returns the fill gradient.
+func (s Style) GetFillGradient(defaults ...*Gradient) *Gradient {
+	if s.FillGradient == nil && len(defaults) > 0 {
+		return defaults[0]
+	}
+	return s.FillGradient
+}
+
+// GetFillPattern returns the fill pattern.
+func (s Style) GetFillPattern(defaults ...*Pattern) *Pattern {
+	if s.FillPattern == nil && len(defaults) > 0 {
+		return defaults[0]
+	}
+	return s.FillPattern
+}
+
 // GetDotColor returns the stroke color.
 func (s Style) GetDotColor(defaults ...drawing.Color) drawing.Color {
 	if s.DotColor.IsZero() {
@@ -298,6 +324,28 @@ func (s Style) GetTextWrap(defaults ...TextWrap) TextWrap {
 	return s.TextWrap
 }
 
+// GetTextWritingMode returns the writing mode.
+func (s Style) GetTextWritingMode(defaults ...TextWritingMode) TextWritingMode {
+	if s.TextWritingMode == TextWritingModeUnset {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return TextWritingModeUnset
+	}
+	return s.TextWritingMode
+}
+
+// GetInterpolation returns the interpolation of line series.
+func (s Style) GetInterpolation(defaults ...Interpolation) Interpolation {
+	if s.Interpolation == InterpolationLinear {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return InterpolationLinear
+	}
+	return s.Interpolation
+}
+
 // GetTextLineSpacing returns the spacing in pixels between lines of text (vertically).
 func (s Style) GetTextLineSpacing(defaults ...int) int {
 	if s.TextLineSpacing == 0 {
@@ -325,6 +373,8 @@ func (s Style) WriteToRenderer(r Renderer) {
 	r.SetStrokeWidth(s.GetStrokeWidth())
 	r.SetStrokeDashArray(s.GetStrokeDashArray())
 	r.SetFillColor(s.GetFillColor())
+	setFillGradient(r, s, Box{})
+	setFillPattern(r, s)
 	r.SetFont(s.GetFont())
 	r.SetFontColor(s.GetFontColor())
 	r.SetFontSize(s.GetFontSize())
@@ -341,6 +391,8 @@ func (s Style) WriteDrawingOptionsToRenderer(r Renderer) {
 	r.SetStrokeWidth(s.GetStrokeWidth())
 	r.SetStrokeDashArray(s.GetStrokeDashArray())
 	r.SetFillColor(s.GetFillColor())
+	setFillGradient(r, s, Box{})
+	setFillPattern(r, s)
 }
 
 // WriteTextOptionsToRenderer passes just the text style options to a renderer.
@@ -363,6 +415,8 @@ func (s Style) InheritFrom(defaults Style) (final Style) {
 	final.DotColorProvider = s.DotColorProvider
 
 	final.FillColor = s.GetFillColor(defaults.FillColor)
+	final.FillGradient = s.GetFillGradient(defaults.FillGradient)
+	final.FillPattern = s.GetFillPattern(defaults.FillPattern)
 	final.FontColor = s.GetFontColor(defaults.FontColor)
 	final.FontSize = s.GetFontSize(defaults.FontSize)
 	final.Font = s.GetFont(defaults.Font)
@@ -372,6 +426,8 @@ func (s Style) InheritFrom(defaults Style) (final Style) {
 	final.TextWrap = s.GetTextWrap(defaults.TextWrap)
 	final.TextLineSpacing = s.GetTextLineSpacing(defaults.TextLineSpacing)
 	final.TextRotationDegrees = s.GetTextRotationDegrees(defaults.TextRotationDegrees)
+	final.TextWritingMode = s.GetTextWritingMode(defaults.TextWritingMode)
+	final.Interpolation = s.GetInterpolation(defaults.Interpolation)
 
 	return
 }
@@ -388,7 +444,9 @@ func (s Style) GetStrokeOptions() Style {
 // GetFillOptions returns the fill components.
 func (s Style) GetFillOptions() Style {
 	return Style{
-		FillColor: s.FillColor,
+		FillColor:    s.FillColor,
+		FillGradient: s.FillGradient,
+		FillPattern:  s.FillPattern,
 	}
 }
 
@@ -407,6 +465,8 @@ func (s Style) GetFillAndStrokeOptions() Style {
 	return Style{
 		StrokeDashArray: s.StrokeDashArray,
 		FillColor:       s.FillColor,
+		FillGradient:    s.FillGradient,
+		FillPattern:     s.FillPattern,
 		StrokeColor:     s.StrokeColor,
 		StrokeWidth:     s.StrokeWidth,
 	}
@@ -423,6 +483,7 @@ func (s Style) GetTextOptions() Style {
 		TextWrap:            s.TextWrap,
 		TextLineSpacing:     s.TextLineSpacing,
 		TextRotationDegrees: s.TextRotationDegrees,
+		TextWritingMode:     s.TextWritingMode,
 	}
 }
 
@@ -438,5 +499,5 @@ func (s Style) ShouldDrawDot() bool {
 
 // ShouldDrawFill tells drawing functions if they should draw the stroke.
 func (s Style) ShouldDrawFill() bool {
-	return !s.FillColor.IsZero()
+	return !s.FillColor.IsZero() || s.FillGradient != nil || s.FillPattern != nil
 }
@@ -0,0 +1,99 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SVGOptions are options of svg output.
+type SVGOptions struct {
+	// Classes wraps the parts of a chart in `<g>` elements with ids and class names, so the svg can be styled
+	// with css or scripted. The parts are the `background`, the `canvas`, the axes (`x-axis`, `y-axis`, and their
+	// `-secondary` counterparts, class `axis`) and their `gridlines`, each series (class `series series-N`, with
+	// an id from the series name), the `title`, the `legend`, and each of the elements (class `element element-N`).
+	Classes bool
+	// IDPrefix is prefixed to the ids, so several charts can be embedded in the same page; it is prefixed to the
+	// ids of the clip paths, gradients and patterns the charts refer to as well as to the ids of the groups.
+	IDPrefix string
+	// Stylesheet is css written into a `<style>` element at the start of the svg.
+	Stylesheet string
+	// Tooltips attaches a `<title>` to the point markers of series, the bars of bar charts and the slices of pie
+	// charts, which browsers show as a tooltip on hover; i.e. `Sales: 2024-03-01, 1.20k`.
+	Tooltips bool
+	// TooltipData also writes the values of the shapes with tooltips as `data-x` and `data-y` attributes:
+	// the x and y values of points in full precision, and the labels and values of bars and slices.
+	TooltipData bool
+}
+
+// SVGWithOptions returns a provider of svg/vector renderers with the given options, i.e.
+//
+//	graph.Render(chart.SVGWithOptions(chart.SVGOptions{Classes: true, Stylesheet: ".series:hover { opacity: 0.5 }"}), w)
+func SVGWithOptions(options SVGOptions) RendererProvider {
+	return func(width, height int) (Renderer, error) {
+		r, err := SVG(width, height)
+		if err != nil {
+			return nil, err
+		}
+		vr := r.(*vectorRenderer)
+		vr.options = options
+		vr.c.idPrefix = options.IDPrefix
+		if len(options.Stylesheet) > 0 {
+			vr.c.Stylesheet(options.Stylesheet)
+		}
+		return vr, nil
+	}
+}
+
+// groupRenderer is a renderer that can group what is drawn, i.e. to tag the parts of a chart.
+type groupRenderer interface {
+	// StartGroup starts a group of what is drawn until the matching `EndGroup`; groups can be nested.
+	StartGroup(id string, classes ...string)
+	EndGroup()
+}
+
+// startGroup starts a group, if the renderer supports it.
+func startGroup(r Renderer, id string, classes ...string) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if gr, isGroupRenderer := r.(groupRenderer); isGroupRenderer {
+		gr.StartGroup(id, classes...)
+	}
+}
+
+// endGroup ends the last group started, if the renderer supports it.
+func endGroup(r Renderer) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if gr, isGroupRenderer := r.(groupRenderer); isGroupRenderer {
+		gr.EndGroup()
+	}
+}
+
+// seriesGroupID returns the id of the group of a series, from its name or else its index.
+func seriesGroupID(s Series, index int) string {
+	if name := svgName(s.GetName()); len(name) > 0 {
+		return "series-" + name
+	}
+	return fmt.Sprintf("series-%d", index)
+}
+
+// svgName returns text as an id or class name: lower case letters and digits, with runs of anything else as a `-`.
+func svgName(text string) string {
+	var output strings.Builder
+	var separate bool
+	for _, r := range strings.ToLower(text) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = output.Len() > 0
+			continue
+		}
+		if separate {
+			output.WriteByte('-')
+			separate = false
+		}
+		output.WriteRune(r)
+	}
+	return output.String()
+}
@@ -0,0 +1,115 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func svgOptionsTestChart() Chart {
+	return Chart{
+		Title:      "Load",
+		TitleStyle: StyleShow(),
+		XAxis:      XAxis{Style: StyleShow(), GridMajorStyle: StyleShow()},
+		YAxis:      YAxis{Style: StyleShow()},
+		Legend:     LegendOptions{Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{Name: "CPU Load", XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{2, 1, 3}},
+		},
+	}
+}
+
+func TestSVGWithOptionsClasses(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(svgOptionsTestChart().Render(SVGWithOptions(SVGOptions{Classes: true, IDPrefix: "load-", Stylesheet: ".series:hover { opacity: 0.5 }"}), buffer))
+	svg := buffer.String()
+
+	assert.True(strings.Contains(svg, "<style><![CDATA[\n.series:hover { opacity: 0.5 }\n]]></style>"))
+	for _, group := range []string{
+		`<g id="load-background" class="background">`,
+		`<g id="load-canvas" class="canvas">`,
+		`<g id="load-x-axis" class="axis x-axis">`,
+		`<g id="load-x-axis-gridlines" class="gridlines">`,
+		`<g id="load-y-axis" class="axis y-axis">`,
+		`<g id="load-series-cpu-load" class="series series-0">`,
+		`<g id="load-series-1" class="series series-1">`,
+		`<g id="load-title" class="title">`,
+		`<g id="load-legend" class="legend">`,
+	} {
+		assert.True(strings.Contains(svg, group), group)
+	}
+
+	// the groups are balanced.
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	var err error
+	for err == nil {
+		_, err = decoder.Token()
+	}
+	assert.Equal(io.EOF, err)
+}
+
+func TestSVGWithOptionsUniqueIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	c := svgOptionsTestChart()
+	c.Series[1] = ContinuousSeries{Name: "cpu load", XValues: []float64{1, 2, 3}, YValues: []float64{2, 1, 3}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVGWithOptions(SVGOptions{Classes: true}), buffer))
+	assert.True(strings.Contains(buffer.String(), `<g id="series-cpu-load-2" class="series series-1">`))
+}
+
+func TestSVGWithOptionsIDPrefixDefinitions(t *testing.T) {
+	assert := assert.New(t)
+
+	ids := func(prefix string) []string {
+		c := svgOptionsTestChart()
+		c.ClipSeries = true
+		c.Series = append(c.Series,
+			ShadedRegion{Style: Style{Show: true, FillGradient: NewLinearGradient(90, ColorBlue, ColorWhite)}, From: 1, To: 1.5},
+			ShadedRegion{Style: Style{Show: true, FillColor: ColorWhite, FillPattern: NewPattern(PatternKindDots, ColorBlack)}, From: 2, To: 2.5},
+		)
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(SVGWithOptions(SVGOptions{IDPrefix: prefix}), buffer))
+		svg := buffer.String()
+
+		var found []string
+		for _, match := range regexp.MustCompile(`id="([^"]+)"`).FindAllStringSubmatch(svg, -1) {
+			found = append(found, match[1])
+			assert.True(strings.HasPrefix(match[1], prefix), match[1])
+			assert.True(strings.Contains(svg, "url(#"+match[1]+")"), match[1])
+		}
+		return found
+	}
+
+	first, second := ids("first-"), ids("second-")
+	assert.Len(first, 3)
+	assert.Len(second, 3)
+	for _, id := range first {
+		for _, other := range second {
+			assert.NotEqual(id, other)
+		}
+	}
+}
+
+func TestSVGWithoutClasses(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(svgOptionsTestChart().Render(SVG, buffer))
+	assert.False(strings.Contains(buffer.String(), "<g "))
+}
+
+func TestSVGName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("cpu-load-1m", svgName(" CPU Load (1m)"))
+	assert.Equal("", svgName("--"))
+}
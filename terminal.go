@@ -0,0 +1,175 @@
+package chart
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+)
+
+const (
+	// DefaultTerminalColumns is the default width of terminal charts, in characters.
+	DefaultTerminalColumns = 80
+	// DefaultTerminalRows is the default height of terminal charts, in lines.
+	DefaultTerminalRows = 24
+	// DefaultTerminalInkThreshold is how far, in any channel, a color must be from the background to be drawn
+	// as a dot of braille.
+	DefaultTerminalInkThreshold = 48
+)
+
+// TerminalMode is how terminal charts draw pixels with characters.
+type TerminalMode int
+
+const (
+	// TerminalModeBlocks draws two pixels a character, one above the other, with upper half blocks in the
+	// foreground and background colors; it is the default.
+	TerminalModeBlocks TerminalMode = iota
+	// TerminalModeBraille draws eight pixels a character, two across and four down, with braille dots in the
+	// average color of the dots on a background color; lines are finer, but a character has one color.
+	TerminalModeBraille
+)
+
+// Terminal returns a renderer provider for raster renderers that write charts as text for terminals, i.e. for
+// previews from command line tools: unicode characters colored with 24 bit ansi escapes, `columns` characters wide
+// and `rows` lines high, which default to `DefaultTerminalColumns` and `DefaultTerminalRows`. The chart is drawn
+// at its own size and scaled to fit the characters; terminal characters are about twice as high as they are wide,
+// so the chart should be about as wide to high as the columns are to twice the rows. Scaling keeps the color of
+// the pixel furthest from the background in each area, so thin lines aren't lost.
+func Terminal(columns, rows int, mode TerminalMode) RendererProvider {
+	if columns <= 0 {
+		columns = DefaultTerminalColumns
+	}
+	if rows <= 0 {
+		rows = DefaultTerminalRows
+	}
+	return func(width, height int) (Renderer, error) {
+		r, err := PNG(width, height)
+		if err != nil {
+			return nil, err
+		}
+		r.(*rasterRenderer).encoder = func(w io.Writer, i image.Image) error {
+			return encodeTerminal(w, i, columns, rows, mode)
+		}
+		return r, nil
+	}
+}
+
+// encodeTerminal writes an image as lines of colored characters.
+func encodeTerminal(w io.Writer, i image.Image, columns, rows int, mode TerminalMode) error {
+	across, down := 1, 2
+	if mode == TerminalModeBraille {
+		across, down = 2, 4
+	}
+	background := terminalColor(i.At(i.Bounds().Min.X, i.Bounds().Min.Y))
+	pixels := inkPool(i, columns*across, rows*down, background)
+
+	bw := bufio.NewWriter(w)
+	for row := 0; row < rows; row++ {
+		var foreground, back color.RGBA
+		written := false
+		for column := 0; column < columns; column++ {
+			var glyph rune
+			var cellForeground, cellBackground color.RGBA
+			if mode == TerminalModeBraille {
+				glyph, cellForeground = brailleCell(pixels, column*across, row*down, columns*across, background)
+				cellBackground = background
+			} else {
+				glyph = '▀'
+				cellForeground = pixels[row*down*columns+column].color
+				cellBackground = pixels[(row*down+1)*columns+column].color
+			}
+			if !written || cellForeground != foreground {
+				fmt.Fprintf(bw, "\x1b[38;2;%d;%d;%dm", cellForeground.R, cellForeground.G, cellForeground.B)
+			}
+			if !written || cellBackground != back {
+				fmt.Fprintf(bw, "\x1b[48;2;%d;%d;%dm", cellBackground.R, cellBackground.G, cellBackground.B)
+			}
+			foreground, back, written = cellForeground, cellBackground, true
+			bw.WriteRune(glyph)
+		}
+		bw.WriteString("\x1b[0m\n")
+	}
+	return bw.Flush()
+}
+
+// brailleDots are the bits of the braille dots of a character, by their column and row.
+var brailleDots = [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+
+// brailleCell returns the braille character of the pixels of a cell that are ink, and their average color.
+func brailleCell(pixels []terminalPixel, left, top, width int, background color.RGBA) (rune, color.RGBA) {
+	glyph := rune(0x2800)
+	var r, g, b, count int
+	for dx := 0; dx < 2; dx++ {
+		for dy := 0; dy < 4; dy++ {
+			p := pixels[(top+dy)*width+left+dx]
+			if !p.ink {
+				continue
+			}
+			glyph |= brailleDots[dx][dy]
+			r, g, b, count = r+int(p.color.R), g+int(p.color.G), b+int(p.color.B), count+1
+		}
+	}
+	if count == 0 {
+		return ' ', background
+	}
+	return glyph, color.RGBA{R: uint8(r / count), G: uint8(g / count), B: uint8(b / count), A: 255}
+}
+
+// terminalPixel is a pixel of an image scaled for a terminal: the color furthest from the background in its area,
+// and if it is far enough to be ink.
+type terminalPixel struct {
+	color color.RGBA
+	ink   bool
+}
+
+// inkPool scales an image to a size, keeping the color furthest from the background in the area of each pixel.
+func inkPool(i image.Image, width, height int, background color.RGBA) []terminalPixel {
+	bounds := i.Bounds()
+	pixels := make([]terminalPixel, width*height)
+	for y := 0; y < height; y++ {
+		top, bottom := bounds.Min.Y+y*bounds.Dy()/height, bounds.Min.Y+(y+1)*bounds.Dy()/height
+		if bottom == top {
+			bottom++
+		}
+		for x := 0; x < width; x++ {
+			left, right := bounds.Min.X+x*bounds.Dx()/width, bounds.Min.X+(x+1)*bounds.Dx()/width
+			if right == left {
+				right++
+			}
+			best, bestDistance := background, -1
+			for sy := top; sy < bottom; sy++ {
+				for sx := left; sx < right; sx++ {
+					c := terminalColor(i.At(sx, sy))
+					if distance := channelDistance(c, background); distance > bestDistance {
+						best, bestDistance = c, distance
+					}
+				}
+			}
+			pixels[y*width+x] = terminalPixel{color: best, ink: bestDistance >= DefaultTerminalInkThreshold}
+		}
+	}
+	return pixels
+}
+
+// terminalColor returns a color as opaque rgb; terminals have no transparency, so transparent colors are drawn
+// over white.
+func terminalColor(c color.Color) color.RGBA {
+	r, g, b, a := c.RGBA()
+	white := 0xffff - a
+	return color.RGBA{R: uint8((r + white) >> 8), G: uint8((g + white) >> 8), B: uint8((b + white) >> 8), A: 255}
+}
+
+// channelDistance returns the largest difference of the channels of two colors.
+func channelDistance(a, b color.RGBA) int {
+	distance := 0
+	for _, d := range []int{int(a.R) - int(b.R), int(a.G) - int(b.G), int(a.B) - int(b.B)} {
+		if d < 0 {
+			d = -d
+		}
+		if d > distance {
+			distance = d
+		}
+	}
+	return distance
+}
@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestTerminalRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  320,
+		Height: 120,
+		Series: []Series{ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}}},
+	}
+	for _, mode := range []TerminalMode{TerminalModeBlocks, TerminalModeBraille} {
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(Terminal(40, 8, mode), buffer))
+		lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+		assert.Len(lines, 8)
+		for _, line := range lines {
+			assert.True(strings.HasSuffix(line, "\x1b[0m"))
+			assert.True(strings.Contains(line, "\x1b[48;2;255;255;255m"), line)
+		}
+		if mode == TerminalModeBlocks {
+			assert.Equal(40, strings.Count(lines[0], "▀"))
+		}
+		// the blue line is drawn, antialiased.
+		var hasBlue bool
+		for _, match := range regexp.MustCompile(`\x1b\[38;2;(\d+);(\d+);(\d+)m`).FindAllStringSubmatch(buffer.String(), -1) {
+			r, _ := strconv.Atoi(match[1])
+			b, _ := strconv.Atoi(match[3])
+			hasBlue = hasBlue || b-r > 100
+		}
+		assert.True(hasBlue, mode)
+	}
+}
+
+func TestEncodeTerminalBraille(t *testing.T) {
+	assert := assert.New(t)
+
+	// a one pixel black line across a white image, half way down the top cell.
+	m := image.NewRGBA(image.Rect(0, 0, 40, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 40; x++ {
+			m.Set(x, y, color.White)
+		}
+	}
+	for x := 0; x < 40; x++ {
+		m.Set(x, 2, color.Black)
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(encodeTerminal(buffer, m, 2, 2, TerminalModeBraille))
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Len(lines, 2)
+	// the line is the third dot row of the top cells, in black, and the bottom cells are blank.
+	assert.Equal("\x1b[38;2;0;0;0m\x1b[48;2;255;255;255m⠤⠤\x1b[0m", lines[0])
+	assert.Equal("\x1b[38;2;255;255;255m\x1b[48;2;255;255;255m  \x1b[0m", lines[1])
+}
+
+func TestEncodeTerminalBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	// transparent pixels are drawn as white.
+	m := image.NewNRGBA(image.Rect(0, 0, 1, 2))
+	m.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(encodeTerminal(buffer, m, 1, 1, TerminalModeBlocks))
+	assert.Equal("\x1b[38;2;255;0;0m\x1b[48;2;255;255;255m▀\x1b[0m\n", buffer.String())
+}
@@ -36,6 +36,19 @@ const (
 	TextWrapRune TextWrap = 3
 )
 
+// TextWritingMode is an enum for the direction lines of text are written in.
+type TextWritingMode int
+
+const (
+	// TextWritingModeUnset is the unset state for writing mode options.
+	TextWritingModeUnset TextWritingMode = 0
+	// TextWritingModeHorizontal writes lines left to right, or right to left for Hebrew and Arabic.
+	TextWritingModeHorizontal TextWritingMode = 1
+	// TextWritingModeVertical writes lines top to bottom with the characters upright, and stacks lines right to left;
+	// the text isn't rotated.
+	TextWritingModeVertical TextWritingMode = 2
+)
+
 // TextVerticalAlign is an enum for the vertical alignment options.
 type TextVerticalAlign int
 
@@ -140,6 +153,33 @@ func (t text) WrapFitRune(r Renderer, value string, width int, style Style) []st
 	return t.appendLast(output, line)
 }
 
+// Ellipsize returns the value if it fits within a width, or else the longest start of it that fits
+// with an ellipsis after it; if not even the ellipsis fits it returns an empty string.
+func (t text) Ellipsize(r Renderer, value string, width int, style Style) string {
+	style.WriteTextOptionsToRenderer(r)
+	if r.MeasureText(value).Width() <= width {
+		return value
+	}
+	runes := []rune(value)
+	shortened := func(length int) string {
+		return strings.TrimRight(string(runes[:length]), " \t") + "…"
+	}
+	// binary search for the longest start that fits.
+	low, high := 0, len(runes)
+	for low < high {
+		middle := (low + high + 1) >> 1
+		if r.MeasureText(shortened(middle)).Width() <= width {
+			low = middle
+		} else {
+			high = middle - 1
+		}
+	}
+	if low == 0 && r.MeasureText("…").Width() > width {
+		return ""
+	}
+	return shortened(low)
+}
+
 func (t text) Trim(value string) string {
 	return strings.Trim(value, " \t\n\r")
 }
@@ -0,0 +1,150 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// anchoredTextRenderer is a renderer that lays text out around an anchor point itself, i.e. svg with
+// `text-anchor` and `dominant-baseline`, so the text stays aligned when the viewer scales the output
+// or substitutes its own fonts.
+type anchoredTextRenderer interface {
+	// TextAnchored draws text aligned on x,y; rotated text turns about x,y.
+	TextAnchored(body string, x, y int, horizontal TextHorizontalAlign, vertical TextVerticalAlign)
+}
+
+// TextAnchored draws text aligned on x,y by the text alignment of the style: horizontally by its start, middle or end,
+// and vertically by its baseline, top, middle or bottom; rotated text turns about x,y. Renderers that can anchor text
+// lay it out themselves; others draw it offset by its measured size. Vertical text is aligned by the box of its column.
+func (d draw) TextAnchored(r Renderer, text string, x, y int, style Style) {
+	if style.TextWritingMode == TextWritingModeVertical {
+		d.verticalText(r, text, x, y, style)
+		return
+	}
+	style.GetTextOptions().WriteToRenderer(r)
+	defer r.ResetStyle()
+
+	if _, isTextFree := r.(textFreeRenderer); isTextFree {
+		return
+	}
+	target, tx, ty := r, x, y
+	if or, isOffset := target.(offsetRenderer); isOffset {
+		target, tx, ty = or.Renderer, x+or.dx, y+or.dy
+	}
+	if ar, isAnchored := target.(anchoredTextRenderer); isAnchored {
+		ar.TextAnchored(text, tx, ty, style.TextHorizontalAlign, style.TextVerticalAlign)
+		return
+	}
+
+	dx, dy := textAnchorOffset(r, text, style)
+	if style.TextRotationDegrees != 0 {
+		theta := util.Math.DegreesToRadians(style.TextRotationDegrees)
+		sin, cos := math.Sin(theta), math.Cos(theta)
+		dx, dy = dx*cos-dy*sin, dx*sin+dy*cos
+	}
+	r.Text(text, x+int(math.Round(dx)), y+int(math.Round(dy)))
+}
+
+// withTextAnchor returns a style with a text alignment, for drawing with `Draw.TextAnchored`.
+func withTextAnchor(s Style, horizontal TextHorizontalAlign, vertical TextVerticalAlign) Style {
+	s.TextHorizontalAlign, s.TextVerticalAlign = horizontal, vertical
+	return s
+}
+
+// textAnchorOffset returns the offset from the anchor of unrotated text to the start of its baseline,
+// which is where renderers draw text from.
+func textAnchorOffset(r Renderer, text string, style Style) (dx, dy float64) {
+	r.ClearTextRotation()
+	tb := r.MeasureText(text)
+	if style.TextRotationDegrees != 0 {
+		r.SetTextRotation(util.Math.DegreesToRadians(style.TextRotationDegrees))
+	}
+
+	width, height := float64(tb.Width()), float64(tb.Height())
+	switch style.TextHorizontalAlign {
+	case TextHorizontalAlignCenter:
+		dx = -width / 2
+	case TextHorizontalAlignRight:
+		dx = -width
+	}
+	switch style.TextVerticalAlign {
+	case TextVerticalAlignTop:
+		dy = height
+	case TextVerticalAlignMiddle, TextVerticalAlignMiddleBaseline:
+		dy = height / 2
+	}
+	return
+}
+
+// svgTextAnchor returns the svg `text-anchor` of a horizontal alignment, or nothing for the start of the text.
+func svgTextAnchor(align TextHorizontalAlign) string {
+	switch align {
+	case TextHorizontalAlignCenter:
+		return "middle"
+	case TextHorizontalAlignRight:
+		return "end"
+	}
+	return ""
+}
+
+// svgDominantBaseline returns the svg `dominant-baseline` of a vertical alignment, or nothing for the baseline.
+func svgDominantBaseline(align TextVerticalAlign) string {
+	switch align {
+	case TextVerticalAlignTop:
+		return "text-before-edge"
+	case TextVerticalAlignMiddle, TextVerticalAlignMiddleBaseline:
+		return "central"
+	case TextVerticalAlignBottom:
+		return "text-after-edge"
+	}
+	return ""
+}
+
+// uprightText returns the style of the characters of vertical text, which are drawn upright one at a time.
+func uprightText(s Style) Style {
+	s.TextRotationDegrees, s.TextWritingMode = 0, TextWritingModeHorizontal
+	return s
+}
+
+// measureVerticalText returns the characters of vertical text, the height of the rows they are drawn in, which is
+// the font size, and the box of the column of them, as wide as the widest.
+func measureVerticalText(r Renderer, text string, style Style) (glyphs []string, rowHeight int, box Box) {
+	uprightText(style).GetTextOptions().WriteToRenderer(r)
+	rowHeight = int(math.Ceil(drawing.PointsToPixels(r.GetDPI(), style.GetFontSize(DefaultFontSize))))
+	for _, glyph := range text {
+		glyphs = append(glyphs, string(glyph))
+		box.Right = util.Math.MaxInt(box.Right, r.MeasureText(string(glyph)).Width())
+	}
+	box.Bottom = len(glyphs) * rowHeight
+	return
+}
+
+// verticalText draws vertical text aligned on x,y by the box of its column, or by the baseline of its first
+// character; each character is centered in its row.
+func (d draw) verticalText(r Renderer, text string, x, y int, style Style) {
+	glyphs, rowHeight, box := measureVerticalText(r, text, style)
+	r.ResetStyle()
+
+	left, top := x, y-rowHeight
+	switch style.TextHorizontalAlign {
+	case TextHorizontalAlignCenter:
+		left = x - box.Width()>>1
+	case TextHorizontalAlignRight:
+		left = x - box.Width()
+	}
+	switch style.TextVerticalAlign {
+	case TextVerticalAlignTop:
+		top = y
+	case TextVerticalAlignMiddle, TextVerticalAlignMiddleBaseline:
+		top = y - box.Height()>>1
+	case TextVerticalAlignBottom:
+		top = y - box.Height()
+	}
+
+	glyphStyle := withTextAnchor(uprightText(style), TextHorizontalAlignCenter, TextVerticalAlignMiddle)
+	for index, glyph := range glyphs {
+		d.TextAnchored(r, glyph, left+box.Width()>>1, top+index*rowHeight+rowHeight>>1, glyphStyle)
+	}
+}
@@ -0,0 +1,69 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestDrawTextAnchoredSVG(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	r, err := SVG(100, 100)
+	assert.Nil(err)
+	Draw.TextAnchored(r, "label", 50, 40, Style{
+		Font:                f,
+		FontSize:            10,
+		FontColor:           ColorBlack,
+		TextHorizontalAlign: TextHorizontalAlignRight,
+		TextVerticalAlign:   TextVerticalAlignMiddle,
+		TextRotationDegrees: 45,
+	})
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	svg := buffer.String()
+	assert.True(strings.Contains(svg, `<text x="50" y="40"`), svg)
+	assert.True(strings.Contains(svg, `text-anchor="end"`), svg)
+	assert.True(strings.Contains(svg, `dominant-baseline="central"`), svg)
+	assert.True(strings.Contains(svg, `transform="rotate(45.00,50,40)"`), svg)
+}
+
+func TestDrawTextAnchoredOffsetRenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := SVG(100, 100)
+	assert.Nil(err)
+	Draw.TextAnchored(offsetRenderer{Renderer: r, dx: 10, dy: 20}, "label", 5, 5, Style{TextHorizontalAlign: TextHorizontalAlignCenter})
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	assert.True(strings.Contains(buffer.String(), `<text x="15" y="25"`), buffer.String())
+	assert.True(strings.Contains(buffer.String(), `text-anchor="middle"`))
+}
+
+func TestTextAnchorOffset(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+
+	style := Style{Font: f, FontSize: 10, TextHorizontalAlign: TextHorizontalAlignCenter, TextVerticalAlign: TextVerticalAlignTop}
+	style.GetTextOptions().WriteToRenderer(r)
+	tb := r.MeasureText("label")
+
+	dx, dy := textAnchorOffset(r, "label", style)
+	assert.InDelta(-float64(tb.Width())/2, dx, 0.0001)
+	assert.InDelta(float64(tb.Height()), dy, 0.0001)
+
+	dx, dy = textAnchorOffset(r, "label", Style{Font: f, FontSize: 10})
+	assert.Zero(dx)
+	assert.Zero(dy)
+}
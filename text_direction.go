@@ -0,0 +1,347 @@
+package chart
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// visualText returns text in the order its characters are drawn from left to right, with the letters of Arabic
+// script in their joined forms, for renderers that draw text glyph by glyph; text without right to left characters
+// is returned as is. A single line is reordered by the unicode bidirectional algorithm, without explicit embeddings
+// or isolates, so i.e. Hebrew and Arabic labels, and the numbers and Latin words in them, read the right way.
+// Svg text is left in logical order, for the viewer to lay out.
+func visualText(text string) string {
+	if !hasRightToLeft(text) {
+		return text
+	}
+	runes := shapeArabic([]rune(text))
+	reorderRunes(runes, bidiLevels(runes))
+	return string(runes)
+}
+
+// hasRightToLeft returns if text has right to left characters.
+func hasRightToLeft(text string) bool {
+	for _, r := range text {
+		if r < 0x0590 {
+			continue
+		}
+		if p, _ := bidi.LookupRune(r); p.Class() == bidi.R || p.Class() == bidi.AL {
+			return true
+		}
+	}
+	return false
+}
+
+// bidiLevels returns the embedding levels of a line of runes; the line is right to left if its first strong
+// character is.
+func bidiLevels(runes []rune) []int {
+	count := len(runes)
+	classes, original := make([]bidi.Class, count), make([]bidi.Class, count)
+	for index, r := range runes {
+		p, _ := bidi.LookupRune(r)
+		classes[index] = p.Class()
+		switch classes[index] {
+		case bidi.L, bidi.R, bidi.AL, bidi.EN, bidi.ES, bidi.ET, bidi.AN, bidi.CS, bidi.NSM, bidi.B, bidi.S, bidi.WS:
+		default:
+			// explicit formatting characters aren't supported; they are neutral.
+			classes[index] = bidi.ON
+		}
+		original[index] = classes[index]
+	}
+
+	base, sor := 0, bidi.L
+	for _, class := range classes {
+		if class == bidi.L {
+			break
+		}
+		if class == bidi.R || class == bidi.AL {
+			base, sor = 1, bidi.R
+			break
+		}
+	}
+
+	// W1 to W3: marks take the class of the character before them, numbers after Arabic letters are Arabic numbers,
+	// and Arabic letters are right to left.
+	previous, strong := sor, sor
+	for index, class := range classes {
+		if class == bidi.NSM {
+			class = previous
+		}
+		switch class {
+		case bidi.L, bidi.R, bidi.AL:
+			strong = class
+		case bidi.EN:
+			if strong == bidi.AL {
+				class = bidi.AN
+			}
+		}
+		previous = class
+		if class == bidi.AL {
+			class = bidi.R
+		}
+		classes[index] = class
+	}
+	// W4: a single separator between numbers of the same kind joins them.
+	for index := 1; index < count-1; index++ {
+		before, after := classes[index-1], classes[index+1]
+		switch {
+		case classes[index] == bidi.ES && before == bidi.EN && after == bidi.EN:
+			classes[index] = bidi.EN
+		case classes[index] == bidi.CS && before == after && (before == bidi.EN || before == bidi.AN):
+			classes[index] = before
+		}
+	}
+	// W5: terminators next to numbers, i.e. currency symbols, are part of them.
+	for start := 0; start < count; {
+		if classes[start] != bidi.ET {
+			start++
+			continue
+		}
+		end := start
+		for end < count && classes[end] == bidi.ET {
+			end++
+		}
+		if (start > 0 && classes[start-1] == bidi.EN) || (end < count && classes[end] == bidi.EN) {
+			for index := start; index < end; index++ {
+				classes[index] = bidi.EN
+			}
+		}
+		start = end
+	}
+	// W6 and W7: other separators are neutral, and numbers after left to right text are left to right.
+	strong = sor
+	for index, class := range classes {
+		switch class {
+		case bidi.ES, bidi.ET, bidi.CS:
+			classes[index] = bidi.ON
+		case bidi.L, bidi.R:
+			strong = class
+		case bidi.EN:
+			if strong == bidi.L {
+				classes[index] = bidi.L
+			}
+		}
+	}
+	// N1 and N2: neutrals between characters of the same direction take it, and others the direction of the line.
+	for start := 0; start < count; {
+		if !isBidiNeutral(classes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < count && isBidiNeutral(classes[end]) {
+			end++
+		}
+		before, after := sor, sor
+		if start > 0 {
+			before = bidiDirection(classes[start-1])
+		}
+		if end < count {
+			after = bidiDirection(classes[end])
+		}
+		direction := sor
+		if before == after {
+			direction = before
+		}
+		for index := start; index < end; index++ {
+			classes[index] = direction
+		}
+		start = end
+	}
+
+	// I1 and I2, and L1: whitespace at the end of the line is at the level of the line.
+	levels := make([]int, count)
+	for index, class := range classes {
+		switch {
+		case base == 0 && class == bidi.R:
+			levels[index] = 1
+		case base == 0 && (class == bidi.AN || class == bidi.EN):
+			levels[index] = 2
+		case base == 1 && (class == bidi.L || class == bidi.AN || class == bidi.EN):
+			levels[index] = 2
+		default:
+			levels[index] = base
+		}
+	}
+	for index := count - 1; index >= 0 && (original[index] == bidi.WS || original[index] == bidi.S); index-- {
+		levels[index] = base
+	}
+	return levels
+}
+
+// isBidiNeutral returns if a resolved class is neutral.
+func isBidiNeutral(class bidi.Class) bool {
+	return class != bidi.L && class != bidi.R && class != bidi.EN && class != bidi.AN
+}
+
+// bidiDirection returns the direction of a resolved class next to neutrals; numbers are right to left.
+func bidiDirection(class bidi.Class) bidi.Class {
+	if class == bidi.L {
+		return bidi.L
+	}
+	return bidi.R
+}
+
+// bidiMirrors are the characters drawn mirrored in right to left text.
+var bidiMirrors = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<',
+	'«': '»', '»': '«', '‹': '›', '›': '‹',
+}
+
+// reorderRunes reorders runes from logical to visual order by their levels (L2), reversing every run of runes
+// at or above each level from the highest down to the lowest odd one, and mirrors brackets in right to left runs (L4).
+func reorderRunes(runes []rune, levels []int) {
+	highest, lowestOdd := 0, -1
+	for index, level := range levels {
+		if level > highest {
+			highest = level
+		}
+		if level%2 == 1 {
+			if lowestOdd < 0 || level < lowestOdd {
+				lowestOdd = level
+			}
+			if mirrored, isMirrored := bidiMirrors[runes[index]]; isMirrored {
+				runes[index] = mirrored
+			}
+		}
+	}
+	if lowestOdd < 0 {
+		return
+	}
+	for level := highest; level >= lowestOdd; level-- {
+		for start := 0; start < len(runes); {
+			if levels[start] < level {
+				start++
+				continue
+			}
+			end := start
+			for end < len(runes) && levels[end] >= level {
+				end++
+			}
+			for i, j := start, end-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+				levels[i], levels[j] = levels[j], levels[i]
+			}
+			start = end
+		}
+	}
+}
+
+// arabicJoining is how an Arabic character joins the characters next to it.
+type arabicJoining int
+
+const (
+	arabicJoiningNone arabicJoining = iota
+	// arabicJoiningRight joins the character before it only.
+	arabicJoiningRight
+	arabicJoiningDual
+	// arabicJoiningCausing joins both sides without changing form, i.e. tatweel.
+	arabicJoiningCausing
+	// arabicJoiningTransparent is skipped over, i.e. vowel marks.
+	arabicJoiningTransparent
+)
+
+const (
+	arabicLam    = 'ل'
+	arabicTatwel = 'ـ'
+)
+
+// arabicForms are the isolated presentation forms of the Arabic letters, and their number of forms: isolated and
+// final forms, followed by initial and medial forms for letters that join on both sides.
+var arabicForms = func() map[rune][2]rune {
+	forms := make(map[rune][2]rune)
+	next := rune(0xfe80)
+	add := func(first rune, counts ...rune) {
+		for index, count := range counts {
+			forms[first+rune(index)] = [2]rune{next, count}
+			next += count
+		}
+	}
+	add(0x0621, 1, 2, 2, 2, 2, 4, 2, 4, 2, 4, 4, 4, 4, 4, 2, 2, 2, 2, 4, 4, 4, 4, 4, 4, 4, 4)
+	add(0x0641, 4, 4, 4, 4, 4, 4, 4, 2, 2, 4)
+	return forms
+}()
+
+// arabicLamAlef are the isolated forms of the ligatures of lam and the alefs; the final forms follow them.
+var arabicLamAlef = map[rune]rune{0x0622: 0xfef5, 0x0623: 0xfef7, 0x0625: 0xfef9, 0x0627: 0xfefb}
+
+// getArabicJoining returns how a character joins the characters next to it.
+func getArabicJoining(r rune) arabicJoining {
+	if r == arabicTatwel {
+		return arabicJoiningCausing
+	}
+	if forms, isLetter := arabicForms[r]; isLetter {
+		switch forms[1] {
+		case 4:
+			return arabicJoiningDual
+		case 2:
+			return arabicJoiningRight
+		}
+		return arabicJoiningNone
+	}
+	if unicode.Is(unicode.Mn, r) {
+		return arabicJoiningTransparent
+	}
+	return arabicJoiningNone
+}
+
+// shapeArabic returns runes with the Arabic letters in their presentation forms: isolated, or joined to the letters
+// before and after them, with lam and alef joined in a ligature.
+func shapeArabic(runes []rune) []rune {
+	joinings := make([]arabicJoining, len(runes))
+	for index, r := range runes {
+		joinings[index] = getArabicJoining(r)
+	}
+	// neighbor returns the index of the closest character that isn't transparent in a direction, or -1.
+	neighbor := func(index, step int) int {
+		for index += step; index >= 0 && index < len(runes); index += step {
+			if joinings[index] != arabicJoiningTransparent {
+				return index
+			}
+		}
+		return -1
+	}
+
+	shaped := make([]rune, 0, len(runes))
+	for index := 0; index < len(runes); index++ {
+		r := runes[index]
+		forms, isLetter := arabicForms[r]
+		if !isLetter {
+			shaped = append(shaped, r)
+			continue
+		}
+		previous, next := neighbor(index, -1), neighbor(index, 1)
+		joinsPrevious := previous >= 0 && (joinings[previous] == arabicJoiningDual || joinings[previous] == arabicJoiningCausing)
+
+		if ligature, isLamAlef := arabicLamAlef[runeAt(runes, index+1)]; r == arabicLam && isLamAlef {
+			if joinsPrevious {
+				ligature++
+			}
+			shaped = append(shaped, ligature)
+			index++
+			continue
+		}
+
+		joinsNext := joinings[index] == arabicJoiningDual && next >= 0 && joinings[next] != arabicJoiningNone && joinings[next] != arabicJoiningTransparent
+		form := forms[0]
+		switch {
+		case joinsPrevious && joinsNext:
+			form += 3
+		case joinsNext:
+			form += 2
+		case joinsPrevious && forms[1] > 1:
+			form++
+		}
+		shaped = append(shaped, form)
+	}
+	return shaped
+}
+
+// runeAt returns the rune at an index, or zero past the end.
+func runeAt(runes []rune, index int) rune {
+	if index < len(runes) {
+		return runes[index]
+	}
+	return 0
+}
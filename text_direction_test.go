@@ -0,0 +1,80 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestVisualText(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("label 123", visualText("label 123"))
+	assert.Equal("םולש", visualText("שלום"))
+	// numbers and latin words keep their order within right to left text, and brackets are mirrored.
+	assert.Equal("Total 123 םולש", visualText("Total שלום 123"))
+	assert.Equal("2024 תנש (םולש)", visualText("(שלום) שנת 2024"))
+	assert.Equal("$10.50 ריחמ", visualText("מחיר $10.50"))
+}
+
+func TestVisualTextArabic(t *testing.T) {
+	assert := assert.New(t)
+
+	// seen is initial, lam and alef join in their final ligature, and meem after the alef is isolated.
+	assert.Equal(string([]rune{0xfee1, 0xfefc, 0xfeb3}), visualText("سلام"))
+	// beh is initial, medial and final, and the vowel mark between them is skipped over.
+	assert.Equal(string([]rune{0xfe90, 0xfe92, 0x064e, 0xfe91}), visualText("بَبب"))
+	// dal joins only the letter before it, and numbers after arabic letters are drawn left to right.
+	assert.Equal("12 "+string([]rune{0xfe8d, 0xfea9}), visualText("دا 12"))
+}
+
+func TestDrawVerticalText(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	style := Style{
+		Font:            f,
+		FontSize:        10,
+		FontColor:       ColorBlack,
+		TextWritingMode: TextWritingModeVertical,
+	}
+
+	r, err := SVG(100, 100)
+	assert.Nil(err)
+	box := Draw.MeasureText(r, "ABC", style)
+	assert.Equal(39, box.Height())
+	assert.True(box.Width() > 0 && box.Width() < 14, box.Width())
+
+	Draw.TextAnchored(r, "ABC", 50, 10, withTextAnchor(style, TextHorizontalAlignCenter, TextVerticalAlignTop))
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	svg := buffer.String()
+	for _, glyph := range []string{`y="16"`, `y="29"`, `y="42"`} {
+		assert.True(strings.Contains(svg, `<text x="50" `+glyph), svg)
+	}
+	assert.False(strings.Contains(svg, "rotate"), svg)
+}
+
+func TestYAxisVerticalName(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Width:  400,
+		Height: 300,
+		YAxis: YAxis{
+			Style:     Style{Show: true},
+			Name:      "Revenue",
+			NameStyle: Style{Show: true, TextWritingMode: TextWritingModeVertical},
+		},
+		Series: []Series{ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}}},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	svg := buffer.String()
+	assert.True(strings.Contains(svg, ">R</text>"), svg)
+	assert.True(strings.Contains(svg, ">e</text>"), svg)
+	assert.False(strings.Contains(svg, ">Revenue</text>"), svg)
+}
@@ -0,0 +1,32 @@
+package chart
+
+import "github.com/golang/freetype/truetype"
+
+// TextFree returns a renderer provider that draws no text.
+// Text measures as empty, so no space is laid out for labels, titles or legends,
+// and no font is ever used; see the `NoText` field of the charts.
+func TextFree(rp RendererProvider) RendererProvider {
+	return func(width, height int) (Renderer, error) {
+		r, err := rp(width, height)
+		if err != nil {
+			return nil, err
+		}
+		return textFreeRenderer{r}, nil
+	}
+}
+
+// textFreeRenderer wraps a renderer and drops all text.
+type textFreeRenderer struct {
+	Renderer
+}
+
+// SetFont implements the interface method; the font is ignored.
+func (tfr textFreeRenderer) SetFont(f *truetype.Font) {}
+
+// Text implements the interface method; nothing is drawn.
+func (tfr textFreeRenderer) Text(body string, x, y int) {}
+
+// MeasureText implements the interface method; all text is empty.
+func (tfr textFreeRenderer) MeasureText(body string) Box {
+	return Box{}
+}
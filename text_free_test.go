@@ -0,0 +1,59 @@
+package chart
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestTextFree(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := TextFree(SVG)(100, 100)
+	assert.Nil(err)
+	r.SetFont(nil)
+	assert.True(r.MeasureText("hello").IsZero())
+	r.Text("hello", 10, 10)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	assert.False(strings.Contains(buffer.String(), "hello"))
+}
+
+func TestChartNoText(t *testing.T) {
+	assert := assert.New(t)
+
+	// a broken default font must not matter, as no font is loaded.
+	t.Setenv(DefaultFontEnvVar, filepath.Join(t.TempDir(), "missing.ttf"))
+	SetDefaultFont(nil)
+	defer SetDefaultFont(nil)
+
+	c := Chart{
+		Title:      "hidden title",
+		TitleStyle: StyleShow(),
+		NoText:     true,
+		XAxis:      XAxis{Style: StyleShow()},
+		YAxis:      YAxis{Style: StyleShow()},
+		Series: []Series{
+			ContinuousSeries{Name: "hidden series", XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+	c.Elements = []Renderable{Legend(&c)}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	assert.False(strings.Contains(buffer.String(), "<text"))
+
+	buffer.Reset()
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+
+	buffer.Reset()
+	assert.Nil(BarChart{NoText: true, Bars: []Value{{Label: "a", Value: 1}, {Label: "b", Value: 2}}}.Render(PNG, buffer))
+	buffer.Reset()
+	assert.Nil(PieChart{NoText: true, Values: []Value{{Label: "a", Value: 1}, {Label: "b", Value: 2}}}.Render(SVG, buffer))
+	assert.False(strings.Contains(buffer.String(), "<text"))
+}
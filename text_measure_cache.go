@@ -0,0 +1,158 @@
+package chart
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+)
+
+var (
+	// DefaultTextMeasureCache is the cache the built in renderers consult before measuring text; deterministic
+	// renderers (see `DeterministicPNG`) don't.
+	DefaultTextMeasureCache = NewTextMeasureCache(DefaultTextMeasureCacheSize)
+)
+
+// TextMeasureMethod is how a renderer measures text; the methods measure the same text differently, so their
+// measurements are cached apart.
+type TextMeasureMethod int
+
+const (
+	// TextMeasureGlyphBounds measures the bounds of the glyph outlines, as the raster renderer does.
+	TextMeasureGlyphBounds TextMeasureMethod = iota
+	// TextMeasureAdvance measures the advance width of the text and the height of the font size, as the vector
+	// and pdf renderers do.
+	TextMeasureAdvance
+)
+
+// NewTextMeasureCache returns a new text measurement cache that holds up to `capacity` entries.
+func NewTextMeasureCache(capacity int) *TextMeasureCache {
+	return &TextMeasureCache{
+		capacity: capacity,
+		enabled:  true,
+		items:    map[textMeasureKey]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// TextMeasureCacheStats are counters describing the effectiveness of a text measure cache.
+type TextMeasureCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+	Capacity  int
+}
+
+// textMeasureKey is everything that can change the (unrotated) measured size of a string.
+type textMeasureKey struct {
+	method TextMeasureMethod
+	font   *truetype.Font
+	size   float64
+	dpi    float64
+	body   string
+}
+
+type textMeasureEntry struct {
+	key textMeasureKey
+	box Box
+}
+
+// TextMeasureCache is a least recently used cache of text measurements keyed by (method, font, size, dpi, text).
+// Tick generation and word wrapping measure the same strings many times per render; the cache
+// lets those repeated measurements skip the glyph math entirely.
+// It is safe for concurrent use.
+type TextMeasureCache struct {
+	lock     sync.Mutex
+	capacity int
+	enabled  bool
+	items    map[textMeasureKey]*list.Element
+	order    *list.List
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// Enabled returns if the cache is enabled.
+func (tmc *TextMeasureCache) Enabled() bool {
+	tmc.lock.Lock()
+	defer tmc.lock.Unlock()
+	return tmc.enabled
+}
+
+// SetEnabled turns the cache on or off; disabling the cache also clears it.
+func (tmc *TextMeasureCache) SetEnabled(enabled bool) {
+	tmc.lock.Lock()
+	defer tmc.lock.Unlock()
+	tmc.enabled = enabled
+	if !enabled {
+		tmc.clear()
+	}
+}
+
+// Get returns a cached measurement for the given text and font settings.
+func (tmc *TextMeasureCache) Get(method TextMeasureMethod, font *truetype.Font, size, dpi float64, body string) (Box, bool) {
+	tmc.lock.Lock()
+	defer tmc.lock.Unlock()
+	if !tmc.enabled {
+		return Box{}, false
+	}
+	if element, hasElement := tmc.items[textMeasureKey{method: method, font: font, size: size, dpi: dpi, body: body}]; hasElement {
+		tmc.hits++
+		tmc.order.MoveToFront(element)
+		return element.Value.(*textMeasureEntry).box, true
+	}
+	tmc.misses++
+	return Box{}, false
+}
+
+// Put stores a measurement, evicting the least recently used entry if the cache is full.
+func (tmc *TextMeasureCache) Put(method TextMeasureMethod, font *truetype.Font, size, dpi float64, body string, box Box) {
+	tmc.lock.Lock()
+	defer tmc.lock.Unlock()
+	if !tmc.enabled || tmc.capacity <= 0 {
+		return
+	}
+
+	key := textMeasureKey{method: method, font: font, size: size, dpi: dpi, body: body}
+	if element, hasElement := tmc.items[key]; hasElement {
+		element.Value.(*textMeasureEntry).box = box
+		tmc.order.MoveToFront(element)
+		return
+	}
+
+	tmc.items[key] = tmc.order.PushFront(&textMeasureEntry{key: key, box: box})
+	for tmc.order.Len() > tmc.capacity {
+		oldest := tmc.order.Back()
+		tmc.order.Remove(oldest)
+		delete(tmc.items, oldest.Value.(*textMeasureEntry).key)
+		tmc.evictions++
+	}
+}
+
+// Stats returns the current cache counters.
+func (tmc *TextMeasureCache) Stats() TextMeasureCacheStats {
+	tmc.lock.Lock()
+	defer tmc.lock.Unlock()
+	return TextMeasureCacheStats{
+		Hits:      tmc.hits,
+		Misses:    tmc.misses,
+		Evictions: tmc.evictions,
+		Len:       tmc.order.Len(),
+		Capacity:  tmc.capacity,
+	}
+}
+
+// Reset clears the cache contents and counters.
+func (tmc *TextMeasureCache) Reset() {
+	tmc.lock.Lock()
+	defer tmc.lock.Unlock()
+	tmc.clear()
+	tmc.hits, tmc.misses, tmc.evictions = 0, 0, 0
+}
+
+func (tmc *TextMeasureCache) clear() {
+	tmc.items = map[textMeasureKey]*list.Element{}
+	tmc.order.Init()
+}
@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestTextMeasureCache(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	tmc := NewTextMeasureCache(2)
+
+	_, isCached := tmc.Get(TextMeasureGlyphBounds, f, 10, DefaultDPI, "foo")
+	assert.False(isCached)
+
+	tmc.Put(TextMeasureGlyphBounds, f, 10, DefaultDPI, "foo", Box{Right: 10, Bottom: 5})
+	box, isCached := tmc.Get(TextMeasureGlyphBounds, f, 10, DefaultDPI, "foo")
+	assert.True(isCached)
+	assert.Equal(10, box.Width())
+
+	_, isCached = tmc.Get(TextMeasureGlyphBounds, f, 12, DefaultDPI, "foo")
+	assert.False(isCached)
+
+	tmc.Put(TextMeasureGlyphBounds, f, 10, DefaultDPI, "bar", Box{Right: 20})
+	tmc.Put(TextMeasureGlyphBounds, f, 10, DefaultDPI, "baz", Box{Right: 30})
+
+	_, isCached = tmc.Get(TextMeasureGlyphBounds, f, 10, DefaultDPI, "foo")
+	assert.False(isCached, "foo should have been evicted")
+
+	stats := tmc.Stats()
+	assert.Equal(1, stats.Hits)
+	assert.Equal(3, stats.Misses)
+	assert.Equal(1, stats.Evictions)
+	assert.Equal(2, stats.Len)
+	assert.Equal(2, stats.Capacity)
+
+	tmc.Reset()
+	assert.Zero(tmc.Stats().Len)
+	assert.Zero(tmc.Stats().Hits)
+}
+
+func TestTextMeasureCacheDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	tmc := NewTextMeasureCache(10)
+	tmc.Put(TextMeasureGlyphBounds, nil, 10, DefaultDPI, "foo", Box{Right: 10})
+	tmc.SetEnabled(false)
+	assert.False(tmc.Enabled())
+	assert.Zero(tmc.Stats().Len)
+
+	tmc.Put(TextMeasureGlyphBounds, nil, 10, DefaultDPI, "foo", Box{Right: 10})
+	_, isCached := tmc.Get(TextMeasureGlyphBounds, nil, 10, DefaultDPI, "foo")
+	assert.False(isCached)
+	assert.Zero(tmc.Stats().Len)
+}
+
+func TestRasterRendererMeasureTextCached(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+	r.SetFont(f)
+	r.SetFontSize(13.0)
+
+	uncached := r.MeasureText("cached text measurement")
+	before := DefaultTextMeasureCache.Stats()
+	measured := r.MeasureText("cached text measurement")
+	after := DefaultTextMeasureCache.Stats()
+
+	// the renderer remembers its own measurements without asking the shared cache.
+	assert.True(uncached.Equals(measured))
+	assert.Equal(before.Hits, after.Hits)
+	assert.Equal(before.Misses, after.Misses)
+
+	other, err := PNG(100, 100)
+	assert.Nil(err)
+	other.SetFont(f)
+	other.SetFontSize(13.0)
+	cached := other.MeasureText("cached text measurement")
+	assert.True(uncached.Equals(cached))
+	assert.Equal(after.Hits+1, DefaultTextMeasureCache.Stats().Hits)
+}
+
+func TestTextMeasureCacheMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	measure := func(rp RendererProvider) Box {
+		r, err := rp(100, 100)
+		assert.Nil(err)
+		r.SetFont(f)
+		r.SetFontSize(12.0)
+		return r.MeasureText("Hello World")
+	}
+
+	DefaultTextMeasureCache.Reset()
+	vector := measure(SVG)
+	raster := measure(PNG)
+	// the raster renderer measures glyph bounds, and mustn't share measurements with the vector renderer.
+	assert.False(vector.Equals(raster))
+	assert.True(vector.Equals(measure(SVG)))
+	assert.True(raster.Equals(measure(PNG)))
+}
@@ -58,3 +58,24 @@ func TestTextWrapRune(t *testing.T) {
 	assert.Equal("this is a t", output[0])
 	assert.Equal("est string", output[1])
 }
+
+func TestTextEllipsize(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	basicTextStyle := Style{Font: f, FontSize: 24}
+
+	assert.Equal("foo", Text.Ellipsize(r, "foo", 100, basicTextStyle))
+
+	shortened := Text.Ellipsize(r, "this is a test string", 100, basicTextStyle)
+	assert.NotEqual("this is a test string", shortened)
+	assert.Equal("…", shortened[len(shortened)-len("…"):])
+	basicTextStyle.WriteTextOptionsToRenderer(r)
+	assert.True(r.MeasureText(shortened).Width() <= 100)
+
+	assert.Equal("", Text.Ellipsize(r, "this is a test string", 2, basicTextStyle))
+}
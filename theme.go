@@ -0,0 +1,149 @@
+package chart
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// Theme is a named set of default chart colors. It is a `ColorPalette` with a grid line color;
+// colors it leaves unset are those of `DefaultColorPalette`.
+type Theme struct {
+	Name string
+
+	Background       drawing.Color
+	BackgroundStroke drawing.Color
+	Canvas           drawing.Color
+	CanvasStroke     drawing.Color
+	Axis             drawing.Color
+	Grid             drawing.Color
+	Text             drawing.Color
+
+	// SeriesColors are the series colors, by series index; the index wraps around.
+	SeriesColors []drawing.Color
+}
+
+// IsZero returns if the theme has no colors set.
+func (t Theme) IsZero() bool {
+	return t.Background.IsZero() && t.BackgroundStroke.IsZero() && t.Canvas.IsZero() && t.CanvasStroke.IsZero() &&
+		t.Axis.IsZero() && t.Grid.IsZero() && t.Text.IsZero() && len(t.SeriesColors) == 0
+}
+
+// BackgroundColor returns the background color.
+func (t Theme) BackgroundColor() drawing.Color {
+	return themeColor(t.Background, DefaultBackgroundColor)
+}
+
+// BackgroundStrokeColor returns the background stroke color.
+func (t Theme) BackgroundStrokeColor() drawing.Color {
+	return themeColor(t.BackgroundStroke, DefaultBackgroundStrokeColor)
+}
+
+// CanvasColor returns the canvas color.
+func (t Theme) CanvasColor() drawing.Color {
+	return themeColor(t.Canvas, DefaultCanvasColor)
+}
+
+// CanvasStrokeColor returns the canvas stroke color.
+func (t Theme) CanvasStrokeColor() drawing.Color {
+	return themeColor(t.CanvasStroke, DefaultCanvasStrokeColor)
+}
+
+// AxisStrokeColor returns the axis color.
+func (t Theme) AxisStrokeColor() drawing.Color {
+	return themeColor(t.Axis, DefaultAxisColor)
+}
+
+// GridColor returns the grid line color.
+func (t Theme) GridColor() drawing.Color {
+	return themeColor(t.Grid, DefaultStrokeColor)
+}
+
+// TextColor returns the text color.
+func (t Theme) TextColor() drawing.Color {
+	return themeColor(t.Text, DefaultTextColor)
+}
+
+// GetSeriesColor returns the color of a series by index.
+func (t Theme) GetSeriesColor(index int) drawing.Color {
+	if len(t.SeriesColors) == 0 {
+		return GetDefaultColor(index)
+	}
+	return t.SeriesColors[index%len(t.SeriesColors)]
+}
+
+func themeColor(color, defaultColor drawing.Color) drawing.Color {
+	if color.IsZero() {
+		return defaultColor
+	}
+	return color
+}
+
+// gridColorProvider is a `ColorPalette` with a grid line color, i.e. a `Theme`.
+type gridColorProvider interface {
+	GridColor() drawing.Color
+}
+
+var (
+	// LightTheme is dark text and axes on a white background; it has the colors of `DefaultColorPalette`.
+	LightTheme = Theme{
+		Name:         "light",
+		Background:   DefaultBackgroundColor,
+		Canvas:       DefaultCanvasColor,
+		Axis:         DefaultAxisColor,
+		Grid:         DefaultStrokeColor,
+		Text:         DefaultTextColor,
+		SeriesColors: DefaultColors,
+	}
+
+	// DarkTheme is light text and axes on a dark gray background; it has the colors of `DarkColorPalette`.
+	DarkTheme = Theme{
+		Name:             "dark",
+		Background:       ColorDarkGray,
+		BackgroundStroke: ColorDarkGray,
+		Canvas:           ColorDarkGray,
+		CanvasStroke:     ColorDarkGray,
+		Axis:             ColorAlternateLightGray,
+		Grid:             drawing.Color{R: 68, G: 68, B: 68, A: 255},
+		Text:             ColorLightGray,
+		SeriesColors:     DefaultAlternateColors,
+	}
+
+	// ColorBlindTheme is `LightTheme` with the `OkabeItoColors` series colors, which stay distinguishable
+	// with the common kinds of color blindness.
+	ColorBlindTheme = Theme{
+		Name:         "colorblind",
+		Background:   DefaultBackgroundColor,
+		Canvas:       DefaultCanvasColor,
+		Axis:         DefaultAxisColor,
+		Grid:         DefaultStrokeColor,
+		Text:         DefaultTextColor,
+		SeriesColors: OkabeItoColors,
+	}
+)
+
+var (
+	_themesLock sync.RWMutex
+	_themes     = map[string]Theme{
+		LightTheme.Name:      LightTheme,
+		DarkTheme.Name:       DarkTheme,
+		ColorBlindTheme.Name: ColorBlindTheme,
+	}
+)
+
+// RegisterTheme registers a theme by its name, replacing a built in theme with the same name.
+func RegisterTheme(t Theme) {
+	_themesLock.Lock()
+	defer _themesLock.Unlock()
+	_themes[strings.ToLower(t.Name)] = t
+}
+
+// GetTheme returns the theme registered with a name; the names are not case sensitive.
+// Built in themes are `light`, `dark` and `colorblind`.
+func GetTheme(name string) (Theme, bool) {
+	_themesLock.RLock()
+	defer _themesLock.RUnlock()
+	t, hasTheme := _themes[strings.ToLower(name)]
+	return t, hasTheme
+}
@@ -0,0 +1,86 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestThemeDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	var theme Theme
+	assert.True(theme.IsZero())
+	assert.Equal(DefaultBackgroundColor, theme.BackgroundColor())
+	assert.Equal(DefaultStrokeColor, theme.GridColor())
+	assert.Equal(DefaultTextColor, theme.TextColor())
+	assert.Equal(GetDefaultColor(2), theme.GetSeriesColor(2))
+
+	theme = Theme{Text: ColorRed, SeriesColors: []drawing.Color{ColorGreen, ColorBlue}}
+	assert.False(theme.IsZero())
+	assert.Equal(ColorRed, theme.TextColor())
+	assert.Equal(DefaultAxisColor, theme.AxisStrokeColor())
+	assert.Equal(ColorBlue, theme.GetSeriesColor(3))
+}
+
+func TestGetTheme(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, name := range []string{"light", "Dark", "colorblind"} {
+		theme, hasTheme := GetTheme(name)
+		assert.True(hasTheme)
+		assert.Equal(strings.ToLower(name), theme.Name)
+	}
+
+	_, hasTheme := GetTheme("solarized")
+	assert.False(hasTheme)
+
+	RegisterTheme(Theme{Name: "Solarized", Background: drawing.ColorFromHex("FDF6E3")})
+	theme, hasTheme := GetTheme("solarized")
+	assert.True(hasTheme)
+	assert.Equal(drawing.ColorFromHex("FDF6E3"), theme.BackgroundColor())
+}
+
+func TestChartTheme(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Theme: DarkTheme,
+		YAxis: YAxis{
+			Style:          StyleShow(),
+			GridMajorStyle: StyleShow(),
+		},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}},
+		},
+	}
+	assert.Equal(DarkTheme.BackgroundColor(), c.GetColorPalette().BackgroundColor())
+
+	buf := bytes.NewBuffer([]byte{})
+	assert.Nil(c.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "fill:"+ColorDarkGray.String()))
+	assert.True(strings.Contains(buf.String(), "stroke:"+DarkTheme.Grid.String()))
+
+	// an explicit palette takes precedence over the theme.
+	c.ColorPalette = AlternateColorPalette
+	assert.Equal(DefaultBackgroundColor, c.GetColorPalette().BackgroundColor())
+}
+
+func TestChartThemeGridStyle(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{Theme: DarkTheme}
+	gridStyle := withGridDefaults(Style{Show: true, StrokeWidth: 2}, c.getGridStyleDefaults())
+	assert.True(gridStyle.Show)
+	assert.Equal(2.0, gridStyle.StrokeWidth)
+	assert.Equal(DarkTheme.Grid, gridStyle.StrokeColor)
+
+	gridStyle = withGridDefaults(Style{StrokeColor: ColorRed}, c.getGridStyleDefaults())
+	assert.False(gridStyle.Show)
+	assert.Equal(ColorRed, gridStyle.StrokeColor)
+
+	assert.True(Chart{}.getGridStyleDefaults().IsZero())
+}
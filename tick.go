@@ -13,6 +13,11 @@ type TicksProvider interface {
 	GetTicks(r Renderer, defaults Style, vf ValueFormatter) []Tick
 }
 
+// axisTicksProvider is a range that provides ticks that depend on the orientation of its axis.
+type axisTicksProvider interface {
+	getAxisTicks(r Renderer, isVertical bool, defaults Style, vf ValueFormatter) []Tick
+}
+
 // Tick represents a label on an axis.
 type Tick struct {
 	Value float64
@@ -47,13 +52,35 @@ func (t Ticks) String() string {
 }
 
 // GenerateContinuousTicks generates a set of ticks.
+// Degenerate ranges get the ticks that can be placed sensibly: a range with an infinite or NaN bound gets a tick
+// for each finite bound, a zero width range a single tick. An inverted range (min > max) is treated as if its
+// bounds were swapped.
 func GenerateContinuousTicks(r Renderer, ra Range, isVertical bool, style Style, vf ValueFormatter) []Tick {
 	if vf == nil {
 		vf = FloatValueFormatter
 	}
 
-	var ticks []Tick
 	min, max := ra.GetMin(), ra.GetMax()
+	if ticks, isDegenerate := degenerateTicks(min, max, ra.IsDescending(), vf); isDegenerate {
+		return ticks
+	}
+	if min > max {
+		min, max = max, min
+	}
+
+	var ticks []Tick
+
+	// the default float format can't tell apart ticks on very small ranges, and prints noise digits
+	// for very large magnitudes; pick a precision that fits the range instead.
+	if isDefaultFloatValueFormatter(vf) {
+		approximateStep := math.Abs(max-min) / DefaultTickCount
+		magnitude := math.Max(math.Abs(min), math.Abs(max))
+		if vf(min) == vf(min+approximateStep) || (magnitude > 0 && math.Log10(magnitude) >= DefaultFixedNotationMaxExponent) {
+			vf = FloatValueFormatterForRange(min, max, approximateStep)
+		}
+	} else if isDefaultGroupedValueFormatter(vf) {
+		vf = DefaultNumberFormat.ForRange(min, max, math.Abs(max-min)/DefaultTickCount)
+	}
 
 	if ra.IsDescending() {
 		ticks = append(ticks, Tick{
@@ -80,7 +107,10 @@ func GenerateContinuousTicks(r Renderer, ra Range, isVertical bool, style Style,
 
 	domain := float64(ra.GetDomain())
 	domainRemainder := domain - (tickSize * 2)
-	intermediateTickCount := int(math.Floor(float64(domainRemainder) / float64(tickSize)))
+	intermediateTickCount := 0
+	if domainRemainder > 0 {
+		intermediateTickCount = int(math.Floor(domainRemainder / tickSize))
+	}
 
 	rangeDelta := math.Abs(max - min)
 	tickStep := rangeDelta / float64(intermediateTickCount)
@@ -95,9 +125,18 @@ func GenerateContinuousTicks(r Renderer, ra Range, isVertical bool, style Style,
 		} else {
 			tickValue = min + util.Math.RoundUp(tickStep*float64(x), roundTo)
 		}
+		// rounding can push the last steps onto or past the final tick.
+		if !isBetweenTicks(tickValue, ticks[len(ticks)-1].Value, finalTickValue(ra), ra.IsDescending()) {
+			continue
+		}
+		label := vf(tickValue)
+		// skip ticks that would print the same label as their neighbors.
+		if label == ticks[len(ticks)-1].Label || (x == intermediateTickCount-1 && label == vf(finalTickValue(ra))) {
+			continue
+		}
 		ticks = append(ticks, Tick{
 			Value: tickValue,
-			Label: vf(tickValue),
+			Label: label,
 		})
 	}
 
@@ -115,3 +154,119 @@ func GenerateContinuousTicks(r Renderer, ra Range, isVertical bool, style Style,
 
 	return ticks
 }
+
+func finalTickValue(ra Range) float64 {
+	if ra.IsDescending() {
+		return math.Min(ra.GetMin(), ra.GetMax())
+	}
+	return math.Max(ra.GetMin(), ra.GetMax())
+}
+
+// isBetweenTicks returns if a value lies strictly between the previous and the final tick.
+func isBetweenTicks(value, previous, final float64, descending bool) bool {
+	if descending {
+		return value < previous && value > final
+	}
+	return value > previous && value < final
+}
+
+// degenerateTicks returns the ticks for a range that can't be subdivided, i.e. one with an infinite or NaN bound
+// or a zero width, and if the range is degenerate.
+func degenerateTicks(min, max float64, descending bool, vf ValueFormatter) ([]Tick, bool) {
+	minFinite := !math.IsNaN(min) && !math.IsInf(min, 0)
+	maxFinite := !math.IsNaN(max) && !math.IsInf(max, 0)
+	if minFinite && maxFinite && min != max && !math.IsInf(max-min, 0) {
+		return nil, false
+	}
+
+	var ticks []Tick
+	if minFinite {
+		ticks = append(ticks, Tick{Value: min, Label: vf(min)})
+	}
+	if maxFinite && (!minFinite || max != min) {
+		ticks = append(ticks, Tick{Value: max, Label: vf(max)})
+	}
+	if len(ticks) == 2 && (ticks[0].Value > ticks[1].Value) != descending {
+		ticks[0], ticks[1] = ticks[1], ticks[0]
+	}
+	return ticks, true
+}
+
+// thinTicks keeps every Nth tick, starting with the first, for the smallest N at which the labels
+// centered under the ticks are at least `DefaultMinimumTickHorizontalSpacing` / 2 apart.
+func thinTicks(r Renderer, ra Range, style Style, ticks []Tick) []Tick {
+	if len(ticks) < 3 {
+		return ticks
+	}
+	labels := measureTickLabels(r, ra, style, ticks)
+
+	every := 1
+	for every < len(ticks)-1 && !labels.fit(every) {
+		every++
+	}
+	if every == 1 {
+		return ticks
+	}
+	thinned := make([]Tick, 0, len(ticks)/every+1)
+	for index := 0; index < len(ticks); index += every {
+		thinned = append(thinned, ticks[index])
+	}
+	return thinned
+}
+
+// ellipsizeTicks returns ticks with the labels that are wider than the space they have shortened to fit,
+// ending in an ellipsis. Labels under their tick have the distance to the nearest other tick, and labels
+// between ticks the distance between them, less half of `DefaultMinimumTickHorizontalSpacing`.
+func ellipsizeTicks(r Renderer, ra Range, style Style, ticks []Tick, betweenTicks bool) []Tick {
+	if len(ticks) < 2 {
+		return ticks
+	}
+	positions := make([]int, len(ticks))
+	for index, t := range ticks {
+		positions[index] = ra.Translate(t.Value)
+	}
+
+	output := make([]Tick, len(ticks))
+	for index, t := range ticks {
+		output[index] = t
+		available := math.MaxInt32
+		if index > 0 {
+			available = util.Math.AbsInt(positions[index] - positions[index-1])
+		} else if betweenTicks {
+			continue
+		}
+		if index < len(ticks)-1 && !betweenTicks {
+			available = util.Math.MinInt(available, util.Math.AbsInt(positions[index+1]-positions[index]))
+		}
+		output[index].Label = Text.Ellipsize(r, t.Label, available-DefaultMinimumTickHorizontalSpacing>>1, style)
+	}
+	return output
+}
+
+// tickLabels are the positions and widths of tick labels centered under their ticks.
+type tickLabels struct {
+	positions []int
+	widths    []int
+}
+
+func measureTickLabels(r Renderer, ra Range, style Style, ticks []Tick) tickLabels {
+	labels := tickLabels{positions: make([]int, len(ticks)), widths: make([]int, len(ticks))}
+	for index, t := range ticks {
+		labels.positions[index] = ra.Translate(t.Value)
+		labels.widths[index] = Draw.MeasureText(r, t.Label, style).Width()
+	}
+	return labels
+}
+
+// fit returns if every Nth label, starting with the first, is at least `DefaultMinimumTickHorizontalSpacing` / 2
+// from the next.
+func (tl tickLabels) fit(every int) bool {
+	for index := every; index < len(tl.positions); index += every {
+		previous := index - every
+		gap := util.Math.AbsInt(tl.positions[index]-tl.positions[previous]) - (tl.widths[index]+tl.widths[previous])>>1
+		if gap < DefaultMinimumTickHorizontalSpacing>>1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package chart
+
+import (
+	"math"
+	"time"
+)
+
+// AxisKind identifies the axis a tick belongs to.
+type AxisKind int
+
+const (
+	// AxisKindX is the x axis.
+	AxisKindX AxisKind = iota
+	// AxisKindY is the primary y axis.
+	AxisKindY
+	// AxisKindYSecondary is the secondary y axis.
+	AxisKindYSecondary
+)
+
+// TickContext is the context a tick label is formatted in.
+type TickContext struct {
+	Axis AxisKind
+	// Index is the index of the tick in `Ticks`.
+	Index int
+	// Ticks is the full tick set, labeled by the axis value formatter.
+	Ticks []Tick
+	Range Range
+	// Span is the absolute difference between the range min and max.
+	Span float64
+}
+
+// IsFirst returns if the tick is the first tick.
+func (tc TickContext) IsFirst() bool {
+	return tc.Index == 0
+}
+
+// IsLast returns if the tick is the last tick.
+func (tc TickContext) IsLast() bool {
+	return tc.Index == len(tc.Ticks)-1
+}
+
+// Previous returns the tick before the tick being formatted and if there is one.
+func (tc TickContext) Previous() (Tick, bool) {
+	if tc.Index == 0 || tc.Index > len(tc.Ticks) {
+		return Tick{}, false
+	}
+	return tc.Ticks[tc.Index-1], true
+}
+
+// TickFormatter formats tick labels with the context of the whole tick set, i.e. to only print
+// a unit on the last tick. It is applied to generated ticks; ticks set on an axis keep their labels.
+type TickFormatter interface {
+	FormatTick(value float64, tc TickContext) string
+}
+
+// TickFormatterFunc is a func that implements TickFormatter.
+type TickFormatterFunc func(value float64, tc TickContext) string
+
+// FormatTick implements TickFormatter.
+func (tff TickFormatterFunc) FormatTick(value float64, tc TickContext) string {
+	return tff(value, tc)
+}
+
+// LastTickUnitFormatter returns a tick formatter that labels ticks with a value formatter and appends
+// a unit to the last tick only.
+func LastTickUnitFormatter(vf ValueFormatter, unit string) TickFormatter {
+	return TickFormatterFunc(func(value float64, tc TickContext) string {
+		if tc.IsLast() {
+			return vf(value) + unit
+		}
+		return vf(value)
+	})
+}
+
+// TimeTickFormatter returns a tick formatter for timestamp ticks that prints the date (in `dateFormat`)
+// only on the first tick and on ticks whose date differs from the previous tick, and the time (in `timeFormat`) on every tick.
+func TimeTickFormatter(dateFormat, timeFormat string) TickFormatter {
+	return TickFormatterFunc(func(value float64, tc TickContext) string {
+		t := time.Unix(0, int64(value))
+		if previous, hasPrevious := tc.Previous(); hasPrevious {
+			if time.Unix(0, int64(previous.Value)).Format(dateFormat) == t.Format(dateFormat) {
+				return t.Format(timeFormat)
+			}
+		}
+		return t.Format(dateFormat) + " " + t.Format(timeFormat)
+	})
+}
+
+// formatTicks relabels ticks with a tick formatter.
+func formatTicks(ticks []Tick, tf TickFormatter, axis AxisKind, ra Range) []Tick {
+	if tf == nil || len(ticks) == 0 {
+		return ticks
+	}
+	tc := TickContext{
+		Axis:  axis,
+		Ticks: ticks,
+		Range: ra,
+		Span:  math.Abs(ra.GetMax() - ra.GetMin()),
+	}
+	formatted := make([]Tick, len(ticks))
+	for index, t := range ticks {
+		tc.Index = index
+		formatted[index] = Tick{Value: t.Value, Label: tf.FormatTick(t.Value, tc)}
+	}
+	return formatted
+}
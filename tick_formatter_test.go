@@ -0,0 +1,83 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestFormatTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	ticks := []Tick{{Value: 0, Label: "0"}, {Value: 5, Label: "5"}, {Value: 10, Label: "10"}}
+	ra := &ContinuousRange{Min: 0, Max: 10}
+
+	var contexts []TickContext
+	formatted := formatTicks(ticks, TickFormatterFunc(func(value float64, tc TickContext) string {
+		contexts = append(contexts, tc)
+		return tc.Ticks[tc.Index].Label + "!"
+	}), AxisKindY, ra)
+	assert.Equal([]Tick{{Value: 0, Label: "0!"}, {Value: 5, Label: "5!"}, {Value: 10, Label: "10!"}}, formatted)
+	assert.Equal("0", ticks[0].Label)
+	assert.Len(contexts, 3)
+	assert.True(contexts[0].IsFirst())
+	assert.True(contexts[2].IsLast())
+	assert.Equal(AxisKindY, contexts[1].Axis)
+	assert.Equal(10.0, contexts[1].Span)
+
+	assert.Equal(ticks, formatTicks(ticks, nil, AxisKindX, ra))
+}
+
+func TestLastTickUnitFormatter(t *testing.T) {
+	assert := assert.New(t)
+
+	ticks := []Tick{{Value: 0}, {Value: 5}, {Value: 10}}
+	formatted := formatTicks(ticks, LastTickUnitFormatter(FloatValueFormatter, " ms"), AxisKindY, &ContinuousRange{Min: 0, Max: 10})
+	assert.Equal("0.00", formatted[0].Label)
+	assert.Equal("5.00", formatted[1].Label)
+	assert.Equal("10.00 ms", formatted[2].Label)
+}
+
+func TestTimeTickFormatter(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2024, 3, 1, 22, 0, 0, 0, time.Local)
+	var ticks []Tick
+	for hour := 0; hour < 4; hour++ {
+		ticks = append(ticks, Tick{Value: float64(start.Add(time.Duration(hour) * time.Hour).UnixNano())})
+	}
+	formatted := formatTicks(ticks, TimeTickFormatter("2006-01-02", "15:04"), AxisKindX, &ContinuousRange{Min: ticks[0].Value, Max: ticks[3].Value})
+	assert.Equal("2024-03-01 22:00", formatted[0].Label)
+	assert.Equal("23:00", formatted[1].Label)
+	assert.Equal("2024-03-02 00:00", formatted[2].Label)
+	assert.Equal("01:00", formatted[3].Label)
+}
+
+func TestAxisTickFormatter(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(400, 200)
+	assert.Nil(err)
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	style := Style{Font: f, FontSize: 10}
+	ra := &ContinuousRange{Min: 0, Max: 10, Domain: 300}
+
+	xa := XAxis{TickFormatter: LastTickUnitFormatter(FloatValueFormatter, "s")}
+	ticks := xa.GetTicks(r, ra, style, FloatValueFormatter)
+	assert.Equal("10.00s", ticks[len(ticks)-1].Label)
+
+	// explicit ticks keep their labels.
+	xa.Ticks = []Tick{{Value: 0, Label: "zero"}, {Value: 10, Label: "ten"}}
+	assert.Equal(xa.Ticks, xa.GetTicks(r, ra, style, FloatValueFormatter))
+
+	ya := YAxis{AxisType: YAxisSecondary, TickFormatter: TickFormatterFunc(func(value float64, tc TickContext) string {
+		if tc.Axis == AxisKindYSecondary {
+			return "secondary"
+		}
+		return "primary"
+	})}
+	ticks = ya.GetTicks(r, ra, style, FloatValueFormatter)
+	assert.Equal("secondary", ticks[0].Label)
+}
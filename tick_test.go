@@ -1,6 +1,7 @@
 package chart
 
 import (
+	"math"
 	"testing"
 
 	assert "github.com/blendlabs/go-assert"
@@ -58,3 +59,126 @@ func TestGenerateContinuousTicksDescending(t *testing.T) {
 	assert.Equal(1.0, ticks[len(ticks)-2].Value)
 	assert.Equal(0.0, ticks[len(ticks)-1].Value)
 }
+
+func TestGenerateContinuousTicksExtremeMagnitudes(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+
+	for _, ra := range []*ContinuousRange{
+		{Min: 1e-9, Max: 2e-9, Domain: 1024},
+		{Min: 1e15, Max: 1.000001e15, Domain: 1024},
+		{Min: -1e-12, Max: 1e-12, Domain: 512},
+	} {
+		ticks := GenerateContinuousTicks(r, ra, false, Style{Font: f, FontSize: 10}, FloatValueFormatter)
+		assert.True(len(ticks) > 2, ra.String())
+
+		labels := map[string]bool{}
+		for index, tick := range ticks {
+			assert.False(labels[tick.Label], tick.Label)
+			labels[tick.Label] = true
+			if index > 0 {
+				assert.True(ra.Translate(tick.Value) > ra.Translate(ticks[index-1].Value))
+			}
+		}
+	}
+}
+
+func TestGenerateContinuousTicksExtremeMagnitudeLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+
+	ra := &ContinuousRange{Min: 1e-9, Max: 2e-9, Domain: 1024}
+	ticks := GenerateContinuousTicks(r, ra, false, Style{Font: f, FontSize: 10}, FloatValueFormatter)
+	assert.Equal("1e-09", ticks[0].Label)
+	assert.Equal("2e-09", ticks[len(ticks)-1].Label)
+
+	ra = &ContinuousRange{Min: 1e15, Max: 1.000001e15, Domain: 1024}
+	ticks = GenerateContinuousTicks(r, ra, false, Style{Font: f, FontSize: 10}, FloatValueFormatter)
+	assert.Equal("1e+15", ticks[0].Label)
+	assert.Equal("1.000001e+15", ticks[len(ticks)-1].Label)
+}
+
+func TestGenerateContinuousTicksDegenerateRanges(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+	style := Style{Font: f, FontSize: 10}
+
+	ticks := GenerateContinuousTicks(r, &ContinuousRange{Min: 5, Max: 5, Domain: 256}, false, style, FloatValueFormatter)
+	assert.Equal([]Tick{{Value: 5, Label: "5.00"}}, ticks)
+
+	ticks = GenerateContinuousTicks(r, &ContinuousRange{Min: math.NaN(), Max: 3, Domain: 256}, false, style, FloatValueFormatter)
+	assert.Equal([]Tick{{Value: 3, Label: "3.00"}}, ticks)
+
+	ticks = GenerateContinuousTicks(r, &ContinuousRange{Min: math.Inf(-1), Max: math.Inf(1), Domain: 256}, false, style, FloatValueFormatter)
+	assert.Empty(ticks)
+
+	ticks = GenerateContinuousTicks(r, &ContinuousRange{Min: -math.MaxFloat64, Max: math.MaxFloat64, Domain: 256}, false, style, FloatValueFormatter)
+	assert.Len(ticks, 2)
+
+	ticks = GenerateContinuousTicks(r, &ContinuousRange{Min: 10, Max: 0, Domain: 256}, false, style, FloatValueFormatter)
+	assert.Equal(GenerateContinuousTicks(r, &ContinuousRange{Min: 0, Max: 10, Domain: 256}, false, style, FloatValueFormatter), ticks)
+
+	ticks = GenerateContinuousTicks(r, &ContinuousRange{Min: 0, Max: 10, Domain: -50}, true, style, FloatValueFormatter)
+	assert.Len(ticks, 2)
+}
+
+func FuzzGenerateContinuousTicks(f *testing.F) {
+	font, err := GetDefaultFont()
+	if err != nil {
+		f.Fatal(err)
+	}
+	r, err := PNG(64, 64)
+	if err != nil {
+		f.Fatal(err)
+	}
+	style := Style{Font: font, FontSize: 10}
+
+	f.Add(0.0, 10.0, 256, false, false)
+	f.Add(5.0, 5.0, 256, false, true)
+	f.Add(10.0, -10.0, 512, true, false)
+	f.Add(1e-300, 2e-300, 1024, false, true)
+	f.Add(-math.MaxFloat64, math.MaxFloat64, 1024, true, true)
+	f.Add(math.NaN(), 1.0, 100, false, false)
+	f.Add(math.Inf(-1), 0.0, 100, false, false)
+	f.Add(1e15, 1.000000000001e15, 4096, false, true)
+	f.Add(0.0, 1.0, 0, false, false)
+	f.Add(0.0, 1.0, 1<<20, true, true)
+
+	f.Fuzz(func(t *testing.T, min, max float64, domain int, descending, vertical bool) {
+		ra := &ContinuousRange{Min: min, Max: max, Domain: domain, Descending: descending}
+		ticks := GenerateContinuousTicks(r, ra, vertical, style, FloatValueFormatter)
+		if len(ticks) > DefaultTickCountSanityCheck+2 {
+			t.Fatalf("%v: too many ticks: %d", ra, len(ticks))
+		}
+		for index, tick := range ticks {
+			if math.IsNaN(tick.Value) || math.IsInf(tick.Value, 0) {
+				t.Fatalf("%v: non-finite tick %d: %v", ra, index, tick.Value)
+			}
+			if index == 0 {
+				continue
+			}
+			previous := ticks[index-1].Value
+			if descending && tick.Value >= previous {
+				t.Fatalf("%v: tick %d (%v) does not descend from %v", ra, index, tick.Value, previous)
+			}
+			if !descending && tick.Value <= previous {
+				t.Fatalf("%v: tick %d (%v) does not ascend from %v", ra, index, tick.Value, previous)
+			}
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package chart
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// TimeRange is a range of timestamps, as produced by `TimeSeries`, whose ticks land on calendar boundaries
+// (minutes, hours, midnights, month and year starts) in a location.
+//
+// Values are translated linearly in absolute time, so a day with a daylight saving transition takes 23 or 25 hours
+// of width; boundaries are computed with calendar arithmetic in the location rather than by adding fixed durations,
+// so midnight ticks stay on local midnight across transitions. Like `time.Time`, it does not account for leap seconds.
+//
+// Charts use a `TimeRange` for the x axis by default if any of their series is a `TimeSeries`.
+type TimeRange struct {
+	ContinuousRange
+
+	// Location is the location calendar boundaries are computed in; it defaults to `time.Local`.
+	Location *time.Location
+
+	// Language is a language tag, i.e. `de` or `en-GB`, whose `Locale` labels the ticks with localized
+	// month names and date ordering. Ticks use the default formats if it is empty or has no locale.
+	Language string
+}
+
+// GetLocation returns the location or the default.
+func (tr TimeRange) GetLocation() *time.Location {
+	if tr.Location == nil {
+		return time.Local
+	}
+	return tr.Location
+}
+
+// fit pads the bounds, with absolute padding in nanoseconds; time ranges round to calendar boundaries
+// by their ticks rather than to nice numbers.
+func (tr *TimeRange) fit() {
+	tr.Min, tr.Max = tr.Padding.pad(tr.Min, tr.Max)
+}
+
+// getNiceNumbers returns false; time ranges aren't rounded to nice numbers.
+func (tr TimeRange) getNiceNumbers() bool {
+	return false
+}
+
+// String returns a simple string for the range.
+func (tr TimeRange) String() string {
+	return fmt.Sprintf("TimeRange [%s,%s] => %d", tr.timeAt(tr.Min).Format(time.RFC3339), tr.timeAt(tr.Max).Format(time.RFC3339), tr.Domain)
+}
+
+// GetTicks returns ticks on the finest calendar boundary whose labels fit the domain.
+// It implements `TicksProvider`.
+func (tr *TimeRange) GetTicks(r Renderer, defaults Style, vf ValueFormatter) []Tick {
+	min, max := tr.GetMin(), tr.GetMax()
+	if max < min {
+		min, max = max, min
+	}
+	delta := time.Duration(max - min)
+	if delta <= 0 {
+		return GenerateContinuousTicks(r, tr, false, defaults, tr.inLocation(vf))
+	}
+
+	defaults.GetTextOptions().WriteToRenderer(r)
+	for _, step := range timeSteps {
+		// skip steps that would produce more ticks than could ever fit.
+		if estimate := int(delta / step.approximate); estimate > DefaultTickCountSanityCheck || estimate*DefaultMinimumTickHorizontalSpacing > tr.Domain {
+			continue
+		}
+		times := tr.boundaries(step, min, max)
+		if len(times) < 2 {
+			continue
+		}
+		stepFormatter := tr.formatterFor(step, vf)
+		if tr.measureTimes(r, stepFormatter, times) <= tr.Domain {
+			return tr.makeTicks(stepFormatter, times)
+		}
+	}
+	return GenerateContinuousTicks(r, tr, false, defaults, tr.inLocation(vf))
+}
+
+// inLocation returns the default time formatter as one that formats timestamps in the range's location,
+// rather than in `time.Local`; other formatters are returned as they are.
+func (tr TimeRange) inLocation(vf ValueFormatter) ValueFormatter {
+	if !isDefaultTimeValueFormatter(vf) {
+		return vf
+	}
+	return func(v interface{}) string {
+		if typed, isTyped := v.(float64); isTyped {
+			return TimeValueFormatter(tr.timeAt(typed))
+		}
+		return TimeValueFormatter(v)
+	}
+}
+
+// isDefaultTimeValueFormatter returns if a formatter is unset or `TimeValueFormatter`.
+func isDefaultTimeValueFormatter(vf ValueFormatter) bool {
+	return vf == nil || reflect.ValueOf(vf).Pointer() == reflect.ValueOf(TimeValueFormatter).Pointer()
+}
+
+func (tr TimeRange) timeAt(value float64) time.Time {
+	return util.Time.FromFloat64(value).In(tr.GetLocation())
+}
+
+// boundaries returns the calendar boundaries of a step within [min, max].
+func (tr TimeRange) boundaries(step timeStep, min, max float64) []time.Time {
+	var times []time.Time
+	start, end := tr.timeAt(min), tr.timeAt(max)
+	for t := step.floor(start); !t.After(end); {
+		if !t.Before(start) {
+			times = append(times, t)
+		}
+		next := step.next(t)
+		if !next.After(t) {
+			break
+		}
+		t = next
+	}
+	return times
+}
+
+// formatterFor returns the value formatter for ticks on a step; the default time formatter is swapped for one
+// that includes the time of day for steps shorter than a day, or for the locale's format for the step.
+func (tr TimeRange) formatterFor(step timeStep, vf ValueFormatter) ValueFormatter {
+	if !isDefaultTimeValueFormatter(vf) {
+		return vf
+	}
+	if locale, hasLocale := GetLocale(tr.Language); hasLocale {
+		switch step.unit {
+		case timeUnitMinute:
+			return locale.TimeValueFormatter(locale.DateMinuteFormat)
+		case timeUnitHour:
+			return locale.TimeValueFormatter(locale.DateHourFormat)
+		case timeUnitDay:
+			return locale.TimeValueFormatter(locale.DateFormat)
+		case timeUnitMonth:
+			return locale.TimeValueFormatter(locale.MonthFormat)
+		default:
+			return locale.TimeValueFormatter(locale.YearFormat)
+		}
+	}
+	switch step.unit {
+	case timeUnitMinute:
+		return TimeValueFormatterWithFormat(DefaultDateMinuteFormat)
+	case timeUnitHour:
+		return TimeValueFormatterWithFormat(DefaultDateHourFormat)
+	}
+	return TimeValueFormatter
+}
+
+func (tr TimeRange) measureTimes(r Renderer, vf ValueFormatter, times []time.Time) int {
+	var total int
+	for index, t := range times {
+		total += r.MeasureText(vf(t)).Width()
+		if index > 0 {
+			total += DefaultMinimumTickHorizontalSpacing
+		}
+	}
+	return total
+}
+
+func (tr TimeRange) makeTicks(vf ValueFormatter, times []time.Time) []Tick {
+	ticks := make([]Tick, len(times))
+	for index, t := range times {
+		ticks[index] = Tick{Value: util.Time.ToFloat64(t), Label: vf(t)}
+	}
+	if tr.IsDescending() {
+		for i, j := 0, len(ticks)-1; i < j; i, j = i+1, j-1 {
+			ticks[i], ticks[j] = ticks[j], ticks[i]
+		}
+	}
+	return ticks
+}
+
+type timeUnit int
+
+const (
+	timeUnitMinute timeUnit = iota
+	timeUnitHour
+	timeUnitDay
+	timeUnitMonth
+	timeUnitYear
+)
+
+// timeStep is a calendar step, i.e. every 6 hours or every 3 months.
+type timeStep struct {
+	unit        timeUnit
+	count       int
+	approximate time.Duration
+}
+
+// timeSteps are the candidate tick steps, finest first.
+var timeSteps = []timeStep{
+	{timeUnitMinute, 1, time.Minute},
+	{timeUnitMinute, 5, 5 * time.Minute},
+	{timeUnitMinute, 15, 15 * time.Minute},
+	{timeUnitMinute, 30, 30 * time.Minute},
+	{timeUnitHour, 1, time.Hour},
+	{timeUnitHour, 3, 3 * time.Hour},
+	{timeUnitHour, 6, 6 * time.Hour},
+	{timeUnitHour, 12, 12 * time.Hour},
+	{timeUnitDay, 1, 24 * time.Hour},
+	{timeUnitDay, 2, 2 * 24 * time.Hour},
+	{timeUnitDay, 7, 7 * 24 * time.Hour},
+	{timeUnitMonth, 1, 30 * 24 * time.Hour},
+	{timeUnitMonth, 3, 91 * 24 * time.Hour},
+	{timeUnitMonth, 6, 182 * 24 * time.Hour},
+	{timeUnitYear, 1, 365 * 24 * time.Hour},
+	{timeUnitYear, 5, 5 * 365 * 24 * time.Hour},
+	{timeUnitYear, 10, 10 * 365 * 24 * time.Hour},
+	{timeUnitYear, 50, 50 * 365 * 24 * time.Hour},
+	{timeUnitYear, 100, 100 * 365 * 24 * time.Hour},
+}
+
+// floor returns the last boundary of the step at or before t, in t's location.
+func (ts timeStep) floor(t time.Time) time.Time {
+	year, month, day := t.Date()
+	loc := t.Location()
+	switch ts.unit {
+	case timeUnitMinute:
+		return time.Date(year, month, day, t.Hour(), (t.Minute()/ts.count)*ts.count, 0, 0, loc)
+	case timeUnitHour:
+		return time.Date(year, month, day, (t.Hour()/ts.count)*ts.count, 0, 0, 0, loc)
+	case timeUnitDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	case timeUnitMonth:
+		return time.Date(year, time.Month(((int(month)-1)/ts.count)*ts.count+1), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date((year/ts.count)*ts.count, time.January, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+// next returns the boundary after t. Hour steps are counted on the local clock, so 6 hour ticks
+// stay on 00:00, 06:00, 12:00 and 18:00 across daylight saving transitions.
+func (ts timeStep) next(t time.Time) time.Time {
+	year, month, day := t.Date()
+	loc := t.Location()
+	switch ts.unit {
+	case timeUnitMinute:
+		return t.Add(time.Duration(ts.count) * time.Minute)
+	case timeUnitHour:
+		next := time.Date(year, month, day, t.Hour()+ts.count, 0, 0, 0, loc)
+		if !next.After(t) {
+			next = t.Add(time.Duration(ts.count) * time.Hour)
+		}
+		return next
+	case timeUnitDay:
+		return time.Date(year, month, day+ts.count, 0, 0, 0, 0, loc)
+	case timeUnitMonth:
+		return time.Date(year, month+time.Month(ts.count), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(year+ts.count, time.January, 1, 0, 0, 0, 0, loc)
+	}
+}
@@ -0,0 +1,170 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+func timeRangeTestRenderer(t *testing.T) (Renderer, Style) {
+	r, err := PNG(1024, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := GetDefaultFont()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, Style{Font: f, FontSize: DefaultAxisFontSize}
+}
+
+func TestTimeRangeDayTicksAcrossDST(t *testing.T) {
+	assert := assert.New(t)
+
+	eastern := util.Date.Eastern()
+	// the week of the 2024 spring forward, which has a 23 hour day.
+	min := time.Date(2024, 3, 7, 9, 30, 0, 0, eastern)
+	max := time.Date(2024, 3, 13, 16, 0, 0, 0, eastern)
+	tr := &TimeRange{Location: eastern}
+	tr.SetMin(util.Time.ToFloat64(min))
+	tr.SetMax(util.Time.ToFloat64(max))
+	tr.SetDomain(600)
+
+	r, style := timeRangeTestRenderer(t)
+	ticks := tr.GetTicks(r, style, TimeValueFormatter)
+	assert.Len(ticks, 6)
+	for _, tick := range ticks {
+		local := util.Time.FromFloat64(tick.Value).In(eastern)
+		assert.Equal(0, local.Hour(), local.String())
+		assert.Equal(0, local.Minute(), local.String())
+		assert.Equal(local.Format(DefaultDateFormat), tick.Label)
+	}
+	assert.Equal("2024-03-08", ticks[0].Label)
+	assert.Equal("2024-03-13", ticks[5].Label)
+
+	// the short day is narrower than its neighbors.
+	sunday := tr.Translate(ticks[3].Value) - tr.Translate(ticks[2].Value)
+	monday := tr.Translate(ticks[4].Value) - tr.Translate(ticks[3].Value)
+	assert.True(sunday < monday, sunday, monday)
+}
+
+func TestTimeRangeHourTicksAcrossDST(t *testing.T) {
+	assert := assert.New(t)
+
+	eastern := util.Date.Eastern()
+	// the 2024 fall back, where 01:00 to 02:00 happens twice.
+	min := time.Date(2024, 11, 2, 20, 0, 0, 0, eastern)
+	max := time.Date(2024, 11, 3, 20, 0, 0, 0, eastern)
+	tr := &TimeRange{Location: eastern}
+	tr.SetMin(util.Time.ToFloat64(min))
+	tr.SetMax(util.Time.ToFloat64(max))
+	tr.SetDomain(1000)
+
+	r, style := timeRangeTestRenderer(t)
+	ticks := tr.GetTicks(r, style, TimeValueFormatter)
+	assert.True(len(ticks) > 2)
+	step := util.Time.FromFloat64(ticks[1].Value).Sub(util.Time.FromFloat64(ticks[0].Value))
+	for _, tick := range ticks {
+		local := util.Time.FromFloat64(tick.Value).In(eastern)
+		assert.Zero(local.Minute())
+		assert.Zero(local.Hour()%int(step.Hours()), local.String())
+	}
+}
+
+func TestTimeRangeMonthTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	tr := &TimeRange{Location: time.UTC}
+	tr.SetMin(util.Time.ToFloat64(time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)))
+	tr.SetMax(util.Time.ToFloat64(time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)))
+	tr.SetDomain(2000)
+
+	r, style := timeRangeTestRenderer(t)
+	ticks := tr.GetTicks(r, style, TimeValueFormatter)
+	assert.Len(ticks, 11)
+	for _, tick := range ticks {
+		assert.Equal(1, util.Time.FromFloat64(tick.Value).In(time.UTC).Day())
+	}
+}
+
+func TestTimeStepNext(t *testing.T) {
+	assert := assert.New(t)
+
+	eastern := util.Date.Eastern()
+	sixHours := timeStep{timeUnitHour, 6, 6 * time.Hour}
+	t0 := time.Date(2024, 3, 10, 0, 0, 0, 0, eastern)
+	t1 := sixHours.next(t0)
+	assert.Equal(6, t1.Hour())
+	// six local hours across the spring forward are five real hours.
+	assert.Equal(5*time.Hour, t1.Sub(t0))
+
+	day := timeStep{timeUnitDay, 1, 24 * time.Hour}
+	assert.Equal(23*time.Hour, day.next(t0).Sub(t0))
+}
+
+func TestChartTimeSeriesUsesTimeRange(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		Series: []Series{
+			TimeSeries{
+				XValues: []time.Time{now, now.AddDate(0, 0, 10), now.AddDate(0, 0, 20)},
+				YValues: []float64{1, 2, 3},
+			},
+		},
+	}
+	xr, _, _ := c.getRanges()
+	_, isTimeRange := xr.(*TimeRange)
+	assert.True(isTimeRange)
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestChartXAxisTimeZone(t *testing.T) {
+	assert := assert.New(t)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow(), TimeZone: tokyo},
+		Series: []Series{
+			TimeSeries{
+				XValues: []time.Time{start, start.AddDate(0, 0, 3), start.AddDate(0, 0, 6)},
+				YValues: []float64{1, 2, 3},
+			},
+		},
+	}
+	xr, _, _ := c.getRanges()
+	tr, isTimeRange := xr.(*TimeRange)
+	assert.True(isTimeRange)
+	assert.Equal(tokyo, tr.Location)
+
+	tr.SetDomain(600)
+	r, style := timeRangeTestRenderer(t)
+	ticks := tr.GetTicks(r, style, TimeValueFormatter)
+	assert.NotEmpty(ticks)
+	for _, tick := range ticks {
+		local := util.Time.FromFloat64(tick.Value).In(tokyo)
+		assert.Equal(0, local.Hour(), local.String())
+		assert.Equal(local.Format(DefaultDateFormat), tick.Label)
+	}
+	assert.Nil(c.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestTimeRangeFallbackTicksInLocation(t *testing.T) {
+	assert := assert.New(t)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tr := TimeRange{Location: tokyo}
+	at := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	vf := tr.inLocation(TimeValueFormatter)
+	assert.Equal(at.In(tokyo).Format(DefaultDateFormat), vf(util.Time.ToFloat64(at)))
+	assert.Equal("2024-03-02", vf(util.Time.ToFloat64(at)))
+
+	// other formatters are left alone.
+	assert.Equal("x", tr.inLocation(func(v interface{}) string { return "x" })(0.0))
+}
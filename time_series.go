@@ -1,7 +1,6 @@
 package chart
 
 import (
-	"fmt"
 	"time"
 
 	util "github.com/wcharczuk/go-chart/util"
@@ -13,9 +12,17 @@ type TimeSeries struct {
 	Style Style
 
 	YAxis YAxisType
+	XAxis XAxisType
 
 	XValues []time.Time
 	YValues []float64
+
+	// StyleProvider styles individual points, i.e. to color the values above a threshold; the style it returns
+	// inherits from the series style and is used for the dot at the point and the segment ending at it.
+	StyleProvider func(index int, x, y float64) Style
+
+	// GapPolicy is how the line is drawn across missing values, i.e. NaN y values.
+	GapPolicy GapPolicy
 }
 
 // GetName returns the name of the time series.
@@ -36,14 +43,32 @@ func (ts TimeSeries) Len() int {
 // GetValues gets a value at a given index.
 func (ts TimeSeries) GetValues(index int) (x, y float64) {
 	x = util.Time.ToFloat64(ts.XValues[index])
-	y = ts.YValues[index]
+	y = gapValue(ts.YValues[index], ts.GapPolicy, nil)
 	return
 }
 
+// GetValuesInto copies the values starting at a given index into the given slices.
+func (ts TimeSeries) GetValuesInto(start int, xvalues, yvalues []float64) int {
+	count := valuesIntoCount(len(ts.XValues), start, xvalues, yvalues)
+	if count == 0 {
+		return 0
+	}
+	for index := 0; index < count; index++ {
+		xvalues[index] = util.Time.ToFloat64(ts.XValues[start+index])
+	}
+	copy(yvalues[:count], ts.YValues[start:])
+	if ts.GapPolicy == GapPolicyZero {
+		for index := range yvalues[:count] {
+			yvalues[index] = gapValue(yvalues[index], ts.GapPolicy, nil)
+		}
+	}
+	return count
+}
+
 // GetLastValues gets the last value.
 func (ts TimeSeries) GetLastValues() (x, y float64) {
 	x = util.Time.ToFloat64(ts.XValues[len(ts.XValues)-1])
-	y = ts.YValues[len(ts.YValues)-1]
+	y = gapValue(ts.YValues[len(ts.YValues)-1], ts.GapPolicy, nil)
 	return
 }
 
@@ -59,20 +84,52 @@ func (ts TimeSeries) GetYAxis() YAxisType {
 	return ts.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (ts TimeSeries) GetXAxis() XAxisType {
+	return ts.XAxis
+}
+
 // Render renders the series.
 func (ts TimeSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
 	style := ts.Style.InheritFrom(defaults)
-	Draw.LineSeries(r, canvasBox, xrange, yrange, style, ts)
+	if ts.GapPolicy == GapPolicyBreak {
+		for _, run := range gapRuns(ts) {
+			ts.drawLine(r, canvasBox, xrange, yrange, style, valuesRun{ValuesProvider: ts, start: run[0], end: run[1]}, run[0])
+		}
+		return
+	}
+	ts.drawLine(r, canvasBox, xrange, yrange, style, ts, 0)
+}
+
+// drawLine draws the line through values of the series, which start at an offset within it.
+func (ts TimeSeries) drawLine(r Renderer, canvasBox Box, xrange, yrange Range, style Style, vs ValuesProvider, offset int) {
+	if ts.StyleProvider == nil {
+		Draw.LineSeries(r, canvasBox, xrange, yrange, style, vs)
+		return
+	}
+	Draw.StyledLineSeries(r, canvasBox, xrange, yrange, style, vs, func(index int, x, y float64) Style {
+		return ts.StyleProvider(offset+index, x, y)
+	})
 }
 
 // Validate validates the series.
 func (ts TimeSeries) Validate() error {
 	if len(ts.XValues) == 0 {
-		return fmt.Errorf("time series must have xvalues set")
+		return newValidationError(ErrEmptySeries, "time series must have xvalues set")
 	}
 
 	if len(ts.YValues) == 0 {
-		return fmt.Errorf("time series must have yvalues set")
+		return newValidationError(ErrEmptySeries, "time series must have yvalues set")
+	}
+
+	if len(ts.XValues) != len(ts.YValues) {
+		return newValidationError(ErrLengthMismatch, "time series has %d xvalues but %d yvalues", len(ts.XValues), len(ts.YValues))
+	}
+
+	for index := 1; index < len(ts.XValues); index++ {
+		if ts.XValues[index].Before(ts.XValues[index-1]) {
+			return newValidationError(ErrUnsortedXValues, "time series xvalue %d is before xvalue %d", index, index-1)
+		}
 	}
 	return nil
 }
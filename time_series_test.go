@@ -1,6 +1,7 @@
 package chart
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -67,3 +68,25 @@ func TestTimeSeriesValidate(t *testing.T) {
 	}
 	assert.NotNil(cs.Validate())
 }
+
+func TestTimeSeriesGapPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := TimeSeries{
+		XValues: []time.Time{start, start.Add(time.Minute), start.Add(2 * time.Minute), start.Add(3 * time.Minute)},
+		YValues: []float64{1, math.NaN(), 3, 4},
+	}
+	_, y := ts.GetValues(1)
+	assert.True(math.IsNaN(y))
+
+	ts.GapPolicy = GapPolicyBreak
+	assert.Equal([][2]int{{0, 1}, {2, 4}}, gapRuns(ts))
+
+	ts.GapPolicy = GapPolicyZero
+	_, y = ts.GetValues(1)
+	assert.Zero(y)
+	xvalues, yvalues := make([]float64, 4), make([]float64, 4)
+	ts.GetValuesInto(0, xvalues, yvalues)
+	assert.Equal([]float64{1, 0, 3, 4}, yvalues)
+}
@@ -0,0 +1,107 @@
+package chart
+
+// titleBlock is a block of text around the plot of a chart, with its style.
+type titleBlock struct {
+	Text  string
+	Style Style
+}
+
+// titleBlocks are the title and subtitle above the plot of a chart, and the footer and source below it.
+// Once a chart has a subtitle, the title and subtitle are laid out above the plot, which is shrunk to make
+// room for them; a title on its own is drawn by the chart over its top padding as before. The footer and
+// source are laid out below the plot whenever they are set. All of the blocks wrap to the width of the chart.
+type titleBlocks struct {
+	Title    titleBlock
+	Subtitle titleBlock
+	Footer   titleBlock
+	Source   titleBlock
+}
+
+// hasHeader returns if the title and subtitle are laid out above the plot.
+func (tb titleBlocks) hasHeader() bool {
+	return len(tb.Subtitle.Text) > 0
+}
+
+// header returns the blocks above the plot, from the top down.
+func (tb titleBlocks) header() (blocks []titleBlock) {
+	if !tb.hasHeader() {
+		return
+	}
+	if len(tb.Title.Text) > 0 && tb.Title.Style.Show {
+		blocks = append(blocks, tb.Title)
+	}
+	return append(blocks, tb.Subtitle)
+}
+
+// footer returns the blocks below the plot, from the top down.
+func (tb titleBlocks) footer() (blocks []titleBlock) {
+	for _, block := range []titleBlock{tb.Footer, tb.Source} {
+		if len(block.Text) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	return
+}
+
+// adjust returns a box less the space taken by the blocks above and below the plot.
+func (tb titleBlocks) adjust(r Renderer, box Box) Box {
+	var layout BoxLayout
+	if height := tb.measure(r, tb.header(), box.Width()); height > 0 {
+		layout.Items = append(layout.Items, BoxLayoutItem{Anchor: LayoutAnchorTop, Intrinsic: height, Gap: DefaultTitleBlockSpacing})
+	}
+	if height := tb.measure(r, tb.footer(), box.Width()); height > 0 {
+		layout.Items = append(layout.Items, BoxLayoutItem{Anchor: LayoutAnchorBottom, Intrinsic: height, Gap: DefaultTitleBlockSpacing})
+	}
+	_, box = layout.Solve(box)
+	return box
+}
+
+// draw draws the blocks within a box; the header is drawn from its top down and the footer up to its bottom.
+func (tb titleBlocks) draw(r Renderer, box Box) {
+	header, footer := tb.header(), tb.footer()
+	tb.drawBlocks(r, header, box.Left, box.Right, box.Top)
+	tb.drawBlocks(r, footer, box.Left, box.Right, box.Bottom-tb.measure(r, footer, box.Width()))
+}
+
+// measure returns the height of blocks stacked within a width.
+func (tb titleBlocks) measure(r Renderer, blocks []titleBlock, width int) (height int) {
+	for index, block := range blocks {
+		if index > 0 {
+			height += DefaultLineSpacing
+		}
+		height += tb.measureBlock(r, block, width)
+	}
+	return
+}
+
+// measureBlock returns the height of a block wrapped to a width.
+func (tb titleBlocks) measureBlock(r Renderer, block titleBlock, width int) int {
+	defer r.ResetStyle()
+	return Text.MeasureLines(r, Text.WrapFit(r, block.Text, width, block.Style), block.Style).Height()
+}
+
+// drawBlocks draws blocks stacked from a top down.
+func (tb titleBlocks) drawBlocks(r Renderer, blocks []titleBlock, left, right, top int) {
+	for _, block := range blocks {
+		height := tb.measureBlock(r, block, right-left)
+		Draw.TextWithin(r, block.Text, Box{Top: top, Left: left, Right: right, Bottom: top + height}, block.Style)
+		top += height + DefaultLineSpacing
+	}
+}
+
+// styleDefaultsTitleBlocks returns the default styles of the subtitle, footer and source of a chart, given the
+// font and text color of the chart. The subtitle is centered under the title, the footer is left aligned
+// and the source right aligned.
+func styleDefaultsTitleBlocks(font Style) (subtitle, footer, source Style) {
+	base := Style{
+		Font:              font.Font,
+		FontColor:         font.FontColor,
+		TextWrap:          TextWrapWord,
+		TextVerticalAlign: TextVerticalAlignTop,
+	}
+	subtitle, footer, source = base, base, base
+	subtitle.FontSize, subtitle.TextHorizontalAlign = DefaultSubtitleFontSize, TextHorizontalAlignCenter
+	footer.FontSize, footer.TextHorizontalAlign = DefaultFooterFontSize, TextHorizontalAlignLeft
+	source.FontSize, source.TextHorizontalAlign = DefaultSourceFontSize, TextHorizontalAlignRight
+	return
+}
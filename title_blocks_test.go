@@ -0,0 +1,93 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestTitleBlocksAdjust(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	r, err := PNG(400, 300)
+	assert.Nil(err)
+
+	subtitle, footer, source := styleDefaultsTitleBlocks(Style{Font: f, FontColor: ColorBlack})
+	box := NewBox(0, 0, 400, 300)
+
+	blocks := titleBlocks{Title: titleBlock{Text: "Title", Style: Style{Show: true, Font: f, FontSize: 18}}}
+	assert.Equal(box, blocks.adjust(r, box))
+
+	blocks.Subtitle = titleBlock{Text: "Subtitle", Style: subtitle}
+	withHeader := blocks.adjust(r, box)
+	assert.True(withHeader.Top > 18+12)
+	assert.Equal(box.Bottom, withHeader.Bottom)
+
+	blocks.Footer = titleBlock{Text: "Footer", Style: footer}
+	withFooter := blocks.adjust(r, box)
+	assert.Equal(withHeader.Top, withFooter.Top)
+	assert.True(withFooter.Bottom < box.Bottom)
+
+	blocks.Source = titleBlock{Text: "Source: somewhere", Style: source}
+	withSource := blocks.adjust(r, box)
+	assert.True(withSource.Bottom < withFooter.Bottom)
+
+	// long blocks wrap to the width of the chart.
+	blocks.Footer.Text = "a footer that is long enough that it has to wrap onto a second line at this width of the chart"
+	assert.True(blocks.adjust(r, box).Bottom < withSource.Bottom)
+}
+
+func TestChartTitleBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Title:      "Title",
+		TitleStyle: StyleShow(),
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	r, err := c.NewRenderer(PNG)
+	assert.Nil(err)
+	c.Font, _ = GetDefaultFont()
+	plain, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+
+	c.Subtitle, c.Footer, c.Source = "Subtitle", "Footer", "Source"
+	withBlocks, err := c.Layout(r, c.Measure())
+	assert.Nil(err)
+	assert.True(withBlocks.Canvas.Top > plain.Canvas.Top)
+	assert.True(withBlocks.Canvas.Bottom < plain.Canvas.Bottom)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	for _, text := range []string{"Title", "Subtitle", "Footer", "Source"} {
+		assert.True(bytes.Contains(buffer.Bytes(), []byte(">"+text+"<")), text)
+	}
+}
+
+func TestBarAndPieChartTitleBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Title:    "Bars",
+		Subtitle: "Subtitle",
+		Source:   "Source",
+		Bars:     []Value{{Value: 1, Label: "A"}, {Value: 2, Label: "B"}},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(bc.Render(SVG, buffer))
+	assert.True(bytes.Contains(buffer.Bytes(), []byte(">Subtitle<")))
+	assert.True(bytes.Contains(buffer.Bytes(), []byte(">Source<")))
+
+	pc := PieChart{
+		Footer: "Footer",
+		Values: []Value{{Value: 1, Label: "A"}, {Value: 2, Label: "B"}},
+	}
+	buffer.Reset()
+	assert.Nil(pc.Render(SVG, buffer))
+	assert.True(bytes.Contains(buffer.Bytes(), []byte(">Footer<")))
+}
@@ -0,0 +1,118 @@
+package chart
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Tooltip describes a shape a chart draws for what shows when it is hovered over: a title, which browsers show
+// as a native tooltip of svg output, and the values the shape was drawn from, written as `data-x` and `data-y`
+// attributes for scripts (see `SVGOptions`).
+type Tooltip struct {
+	Title string
+	X, Y  string
+}
+
+// IsZero returns if the tooltip describes nothing.
+func (t Tooltip) IsZero() bool {
+	return len(t.Title) == 0 && len(t.X) == 0 && len(t.Y) == 0
+}
+
+// tooltipRenderer is a renderer that can attach tooltips to the shapes it draws, i.e. svg with `SVGOptions.Tooltips`.
+type tooltipRenderer interface {
+	// ShowsTooltips returns if tooltips are attached; describing shapes can be skipped if not.
+	ShowsTooltips() bool
+	// SetTooltip attaches a tooltip to the next path or circle drawn.
+	SetTooltip(tooltip Tooltip)
+	// SetTooltipSeries sets the series the points drawn next belong to, which their tooltips name and format them by.
+	SetTooltipSeries(series tooltipSeries)
+	// GetTooltipSeries returns the series the points drawn belong to.
+	GetTooltipSeries() tooltipSeries
+}
+
+// tooltipSeries is the name and value formatters of a series, for the tooltips of its points.
+type tooltipSeries struct {
+	Name       string
+	XFormatter ValueFormatter
+	YFormatter ValueFormatter
+}
+
+// getTooltipSeries returns the name and value formatters of a series for the tooltips of its points.
+func getTooltipSeries(s Series) tooltipSeries {
+	series := tooltipSeries{Name: s.GetName()}
+	if vfp, isValueFormatterProvider := s.(ValueFormatterProvider); isValueFormatterProvider {
+		series.XFormatter, series.YFormatter = vfp.GetValueFormatters()
+	}
+	return series
+}
+
+// getTooltipRenderer returns the renderer as a tooltip renderer, if it shows tooltips.
+func getTooltipRenderer(r Renderer) (tooltipRenderer, bool) {
+	if tfr, isTextFree := r.(textFreeRenderer); isTextFree {
+		r = tfr.Renderer
+	}
+	if or, isOffset := r.(offsetRenderer); isOffset {
+		r = or.Renderer
+	}
+	if tr, isTooltipRenderer := r.(tooltipRenderer); isTooltipRenderer && tr.ShowsTooltips() {
+		return tr, true
+	}
+	return nil, false
+}
+
+// showsTooltips returns if the renderer shows tooltips.
+func showsTooltips(r Renderer) bool {
+	_, shows := getTooltipRenderer(r)
+	return shows
+}
+
+// setTooltip attaches a tooltip to the next shape drawn, if the renderer shows tooltips.
+func setTooltip(r Renderer, tooltip Tooltip) {
+	if tr, shows := getTooltipRenderer(r); shows {
+		tr.SetTooltip(tooltip)
+	}
+}
+
+// setTooltipSeries sets the series the points drawn next belong to, if the renderer shows tooltips;
+// the zero series clears it.
+func setTooltipSeries(r Renderer, series tooltipSeries) {
+	if tr, shows := getTooltipRenderer(r); shows {
+		tr.SetTooltipSeries(series)
+	}
+}
+
+// setPointTooltip attaches the tooltip of a point of the current series to the next shape drawn,
+// if the renderer shows tooltips.
+func setPointTooltip(r Renderer, x, y float64) {
+	tr, shows := getTooltipRenderer(r)
+	if !shows {
+		return
+	}
+	series := tr.GetTooltipSeries()
+	xf, yf := series.XFormatter, series.YFormatter
+	if xf == nil {
+		xf = FloatValueFormatter
+	}
+	if yf == nil {
+		yf = FloatValueFormatter
+	}
+	tr.SetTooltip(Tooltip{
+		Title: tooltipTitle(series.Name, xf(x), yf(y)),
+		X:     formatTooltipValue(x),
+		Y:     formatTooltipValue(y),
+	})
+}
+
+// tooltipTitle returns the title of a tooltip: the values joined, after the name if there is one.
+func tooltipTitle(name string, values ...string) string {
+	text := strings.Join(values, ", ")
+	if len(name) == 0 {
+		return text
+	}
+	return name + ": " + text
+}
+
+// formatTooltipValue returns a value as it is written into a data attribute, in full precision.
+func formatTooltipValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+// renderTooltips renders a chart to svg with tooltips, and checks the svg is well formed.
+func renderTooltips(assert *assert.Assertions, c interface {
+	Render(RendererProvider, io.Writer) error
+}, options SVGOptions) string {
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVGWithOptions(options), buffer))
+	decoder := xml.NewDecoder(bytes.NewReader(buffer.Bytes()))
+	var err error
+	for err == nil {
+		_, err = decoder.Token()
+	}
+	assert.Equal(io.EOF, err)
+	return buffer.String()
+}
+
+func TestTooltipsSeriesPoints(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{
+				Name:            "Load <avg>",
+				Style:           Style{Show: true, StrokeWidth: 1, DotWidth: 3},
+				XValues:         []float64{1, 2, 3},
+				YValues:         []float64{1.5, 3, 2},
+				YValueFormatter: PercentValueFormatter,
+			},
+			ScatterSeries{XValues: []float64{1, 2}, YValues: []float64{2, 1}},
+		},
+	}
+
+	svg := renderTooltips(assert, c, SVGOptions{Tooltips: true})
+	assert.True(strings.Contains(svg, "<title>Load &lt;avg&gt;: 2.00, 300.00%</title></circle>"), svg)
+	assert.True(strings.Contains(svg, "<title>1.00, 2.00</title>"), svg)
+	assert.False(strings.Contains(svg, "data-x"))
+
+	svg = renderTooltips(assert, c, SVGOptions{Tooltips: true, TooltipData: true})
+	assert.True(strings.Contains(svg, `data-x="3" data-y="2"><title>`), svg)
+
+	// there are no tooltips unless asked for.
+	assert.False(strings.Contains(renderTooltips(assert, c, SVGOptions{}), "<title>Load"))
+}
+
+func TestTooltipsBarsAndSlices(t *testing.T) {
+	assert := assert.New(t)
+
+	bc := BarChart{
+		Bars: []Value{{Label: "Q1"}, {Label: "Q2"}},
+		Series: []BarSeries{
+			{Name: "North", Values: []float64{1, 2}},
+			{Name: "South", Values: []float64{3, 4}},
+		},
+	}
+	svg := renderTooltips(assert, bc, SVGOptions{Tooltips: true, TooltipData: true})
+	assert.True(strings.Contains(svg, `data-x="Q2" data-y="4"><title>South: Q2, 4.00</title></path>`), svg)
+
+	bc = BarChart{Bars: []Value{{Label: "a", Value: 1}, {Label: "b", Value: 2}}}
+	assert.True(strings.Contains(renderTooltips(assert, bc, SVGOptions{Tooltips: true}), "<title>b, 2.00</title>"))
+
+	pc := PieChart{Values: []Value{{Label: "a", Value: 1}, {Label: "b", Value: 3}}}
+	assert.True(strings.Contains(renderTooltips(assert, pc, SVGOptions{Tooltips: true}), "<title>b: 75.00%</title>"))
+
+	sbc := StackedBarChart{Bars: []StackedBar{{Name: "2024", Values: []Value{{Label: "a", Value: 1}, {Label: "b", Value: 1}}}}}
+	assert.True(strings.Contains(renderTooltips(assert, sbc, SVGOptions{Tooltips: true}), "<title>2024: a, 50.00%</title>"))
+}
@@ -0,0 +1,446 @@
+package chart
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// TreeMapNode is a named value in a tree map; the value of a node with children is the sum of theirs.
+type TreeMapNode struct {
+	Label string
+	Value float64
+	// Style overrides the style of the node's cell; its children inherit the fill color.
+	Style    Style
+	Children []TreeMapNode
+}
+
+// GetValue returns the value of the node, or the sum of the values of its children if it has any.
+// NaN and negative values count as 0.
+func (tmn TreeMapNode) GetValue() float64 {
+	if len(tmn.Children) == 0 {
+		if math.IsNaN(tmn.Value) || tmn.Value < 0 {
+			return 0
+		}
+		return tmn.Value
+	}
+	var total float64
+	for _, child := range tmn.Children {
+		total += child.GetValue()
+	}
+	return total
+}
+
+// TreeMap draws a hierarchy of values as nested rectangles with areas in proportion to the values,
+// laid out by the squarified algorithm so cells stay close to square. Top level nodes are colored
+// from the color palette and each level below is a lighter shade of its parent. Cells with children
+// are labeled in a header above them and cells without in their top left corner; cells smaller than
+// `MinLabelSize`, or too small for their label, are not labeled.
+type TreeMap struct {
+	Title      string
+	TitleStyle Style
+
+	ColorPalette ColorPalette
+
+	Width  int
+	Height int
+	DPI    float64
+
+	Background Style
+	Canvas     Style
+
+	// BackgroundImage is drawn over the background fill and under the canvas.
+	BackgroundImage BackgroundImage
+	// Watermark is text drawn across the chart, i.e. "CONFIDENTIAL"; it is drawn over the canvas fill.
+	Watermark Watermark
+
+	// CellStyle is the style of the cells; their fill color comes from the color palette.
+	CellStyle Style
+	// LabelStyle is the style of the cell labels; the font color defaults to one that reads on the cell.
+	LabelStyle Style
+
+	// Padding is the space between a cell and the cells of its children; it defaults to `DefaultTreeMapPadding`.
+	Padding int
+	// MinLabelSize is the width and height below which cells are not labeled; it defaults to `DefaultTreeMapMinLabelSize`.
+	MinLabelSize int
+
+	Font        *truetype.Font
+	defaultFont *truetype.Font
+
+	// NoText renders the chart without any text, and without loading a font.
+	NoText bool
+
+	// BeforeRender is called after the background and canvas are drawn, and AfterRender after the rest
+	// of the chart is drawn; both are optional.
+	BeforeRender RenderHook
+	AfterRender  RenderHook
+
+	// Accessibility describes the chart for screen readers in svg output; it is generated if left empty.
+	Accessibility Accessibility
+
+	Nodes    []TreeMapNode
+	Elements []Renderable
+}
+
+// GetDPI returns the dpi for the chart.
+func (tm TreeMap) GetDPI(defaults ...float64) float64 {
+	if tm.DPI == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultDPI
+	}
+	return tm.DPI
+}
+
+// GetFont returns the text font.
+func (tm TreeMap) GetFont() *truetype.Font {
+	if tm.Font == nil {
+		return tm.defaultFont
+	}
+	return tm.Font
+}
+
+// GetWidth returns the chart width or the default value.
+func (tm TreeMap) GetWidth() int {
+	if tm.Width == 0 {
+		return DefaultChartWidth
+	}
+	return tm.Width
+}
+
+// GetHeight returns the chart height or the default value.
+func (tm TreeMap) GetHeight() int {
+	if tm.Height == 0 {
+		return DefaultChartHeight
+	}
+	return tm.Height
+}
+
+// GetPadding returns the space between a cell and the cells of its children or the default.
+func (tm TreeMap) GetPadding() int {
+	if tm.Padding <= 0 {
+		return DefaultTreeMapPadding
+	}
+	return tm.Padding
+}
+
+// GetMinLabelSize returns the width and height below which cells are not labeled or the default.
+func (tm TreeMap) GetMinLabelSize() int {
+	if tm.MinLabelSize <= 0 {
+		return DefaultTreeMapMinLabelSize
+	}
+	return tm.MinLabelSize
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (tm TreeMap) Validate() error {
+	var errs ValidationErrors
+	if err := validateFont(tm.Font, tm.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if (TreeMapNode{Children: tm.Nodes}).GetValue() <= 0 {
+		errs = append(errs, newValidationError(ErrEmptySeries, "please provide at least one positive value"))
+	}
+	return errs.asError()
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+// Panics raised while rendering are returned as a `RenderPanicError`.
+func (tm TreeMap) Render(rp RendererProvider, w io.Writer) (err error) {
+	defer recoverRender(&err)
+
+	if err = tm.Validate(); err != nil {
+		return err
+	}
+
+	r, err := rp(tm.GetWidth(), tm.GetHeight())
+	if err != nil {
+		return err
+	}
+
+	if tm.NoText {
+		r = textFreeRenderer{r}
+	} else if tm.Font == nil {
+		defaultFont, err := loadDefaultFont()
+		if err != nil {
+			return err
+		}
+		tm.defaultFont = defaultFont
+	}
+	r.SetDPI(tm.GetDPI(DefaultDPI))
+
+	canvasBox := tm.getCanvasBox(r)
+	Draw.Box(r, Box{Right: tm.GetWidth(), Bottom: tm.GetHeight()}, tm.Background.InheritFrom(tm.styleDefaultsBackground()))
+	tm.BackgroundImage.draw(r, Box{Right: tm.GetWidth(), Bottom: tm.GetHeight()})
+	Draw.Box(r, canvasBox, tm.Canvas.InheritFrom(tm.styleDefaultsCanvas()))
+	tm.Watermark.draw(r, Box{Right: tm.GetWidth(), Bottom: tm.GetHeight()}, tm.GetFont(), tm.GetColorPalette(), false)
+	cl := ChartLayout{Canvas: canvasBox}
+	callRenderHook(tm.BeforeRender, r, cl)
+
+	tm.drawNodes(r, canvasBox, tm.Nodes, 0, drawing.Color{})
+
+	if len(tm.Title) > 0 && tm.TitleStyle.Show {
+		Draw.TextWithin(r, tm.Title, tm.Box(), tm.styleDefaultsTitle())
+	}
+	for _, a := range tm.Elements {
+		a(r, canvasBox, Style{Font: tm.GetFont()})
+	}
+	tm.Watermark.draw(r, Box{Right: tm.GetWidth(), Bottom: tm.GetHeight()}, tm.GetFont(), tm.GetColorPalette(), true)
+	callRenderHook(tm.AfterRender, r, cl)
+
+	setAccessibility(r, tm.Accessibility.withDefaults(tm.Title, func() string {
+		values := make([]Value, len(tm.Nodes))
+		for index, node := range tm.Nodes {
+			values[index] = Value{Label: node.Label, Value: node.GetValue()}
+		}
+		return describeValues("Tree map", values)
+	}))
+	return r.Save(w)
+}
+
+// getCanvasBox returns the box of the cells, which leaves room for the title.
+func (tm TreeMap) getCanvasBox(r Renderer) Box {
+	canvasBox := tm.Box()
+	if len(tm.Title) > 0 && tm.TitleStyle.Show {
+		tm.styleDefaultsTitle().GetTextOptions().WriteToRenderer(r)
+		lines := Text.WrapFit(r, tm.Title, canvasBox.Width(), tm.styleDefaultsTitle())
+		canvasBox.Top += Text.MeasureLines(r, lines, tm.styleDefaultsTitle()).Height() + DefaultLineSpacing
+	}
+	return canvasBox
+}
+
+// getCells returns the cells of nodes laid out within a box.
+func (tm TreeMap) getCells(box Box, nodes []TreeMapNode) []Box {
+	values := make([]float64, len(nodes))
+	for index, node := range nodes {
+		values[index] = node.GetValue()
+	}
+	return squarify(values, box)
+}
+
+// getColor returns the fill color of a node; top level nodes take colors from the color palette
+// and the nodes below them a lighter shade of their parent's color.
+func (tm TreeMap) getColor(node TreeMapNode, index, depth int, parent drawing.Color) drawing.Color {
+	if !node.Style.FillColor.IsZero() {
+		return node.Style.FillColor
+	}
+	if depth == 0 {
+		return tm.GetColorPalette().GetSeriesColor(index)
+	}
+	return blendColors(parent, ColorWhite, 0.25)
+}
+
+func (tm TreeMap) drawNodes(r Renderer, box Box, nodes []TreeMapNode, depth int, parent drawing.Color) {
+	cells := tm.getCells(box, nodes)
+	for index, node := range nodes {
+		cell := cells[index]
+		if cell.Width() <= 0 || cell.Height() <= 0 {
+			continue
+		}
+		color := tm.getColor(node, index, depth, parent)
+		style := node.Style.InheritFrom(tm.CellStyle.InheritFrom(tm.styleDefaultsCell()))
+		style.FillColor = color
+		Draw.Box(r, cell, style)
+
+		header := tm.drawLabel(r, cell, node, color)
+		if len(node.Children) == 0 {
+			continue
+		}
+		padding := tm.GetPadding()
+		inner := Box{
+			Top:    cell.Top + util.Math.MaxInt(padding, header),
+			Left:   cell.Left + padding,
+			Right:  cell.Right - padding,
+			Bottom: cell.Bottom - padding,
+		}
+		if inner.Width() > 0 && inner.Height() > 0 {
+			tm.drawNodes(r, inner, node.Children, depth+1, color)
+		}
+	}
+}
+
+// drawLabel draws the label of a node in the top left corner of its cell, if it is large enough,
+// and returns the height the label takes up including padding, or 0 if it is not drawn.
+func (tm TreeMap) drawLabel(r Renderer, cell Box, node TreeMapNode, fill drawing.Color) int {
+	minSize := tm.GetMinLabelSize()
+	if len(node.Label) == 0 || cell.Width() < minSize || cell.Height() < minSize {
+		return 0
+	}
+	style := tm.LabelStyle.InheritFrom(tm.styleDefaultsLabel())
+	if tm.LabelStyle.FontColor.IsZero() {
+		style.FontColor = contrastingTextColor(fill)
+	}
+	style.GetTextOptions().WriteToRenderer(r)
+	tb := r.MeasureText(node.Label)
+	padding := tm.GetPadding()
+	if tb.Width()+2*padding > cell.Width() || tb.Height()+2*padding > cell.Height() {
+		return 0
+	}
+	r.Text(node.Label, cell.Left+padding, cell.Top+padding+tb.Height())
+	return tb.Height() + 2*padding
+}
+
+// squarify lays out values as cells that tile a box, with areas in proportion to the values; cells are
+// returned in the order of the values. Larger values are placed first, in rows along the shorter side
+// of the box that is left, and a row takes more values for as long as that keeps its cells closer to square.
+func squarify(values []float64, box Box) []Box {
+	cells := make([]Box, len(values))
+	order := make([]int, 0, len(values))
+	var total float64
+	for index, v := range values {
+		if v > 0 {
+			order = append(order, index)
+			total += v
+		}
+	}
+	if total <= 0 {
+		return cells
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	x, y := float64(box.Left), float64(box.Top)
+	w, h := float64(box.Width()), float64(box.Height())
+	areas := make([]float64, len(order))
+	for index, valueIndex := range order {
+		areas[index] = values[valueIndex] / total * w * h
+	}
+
+	for start := 0; start < len(order); {
+		side := math.Min(w, h)
+		end := start + 1
+		for end < len(order) && worstAspectRatio(areas[start:end+1], side) <= worstAspectRatio(areas[start:end], side) {
+			end++
+		}
+
+		var rowArea float64
+		for _, area := range areas[start:end] {
+			rowArea += area
+		}
+		offset := 0.0
+		if w >= h {
+			rowWidth := rowArea / h
+			for index := start; index < end; index++ {
+				cellHeight := areas[index] / rowWidth
+				cells[order[index]] = roundBox(x, y+offset, x+rowWidth, y+offset+cellHeight)
+				offset += cellHeight
+			}
+			x, w = x+rowWidth, w-rowWidth
+		} else {
+			rowHeight := rowArea / w
+			for index := start; index < end; index++ {
+				cellWidth := areas[index] / rowHeight
+				cells[order[index]] = roundBox(x+offset, y, x+offset+cellWidth, y+rowHeight)
+				offset += cellWidth
+			}
+			y, h = y+rowHeight, h-rowHeight
+		}
+		start = end
+	}
+	return cells
+}
+
+// worstAspectRatio returns the largest ratio of the longer to the shorter side of the cells of a row of areas
+// laid out along a side.
+func worstAspectRatio(areas []float64, side float64) float64 {
+	var sum float64
+	min, max := math.MaxFloat64, 0.0
+	for _, area := range areas {
+		sum += area
+		min, max = math.Min(min, area), math.Max(max, area)
+	}
+	return math.Max(side*side*max/(sum*sum), sum*sum/(side*side*min))
+}
+
+// roundBox returns the box of corners rounded to the nearest pixel, so neighboring cells share their edges.
+func roundBox(left, top, right, bottom float64) Box {
+	return Box{
+		Top:    int(math.Round(top)),
+		Left:   int(math.Round(left)),
+		Right:  int(math.Round(right)),
+		Bottom: int(math.Round(bottom)),
+	}
+}
+
+func (tm TreeMap) styleDefaultsBackground() Style {
+	return Style{
+		FillColor:   tm.GetColorPalette().BackgroundColor(),
+		StrokeColor: tm.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (tm TreeMap) styleDefaultsCanvas() Style {
+	return Style{
+		FillColor:   tm.GetColorPalette().CanvasColor(),
+		StrokeColor: tm.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: DefaultStrokeWidth,
+	}
+}
+
+func (tm TreeMap) styleDefaultsCell() Style {
+	return Style{
+		StrokeColor: tm.GetColorPalette().CanvasColor(),
+		StrokeWidth: 1,
+	}
+}
+
+func (tm TreeMap) styleDefaultsLabel() Style {
+	return Style{
+		Font:     tm.GetFont(),
+		FontSize: DefaultAxisFontSize,
+	}
+}
+
+func (tm TreeMap) styleDefaultsTitle() Style {
+	return tm.TitleStyle.InheritFrom(Style{
+		FontColor:           tm.GetColorPalette().TextColor(),
+		Font:                tm.GetFont(),
+		FontSize:            tm.getTitleFontSize(),
+		TextHorizontalAlign: TextHorizontalAlignCenter,
+		TextVerticalAlign:   TextVerticalAlignTop,
+		TextWrap:            TextWrapWord,
+	})
+}
+
+func (tm TreeMap) getTitleFontSize() float64 {
+	effectiveDimension := util.Math.MinInt(tm.GetWidth(), tm.GetHeight())
+	if effectiveDimension >= 2048 {
+		return 48
+	} else if effectiveDimension >= 1024 {
+		return 24
+	} else if effectiveDimension >= 512 {
+		return 18
+	} else if effectiveDimension >= 256 {
+		return 12
+	}
+	return 10
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (tm TreeMap) GetColorPalette() ColorPalette {
+	if tm.ColorPalette != nil {
+		return tm.ColorPalette
+	}
+	return DefaultColorPalette
+}
+
+// Box returns the chart bounds as a box.
+func (tm TreeMap) Box() Box {
+	dpr := tm.Background.Padding.GetRight(DefaultBackgroundPadding.Right)
+	dpb := tm.Background.Padding.GetBottom(DefaultBackgroundPadding.Bottom)
+
+	return Box{
+		Top:    tm.Background.Padding.GetTop(DefaultBackgroundPadding.Top),
+		Left:   tm.Background.Padding.GetLeft(DefaultBackgroundPadding.Left),
+		Right:  tm.GetWidth() - dpr,
+		Bottom: tm.GetHeight() - dpb,
+	}
+}
@@ -0,0 +1,65 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	assert "github.com/blendlabs/go-assert"
+)
+
+func TestTreeMapNodeGetValue(t *testing.T) {
+	assert := assert.New(t)
+
+	node := TreeMapNode{Value: 100, Children: []TreeMapNode{{Value: 2}, {Value: 3, Children: []TreeMapNode{{Value: 1}, {Value: math.NaN()}, {Value: -4}}}}}
+	assert.Equal(3.0, node.GetValue())
+	assert.Equal(5.0, TreeMapNode{Value: 5}.GetValue())
+}
+
+func TestSquarify(t *testing.T) {
+	assert := assert.New(t)
+
+	// the example of the squarified treemaps paper: a 6x4 box of areas 6, 6, 4, 3, 2, 2, 1.
+	cells := squarify([]float64{6, 6, 4, 3, 2, 2, 1}, Box{Right: 600, Bottom: 400})
+	assert.Len(cells, 7)
+	assert.Equal(Box{Top: 0, Left: 0, Right: 300, Bottom: 200}, cells[0])
+	assert.Equal(Box{Top: 200, Left: 0, Right: 300, Bottom: 400}, cells[1])
+	assert.Equal(Box{Top: 0, Left: 300, Right: 471, Bottom: 233}, cells[2])
+
+	var area int
+	for _, cell := range cells {
+		area += cell.Width() * cell.Height()
+	}
+	assert.InDelta(240000, float64(area), 2000)
+
+	cells = squarify([]float64{1, 0, 3}, Box{Right: 40, Bottom: 10})
+	assert.Equal(Box{Top: 0, Left: 30, Right: 40, Bottom: 10}, cells[0])
+	assert.True(cells[1].IsZero())
+	assert.Equal(Box{Top: 0, Left: 0, Right: 30, Bottom: 10}, cells[2])
+}
+
+func TestTreeMapRender(t *testing.T) {
+	assert := assert.New(t)
+
+	tm := TreeMap{
+		MinLabelSize: 30,
+		Nodes: []TreeMapNode{
+			{Label: "Fruit", Children: []TreeMapNode{{Label: "Apples", Value: 40}, {Label: "Pears", Value: 20}}},
+			{Label: "Vegetables", Children: []TreeMapNode{{Label: "Kale", Value: 25}, {Label: "Leek", Value: 0.1}}},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(tm.Render(PNG, buf))
+	assert.NotZero(buf.Len())
+
+	buf.Reset()
+	assert.Nil(tm.Render(SVG, buf))
+	assert.True(strings.Contains(buf.String(), "Apples"))
+	assert.True(strings.Contains(buf.String(), "Vegetables"))
+	assert.False(strings.Contains(buf.String(), "Leek</text>"))
+
+	assert.NotNil(TreeMap{}.Render(PNG, bytes.NewBuffer(nil)))
+	assert.NotNil(TreeMap{Nodes: []TreeMapNode{{Value: -1}}}.Render(PNG, bytes.NewBuffer(nil)))
+}
@@ -0,0 +1,240 @@
+package chart
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// Unit is the unit of the values on an axis, i.e. bytes or operations per second.
+// It labels ticks with the unit (`1.5 GiB`, `42%`) and places them on steps that are round in the unit,
+// i.e. powers of two of a prefix for bytes. See `XAxis.Unit` and `YAxis.Unit`.
+type Unit struct {
+	// Symbol is written after the value and prefix, i.e. `B` or `ops/s`.
+	Symbol string
+	// Separator is written between the value and the prefixed symbol.
+	Separator string
+	// Base is the ratio between prefixes, i.e. 1000 for SI or 1024 for IEC prefixes; prefixes are not used if it is 0.
+	Base float64
+	// Prefixes are the prefixes for increasing powers of the base, starting with the empty prefix.
+	Prefixes []string
+	// Scale multiplies values before they are formatted, i.e. 100 for ratios formatted as percentages.
+	Scale float64
+}
+
+var (
+	// UnitBytesIEC is bytes with binary prefixes (KiB, MiB, GiB ...); ticks are on powers of two of a prefix.
+	UnitBytesIEC = &Unit{Symbol: "B", Separator: " ", Base: 1024, Prefixes: []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}}
+	// UnitBytesSI is bytes with decimal prefixes (kB, MB, GB ...).
+	UnitBytesSI = &Unit{Symbol: "B", Separator: " ", Base: 1000, Prefixes: []string{"", "k", "M", "G", "T", "P", "E"}}
+	// UnitPercent is ratios formatted as percentages, i.e. 0.42 is `42%`, like `PercentValueFormatter`.
+	UnitPercent = &Unit{Symbol: "%", Scale: 100}
+	// UnitOpsPerSecond is a rate of operations per second with decimal prefixes (k, M, G ...).
+	UnitOpsPerSecond = &Unit{Symbol: "ops/s", Separator: " ", Base: 1000, Prefixes: []string{"", "k", "M", "G", "T"}}
+)
+
+// GetScale returns the scale or a default.
+func (u Unit) GetScale() float64 {
+	if u.Scale == 0 {
+		return 1
+	}
+	return u.Scale
+}
+
+// prefixFor returns the index of the largest prefix not larger than the magnitude of a (scaled) value.
+func (u Unit) prefixFor(value float64) int {
+	if u.Base <= 1 || len(u.Prefixes) == 0 {
+		return 0
+	}
+	magnitude := math.Abs(value)
+	var prefix int
+	for prefix < len(u.Prefixes)-1 && magnitude >= math.Pow(u.Base, float64(prefix+1)) {
+		prefix++
+	}
+	return prefix
+}
+
+// format formats a value with a prefix and a number of decimals.
+func (u Unit) format(value float64, prefix, decimals int) string {
+	number := u.formatNumber(value, prefix, decimals)
+	symbol := u.symbolFor(prefix)
+	if len(symbol) == 0 {
+		return number
+	}
+	return number + u.Separator + symbol
+}
+
+// formatNumber formats a value with a prefix and a number of decimals, without the prefixed symbol.
+func (u Unit) formatNumber(value float64, prefix, decimals int) string {
+	scaled := value * u.GetScale()
+	if u.Base > 1 && prefix > 0 {
+		scaled /= math.Pow(u.Base, float64(prefix))
+	}
+	number := strconv.FormatFloat(scaled, 'f', decimals, 64)
+	if strings.Contains(number, ".") {
+		number = strings.TrimRight(strings.TrimRight(number, "0"), ".")
+	}
+	if number == "-0" {
+		number = "0"
+	}
+	return number
+}
+
+// symbolFor returns the symbol with a prefix, i.e. `GiB`.
+func (u Unit) symbolFor(prefix int) string {
+	if prefix < len(u.Prefixes) {
+		return u.Prefixes[prefix] + u.Symbol
+	}
+	return u.Symbol
+}
+
+// Format formats a value with the largest prefix that keeps it at least one and up to two decimals, i.e. `1.5 GiB`.
+// It is a ValueFormatter.
+func (u Unit) Format(v interface{}) string {
+	value, isNumber := toFloat64(v)
+	if !isNumber {
+		return ""
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return u.format(value, u.prefixFor(value*u.GetScale()), 2)
+}
+
+// formatterForStep returns a value formatter for ticks `step` apart, with a prefix for the magnitude of the range
+// and enough decimals to tell the ticks apart.
+func (u Unit) formatterForStep(min, max, step float64) ValueFormatter {
+	prefix, decimals := u.formatForStep(min, max, step)
+	return func(v interface{}) string {
+		value, isNumber := toFloat64(v)
+		if !isNumber {
+			return ""
+		}
+		if value == 0 {
+			return u.format(value, 0, 0)
+		}
+		return u.format(value, prefix, decimals)
+	}
+}
+
+// formatForStep returns the prefix and decimals of ticks `step` apart.
+func (u Unit) formatForStep(min, max, step float64) (prefix, decimals int) {
+	scale := u.GetScale()
+	prefix = u.prefixFor(math.Max(math.Abs(min), math.Abs(max)) * scale)
+	scaledStep := math.Abs(step * scale)
+	if u.Base > 1 && prefix > 0 {
+		scaledStep /= math.Pow(u.Base, float64(prefix))
+	}
+	if isFiniteNonZero(scaledStep) {
+		if d := int(math.Ceil(-math.Log10(scaledStep))); d > 0 {
+			decimals = d
+		}
+	}
+	return
+}
+
+// nextStep returns the candidate tick step after `step`; candidates are 1, 2 and 5 times powers of ten,
+// or for a base 1024 unit, powers of two (which are also powers of two of each prefix).
+func (u Unit) nextStep(step float64) float64 {
+	scale := u.GetScale()
+	scaled := step * scale
+	if u.Base == 1024 && scaled >= 1 {
+		return step * 2
+	}
+	exponent := math.Floor(math.Log10(scaled))
+	mantissa := scaled / math.Pow(10, exponent)
+	switch {
+	case mantissa < 1.5:
+		return 2 * math.Pow(10, exponent) / scale
+	case mantissa < 3.5:
+		return 5 * math.Pow(10, exponent) / scale
+	default:
+		return 10 * math.Pow(10, exponent) / scale
+	}
+}
+
+// GenerateUnitTicks generates ticks on steps that are round in a unit, labeled with the unit.
+// If `vf` is not nil it labels the ticks instead of the unit.
+func GenerateUnitTicks(r Renderer, ra Range, isVertical bool, style Style, unit *Unit, vf ValueFormatter) []Tick {
+	return generateUnitTicks(r, ra, isVertical, style, unit, vf, UnitPlacementTicks)
+}
+
+// generateUnitTicks generates unit ticks with the unit placed on every tick label, or only on the last tick,
+// which is the end of the axis.
+func generateUnitTicks(r Renderer, ra Range, isVertical bool, style Style, unit *Unit, vf ValueFormatter, placement UnitPlacement) []Tick {
+	min, max := ra.GetMin(), ra.GetMax()
+	if min > max {
+		min, max = max, min
+	}
+	if unit == nil {
+		return GenerateContinuousTicks(r, ra, isVertical, style, vf)
+	}
+	if ticks, isDegenerate := degenerateTicks(min, max, ra.IsDescending(), unit.Format); isDegenerate {
+		return ticks
+	}
+
+	// size the ticks by the widest label, as for continuous ticks.
+	style.GetTextOptions().WriteToRenderer(r)
+	labelBox := r.MeasureText(unit.Format(max))
+	if minBox := r.MeasureText(unit.Format(min)); minBox.Width() > labelBox.Width() {
+		labelBox = minBox
+	}
+	tickSize := float64(labelBox.Width() + DefaultMinimumTickHorizontalSpacing)
+	if isVertical {
+		tickSize = float64(labelBox.Height() + DefaultMinimumTickVerticalSpacing)
+	}
+	maxTicks := int(float64(ra.GetDomain()) / tickSize)
+	if maxTicks < 2 {
+		maxTicks = 2
+	}
+	maxTicks = util.Math.MinInt(maxTicks, DefaultTickCountSanityCheck)
+
+	span := max - min
+	step := smallestUnitStep(unit, span/float64(maxTicks))
+	for math.Floor(max/step)-math.Ceil(min/step)+1 > float64(maxTicks) {
+		step = unit.nextStep(step)
+	}
+
+	atEnd := vf == nil && placement == UnitPlacementAxisEnd
+	if vf == nil {
+		vf = unit.formatterForStep(min, max, step)
+	}
+	var ticks []Tick
+	for index := math.Ceil(min / step); index*step <= max; index++ {
+		value := index * step
+		ticks = append(ticks, Tick{Value: value, Label: vf(value)})
+	}
+	if ra.IsDescending() {
+		for i, j := 0, len(ticks)-1; i < j; i, j = i+1, j-1 {
+			ticks[i], ticks[j] = ticks[j], ticks[i]
+		}
+	}
+	if atEnd && len(ticks) > 0 {
+		// every tick shares the prefix, so only the last is labeled with the prefixed symbol.
+		prefix, decimals := unit.formatForStep(min, max, step)
+		for index := range ticks {
+			ticks[index].Label = unit.formatNumber(ticks[index].Value, prefix, decimals)
+		}
+		last := &ticks[len(ticks)-1]
+		last.Label = unit.format(last.Value, prefix, decimals)
+	}
+	return ticks
+}
+
+// smallestUnitStep returns the first candidate step of a unit at or above a minimum step.
+func smallestUnitStep(unit *Unit, minimum float64) float64 {
+	scale := unit.GetScale()
+	var step float64
+	if unit.Base == 1024 && minimum*scale >= 1 {
+		// the largest power of two of the prefix below the minimum.
+		step = math.Pow(2, math.Floor(math.Log2(minimum*scale))) / scale
+	} else {
+		step = math.Pow(10, math.Floor(math.Log10(minimum*scale))) / scale
+	}
+	for step < minimum {
+		step = unit.nextStep(step)
+	}
+	return step
+}
@@ -0,0 +1,113 @@
+package chart
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestUnitFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("1.5 GiB", UnitBytesIEC.Format(1.5*1024*1024*1024))
+	assert.Equal("512 B", UnitBytesIEC.Format(512))
+	assert.Equal("1 KiB", UnitBytesIEC.Format(int64(1024)))
+	assert.Equal("1.5 GB", UnitBytesSI.Format(1.5e9))
+	assert.Equal("42%", UnitPercent.Format(0.42))
+	assert.Equal("-12.35 kops/s", UnitOpsPerSecond.Format(-12345.0))
+	assert.Equal("0 B", UnitBytesIEC.Format(0))
+	assert.Equal("NaN", UnitBytesIEC.Format(math.NaN()))
+	assert.Equal("", UnitBytesIEC.Format("nope"))
+	assert.Equal("3", Unit{}.Format(3))
+}
+
+func unitTicks(t *testing.T, unit *Unit, min, max float64, domain int) []Tick {
+	f, err := GetDefaultFont()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := PNG(1024, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ra := &ContinuousRange{Min: min, Max: max, Domain: domain}
+	return GenerateUnitTicks(r, ra, true, Style{Font: f, FontSize: DefaultAxisFontSize}, unit, nil)
+}
+
+func TestGenerateUnitTicksBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	gib := 1024.0 * 1024 * 1024
+	ticks := unitTicks(t, UnitBytesIEC, 0, 3*gib, 300)
+	assert.NotEmpty(ticks)
+	assert.True(len(ticks) <= 300/(DefaultMinimumTickVerticalSpacing+1))
+	step := ticks[1].Value - ticks[0].Value
+	// steps are powers of two.
+	assert.Equal(math.Floor(math.Log2(step)), math.Log2(step))
+	assert.Equal("0 B", ticks[0].Label)
+	for _, tick := range ticks {
+		assert.Zero(math.Mod(tick.Value, step))
+	}
+	assert.Equal("3 GiB", ticks[len(ticks)-1].Label)
+}
+
+func TestGenerateUnitTicksPercent(t *testing.T) {
+	assert := assert.New(t)
+
+	ticks := unitTicks(t, UnitPercent, 0, 1, 100)
+	assert.Equal([]Tick{{0, "0%"}, {0.5, "50%"}, {1, "100%"}}, ticks)
+
+	ticks = unitTicks(t, UnitPercent, 0, 0.01, 400)
+	assert.Equal("0.1%", ticks[1].Label)
+}
+
+func TestGenerateUnitTicksDescendingAndDegenerate(t *testing.T) {
+	assert := assert.New(t)
+
+	f, _ := GetDefaultFont()
+	r, _ := PNG(100, 100)
+	ticks := GenerateUnitTicks(r, &ContinuousRange{Min: 0, Max: 1000, Domain: 200, Descending: true}, true, Style{Font: f, FontSize: 10}, UnitOpsPerSecond, nil)
+	assert.True(ticks[0].Value > ticks[len(ticks)-1].Value)
+
+	ticks = GenerateUnitTicks(r, &ContinuousRange{Min: 5, Max: 5, Domain: 200}, true, Style{Font: f, FontSize: 10}, UnitOpsPerSecond, nil)
+	assert.Len(ticks, 1)
+}
+
+func TestGenerateUnitTicksAxisEnd(t *testing.T) {
+	assert := assert.New(t)
+
+	f, _ := GetDefaultFont()
+	r, _ := PNG(100, 100)
+	mib := 1024.0 * 1024
+	ticks := generateUnitTicks(r, &ContinuousRange{Min: 0, Max: 768 * mib, Domain: 300}, true, Style{Font: f, FontSize: 10}, UnitBytesIEC, nil, UnitPlacementAxisEnd)
+	assert.True(len(ticks) > 2)
+	assert.Equal("0", ticks[0].Label)
+	assert.Equal("768 MiB", ticks[len(ticks)-1].Label)
+	for _, tick := range ticks[:len(ticks)-1] {
+		assert.False(strings.Contains(tick.Label, "B"))
+	}
+
+	ticks = generateUnitTicks(r, &ContinuousRange{Min: 0, Max: 1, Domain: 100, Descending: true}, true, Style{Font: f, FontSize: 10}, UnitPercent, nil, UnitPlacementAxisEnd)
+	assert.Equal("0%", ticks[len(ticks)-1].Label)
+	assert.Equal("100", ticks[0].Label)
+}
+
+func TestChartUnitAxis(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		YAxis: YAxis{Style: StyleShow(), Unit: UnitBytesIEC},
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{0, 1 << 30, 3 << 29}},
+		},
+	}
+	_, y, _ := c.getValueFormatters()
+	assert.Equal("1 GiB", y(float64(1<<30)))
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	assert.NotZero(buffer.Len())
+}
@@ -81,24 +81,32 @@ func (m mathUtil) MinAndMaxOfTime(values ...time.Time) (min time.Time, max time.
 
 // GetRoundToForDelta returns a `roundTo` value for a given delta.
 func (m mathUtil) GetRoundToForDelta(delta float64) float64 {
-	startingDeltaBound := math.Pow(10.0, 10.0)
-	for cursor := startingDeltaBound; cursor > 0; cursor /= 10.0 {
-		if delta > cursor {
-			return cursor / 10.0
-		}
+	if delta <= 0 || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return 0.0
 	}
-
-	return 0.0
+	// the largest power of ten strictly less than the delta, computed directly so
+	// very large and very small deltas don't accumulate error.
+	exponent := math.Floor(math.Log10(delta))
+	if math.Pow(10.0, exponent) >= delta {
+		exponent--
+	}
+	return math.Pow(10.0, exponent-1)
 }
 
 // RoundUp rounds up to a given roundTo value.
 func (m mathUtil) RoundUp(value, roundTo float64) float64 {
+	if roundTo == 0 {
+		return value
+	}
 	d1 := math.Ceil(value / roundTo)
 	return d1 * roundTo
 }
 
 // RoundDown rounds down to a given roundTo value.
 func (m mathUtil) RoundDown(value, roundTo float64) float64 {
+	if roundTo == 0 {
+		return value
+	}
 	d1 := math.Floor(value / roundTo)
 	return d1 * roundTo
 }
@@ -71,6 +71,11 @@ func TestGetRoundToForDelta(t *testing.T) {
 	assert.Equal(100.0, Math.GetRoundToForDelta(1001.00))
 	assert.Equal(10.0, Math.GetRoundToForDelta(101.00))
 	assert.Equal(1.0, Math.GetRoundToForDelta(11.00))
+	assert.Equal(1.0, Math.GetRoundToForDelta(100.00))
+	assert.Equal(1e9, Math.GetRoundToForDelta(2e10))
+	assert.Equal(1e12, Math.GetRoundToForDelta(5e13))
+	assert.Equal(1e-10, Math.GetRoundToForDelta(1.5e-9))
+	assert.Zero(Math.GetRoundToForDelta(0))
 }
 
 func TestRoundUp(t *testing.T) {
@@ -78,6 +83,7 @@ func TestRoundUp(t *testing.T) {
 	assert.Equal(0.5, Math.RoundUp(0.49, 0.1))
 	assert.Equal(1.0, Math.RoundUp(0.51, 1.0))
 	assert.Equal(0.4999, Math.RoundUp(0.49988, 0.0001))
+	assert.Equal(0.49, Math.RoundUp(0.49, 0))
 }
 
 func TestRoundDown(t *testing.T) {
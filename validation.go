@@ -0,0 +1,329 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+)
+
+var (
+	// ErrEmptySeries is the kind of validation error for a chart without any series, bars or values.
+	ErrEmptySeries = errors.New("empty series")
+	// ErrNoVisibleSeries is the kind of validation error for a chart whose series are all hidden.
+	ErrNoVisibleSeries = errors.New("no visible series")
+	// ErrLengthMismatch is the kind of validation error for a series with a different number of x and y values.
+	ErrLengthMismatch = errors.New("mismatched x and y lengths")
+	// ErrInvalidRange is the kind of validation error for a range that is zero, infinite or NaN.
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrInvalidCanvas is the kind of validation error for a canvas with a zero or negative size.
+	ErrInvalidCanvas = errors.New("invalid canvas size")
+	// ErrCanvasTooLarge is the kind of error for a canvas that exceeds the `DefaultCanvasLimits`.
+	ErrCanvasTooLarge = errors.New("canvas too large")
+	// ErrUnsortedXValues is the kind of validation error for a time series whose x values go back in time.
+	ErrUnsortedXValues = errors.New("unsorted x values")
+	// ErrMissingFont is the kind of validation error for a chart that draws text without a font, because the default
+	// font can't be loaded.
+	ErrMissingFont = errors.New("missing font")
+	// ErrInvalidSeries is the kind of validation error for any other error returned by a series' `Validate`.
+	ErrInvalidSeries = errors.New("invalid series")
+)
+
+// ValidationError is a structured validation error.
+// Its kind is one of the `Err*` validation errors and can be tested for with `errors.Is`.
+type ValidationError struct {
+	Kind    error
+	Message string
+
+	// SeriesIndex is the index of the series (or bar, or value) the error applies to, or -1.
+	SeriesIndex int
+	// SeriesName is the name of the series the error applies to, if it has one.
+	SeriesName string
+
+	// Cause is the underlying error, if any.
+	Cause error
+}
+
+// Error implements error.
+func (ve *ValidationError) Error() string {
+	if ve.SeriesIndex < 0 {
+		return ve.Message
+	}
+	if len(ve.SeriesName) > 0 {
+		return fmt.Sprintf("series %d (%s): %s", ve.SeriesIndex, ve.SeriesName, ve.Message)
+	}
+	return fmt.Sprintf("series %d: %s", ve.SeriesIndex, ve.Message)
+}
+
+// Unwrap returns the kind and cause of the error.
+func (ve *ValidationError) Unwrap() []error {
+	if ve.Cause != nil {
+		return []error{ve.Kind, ve.Cause}
+	}
+	return []error{ve.Kind}
+}
+
+// ValidationErrors is the list of errors returned by the chart `Validate` methods.
+type ValidationErrors []*ValidationError
+
+// Error implements error.
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, len(ve))
+	for index, err := range ve {
+		messages[index] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the individual errors.
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for index, err := range ve {
+		errs[index] = err
+	}
+	return errs
+}
+
+// asError returns the list as an error, or nil if it is empty.
+func (ve ValidationErrors) asError() error {
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
+
+func newValidationError(kind error, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Kind:        kind,
+		Message:     fmt.Sprintf(format, args...),
+		SeriesIndex: -1,
+	}
+}
+
+// seriesValidationError attributes a series' validation error to the series.
+func seriesValidationError(s Series, index int, err error) *ValidationError {
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		ve = &ValidationError{Kind: ErrInvalidSeries, Message: err.Error(), Cause: err}
+	} else {
+		copied := *ve
+		ve = &copied
+	}
+	ve.SeriesIndex = index
+	ve.SeriesName = s.GetName()
+	return ve
+}
+
+func validateCanvas(kind string, width, height int, canvasBox Box) *ValidationError {
+	if width < 0 || height < 0 {
+		return newValidationError(ErrInvalidCanvas, "%s size must not be negative; got %dx%d", kind, width, height)
+	}
+	// box width and height are absolute, so compare the edges directly.
+	if canvasBox.Right <= canvasBox.Left || canvasBox.Bottom <= canvasBox.Top {
+		return newValidationError(ErrInvalidCanvas, "%s canvas is empty after padding; got %dx%d", kind, canvasBox.Right-canvasBox.Left, canvasBox.Bottom-canvasBox.Top)
+	}
+	return nil
+}
+
+// validateFont checks that a chart that draws text has a font, loading the default font if it isn't set.
+func validateFont(font *truetype.Font, noText bool) *ValidationError {
+	if font != nil || noText {
+		return nil
+	}
+	if _, err := loadDefaultFont(); err != nil {
+		return err.(*ValidationError)
+	}
+	return nil
+}
+
+// loadDefaultFont returns the default font, or a validation error of the kind `ErrMissingFont` if it can't be loaded.
+func loadDefaultFont() (*truetype.Font, error) {
+	font, err := GetDefaultFont()
+	if err != nil {
+		return nil, &ValidationError{Kind: ErrMissingFont, Message: fmt.Sprintf("cannot load the default font: %v", err), SeriesIndex: -1, Cause: err}
+	}
+	return font, nil
+}
+
+func validateRangeDelta(name string, delta float64) *ValidationError {
+	if math.IsInf(delta, 0) {
+		return newValidationError(ErrInvalidRange, "infinite %s delta", name)
+	}
+	if math.IsNaN(delta) {
+		return newValidationError(ErrInvalidRange, "nan %s delta", name)
+	}
+	if delta == 0 {
+		return newValidationError(ErrInvalidRange, "zero %s delta", name)
+	}
+	return nil
+}
+
+// validateLogarithmicRange checks that the bounds of a range are positive if it is logarithmic.
+func validateLogarithmicRange(name string, ra Range) *ValidationError {
+	if _, isLogarithmic := ra.(*LogarithmicRange); isLogarithmic && (ra.GetMin() <= 0 || ra.GetMax() <= 0) {
+		return newValidationError(ErrInvalidRange, "logarithmic %s needs positive values", name)
+	}
+	return nil
+}
+
+// validateScaleFactor checks that a scale factor is positive and that the scaled raster is within the canvas limits.
+func validateScaleFactor(scale float64, width, height int) *ValidationError {
+	if scale <= 0 || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return newValidationError(ErrInvalidCanvas, "scale factor must be positive; got %v", scale)
+	}
+	return DefaultCanvasLimits.check(scaledSize(width, height, scale))
+}
+
+// Validate checks the chart for problems that would prevent it from rendering,
+// i.e. no series, series with mismatched x and y values, degenerate ranges or an empty canvas.
+// It returns nil or `ValidationErrors`.
+func (c Chart) Validate() error {
+	var errs ValidationErrors
+	if err := validateCanvas("chart", c.Width, c.Height, c.getDefaultCanvasBox()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateScaleFactor(c.GetScaleFactor(), c.GetWidth(), c.GetHeight()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFont(c.Font, c.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(c.Series) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one series"))
+	}
+	if err := c.checkHasVisibleSeries(); err != nil {
+		errs = append(errs, newValidationError(ErrNoVisibleSeries, "%s", err.Error()))
+	}
+
+	var seriesErrors int
+	for index, s := range c.Series {
+		if err := s.Validate(); err != nil {
+			errs = append(errs, seriesValidationError(s, index, err))
+			seriesErrors++
+		}
+	}
+
+	// the ranges read every value, which isn't safe if a series is invalid.
+	if seriesErrors == 0 {
+		xr, yr, yra := c.getRanges()
+		if err := c.checkRanges(xr, yr, yra); err != nil {
+			errs = append(errs, err.(*ValidationError))
+		}
+		if err := c.checkSecondaryXRange(c.getSecondaryXRange(xr)); err != nil {
+			errs = append(errs, err.(*ValidationError))
+		}
+	}
+	return errs.asError()
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (bc BarChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateCanvas("chart", bc.Width, bc.Height, bc.getDefaultCanvasBox()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateScaleFactor(bc.GetScaleFactor(), bc.GetWidth(), bc.GetHeight()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFont(bc.Font, bc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(bc.Bars) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one bar"))
+	}
+	for index, bar := range bc.Bars {
+		if math.IsNaN(bar.Value) || math.IsInf(bar.Value, 0) {
+			errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "value must be finite", SeriesIndex: index, SeriesName: bar.Label})
+		}
+		if index < len(bc.BarErrors) {
+			if barError := bc.BarErrors[index]; math.IsNaN(barError) || math.IsInf(barError, 0) || barError < 0 {
+				errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "error must be finite and not negative", SeriesIndex: index, SeriesName: bar.Label})
+			}
+		}
+	}
+	for index, series := range bc.Series {
+		if len(series.Values) > len(bc.Bars) {
+			errs = append(errs, &ValidationError{Kind: ErrLengthMismatch, Message: fmt.Sprintf("series has %d values but there are %d bars", len(series.Values), len(bc.Bars)), SeriesIndex: index, SeriesName: series.Name})
+		}
+		for _, value := range series.Values {
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "value must be finite", SeriesIndex: index, SeriesName: series.Name})
+				break
+			}
+		}
+	}
+	if len(errs) == 0 {
+		yr := bc.getRanges()
+		if yr.GetMax()-yr.GetMin() == 0 {
+			errs = append(errs, newValidationError(ErrInvalidRange, "invalid data range; cannot be zero"))
+		}
+	}
+	return errs.asError()
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (sbc StackedBarChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateCanvas("chart", sbc.Width, sbc.Height, sbc.getDefaultCanvasBox()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := DefaultCanvasLimits.check(sbc.GetWidth(), sbc.GetHeight()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFont(sbc.Font, sbc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(sbc.Bars) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one bar"))
+	}
+	for index, bar := range sbc.Bars {
+		if len(bar.Values) == 0 {
+			errs = append(errs, &ValidationError{Kind: ErrEmptySeries, Message: "bar must have at least one value", SeriesIndex: index, SeriesName: bar.Name})
+			continue
+		}
+		var total float64
+		for _, v := range bar.Values {
+			total += v.Value
+		}
+		if math.IsNaN(total) || math.IsInf(total, 0) {
+			errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "values must be finite", SeriesIndex: index, SeriesName: bar.Name})
+		} else if total == 0 {
+			errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "values must not sum to zero", SeriesIndex: index, SeriesName: bar.Name})
+		}
+	}
+	return errs.asError()
+}
+
+// Validate checks the chart for problems that would prevent it from rendering.
+// It returns nil or `ValidationErrors`.
+func (pc PieChart) Validate() error {
+	var errs ValidationErrors
+	if err := validateCanvas("chart", pc.Width, pc.Height, pc.getDefaultCanvasBox()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateScaleFactor(pc.GetScaleFactor(), pc.GetWidth(), pc.GetHeight()); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFont(pc.Font, pc.NoText); err != nil {
+		errs = append(errs, err)
+	}
+	if len(pc.Values) == 0 {
+		return append(errs, newValidationError(ErrEmptySeries, "please provide at least one value"))
+	}
+	var total float64
+	for index, v := range pc.Values {
+		if math.IsNaN(v.Value) || math.IsInf(v.Value, 0) || v.Value < 0 {
+			errs = append(errs, &ValidationError{Kind: ErrInvalidRange, Message: "value must be finite and not negative", SeriesIndex: index, SeriesName: v.Label})
+			continue
+		}
+		total += v.Value
+	}
+	if total == 0 {
+		errs = append(errs, newValidationError(ErrInvalidRange, "pie chart must contain at least (1) non-zero value"))
+	}
+	return errs.asError()
+}
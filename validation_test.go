@@ -0,0 +1,169 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	valid := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}},
+		},
+	}
+	assert.Nil(valid.Validate())
+
+	err := Chart{}.Validate()
+	assert.True(errors.Is(err, ErrEmptySeries))
+
+	mismatched := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}},
+			ContinuousSeries{Name: "bad", XValues: []float64{1, 2, 3}, YValues: []float64{1, 2}},
+		},
+	}
+	err = mismatched.Validate()
+	assert.True(errors.Is(err, ErrLengthMismatch))
+	var errs ValidationErrors
+	assert.True(errors.As(err, &errs))
+	assert.Len(errs, 1)
+	assert.Equal(1, errs[0].SeriesIndex)
+	assert.Equal("bad", errs[0].SeriesName)
+	assert.Equal("series 1 (bad): continuous series has 3 xvalues but 2 yvalues", errs[0].Error())
+
+	nan := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{math.NaN(), math.NaN()}},
+		},
+	}
+	assert.True(errors.Is(nan.Validate(), ErrInvalidRange))
+
+	hidden := Chart{
+		Series: []Series{
+			ContinuousSeries{Style: Style{StrokeWidth: 2}, XValues: []float64{1, 2}, YValues: []float64{1, 2}},
+		},
+	}
+	assert.True(errors.Is(hidden.Validate(), ErrNoVisibleSeries))
+
+	tiny := valid
+	tiny.Width, tiny.Height = 8, 8
+	tiny.Background = Style{Padding: Box{Top: 10, Left: 10, Right: 10, Bottom: 10}}
+	assert.True(errors.Is(tiny.Validate(), ErrInvalidCanvas))
+
+	negative := valid
+	negative.Width = -1
+	assert.True(errors.Is(negative.Validate(), ErrInvalidCanvas))
+}
+
+func TestChartValidateSeriesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		Series: []Series{
+			SMASeries{Name: "sma"},
+		},
+	}
+	err := c.Validate()
+	assert.True(errors.Is(err, ErrInvalidSeries))
+	assert.Equal("series 0 (sma): sma series requires InnerSeries to be set", err.Error())
+}
+
+func TestBarChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(BarChart{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(BarChart{Bars: []Value{{Value: 1}, {Value: 1}}}.Validate(), ErrInvalidRange))
+	assert.True(errors.Is(BarChart{Bars: []Value{{Value: 1}, {Value: math.Inf(1)}}}.Validate(), ErrInvalidRange))
+	assert.Nil(BarChart{Bars: []Value{{Value: 1}, {Value: 2}}}.Validate())
+}
+
+func TestStackedBarChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(StackedBarChart{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(StackedBarChart{Bars: []StackedBar{{Name: "empty"}}}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(StackedBarChart{Bars: []StackedBar{{Values: []Value{{Value: 0}}}}}.Validate(), ErrInvalidRange))
+	assert.Nil(StackedBarChart{Bars: []StackedBar{{Values: []Value{{Value: 1}}}}}.Validate())
+}
+
+func TestPieChartValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(PieChart{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(PieChart{Values: []Value{{Value: 0}}}.Validate(), ErrInvalidRange))
+	assert.True(errors.Is(PieChart{Values: []Value{{Value: 1}, {Value: -1}}}.Validate(), ErrInvalidRange))
+	assert.Nil(PieChart{Values: []Value{{Value: 1}}}.Validate())
+}
+
+func TestValidateMissingFont(t *testing.T) {
+	assert := assert.New(t)
+	defer SetDefaultFont(nil)
+
+	t.Setenv(DefaultFontEnvVar, filepath.Join(t.TempDir(), "missing.ttf"))
+	SetDefaultFont(nil)
+
+	c := Chart{Series: []Series{ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}}}}
+	err := c.Validate()
+	assert.True(errors.Is(err, ErrMissingFont))
+	assert.True(errors.Is(c.Render(PNG, bytes.NewBuffer(nil)), ErrMissingFont))
+	assert.True(errors.Is(PieChart{Values: []Value{{Value: 1}}}.Validate(), ErrMissingFont))
+
+	c.NoText = true
+	assert.Nil(c.Validate())
+}
+
+func TestChartValidateUnsortedTimeSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := Chart{
+		Series: []Series{
+			TimeSeries{Name: "sorted", XValues: []time.Time{start, start.Add(time.Hour)}, YValues: []float64{1, 2}},
+			TimeSeries{Name: "unsorted", XValues: []time.Time{start, start.Add(time.Hour), start}, YValues: []float64{1, 2, 3}},
+		},
+	}
+	err := c.Validate()
+	assert.True(errors.Is(err, ErrUnsortedXValues))
+	assert.Equal("series 1 (unsorted): time series xvalue 2 is before xvalue 1", err.Error())
+}
+
+func TestChartTypesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(HeatMap{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(HeatMap{Values: [][]float64{{math.NaN()}}}.Validate(), ErrInvalidRange))
+	assert.Nil(HeatMap{Values: [][]float64{{1, 2}}}.Validate())
+
+	assert.True(errors.Is(RadialBarChart{}.Validate(), ErrEmptySeries))
+	err := RadialBarChart{Values: []Value{{Value: 1}, {Label: "inf", Value: math.Inf(1)}}}.Validate()
+	assert.True(errors.Is(err, ErrInvalidRange))
+	assert.Equal("series 1 (inf): value must not be infinite", err.Error())
+
+	assert.True(errors.Is(RoseChart{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(RoseChart{Values: []Value{{Value: 0}}}.Validate(), ErrInvalidRange))
+	assert.Nil(RoseChart{Values: []Value{{Value: 1}}}.Validate())
+
+	assert.True(errors.Is(TreeMap{Nodes: []TreeMapNode{{Value: -1}}}.Validate(), ErrEmptySeries))
+	assert.Nil(TreeMap{Nodes: []TreeMapNode{{Value: 1}}}.Validate())
+
+	assert.True(errors.Is(Sparkline{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(Sparkline{Values: []float64{math.NaN()}}.Validate(), ErrInvalidRange))
+	assert.Nil(Sparkline{Values: []float64{math.NaN()}, WinLoss: true}.Validate())
+}
+
+func TestRenderErrorsAreTyped(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(Chart{}.Render(PNG, bytes.NewBuffer(nil)), ErrEmptySeries))
+	assert.True(errors.Is(BarChart{}.Render(PNG, bytes.NewBuffer(nil)), ErrEmptySeries))
+	assert.True(errors.Is(PieChart{Values: []Value{{Value: 0}}}.Render(PNG, bytes.NewBuffer(nil)), ErrInvalidRange))
+	assert.True(errors.Is(PanelGrid{}.Render(PNG, bytes.NewBuffer(nil)), ErrEmptySeries))
+}
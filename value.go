@@ -52,4 +52,17 @@ type Value2 struct {
 	Style          Style
 	Label          string
 	XValue, YValue float64
+
+	// Anchor is the side or corner of the label placed at the value, and Offset moves the label from the value
+	// by pixels; Arrow draws a line with an arrow head from the label to the value. They are used by annotations.
+	Anchor AnnotationAnchor
+	Offset Point
+	Arrow  bool
+}
+
+// Value3 is a three axis value; `ZValue` is a third dimension, i.e. the size of a bubble.
+type Value3 struct {
+	Style                  Style
+	Label                  string
+	XValue, YValue, ZValue float64
 }
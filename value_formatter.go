@@ -2,6 +2,9 @@ package chart
 
 import (
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"time"
 )
 
@@ -79,3 +82,58 @@ func FloatValueFormatterWithFormat(v interface{}, floatFormat string) string {
 	}
 	return ""
 }
+
+// FloatValueFormatterForRange returns a float formatter with enough precision to tell apart values `step` apart
+// on the range [min, max]. Very large and very small magnitudes are written in exponent notation
+// with just enough significant digits, i.e. `1.0000005e+15` or `1.25e-09`.
+func FloatValueFormatterForRange(min, max, step float64) ValueFormatter {
+	magnitude := math.Max(math.Abs(min), math.Abs(max))
+	step = math.Abs(step)
+	if !isFiniteNonZero(step) || !isFiniteNonZero(magnitude) {
+		return FloatValueFormatter
+	}
+
+	stepExponent := int(math.Floor(math.Log10(step)))
+	magnitudeExponent := int(math.Floor(math.Log10(magnitude)))
+	if magnitudeExponent < DefaultFixedNotationMaxExponent && stepExponent >= DefaultFixedNotationMinExponent {
+		decimals := 1 - stepExponent
+		if decimals < 0 {
+			decimals = 0
+		}
+		format := fmt.Sprintf("%%.%df", decimals)
+		return func(v interface{}) string {
+			return FloatValueFormatterWithFormat(v, format)
+		}
+	}
+
+	digits := magnitudeExponent - stepExponent + 2
+	return func(v interface{}) string {
+		if typed, isTyped := toFloat64(v); isTyped {
+			return strconv.FormatFloat(typed, 'g', digits, 64)
+		}
+		return ""
+	}
+}
+
+// isDefaultFloatValueFormatter returns if a formatter is `FloatValueFormatter`.
+func isDefaultFloatValueFormatter(vf ValueFormatter) bool {
+	return vf != nil && reflect.ValueOf(vf).Pointer() == reflect.ValueOf(FloatValueFormatter).Pointer()
+}
+
+func isFiniteNonZero(v float64) bool {
+	return v != 0 && !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch typed := v.(type) {
+	case float64:
+		return typed, true
+	case float32:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	}
+	return 0, false
+}
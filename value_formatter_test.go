@@ -57,3 +57,17 @@ func TestFloatValueFormatterWithFormat(t *testing.T) {
 	assert.Equal("123.456", sv)
 	assert.Equal("123.000", FloatValueFormatterWithFormat(123, "%.3f"))
 }
+
+func TestFloatValueFormatterForRange(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0.0035", FloatValueFormatterForRange(0, 0.01, 0.001)(0.0035))
+	assert.Equal("12", FloatValueFormatterForRange(0, 100, 10)(12.0))
+	assert.Equal("1.5e-09", FloatValueFormatterForRange(1e-9, 2e-9, 1e-10)(1.5e-9))
+	assert.Equal("1.0000005e+15", FloatValueFormatterForRange(1e15, 1.000001e15, 1e8)(1.0000005e15))
+	assert.Equal("3.00", FloatValueFormatterForRange(0, 0, 0)(3.0))
+
+	assert.True(isDefaultFloatValueFormatter(FloatValueFormatter))
+	assert.False(isDefaultFloatValueFormatter(PercentValueFormatter))
+	assert.False(isDefaultFloatValueFormatter(nil))
+}
@@ -1,6 +1,10 @@
 package chart
 
-import "github.com/wcharczuk/go-chart/drawing"
+import (
+	"math"
+
+	"github.com/wcharczuk/go-chart/drawing"
+)
 
 // ValuesProvider is a type that produces values.
 type ValuesProvider interface {
@@ -8,6 +12,77 @@ type ValuesProvider interface {
 	GetValues(index int) (float64, float64)
 }
 
+// BatchValuesProvider is a values provider that can copy a run of values into caller provided slices,
+// which lets render loops read values without an interface call per point.
+type BatchValuesProvider interface {
+	ValuesProvider
+	// GetValuesInto copies values starting at `start` into `xvalues` and `yvalues` and returns the count copied,
+	// which is bounded by the shorter of the two slices.
+	GetValuesInto(start int, xvalues, yvalues []float64) int
+}
+
+// GetValuesInto copies values from a provider starting at `start` into the given slices and returns the count copied.
+// It uses the provider's batch accessor if it is a `BatchValuesProvider`.
+func GetValuesInto(vp ValuesProvider, start int, xvalues, yvalues []float64) int {
+	if bvp, isBatch := vp.(BatchValuesProvider); isBatch {
+		return bvp.GetValuesInto(start, xvalues, yvalues)
+	}
+	count := valuesIntoCount(vp.Len(), start, xvalues, yvalues)
+	for index := 0; index < count; index++ {
+		xvalues[index], yvalues[index] = vp.GetValues(start + index)
+	}
+	return count
+}
+
+// valuesIntoCount returns the number of values a batch read starting at `start` can copy.
+func valuesIntoCount(length, start int, xvalues, yvalues []float64) int {
+	count := length - start
+	if len(xvalues) < count {
+		count = len(xvalues)
+	}
+	if len(yvalues) < count {
+		count = len(yvalues)
+	}
+	if count < 0 {
+		return 0
+	}
+	return count
+}
+
+// isFinitePoint returns if both values of a point are drawable, i.e. neither NaN nor infinite.
+func isFinitePoint(x, y float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0) && !math.IsNaN(y) && !math.IsInf(y, 0)
+}
+
+// firstFiniteIndex returns the index of the first drawable point, or -1.
+func firstFiniteIndex(vp ValuesProvider) int {
+	for index := 0; index < vp.Len(); index++ {
+		if isFinitePoint(vp.GetValues(index)) {
+			return index
+		}
+	}
+	return -1
+}
+
+// countNonFinite returns the number of points with a NaN or infinite value.
+func countNonFinite(vp ValuesProvider) (count int) {
+	var xvalues, yvalues [valuesBatchSize]float64
+	length := vp.Len()
+	for start := 0; start < length; {
+		read := GetValuesInto(vp, start, xvalues[:], yvalues[:])
+		if read == 0 {
+			break
+		}
+		for index := 0; index < read; index++ {
+			if !isFinitePoint(xvalues[index], yvalues[index]) {
+				count++
+			}
+		}
+		start += read
+	}
+	return
+}
+
 // BoundedValuesProvider allows series to return a range.
 type BoundedValuesProvider interface {
 	Len() int
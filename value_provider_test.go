@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+// plainValues is a values provider without a batch accessor.
+type plainValues struct {
+	ContinuousSeries
+}
+
+func (pv plainValues) Len() int {
+	return pv.ContinuousSeries.Len()
+}
+
+func (pv plainValues) GetValues(index int) (float64, float64) {
+	return pv.ContinuousSeries.GetValues(index)
+}
+
+func TestGetValuesInto(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := ContinuousSeries{
+		XValues: []float64{1, 2, 3, 4, 5},
+		YValues: []float64{10, 20, 30, 40, 50},
+	}
+	xvalues, yvalues := make([]float64, 3), make([]float64, 3)
+
+	for _, vp := range []ValuesProvider{cs, plainValues{cs}} {
+		assert.Equal(3, GetValuesInto(vp, 0, xvalues, yvalues))
+		assert.Equal([]float64{1, 2, 3}, xvalues)
+		assert.Equal([]float64{10, 20, 30}, yvalues)
+
+		assert.Equal(2, GetValuesInto(vp, 3, xvalues, yvalues))
+		assert.Equal([]float64{4, 5}, xvalues[:2])
+		assert.Equal([]float64{40, 50}, yvalues[:2])
+
+		assert.Zero(GetValuesInto(vp, 5, xvalues, yvalues))
+		assert.Zero(GetValuesInto(vp, 7, xvalues, yvalues))
+		assert.Equal(1, GetValuesInto(vp, 0, xvalues, yvalues[:1]))
+	}
+}
+
+func TestTimeSeriesGetValuesInto(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	ts := TimeSeries{
+		XValues: []time.Time{now, now.Add(time.Hour)},
+		YValues: []float64{1, 2},
+	}
+	xvalues, yvalues := make([]float64, 4), make([]float64, 4)
+	assert.Equal(1, ts.GetValuesInto(1, xvalues, yvalues))
+	// a start past the end copies nothing.
+	assert.Zero(ts.GetValuesInto(3, xvalues[1:], yvalues[1:]))
+
+	x, y := ts.GetValues(1)
+	assert.Equal(x, xvalues[0])
+	assert.Equal(y, yvalues[0])
+}
+
+func TestGetValuesIntoDoesNotAllocate(t *testing.T) {
+	assert := assert.New(t)
+
+	cs := ContinuousSeries{XValues: make([]float64, 1024), YValues: make([]float64, 1024)}
+	var vp ValuesProvider = cs
+	var xvalues, yvalues [valuesBatchSize]float64
+	allocs := testing.AllocsPerRun(10, func() {
+		for start := 0; start < vp.Len(); start += valuesBatchSize {
+			GetValuesInto(vp, start, xvalues[:], yvalues[:])
+		}
+	})
+	assert.Zero(allocs)
+}
@@ -2,9 +2,14 @@ package chart
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/xml"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"math"
+	"strconv"
 	"strings"
 
 	"golang.org/x/image/font"
@@ -16,7 +21,10 @@ import (
 
 // SVG returns a new png/raster renderer.
 func SVG(width, height int) (Renderer, error) {
-	buffer := bytes.NewBuffer([]byte{})
+	return newVectorRenderer(bytes.NewBuffer([]byte{}), width, height), nil
+}
+
+func newVectorRenderer(buffer *bytes.Buffer, width, height int) *vectorRenderer {
 	canvas := newCanvas(buffer)
 	canvas.Start(width, height)
 	return &vectorRenderer{
@@ -25,7 +33,7 @@ func SVG(width, height int) (Renderer, error) {
 		s:   &Style{},
 		p:   []string{},
 		dpi: DefaultDPI,
-	}, nil
+	}
 }
 
 // vectorRenderer renders chart commands to a bitmap.
@@ -36,6 +44,27 @@ type vectorRenderer struct {
 	s   *Style
 	p   []string
 	fc  *font.Drawer
+
+	pool *RendererPool
+
+	deterministic bool
+	// normalizedFont is the font normalized renderers lay out all text in; they write no metadata either.
+	normalizedFont *truetype.Font
+
+	accessibility *Accessibility
+	metadata      map[string]string
+
+	options SVGOptions
+	// tooltip is attached to the next shape drawn, and tooltipSeries describes the points drawn.
+	tooltip       Tooltip
+	tooltipSeries tooltipSeries
+	// ids counts the groups started with each id, to keep the ids unique.
+	ids map[string]int
+}
+
+// IsDeterministic returns if the renderer produces byte for byte stable output.
+func (vr *vectorRenderer) IsDeterministic() bool {
+	return vr.deterministic
 }
 
 func (vr *vectorRenderer) ResetStyle() {
@@ -62,6 +91,21 @@ func (vr *vectorRenderer) SetStrokeColor(c drawing.Color) {
 // SetFillColor implements the interface method.
 func (vr *vectorRenderer) SetFillColor(c drawing.Color) {
 	vr.s.FillColor = c
+	vr.s.FillGradient = nil
+	vr.s.FillPattern = nil
+}
+
+// SetFillGradient sets the gradient to fill with until the fill color is next set.
+func (vr *vectorRenderer) SetFillGradient(g *Gradient, box Box) {
+	vr.s.FillGradient = g
+	vr.c.gradientBox = box
+	vr.c.gradientID = ""
+}
+
+// SetFillPattern sets the pattern to fill with until the fill color is next set.
+func (vr *vectorRenderer) SetFillPattern(p *Pattern) {
+	vr.s.FillPattern = p
+	vr.c.patternID = ""
 }
 
 // SetLineWidth implements the interface method.
@@ -89,6 +133,11 @@ func (vr *vectorRenderer) QuadCurveTo(cx, cy, x, y int) {
 	vr.p = append(vr.p, fmt.Sprintf("Q%d,%d %d,%d", cx, cy, x, y))
 }
 
+// CubicCurveTo draws a cubic curve.
+func (vr *vectorRenderer) CubicCurveTo(c1x, c1y, c2x, c2y, x, y int) {
+	vr.p = append(vr.p, fmt.Sprintf("C%d,%d %d,%d %d,%d", c1x, c1y, c2x, c2y, x, y))
+}
+
 func (vr *vectorRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
 	startAngle = util.Math.RadianAdd(startAngle, _pi2)
 	endAngle := util.Math.RadianAdd(startAngle, delta)
@@ -115,6 +164,23 @@ func (vr *vectorRenderer) Close() {
 	vr.p = append(vr.p, fmt.Sprintf("Z"))
 }
 
+// Polygon adds a closed shape as a single path command list, i.e. `M 0 0 L 10 0 10 10 Z`.
+func (vr *vectorRenderer) Polygon(points []Point) {
+	if len(points) == 0 {
+		return
+	}
+	var d strings.Builder
+	fmt.Fprintf(&d, "M %d %d", points[0].X, points[0].Y)
+	if len(points) > 1 {
+		d.WriteString(" L")
+		for _, p := range points[1:] {
+			fmt.Fprintf(&d, " %d %d", p.X, p.Y)
+		}
+	}
+	d.WriteString(" Z")
+	vr.p = append(vr.p, d.String())
+}
+
 // Stroke draws the path with no fill.
 func (vr *vectorRenderer) Stroke() {
 	vr.drawPath(vr.s.GetStrokeOptions())
@@ -130,20 +196,65 @@ func (vr *vectorRenderer) FillStroke() {
 	vr.drawPath(vr.s.GetFillAndStrokeOptions())
 }
 
-// drawPath draws a path.
+// drawPath draws a path with the fill and stroke options of a style.
 func (vr *vectorRenderer) drawPath(s Style) {
-	vr.c.Path(strings.Join(vr.p, "\n"), vr.s.GetFillAndStrokeOptions())
+	vr.c.Path(strings.Join(vr.p, "\n"), s, vr.takeTooltip())
 	vr.p = []string{} // clear the path
 }
 
 // Circle implements the interface method.
 func (vr *vectorRenderer) Circle(radius float64, x, y int) {
-	vr.c.Circle(x, y, int(radius), vr.s.GetFillAndStrokeOptions())
+	vr.c.Circle(x, y, int(radius), vr.s.GetFillAndStrokeOptions(), vr.takeTooltip())
+}
+
+// ShowsTooltips returns if tooltips are attached to shapes (see `SVGOptions`).
+func (vr *vectorRenderer) ShowsTooltips() bool {
+	return vr.options.Tooltips
+}
+
+// SetTooltip attaches a tooltip to the next path or circle drawn.
+func (vr *vectorRenderer) SetTooltip(tooltip Tooltip) {
+	vr.tooltip = tooltip
+}
+
+// SetTooltipSeries sets the series the points drawn next belong to.
+func (vr *vectorRenderer) SetTooltipSeries(series tooltipSeries) {
+	vr.tooltipSeries = series
+}
+
+// GetTooltipSeries returns the series the points drawn belong to.
+func (vr *vectorRenderer) GetTooltipSeries() tooltipSeries {
+	return vr.tooltipSeries
+}
+
+// takeTooltip returns the tooltip of the shape being drawn, without its data unless it is written, and clears it.
+func (vr *vectorRenderer) takeTooltip() Tooltip {
+	tooltip := vr.tooltip
+	vr.tooltip = Tooltip{}
+	if !vr.options.TooltipData {
+		tooltip.X, tooltip.Y = "", ""
+	}
+	return tooltip
+}
+
+// SetClip clips what is drawn afterwards to a box, until the clip is cleared.
+func (vr *vectorRenderer) SetClip(box Box) {
+	vr.c.StartClip(box)
+}
+
+// ClearClip clears the clip.
+func (vr *vectorRenderer) ClearClip() {
+	vr.c.EndClip()
+}
+
+// DrawImage draws an image scaled into a box, inlined as a png data uri.
+func (vr *vectorRenderer) DrawImage(img image.Image, box Box) {
+	vr.c.Image(box, img)
 }
 
 // SetFont implements the interface method.
 func (vr *vectorRenderer) SetFont(f *truetype.Font) {
-	vr.s.Font = f
+	vr.s.Font = normalizedFontOr(vr.normalizedFont, f)
 }
 
 // SetFontColor implements the interface method.
@@ -161,19 +272,36 @@ func (vr *vectorRenderer) Text(body string, x, y int) {
 	vr.c.Text(x, y, body, vr.s.GetTextOptions())
 }
 
+// TextAnchored draws text aligned on x,y with `text-anchor` and `dominant-baseline`, leaving the layout
+// of the text to the viewer.
+func (vr *vectorRenderer) TextAnchored(body string, x, y int, horizontal TextHorizontalAlign, vertical TextVerticalAlign) {
+	vr.c.TextAnchored(x, y, body, vr.s.GetTextOptions(), svgTextAnchor(horizontal), svgDominantBaseline(vertical))
+}
+
 // MeasureText uses the truetype font drawer to measure the width of text.
 func (vr *vectorRenderer) MeasureText(body string) (box Box) {
 	if vr.s.GetFont() != nil {
-		vr.fc = &font.Drawer{
-			Face: truetype.NewFace(vr.s.GetFont(), &truetype.Options{
-				DPI:  vr.dpi,
-				Size: vr.s.FontSize,
-			}),
+		// deterministic renderers measure all text themselves, whatever was rendered before them.
+		var isCached bool
+		if !vr.deterministic {
+			box, isCached = DefaultTextMeasureCache.Get(TextMeasureAdvance, vr.s.GetFont(), vr.s.FontSize, vr.dpi, body)
+		}
+		if !isCached {
+			vr.fc = &font.Drawer{
+				Face: truetype.NewFace(vr.s.GetFont(), &truetype.Options{
+					DPI:  vr.dpi,
+					Size: vr.s.FontSize,
+				}),
+			}
+			// the viewer shapes and reorders the text; it is measured as it will be drawn.
+			w := vr.fc.MeasureString(visualText(body)).Ceil()
+
+			box.Right = w
+			box.Bottom = int(drawing.PointsToPixels(vr.dpi, vr.s.FontSize))
+			if !vr.deterministic {
+				DefaultTextMeasureCache.Put(TextMeasureAdvance, vr.s.GetFont(), vr.s.FontSize, vr.dpi, body, box)
+			}
 		}
-		w := vr.fc.MeasureString(body).Ceil()
-
-		box.Right = w
-		box.Bottom = int(drawing.PointsToPixels(vr.dpi, vr.s.FontSize))
 		if vr.c.textTheta == nil {
 			return
 		}
@@ -192,10 +320,61 @@ func (vr *vectorRenderer) ClearTextRotation() {
 	vr.c.textTheta = nil
 }
 
+// SetAccessibility sets the aria attributes, title and description of the svg.
+func (vr *vectorRenderer) SetAccessibility(a Accessibility) {
+	vr.accessibility = &a
+}
+
+// SetMetadata sets text metadata to write into the svg as a `<metadata>` element.
+func (vr *vectorRenderer) SetMetadata(metadata map[string]string) {
+	if vr.normalizedFont != nil {
+		return
+	}
+	vr.metadata = metadata
+}
+
+// StartGroup starts a `<g>` element with an id and class names, if the renderer writes them (see `SVGOptions`).
+// Ids are made unique by appending a count to repeated ids.
+func (vr *vectorRenderer) StartGroup(id string, classes ...string) {
+	if !vr.options.Classes {
+		return
+	}
+	if vr.ids == nil {
+		vr.ids = map[string]int{}
+	}
+	id = vr.options.IDPrefix + id
+	vr.ids[id]++
+	if count := vr.ids[id]; count > 1 {
+		id = fmt.Sprintf("%s-%d", id, count)
+	}
+	vr.c.StartGroup(id, classes)
+}
+
+// EndGroup ends the last `<g>` element started.
+func (vr *vectorRenderer) EndGroup() {
+	if !vr.options.Classes {
+		return
+	}
+	vr.c.EndGroup()
+}
+
 // Save saves the renderer's contents to a writer.
 func (vr *vectorRenderer) Save(w io.Writer) error {
+	if len(vr.metadata) > 0 {
+		writeSVGMetadata(vr.b, vr.metadata)
+	}
 	vr.c.End()
-	_, err := w.Write(vr.b.Bytes())
+	contents := vr.b.Bytes()
+	if vr.accessibility != nil {
+		buffer := bytes.NewBuffer(make([]byte, 0, len(contents)+512))
+		writeAccessibility(buffer, contents, *vr.accessibility)
+		contents = buffer.Bytes()
+	}
+	_, err := w.Write(contents)
+	if vr.pool != nil {
+		vr.pool.releaseVector(vr.b)
+		vr.b = nil
+	}
 	return err
 }
 
@@ -212,6 +391,20 @@ type canvas struct {
 	textTheta *float64
 	width     int
 	height    int
+
+	gradientBox Box
+	gradientID  string
+	gradients   int
+
+	patternID string
+	patterns  int
+
+	// clipIDs are the ids of the clip paths written for each box, and isClipped if a clipped group is open.
+	clipIDs   map[Box]string
+	isClipped bool
+
+	// idPrefix is prefixed to the ids of the definitions, like the ids of groups (see `SVGOptions.IDPrefix`).
+	idPrefix string
 }
 
 func (c *canvas) Start(width, height int) {
@@ -220,39 +413,179 @@ func (c *canvas) Start(width, height int) {
 	c.w.Write([]byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d">\n`, c.width, c.height)))
 }
 
-func (c *canvas) Path(d string, style Style) {
+func (c *canvas) Path(d string, style Style, tooltip Tooltip) {
 	var strokeDashArrayProperty string
 	if len(style.StrokeDashArray) > 0 {
-		strokeDashArrayProperty = c.getStrokeDashArray(style)
+		strokeDashArrayProperty = " " + c.getStrokeDashArray(style)
 	}
-	c.w.Write([]byte(fmt.Sprintf(`<path %s d="%s" style="%s"/>`, strokeDashArrayProperty, d, c.styleAsSVG(style))))
+	c.writeGradient(style)
+	c.writePattern(style)
+	c.w.Write([]byte(fmt.Sprintf(`<path%s d="%s" style="%s"`, strokeDashArrayProperty, d, c.styleAsSVG(style))))
+	c.endShape("path", tooltip)
 }
 
 func (c *canvas) Text(x, y int, body string, style Style) {
-	if c.textTheta == nil {
-		c.w.Write([]byte(fmt.Sprintf(`<text x="%d" y="%d" style="%s">%s</text>`, x, y, c.styleAsSVG(style), body)))
-	} else {
-		transform := fmt.Sprintf(` transform="rotate(%0.2f,%d,%d)"`, util.Math.RadiansToDegrees(*c.textTheta), x, y)
-		c.w.Write([]byte(fmt.Sprintf(`<text x="%d" y="%d" style="%s"%s>%s</text>`, x, y, c.styleAsSVG(style), transform, body)))
+	c.TextAnchored(x, y, body, style, "", "")
+}
+
+// TextAnchored writes a text element with a `text-anchor` and `dominant-baseline`, if they are set;
+// rotated text turns about x,y.
+func (c *canvas) TextAnchored(x, y int, body string, style Style, anchor, baseline string) {
+	var attributes string
+	if len(anchor) > 0 {
+		attributes += fmt.Sprintf(` text-anchor="%s"`, anchor)
+	}
+	if len(baseline) > 0 {
+		attributes += fmt.Sprintf(` dominant-baseline="%s"`, baseline)
+	}
+	if c.textTheta != nil {
+		attributes += fmt.Sprintf(` transform="rotate(%0.2f,%d,%d)"`, util.Math.RadiansToDegrees(*c.textTheta), x, y)
+	}
+	c.w.Write([]byte(fmt.Sprintf(`<text x="%d" y="%d" style="%s"%s>%s</text>`, x, y, c.styleAsSVG(style), attributes, body)))
+}
+
+func (c *canvas) Circle(x, y, r int, style Style, tooltip Tooltip) {
+	c.writeGradient(style)
+	c.writePattern(style)
+	c.w.Write([]byte(fmt.Sprintf(`<circle cx="%d" cy="%d" r="%d" style="%s"`, x, y, r, c.styleAsSVG(style))))
+	c.endShape("circle", tooltip)
+}
+
+// endShape ends the start tag of a shape element with the data attributes of its tooltip, and writes
+// the title of the tooltip as its child.
+func (c *canvas) endShape(element string, tooltip Tooltip) {
+	if tooltip.IsZero() {
+		io.WriteString(c.w, "/>")
+		return
+	}
+	if len(tooltip.X) > 0 {
+		io.WriteString(c.w, ` data-x="`)
+		xml.EscapeText(c.w, []byte(tooltip.X))
+		io.WriteString(c.w, `"`)
+	}
+	if len(tooltip.Y) > 0 {
+		io.WriteString(c.w, ` data-y="`)
+		xml.EscapeText(c.w, []byte(tooltip.Y))
+		io.WriteString(c.w, `"`)
+	}
+	if len(tooltip.Title) == 0 {
+		io.WriteString(c.w, "/>")
+		return
+	}
+	io.WriteString(c.w, "><title>")
+	xml.EscapeText(c.w, []byte(tooltip.Title))
+	io.WriteString(c.w, "</title></"+element+">")
+}
+
+// Image writes an `<image>` element of an image stretched over a box, inlined as a png data uri.
+func (c *canvas) Image(box Box, img image.Image) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		return
+	}
+	c.w.Write([]byte(fmt.Sprintf(`<image x="%d" y="%d" width="%d" height="%d" preserveAspectRatio="none" xlink:href="data:image/png;base64,%s"/>`,
+		box.Left, box.Top, box.Width(), box.Height(), base64.StdEncoding.EncodeToString(encoded.Bytes()))))
+}
+
+// StartClip starts a group clipped to a box; the clip path of the box is written once, for all the groups clipped to it.
+func (c *canvas) StartClip(box Box) {
+	c.EndClip()
+	box = Box{Top: box.Top, Left: box.Left, Right: box.Right, Bottom: box.Bottom}
+	id, hasID := c.clipIDs[box]
+	if !hasID {
+		if c.clipIDs == nil {
+			c.clipIDs = map[Box]string{}
+		}
+		id = fmt.Sprintf("%sclip-%d", c.idPrefix, len(c.clipIDs)+1)
+		c.clipIDs[box] = id
+		c.w.Write([]byte(fmt.Sprintf(`<defs><clipPath id="%s"><rect x="%d" y="%d" width="%d" height="%d"/></clipPath></defs>`, id, box.Left, box.Top, box.Width(), box.Height())))
+	}
+	c.w.Write([]byte(fmt.Sprintf(`<g clip-path="url(#%s)">`, id)))
+	c.isClipped = true
+}
+
+// EndClip ends the clipped group, if one is open.
+func (c *canvas) EndClip() {
+	if c.isClipped {
+		c.w.Write([]byte("</g>"))
+		c.isClipped = false
 	}
 }
 
-func (c *canvas) Circle(x, y, r int, style Style) {
-	c.w.Write([]byte(fmt.Sprintf(`<circle cx="%d" cy="%d" r="%d" style="%s"/>`, x, y, r, c.styleAsSVG(style))))
+// Stylesheet writes a `<style>` element of css.
+func (c *canvas) Stylesheet(css string) {
+	c.w.Write([]byte(fmt.Sprintf("<style><![CDATA[\n%s\n]]></style>", strings.Replace(css, "]]>", "]]]]><![CDATA[>", -1))))
+}
+
+// StartGroup starts a `<g>` element.
+func (c *canvas) StartGroup(id string, classes []string) {
+	if len(classes) > 0 {
+		c.w.Write([]byte(fmt.Sprintf(`<g id="%s" class="%s">`, id, strings.Join(classes, " "))))
+		return
+	}
+	c.w.Write([]byte(fmt.Sprintf(`<g id="%s">`, id)))
+}
+
+// EndGroup ends a `<g>` element.
+func (c *canvas) EndGroup() {
+	c.w.Write([]byte("</g>"))
 }
 
 func (c *canvas) End() {
 	c.w.Write([]byte("</svg>"))
 }
 
+// writeGradient writes the definition of the style's fill gradient, once for all the elements filled with it.
+// Its coordinates are in units of the gradient box, like the `objectBoundingBox` units svg uses when there is no box.
+func (c *canvas) writeGradient(s Style) {
+	if s.FillGradient == nil || len(c.gradientID) > 0 {
+		return
+	}
+	c.gradients++
+	c.gradientID = fmt.Sprintf("%sgradient-%d", c.idPrefix, c.gradients)
+
+	var units string
+	if !c.gradientBox.IsZero() {
+		b := c.gradientBox
+		units = fmt.Sprintf(` gradientUnits="userSpaceOnUse" gradientTransform="matrix(%d 0 0 %d %d %d)"`, b.Right-b.Left, b.Bottom-b.Top, b.Left, b.Top)
+	}
+
+	g := s.FillGradient
+	c.w.Write([]byte("<defs>"))
+	if g.Radial {
+		c.w.Write([]byte(fmt.Sprintf(`<radialGradient id="%s" cx="0.5" cy="0.5" r="0.5"%s>`, c.gradientID, units)))
+	} else {
+		dx, dy, halfExtent := g.getDirection()
+		c.w.Write([]byte(fmt.Sprintf(`<linearGradient id="%s" x1="%0.4f" y1="%0.4f" x2="%0.4f" y2="%0.4f"%s>`, c.gradientID, 0.5-dx*halfExtent, 0.5-dy*halfExtent, 0.5+dx*halfExtent, 0.5+dy*halfExtent, units)))
+	}
+	for _, stop := range g.Stops {
+		c.w.Write([]byte(fmt.Sprintf(`<stop offset="%0.4f" stop-color="%s"/>`, stop.Offset, stop.Color.String())))
+	}
+	if g.Radial {
+		c.w.Write([]byte("</radialGradient></defs>"))
+	} else {
+		c.w.Write([]byte("</linearGradient></defs>"))
+	}
+}
+
+// writePattern writes the definition of the style's fill pattern over its fill color, once for all the elements filled with it.
+func (c *canvas) writePattern(s Style) {
+	if s.FillPattern == nil || len(c.patternID) > 0 {
+		return
+	}
+	c.patterns++
+	c.patternID = fmt.Sprintf("%spattern-%d", c.idPrefix, c.patterns)
+	writePatternDefinition(c.w, c.patternID, *s.FillPattern, s.FillColor)
+}
+
 // getStrokeDashArray returns the stroke-dasharray property of a style.
 func (c *canvas) getStrokeDashArray(s Style) string {
 	if len(s.StrokeDashArray) > 0 {
 		var values []string
 		for _, v := range s.StrokeDashArray {
-			values = append(values, fmt.Sprintf("%0.1f", v))
+			values = append(values, formatSVGNumber(v))
 		}
-		return "stroke-dasharray=\"" + strings.Join(values, ", ") + "\""
+		return "stroke-dasharray=\"" + strings.Join(values, " ") + "\""
 	}
 	return ""
 }
@@ -280,19 +613,27 @@ func (c *canvas) styleAsSVG(s Style) string {
 	var pieces []string
 
 	if sw != 0 {
-		pieces = append(pieces, "stroke-width:"+fmt.Sprintf("%d", int(sw)))
+		pieces = append(pieces, "stroke-width:"+formatSVGNumber(sw))
 	} else {
 		pieces = append(pieces, "stroke-width:0")
 	}
 
 	if !sc.IsZero() {
 		pieces = append(pieces, "stroke:"+sc.String())
+		if sw != 0 {
+			// the raster renderer rounds the caps and joins of strokes, and so the ends of dashes.
+			pieces = append(pieces, "stroke-linecap:round;stroke-linejoin:round")
+		}
 	} else {
 		pieces = append(pieces, "stroke:none")
 	}
 
 	if !fnc.IsZero() {
 		pieces = append(pieces, "fill:"+fnc.String())
+	} else if s.FillPattern != nil {
+		pieces = append(pieces, "fill:url(#"+c.patternID+")")
+	} else if s.FillGradient != nil {
+		pieces = append(pieces, "fill:url(#"+c.gradientID+")")
 	} else if !fc.IsZero() {
 		pieces = append(pieces, "fill:"+fc.String())
 	} else {
@@ -308,3 +649,8 @@ func (c *canvas) styleAsSVG(s Style) string {
 	}
 	return strings.Join(pieces, ";")
 }
+
+// formatSVGNumber formats a length with at most two decimals and no trailing zeros.
+func formatSVGNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
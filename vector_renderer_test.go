@@ -34,6 +34,25 @@ func TestVectorRendererPath(t *testing.T) {
 	assert.True(strings.HasSuffix(raw, "</svg>"))
 }
 
+func TestVectorRendererCurvesAndPolygons(t *testing.T) {
+	assert := assert.New(t)
+
+	vr, err := SVG(100, 100)
+	assert.Nil(err)
+
+	vr.MoveTo(0, 50)
+	vr.CubicCurveTo(10, 0, 40, 0, 50, 50)
+	vr.Stroke()
+	vr.Polygon([]Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}})
+	vr.Fill()
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(vr.Save(buffer))
+	raw := buffer.String()
+	assert.True(strings.Contains(raw, `d="M 0 50`+"\n"+`C10,0 40,0 50,50"`), raw)
+	assert.True(strings.Contains(raw, `d="M 0 0 L 10 0 10 10 Z"`), raw)
+}
+
 func TestVectorRendererMeasureText(t *testing.T) {
 	assert := assert.New(t)
 
@@ -75,3 +94,26 @@ func TestCanvasStyleSVG(t *testing.T) {
 	assert.True(strings.Contains(svgString, "stroke-width:5"))
 	assert.True(strings.Contains(svgString, "fill:rgba(255,255,255,1.0)"))
 }
+
+func TestVectorRendererStrokeFidelity(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := SVG(100, 100)
+	assert.Nil(err)
+	r.SetStrokeColor(ColorBlue)
+	r.SetFillColor(ColorOrange)
+	r.SetStrokeWidth(1.5)
+	r.SetStrokeDashArray([]float64{5, 2.5})
+	r.MoveTo(0, 0)
+	r.LineTo(10, 10)
+	r.Stroke()
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	svg := buffer.String()
+	assert.True(strings.Contains(svg, `<path stroke-dasharray="5 2.5"`), svg)
+	assert.True(strings.Contains(svg, "stroke-width:1.5"), svg)
+	assert.True(strings.Contains(svg, "stroke-linecap:round"), svg)
+	// stroking a path doesn't fill it.
+	assert.True(strings.Contains(svg, "fill:none"), svg)
+}
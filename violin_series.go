@@ -0,0 +1,262 @@
+package chart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/util"
+)
+
+// Violin is the samples of one category of a violin plot.
+type Violin struct {
+	Label   string
+	Samples []float64
+}
+
+// finiteSamples returns the samples that are finite, sorted.
+func (v Violin) finiteSamples() []float64 {
+	var sorted []float64
+	for _, sample := range v.Samples {
+		if !math.IsNaN(sample) && !math.IsInf(sample, 0) {
+			sorted = append(sorted, sample)
+		}
+	}
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// ViolinSeries draws the distribution of the samples of each of its violins as a kernel density estimate,
+// mirrored about the category; violin i is drawn at x value i, so like a `BoxPlotSeries` the series is meant
+// for a `CategoryRange`, which charts use for their x axis by default if they have a violin series.
+// The density is estimated between the smallest and largest sample, and every violin is as wide at its widest.
+// Its values are the medians.
+type ViolinSeries struct {
+	Name  string
+	Style Style
+
+	// InnerBox draws a box plot inside each violin: a line between the whiskers, a box between the quartiles
+	// and a dot at the median. BoxStyle is its style; the box is filled with the stroke of the style by default.
+	InnerBox bool
+	BoxStyle Style
+
+	// Bandwidth is the bandwidth of the gaussian kernel of the density estimates, in y units;
+	// by default it is picked for each violin by Silverman's rule of thumb.
+	Bandwidth float64
+
+	// Width is the width of the violins at their widest as a share of the width of a category;
+	// it defaults to `DefaultViolinWidth`.
+	Width float64
+
+	YAxis YAxisType
+
+	YValueFormatter ValueFormatter
+
+	Violins []Violin
+}
+
+// GetName returns the name of the series.
+func (vs ViolinSeries) GetName() string {
+	return vs.Name
+}
+
+// GetStyle returns the series style.
+func (vs ViolinSeries) GetStyle() Style {
+	return vs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (vs ViolinSeries) GetYAxis() YAxisType {
+	return vs.YAxis
+}
+
+// GetWidth returns the violin width or a default.
+func (vs ViolinSeries) GetWidth() float64 {
+	if vs.Width <= 0 || vs.Width > 1 {
+		return DefaultViolinWidth
+	}
+	return vs.Width
+}
+
+// GetBandwidth returns the bandwidth of the density estimate of samples, or the bandwidth by Silverman's rule
+// of thumb; it is 0 if the samples have no spread.
+func (vs ViolinSeries) GetBandwidth(sorted []float64) float64 {
+	if vs.Bandwidth > 0 {
+		return vs.Bandwidth
+	}
+	if len(sorted) < 2 {
+		return 0
+	}
+	var mean, variance float64
+	for _, sample := range sorted {
+		mean += sample
+	}
+	mean /= float64(len(sorted))
+	for _, sample := range sorted {
+		variance += (sample - mean) * (sample - mean)
+	}
+	spread := math.Sqrt(variance / float64(len(sorted)-1))
+	if iqr := (quantile(sorted, 0.75) - quantile(sorted, 0.25)) / 1.34; iqr > 0 {
+		spread = math.Min(spread, iqr)
+	}
+	return 0.9 * spread * math.Pow(float64(len(sorted)), -0.2)
+}
+
+// GetCategories returns the labels of the violins.
+func (vs ViolinSeries) GetCategories() []string {
+	categories := make([]string, len(vs.Violins))
+	for index, v := range vs.Violins {
+		categories[index] = v.Label
+	}
+	return categories
+}
+
+// Len returns the number of violins.
+func (vs ViolinSeries) Len() int {
+	return len(vs.Violins)
+}
+
+// GetValues gets the x value and median of a violin.
+func (vs ViolinSeries) GetValues(index int) (float64, float64) {
+	sorted := vs.Violins[index].finiteSamples()
+	if len(sorted) == 0 {
+		return float64(index), math.NaN()
+	}
+	return float64(index), quantile(sorted, 0.5)
+}
+
+// GetLastValues gets the x value and median of the last violin.
+func (vs ViolinSeries) GetLastValues() (float64, float64) {
+	return vs.GetValues(len(vs.Violins) - 1)
+}
+
+// GetBounds implements BoundsProvider; the y bounds span the samples, and the x bounds are padded
+// by half a category so the first and last violins aren't cut in half.
+func (vs ViolinSeries) GetBounds() (minX, maxX, minY, maxY float64) {
+	minY, maxY = math.MaxFloat64, -math.MaxFloat64
+	for _, v := range vs.Violins {
+		if sorted := v.finiteSamples(); len(sorted) > 0 {
+			minY, maxY = math.Min(minY, sorted[0]), math.Max(maxY, sorted[len(sorted)-1])
+		}
+	}
+	return -0.5, float64(len(vs.Violins)) - 0.5, minY, maxY
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (vs ViolinSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x, y = FloatValueFormatter, FloatValueFormatter
+	if vs.YValueFormatter != nil {
+		y = vs.YValueFormatter
+	}
+	return
+}
+
+// violinProfile is the density of a violin at evenly spaced values from its smallest to its largest sample,
+// scaled so the largest density is 1.
+type violinProfile struct {
+	Values  []float64
+	Density []float64
+}
+
+// getProfile estimates the density of sorted samples with a gaussian kernel.
+func (vs ViolinSeries) getProfile(sorted []float64) (profile violinProfile) {
+	bandwidth := vs.GetBandwidth(sorted)
+	min, max := sorted[0], sorted[len(sorted)-1]
+	if bandwidth <= 0 || max <= min {
+		return
+	}
+	var peak float64
+	for index := 0; index < DefaultViolinResolution; index++ {
+		value := min + (max-min)*float64(index)/float64(DefaultViolinResolution-1)
+		var density float64
+		for _, sample := range sorted {
+			u := (value - sample) / bandwidth
+			density += math.Exp(-u * u / 2)
+		}
+		profile.Values = append(profile.Values, value)
+		profile.Density = append(profile.Density, density)
+		peak = math.Max(peak, density)
+	}
+	for index := range profile.Density {
+		profile.Density[index] /= peak
+	}
+	return
+}
+
+// Render renders the series.
+func (vs ViolinSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := vs.Style.InheritFrom(vs.styleDefaultsViolin(defaults))
+	boxStyle := vs.BoxStyle.InheritFrom(Style{StrokeColor: style.StrokeColor, StrokeWidth: style.StrokeWidth, FillColor: style.StrokeColor, DotColor: drawing.ColorWhite})
+
+	categoryWidth := math.Abs(float64(xrange.Translate(1) - xrange.Translate(0)))
+	halfWidth := categoryWidth * vs.GetWidth() / 2
+	boxHalfWidth := util.Math.MaxInt(1, int(categoryWidth*DefaultViolinBoxWidth)>>1)
+	translateY := func(value float64) int {
+		return canvasBox.Bottom - yrange.Translate(value)
+	}
+
+	for index, v := range vs.Violins {
+		sorted := v.finiteSamples()
+		if len(sorted) == 0 {
+			continue
+		}
+		x := canvasBox.Left + xrange.Translate(float64(index))
+
+		if profile := vs.getProfile(sorted); len(profile.Values) > 0 {
+			outline := make([]Point, 0, 2*len(profile.Values))
+			for i, value := range profile.Values {
+				outline = append(outline, Point{X: x + int(math.Round(profile.Density[i]*halfWidth)), Y: translateY(value)})
+			}
+			for i := len(profile.Values) - 1; i >= 0; i-- {
+				outline = append(outline, Point{X: x - int(math.Round(profile.Density[i]*halfWidth)), Y: translateY(profile.Values[i])})
+			}
+			style.GetFillAndStrokeOptions().WriteToRenderer(r)
+			r.Polygon(outline)
+			r.FillStroke()
+		}
+
+		if vs.InnerBox {
+			box := NewBoxPlot(v.Label, sorted...)
+			boxStyle.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+			r.MoveTo(x, translateY(box.Min))
+			r.LineTo(x, translateY(box.Max))
+			r.Stroke()
+
+			q1Y, q3Y := translateY(box.Q1), translateY(box.Q3)
+			Draw.Box(r, Box{
+				Top:    util.Math.MinInt(q1Y, q3Y),
+				Left:   x - boxHalfWidth,
+				Right:  x + boxHalfWidth,
+				Bottom: util.Math.MaxInt(q1Y, q3Y),
+			}, boxStyle)
+
+			r.SetFillColor(boxStyle.GetDotColor())
+			r.SetStrokeColor(boxStyle.GetDotColor())
+			r.SetStrokeWidth(0)
+			MarkerCircle(r, x, translateY(box.Median), math.Max(1, float64(boxHalfWidth)/2))
+		}
+	}
+}
+
+// styleDefaultsViolin returns the default violin style; the violins are filled with a light shade of the series color.
+func (vs ViolinSeries) styleDefaultsViolin(defaults Style) Style {
+	color := defaults.GetStrokeColor(drawing.ColorBlack)
+	return Style{
+		StrokeColor: color,
+		StrokeWidth: 1.5,
+		FillColor:   color.WithAlpha(64),
+	}
+}
+
+// Validate validates the series.
+func (vs ViolinSeries) Validate() error {
+	if len(vs.Violins) == 0 {
+		return newValidationError(ErrEmptySeries, "violin series must have violins set")
+	}
+	for index, v := range vs.Violins {
+		if len(v.finiteSamples()) == 0 {
+			return newValidationError(ErrEmptySeries, "violin series has a violin at index %d without finite samples", index)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestViolinSeriesGetBandwidth(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := ViolinSeries{}
+	sorted := Violin{Samples: []float64{5, 1, 3, 2, 4, math.NaN()}}.finiteSamples()
+	assert.Equal([]float64{1, 2, 3, 4, 5}, sorted)
+
+	// the interquartile range of 2 over 1.34 is less than the sample deviation of sqrt(2.5).
+	assert.InDelta(0.9*(2/1.34)*math.Pow(5, -0.2), vs.GetBandwidth(sorted), 1e-9)
+	assert.Zero(vs.GetBandwidth([]float64{3}))
+	assert.Equal(0.5, ViolinSeries{Bandwidth: 0.5}.GetBandwidth(sorted))
+}
+
+func TestViolinSeriesGetProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	profile := ViolinSeries{}.getProfile([]float64{1, 2, 2, 3})
+	assert.Len(profile.Values, DefaultViolinResolution)
+	assert.Equal(1.0, profile.Values[0])
+	assert.Equal(3.0, profile.Values[len(profile.Values)-1])
+
+	// the samples are symmetric about 2, so the density peaks in the middle and matches at the ends.
+	var peak float64
+	for _, density := range profile.Density {
+		peak = math.Max(peak, density)
+	}
+	assert.Equal(1.0, peak)
+	assert.InDelta(profile.Density[0], profile.Density[len(profile.Density)-1], 1e-9)
+	assert.True(profile.Density[0] < profile.Density[DefaultViolinResolution/2])
+
+	assert.Empty(ViolinSeries{}.getProfile([]float64{2, 2}).Values)
+}
+
+func TestViolinSeriesGetBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := ViolinSeries{Violins: []Violin{
+		{Label: "a", Samples: []float64{1, 2, 3}},
+		{Label: "b", Samples: []float64{-2, 4, 9, math.Inf(1)}},
+	}}
+	minX, maxX, minY, maxY := vs.GetBounds()
+	assert.Equal(-0.5, minX)
+	assert.Equal(1.5, maxX)
+	assert.Equal(-2.0, minY)
+	assert.Equal(9.0, maxY)
+
+	x, y := vs.GetLastValues()
+	assert.Equal(1.0, x)
+	assert.Equal(4.0, y)
+}
+
+func TestViolinSeriesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(ViolinSeries{}.Validate(), ErrEmptySeries))
+	assert.True(errors.Is(ViolinSeries{Violins: []Violin{{Label: "a", Samples: []float64{math.NaN()}}}}.Validate(), ErrEmptySeries))
+	assert.Nil(ViolinSeries{Violins: []Violin{{Label: "a", Samples: []float64{1, 2, 3}}}}.Validate())
+}
+
+func TestViolinSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		XAxis: XAxis{Style: StyleShow()},
+		YAxis: YAxis{Style: StyleShow()},
+		Series: []Series{
+			ViolinSeries{
+				InnerBox: true,
+				Violins: []Violin{
+					{Label: "a", Samples: []float64{1, 2, 2, 3, 3, 3, 4, 4, 5}},
+					{Label: "b", Samples: []float64{2, 3, 4, 5, 6}},
+					{Label: "c", Samples: []float64{4}},
+				},
+			},
+		},
+	}
+
+	xr, _, _ := c.getRanges()
+	cr, isCategoryRange := xr.(*CategoryRange)
+	assert.True(isCategoryRange)
+	assert.Equal([]string{"a", "b", "c"}, cr.Categories)
+	assert.Equal(-0.5, cr.GetMin())
+	assert.Equal(2.5, cr.GetMax())
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(PNG, buffer))
+	buffer.Reset()
+	assert.Nil(c.Render(SVG, buffer))
+}
@@ -0,0 +1,150 @@
+package chart
+
+import (
+	"image"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// Watermark is text drawn across a chart, i.e. "CONFIDENTIAL", once in the middle or tiled over the whole chart.
+// It is drawn over the canvas fill and under the series, unless it is `OnTop`.
+type Watermark struct {
+	Text string
+	// Style is the style of the text; its font size defaults to `DefaultWatermarkFontSize` and its font color
+	// to the text color of the palette.
+	Style Style
+	// Opacity is the opacity of the text from 0 to 1; it defaults to `DefaultWatermarkOpacity`.
+	Opacity float64
+	// Rotation is the angle of the text in degrees, clockwise; 0 is horizontal.
+	Rotation float64
+	// Tiled repeats the text in staggered rows over the whole chart, rather than drawing it once in the middle.
+	Tiled bool
+	// OnTop draws the text over the series, the title and the legend, so nothing hides it.
+	OnTop bool
+}
+
+// IsZero returns if the watermark has no text.
+func (wm Watermark) IsZero() bool {
+	return len(wm.Text) == 0
+}
+
+// GetOpacity returns the opacity or a default.
+func (wm Watermark) GetOpacity() float64 {
+	if wm.Opacity <= 0 {
+		return DefaultWatermarkOpacity
+	}
+	return math.Min(wm.Opacity, 1)
+}
+
+// getStyle returns the text style of the watermark, with its opacity applied to the font color.
+func (wm Watermark) getStyle(font *truetype.Font, palette ColorPalette) Style {
+	s := wm.Style.InheritFrom(Style{
+		Font:      font,
+		FontSize:  DefaultWatermarkFontSize,
+		FontColor: palette.TextColor(),
+	})
+	s.FontColor = s.FontColor.WithAlpha(uint8(float64(s.FontColor.A) * wm.GetOpacity()))
+	s.TextRotationDegrees = wm.Rotation
+	return withTextAnchor(s, TextHorizontalAlignCenter, TextVerticalAlignMiddle)
+}
+
+// draw draws the watermark over a chart box, if it is drawn on top or not as `onTop` is.
+func (wm Watermark) draw(r Renderer, box Box, font *truetype.Font, palette ColorPalette, onTop bool) {
+	if wm.IsZero() || wm.OnTop != onTop {
+		return
+	}
+	s := wm.getStyle(font, palette)
+	cx, cy := box.Center()
+	if !wm.Tiled {
+		Draw.TextAnchored(r, wm.Text, cx, cy, s)
+		return
+	}
+
+	for _, p := range wm.getTiles(r, box, s) {
+		Draw.TextAnchored(r, wm.Text, p.X, p.Y, s)
+	}
+}
+
+// getTiles returns the centers of the copies of tiled text over a box: rows of copies a rotated text box
+// and a font size apart, with every other row shifted by half a copy, spreading out from the middle of the box.
+func (wm Watermark) getTiles(r Renderer, box Box, s Style) (tiles []Point) {
+	tb := Draw.MeasureText(r, wm.Text, Style{Font: s.Font, FontSize: s.FontSize})
+	theta := util.Math.DegreesToRadians(wm.Rotation)
+	sin, cos := math.Abs(math.Sin(theta)), math.Abs(math.Cos(theta))
+	w, h := float64(tb.Width()), float64(tb.Height())
+	spacing := s.FontSize
+	stepX := int(math.Ceil(w*cos + h*sin + spacing))
+	stepY := int(math.Ceil(w*sin + h*cos + spacing))
+	if stepX <= 0 || stepY <= 0 {
+		return nil
+	}
+
+	cx, cy := box.Center()
+	rows := box.Height()/(stepY<<1) + 1
+	columns := box.Width()/(stepX<<1) + 1
+	for row := -rows; row <= rows; row++ {
+		offset := 0
+		if row%2 != 0 {
+			offset = stepX >> 1
+		}
+		for column := -columns - 1; column <= columns; column++ {
+			tiles = append(tiles, Point{X: cx + column*stepX + offset, Y: cy + row*stepY})
+		}
+	}
+	return
+}
+
+// ImageFit is how a background image is fit into the chart.
+type ImageFit int
+
+const (
+	// ImageFitStretch stretches the image over the whole chart. It is the default.
+	ImageFitStretch ImageFit = iota
+	// ImageFitContain scales the image to fit inside the chart, keeping its aspect ratio.
+	ImageFitContain
+	// ImageFitCover scales the image to cover the whole chart, keeping its aspect ratio; it is cropped to the chart.
+	ImageFitCover
+	// ImageFitCenter draws the image at its own size in the middle of the chart.
+	ImageFitCenter
+)
+
+// BackgroundImage is an image drawn over the background fill of a chart, i.e. a logo or a texture. It is drawn
+// under the canvas, so it only shows through the canvas if the canvas fill is transparent, i.e. `ColorTransparent`.
+// It is drawn by renderers that can draw images: the raster and svg renderers.
+type BackgroundImage struct {
+	Image image.Image
+	Fit   ImageFit
+}
+
+// getBox returns the box the image is drawn into over a box.
+func (bi BackgroundImage) getBox(box Box) Box {
+	size := bi.Image.Bounds().Size()
+	if size.X <= 0 || size.Y <= 0 || bi.Fit == ImageFitStretch {
+		return box
+	}
+	width, height := float64(size.X), float64(size.Y)
+	switch bi.Fit {
+	case ImageFitContain, ImageFitCover:
+		scaleX, scaleY := float64(box.Width())/width, float64(box.Height())/height
+		scale := math.Min(scaleX, scaleY)
+		if bi.Fit == ImageFitCover {
+			scale = math.Max(scaleX, scaleY)
+		}
+		width, height = width*scale, height*scale
+	}
+	cx, cy := box.Center()
+	left, top := cx-int(math.Round(width/2)), cy-int(math.Round(height/2))
+	return Box{Top: top, Left: left, Right: left + int(math.Round(width)), Bottom: top + int(math.Round(height))}
+}
+
+// draw draws the image over a box.
+func (bi BackgroundImage) draw(r Renderer, box Box) {
+	if bi.Image == nil {
+		return
+	}
+	setClip(r, box)
+	drawImage(r, bi.Image, bi.getBox(box))
+	clearClip(r)
+}
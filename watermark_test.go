@@ -0,0 +1,105 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+func TestWatermarkGetStyle(t *testing.T) {
+	assert := assert.New(t)
+
+	s := Watermark{Text: "DRAFT", Rotation: -45}.getStyle(nil, DefaultColorPalette)
+	assert.Equal(DefaultWatermarkFontSize, s.FontSize)
+	assert.Equal(uint8(float64(DefaultColorPalette.TextColor().A)*DefaultWatermarkOpacity), s.FontColor.A)
+	assert.Equal(-45.0, s.TextRotationDegrees)
+
+	s = Watermark{Text: "DRAFT", Opacity: 2, Style: Style{FontColor: ColorRed}}.getStyle(nil, DefaultColorPalette)
+	assert.Equal(ColorRed, s.FontColor)
+}
+
+func TestWatermarkGetTiles(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(400, 300)
+	assert.Nil(err)
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	wm := Watermark{Text: "CONFIDENTIAL", Rotation: -30, Tiled: true}
+	box := Box{Right: 400, Bottom: 300}
+	tiles := wm.getTiles(r, box, wm.getStyle(f, DefaultColorPalette))
+	assert.NotZero(len(tiles))
+
+	// the tiles reach past every side of the box.
+	left, top, right, bottom := box.Right, box.Bottom, box.Left, box.Top
+	for _, tile := range tiles {
+		left, right = util.Math.MinInt(left, tile.X), util.Math.MaxInt(right, tile.X)
+		top, bottom = util.Math.MinInt(top, tile.Y), util.Math.MaxInt(bottom, tile.Y)
+	}
+	assert.True(left <= box.Left && top <= box.Top && right >= box.Right && bottom >= box.Bottom)
+}
+
+func TestBackgroundImageGetBox(t *testing.T) {
+	assert := assert.New(t)
+
+	box := Box{Right: 400, Bottom: 200}
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	assert.Equal(box, BackgroundImage{Image: img}.getBox(box))
+	assert.Equal(Box{Left: 100, Right: 300, Bottom: 200}, BackgroundImage{Image: img, Fit: ImageFitContain}.getBox(box))
+	assert.Equal(Box{Top: -100, Right: 400, Bottom: 300}, BackgroundImage{Image: img, Fit: ImageFitCover}.getBox(box))
+	assert.Equal(Box{Top: 50, Left: 150, Right: 250, Bottom: 150}, BackgroundImage{Image: img, Fit: ImageFitCenter}.getBox(box))
+}
+
+func TestChartWatermark(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Chart{
+		BackgroundImage: BackgroundImage{Image: image.NewRGBA(image.Rect(0, 0, 10, 10))},
+		Watermark:       Watermark{Text: "CONFIDENTIAL"},
+		Series:          []Series{ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 3, 2}}},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(c.Render(SVG, buffer))
+	under := buffer.String()
+	assert.True(strings.Contains(under, "<image"), under)
+	assert.Equal(1, strings.Count(under, "CONFIDENTIAL"))
+	// the watermark is drawn before the series.
+	assert.True(strings.Index(under, "CONFIDENTIAL") < strings.Index(under, "stroke:rgba(0,116,217"), under)
+
+	c.Watermark.OnTop = true
+	buffer.Reset()
+	assert.Nil(c.Render(SVG, buffer))
+	over := buffer.String()
+	assert.True(strings.Index(over, "CONFIDENTIAL") > strings.Index(over, "stroke:rgba(0,116,217"), over)
+}
+
+func TestChartTypesWatermark(t *testing.T) {
+	assert := assert.New(t)
+
+	wm := Watermark{Text: "CONFIDENTIAL", Tiled: true, Rotation: -45}
+	values := []Value{{Label: "a", Value: 1}, {Label: "b", Value: 2}}
+	charts := []interface {
+		Render(RendererProvider, io.Writer) error
+	}{
+		BarChart{Watermark: wm, Bars: values},
+		StackedBarChart{Watermark: wm, Bars: []StackedBar{{Name: "a", Values: values}}},
+		PieChart{Watermark: wm, Values: values},
+		RoseChart{Watermark: wm, Values: values},
+		RadialBarChart{Watermark: wm, Values: values},
+		FunnelChart{Watermark: wm, Values: values},
+		TreeMap{Watermark: wm, Nodes: []TreeMapNode{{Label: "a", Value: 1}, {Label: "b", Value: 2}}},
+		HeatMap{Watermark: wm, Values: [][]float64{{1, 2}, {3, 4}}},
+		PolarChart{Watermark: wm, Series: []PolarSeries{{Angles: []float64{0, 90, 180}, Radii: []float64{1, 2, 3}}}},
+	}
+	for _, c := range charts {
+		buffer := bytes.NewBuffer(nil)
+		assert.Nil(c.Render(SVG, buffer))
+		assert.True(strings.Count(buffer.String(), "CONFIDENTIAL") > 1)
+	}
+}
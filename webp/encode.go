@@ -0,0 +1,415 @@
+// Package webp encodes images as lossless webp, which the standard library and `golang.org/x/image/webp` can only
+// decode.
+//
+// Images are encoded with the subtract green and predictor transforms and LZ77 backward references, which suit
+// the flat areas and repeated rows of charts; a line chart typically comes out about half the size of its png.
+package webp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"math/bits"
+)
+
+const (
+	// MaxSize is the largest width and height of a webp image.
+	MaxSize = 1 << 14
+
+	// predictorBits is the log 2 of the size of the tiles the predictor is chosen for.
+	predictorBits = 4
+
+	minMatchLength = 3
+	maxMatchLength = 4096
+	// maxDistance is the largest distance of a backward reference; distance codes are offset by 120.
+	maxDistance = 1<<20 - 120
+	// maxChainLength is the number of earlier positions tried for a backward reference.
+	maxChainLength = 32
+	hashBits       = 16
+
+	literalCodes  = 256
+	lengthCodes   = 24
+	distanceCodes = 40
+)
+
+const (
+	transformPredictor     = 0
+	transformSubtractGreen = 2
+)
+
+// predictors are the predictor modes tried for each tile: L, T, the average of L and T, and Select(L, T, TL).
+var predictors = []uint32{1, 2, 7, 11}
+
+// distanceOffsets are the offsets in columns and rows of the 120 short distance codes, as `(8-x) | y<<4`.
+var distanceOffsets = [120]uint8{
+	0x18, 0x07, 0x17, 0x19, 0x28, 0x06, 0x27, 0x29, 0x16, 0x1a,
+	0x26, 0x2a, 0x38, 0x05, 0x37, 0x39, 0x15, 0x1b, 0x36, 0x3a,
+	0x25, 0x2b, 0x48, 0x04, 0x47, 0x49, 0x14, 0x1c, 0x35, 0x3b,
+	0x46, 0x4a, 0x24, 0x2c, 0x58, 0x45, 0x4b, 0x34, 0x3c, 0x03,
+	0x57, 0x59, 0x13, 0x1d, 0x56, 0x5a, 0x23, 0x2d, 0x44, 0x4c,
+	0x55, 0x5b, 0x33, 0x3d, 0x68, 0x02, 0x67, 0x69, 0x12, 0x1e,
+	0x66, 0x6a, 0x22, 0x2e, 0x54, 0x5c, 0x43, 0x4d, 0x65, 0x6b,
+	0x32, 0x3e, 0x78, 0x01, 0x77, 0x79, 0x53, 0x5d, 0x11, 0x1f,
+	0x64, 0x6c, 0x42, 0x4e, 0x76, 0x7a, 0x21, 0x2f, 0x75, 0x7b,
+	0x31, 0x3f, 0x63, 0x6d, 0x52, 0x5e, 0x00, 0x74, 0x7c, 0x41,
+	0x4f, 0x10, 0x20, 0x62, 0x6e, 0x30, 0x73, 0x7d, 0x51, 0x5f,
+	0x40, 0x72, 0x7e, 0x61, 0x6f, 0x50, 0x71, 0x7f, 0x60, 0x70,
+}
+
+// Encode writes an image to a writer as a lossless webp.
+func Encode(w io.Writer, m image.Image) error {
+	bounds := m.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > MaxSize || height > MaxSize {
+		return fmt.Errorf("webp: invalid image size %dx%d; the width and height must be from 1 to %d", width, height, MaxSize)
+	}
+	pixels, hasAlpha := toARGB(m)
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3)
+
+	// transforms are undone in the reverse of the order they are written.
+	bw.write(1, 1)
+	bw.write(transformSubtractGreen, 2)
+	subtractGreen(pixels)
+
+	bw.write(1, 1)
+	bw.write(transformPredictor, 2)
+	bw.write(predictorBits-2, 3)
+	modes, tiles := predict(pixels, width, height)
+	writeImage(bw, modes, tiles, false)
+	bw.write(0, 1)
+
+	writeImage(bw, pixels, width, true)
+	return writeRIFF(w, bw.bytes())
+}
+
+// writeRIFF writes the webp container around a vp8l bitstream.
+func writeRIFF(w io.Writer, data []byte) error {
+	chunkSize := len(data)
+	padding := chunkSize & 1
+	header := make([]byte, 20)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(4+8+chunkSize+padding))
+	copy(header[8:12], "WEBP")
+	copy(header[12:16], "VP8L")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(chunkSize))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if padding > 0 {
+		data = append(data, 0)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// toARGB returns the pixels of an image as non premultiplied ARGB, and if any of them aren't opaque.
+func toARGB(m image.Image) ([]uint32, bool) {
+	bounds := m.Bounds()
+	nrgba, isNRGBA := m.(*image.NRGBA)
+	if !isNRGBA {
+		nrgba = image.NewNRGBA(bounds)
+		draw.Draw(nrgba, bounds, m, bounds.Min, draw.Src)
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]uint32, 0, width*height)
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		row := nrgba.Pix[nrgba.PixOffset(bounds.Min.X, bounds.Min.Y+y):]
+		for x := 0; x < width; x++ {
+			r, g, b, a := row[4*x], row[4*x+1], row[4*x+2], row[4*x+3]
+			hasAlpha = hasAlpha || a != 0xff
+			pixels = append(pixels, uint32(a)<<24|uint32(r)<<16|uint32(g)<<8|uint32(b))
+		}
+	}
+	return pixels, hasAlpha
+}
+
+// subtractGreen subtracts the green of each pixel from its red and blue.
+func subtractGreen(pixels []uint32) {
+	for index, argb := range pixels {
+		green := (argb >> 8) & 0xff
+		pixels[index] = subPixels(argb, green<<16|green)
+	}
+}
+
+// predict replaces pixels with their residuals from the predictor of their tile that leaves the smallest,
+// and returns the modes of the tiles as an image, and its width.
+func predict(pixels []uint32, width, height int) ([]uint32, int) {
+	size := 1 << predictorBits
+	tilesX, tilesY := (width+size-1)>>predictorBits, (height+size-1)>>predictorBits
+	modes := make([]uint32, tilesX*tilesY)
+	source := make([]uint32, len(pixels))
+	copy(source, pixels)
+
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			x0, y0 := tx<<predictorBits, ty<<predictorBits
+			x1, y1 := x0+size, y0+size
+			if x1 > width {
+				x1 = width
+			}
+			if y1 > height {
+				y1 = height
+			}
+
+			mode, best := predictors[0], -1
+			for _, candidate := range predictors {
+				cost := 0
+				for y := y0; y < y1; y++ {
+					for x := x0; x < x1; x++ {
+						cost += residualCost(subPixels(source[y*width+x], prediction(source, width, x, y, candidate)))
+					}
+				}
+				if best < 0 || cost < best {
+					mode, best = candidate, cost
+				}
+			}
+			modes[ty*tilesX+tx] = 0xff000000 | mode<<8
+
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					pixels[y*width+x] = subPixels(source[y*width+x], prediction(source, width, x, y, mode))
+				}
+			}
+		}
+	}
+	return modes, tilesX
+}
+
+// prediction returns the prediction of a pixel by a mode; the first pixel is predicted as opaque black,
+// the rest of the first row by the pixel to the left and the first column by the pixel above.
+func prediction(pixels []uint32, width, x, y int, mode uint32) uint32 {
+	index := y*width + x
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return pixels[index-1]
+	case x == 0:
+		return pixels[index-width]
+	}
+	left, top, topLeft := pixels[index-1], pixels[index-width], pixels[index-width-1]
+	switch mode {
+	case 1:
+		return left
+	case 2:
+		return top
+	case 7:
+		return average2(left, top)
+	}
+	if channelDistance(topLeft, top) < channelDistance(topLeft, left) {
+		return left
+	}
+	return top
+}
+
+// residualCost estimates the cost of coding a residual as the sum of the distances of its channels from zero.
+func residualCost(residual uint32) (cost int) {
+	for shift := uint(0); shift < 32; shift += 8 {
+		channel := int(int8(residual >> shift))
+		if channel < 0 {
+			channel = -channel
+		}
+		cost += channel
+	}
+	return
+}
+
+// subPixels subtracts the channels of two pixels modulo 256.
+func subPixels(a, b uint32) uint32 {
+	alphaAndGreen := 0x00ff00ff + (a & 0xff00ff00) - (b & 0xff00ff00)
+	redAndBlue := 0xff00ff00 + (a & 0x00ff00ff) - (b & 0x00ff00ff)
+	return alphaAndGreen&0xff00ff00 | redAndBlue&0x00ff00ff
+}
+
+// average2 returns the averages of the channels of two pixels, rounded down.
+func average2(a, b uint32) uint32 {
+	return ((a^b)&0xfefefefe)>>1 + a&b
+}
+
+// channelDistance returns the sum of the differences of the channels of two pixels.
+func channelDistance(a, b uint32) (distance int) {
+	for shift := uint(0); shift < 32; shift += 8 {
+		d := int((a>>shift)&0xff) - int((b>>shift)&0xff)
+		if d < 0 {
+			d = -d
+		}
+		distance += d
+	}
+	return
+}
+
+// symbol is a literal pixel, or a backward reference to copy a length of pixels from a distance back.
+type symbol struct {
+	argb     uint32
+	length   int
+	distance int
+}
+
+// writeImage writes the pixels of an image, or of the image of a transform, coded with a single group
+// of huffman codes.
+func writeImage(bw *bitWriter, pixels []uint32, width int, topLevel bool) {
+	// no color cache.
+	bw.write(0, 1)
+	if topLevel {
+		// no meta prefix codes.
+		bw.write(0, 1)
+	}
+
+	symbols := backwardReferences(pixels, width)
+	shortCodes := shortDistanceCodes(width)
+	green, red, blue := make([]int, literalCodes+lengthCodes), make([]int, literalCodes), make([]int, literalCodes)
+	alpha, distance := make([]int, literalCodes), make([]int, distanceCodes)
+	for _, s := range symbols {
+		if s.length == 0 {
+			green[(s.argb>>8)&0xff]++
+			red[(s.argb>>16)&0xff]++
+			blue[s.argb&0xff]++
+			alpha[s.argb>>24]++
+			continue
+		}
+		lengthPrefix, _, _ := prefixEncode(s.length)
+		green[literalCodes+lengthPrefix]++
+		distancePrefix, _, _ := prefixEncode(distanceCode(s.distance, shortCodes))
+		distance[distancePrefix]++
+	}
+
+	codes := [5]huffmanCode{
+		newHuffmanCode(green, maxCodeLength),
+		newHuffmanCode(red, maxCodeLength),
+		newHuffmanCode(blue, maxCodeLength),
+		newHuffmanCode(alpha, maxCodeLength),
+		newHuffmanCode(distance, maxCodeLength),
+	}
+	for _, code := range codes {
+		code.write(bw)
+	}
+
+	for _, s := range symbols {
+		if s.length == 0 {
+			codes[0].writeSymbol(bw, int((s.argb>>8)&0xff))
+			codes[1].writeSymbol(bw, int((s.argb>>16)&0xff))
+			codes[2].writeSymbol(bw, int(s.argb&0xff))
+			codes[3].writeSymbol(bw, int(s.argb>>24))
+			continue
+		}
+		prefix, extraBits, extra := prefixEncode(s.length)
+		codes[0].writeSymbol(bw, literalCodes+prefix)
+		bw.write(uint32(extra), uint(extraBits))
+		prefix, extraBits, extra = prefixEncode(distanceCode(s.distance, shortCodes))
+		codes[4].writeSymbol(bw, prefix)
+		bw.write(uint32(extra), uint(extraBits))
+	}
+}
+
+// backwardReferences returns the pixels as literals and backward references to earlier runs of the same pixels,
+// found by trying the pixel to the left, the pixel above, and the earlier positions with the same next two pixels.
+func backwardReferences(pixels []uint32, width int) []symbol {
+	count := len(pixels)
+	head := make([]int32, 1<<hashBits)
+	for index := range head {
+		head[index] = -1
+	}
+	chain := make([]int32, count)
+	hash := func(index int) uint32 {
+		return (pixels[index]*0x1e35a7bd ^ pixels[index+1]*0x9e3779b1) >> (32 - hashBits)
+	}
+	insert := func(index int) {
+		if index+1 < count {
+			h := hash(index)
+			chain[index] = head[h]
+			head[h] = int32(index)
+		}
+	}
+	matchLength := func(index, from int) int {
+		length := 0
+		for index+length < count && length < maxMatchLength && pixels[index+length] == pixels[from+length] {
+			length++
+		}
+		return length
+	}
+
+	var symbols []symbol
+	for index := 0; index < count; {
+		bestLength, bestDistance := 0, 0
+		for _, distance := range []int{1, width} {
+			if distance <= index {
+				if length := matchLength(index, index-distance); length > bestLength {
+					bestLength, bestDistance = length, distance
+				}
+			}
+		}
+		if index+1 < count {
+			for from, tries := head[hash(index)], 0; from >= 0 && tries < maxChainLength && bestLength < maxMatchLength; from, tries = chain[from], tries+1 {
+				distance := index - int(from)
+				if distance > maxDistance {
+					break
+				}
+				if length := matchLength(index, int(from)); length > bestLength {
+					bestLength, bestDistance = length, distance
+				}
+			}
+		}
+
+		if bestLength < minMatchLength {
+			symbols = append(symbols, symbol{argb: pixels[index]})
+			insert(index)
+			index++
+			continue
+		}
+		symbols = append(symbols, symbol{length: bestLength, distance: bestDistance})
+		for end := index + bestLength; index < end; index++ {
+			insert(index)
+		}
+	}
+	return symbols
+}
+
+// shortDistanceCodes returns the codes of the distances the short distance codes cover in an image of a width.
+func shortDistanceCodes(width int) map[int]int {
+	codes := make(map[int]int, len(distanceOffsets))
+	for index, offset := range distanceOffsets {
+		distance := int(offset>>4)*width + 8 - int(offset&0xf)
+		if distance < 1 {
+			distance = 1
+		}
+		if _, hasCode := codes[distance]; !hasCode {
+			codes[distance] = index + 1
+		}
+	}
+	return codes
+}
+
+// distanceCode returns the code of a distance; a short distance code if there is one, or else the distance
+// offset by the number of short distance codes.
+func distanceCode(distance int, shortCodes map[int]int) int {
+	if code, hasCode := shortCodes[distance]; hasCode {
+		return code
+	}
+	return distance + len(distanceOffsets)
+}
+
+// prefixEncode returns the prefix code of a length or a distance code, and the extra bits after it.
+func prefixEncode(value int) (prefix, extraBits, extra int) {
+	if value <= 4 {
+		return value - 1, 0, 0
+	}
+	value--
+	highest := bits.Len(uint(value)) - 1
+	second := (value >> (highest - 1)) & 1
+	extraBits = highest - 1
+	return 2*highest + second, extraBits, value & (1<<extraBits - 1)
+}
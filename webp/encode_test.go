@@ -0,0 +1,114 @@
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	xwebp "golang.org/x/image/webp"
+)
+
+// roundTrip encodes an image and decodes it again.
+func roundTrip(assert *assert.Assertions, m image.Image) (*image.NRGBA, int) {
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(Encode(buffer, m))
+	size := buffer.Len()
+	decoded, err := xwebp.Decode(buffer)
+	assert.Nil(err)
+	nrgba, isNRGBA := decoded.(*image.NRGBA)
+	assert.True(isNRGBA)
+	return nrgba, size
+}
+
+// assertSamePixels asserts an image decoded the same as it was encoded.
+func assertSamePixels(assert *assert.Assertions, expected image.Image, actual *image.NRGBA) {
+	bounds := expected.Bounds()
+	assert.Equal(bounds.Dx(), actual.Bounds().Dx())
+	assert.Equal(bounds.Dy(), actual.Bounds().Dy())
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			assert.Equal(color.NRGBAModel.Convert(expected.At(bounds.Min.X+x, bounds.Min.Y+y)), actual.NRGBAAt(x, y), x, y)
+		}
+	}
+}
+
+func TestEncodeNoise(t *testing.T) {
+	assert := assert.New(t)
+
+	random := rand.New(rand.NewSource(1))
+	m := image.NewNRGBA(image.Rect(0, 0, 67, 41))
+	random.Read(m.Pix)
+	decoded, _ := roundTrip(assert, m)
+	assertSamePixels(assert, m, decoded)
+}
+
+func TestEncodeFlat(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, size := range []image.Point{{1, 1}, {1, 300}, {300, 1}, {5000, 3}} {
+		m := image.NewRGBA(image.Rectangle{Max: size})
+		for index := range m.Pix {
+			m.Pix[index] = 0xff
+		}
+		decoded, _ := roundTrip(assert, m)
+		assertSamePixels(assert, m, decoded)
+	}
+}
+
+func TestEncodeChart(t *testing.T) {
+	assert := assert.New(t)
+
+	// lines and antialiased edges over a flat background, in a sub image with premultiplied alpha.
+	m := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 400; x++ {
+			c := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			switch {
+			case y%50 == 0:
+				c = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+			case (x+y)%97 < 3:
+				c = color.RGBA{R: 0, G: uint8(116 - 30*((x+y)%97)), B: 217, A: 255}
+			case x > 350:
+				c = color.RGBA{R: uint8(x - 350), G: 0, B: 0, A: uint8(x - 300)}
+			}
+			m.SetRGBA(x, y, c)
+		}
+	}
+	sub := m.SubImage(image.Rect(10, 20, 390, 290))
+	decoded, size := roundTrip(assert, sub)
+	assertSamePixels(assert, sub, decoded)
+
+	encoded := bytes.NewBuffer(nil)
+	assert.Nil(png.Encode(encoded, sub))
+	assert.True(size < encoded.Len(), size, encoded.Len())
+}
+
+func TestEncodeInvalidSize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil(Encode(bytes.NewBuffer(nil), image.NewRGBA(image.Rect(0, 0, 0, 10))))
+	assert.NotNil(Encode(bytes.NewBuffer(nil), image.NewRGBA(image.Rect(0, 0, MaxSize+1, 1))))
+}
+
+func TestCodeLengths(t *testing.T) {
+	assert := assert.New(t)
+
+	// fibonacci counts make the deepest huffman trees; the lengths are limited.
+	counts := make([]int, 30)
+	a, b := 1, 1
+	for index := range counts {
+		counts[index] = a
+		a, b = b, a+b
+	}
+	lengths := codeLengths(counts, 7)
+	kraft := 0.0
+	for _, length := range lengths {
+		assert.True(length > 0 && length <= 7)
+		kraft += 1 / float64(uint(1)<<length)
+	}
+	assert.True(kraft <= 1)
+}
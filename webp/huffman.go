@@ -0,0 +1,280 @@
+package webp
+
+import (
+	"sort"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+const (
+	// maxCodeLength is the longest code of the codes of the pixels.
+	maxCodeLength = 15
+	// maxCodeLengthCodeLength is the longest code of the code of the code lengths.
+	maxCodeLengthCodeLength = 7
+)
+
+// codeLengthCodeOrder is the order the lengths of the code of the code lengths are written in.
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// bitWriter writes bits least significant bit first, as vp8l reads them.
+type bitWriter struct {
+	buffer []byte
+	bits   uint64
+	count  uint
+}
+
+// write writes the low n bits of a value.
+func (bw *bitWriter) write(value uint32, n uint) {
+	bw.bits |= uint64(value) << bw.count
+	bw.count += n
+	for bw.count >= 8 {
+		bw.buffer = append(bw.buffer, byte(bw.bits))
+		bw.bits >>= 8
+		bw.count -= 8
+	}
+}
+
+// bytes returns the bits written, padded with zeros to a whole byte.
+func (bw *bitWriter) bytes() []byte {
+	if bw.count > 0 {
+		bw.write(0, 8-bw.count)
+	}
+	return bw.buffer
+}
+
+// huffmanCode is a canonical prefix code of an alphabet.
+type huffmanCode struct {
+	lengths []uint8
+	// codes are the codes of the symbols, bit reversed as they are written.
+	codes []uint16
+	// symbols is the number of symbols with a code; a code of a single symbol takes no bits.
+	symbols int
+}
+
+// newHuffmanCode returns the code of an alphabet for the counts of its symbols, with no code longer than a length.
+func newHuffmanCode(counts []int, maxLength int) huffmanCode {
+	hc := huffmanCode{lengths: codeLengths(counts, maxLength), codes: make([]uint16, len(counts))}
+	var lengthCounts [maxCodeLength + 1]int
+	for _, length := range hc.lengths {
+		if length > 0 {
+			lengthCounts[length]++
+			hc.symbols++
+		}
+	}
+	var next [maxCodeLength + 1]int
+	code := 0
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + lengthCounts[length-1]) << 1
+		next[length] = code
+	}
+	for symbol, length := range hc.lengths {
+		if length == 0 {
+			continue
+		}
+		hc.codes[symbol] = reverseBits(uint16(next[length]), length)
+		next[length]++
+	}
+	return hc
+}
+
+// writeSymbol writes the code of a symbol.
+func (hc huffmanCode) writeSymbol(bw *bitWriter, symbol int) {
+	if hc.symbols > 1 {
+		bw.write(uint32(hc.codes[symbol]), uint(hc.lengths[symbol]))
+	}
+}
+
+// write writes the code itself: the symbols of a code of up to two symbols below 256, or else the code lengths
+// run length encoded with a code of their own.
+func (hc huffmanCode) write(bw *bitWriter) {
+	var symbols []int
+	for symbol, length := range hc.lengths {
+		if length > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		symbols = []int{0}
+	}
+	if len(symbols) <= 2 && symbols[len(symbols)-1] < 256 {
+		bw.write(1, 1)
+		bw.write(uint32(len(symbols)-1), 1)
+		if symbols[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(symbols[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(symbols[0]), 8)
+		}
+		if len(symbols) == 2 {
+			bw.write(uint32(symbols[1]), 8)
+		}
+		return
+	}
+
+	bw.write(0, 1)
+	tokens := codeLengthTokens(hc.lengths)
+	counts := make([]int, len(codeLengthCodeOrder))
+	for _, t := range tokens {
+		counts[t.symbol]++
+	}
+	lengthCode := newHuffmanCode(counts, maxCodeLengthCodeLength)
+	written := len(codeLengthCodeOrder)
+	for written > 4 && lengthCode.lengths[codeLengthCodeOrder[written-1]] == 0 {
+		written--
+	}
+	bw.write(uint32(written-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:written] {
+		bw.write(uint32(lengthCode.lengths[symbol]), 3)
+	}
+	// the lengths of the whole alphabet follow.
+	bw.write(0, 1)
+	for _, t := range tokens {
+		lengthCode.writeSymbol(bw, t.symbol)
+		switch t.symbol {
+		case 16:
+			bw.write(uint32(t.extra), 2)
+		case 17:
+			bw.write(uint32(t.extra), 3)
+		case 18:
+			bw.write(uint32(t.extra), 7)
+		}
+	}
+}
+
+// codeLengthToken is a code length, or a run of repeated code lengths.
+type codeLengthToken struct {
+	symbol int
+	extra  int
+}
+
+// codeLengthTokens run length encodes code lengths: 16 repeats the last length that isn't zero 3 to 6 times,
+// 17 repeats zero 3 to 10 times and 18 11 to 138 times.
+func codeLengthTokens(lengths []uint8) (tokens []codeLengthToken) {
+	previous := 8
+	for start := 0; start < len(lengths); {
+		value := int(lengths[start])
+		end := start + 1
+		for end < len(lengths) && int(lengths[end]) == value {
+			end++
+		}
+		run := end - start
+		start = end
+
+		if value == 0 {
+			for run >= 11 {
+				n := util.Math.MinInt(run, 138)
+				tokens = append(tokens, codeLengthToken{symbol: 18, extra: n - 11})
+				run -= n
+			}
+			if run >= 3 {
+				tokens = append(tokens, codeLengthToken{symbol: 17, extra: run - 3})
+				run = 0
+			}
+		} else {
+			if value != previous {
+				tokens = append(tokens, codeLengthToken{symbol: value})
+				previous = value
+				run--
+			}
+			for run >= 3 {
+				n := util.Math.MinInt(run, 6)
+				tokens = append(tokens, codeLengthToken{symbol: 16, extra: n - 3})
+				run -= n
+			}
+		}
+		for ; run > 0; run-- {
+			tokens = append(tokens, codeLengthToken{symbol: value})
+		}
+	}
+	return
+}
+
+// codeLengths returns the lengths of the codes of a huffman code for the counts of symbols, no longer than a length;
+// if the lengths come out longer, the smallest counts are raised until they don't.
+func codeLengths(counts []int, maxLength int) []uint8 {
+	lengths := make([]uint8, len(counts))
+	var used []int
+	for symbol, count := range counts {
+		if count > 0 {
+			used = append(used, symbol)
+		}
+	}
+	switch len(used) {
+	case 0:
+		return lengths
+	case 1:
+		lengths[used[0]] = 1
+		return lengths
+	}
+
+	for minCount := 1; ; minCount <<= 1 {
+		if huffmanDepths(counts, used, minCount, lengths) <= maxLength {
+			return lengths
+		}
+	}
+}
+
+// huffmanNode is a node of a huffman tree; leaves have no children.
+type huffmanNode struct {
+	count       int
+	symbol      int
+	left, right int
+}
+
+// huffmanDepths sets the depths of the used symbols in a huffman tree of their counts, raised to a minimum,
+// and returns the deepest.
+func huffmanDepths(counts, used []int, minCount int, lengths []uint8) int {
+	nodes := make([]huffmanNode, 0, 2*len(used))
+	for _, symbol := range used {
+		nodes = append(nodes, huffmanNode{count: util.Math.MaxInt(counts[symbol], minCount), symbol: symbol, left: -1, right: -1})
+	}
+	// leaves are merged in the order of their counts, and then their symbols, so the code is stable.
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].count != nodes[j].count {
+			return nodes[i].count < nodes[j].count
+		}
+		return nodes[i].symbol < nodes[j].symbol
+	})
+
+	// two queues: the sorted leaves, and the merged nodes, which are made in order of their counts.
+	leaf, merged := 0, len(nodes)
+	take := func() int {
+		if leaf < len(used) && (merged >= len(nodes) || nodes[leaf].count <= nodes[merged].count) {
+			leaf++
+			return leaf - 1
+		}
+		merged++
+		return merged - 1
+	}
+	for count := len(used); count > 1; count-- {
+		left := take()
+		right := take()
+		nodes = append(nodes, huffmanNode{count: nodes[left].count + nodes[right].count, left: left, right: right})
+	}
+
+	deepest := 0
+	var walk func(index, depth int)
+	walk = func(index, depth int) {
+		node := nodes[index]
+		if node.left < 0 {
+			lengths[node.symbol] = uint8(depth)
+			deepest = util.Math.MaxInt(deepest, depth)
+			return
+		}
+		walk(node.left, depth+1)
+		walk(node.right, depth+1)
+	}
+	walk(len(nodes)-1, 0)
+	return deepest
+}
+
+// reverseBits reverses the low bits of a code.
+func reverseBits(code uint16, length uint8) uint16 {
+	var reversed uint16
+	for i := uint8(0); i < length; i++ {
+		reversed = reversed<<1 | code&1
+		code >>= 1
+	}
+	return reversed
+}
@@ -0,0 +1,188 @@
+package chart
+
+import (
+	"sort"
+
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// WindowedSeries is the values of an inner series with x values within a window, i.e. the zoomed in view of
+// a long series. The window is found by binary search over the ascending x values of the inner series, so the
+// values are neither copied nor scanned. The values just outside the window on either side are kept so lines
+// run to its edges; the series is clipped to the canvas, and the x range is best fixed to the window (see
+// `Chart.Window`).
+type WindowedSeries struct {
+	Name  string
+	Style Style
+	YAxis YAxisType
+	XAxis XAxisType
+
+	// XMin and XMax are the bounds of the window.
+	XMin, XMax float64
+	// InnerSeries is the series to draw a window of; its x values must be ascending.
+	InnerSeries ValuesProvider
+
+	window seriesWindow
+}
+
+// seriesWindow is the indices of the values of a series in a window, for the length and bounds they were found for.
+type seriesWindow struct {
+	start, end int
+	length     int
+	xmin, xmax float64
+	found      bool
+}
+
+// GetName returns the name of the series.
+func (ws WindowedSeries) GetName() string {
+	return ws.Name
+}
+
+// GetStyle returns the line style.
+func (ws WindowedSeries) GetStyle() Style {
+	return ws.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ws WindowedSeries) GetYAxis() YAxisType {
+	return ws.YAxis
+}
+
+// GetXAxis returns which XAxis the series draws on.
+func (ws WindowedSeries) GetXAxis() XAxisType {
+	return ws.XAxis
+}
+
+// IsClipped returns true; the values kept outside the window are clipped to the canvas.
+func (ws WindowedSeries) IsClipped() bool {
+	return true
+}
+
+// getWindow returns the indices of the values of the inner series in the window, searching for them once.
+func (ws *WindowedSeries) getWindow() (start, end int) {
+	length := ws.InnerSeries.Len()
+	if !ws.window.found || ws.window.length != length || ws.window.xmin != ws.XMin || ws.window.xmax != ws.XMax {
+		start, end := windowIndices(length, func(index int) float64 {
+			x, _ := ws.InnerSeries.GetValues(index)
+			return x
+		}, ws.XMin, ws.XMax)
+		ws.window = seriesWindow{start: start, end: end, length: length, xmin: ws.XMin, xmax: ws.XMax, found: true}
+	}
+	return ws.window.start, ws.window.end
+}
+
+// Len returns the number of values in the window.
+func (ws *WindowedSeries) Len() int {
+	start, end := ws.getWindow()
+	return end - start
+}
+
+// GetValues gets the x,y values at a given index of the window.
+func (ws *WindowedSeries) GetValues(index int) (float64, float64) {
+	start, _ := ws.getWindow()
+	return ws.InnerSeries.GetValues(start + index)
+}
+
+// GetValuesInto copies the values starting at a given index of the window into the given slices.
+func (ws *WindowedSeries) GetValuesInto(start int, xvalues, yvalues []float64) int {
+	windowStart, windowEnd := ws.getWindow()
+	count := valuesIntoCount(windowEnd-windowStart, start, xvalues, yvalues)
+	return GetValuesInto(ws.InnerSeries, windowStart+start, xvalues[:count], yvalues[:count])
+}
+
+// GetLastValues gets the last values of the window.
+func (ws *WindowedSeries) GetLastValues() (float64, float64) {
+	return ws.GetValues(ws.Len() - 1)
+}
+
+// GetValueFormatters returns the value formatters of the inner series, if it has them.
+func (ws WindowedSeries) GetValueFormatters() (x, y ValueFormatter) {
+	if vfp, isValueFormatterProvider := ws.InnerSeries.(ValueFormatterProvider); isValueFormatterProvider {
+		return vfp.GetValueFormatters()
+	}
+	return FloatValueFormatter, FloatValueFormatter
+}
+
+// Render renders the series.
+func (ws *WindowedSeries) Render(r Renderer, canvasBox Box, xrange, yrange Range, defaults Style) {
+	style := ws.Style.InheritFrom(defaults)
+	Draw.LineSeries(r, canvasBox, xrange, yrange, style, ws)
+}
+
+// Validate validates the series.
+func (ws *WindowedSeries) Validate() error {
+	if ws.InnerSeries == nil {
+		return newValidationError(ErrEmptySeries, "windowed series requires InnerSeries to be set")
+	}
+	if ws.XMin > ws.XMax {
+		return newValidationError(ErrInvalidRange, "windowed series XMin must not be more than XMax; got %v and %v", ws.XMin, ws.XMax)
+	}
+	return nil
+}
+
+// windowIndices returns the indices of the ascending x values within bounds, and of the values just outside them
+// on either side.
+func windowIndices(length int, xAt func(index int) float64, xmin, xmax float64) (start, end int) {
+	start = sort.Search(length, func(index int) bool { return xAt(index) >= xmin })
+	end = sort.Search(length, func(index int) bool { return xAt(index) > xmax })
+	if start > 0 {
+		start--
+	}
+	if end < length {
+		end++
+	}
+	return
+}
+
+// Window returns a copy of the chart zoomed in to x values from `xmin` to `xmax`, for time series the times
+// as `util.Time.ToFloat64`. The x values and y values of continuous and time series, and of the inner series
+// of decimation series, are resliced to the window by binary search rather than copied, so windows of long series
+// are cheap to render; their x values must be ascending. Other series are drawn whole.
+//
+// The x range is fixed to the window, unless the x-axis has a range, and the series are clipped to the canvas.
+// Style providers and data labels of windowed series see the indices of values within the window.
+func (c Chart) Window(xmin, xmax float64) Chart {
+	windowed := c
+	windowed.Series = make([]Series, len(c.Series))
+	for index, s := range c.Series {
+		windowed.Series[index] = windowSeries(s, xmin, xmax)
+	}
+	if c.XAxis.Range == nil {
+		window := ContinuousRange{Min: xmin, Max: xmax, Descending: c.XAxis.Descending}
+		if c.hasTimeSeries() {
+			windowed.XAxis.Range = &TimeRange{ContinuousRange: window, Location: c.XAxis.TimeZone}
+		} else {
+			windowed.XAxis.Range = &window
+		}
+	}
+	windowed.ClipSeries = true
+	return windowed
+}
+
+// windowSeries returns a series resliced to a window of x values, or as it is if it can't be.
+func windowSeries(s Series, xmin, xmax float64) Series {
+	switch typed := s.(type) {
+	case ContinuousSeries:
+		if len(typed.XValues) == len(typed.YValues) {
+			start, end := windowIndices(len(typed.XValues), func(index int) float64 { return typed.XValues[index] }, xmin, xmax)
+			typed.XValues, typed.YValues = typed.XValues[start:end], typed.YValues[start:end]
+		}
+		return typed
+	case TimeSeries:
+		if len(typed.XValues) == len(typed.YValues) {
+			start, end := windowIndices(len(typed.XValues), func(index int) float64 { return util.Time.ToFloat64(typed.XValues[index]) }, xmin, xmax)
+			typed.XValues, typed.YValues = typed.XValues[start:end], typed.YValues[start:end]
+		}
+		return typed
+	case DecimationSeries:
+		switch inner := typed.InnerSeries.(type) {
+		case ContinuousSeries, TimeSeries:
+			typed.InnerSeries = windowSeries(inner.(Series), xmin, xmax).(ValuesProvider)
+		case nil:
+		default:
+			typed.InnerSeries = &WindowedSeries{XMin: xmin, XMax: xmax, InnerSeries: inner}
+		}
+		return typed
+	}
+	return s
+}
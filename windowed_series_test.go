@@ -0,0 +1,107 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// countingValues is a series that counts the values read from it.
+type countingValues struct {
+	ContinuousSeries
+	reads *int
+}
+
+func (cv countingValues) GetValues(index int) (float64, float64) {
+	*cv.reads++
+	return cv.ContinuousSeries.GetValues(index)
+}
+
+func linearValues(count int) ([]float64, []float64) {
+	xvalues, yvalues := make([]float64, count), make([]float64, count)
+	for index := range xvalues {
+		xvalues[index], yvalues[index] = float64(index), float64(index*2)
+	}
+	return xvalues, yvalues
+}
+
+func TestWindowedSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	xvalues, yvalues := linearValues(100000)
+	reads := 0
+	ws := &WindowedSeries{XMin: 500.5, XMax: 600, InnerSeries: countingValues{ContinuousSeries{XValues: xvalues, YValues: yvalues}, &reads}}
+	assert.Nil(ws.Validate())
+
+	// 501 to 600, and 500 and 601 just outside.
+	assert.Equal(102, ws.Len())
+	x, y := ws.GetValues(0)
+	assert.Equal(500.0, x)
+	assert.Equal(1000.0, y)
+	x, _ = ws.GetLastValues()
+	assert.Equal(601.0, x)
+	// the window is found by binary search, once.
+	assert.True(reads < 100, reads)
+
+	xs, ys := make([]float64, 10), make([]float64, 10)
+	assert.Equal(2, ws.GetValuesInto(100, xs, ys))
+	assert.Equal([]float64{600, 601}, xs[:2])
+
+	ws.XMin, ws.XMax = -10, 0.5
+	assert.Equal(2, ws.Len())
+
+	assert.NotNil((&WindowedSeries{InnerSeries: ContinuousSeries{}, XMin: 2, XMax: 1}).Validate())
+}
+
+func TestChartWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	xvalues, yvalues := linearValues(1000)
+	c := Chart{
+		Series: []Series{
+			ContinuousSeries{XValues: xvalues, YValues: yvalues},
+			DecimationSeries{InnerSeries: ContinuousSeries{XValues: xvalues, YValues: yvalues}},
+			DecimationSeries{InnerSeries: SMASeries{InnerSeries: ContinuousSeries{XValues: xvalues, YValues: yvalues}}},
+		},
+	}
+	windowed := c.Window(100, 200)
+	assert.True(windowed.ClipSeries)
+	assert.False(c.ClipSeries)
+	assert.Nil(c.XAxis.Range)
+
+	cs := windowed.Series[0].(ContinuousSeries)
+	assert.Len(cs.XValues, 103)
+	// the values are resliced, not copied.
+	assert.True(&cs.XValues[0] == &xvalues[99])
+	assert.Len(windowed.Series[1].(DecimationSeries).InnerSeries.(ContinuousSeries).YValues, 103)
+	_, isWindowed := windowed.Series[2].(DecimationSeries).InnerSeries.(*WindowedSeries)
+	assert.True(isWindowed)
+
+	xrange, yrange, _ := windowed.getRanges()
+	assert.Equal(100.0, xrange.GetMin())
+	assert.Equal(200.0, xrange.GetMax())
+	assert.True(yrange.GetMax() < 500)
+
+	assert.Nil(windowed.Render(PNG, bytes.NewBuffer(nil)))
+}
+
+func TestChartWindowTimeSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	var values []float64
+	for index := 0; index < 100; index++ {
+		times = append(times, start.Add(time.Duration(index)*time.Hour))
+		values = append(values, float64(index))
+	}
+	c := Chart{Series: []Series{TimeSeries{XValues: times, YValues: values}}}
+	windowed := c.Window(util.Time.ToFloat64(start.Add(10*time.Hour)), util.Time.ToFloat64(start.Add(20*time.Hour)))
+	assert.Len(windowed.Series[0].(TimeSeries).XValues, 13)
+	_, isTimeRange := windowed.XAxis.Range.(*TimeRange)
+	assert.True(isTimeRange)
+	assert.Nil(windowed.Render(PNG, bytes.NewBuffer(nil)))
+}
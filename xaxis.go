@@ -2,26 +2,63 @@ package chart
 
 import (
 	"math"
+	"time"
 
 	util "github.com/wcharczuk/go-chart/util"
 )
 
 // XAxis represents the horizontal axis.
 type XAxis struct {
-	Name      string
-	NameStyle Style
+	Name string
+	// NameStyle is the style of the name; names break at newlines, and wrap to the length of the axis
+	// if the style wraps text. NamePlacement is where the name is drawn along the axis.
+	NameStyle     Style
+	NamePlacement AxisNamePlacement
+
+	// AxisType is set by the chart; the secondary axis is drawn along the top of the canvas,
+	// with its ticks and labels above it.
+	AxisType XAxisType
 
 	Style          Style
 	ValueFormatter ValueFormatter
+	TickFormatter  TickFormatter
 	Range          Range
 
+	// Unit is the unit of the values; it labels and places the ticks unless a value formatter is set.
+	Unit *Unit
+	// UnitPlacement is where the unit is drawn; on every tick label, or only on the tick at the end of the axis.
+	UnitPlacement UnitPlacement
+
+	// TimeZone is the location a time axis places its ticks on calendar boundaries (midnights, hours, month starts)
+	// and formats its labels in, whatever the location of the series' times; it defaults to `time.Local`.
+	// It is the location of the `TimeRange` the chart picks for the axis; a range set on the axis uses its own.
+	TimeZone *time.Location
+
+	// Descending draws larger values to the left; it sets `Descending` on the range the chart picks for the axis,
+	// and a range set on the axis uses its own.
+	Descending bool
+
 	TickStyle    Style
 	Ticks        []Tick
 	TickPosition TickPosition
 
+	// TickRotationDegrees rotates the tick labels clockwise, i.e. 45 or -45 for long timestamps; it overrides
+	// the text rotation of the tick style. Rotated labels are drawn with the end nearest the axis at their tick,
+	// and the axis is measured by their rotated bounds.
+	TickRotationDegrees float64
+
+	// MinorTicks are the unlabeled ticks between the major ticks.
+	MinorTicks MinorTicks
+
+	// TickLabelLayout is how tick labels that would overlap are laid out; by default they are drawn as they are.
+	TickLabelLayout TickLabelLayout
+
 	GridLines      []GridLine
 	GridMajorStyle Style
 	GridMinorStyle Style
+	// GridBandStyle is the style of the bands shaded between every other pair of major grid lines, if it is shown;
+	// the fill color defaults to a light shade of the grid color.
+	GridBandStyle Style
 }
 
 // GetName returns the name.
@@ -42,6 +79,15 @@ func (xa XAxis) GetValueFormatter() ValueFormatter {
 	return FloatValueFormatter
 }
 
+// getTickStyle returns the style of the tick labels, with the tick rotation.
+func (xa XAxis) getTickStyle(defaults Style) Style {
+	tickStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
+	if xa.TickRotationDegrees != 0 {
+		tickStyle.TextRotationDegrees = xa.TickRotationDegrees
+	}
+	return tickStyle
+}
+
 // GetTickPosition returns the tick position option for the axis.
 func (xa XAxis) GetTickPosition(defaults ...TickPosition) TickPosition {
 	if xa.TickPosition == TickPositionUnset {
@@ -58,15 +104,43 @@ func (xa XAxis) GetTickPosition(defaults ...TickPosition) TickPosition {
 // 	- User Supplied Ticks (i.e. Ticks array on the axis itself).
 // 	- Range ticks (i.e. if the range provides ticks).
 //	- Generating continuous ticks based on minimum spacing and canvas width.
+// Range and generated ticks are relabeled by the tick formatter, if there is one.
+// The ticks are then laid out by the tick label layout.
 func (xa XAxis) GetTicks(r Renderer, ra Range, defaults Style, vf ValueFormatter) []Tick {
+	return xa.layoutTicks(r, ra, defaults, xa.getTicks(r, ra, defaults, vf))
+}
+
+func (xa XAxis) getTicks(r Renderer, ra Range, defaults Style, vf ValueFormatter) []Tick {
 	if len(xa.Ticks) > 0 {
 		return xa.Ticks
 	}
+	if atp, isAxisTicksProvider := ra.(axisTicksProvider); isAxisTicksProvider {
+		return formatTicks(atp.getAxisTicks(r, false, defaults, vf), xa.TickFormatter, AxisKindX, ra)
+	}
 	if tp, isTickProvider := ra.(TicksProvider); isTickProvider {
-		return tp.GetTicks(r, defaults, vf)
+		return formatTicks(tp.GetTicks(r, defaults, vf), xa.TickFormatter, AxisKindX, ra)
 	}
 	tickStyle := xa.Style.InheritFrom(defaults)
-	return GenerateContinuousTicks(r, ra, false, tickStyle, vf)
+	if xa.Unit != nil {
+		return formatTicks(generateUnitTicks(r, ra, false, tickStyle, xa.Unit, xa.ValueFormatter, xa.UnitPlacement), xa.TickFormatter, AxisKindX, ra)
+	}
+	return formatTicks(GenerateContinuousTicks(r, ra, false, tickStyle, vf), xa.TickFormatter, AxisKindX, ra)
+}
+
+// layoutTicks lays out the tick labels by the tick label layout.
+func (xa XAxis) layoutTicks(r Renderer, ra Range, defaults Style, ticks []Tick) []Tick {
+	switch xa.TickLabelLayout {
+	case TickLabelLayoutThin:
+		if xa.GetTickPosition() != TickPositionBetweenTicks {
+			return thinTicks(r, ra, xa.getTickStyle(defaults), ticks)
+		}
+	case TickLabelLayoutEllipsize:
+		// rotated labels are an alternative to shortening them.
+		if tickStyle := xa.getTickStyle(defaults); tickStyle.TextRotationDegrees == 0 {
+			return ellipsizeTicks(r, ra, tickStyle, ticks, xa.GetTickPosition() == TickPositionBetweenTicks)
+		}
+	}
+	return ticks
 }
 
 // GetGridLines returns the gridlines for the axis.
@@ -77,23 +151,145 @@ func (xa XAxis) GetGridLines(ticks []Tick) []GridLine {
 	return GenerateGridLines(ticks, xa.GridMajorStyle, xa.GridMinorStyle)
 }
 
+// getGridLinesGroupID returns the id of the group of the axis gridlines (see `SVGOptions`).
+func (xa XAxis) getGridLinesGroupID() string {
+	if xa.AxisType == XAxisSecondary {
+		return "x-axis-secondary-gridlines"
+	}
+	return "x-axis-gridlines"
+}
+
+// getGridLines returns the gridlines for the axis; ranges that are a `GridLineProvider` provide them
+// unless the axis has grid lines set.
+func (xa XAxis) getGridLines(ra Range, ticks []Tick) []GridLine {
+	if !xa.MinorTicks.IsZero() && len(xa.GridLines) == 0 {
+		return generateMinorGridLines(ticks, xa.MinorTicks.GetValues(ra, ticks), xa.GridMajorStyle, xa.GridMinorStyle)
+	}
+	if glp, isGridLineProvider := ra.(GridLineProvider); isGridLineProvider && len(xa.GridLines) == 0 {
+		return glp.GetGridLines(ticks, true, xa.GridMajorStyle, xa.GridMinorStyle)
+	}
+	return xa.GetGridLines(ticks)
+}
+
+// getStaggerOffset returns how far below the first row of tick labels the second row is,
+// or zero if the labels are not staggered.
+func (xa XAxis) getStaggerOffset(r Renderer, ra Range, tickStyle Style, ticks []Tick) int {
+	if xa.TickLabelLayout != TickLabelLayoutStagger || xa.GetTickPosition() == TickPositionBetweenTicks || len(ticks) < 2 {
+		return 0
+	}
+	// rotated labels are an alternative to staggering them.
+	if tickStyle.TextRotationDegrees != 0 {
+		return 0
+	}
+	if measureTickLabels(r, ra, tickStyle, ticks).fit(1) {
+		return 0
+	}
+	var height int
+	for _, t := range ticks {
+		height = util.Math.MaxInt(height, Draw.MeasureText(r, t.Label, tickStyle).Height())
+	}
+	return height + DefaultLineSpacing
+}
+
+// getRotatedLabel returns where to draw a rotated tick label, and its rotated bounds. The middle of the end of the
+// label nearest the axis is at the tick, i.e. labels below the axis rotated clockwise hang from their start,
+// and ones rotated counterclockwise from their end; the bounds start a margin away from the axis.
+func (xa XAxis) getRotatedLabel(r Renderer, label string, tickStyle Style, tx, axisY, direction int) (x, y int, bounds Box) {
+	unrotated := tickStyle
+	unrotated.TextRotationDegrees = 0
+	tb := Draw.MeasureText(r, label, unrotated)
+	width, height := float64(tb.Width()), float64(tb.Height())
+
+	theta := util.Math.DegreesToRadians(tickStyle.TextRotationDegrees)
+	sin, cos := math.Sin(theta), math.Cos(theta)
+	rotate := func(px, py float64) (float64, float64) {
+		return px*cos - py*sin, px*sin + py*cos
+	}
+
+	// the corners are relative to the start of the baseline, which is where the text is drawn from.
+	corners := [4][2]float64{{0, -height}, {width, -height}, {width, 0}, {0, 0}}
+	anchorX := 0.0
+	anchor := xa.getRotatedLabelAnchor(tickStyle, tx, axisY, direction, height)
+	if anchor.TextHorizontalAlign == TextHorizontalAlignRight {
+		anchorX = width
+	}
+	ax, ay := rotate(anchorX, -height/2)
+	originX, originY := float64(anchor.X)-ax, float64(anchor.Y)-ay
+
+	left, top, right, bottom := math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64
+	for _, corner := range corners {
+		cx, cy := rotate(corner[0], corner[1])
+		left, right = math.Min(left, originX+cx), math.Max(right, originX+cx)
+		top, bottom = math.Min(top, originY+cy), math.Max(bottom, originY+cy)
+	}
+	return int(math.Round(originX)), int(math.Round(originY)), Box{
+		Top:    int(math.Floor(top)),
+		Left:   int(math.Floor(left)),
+		Right:  int(math.Ceil(right)),
+		Bottom: int(math.Ceil(bottom)),
+	}
+}
+
+// rotatedLabelAnchor is the point a rotated tick label is aligned on, and the end of the label that is there.
+type rotatedLabelAnchor struct {
+	X, Y                int
+	TextHorizontalAlign TextHorizontalAlign
+}
+
+// getRotatedLabelAnchor returns the middle of the end of a rotated tick label nearest the axis, moved off the axis
+// so the corners of that end clear the margin, given the height of the label.
+func (xa XAxis) getRotatedLabelAnchor(tickStyle Style, tx, axisY, direction int, height float64) rotatedLabelAnchor {
+	theta := util.Math.DegreesToRadians(tickStyle.TextRotationDegrees)
+	align := TextHorizontalAlignLeft
+	if math.Sin(theta)*float64(direction) < 0 {
+		align = TextHorizontalAlignRight
+	}
+	offset := float64(DefaultXAxisMargin) + math.Abs(math.Cos(theta))*height/2
+	return rotatedLabelAnchor{X: tx, Y: axisY + int(math.Round(float64(direction)*offset)), TextHorizontalAlign: align}
+}
+
 // Measure returns the bounds of the axis.
 func (xa XAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, ticks []Tick) Box {
-	tickStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
+	tickStyle := xa.getTickStyle(defaults)
+	axisY, direction := canvasBox.Bottom, 1
+	if xa.AxisType == XAxisSecondary {
+		axisY, direction = canvasBox.Top, -1
+	}
 
 	tp := xa.GetTickPosition()
+	staggerOffset := xa.getStaggerOffset(r, ra, tickStyle, ticks)
 
 	var ltx, rtx int
 	var tx, ty int
 	var left, right, bottom = math.MaxInt32, 0, 0
+	top := canvasBox.Top
 	for index, t := range ticks {
 		v := t.Value
 		tb := Draw.MeasureText(r, t.Label, tickStyle.GetTextOptions())
 
 		tx = canvasBox.Left + ra.Translate(v)
-		ty = canvasBox.Bottom + DefaultXAxisMargin + tb.Height()
+		if xa.AxisType == XAxisSecondary {
+			ty = canvasBox.Top - DefaultXAxisMargin - tb.Height()
+			if index%2 == 1 {
+				ty -= staggerOffset
+			}
+		} else {
+			ty = canvasBox.Bottom + DefaultXAxisMargin + tb.Height()
+			if index%2 == 1 {
+				ty += staggerOffset
+			}
+		}
 		switch tp {
 		case TickPositionUnderTick, TickPositionUnset:
+			if tickStyle.TextRotationDegrees != 0 {
+				_, _, lb := xa.getRotatedLabel(r, t.Label, tickStyle, tx, axisY, direction)
+				ltx, rtx = lb.Left, lb.Right
+				ty = lb.Bottom
+				if xa.AxisType == XAxisSecondary {
+					ty = lb.Top
+				}
+				break
+			}
 			ltx = tx - tb.Width()>>1
 			rtx = tx + tb.Width()>>1
 			break
@@ -107,14 +303,24 @@ func (xa XAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, tic
 
 		left = util.Math.MinInt(left, ltx)
 		right = util.Math.MaxInt(right, rtx)
+		top = util.Math.MinInt(top, ty)
 		bottom = util.Math.MaxInt(bottom, ty)
 	}
 
 	if xa.NameStyle.Show && len(xa.Name) > 0 {
-		tb := Draw.MeasureText(r, xa.Name, xa.NameStyle.InheritFrom(defaults))
-		bottom += DefaultXAxisMargin + tb.Height()
+		name := measureAxisName(r, xa.Name, canvasBox.Width(), xa.NameStyle.InheritFrom(defaults))
+		top -= DefaultXAxisMargin + name.Height
+		bottom += DefaultXAxisMargin + name.Height
 	}
 
+	if xa.AxisType == XAxisSecondary {
+		return Box{
+			Top:    top,
+			Left:   left,
+			Right:  right,
+			Bottom: canvasBox.Top,
+		}
+	}
 	return Box{
 		Top:    canvasBox.Bottom,
 		Left:   left,
@@ -125,14 +331,32 @@ func (xa XAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, tic
 
 // Render renders the axis
 func (xa XAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, ticks []Tick) {
-	tickStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
+	tickStyle := xa.getTickStyle(defaults)
+	xa.renderGridBands(r, canvasBox, ra, ticks)
+
+	// the secondary axis mirrors the primary above the canvas; ticks and labels extend up from its top.
+	axisY, direction := canvasBox.Bottom, 1
+	if xa.AxisType == XAxisSecondary {
+		axisY, direction = canvasBox.Top, -1
+	}
 
 	tickStyle.GetStrokeOptions().WriteToRenderer(r)
-	r.MoveTo(canvasBox.Left, canvasBox.Bottom)
-	r.LineTo(canvasBox.Right, canvasBox.Bottom)
+	r.MoveTo(canvasBox.Left, axisY)
+	r.LineTo(canvasBox.Right, axisY)
 	r.Stroke()
 
+	if minorValues := xa.MinorTicks.GetValues(ra, ticks); len(minorValues) > 0 {
+		xa.MinorTicks.getStyle(tickStyle).GetStrokeOptions().WriteToRenderer(r)
+		for _, v := range minorValues {
+			tx := canvasBox.Left + ra.Translate(v)
+			r.MoveTo(tx, axisY)
+			r.LineTo(tx, axisY+direction*(DefaultVerticalTickHeight>>1))
+			r.Stroke()
+		}
+	}
+
 	tp := xa.GetTickPosition()
+	staggerOffset := xa.getStaggerOffset(r, ra, tickStyle, ticks)
 
 	var tx, ty int
 	var maxTextHeight int
@@ -143,35 +367,49 @@ func (xa XAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 		tx = canvasBox.Left + lx
 
 		tickStyle.GetStrokeOptions().WriteToRenderer(r)
-		r.MoveTo(tx, canvasBox.Bottom)
-		r.LineTo(tx, canvasBox.Bottom+DefaultVerticalTickHeight)
+		r.MoveTo(tx, axisY)
+		r.LineTo(tx, axisY+direction*DefaultVerticalTickHeight)
 		r.Stroke()
 
-		tickWithAxisStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
+		tickWithAxisStyle := xa.getTickStyle(defaults)
 		tb := Draw.MeasureText(r, t.Label, tickWithAxisStyle)
 
 		switch tp {
 		case TickPositionUnderTick, TickPositionUnset:
-			if tickStyle.TextRotationDegrees == 0 {
-				tx = tx - tb.Width()>>1
-				ty = canvasBox.Bottom + DefaultXAxisMargin + tb.Height()
+			if tickStyle.TextRotationDegrees != 0 {
+				_, _, lb := xa.getRotatedLabel(r, t.Label, tickWithAxisStyle, tx, axisY, direction)
+				unrotated := tickWithAxisStyle
+				unrotated.TextRotationDegrees = 0
+				anchor := xa.getRotatedLabelAnchor(tickWithAxisStyle, tx, axisY, direction, float64(Draw.MeasureText(r, t.Label, unrotated).Height()))
+				Draw.TextAnchored(r, t.Label, anchor.X, anchor.Y, withTextAnchor(tickWithAxisStyle, anchor.TextHorizontalAlign, TextVerticalAlignMiddle))
+				maxTextHeight = util.Math.MaxInt(maxTextHeight, lb.Height())
+				break
+			}
+			if xa.AxisType == XAxisSecondary {
+				ty = canvasBox.Top - DefaultXAxisMargin
 			} else {
-				ty = canvasBox.Bottom + (2 * DefaultXAxisMargin)
+				ty = canvasBox.Bottom + DefaultXAxisMargin + tb.Height()
+			}
+			if index%2 == 1 {
+				ty += direction * staggerOffset
 			}
-			Draw.Text(r, t.Label, tx, ty, tickWithAxisStyle)
-			maxTextHeight = util.Math.MaxInt(maxTextHeight, tb.Height())
+			Draw.TextAnchored(r, t.Label, tx, ty, withTextAnchor(tickWithAxisStyle, TextHorizontalAlignCenter, TextVerticalAlignBaseline))
+			maxTextHeight = util.Math.MaxInt(maxTextHeight, tb.Height()+staggerOffset)
 			break
 		case TickPositionBetweenTicks:
 			if index > 0 {
 				llx := ra.Translate(ticks[index-1].Value)
 				ltx := canvasBox.Left + llx
 				finalTickStyle := tickWithAxisStyle.InheritFrom(Style{TextHorizontalAlign: TextHorizontalAlignCenter})
+				if xa.AxisType == XAxisSecondary {
+					finalTickStyle = finalTickStyle.InheritFrom(Style{TextVerticalAlign: TextVerticalAlignBottom})
+				}
 
 				Draw.TextWithin(r, t.Label, Box{
 					Left:   ltx,
 					Right:  tx,
-					Top:    canvasBox.Bottom + DefaultXAxisMargin,
-					Bottom: canvasBox.Bottom + DefaultXAxisMargin,
+					Top:    axisY + direction*DefaultXAxisMargin,
+					Bottom: axisY + direction*DefaultXAxisMargin,
 				}, finalTickStyle)
 
 				ftb := Text.MeasureLines(r, Text.WrapFit(r, t.Label, tx-ltx, finalTickStyle), finalTickStyle)
@@ -183,14 +421,32 @@ func (xa XAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 
 	nameStyle := xa.NameStyle.InheritFrom(defaults)
 	if xa.NameStyle.Show && len(xa.Name) > 0 {
-		tb := Draw.MeasureText(r, xa.Name, nameStyle)
-		tx := canvasBox.Right - (canvasBox.Width()>>1 + tb.Width()>>1)
-		ty := canvasBox.Bottom + DefaultXAxisMargin + maxTextHeight + DefaultXAxisMargin + tb.Height()
-		Draw.Text(r, xa.Name, tx, ty, nameStyle)
+		name := measureAxisName(r, xa.Name, canvasBox.Width(), nameStyle)
+		tx, align := canvasBox.Left+canvasBox.Width()>>1, TextHorizontalAlignCenter
+		if xa.NamePlacement == AxisNamePlacementEnd {
+			tx, align = canvasBox.Right, TextHorizontalAlignRight
+		}
+		ty := canvasBox.Bottom + DefaultXAxisMargin + maxTextHeight + DefaultXAxisMargin + name.Height>>1
+		if xa.AxisType == XAxisSecondary {
+			ty = canvasBox.Top - DefaultXAxisMargin - maxTextHeight - DefaultXAxisMargin - name.Height>>1
+		}
+		name.draw(r, tx, ty, align, nameStyle)
 	}
+	xa.renderGridLines(r, canvasBox, ra, ticks)
+}
+
+// renderGridBands shades the bands between the major grid lines, if the band style is shown.
+func (xa XAxis) renderGridBands(r Renderer, canvasBox Box, ra Range, ticks []Tick) {
+	if xa.GridBandStyle.Show {
+		drawGridBands(r, canvasBox, ra, true, xa.getGridLines(ra, ticks), xa.GridBandStyle.InheritFrom(Style{FillColor: DefaultGridLineColor.WithAlpha(96)}))
+	}
+}
 
+// renderGridLines draws the grid lines whose style is shown.
+func (xa XAxis) renderGridLines(r Renderer, canvasBox Box, ra Range, ticks []Tick) {
 	if xa.GridMajorStyle.Show || xa.GridMinorStyle.Show {
-		for _, gl := range xa.GetGridLines(ticks) {
+		startGroup(r, xa.getGridLinesGroupID(), "gridlines")
+		for _, gl := range xa.getGridLines(ra, ticks) {
 			if (gl.IsMinor && xa.GridMinorStyle.Show) || (!gl.IsMinor && xa.GridMajorStyle.Show) {
 				defaults := xa.GridMajorStyle
 				if gl.IsMinor {
@@ -199,5 +455,6 @@ func (xa XAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 				gl.Render(r, canvasBox, ra, true, gl.Style.InheritFrom(defaults))
 			}
 		}
+		endGroup(r)
 	}
 }
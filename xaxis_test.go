@@ -1,6 +1,7 @@
 package chart
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/blendlabs/go-assert"
@@ -65,3 +66,160 @@ func TestXAxisMeasure(t *testing.T) {
 	assert.Equal(122, xab.Width())
 	assert.Equal(21, xab.Height())
 }
+
+func TestXAxisMeasureRotated(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	style := Style{
+		Font:     f,
+		FontSize: 10.0,
+	}
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+	ticks := []Tick{{Value: 1.0, Label: "2018-01-01 12:00"}, {Value: 3.0, Label: "2018-01-02 12:00"}}
+	cb := NewBox(0, 0, 100, 100)
+	ra := &ContinuousRange{Min: 1.0, Max: 3.0, Domain: 100}
+
+	flat := XAxis{}.Measure(r, cb, ra, style, ticks)
+	rotated := XAxis{TickRotationDegrees: -45}.Measure(r, cb, ra, style, ticks)
+	// the labels end at their ticks, so they extend left of the first tick and not right of the last.
+	assert.True(rotated.Left < flat.Left)
+	assert.True(rotated.Right < flat.Right)
+	assert.True(rotated.Height() > flat.Height())
+
+	vertical := XAxis{TickRotationDegrees: 90}
+	x, y, bounds := vertical.getRotatedLabel(r, ticks[0].Label, vertical.getTickStyle(style), 50, 100, 1)
+	assert.True(x > bounds.Left && x <= bounds.Right)
+	assert.True(y >= bounds.Top)
+	assert.True(bounds.Top >= 100+DefaultXAxisMargin-1 && bounds.Top <= 100+DefaultXAxisMargin+1, bounds.String())
+	assert.True(bounds.Height() > bounds.Width())
+}
+
+func TestXAxisMeasureSecondary(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+	style := Style{
+		Font:     f,
+		FontSize: 10.0,
+	}
+	r, err := PNG(100, 100)
+	assert.Nil(err)
+	ticks := []Tick{{Value: 1.0, Label: "1.0"}, {Value: 2.0, Label: "2.0"}, {Value: 3.0, Label: "3.0"}}
+	xa := XAxis{AxisType: XAxisSecondary}
+	xab := xa.Measure(r, NewBox(50, 0, 100, 150), &ContinuousRange{Min: 1.0, Max: 3.0, Domain: 100}, style, ticks)
+	assert.Equal(122, xab.Width())
+	assert.Equal(21, xab.Height())
+	assert.Equal(50, xab.Bottom)
+}
+
+func TestXAxisGetTicksThin(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	var ticks []Tick
+	for value := 0; value <= 100; value += 5 {
+		ticks = append(ticks, Tick{Value: float64(value), Label: "2018-01-01 12:00"})
+	}
+	xr := &ContinuousRange{Min: 0, Max: 100, Domain: 400}
+	styleDefaults := Style{
+		Font:     f,
+		FontSize: 10.0,
+	}
+
+	xa := XAxis{Ticks: ticks}
+	assert.Len(xa.GetTicks(r, xr, styleDefaults, nil), len(ticks))
+
+	xa.TickLabelLayout = TickLabelLayoutThin
+	thinned := xa.GetTicks(r, xr, styleDefaults, nil)
+	assert.True(len(thinned) < len(ticks))
+	assert.True(len(thinned) > 1)
+	assert.Equal(ticks[0], thinned[0])
+
+	every := int(thinned[1].Value-thinned[0].Value) / 5
+	for index := 1; index < len(thinned); index++ {
+		assert.Equal(float64(5*every), thinned[index].Value-thinned[index-1].Value)
+	}
+
+	// labels that fit are kept.
+	xr.Domain = 100000
+	assert.Len(xa.GetTicks(r, xr, styleDefaults, nil), len(ticks))
+}
+
+func TestXAxisStaggerTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	var ticks []Tick
+	for value := 0; value <= 100; value += 10 {
+		ticks = append(ticks, Tick{Value: float64(value), Label: "2018-01-01"})
+	}
+	xr := &ContinuousRange{Min: 0, Max: 100, Domain: 500}
+	styleDefaults := Style{
+		Font:     f,
+		FontSize: 10.0,
+	}
+	canvasBox := Box{Top: 0, Left: 0, Right: 500, Bottom: 500}
+
+	xa := XAxis{Ticks: ticks}
+	single := xa.Measure(r, canvasBox, xr, styleDefaults, ticks)
+
+	xa.TickLabelLayout = TickLabelLayoutStagger
+	assert.Len(xa.GetTicks(r, xr, styleDefaults, nil), len(ticks))
+	offset := xa.getStaggerOffset(r, xr, styleDefaults, ticks)
+	assert.NotZero(offset)
+	staggered := xa.Measure(r, canvasBox, xr, styleDefaults, ticks)
+	assert.Equal(single.Bottom+offset, staggered.Bottom)
+
+	// labels that fit in one row are not staggered.
+	xr.Domain = 100000
+	assert.Zero(xa.getStaggerOffset(r, xr, styleDefaults, ticks))
+}
+
+func TestXAxisEllipsizeTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PNG(1024, 1024)
+	assert.Nil(err)
+
+	f, err := GetDefaultFont()
+	assert.Nil(err)
+
+	ticks := []Tick{{Value: 0, Label: "Short"}, {Value: 50, Label: "A very long category name"}, {Value: 100, Label: "Short"}}
+	xr := &ContinuousRange{Min: 0, Max: 100, Domain: 200}
+	styleDefaults := Style{
+		Font:     f,
+		FontSize: 10.0,
+	}
+
+	xa := XAxis{Ticks: ticks, TickLabelLayout: TickLabelLayoutEllipsize}
+	shortened := xa.GetTicks(r, xr, styleDefaults, nil)
+	assert.Len(shortened, len(ticks))
+	assert.Equal("Short", shortened[0].Label)
+	assert.True(strings.HasPrefix(shortened[1].Label, "A very"))
+	assert.True(strings.HasSuffix(shortened[1].Label, "…"))
+	assert.True(Draw.MeasureText(r, shortened[1].Label, styleDefaults).Width() <= 100-DefaultMinimumTickHorizontalSpacing>>1)
+
+	// between ticks, the first tick has no label to shorten.
+	xa.TickPosition = TickPositionBetweenTicks
+	shortened = xa.GetTicks(r, xr, styleDefaults, nil)
+	assert.Equal("Short", shortened[0].Label)
+	assert.True(strings.HasSuffix(shortened[1].Label, "…"))
+
+	// labels that fit are kept.
+	xr.Domain = 100000
+	assert.Equal(ticks, xa.GetTicks(r, xr, styleDefaults, nil))
+}
@@ -9,25 +9,50 @@ import (
 // YAxis is a veritcal rule of the range.
 // There can be (2) y-axes; a primary and secondary.
 type YAxis struct {
-	Name      string
-	NameStyle Style
+	Name string
+	// NameStyle is the style of the name; names break at newlines, and wrap to the length of the axis
+	// if the style wraps text. NamePlacement is where the name is drawn along the axis.
+	NameStyle     Style
+	NamePlacement AxisNamePlacement
 
 	Style Style
 
+	// Zero is the zero line; it is drawn over the grid lines if its style is shown and the range spans its value.
 	Zero GridLine
 
 	AxisType  YAxisType
 	Ascending bool
 
 	ValueFormatter ValueFormatter
+	TickFormatter  TickFormatter
 	Range          Range
 
+	// Unit is the unit of the values; it labels and places the ticks unless a value formatter is set.
+	Unit *Unit
+	// UnitPlacement is where the unit is drawn; on every tick label, or only on the tick at the end of the axis.
+	UnitPlacement UnitPlacement
+
+	// Descending draws larger values at the bottom, i.e. for depths or rankings; it sets `Descending` on the range
+	// the chart picks for the axis, and a range set on the axis uses its own.
+	Descending bool
+
 	TickStyle Style
 	Ticks     []Tick
 
+	// MinorTicks are the unlabeled ticks between the major ticks.
+	MinorTicks MinorTicks
+
 	GridLines      []GridLine
 	GridMajorStyle Style
 	GridMinorStyle Style
+	// GridBandStyle is the style of the bands shaded between every other pair of major grid lines, if it is shown;
+	// the fill color defaults to a light shade of the grid color.
+	GridBandStyle Style
+
+	// Mirror draws the primary y axis on the other side of the canvas too, with the same range and ticks
+	// but without its name or grid lines, so the values can be read from either side of a wide chart.
+	// It is ignored if the secondary y axis is shown.
+	Mirror bool
 }
 
 // GetName returns the name.
@@ -40,6 +65,11 @@ func (ya YAxis) GetNameStyle() Style {
 	return ya.NameStyle
 }
 
+// getNameStyle returns the style of the name, which is rotated to read along the axis by default.
+func (ya YAxis) getNameStyle(defaults Style) Style {
+	return ya.NameStyle.InheritFrom(defaults.InheritFrom(Style{TextRotationDegrees: 90}))
+}
+
 // GetStyle returns the style.
 func (ya YAxis) GetStyle() Style {
 	return ya.Style
@@ -63,15 +93,40 @@ func (ya YAxis) GetTickStyle() Style {
 // 	- User Supplied Ticks (i.e. Ticks array on the axis itself).
 // 	- Range ticks (i.e. if the range provides ticks).
 //	- Generating continuous ticks based on minimum spacing and canvas width.
+// Range and generated ticks are relabeled by the tick formatter, if there is one.
 func (ya YAxis) GetTicks(r Renderer, ra Range, defaults Style, vf ValueFormatter) []Tick {
 	if len(ya.Ticks) > 0 {
 		return ya.Ticks
 	}
+	if atp, isAxisTicksProvider := ra.(axisTicksProvider); isAxisTicksProvider {
+		return formatTicks(atp.getAxisTicks(r, true, defaults, vf), ya.TickFormatter, ya.getAxisKind(), ra)
+	}
 	if tp, isTickProvider := ra.(TicksProvider); isTickProvider {
-		return tp.GetTicks(r, defaults, vf)
+		return formatTicks(tp.GetTicks(r, defaults, vf), ya.TickFormatter, ya.getAxisKind(), ra)
 	}
 	tickStyle := ya.Style.InheritFrom(defaults)
-	return GenerateContinuousTicks(r, ra, true, tickStyle, vf)
+	if ya.Unit != nil {
+		return formatTicks(generateUnitTicks(r, ra, true, tickStyle, ya.Unit, ya.ValueFormatter, ya.UnitPlacement), ya.TickFormatter, ya.getAxisKind(), ra)
+	}
+	return formatTicks(GenerateContinuousTicks(r, ra, true, tickStyle, vf), ya.TickFormatter, ya.getAxisKind(), ra)
+}
+
+func (ya YAxis) getAxisKind() AxisKind {
+	if ya.AxisType == YAxisSecondary {
+		return AxisKindYSecondary
+	}
+	return AxisKindY
+}
+
+// getMirror returns the axis as it is mirrored on the other side of the canvas.
+func (ya YAxis) getMirror() YAxis {
+	mirror := ya
+	mirror.AxisType = YAxisSecondary
+	mirror.Name = ""
+	mirror.Zero = GridLine{}
+	mirror.GridLines = nil
+	mirror.GridMajorStyle, mirror.GridMinorStyle, mirror.GridBandStyle = Style{}, Style{}, Style{}
+	return mirror
 }
 
 // GetGridLines returns the gridlines for the axis.
@@ -82,6 +137,26 @@ func (ya YAxis) GetGridLines(ticks []Tick) []GridLine {
 	return GenerateGridLines(ticks, ya.GridMajorStyle, ya.GridMinorStyle)
 }
 
+// getGridLinesGroupID returns the id of the group of the axis gridlines (see `SVGOptions`).
+func (ya YAxis) getGridLinesGroupID() string {
+	if ya.AxisType == YAxisSecondary {
+		return "y-axis-secondary-gridlines"
+	}
+	return "y-axis-gridlines"
+}
+
+// getGridLines returns the gridlines for the axis; ranges that are a `GridLineProvider` provide them
+// unless the axis has grid lines set.
+func (ya YAxis) getGridLines(ra Range, ticks []Tick) []GridLine {
+	if !ya.MinorTicks.IsZero() && len(ya.GridLines) == 0 {
+		return generateMinorGridLines(ticks, ya.MinorTicks.GetValues(ra, ticks), ya.GridMajorStyle, ya.GridMinorStyle)
+	}
+	if glp, isGridLineProvider := ra.(GridLineProvider); isGridLineProvider && len(ya.GridLines) == 0 {
+		return glp.GetGridLines(ticks, false, ya.GridMajorStyle, ya.GridMinorStyle)
+	}
+	return ya.GetGridLines(ticks)
+}
+
 // Measure returns the bounds of the axis.
 func (ya YAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, ticks []Tick) Box {
 	var tx int
@@ -93,7 +168,6 @@ func (ya YAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, tic
 
 	ya.TickStyle.InheritFrom(ya.Style.InheritFrom(defaults)).WriteToRenderer(r)
 	var minx, maxx, miny, maxy = math.MaxInt32, 0, math.MaxInt32, 0
-	var maxTextHeight int
 	for _, t := range ticks {
 		v := t.Value
 		ly := canvasBox.Bottom - ra.Translate(v)
@@ -105,8 +179,6 @@ func (ya YAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, tic
 			finalTextX = tx - tb.Width()
 		}
 
-		maxTextHeight = util.Math.MaxInt(tb.Height(), maxTextHeight)
-
 		if ya.AxisType == YAxisPrimary {
 			minx = canvasBox.Right
 			maxx = util.Math.MaxInt(maxx, tx+tb.Width())
@@ -120,7 +192,12 @@ func (ya YAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, tic
 	}
 
 	if ya.NameStyle.Show && len(ya.Name) > 0 {
-		maxx += (DefaultYAxisMargin + maxTextHeight)
+		name := measureAxisName(r, ya.Name, canvasBox.Height(), ya.getNameStyle(defaults))
+		if ya.AxisType == YAxisSecondary {
+			minx -= DefaultYAxisMargin + name.Height
+		} else {
+			maxx += DefaultYAxisMargin + name.Height
+		}
 	}
 
 	return Box{
@@ -133,6 +210,7 @@ func (ya YAxis) Measure(r Renderer, canvasBox Box, ra Range, defaults Style, tic
 
 // Render renders the axis.
 func (ya YAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, ticks []Tick) {
+	ya.renderGridBands(r, canvasBox, ra, ticks)
 	tickStyle := ya.TickStyle.InheritFrom(ya.Style.InheritFrom(defaults))
 	tickStyle.WriteToRenderer(r)
 
@@ -152,8 +230,22 @@ func (ya YAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 	r.LineTo(lx, canvasBox.Top)
 	r.Stroke()
 
+	if minorValues := ya.MinorTicks.GetValues(ra, ticks); len(minorValues) > 0 {
+		ya.MinorTicks.getStyle(tickStyle).GetStrokeOptions().WriteToRenderer(r)
+		tickWidth := DefaultHorizontalTickWidth >> 1
+		if ya.AxisType == YAxisSecondary {
+			tickWidth = -tickWidth
+		}
+		for _, v := range minorValues {
+			ly := canvasBox.Bottom - ra.Translate(v)
+			r.MoveTo(lx, ly)
+			r.LineTo(lx+tickWidth, ly)
+			r.Stroke()
+		}
+	}
+
 	var maxTextWidth int
-	var finalTextX, finalTextY int
+	var finalTextX int
 	for _, t := range ticks {
 		v := t.Value
 		ly := canvasBox.Bottom - ra.Translate(v)
@@ -164,18 +256,13 @@ func (ya YAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 			maxTextWidth = tb.Width()
 		}
 
+		align := TextHorizontalAlignLeft
 		if ya.AxisType == YAxisSecondary {
-			finalTextX = tx - tb.Width()
+			finalTextX, align = tx-tb.Width(), TextHorizontalAlignRight
 		} else {
 			finalTextX = tx
 		}
 
-		if tickStyle.TextRotationDegrees == 0 {
-			finalTextY = ly + tb.Height()>>1
-		} else {
-			finalTextY = ly
-		}
-
 		tickStyle.WriteToRenderer(r)
 
 		r.MoveTo(lx, ly)
@@ -186,37 +273,51 @@ func (ya YAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 		}
 		r.Stroke()
 
-		Draw.Text(r, t.Label, finalTextX, finalTextY, tickStyle)
+		if tickStyle.TextRotationDegrees == 0 {
+			Draw.TextAnchored(r, t.Label, tx, ly, withTextAnchor(tickStyle, align, TextVerticalAlignMiddle))
+		} else {
+			Draw.Text(r, t.Label, finalTextX, ly, tickStyle)
+		}
 	}
 
-	nameStyle := ya.NameStyle.InheritFrom(defaults.InheritFrom(Style{TextRotationDegrees: 90}))
+	nameStyle := ya.getNameStyle(defaults)
 	if ya.NameStyle.Show && len(ya.Name) > 0 {
 		nameStyle.GetTextOptions().WriteToRenderer(r)
-		tb := Draw.MeasureText(r, ya.Name, nameStyle)
+		name := measureAxisName(r, ya.Name, canvasBox.Height(), nameStyle)
 
+		// the name is drawn outside the tick labels, centered across its lines.
 		var tx int
 		if ya.AxisType == YAxisPrimary {
-			tx = canvasBox.Right + int(sw) + DefaultYAxisMargin + maxTextWidth + DefaultYAxisMargin
+			tx = canvasBox.Right + int(sw) + DefaultYAxisMargin + maxTextWidth + DefaultYAxisMargin + name.Height>>1
 		} else if ya.AxisType == YAxisSecondary {
-			tx = canvasBox.Left - (DefaultYAxisMargin + int(sw) + maxTextWidth + DefaultYAxisMargin)
+			tx = canvasBox.Left - (DefaultYAxisMargin + int(sw) + maxTextWidth + DefaultYAxisMargin + name.Height>>1)
 		}
 
-		var ty int
-		if nameStyle.TextRotationDegrees == 0 {
-			ty = canvasBox.Top + (canvasBox.Height()>>1 - tb.Width()>>1)
-		} else {
-			ty = canvasBox.Top + (canvasBox.Height()>>1 - tb.Height()>>1)
+		ty, align := canvasBox.Top+canvasBox.Height()>>1, TextHorizontalAlignCenter
+		if ya.NamePlacement == AxisNamePlacementEnd {
+			// the name reads from the top of the axis down, or up to it if it is rotated the other way.
+			ty, align = canvasBox.Top, TextHorizontalAlignLeft
+			if nameStyle.TextWritingMode != TextWritingModeVertical && math.Sin(util.Math.DegreesToRadians(nameStyle.TextRotationDegrees)) < 0 {
+				align = TextHorizontalAlignRight
+			}
 		}
-
-		Draw.Text(r, ya.Name, tx, ty, nameStyle)
+		name.draw(r, tx, ty, align, nameStyle)
 	}
+	ya.renderGridLines(r, canvasBox, ra, ticks)
+}
 
-	if ya.Zero.Style.Show {
-		ya.Zero.Render(r, canvasBox, ra, false, Style{})
+// renderGridBands shades the bands between the major grid lines, if the band style is shown.
+func (ya YAxis) renderGridBands(r Renderer, canvasBox Box, ra Range, ticks []Tick) {
+	if ya.GridBandStyle.Show {
+		drawGridBands(r, canvasBox, ra, false, ya.getGridLines(ra, ticks), ya.GridBandStyle.InheritFrom(Style{FillColor: DefaultGridLineColor.WithAlpha(96)}))
 	}
+}
 
+// renderGridLines draws the grid lines whose style is shown, and the zero line over them.
+func (ya YAxis) renderGridLines(r Renderer, canvasBox Box, ra Range, ticks []Tick) {
 	if ya.GridMajorStyle.Show || ya.GridMinorStyle.Show {
-		for _, gl := range ya.GetGridLines(ticks) {
+		startGroup(r, ya.getGridLinesGroupID(), "gridlines")
+		for _, gl := range ya.getGridLines(ra, ticks) {
 			if (gl.IsMinor && ya.GridMinorStyle.Show) || (!gl.IsMinor && ya.GridMajorStyle.Show) {
 				defaults := ya.GridMajorStyle
 				if gl.IsMinor {
@@ -225,5 +326,9 @@ func (ya YAxis) Render(r Renderer, canvasBox Box, ra Range, defaults Style, tick
 				gl.Render(r, canvasBox, ra, false, gl.Style.InheritFrom(defaults))
 			}
 		}
+		endGroup(r)
+	}
+	if ya.Zero.Style.Show && isInRange(ra, ya.Zero.Value) {
+		ya.Zero.Render(r, canvasBox, ra, false, Style{StrokeColor: DefaultAxisColor, StrokeWidth: DefaultZeroLineWidth})
 	}
 }
@@ -1,6 +1,8 @@
 package chart
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/blendlabs/go-assert"
@@ -83,3 +85,47 @@ func TestYAxisSecondaryMeasure(t *testing.T) {
 	assert.Equal(32, yab.Width())
 	assert.Equal(110, yab.Height())
 }
+
+func TestYAxisMirror(t *testing.T) {
+	assert := assert.New(t)
+
+	makeChart := func(mirror bool) Chart {
+		return Chart{
+			YAxis: YAxis{
+				Name:           "value",
+				NameStyle:      StyleShow(),
+				Style:          StyleShow(),
+				GridMajorStyle: StyleShow(),
+				Mirror:         mirror,
+			},
+			Series: []Series{
+				ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}},
+			},
+		}
+	}
+
+	mirror := makeChart(true).YAxis.getMirror()
+	assert.Equal(YAxisSecondary, mirror.AxisType)
+	assert.Empty(mirror.Name)
+	assert.False(mirror.GridMajorStyle.Show)
+
+	r, err := PNG(1024, 400)
+	assert.Nil(err)
+	plain, err := makeChart(false).Layout(r, makeChart(false).Measure())
+	assert.Nil(err)
+	mirrored, err := makeChart(true).Layout(r, makeChart(true).Measure())
+	assert.Nil(err)
+	// the canvas makes room on the left for the mirrored tick labels; the ticks are the same.
+	assert.True(mirrored.Canvas.Left > plain.Canvas.Left)
+	assert.Equal(len(plain.YTicks), len(mirrored.YTicks))
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(makeChart(true).Render(SVGWithOptions(SVGOptions{Classes: true}), buffer))
+	assert.True(strings.Contains(buffer.String(), `<g id="y-axis-mirror" class="axis y-axis mirror">`))
+	assert.Equal(1, strings.Count(buffer.String(), ">value<"))
+
+	// the secondary y axis takes the place of the mirror.
+	c := makeChart(true)
+	c.YAxisSecondary.Style = StyleShow()
+	assert.False(c.hasYAxisMirror())
+}